@@ -3,6 +3,8 @@ package model
 import (
 	"time"
 
+	"go-fiber/helper"
+
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -18,19 +20,26 @@ type Alumni struct {
 	NoTelepon    *string             `bson:"no_telepon,omitempty" json:"no_telepon,omitempty"`
 	Alamat       *string             `bson:"alamat,omitempty" json:"alamat,omitempty"`
 	Role         string              `bson:"role" json:"role"`
+	Version      int                 `bson:"version" json:"version"`
 	CreatedAt    time.Time           `bson:"created_at" json:"created_at"`
 	UpdatedAt    time.Time           `bson:"updated_at" json:"updated_at"`
 }
 
+type AlumniDetail struct {
+	Alumni    `bson:",inline"`
+	Pekerjaan []PekerjaanAlumni `bson:"pekerjaan" json:"pekerjaan"`
+	Files     []File            `bson:"files" json:"files"`
+}
+
 type CreateAlumniRequest struct {
-	NIM        string  `bson:"nim" json:"nim" validate:"required"`
+	NIM        string  `bson:"nim" json:"nim" validate:"required,nim"`
 	Nama       string  `bson:"nama" json:"nama" validate:"required"`
 	Jurusan    string  `bson:"jurusan" json:"jurusan" validate:"required"`
 	Angkatan   int     `bson:"angkatan" json:"angkatan" validate:"required"`
-	TahunLulus int     `bson:"tahun_lulus" json:"tahun_lulus" validate:"required"`
+	TahunLulus int     `bson:"tahun_lulus" json:"tahun_lulus" validate:"required,gtefield=Angkatan"`
 	Email      string  `bson:"email" json:"email" validate:"required,email"`
 	Password   string  `bson:"password" json:"password" validate:"required"`
-	NoTelepon  *string `bson:"no_telepon,omitempty" json:"no_telepon"`
+	NoTelepon  *string `bson:"no_telepon,omitempty" json:"no_telepon" validate:"omitempty,phone"`
 	Alamat     *string `bson:"alamat,omitempty" json:"alamat"`
 	Role       string  `bson:"role" json:"role" validate:"required,oneof=admin user"`
 }
@@ -39,10 +48,31 @@ type UpdateAlumniRequest struct {
 	Nama       string  `bson:"nama" json:"nama" validate:"required"`
 	Jurusan    string  `bson:"jurusan" json:"jurusan" validate:"required"`
 	Angkatan   int     `bson:"angkatan" json:"angkatan" validate:"required"`
-	TahunLulus int     `bson:"tahun_lulus" json:"tahun_lulus" validate:"required"`
+	TahunLulus int     `bson:"tahun_lulus" json:"tahun_lulus" validate:"required,gtefield=Angkatan"`
 	Email      string  `bson:"email" json:"email" validate:"required,email"`
-	NoTelepon  *string `bson:"no_telepon,omitempty" json:"no_telepon"`
+	NoTelepon  *string `bson:"no_telepon,omitempty" json:"no_telepon" validate:"omitempty,phone"`
 	Alamat     *string `bson:"alamat,omitempty" json:"alamat"`
 	Role       string  `bson:"role" json:"role" validate:"required,oneof=admin user"`
 }
 
+// Normalize menyeragamkan field yang diterima validasi dalam beberapa bentuk
+// sebelum disimpan.
+func (r *CreateAlumniRequest) Normalize() {
+	r.NoTelepon = normalizePhone(r.NoTelepon)
+}
+
+// Normalize menyeragamkan field seperti CreateAlumniRequest.Normalize.
+func (r *UpdateAlumniRequest) Normalize() {
+	r.NoTelepon = normalizePhone(r.NoTelepon)
+}
+
+// normalizePhone mengembalikan salinan nomor telepon tanpa pemisah agar
+// nilai asal, misalnya data sebelum patch, tidak ikut berubah.
+func normalizePhone(phone *string) *string {
+	if phone == nil {
+		return nil
+	}
+	normalized := helper.NormalizePhone(*phone)
+	return &normalized
+}
+
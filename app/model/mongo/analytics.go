@@ -0,0 +1,45 @@
+package model
+
+type AnalyticsFilter struct {
+	GroupBy    string
+	Angkatan   *int
+	TahunLulus *int
+	Jurusan    string
+}
+
+type EmploymentRate struct {
+	Group          string  `bson:"_id" json:"group"`
+	TotalAlumni    int     `bson:"total_alumni" json:"total_alumni"`
+	EmployedAlumni int     `bson:"employed_alumni" json:"employed_alumni"`
+	EmploymentRate float64 `bson:"-" json:"employment_rate"`
+}
+
+type TimeToEmployment struct {
+	Group        string    `bson:"_id" json:"group"`
+	TotalAlumni  int       `bson:"-" json:"total_alumni"`
+	MedianDays   float64   `bson:"-" json:"median_days"`
+	MedianMonths float64   `bson:"-" json:"median_months"`
+	Days         []float64 `bson:"days" json:"-"`
+}
+
+type DistributionItem struct {
+	Group    string `bson:"group" json:"group"`
+	Category string `bson:"category" json:"category"`
+	Total    int    `bson:"total" json:"total"`
+}
+
+type TopEmployer struct {
+	Group          string `bson:"group" json:"group"`
+	NamaPerusahaan string `bson:"nama_perusahaan" json:"nama_perusahaan"`
+	TotalAlumni    int    `bson:"total_alumni" json:"total_alumni"`
+}
+
+type SalaryStatistic struct {
+	Group       string    `bson:"_id" json:"group"`
+	Currency    string    `bson:"-" json:"currency"`
+	TotalSampel int       `bson:"total_sampel" json:"total_sampel"`
+	P25         float64   `bson:"-" json:"p25"`
+	Median      float64   `bson:"-" json:"median"`
+	P75         float64   `bson:"-" json:"p75"`
+	Values      []float64 `bson:"values" json:"-"`
+}
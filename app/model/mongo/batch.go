@@ -0,0 +1,57 @@
+package model
+
+import "encoding/json"
+
+// AlumniBatchFilter memilih alumni untuk batch berdasarkan kecocokan persis.
+// Field kosong tidak dipakai sebagai kondisi.
+type AlumniBatchFilter struct {
+	Jurusan    string `json:"jurusan" example:"Teknik Informatika"`
+	Angkatan   int    `json:"angkatan" example:"2019"`
+	TahunLulus int    `json:"tahun_lulus" example:"2023"`
+}
+
+// IsEmpty melaporkan apakah filter tidak berisi kondisi apa pun.
+func (f *AlumniBatchFilter) IsEmpty() bool {
+	return f == nil || *f == AlumniBatchFilter{}
+}
+
+// PekerjaanBatchFilter memilih pekerjaan untuk batch. NamaPerusahaan
+// dicocokkan tanpa membedakan huruf besar-kecil.
+type PekerjaanBatchFilter struct {
+	AlumniID        string `json:"alumni_id" example:"6718f0c2a1b2c3d4e5f60718"`
+	NamaPerusahaan  string `json:"nama_perusahaan" example:"PT Maju Jaya"`
+	BidangIndustri  string `json:"bidang_industri"`
+	StatusPekerjaan string `json:"status_pekerjaan" validate:"omitempty,oneof=aktif selesai resigned" example:"aktif"`
+}
+
+// IsEmpty melaporkan apakah filter tidak berisi kondisi apa pun.
+func (f *PekerjaanBatchFilter) IsEmpty() bool {
+	return f == nil || *f == PekerjaanBatchFilter{}
+}
+
+// AlumniBatchUpdateRequest menerapkan Changes, berupa JSON Merge Patch dengan
+// field UpdateAlumniRequest, pada setiap alumni yang dipilih IDs atau Filter.
+type AlumniBatchUpdateRequest struct {
+	IDs     []string           `json:"ids" example:"6718f0c2a1b2c3d4e5f60718"`
+	Filter  *AlumniBatchFilter `json:"filter"`
+	Changes json.RawMessage    `json:"changes" swaggertype:"object"`
+}
+
+type AlumniBatchDeleteRequest struct {
+	IDs    []string           `json:"ids" example:"6718f0c2a1b2c3d4e5f60718"`
+	Filter *AlumniBatchFilter `json:"filter"`
+}
+
+// PekerjaanBatchUpdateRequest menerapkan Changes, berupa JSON Merge Patch
+// dengan field UpdatePekerjaanAlumniRequest, pada setiap pekerjaan yang
+// dipilih IDs atau Filter.
+type PekerjaanBatchUpdateRequest struct {
+	IDs     []string              `json:"ids" example:"6718f0c2a1b2c3d4e5f60718"`
+	Filter  *PekerjaanBatchFilter `json:"filter"`
+	Changes json.RawMessage       `json:"changes" swaggertype:"object"`
+}
+
+type PekerjaanBatchDeleteRequest struct {
+	IDs    []string              `json:"ids" example:"6718f0c2a1b2c3d4e5f60718"`
+	Filter *PekerjaanBatchFilter `json:"filter"`
+}
@@ -0,0 +1,21 @@
+package model
+
+import "go-fiber/helper"
+
+type ImportResult struct {
+	DryRun      bool                    `json:"dry_run"`
+	TotalRows   int                     `json:"total_rows"`
+	ValidRows   int                     `json:"valid_rows"`
+	Imported    int                     `json:"imported"`
+	Errors      []helper.ImportRowError `json:"errors"`
+	Credentials []ImportCredential      `json:"credentials,omitempty"`
+}
+
+// ImportCredential berisi password awal yang dibuat otomatis untuk alumni
+// hasil import. Password hanya ditampilkan sekali pada response import.
+type ImportCredential struct {
+	Row      int    `json:"row"`
+	NIM      string `json:"nim"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
@@ -8,9 +8,9 @@ import (
 
 type AlumniInfo struct {
 	AlumniID    primitive.ObjectID `bson:"alumni_id" json:"alumni_id"`
-	NIM         string             `bson:"nim" json:"nim"`
-	Nama        string             `bson:"nama" json:"nama"`
-	Email       string             `bson:"email" json:"email"`
+	NIM         string             `bson:"nim" json:"nim" validate:"required,nim"`
+	Nama        string             `bson:"nama" json:"nama" validate:"required"`
+	Email       string             `bson:"email" json:"email" validate:"required,email"`
 }
 
 type PekerjaanAlumni struct {
@@ -21,10 +21,15 @@ type PekerjaanAlumni struct {
 	BidangIndustri      string              `bson:"bidang_industri" json:"bidang_industri"`
 	LokasiKerja         string              `bson:"lokasi_kerja" json:"lokasi_kerja"`
 	GajiRange           *string             `bson:"gaji_range,omitempty" json:"gaji_range,omitempty"`
+	GajiMin             *int64              `bson:"gaji_min,omitempty" json:"gaji_min,omitempty"`
+	GajiMax             *int64              `bson:"gaji_max,omitempty" json:"gaji_max,omitempty"`
+	GajiCurrency        *string             `bson:"gaji_currency,omitempty" json:"gaji_currency,omitempty"`
+	GajiPeriod          *string             `bson:"gaji_period,omitempty" json:"gaji_period,omitempty"`
 	TanggalMulaiKerja   time.Time           `bson:"tanggal_mulai_kerja" json:"tanggal_mulai_kerja"`
 	TanggalSelesaiKerja *time.Time          `bson:"tanggal_selesai_kerja,omitempty" json:"tanggal_selesai_kerja,omitempty"`
 	StatusPekerjaan     string              `bson:"status_pekerjaan" json:"status_pekerjaan"`
 	DeskripsiPekerjaan  *string             `bson:"deskripsi_pekerjaan,omitempty" json:"deskripsi_pekerjaan,omitempty"`
+	Version             int                 `bson:"version" json:"version"`
 	CreatedAt           time.Time           `bson:"created_at" json:"created_at"`
 	UpdatedAt           time.Time           `bson:"updated_at" json:"updated_at"`
 }
@@ -36,8 +41,12 @@ type CreatePekerjaanAlumniRequest struct {
 	BidangIndustri      string  `bson:"bidang_industri" json:"bidang_industri" validate:"required"`
 	LokasiKerja         string  `bson:"lokasi_kerja" json:"lokasi_kerja" validate:"required"`
 	GajiRange           *string `bson:"gaji_range,omitempty" json:"gaji_range"`
-	TanggalMulaiKerja   string  `bson:"tanggal_mulai_kerja" json:"tanggal_mulai_kerja" validate:"required"`
-	TanggalSelesaiKerja *string `bson:"tanggal_selesai_kerja,omitempty" json:"tanggal_selesai_kerja"`
+	GajiMin             *int64  `bson:"gaji_min,omitempty" json:"gaji_min"`
+	GajiMax             *int64  `bson:"gaji_max,omitempty" json:"gaji_max"`
+	GajiCurrency        *string `bson:"gaji_currency,omitempty" json:"gaji_currency" example:"IDR"`
+	GajiPeriod          *string `bson:"gaji_period,omitempty" json:"gaji_period" example:"bulanan"`
+	TanggalMulaiKerja   string  `bson:"tanggal_mulai_kerja" json:"tanggal_mulai_kerja" validate:"required,date"`
+	TanggalSelesaiKerja *string `bson:"tanggal_selesai_kerja,omitempty" json:"tanggal_selesai_kerja" validate:"omitempty,date,after=TanggalMulaiKerja"`
 	StatusPekerjaan     string  `bson:"status_pekerjaan" json:"status_pekerjaan" validate:"required,oneof=aktif selesai resigned"`
 	DeskripsiPekerjaan  *string `bson:"deskripsi_pekerjaan,omitempty" json:"deskripsi_pekerjaan"`
 }
@@ -48,8 +57,12 @@ type UpdatePekerjaanAlumniRequest struct {
 	BidangIndustri      string  `bson:"bidang_industri" json:"bidang_industri" validate:"required"`
 	LokasiKerja         string  `bson:"lokasi_kerja" json:"lokasi_kerja" validate:"required"`
 	GajiRange           *string `bson:"gaji_range,omitempty" json:"gaji_range"`
-	TanggalMulaiKerja   string  `bson:"tanggal_mulai_kerja" json:"tanggal_mulai_kerja" validate:"required"`
-	TanggalSelesaiKerja *string `bson:"tanggal_selesai_kerja,omitempty" json:"tanggal_selesai_kerja"`
+	GajiMin             *int64  `bson:"gaji_min,omitempty" json:"gaji_min"`
+	GajiMax             *int64  `bson:"gaji_max,omitempty" json:"gaji_max"`
+	GajiCurrency        *string `bson:"gaji_currency,omitempty" json:"gaji_currency" example:"IDR"`
+	GajiPeriod          *string `bson:"gaji_period,omitempty" json:"gaji_period" example:"bulanan"`
+	TanggalMulaiKerja   string  `bson:"tanggal_mulai_kerja" json:"tanggal_mulai_kerja" validate:"required,date"`
+	TanggalSelesaiKerja *string `bson:"tanggal_selesai_kerja,omitempty" json:"tanggal_selesai_kerja" validate:"omitempty,date,after=TanggalMulaiKerja"`
 	StatusPekerjaan     string  `bson:"status_pekerjaan" json:"status_pekerjaan" validate:"required,oneof=aktif selesai resigned"`
 	DeskripsiPekerjaan  *string `bson:"deskripsi_pekerjaan,omitempty" json:"deskripsi_pekerjaan"`
 }
@@ -6,8 +6,17 @@ type SuccessResponse struct {
 	Data    interface{} `json:"data,omitempty"`
 }
 
+// ErrorResponse adalah body error problem+json (RFC 7807), lihat
+// apperror.Problem.
 type ErrorResponse struct {
-	Success bool   `json:"success" example:"false"`
-	Message string `json:"message" example:"Error message"`
+	Type      string      `json:"type" example:"about:blank"`
+	Title     string      `json:"title" example:"Not Found"`
+	Status    int         `json:"status" example:"404"`
+	Detail    string      `json:"detail" example:"Data alumni dengan ID tersebut tidak ditemukan di database."`
+	Instance  string      `json:"instance" example:"/go-fiber-mongo/alumni/6718f0c2a1b2c3d4e5f60718"`
+	Code      string      `json:"code" example:"not_found"`
+	RequestID string      `json:"request_id,omitempty" example:"0b8f5c1e-3a52-4c55-9a53-0d7c1f0f6e21"`
+	Details   interface{} `json:"details,omitempty"`
+	Success   bool        `json:"success" example:"false"`
 }
 
@@ -1,6 +1,9 @@
 package model
 
-import "time"
+import (
+	"go-fiber/helper"
+	"time"
+)
 
 type Alumni struct {
 	ID           int       `json:"id"`
@@ -15,38 +18,65 @@ type Alumni struct {
 	Alamat       *string   `json:"alamat"`
 	RoleID       int       `json:"role_id"`
 	Role         *Role     `json:"role,omitempty"`
+	Version      int       `json:"version"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
 }
 
+type AlumniDetail struct {
+	Alumni
+	Pekerjaan []PekerjaanAlumni `json:"pekerjaan"`
+}
+
 type CreateAlumniRequest struct {
-	NIM        string  `json:"nim" validate:"required"`
+	NIM        string  `json:"nim" validate:"required,nim"`
 	Nama       string  `json:"nama" validate:"required"`
 	Jurusan    string  `json:"jurusan" validate:"required"`
 	Angkatan   int     `json:"angkatan" validate:"required"`
-	TahunLulus int     `json:"tahun_lulus" validate:"required"`
+	TahunLulus int     `json:"tahun_lulus" validate:"required,gtefield=Angkatan"`
 	Email      string  `json:"email" validate:"required,email"`
 	Password   string  `json:"password" validate:"required"`
-	NoTelepon  *string `json:"no_telepon"`
+	NoTelepon  *string `json:"no_telepon" validate:"omitempty,phone"`
 	Alamat     *string `json:"alamat"`
-	RoleID     int     `json:"role_id" validate:"required"`
+	RoleID     int     `json:"role_id" validate:"required,oneof=1 2"`
 }
 
 type UpdateAlumniRequest struct {
 	Nama      string  `json:"nama" validate:"required"`
 	Jurusan   string  `json:"jurusan" validate:"required"`
 	Angkatan  int     `json:"angkatan" validate:"required"`
-	TahunLulus int    `json:"tahun_lulus" validate:"required"`
+	TahunLulus int    `json:"tahun_lulus" validate:"required,gtefield=Angkatan"`
 	Email     string  `json:"email" validate:"required,email"`
-	NoTelepon *string `json:"no_telepon"`
+	NoTelepon *string `json:"no_telepon" validate:"omitempty,phone"`
 	Alamat    *string `json:"alamat"`
-	RoleID    int     `json:"role_id" validate:"required"`
+	RoleID    int     `json:"role_id" validate:"required,oneof=1 2"`
+}
+
+// Normalize menyeragamkan field yang diterima validasi dalam beberapa bentuk
+// sebelum disimpan.
+func (r *CreateAlumniRequest) Normalize() {
+	r.NoTelepon = normalizePhone(r.NoTelepon)
+}
+
+// Normalize menyeragamkan field seperti CreateAlumniRequest.Normalize.
+func (r *UpdateAlumniRequest) Normalize() {
+	r.NoTelepon = normalizePhone(r.NoTelepon)
+}
+
+// normalizePhone mengembalikan salinan nomor telepon tanpa pemisah agar
+// nilai asal, misalnya data sebelum patch, tidak ikut berubah.
+func normalizePhone(phone *string) *string {
+	if phone == nil {
+		return nil
+	}
+	normalized := helper.NormalizePhone(*phone)
+	return &normalized
 }
 
 type GetAlumniByIDResponse struct {
-	Success bool   `json:"success"`
-	Message string `json:"message"`
-	Data    Alumni `json:"data"`
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data"`
 }
 
 type CreateAlumniResponse struct {
@@ -0,0 +1,43 @@
+package model
+
+type AnalyticsFilter struct {
+	GroupBy    string
+	Angkatan   *int
+	TahunLulus *int
+	Jurusan    string
+}
+
+type EmploymentRate struct {
+	Group          string  `json:"group"`
+	TotalAlumni    int     `json:"total_alumni"`
+	EmployedAlumni int     `json:"employed_alumni"`
+	EmploymentRate float64 `json:"employment_rate"`
+}
+
+type TimeToEmployment struct {
+	Group        string  `json:"group"`
+	TotalAlumni  int     `json:"total_alumni"`
+	MedianDays   float64 `json:"median_days"`
+	MedianMonths float64 `json:"median_months"`
+}
+
+type DistributionItem struct {
+	Group    string `json:"group"`
+	Category string `json:"category"`
+	Total    int    `json:"total"`
+}
+
+type TopEmployer struct {
+	Group          string `json:"group"`
+	NamaPerusahaan string `json:"nama_perusahaan"`
+	TotalAlumni    int    `json:"total_alumni"`
+}
+
+type SalaryStatistic struct {
+	Group       string  `json:"group"`
+	Currency    string  `json:"currency"`
+	TotalSampel int     `json:"total_sampel"`
+	P25         float64 `json:"p25"`
+	Median      float64 `json:"median"`
+	P75         float64 `json:"p75"`
+}
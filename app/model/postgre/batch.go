@@ -0,0 +1,57 @@
+package model
+
+import "encoding/json"
+
+// AlumniBatchFilter memilih alumni untuk batch berdasarkan kecocokan persis.
+// Field kosong tidak dipakai sebagai kondisi.
+type AlumniBatchFilter struct {
+	Jurusan    string `json:"jurusan"`
+	Angkatan   int    `json:"angkatan"`
+	TahunLulus int    `json:"tahun_lulus"`
+}
+
+// IsEmpty melaporkan apakah filter tidak berisi kondisi apa pun.
+func (f *AlumniBatchFilter) IsEmpty() bool {
+	return f == nil || *f == AlumniBatchFilter{}
+}
+
+// PekerjaanBatchFilter memilih pekerjaan yang belum di-soft delete untuk
+// batch. NamaPerusahaan dicocokkan tanpa membedakan huruf besar-kecil.
+type PekerjaanBatchFilter struct {
+	AlumniID        int    `json:"alumni_id"`
+	NamaPerusahaan  string `json:"nama_perusahaan"`
+	BidangIndustri  string `json:"bidang_industri"`
+	StatusPekerjaan string `json:"status_pekerjaan" validate:"omitempty,oneof=aktif selesai resigned"`
+}
+
+// IsEmpty melaporkan apakah filter tidak berisi kondisi apa pun.
+func (f *PekerjaanBatchFilter) IsEmpty() bool {
+	return f == nil || *f == PekerjaanBatchFilter{}
+}
+
+// AlumniBatchUpdateRequest menerapkan Changes, berupa JSON Merge Patch dengan
+// field UpdateAlumniRequest, pada setiap alumni yang dipilih IDs atau Filter.
+type AlumniBatchUpdateRequest struct {
+	IDs     []int              `json:"ids"`
+	Filter  *AlumniBatchFilter `json:"filter"`
+	Changes json.RawMessage    `json:"changes" swaggertype:"object"`
+}
+
+type AlumniBatchDeleteRequest struct {
+	IDs    []int              `json:"ids"`
+	Filter *AlumniBatchFilter `json:"filter"`
+}
+
+// PekerjaanBatchUpdateRequest menerapkan Changes, berupa JSON Merge Patch
+// dengan field UpdatePekerjaanAlumniRequest, pada setiap pekerjaan yang
+// dipilih IDs atau Filter.
+type PekerjaanBatchUpdateRequest struct {
+	IDs     []int                 `json:"ids"`
+	Filter  *PekerjaanBatchFilter `json:"filter"`
+	Changes json.RawMessage       `json:"changes" swaggertype:"object"`
+}
+
+type PekerjaanBatchDeleteRequest struct {
+	IDs    []int                 `json:"ids"`
+	Filter *PekerjaanBatchFilter `json:"filter"`
+}
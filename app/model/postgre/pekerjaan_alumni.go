@@ -10,11 +10,16 @@ type PekerjaanAlumni struct {
 	BidangIndustri      string     `json:"bidang_industri"`
 	LokasiKerja         string     `json:"lokasi_kerja"`
 	GajiRange           *string    `json:"gaji_range"`
+	GajiMin             *int64     `json:"gaji_min"`
+	GajiMax             *int64     `json:"gaji_max"`
+	GajiCurrency        *string    `json:"gaji_currency"`
+	GajiPeriod          *string    `json:"gaji_period"`
 	TanggalMulaiKerja   time.Time  `json:"tanggal_mulai_kerja"`
 	TanggalSelesaiKerja *time.Time `json:"tanggal_selesai_kerja"`
 	StatusPekerjaan     string     `json:"status_pekerjaan"`
 	DeskripsiPekerjaan  *string    `json:"deskripsi_pekerjaan"`
 	IsDelete            *time.Time `json:"is_delete"`
+	Version             int        `json:"version"`
 	CreatedAt           time.Time  `json:"created_at"`
 	UpdatedAt           time.Time  `json:"updated_at"`
 }
@@ -26,8 +31,12 @@ type CreatePekerjaanAlumniRequest struct {
 	BidangIndustri      string     `json:"bidang_industri" validate:"required"`
 	LokasiKerja         string     `json:"lokasi_kerja" validate:"required"`
 	GajiRange           *string    `json:"gaji_range"`
-	TanggalMulaiKerja   string     `json:"tanggal_mulai_kerja" validate:"required"`
-	TanggalSelesaiKerja *string    `json:"tanggal_selesai_kerja"`
+	GajiMin             *int64     `json:"gaji_min"`
+	GajiMax             *int64     `json:"gaji_max"`
+	GajiCurrency        *string    `json:"gaji_currency"`
+	GajiPeriod          *string    `json:"gaji_period"`
+	TanggalMulaiKerja   string     `json:"tanggal_mulai_kerja" validate:"required,date"`
+	TanggalSelesaiKerja *string    `json:"tanggal_selesai_kerja" validate:"omitempty,date,after=TanggalMulaiKerja"`
 	StatusPekerjaan     string     `json:"status_pekerjaan" validate:"required,oneof=aktif selesai resigned"`
 	DeskripsiPekerjaan  *string    `json:"deskripsi_pekerjaan"`
 }
@@ -39,6 +48,10 @@ type CreatePekerjaanAlumniRepositoryRequest struct {
 	BidangIndustri      string     `json:"bidang_industri"`
 	LokasiKerja         string     `json:"lokasi_kerja"`
 	GajiRange           *string    `json:"gaji_range"`
+	GajiMin             *int64     `json:"gaji_min"`
+	GajiMax             *int64     `json:"gaji_max"`
+	GajiCurrency        *string    `json:"gaji_currency"`
+	GajiPeriod          *string    `json:"gaji_period"`
 	TanggalMulaiKerja   time.Time  `json:"tanggal_mulai_kerja"`
 	TanggalSelesaiKerja *time.Time `json:"tanggal_selesai_kerja"`
 	StatusPekerjaan     string     `json:"status_pekerjaan"`
@@ -51,8 +64,12 @@ type UpdatePekerjaanAlumniRequest struct {
 	BidangIndustri      string     `json:"bidang_industri" validate:"required"`
 	LokasiKerja         string     `json:"lokasi_kerja" validate:"required"`
 	GajiRange           *string    `json:"gaji_range"`
-	TanggalMulaiKerja   string     `json:"tanggal_mulai_kerja" validate:"required"`
-	TanggalSelesaiKerja *string    `json:"tanggal_selesai_kerja"`
+	GajiMin             *int64     `json:"gaji_min"`
+	GajiMax             *int64     `json:"gaji_max"`
+	GajiCurrency        *string    `json:"gaji_currency"`
+	GajiPeriod          *string    `json:"gaji_period"`
+	TanggalMulaiKerja   string     `json:"tanggal_mulai_kerja" validate:"required,date"`
+	TanggalSelesaiKerja *string    `json:"tanggal_selesai_kerja" validate:"omitempty,date,after=TanggalMulaiKerja"`
 	StatusPekerjaan     string     `json:"status_pekerjaan" validate:"required,oneof=aktif selesai resigned"`
 	DeskripsiPekerjaan  *string    `json:"deskripsi_pekerjaan"`
 }
@@ -63,6 +80,10 @@ type UpdatePekerjaanAlumniRepositoryRequest struct {
 	BidangIndustri      string     `json:"bidang_industri"`
 	LokasiKerja         string     `json:"lokasi_kerja"`
 	GajiRange           *string    `json:"gaji_range"`
+	GajiMin             *int64     `json:"gaji_min"`
+	GajiMax             *int64     `json:"gaji_max"`
+	GajiCurrency        *string    `json:"gaji_currency"`
+	GajiPeriod          *string    `json:"gaji_period"`
 	TanggalMulaiKerja   time.Time  `json:"tanggal_mulai_kerja"`
 	TanggalSelesaiKerja *time.Time `json:"tanggal_selesai_kerja"`
 	StatusPekerjaan     string     `json:"status_pekerjaan"`
@@ -11,11 +11,11 @@ type MetaInfo struct {
 }
 
 type AlumniResponse struct {
-	Data []Alumni `json:"data"`
-	Meta MetaInfo `json:"meta"`
+	Data interface{} `json:"data"`
+	Meta MetaInfo    `json:"meta"`
 }
 
 type PekerjaanAlumniResponse struct {
-	Data []PekerjaanAlumni `json:"data"`
-	Meta MetaInfo          `json:"meta"`
+	Data interface{} `json:"data"`
+	Meta MetaInfo    `json:"meta"`
 }
@@ -2,8 +2,9 @@ package repository
 
 import (
 	"context"
-	"errors"
 	model "go-fiber/app/model/mongo"
+	"go-fiber/apperror"
+	"go-fiber/tracing"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -17,8 +18,11 @@ type IAlumniRepository interface {
 	FindAlumniByEmail(ctx context.Context, email string) (*model.Alumni, error)
 	FindAlumniByNIM(ctx context.Context, nim string) (*model.Alumni, error)
 	FindAllAlumni(ctx context.Context) ([]model.Alumni, error)
-	UpdateAlumni(ctx context.Context, id string, alumni *model.Alumni) (*model.Alumni, error)
-	DeleteAlumni(ctx context.Context, id string) error
+	UpdateAlumni(ctx context.Context, id string, version int, alumni *model.Alumni) (*model.Alumni, error)
+	PatchAlumni(ctx context.Context, id string, version int, changes bson.M) (*model.Alumni, error)
+	DeleteAlumni(ctx context.Context, id string, version int) error
+	FindAlumniByIDWithRelations(ctx context.Context, id string, fields, include []string) (*model.AlumniDetail, error)
+	FindAllAlumniWithRelations(ctx context.Context, fields, include []string) ([]model.AlumniDetail, error)
 }
 
 type AlumniRepository struct {
@@ -33,13 +37,17 @@ func NewAlumniRepository(db *mongo.Database) IAlumniRepository {
 
 
 func (r *AlumniRepository) CreateAlumni(ctx context.Context, alumni *model.Alumni) (*model.Alumni, error) {
+	ctx, span := tracing.StartMongo(ctx, "AlumniRepository.CreateAlumni")
+	defer span.End()
+
 	alumni.ID = primitive.NilObjectID
+	alumni.Version = 1
 	alumni.CreatedAt = time.Now()
 	alumni.UpdatedAt = time.Now()
 
 	result, err := r.collection.InsertOne(ctx, alumni)
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, apperror.FromDB(err))
 	}
 
 	alumni.ID = result.InsertedID.(primitive.ObjectID)
@@ -47,9 +55,12 @@ func (r *AlumniRepository) CreateAlumni(ctx context.Context, alumni *model.Alumn
 }
 
 func (r *AlumniRepository) FindAlumniByID(ctx context.Context, id string) (*model.Alumni, error) {
+	ctx, span := tracing.StartMongo(ctx, "AlumniRepository.FindAlumniByID")
+	defer span.End()
+
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return nil, errors.New("ID tidak valid")
+		return nil, apperror.InvalidID()
 	}
 
 	var alumni model.Alumni
@@ -59,13 +70,16 @@ func (r *AlumniRepository) FindAlumniByID(ctx context.Context, id string) (*mode
 		if err == mongo.ErrNoDocuments {
 			return nil, nil
 		}
-		return nil, err
+		return nil, tracing.Error(span, err)
 	}
 
 	return &alumni, nil
 }
 
 func (r *AlumniRepository) FindAlumniByEmail(ctx context.Context, email string) (*model.Alumni, error) {
+	ctx, span := tracing.StartMongo(ctx, "AlumniRepository.FindAlumniByEmail")
+	defer span.End()
+
 	var alumni model.Alumni
 	filter := bson.M{"email": email}
 	err := r.collection.FindOne(ctx, filter).Decode(&alumni)
@@ -73,13 +87,16 @@ func (r *AlumniRepository) FindAlumniByEmail(ctx context.Context, email string)
 		if err == mongo.ErrNoDocuments {
 			return nil, nil
 		}
-		return nil, err
+		return nil, tracing.Error(span, err)
 	}
 
 	return &alumni, nil
 }
 
 func (r *AlumniRepository) FindAlumniByNIM(ctx context.Context, nim string) (*model.Alumni, error) {
+	ctx, span := tracing.StartMongo(ctx, "AlumniRepository.FindAlumniByNIM")
+	defer span.End()
+
 	var alumni model.Alumni
 	filter := bson.M{"nim": nim}
 	err := r.collection.FindOne(ctx, filter).Decode(&alumni)
@@ -87,76 +104,206 @@ func (r *AlumniRepository) FindAlumniByNIM(ctx context.Context, nim string) (*mo
 		if err == mongo.ErrNoDocuments {
 			return nil, nil
 		}
-		return nil, err
+		return nil, tracing.Error(span, err)
 	}
 
 	return &alumni, nil
 }
 
 func (r *AlumniRepository) FindAllAlumni(ctx context.Context) ([]model.Alumni, error) {
+	ctx, span := tracing.StartMongo(ctx, "AlumniRepository.FindAllAlumni")
+	defer span.End()
+
 	cursor, err := r.collection.Find(ctx, bson.M{})
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, err)
 	}
 	defer cursor.Close(ctx)
 
 	var alumniList []model.Alumni
 	if err = cursor.All(ctx, &alumniList); err != nil {
-		return nil, err
+		return nil, tracing.Error(span, err)
 	}
 
 
 	return alumniList, nil
 }
 
-func (r *AlumniRepository) UpdateAlumni(ctx context.Context, id string, alumni *model.Alumni) (*model.Alumni, error) {
+// UpdateAlumni hanya mengubah alumni yang version-nya masih sama dengan
+// version, lalu menaikkan version. Jika dokumen sudah diubah request lain,
+// hasilnya apperror.VersionConflict.
+func (r *AlumniRepository) UpdateAlumni(ctx context.Context, id string, version int, alumni *model.Alumni) (*model.Alumni, error) {
+	ctx, span := tracing.StartMongo(ctx, "AlumniRepository.UpdateAlumni")
+	defer span.End()
+
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return nil, errors.New("ID tidak valid")
+		return nil, apperror.InvalidID()
 	}
 
-	filter := bson.M{"_id": objID}
-	update := bson.M{
-		"$set": bson.M{
-			"nama":        alumni.Nama,
-			"jurusan":     alumni.Jurusan,
-			"angkatan":    alumni.Angkatan,
-			"tahun_lulus": alumni.TahunLulus,
-			"email":       alumni.Email,
-			"no_telepon":  alumni.NoTelepon,
-			"alamat":      alumni.Alamat,
-			"role":        alumni.Role,
-			"updated_at":  time.Now(),
-		},
-	}
+	filter := bson.M{"_id": objID, "version": version}
+	update := versionedUpdate(bson.M{
+		"nama":        alumni.Nama,
+		"jurusan":     alumni.Jurusan,
+		"angkatan":    alumni.Angkatan,
+		"tahun_lulus": alumni.TahunLulus,
+		"email":       alumni.Email,
+		"no_telepon":  alumni.NoTelepon,
+		"alamat":      alumni.Alamat,
+		"role":        alumni.Role,
+		"updated_at":  time.Now(),
+	})
 
 	result := r.collection.FindOneAndUpdate(ctx, filter, update)
 	if result.Err() != nil {
 		if result.Err() == mongo.ErrNoDocuments {
-			return nil, nil
+			return nil, apperror.VersionConflict()
 		}
-		return nil, result.Err()
+		return nil, tracing.Error(span, apperror.FromDB(result.Err()))
 	}
 
 	return r.FindAlumniByID(ctx, id)
 }
 
-func (r *AlumniRepository) DeleteAlumni(ctx context.Context, id string) error {
+// alumniPatchFields adalah field yang boleh diubah PatchAlumni.
+var alumniPatchFields = map[string]bool{"nama": true, "jurusan": true, "angkatan": true, "tahun_lulus": true, "email": true, "no_telepon": true, "alamat": true, "role": true}
+
+// PatchAlumni hanya mengubah field pada changes; nilai nil menghapus field.
+// Aturan version sama seperti UpdateAlumni.
+func (r *AlumniRepository) PatchAlumni(ctx context.Context, id string, version int, changes bson.M) (*model.Alumni, error) {
+	ctx, span := tracing.StartMongo(ctx, "AlumniRepository.PatchAlumni")
+	defer span.End()
+
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return errors.New("ID tidak valid")
+		return nil, apperror.InvalidID()
+	}
+	if err := patchFields(changes, alumniPatchFields); err != nil {
+		return nil, tracing.Error(span, err)
 	}
 
-	filter := bson.M{"_id": objID}
+	fields := bson.M{"updated_at": time.Now()}
+	for field, value := range changes {
+		fields[field] = value
+	}
+
+	filter := bson.M{"_id": objID, "version": version}
+	result := r.collection.FindOneAndUpdate(ctx, filter, versionedUpdate(fields))
+	if result.Err() != nil {
+		if result.Err() == mongo.ErrNoDocuments {
+			return nil, apperror.VersionConflict()
+		}
+		return nil, tracing.Error(span, apperror.FromDB(result.Err()))
+	}
+
+	return r.FindAlumniByID(ctx, id)
+}
+
+func (r *AlumniRepository) DeleteAlumni(ctx context.Context, id string, version int) error {
+	ctx, span := tracing.StartMongo(ctx, "AlumniRepository.DeleteAlumni")
+	defer span.End()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return apperror.InvalidID()
+	}
+
+	filter := bson.M{"_id": objID, "version": version}
 	result, err := r.collection.DeleteOne(ctx, filter)
 	if err != nil {
-		return err
+		return tracing.Error(span, err)
 	}
 
 	if result.DeletedCount == 0 {
-		return errors.New("data alumni tidak ditemukan")
+		return apperror.VersionConflict()
 	}
 
 	return nil
 }
 
+
+// alumniRelationPipeline menyusun aggregation pipeline yang meng-embed relasi
+// lewat $lookup dalam satu round-trip, lalu memproyeksikan field yang diminta.
+// Nama field mengikuti key JSON, dengan "id" dipetakan ke "_id".
+func alumniRelationPipeline(match bson.M, fields, include []string) mongo.Pipeline {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+	}
+
+	for _, relation := range include {
+		switch relation {
+		case "pekerjaan":
+			pipeline = append(pipeline, bson.D{{Key: "$lookup", Value: bson.M{
+				"from":         "pekerjaan_alumni",
+				"localField":   "_id",
+				"foreignField": "alumni_info.alumni_id",
+				"as":           "pekerjaan",
+			}}})
+		case "files":
+			pipeline = append(pipeline, bson.D{{Key: "$lookup", Value: bson.M{
+				"from":         "files",
+				"localField":   "_id",
+				"foreignField": "alumni_info.alumni_id",
+				"as":           "files",
+			}}})
+		}
+	}
+
+	if len(fields) > 0 {
+		projection := bson.M{}
+		for _, field := range fields {
+			if field == "id" {
+				field = "_id"
+			}
+			projection[field] = 1
+		}
+		pipeline = append(pipeline, bson.D{{Key: "$project", Value: projection}})
+	}
+
+	return pipeline
+}
+
+func (r *AlumniRepository) FindAlumniByIDWithRelations(ctx context.Context, id string, fields, include []string) (*model.AlumniDetail, error) {
+	ctx, span := tracing.StartMongo(ctx, "AlumniRepository.FindAlumniByIDWithRelations")
+	defer span.End()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, apperror.InvalidID()
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, alumniRelationPipeline(bson.M{"_id": objID}, fields, include))
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	defer cursor.Close(ctx)
+
+	var results []model.AlumniDetail
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	return &results[0], nil
+}
+
+func (r *AlumniRepository) FindAllAlumniWithRelations(ctx context.Context, fields, include []string) ([]model.AlumniDetail, error) {
+	ctx, span := tracing.StartMongo(ctx, "AlumniRepository.FindAllAlumniWithRelations")
+	defer span.End()
+
+	cursor, err := r.collection.Aggregate(ctx, alumniRelationPipeline(bson.M{}, fields, include))
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	defer cursor.Close(ctx)
+
+	var alumniList []model.AlumniDetail
+	if err = cursor.All(ctx, &alumniList); err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	return alumniList, nil
+}
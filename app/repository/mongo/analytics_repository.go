@@ -0,0 +1,281 @@
+package repository
+
+import (
+	"context"
+	model "go-fiber/app/model/mongo"
+	"go-fiber/apperror"
+	"go-fiber/tracing"
+	"regexp"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type IAnalyticsRepository interface {
+	GetEmploymentRate(ctx context.Context, filter model.AnalyticsFilter) ([]model.EmploymentRate, error)
+	GetTimeToEmployment(ctx context.Context, filter model.AnalyticsFilter) ([]model.TimeToEmployment, error)
+	GetEmploymentDistribution(ctx context.Context, filter model.AnalyticsFilter, dimension, status string) ([]model.DistributionItem, error)
+	GetTopEmployers(ctx context.Context, filter model.AnalyticsFilter, limit int) ([]model.TopEmployer, error)
+	GetSalaryStatistics(ctx context.Context, filter model.AnalyticsFilter, currency string) ([]model.SalaryStatistic, error)
+}
+
+type AnalyticsRepository struct {
+	alumniCollection    *mongo.Collection
+	pekerjaanCollection *mongo.Collection
+}
+
+func NewAnalyticsRepository(db *mongo.Database) IAnalyticsRepository {
+	return &AnalyticsRepository{
+		alumniCollection:    db.Collection("alumni"),
+		pekerjaanCollection: db.Collection("pekerjaan_alumni"),
+	}
+}
+
+var analyticsDimensionFields = map[string]string{
+	"bidang_industri": "$bidang_industri",
+	"lokasi_kerja":    "$lokasi_kerja",
+}
+
+// analyticsGroupExpr mengembalikan ekspresi pengelompokan untuk field alumni
+// dengan awalan prefix. Tanpa group_by semua data masuk ke kelompok "semua".
+func analyticsGroupExpr(groupBy, prefix string) interface{} {
+	switch groupBy {
+	case "angkatan", "tahun_lulus":
+		return bson.M{"$toString": "$" + prefix + groupBy}
+	case "jurusan":
+		return "$" + prefix + groupBy
+	default:
+		return bson.M{"$literal": "semua"}
+	}
+}
+
+func analyticsMatch(filter model.AnalyticsFilter, prefix string) bson.M {
+	match := bson.M{}
+	if filter.Angkatan != nil {
+		match[prefix+"angkatan"] = *filter.Angkatan
+	}
+	if filter.TahunLulus != nil {
+		match[prefix+"tahun_lulus"] = *filter.TahunLulus
+	}
+	if filter.Jurusan != "" {
+		match[prefix+"jurusan"] = bson.M{"$regex": "^" + regexp.QuoteMeta(filter.Jurusan) + "$", "$options": "i"}
+	}
+	return match
+}
+
+// pekerjaanWithAlumniPipeline menggabungkan pekerjaan dengan data alumninya
+// agar filter dan pengelompokan alumni bisa dipakai pada koleksi pekerjaan.
+func pekerjaanWithAlumniPipeline(filter model.AnalyticsFilter) mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "alumni",
+			"localField":   "alumni_info.alumni_id",
+			"foreignField": "_id",
+			"as":           "alumni",
+		}}},
+		{{Key: "$unwind", Value: "$alumni"}},
+		{{Key: "$match", Value: analyticsMatch(filter, "alumni.")}},
+	}
+}
+
+func (r *AnalyticsRepository) GetEmploymentRate(ctx context.Context, filter model.AnalyticsFilter) ([]model.EmploymentRate, error) {
+	ctx, span := tracing.StartMongo(ctx, "AnalyticsRepository.GetEmploymentRate")
+	defer span.End()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: analyticsMatch(filter, "")}},
+		{{Key: "$lookup", Value: bson.M{
+			"from": "pekerjaan_alumni",
+			"let":  bson.M{"alumni_id": "$_id"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{"$expr": bson.M{"$and": bson.A{
+					bson.M{"$eq": bson.A{"$alumni_info.alumni_id", "$$alumni_id"}},
+					bson.M{"$eq": bson.A{"$status_pekerjaan", "aktif"}},
+				}}}},
+				bson.M{"$limit": 1},
+			},
+			"as": "pekerjaan_aktif",
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":          analyticsGroupExpr(filter.GroupBy, ""),
+			"total_alumni": bson.M{"$sum": 1},
+			"employed_alumni": bson.M{"$sum": bson.M{"$cond": bson.A{
+				bson.M{"$gt": bson.A{bson.M{"$size": "$pekerjaan_aktif"}, 0}}, 1, 0,
+			}}},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	cursor, err := r.alumniCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	defer cursor.Close(ctx)
+
+	result := []model.EmploymentRate{}
+	if err = cursor.All(ctx, &result); err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	return result, nil
+}
+
+// GetTimeToEmployment mengumpulkan jarak hari antara kelulusan (diasumsikan
+// 1 Januari tahun_lulus) dan pekerjaan pertama per kelompok. Median dihitung
+// di service karena $median baru tersedia di MongoDB 7.0.
+func (r *AnalyticsRepository) GetTimeToEmployment(ctx context.Context, filter model.AnalyticsFilter) ([]model.TimeToEmployment, error) {
+	ctx, span := tracing.StartMongo(ctx, "AnalyticsRepository.GetTimeToEmployment")
+	defer span.End()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: analyticsMatch(filter, "")}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "pekerjaan_alumni",
+			"localField":   "_id",
+			"foreignField": "alumni_info.alumni_id",
+			"as":           "pekerjaan",
+		}}},
+		{{Key: "$match", Value: bson.M{"pekerjaan.0": bson.M{"$exists": true}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": analyticsGroupExpr(filter.GroupBy, ""),
+			"days": bson.M{"$push": bson.M{"$divide": bson.A{
+				bson.M{"$subtract": bson.A{
+					bson.M{"$min": "$pekerjaan.tanggal_mulai_kerja"},
+					bson.M{"$dateFromParts": bson.M{"year": "$tahun_lulus"}},
+				}},
+				86400000,
+			}}},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	cursor, err := r.alumniCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	defer cursor.Close(ctx)
+
+	result := []model.TimeToEmployment{}
+	if err = cursor.All(ctx, &result); err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	return result, nil
+}
+
+func (r *AnalyticsRepository) GetEmploymentDistribution(ctx context.Context, filter model.AnalyticsFilter, dimension, status string) ([]model.DistributionItem, error) {
+	ctx, span := tracing.StartMongo(ctx, "AnalyticsRepository.GetEmploymentDistribution")
+	defer span.End()
+
+	dimensionField, ok := analyticsDimensionFields[dimension]
+	if !ok {
+		return nil, apperror.Validation("analytics.unsupported_dimension", dimension)
+	}
+
+	pipeline := pekerjaanWithAlumniPipeline(filter)
+	if status != "" {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"status_pekerjaan": status}}})
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"group": analyticsGroupExpr(filter.GroupBy, "alumni."), "category": dimensionField},
+			"total": bson.M{"$sum": 1},
+		}}},
+		bson.D{{Key: "$project", Value: bson.M{"_id": 0, "group": "$_id.group", "category": "$_id.category", "total": 1}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "group", Value: 1}, {Key: "total", Value: -1}, {Key: "category", Value: 1}}}},
+	)
+
+	cursor, err := r.pekerjaanCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	defer cursor.Close(ctx)
+
+	result := []model.DistributionItem{}
+	if err = cursor.All(ctx, &result); err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	return result, nil
+}
+
+func (r *AnalyticsRepository) GetTopEmployers(ctx context.Context, filter model.AnalyticsFilter, limit int) ([]model.TopEmployer, error) {
+	ctx, span := tracing.StartMongo(ctx, "AnalyticsRepository.GetTopEmployers")
+	defer span.End()
+
+	pipeline := append(pekerjaanWithAlumniPipeline(filter),
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":    bson.M{"group": analyticsGroupExpr(filter.GroupBy, "alumni."), "nama_perusahaan": "$nama_perusahaan"},
+			"alumni": bson.M{"$addToSet": "$alumni_info.alumni_id"},
+		}}},
+		bson.D{{Key: "$project", Value: bson.M{
+			"_id":             0,
+			"group":           "$_id.group",
+			"nama_perusahaan": "$_id.nama_perusahaan",
+			"total_alumni":    bson.M{"$size": "$alumni"},
+		}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "group", Value: 1}, {Key: "total_alumni", Value: -1}, {Key: "nama_perusahaan", Value: 1}}}},
+		bson.D{{Key: "$group", Value: bson.M{"_id": "$group", "items": bson.M{"$push": "$$ROOT"}}}},
+		bson.D{{Key: "$project", Value: bson.M{"items": bson.M{"$slice": bson.A{"$items", limit}}}}},
+		bson.D{{Key: "$unwind", Value: "$items"}},
+		bson.D{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$items"}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "group", Value: 1}, {Key: "total_alumni", Value: -1}, {Key: "nama_perusahaan", Value: 1}}}},
+	)
+
+	cursor, err := r.pekerjaanCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	defer cursor.Close(ctx)
+
+	result := []model.TopEmployer{}
+	if err = cursor.All(ctx, &result); err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	return result, nil
+}
+
+// GetSalaryStatistics mengumpulkan gaji bulanan (titik tengah gaji_min dan
+// gaji_max) per kelompok beserta jumlah alumni unik. Persentil dihitung di
+// service dengan helper.Percentile.
+func (r *AnalyticsRepository) GetSalaryStatistics(ctx context.Context, filter model.AnalyticsFilter, currency string) ([]model.SalaryStatistic, error) {
+	ctx, span := tracing.StartMongo(ctx, "AnalyticsRepository.GetSalaryStatistics")
+	defer span.End()
+
+	groupExpr := analyticsGroupExpr(filter.GroupBy, "alumni.")
+	if filter.GroupBy == "bidang_industri" {
+		groupExpr = "$bidang_industri"
+	}
+
+	pipeline := append(pekerjaanWithAlumniPipeline(filter),
+		bson.D{{Key: "$match", Value: bson.M{"gaji_min": bson.M{"$type": "number"}, "gaji_currency": currency}}},
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id": groupExpr,
+			"values": bson.M{"$push": bson.M{"$divide": bson.A{
+				bson.M{"$divide": bson.A{bson.M{"$add": bson.A{"$gaji_min", "$gaji_max"}}, 2}},
+				bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$gaji_period", "tahunan"}}, 12, 1}},
+			}}},
+			"alumni": bson.M{"$addToSet": "$alumni_info.alumni_id"},
+		}}},
+		bson.D{{Key: "$project", Value: bson.M{"values": 1, "total_sampel": bson.M{"$size": "$alumni"}}}},
+		bson.D{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	)
+
+	cursor, err := r.pekerjaanCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	defer cursor.Close(ctx)
+
+	result := []model.SalaryStatistic{}
+	if err = cursor.All(ctx, &result); err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	for i := range result {
+		result[i].Currency = currency
+	}
+
+	return result, nil
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	model "go-fiber/app/model/mongo"
+	"go-fiber/apperror"
+	"go-fiber/tracing"
+	"regexp"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IBatchRepository interface {
+	FindAlumniIDs(ctx context.Context, filter model.AlumniBatchFilter, limit int) ([]string, error)
+	FindPekerjaanAlumniIDs(ctx context.Context, filter model.PekerjaanBatchFilter, limit int) ([]string, error)
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type BatchRepository struct {
+	client              *mongo.Client
+	alumniCollection    *mongo.Collection
+	pekerjaanCollection *mongo.Collection
+}
+
+func NewBatchRepository(db *mongo.Database) IBatchRepository {
+	return &BatchRepository{
+		client:              db.Client(),
+		alumniCollection:    db.Collection("alumni"),
+		pekerjaanCollection: db.Collection("pekerjaan_alumni"),
+	}
+}
+
+// FindAlumniIDs mengembalikan id alumni yang cocok dengan filter batch,
+// terurut, paling banyak limit data.
+func (r *BatchRepository) FindAlumniIDs(ctx context.Context, filter model.AlumniBatchFilter, limit int) ([]string, error) {
+	ctx, span := tracing.StartMongo(ctx, "BatchRepository.FindAlumniIDs")
+	defer span.End()
+
+	query := bson.M{}
+	if filter.Jurusan != "" {
+		query["jurusan"] = filter.Jurusan
+	}
+	if filter.Angkatan != 0 {
+		query["angkatan"] = filter.Angkatan
+	}
+	if filter.TahunLulus != 0 {
+		query["tahun_lulus"] = filter.TahunLulus
+	}
+
+	ids, err := findIDs(ctx, r.alumniCollection, query, limit)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	return ids, nil
+}
+
+// FindPekerjaanAlumniIDs mengembalikan id pekerjaan yang cocok dengan filter
+// batch, terurut, paling banyak limit data.
+func (r *BatchRepository) FindPekerjaanAlumniIDs(ctx context.Context, filter model.PekerjaanBatchFilter, limit int) ([]string, error) {
+	ctx, span := tracing.StartMongo(ctx, "BatchRepository.FindPekerjaanAlumniIDs")
+	defer span.End()
+
+	query := bson.M{}
+	if filter.AlumniID != "" {
+		alumniID, err := primitive.ObjectIDFromHex(filter.AlumniID)
+		if err != nil {
+			return nil, apperror.InvalidID()
+		}
+		query["alumni_info.alumni_id"] = alumniID
+	}
+	if filter.NamaPerusahaan != "" {
+		query["nama_perusahaan"] = primitive.Regex{Pattern: "^" + regexp.QuoteMeta(filter.NamaPerusahaan) + "$", Options: "i"}
+	}
+	if filter.BidangIndustri != "" {
+		query["bidang_industri"] = filter.BidangIndustri
+	}
+	if filter.StatusPekerjaan != "" {
+		query["status_pekerjaan"] = filter.StatusPekerjaan
+	}
+
+	ids, err := findIDs(ctx, r.pekerjaanCollection, query, limit)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	return ids, nil
+}
+
+// WithTransaction menjalankan fn dalam satu transaksi. Repository lain yang
+// dipanggil dengan ctx dari fn ikut masuk transaksi. fn bisa dijalankan ulang
+// jika transaksi gagal sementara, jadi tidak boleh menyimpan state di luar.
+// Transaksi MongoDB membutuhkan replica set atau sharded cluster.
+func (r *BatchRepository) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	session, err := r.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+	return err
+}
+
+func findIDs(ctx context.Context, collection *mongo.Collection, query bson.M, limit int) ([]string, error) {
+	opts := options.Find().
+		SetProjection(bson.M{"_id": 1}).
+		SetSort(bson.M{"_id": 1}).
+		SetLimit(int64(limit))
+	cursor, err := collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	ids := []string{}
+	for cursor.Next(ctx) {
+		var document struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cursor.Decode(&document); err != nil {
+			return nil, err
+		}
+		ids = append(ids, document.ID.Hex())
+	}
+	return ids, cursor.Err()
+}
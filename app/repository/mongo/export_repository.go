@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	model "go-fiber/app/model/mongo"
+	"go-fiber/tracing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IExportRepository interface {
+	CountAlumni(ctx context.Context) (int64, error)
+	CountPekerjaanAlumni(ctx context.Context) (int64, error)
+	StreamAlumni(ctx context.Context, fn func(model.Alumni) error) error
+	StreamPekerjaanAlumni(ctx context.Context, fn func(model.PekerjaanAlumni) error) error
+}
+
+type ExportRepository struct {
+	alumniCollection    *mongo.Collection
+	pekerjaanCollection *mongo.Collection
+}
+
+func NewExportRepository(db *mongo.Database) IExportRepository {
+	return &ExportRepository{
+		alumniCollection:    db.Collection("alumni"),
+		pekerjaanCollection: db.Collection("pekerjaan_alumni"),
+	}
+}
+
+func (r *ExportRepository) CountAlumni(ctx context.Context) (int64, error) {
+	ctx, span := tracing.StartMongo(ctx, "ExportRepository.CountAlumni")
+	defer span.End()
+
+	return r.alumniCollection.CountDocuments(ctx, bson.M{})
+}
+
+func (r *ExportRepository) CountPekerjaanAlumni(ctx context.Context) (int64, error) {
+	ctx, span := tracing.StartMongo(ctx, "ExportRepository.CountPekerjaanAlumni")
+	defer span.End()
+
+	return r.pekerjaanCollection.CountDocuments(ctx, bson.M{})
+}
+
+// StreamAlumni membaca alumni satu per satu dari cursor dan memanggil fn
+// untuk setiap dokumen tanpa menampung seluruh hasil di memori.
+func (r *ExportRepository) StreamAlumni(ctx context.Context, fn func(model.Alumni) error) error {
+	ctx, span := tracing.StartMongo(ctx, "ExportRepository.StreamAlumni")
+	defer span.End()
+
+	cursor, err := r.alumniCollection.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var alumni model.Alumni
+		if err := cursor.Decode(&alumni); err != nil {
+			return tracing.Error(span, err)
+		}
+		if err := fn(alumni); err != nil {
+			return tracing.Error(span, err)
+		}
+	}
+
+	return cursor.Err()
+}
+
+func (r *ExportRepository) StreamPekerjaanAlumni(ctx context.Context, fn func(model.PekerjaanAlumni) error) error {
+	ctx, span := tracing.StartMongo(ctx, "ExportRepository.StreamPekerjaanAlumni")
+	defer span.End()
+
+	cursor, err := r.pekerjaanCollection.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var pekerjaan model.PekerjaanAlumni
+		if err := cursor.Decode(&pekerjaan); err != nil {
+			return tracing.Error(span, err)
+		}
+		if err := fn(pekerjaan); err != nil {
+			return tracing.Error(span, err)
+		}
+	}
+
+	return cursor.Err()
+}
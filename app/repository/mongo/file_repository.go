@@ -2,8 +2,9 @@ package repository
 
 import (
 	"context"
-	"errors"
 	model "go-fiber/app/model/mongo"
+	"go-fiber/apperror"
+	"go-fiber/tracing"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -30,13 +31,16 @@ func NewFileRepository(db *mongo.Database) IFileRepository {
 }
 
 func (r *FileRepository) CreateFile(ctx context.Context, file *model.File) (*model.File, error) {
+	ctx, span := tracing.StartMongo(ctx, "FileRepository.CreateFile")
+	defer span.End()
+
 	file.ID = primitive.NilObjectID
 	file.CreatedAt = time.Now()
 	file.UpdatedAt = time.Now()
 
 	result, err := r.collection.InsertOne(ctx, file)
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, apperror.FromDB(err))
 	}
 
 	file.ID = result.InsertedID.(primitive.ObjectID)
@@ -44,44 +48,53 @@ func (r *FileRepository) CreateFile(ctx context.Context, file *model.File) (*mod
 }
 
 func (r *FileRepository) FindAllFiles(ctx context.Context) ([]model.File, error) {
+	ctx, span := tracing.StartMongo(ctx, "FileRepository.FindAllFiles")
+	defer span.End()
+
 	cursor, err := r.collection.Find(ctx, bson.M{})
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, err)
 	}
 	defer cursor.Close(ctx)
 
 	var files []model.File
 	if err = cursor.All(ctx, &files); err != nil {
-		return nil, err
+		return nil, tracing.Error(span, err)
 	}
 
 	return files, nil
 }
 
 func (r *FileRepository) FindFilesByAlumniID(ctx context.Context, alumniID string) ([]model.File, error) {
+	ctx, span := tracing.StartMongo(ctx, "FileRepository.FindFilesByAlumniID")
+	defer span.End()
+
 	objID, err := primitive.ObjectIDFromHex(alumniID)
 	if err != nil {
-		return nil, errors.New("alumni ID tidak valid")
+		return nil, apperror.Validation("alumni.invalid_id").WithCode("invalid_id")
 	}
 
 	cursor, err := r.collection.Find(ctx, bson.M{"alumni_info.alumni_id": objID})
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, err)
 	}
 	defer cursor.Close(ctx)
 
 	var files []model.File
 	if err = cursor.All(ctx, &files); err != nil {
-		return nil, err
+		return nil, tracing.Error(span, err)
 	}
 
 	return files, nil
 }
 
 func (r *FileRepository) FindFileByID(ctx context.Context, id string) (*model.File, error) {
+	ctx, span := tracing.StartMongo(ctx, "FileRepository.FindFileByID")
+	defer span.End()
+
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return nil, errors.New("ID tidak valid")
+		return nil, apperror.InvalidID()
 	}
 
 	var file model.File
@@ -91,26 +104,29 @@ func (r *FileRepository) FindFileByID(ctx context.Context, id string) (*model.Fi
 		if err == mongo.ErrNoDocuments {
 			return nil, nil
 		}
-		return nil, err
+		return nil, tracing.Error(span, err)
 	}
 
 	return &file, nil
 }
 
 func (r *FileRepository) DeleteFile(ctx context.Context, id string) error {
+	ctx, span := tracing.StartMongo(ctx, "FileRepository.DeleteFile")
+	defer span.End()
+
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return errors.New("ID tidak valid")
+		return apperror.InvalidID()
 	}
 
 	filter := bson.M{"_id": objID}
 	result, err := r.collection.DeleteOne(ctx, filter)
 	if err != nil {
-		return err
+		return tracing.Error(span, err)
 	}
 
 	if result.DeletedCount == 0 {
-		return errors.New("file tidak ditemukan")
+		return apperror.NotFound("file.not_found")
 	}
 
 	return nil
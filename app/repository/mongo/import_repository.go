@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	model "go-fiber/app/model/mongo"
+	"go-fiber/apperror"
+	"go-fiber/tracing"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type IImportRepository interface {
+	FindExistingAlumniKeys(ctx context.Context, nims, emails []string) (map[string]bool, map[string]bool, error)
+	FindAlumniByNIMs(ctx context.Context, nims []string) (map[string]model.Alumni, error)
+	InsertAlumni(ctx context.Context, alumni []model.Alumni) error
+	InsertPekerjaanAlumni(ctx context.Context, pekerjaan []model.PekerjaanAlumni) error
+}
+
+type ImportRepository struct {
+	client              *mongo.Client
+	alumniCollection    *mongo.Collection
+	pekerjaanCollection *mongo.Collection
+}
+
+func NewImportRepository(db *mongo.Database) IImportRepository {
+	return &ImportRepository{
+		client:              db.Client(),
+		alumniCollection:    db.Collection("alumni"),
+		pekerjaanCollection: db.Collection("pekerjaan_alumni"),
+	}
+}
+
+// FindExistingAlumniKeys mengembalikan NIM dan email (huruf kecil) yang sudah
+// terdaftar dari daftar yang diberikan.
+func (r *ImportRepository) FindExistingAlumniKeys(ctx context.Context, nims, emails []string) (map[string]bool, map[string]bool, error) {
+	ctx, span := tracing.StartMongo(ctx, "ImportRepository.FindExistingAlumniKeys")
+	defer span.End()
+
+	emailPatterns := bson.A{}
+	for _, email := range emails {
+		emailPatterns = append(emailPatterns, primitive.Regex{Pattern: "^" + regexp.QuoteMeta(email) + "$", Options: "i"})
+	}
+
+	filter := bson.M{"$or": bson.A{
+		bson.M{"nim": bson.M{"$in": nims}},
+		bson.M{"email": bson.M{"$in": emailPatterns}},
+	}}
+	cursor, err := r.alumniCollection.Find(ctx, filter)
+	if err != nil {
+		return nil, nil, tracing.Error(span, err)
+	}
+	defer cursor.Close(ctx)
+
+	existingNIM := map[string]bool{}
+	existingEmail := map[string]bool{}
+	for cursor.Next(ctx) {
+		var alumni model.Alumni
+		if err := cursor.Decode(&alumni); err != nil {
+			return nil, nil, tracing.Error(span, err)
+		}
+		existingNIM[alumni.NIM] = true
+		existingEmail[strings.ToLower(alumni.Email)] = true
+	}
+
+	return existingNIM, existingEmail, cursor.Err()
+}
+
+func (r *ImportRepository) FindAlumniByNIMs(ctx context.Context, nims []string) (map[string]model.Alumni, error) {
+	ctx, span := tracing.StartMongo(ctx, "ImportRepository.FindAlumniByNIMs")
+	defer span.End()
+
+	cursor, err := r.alumniCollection.Find(ctx, bson.M{"nim": bson.M{"$in": nims}})
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	defer cursor.Close(ctx)
+
+	result := map[string]model.Alumni{}
+	for cursor.Next(ctx) {
+		var alumni model.Alumni
+		if err := cursor.Decode(&alumni); err != nil {
+			return nil, tracing.Error(span, err)
+		}
+		result[alumni.NIM] = alumni
+	}
+
+	return result, cursor.Err()
+}
+
+// InsertAlumni menyimpan seluruh alumni dalam satu transaksi. Transaksi
+// MongoDB membutuhkan replica set atau sharded cluster. ID setiap alumni
+// diisi sebelum insert sehingga pemanggil bisa memakainya.
+func (r *ImportRepository) InsertAlumni(ctx context.Context, alumni []model.Alumni) error {
+	ctx, span := tracing.StartMongo(ctx, "ImportRepository.InsertAlumni")
+	defer span.End()
+
+	now := time.Now()
+	documents := make([]interface{}, len(alumni))
+	for i := range alumni {
+		alumni[i].ID = primitive.NewObjectID()
+		alumni[i].Version = 1
+		alumni[i].CreatedAt = now
+		alumni[i].UpdatedAt = now
+		documents[i] = alumni[i]
+	}
+
+	return r.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		_, err := r.alumniCollection.InsertMany(sc, documents)
+		return tracing.Error(span, apperror.FromDB(err))
+	})
+}
+
+func (r *ImportRepository) InsertPekerjaanAlumni(ctx context.Context, pekerjaan []model.PekerjaanAlumni) error {
+	ctx, span := tracing.StartMongo(ctx, "ImportRepository.InsertPekerjaanAlumni")
+	defer span.End()
+
+	now := time.Now()
+	documents := make([]interface{}, len(pekerjaan))
+	for i := range pekerjaan {
+		pekerjaan[i].ID = primitive.NewObjectID()
+		pekerjaan[i].Version = 1
+		pekerjaan[i].CreatedAt = now
+		pekerjaan[i].UpdatedAt = now
+		documents[i] = pekerjaan[i]
+	}
+
+	return r.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		_, err := r.pekerjaanCollection.InsertMany(sc, documents)
+		return tracing.Error(span, apperror.FromDB(err))
+	})
+}
+
+// withTransaction menjalankan fn dalam transaksi baru, atau dalam transaksi
+// pemanggil jika ctx sudah membawa session (misalnya dari outbox) agar
+// insert dan event-nya tersimpan bersama.
+func (r *ImportRepository) withTransaction(ctx context.Context, fn func(sc mongo.SessionContext) error) error {
+	if session := mongo.SessionFromContext(ctx); session != nil {
+		return fn(mongo.NewSessionContext(ctx, session))
+	}
+
+	session, err := r.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+	return err
+}
@@ -2,8 +2,9 @@ package repository
 
 import (
 	"context"
-	"errors"
 	model "go-fiber/app/model/mongo"
+	"go-fiber/apperror"
+	"go-fiber/tracing"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -16,8 +17,9 @@ type IPekerjaanAlumniRepository interface {
 	FindPekerjaanAlumniByID(ctx context.Context, id string) (*model.PekerjaanAlumni, error)
 	FindAllPekerjaanAlumni(ctx context.Context) ([]model.PekerjaanAlumni, error)
 	FindPekerjaanAlumniByAlumniID(ctx context.Context, alumniID string) ([]model.PekerjaanAlumni, error)
-	UpdatePekerjaanAlumni(ctx context.Context, id string, pekerjaan *model.PekerjaanAlumni) (*model.PekerjaanAlumni, error)
-	DeletePekerjaanAlumni(ctx context.Context, id string) error
+	UpdatePekerjaanAlumni(ctx context.Context, id string, version int, pekerjaan *model.PekerjaanAlumni) (*model.PekerjaanAlumni, error)
+	PatchPekerjaanAlumni(ctx context.Context, id string, version int, changes bson.M) (*model.PekerjaanAlumni, error)
+	DeletePekerjaanAlumni(ctx context.Context, id string, version int) error
 }
 
 type PekerjaanAlumniRepository struct {
@@ -31,13 +33,17 @@ func NewPekerjaanAlumniRepository(db *mongo.Database) IPekerjaanAlumniRepository
 }
 
 func (r *PekerjaanAlumniRepository) CreatePekerjaanAlumni(ctx context.Context, pekerjaan *model.PekerjaanAlumni) (*model.PekerjaanAlumni, error) {
+	ctx, span := tracing.StartMongo(ctx, "PekerjaanAlumniRepository.CreatePekerjaanAlumni")
+	defer span.End()
+
 	pekerjaan.ID = primitive.NilObjectID
+	pekerjaan.Version = 1
 	pekerjaan.CreatedAt = time.Now()
 	pekerjaan.UpdatedAt = time.Now()
 
 	result, err := r.collection.InsertOne(ctx, pekerjaan)
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, apperror.FromDB(err))
 	}
 
 	pekerjaan.ID = result.InsertedID.(primitive.ObjectID)
@@ -45,9 +51,12 @@ func (r *PekerjaanAlumniRepository) CreatePekerjaanAlumni(ctx context.Context, p
 }
 
 func (r *PekerjaanAlumniRepository) FindPekerjaanAlumniByID(ctx context.Context, id string) (*model.PekerjaanAlumni, error) {
+	ctx, span := tracing.StartMongo(ctx, "PekerjaanAlumniRepository.FindPekerjaanAlumniByID")
+	defer span.End()
+
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return nil, errors.New("ID tidak valid")
+		return nil, apperror.InvalidID()
 	}
 
 	var pekerjaan model.PekerjaanAlumni
@@ -57,75 +66,89 @@ func (r *PekerjaanAlumniRepository) FindPekerjaanAlumniByID(ctx context.Context,
 		if err == mongo.ErrNoDocuments {
 			return nil, nil
 		}
-		return nil, err
+		return nil, tracing.Error(span, err)
 	}
 
 	return &pekerjaan, nil
 }
 
 func (r *PekerjaanAlumniRepository) FindAllPekerjaanAlumni(ctx context.Context) ([]model.PekerjaanAlumni, error) {
+	ctx, span := tracing.StartMongo(ctx, "PekerjaanAlumniRepository.FindAllPekerjaanAlumni")
+	defer span.End()
+
 	cursor, err := r.collection.Find(ctx, bson.M{})
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, err)
 	}
 	defer cursor.Close(ctx)
 
 	var pekerjaanList []model.PekerjaanAlumni
 	if err = cursor.All(ctx, &pekerjaanList); err != nil {
-		return nil, err
+		return nil, tracing.Error(span, err)
 	}
 
 	return pekerjaanList, nil
 }
 
 func (r *PekerjaanAlumniRepository) FindPekerjaanAlumniByAlumniID(ctx context.Context, alumniID string) ([]model.PekerjaanAlumni, error) {
+	ctx, span := tracing.StartMongo(ctx, "PekerjaanAlumniRepository.FindPekerjaanAlumniByAlumniID")
+	defer span.End()
+
 	objID, err := primitive.ObjectIDFromHex(alumniID)
 	if err != nil {
-		return nil, errors.New("alumni ID tidak valid")
+		return nil, apperror.Validation("alumni.invalid_id").WithCode("invalid_id")
 	}
 
 	filter := bson.M{"alumni_info.alumni_id": objID}
 	cursor, err := r.collection.Find(ctx, filter)
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, err)
 	}
 	defer cursor.Close(ctx)
 
 	var pekerjaanList []model.PekerjaanAlumni
 	if err = cursor.All(ctx, &pekerjaanList); err != nil {
-		return nil, err
+		return nil, tracing.Error(span, err)
 	}
 
 	return pekerjaanList, nil
 }
 
-func (r *PekerjaanAlumniRepository) UpdatePekerjaanAlumni(ctx context.Context, id string, pekerjaan *model.PekerjaanAlumni) (*model.PekerjaanAlumni, error) {
+// UpdatePekerjaanAlumni hanya mengubah pekerjaan yang version-nya masih sama
+// dengan version, lalu menaikkan version. Jika dokumen sudah diubah request
+// lain, hasilnya apperror.VersionConflict.
+func (r *PekerjaanAlumniRepository) UpdatePekerjaanAlumni(ctx context.Context, id string, version int, pekerjaan *model.PekerjaanAlumni) (*model.PekerjaanAlumni, error) {
+	ctx, span := tracing.StartMongo(ctx, "PekerjaanAlumniRepository.UpdatePekerjaanAlumni")
+	defer span.End()
+
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return nil, errors.New("ID tidak valid")
+		return nil, apperror.InvalidID()
 	}
 
-	filter := bson.M{"_id": objID}
-	update := bson.M{
-		"$set": bson.M{
-			"alumni_info":          pekerjaan.AlumniInfo,
-			"nama_perusahaan":      pekerjaan.NamaPerusahaan,
-			"posisi_jabatan":       pekerjaan.PosisiJabatan,
-			"bidang_industri":      pekerjaan.BidangIndustri,
-			"lokasi_kerja":         pekerjaan.LokasiKerja,
-			"gaji_range":           pekerjaan.GajiRange,
-			"tanggal_mulai_kerja":  pekerjaan.TanggalMulaiKerja,
-			"tanggal_selesai_kerja": pekerjaan.TanggalSelesaiKerja,
-			"status_pekerjaan":     pekerjaan.StatusPekerjaan,
-			"deskripsi_pekerjaan":  pekerjaan.DeskripsiPekerjaan,
-			"updated_at":           pekerjaan.UpdatedAt,
-		},
-	}
+	filter := bson.M{"_id": objID, "version": version}
+	update := versionedUpdate(bson.M{
+		"alumni_info":           pekerjaan.AlumniInfo,
+		"nama_perusahaan":       pekerjaan.NamaPerusahaan,
+		"posisi_jabatan":        pekerjaan.PosisiJabatan,
+		"bidang_industri":       pekerjaan.BidangIndustri,
+		"lokasi_kerja":          pekerjaan.LokasiKerja,
+		"gaji_range":            pekerjaan.GajiRange,
+		"gaji_min":              pekerjaan.GajiMin,
+		"gaji_max":              pekerjaan.GajiMax,
+		"gaji_currency":         pekerjaan.GajiCurrency,
+		"gaji_period":           pekerjaan.GajiPeriod,
+		"tanggal_mulai_kerja":   pekerjaan.TanggalMulaiKerja,
+		"tanggal_selesai_kerja": pekerjaan.TanggalSelesaiKerja,
+		"status_pekerjaan":      pekerjaan.StatusPekerjaan,
+		"deskripsi_pekerjaan":   pekerjaan.DeskripsiPekerjaan,
+		"updated_at":            pekerjaan.UpdatedAt,
+	})
 
 	result := r.collection.FindOneAndUpdate(ctx, filter, update)
 	if result.Err() != nil {
 		if result.Err() == mongo.ErrNoDocuments {
-			return nil, nil
+			return nil, apperror.VersionConflict()
 		}
 		return nil, result.Err()
 	}
@@ -133,20 +156,61 @@ func (r *PekerjaanAlumniRepository) UpdatePekerjaanAlumni(ctx context.Context, i
 	return r.FindPekerjaanAlumniByID(ctx, id)
 }
 
-func (r *PekerjaanAlumniRepository) DeletePekerjaanAlumni(ctx context.Context, id string) error {
+// pekerjaanPatchFields adalah field yang boleh diubah PatchPekerjaanAlumni.
+var pekerjaanPatchFields = map[string]bool{
+	"nama_perusahaan": true, "posisi_jabatan": true, "bidang_industri": true, "lokasi_kerja": true,
+	"gaji_range": true, "gaji_min": true, "gaji_max": true, "gaji_currency": true, "gaji_period": true,
+	"tanggal_mulai_kerja": true, "tanggal_selesai_kerja": true, "status_pekerjaan": true, "deskripsi_pekerjaan": true,
+}
+
+// PatchPekerjaanAlumni hanya mengubah field pada changes; nilai nil
+// menghapus field. Aturan version sama seperti UpdatePekerjaanAlumni.
+func (r *PekerjaanAlumniRepository) PatchPekerjaanAlumni(ctx context.Context, id string, version int, changes bson.M) (*model.PekerjaanAlumni, error) {
+	ctx, span := tracing.StartMongo(ctx, "PekerjaanAlumniRepository.PatchPekerjaanAlumni")
+	defer span.End()
+
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return errors.New("ID tidak valid")
+		return nil, apperror.InvalidID()
+	}
+	if err := patchFields(changes, pekerjaanPatchFields); err != nil {
+		return nil, tracing.Error(span, err)
 	}
 
-	filter := bson.M{"_id": objID}
+	fields := bson.M{"updated_at": time.Now()}
+	for field, value := range changes {
+		fields[field] = value
+	}
+
+	filter := bson.M{"_id": objID, "version": version}
+	result := r.collection.FindOneAndUpdate(ctx, filter, versionedUpdate(fields))
+	if result.Err() != nil {
+		if result.Err() == mongo.ErrNoDocuments {
+			return nil, apperror.VersionConflict()
+		}
+		return nil, tracing.Error(span, apperror.FromDB(result.Err()))
+	}
+
+	return r.FindPekerjaanAlumniByID(ctx, id)
+}
+
+func (r *PekerjaanAlumniRepository) DeletePekerjaanAlumni(ctx context.Context, id string, version int) error {
+	ctx, span := tracing.StartMongo(ctx, "PekerjaanAlumniRepository.DeletePekerjaanAlumni")
+	defer span.End()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return apperror.InvalidID()
+	}
+
+	filter := bson.M{"_id": objID, "version": version}
 	result, err := r.collection.DeleteOne(ctx, filter)
 	if err != nil {
-		return err
+		return tracing.Error(span, err)
 	}
 
 	if result.DeletedCount == 0 {
-		return errors.New("data pekerjaan alumni tidak ditemukan")
+		return apperror.VersionConflict()
 	}
 
 	return nil
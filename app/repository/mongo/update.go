@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// versionedUpdate menyusun dokumen update dari fields dan menaikkan version.
+// Nilai nil, termasuk pointer nil, masuk ke $unset sehingga field opsional
+// hilang dari dokumen seperti saat insert dengan omitempty, bukan disimpan
+// sebagai null.
+func versionedUpdate(fields bson.M) bson.M {
+	set := bson.M{}
+	unset := bson.M{}
+	for name, value := range fields {
+		if isNil(value) {
+			unset[name] = ""
+		} else {
+			set[name] = value
+		}
+	}
+
+	update := bson.M{"$inc": bson.M{"version": 1}}
+	if len(set) > 0 {
+		update["$set"] = set
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	return update
+}
+
+func isNil(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
+
+// patchFields menolak field di luar allowed agar nama field update tidak
+// pernah berasal dari input client.
+func patchFields(changes bson.M, allowed map[string]bool) error {
+	for field := range changes {
+		if !allowed[field] {
+			return fmt.Errorf("field %q tidak bisa diubah", field)
+		}
+	}
+	return nil
+}
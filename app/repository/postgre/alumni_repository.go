@@ -1,32 +1,40 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	model "go-fiber/app/model/postgre"
+	"go-fiber/apperror"
+	"go-fiber/tracing"
+	"strings"
 	"time"
 )
 
-func GetAllAlumni(db *sql.DB, search, sortBy, order string, limit, offset int) ([]model.Alumni, error) {
-	sortByMap := map[string]string{
-		"id":           "a.id",
-		"nim":          "a.nim",
-		"nama":         "a.nama",
-		"email":        "a.email",
-		"jurusan":      "a.jurusan",
-		"angkatan":     "a.angkatan",
-		"tahun_lulus":  "a.tahun_lulus",
-		"created_at":   "a.created_at",
-	}
-	
-	sortColumn := sortByMap[sortBy]
+var alumniSortColumns = map[string]string{
+	"id":           "a.id",
+	"nim":          "a.nim",
+	"nama":         "a.nama",
+	"email":        "a.email",
+	"jurusan":      "a.jurusan",
+	"angkatan":     "a.angkatan",
+	"tahun_lulus":  "a.tahun_lulus",
+	"created_at":   "a.created_at",
+}
+
+func GetAllAlumni(ctx context.Context, db *sql.DB, search, sortBy, order string, limit, offset int) ([]model.Alumni, error) {
+	ctx, span := tracing.StartPostgres(ctx, "GetAllAlumni")
+	defer span.End()
+
+	sortColumn := alumniSortColumns[sortBy]
 	if sortColumn == "" {
 		sortColumn = "a.id"
 	}
 	
 	query := fmt.Sprintf(`
 		SELECT a.id, a.nim, a.nama, a.jurusan, a.angkatan, a.tahun_lulus, a.email, 
-		       a.no_telepon, a.alamat, a.role_id, a.created_at, a.updated_at,
+		       a.no_telepon, a.alamat, a.role_id, a.version, a.created_at, a.updated_at,
 		       r.id, r.nama, r.created_at, r.updated_at
 		FROM alumni a
 		LEFT JOIN roles r ON a.role_id = r.id
@@ -35,9 +43,9 @@ func GetAllAlumni(db *sql.DB, search, sortBy, order string, limit, offset int) (
 		LIMIT $2 OFFSET $3
 	`, sortColumn, order)
 	
-	rows, err := db.Query(query, "%"+search+"%", limit, offset)
+	rows, err := db.QueryContext(ctx, query, "%"+search+"%", limit, offset)
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, err)
 	}
 	defer rows.Close()
 
@@ -48,12 +56,12 @@ func GetAllAlumni(db *sql.DB, search, sortBy, order string, limit, offset int) (
 		err := rows.Scan(
 			&alumni.ID, &alumni.NIM, &alumni.Nama, &alumni.Jurusan,
 			&alumni.Angkatan, &alumni.TahunLulus, &alumni.Email,
-			&alumni.NoTelepon, &alumni.Alamat, &alumni.RoleID,
+			&alumni.NoTelepon, &alumni.Alamat, &alumni.RoleID, &alumni.Version,
 			&alumni.CreatedAt, &alumni.UpdatedAt,
 			&role.ID, &role.Nama, &role.CreatedAt, &role.UpdatedAt,
 		)
 		if err != nil {
-			return nil, err
+			return nil, tracing.Error(span, err)
 		}
 		alumni.Role = &role
 		alumniList = append(alumniList, alumni)
@@ -62,20 +70,26 @@ func GetAllAlumni(db *sql.DB, search, sortBy, order string, limit, offset int) (
 	return alumniList, nil
 }
 
-func CountAlumni(db *sql.DB, search string) (int, error) {
+func CountAlumni(ctx context.Context, db *sql.DB, search string) (int, error) {
+	ctx, span := tracing.StartPostgres(ctx, "CountAlumni")
+	defer span.End()
+
 	var total int
 	countQuery := `SELECT COUNT(*) FROM alumni WHERE nama ILIKE $1 OR nim ILIKE $1 OR email ILIKE $1 OR jurusan ILIKE $1`
-	err := db.QueryRow(countQuery, "%"+search+"%").Scan(&total)
+	err := db.QueryRowContext(ctx, countQuery, "%"+search+"%").Scan(&total)
 	if err != nil && err != sql.ErrNoRows {
-		return 0, err
+		return 0, tracing.Error(span, err)
 	}
 	return total, nil
 }
 
-func GetAlumniByID(db *sql.DB, id int) (*model.Alumni, error) {
+func GetAlumniByID(ctx context.Context, db DBTX, id int) (*model.Alumni, error) {
+	ctx, span := tracing.StartPostgres(ctx, "GetAlumniByID")
+	defer span.End()
+
 	query := `
 		SELECT a.id, a.nim, a.nama, a.jurusan, a.angkatan, a.tahun_lulus, a.email, 
-		       a.no_telepon, a.alamat, a.role_id, a.created_at, a.updated_at,
+		       a.no_telepon, a.alamat, a.role_id, a.version, a.created_at, a.updated_at,
 		       r.id, r.nama, r.created_at, r.updated_at
 		FROM alumni a
 		LEFT JOIN roles r ON a.role_id = r.id
@@ -84,85 +98,148 @@ func GetAlumniByID(db *sql.DB, id int) (*model.Alumni, error) {
 	
 	alumni := new(model.Alumni)
 	role := new(model.Role)
-	err := db.QueryRow(query, id).Scan(
+	err := db.QueryRowContext(ctx, query, id).Scan(
 		&alumni.ID, &alumni.NIM, &alumni.Nama, &alumni.Jurusan,
 		&alumni.Angkatan, &alumni.TahunLulus, &alumni.Email,
-		&alumni.NoTelepon, &alumni.Alamat, &alumni.RoleID,
+		&alumni.NoTelepon, &alumni.Alamat, &alumni.RoleID, &alumni.Version,
 		&alumni.CreatedAt, &alumni.UpdatedAt,
 		&role.ID, &role.Nama, &role.CreatedAt, &role.UpdatedAt,
 	)
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, err)
 	}
 	alumni.Role = role
 	
 	return alumni, nil
 }
 
-func CreateAlumni(db *sql.DB, req model.CreateAlumniRequest, passwordHash string) (*model.Alumni, error) {
+func CreateAlumni(ctx context.Context, db DBTX, req model.CreateAlumniRequest, passwordHash string) (*model.Alumni, error) {
+	ctx, span := tracing.StartPostgres(ctx, "CreateAlumni")
+	defer span.End()
+
 	query := `
 		INSERT INTO alumni (nim, nama, jurusan, angkatan, tahun_lulus, email, 
 		                   password_hash, no_telepon, alamat, role_id, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 		RETURNING id, nim, nama, jurusan, angkatan, tahun_lulus, email, 
-		          no_telepon, alamat, role_id, created_at, updated_at
+		          no_telepon, alamat, role_id, version, created_at, updated_at
 	`
 	
 	now := time.Now()
 	alumni := new(model.Alumni)
-	err := db.QueryRow(query, 
+	err := db.QueryRowContext(ctx, query, 
 		req.NIM, req.Nama, req.Jurusan, req.Angkatan, req.TahunLulus,
 		req.Email, passwordHash, req.NoTelepon, req.Alamat, req.RoleID, now, now,
 	).Scan(
 		&alumni.ID, &alumni.NIM, &alumni.Nama, &alumni.Jurusan,
 		&alumni.Angkatan, &alumni.TahunLulus, &alumni.Email,
-		&alumni.NoTelepon, &alumni.Alamat, &alumni.RoleID,
+		&alumni.NoTelepon, &alumni.Alamat, &alumni.RoleID, &alumni.Version,
 		&alumni.CreatedAt, &alumni.UpdatedAt,
 	)
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, apperror.FromDB(err))
 	}
 	
 	return alumni, nil
 }
 
-func UpdateAlumni(db *sql.DB, id int, req model.UpdateAlumniRequest) (*model.Alumni, error) {
+// UpdateAlumni hanya mengubah baris yang version-nya masih sama dengan
+// version, lalu menaikkan version. Jika baris sudah diubah atau dihapus
+// request lain, hasilnya apperror.VersionConflict.
+func UpdateAlumni(ctx context.Context, db DBTX, id int, version int, req model.UpdateAlumniRequest) (*model.Alumni, error) {
+	ctx, span := tracing.StartPostgres(ctx, "UpdateAlumni")
+	defer span.End()
+
 	query := `
 		UPDATE alumni 
 		SET nama = $1, jurusan = $2, angkatan = $3, tahun_lulus = $4, 
-		    email = $5, no_telepon = $6, alamat = $7, role_id = $8, updated_at = $9
-		WHERE id = $10
+		    email = $5, no_telepon = $6, alamat = $7, role_id = $8, updated_at = $9,
+		    version = version + 1
+		WHERE id = $10 AND version = $11
 		RETURNING id, nim, nama, jurusan, angkatan, tahun_lulus, email, 
-		          no_telepon, alamat, role_id, created_at, updated_at
+		          no_telepon, alamat, role_id, version, created_at, updated_at
 	`
 	
 	alumni := new(model.Alumni)
-	err := db.QueryRow(query,
+	err := db.QueryRowContext(ctx, query,
 		req.Nama, req.Jurusan, req.Angkatan, req.TahunLulus,
-		req.Email, req.NoTelepon, req.Alamat, req.RoleID, time.Now(), id,
+		req.Email, req.NoTelepon, req.Alamat, req.RoleID, time.Now(), id, version,
 	).Scan(
 		&alumni.ID, &alumni.NIM, &alumni.Nama, &alumni.Jurusan,
 		&alumni.Angkatan, &alumni.TahunLulus, &alumni.Email,
-		&alumni.NoTelepon, &alumni.Alamat, &alumni.RoleID,
+		&alumni.NoTelepon, &alumni.Alamat, &alumni.RoleID, &alumni.Version,
 		&alumni.CreatedAt, &alumni.UpdatedAt,
 	)
+	if err == sql.ErrNoRows {
+		return nil, apperror.VersionConflict()
+	}
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, apperror.FromDB(err))
 	}
 	
 	return alumni, nil
 }
 
-func DeleteAlumni(db *sql.DB, id int) error {
-	query := `DELETE FROM alumni WHERE id = $1`
-	_, err := db.Exec(query, id)
-	return err
+// DeleteAlumni menghapus alumni hanya jika version-nya masih sama, seperti
+// UpdateAlumni.
+// alumniPatchColumns adalah kolom yang boleh diubah PatchAlumni.
+var alumniPatchColumns = map[string]bool{"nama": true, "jurusan": true, "angkatan": true, "tahun_lulus": true, "email": true, "no_telepon": true, "alamat": true, "role_id": true}
+
+// PatchAlumni hanya mengubah kolom pada changes lalu menaikkan version,
+// dengan aturan version yang sama seperti UpdateAlumni.
+func PatchAlumni(ctx context.Context, db DBTX, id int, version int, changes map[string]interface{}) (*model.Alumni, error) {
+	ctx, span := tracing.StartPostgres(ctx, "PatchAlumni")
+	defer span.End()
+
+	set, args, err := setClause(changes, alumniPatchColumns)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	query := fmt.Sprintf(`
+		UPDATE alumni
+		SET %s, updated_at = $%d, version = version + 1
+		WHERE id = $%d AND version = $%d
+		RETURNING id, nim, nama, jurusan, angkatan, tahun_lulus, email,
+		          no_telepon, alamat, role_id, version, created_at, updated_at
+	`, set, len(args)+1, len(args)+2, len(args)+3)
+	args = append(args, time.Now(), id, version)
+
+	alumni := new(model.Alumni)
+	err = db.QueryRowContext(ctx, query, args...).Scan(
+		&alumni.ID, &alumni.NIM, &alumni.Nama, &alumni.Jurusan,
+		&alumni.Angkatan, &alumni.TahunLulus, &alumni.Email,
+		&alumni.NoTelepon, &alumni.Alamat, &alumni.RoleID, &alumni.Version,
+		&alumni.CreatedAt, &alumni.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, apperror.VersionConflict()
+	}
+	if err != nil {
+		return nil, tracing.Error(span, apperror.FromDB(err))
+	}
+
+	return alumni, nil
+}
+
+func DeleteAlumni(ctx context.Context, db DBTX, id int, version int) error {
+	ctx, span := tracing.StartPostgres(ctx, "DeleteAlumni")
+	defer span.End()
+
+	query := `DELETE FROM alumni WHERE id = $1 AND version = $2`
+	result, err := db.ExecContext(ctx, query, id, version)
+	if err != nil {
+		return tracing.Error(span, apperror.FromDB(err))
+	}
+	return versionedResult(result)
 }
 
-func CheckAlumniByNim(db *sql.DB, nim string) (*model.Alumni, error) {
+func CheckAlumniByNim(ctx context.Context, db *sql.DB, nim string) (*model.Alumni, error) {
+	ctx, span := tracing.StartPostgres(ctx, "CheckAlumniByNim")
+	defer span.End()
+
 	query := `
 		SELECT a.id, a.nim, a.nama, a.jurusan, a.angkatan, a.tahun_lulus, a.email, 
-		       a.no_telepon, a.alamat, a.role_id, a.created_at, a.updated_at,
+		       a.no_telepon, a.alamat, a.role_id, a.version, a.created_at, a.updated_at,
 		       r.id, r.nama, r.created_at, r.updated_at
 		FROM alumni a
 		LEFT JOIN roles r ON a.role_id = r.id
@@ -171,25 +248,28 @@ func CheckAlumniByNim(db *sql.DB, nim string) (*model.Alumni, error) {
 	
 	alumni := new(model.Alumni)
 	role := new(model.Role)
-	err := db.QueryRow(query, nim).Scan(
+	err := db.QueryRowContext(ctx, query, nim).Scan(
 		&alumni.ID, &alumni.NIM, &alumni.Nama, &alumni.Jurusan,
 		&alumni.Angkatan, &alumni.TahunLulus, &alumni.Email,
-		&alumni.NoTelepon, &alumni.Alamat, &alumni.RoleID,
+		&alumni.NoTelepon, &alumni.Alamat, &alumni.RoleID, &alumni.Version,
 		&alumni.CreatedAt, &alumni.UpdatedAt,
 		&role.ID, &role.Nama, &role.CreatedAt, &role.UpdatedAt,
 	)
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, err)
 	}
 	alumni.Role = role
 	
 	return alumni, nil
 }
 
-func GetAlumniByEmail(db *sql.DB, email string) (*model.Alumni, error) {
+func GetAlumniByEmail(ctx context.Context, db *sql.DB, email string) (*model.Alumni, error) {
+	ctx, span := tracing.StartPostgres(ctx, "GetAlumniByEmail")
+	defer span.End()
+
 	query := `
 		SELECT a.id, a.nim, a.nama, a.jurusan, a.angkatan, a.tahun_lulus, a.email, 
-		       a.password_hash, a.no_telepon, a.alamat, a.role_id, a.created_at, a.updated_at,
+		       a.password_hash, a.no_telepon, a.alamat, a.role_id, a.version, a.created_at, a.updated_at,
 		       r.id, r.nama, r.created_at, r.updated_at
 		FROM alumni a
 		LEFT JOIN roles r ON a.role_id = r.id
@@ -198,17 +278,168 @@ func GetAlumniByEmail(db *sql.DB, email string) (*model.Alumni, error) {
 	
 	alumni := new(model.Alumni)
 	role := new(model.Role)
-	err := db.QueryRow(query, email).Scan(
+	err := db.QueryRowContext(ctx, query, email).Scan(
 		&alumni.ID, &alumni.NIM, &alumni.Nama, &alumni.Jurusan,
 		&alumni.Angkatan, &alumni.TahunLulus, &alumni.Email,
-		&alumni.PasswordHash, &alumni.NoTelepon, &alumni.Alamat, &alumni.RoleID,
+		&alumni.PasswordHash, &alumni.NoTelepon, &alumni.Alamat, &alumni.RoleID, &alumni.Version,
 		&alumni.CreatedAt, &alumni.UpdatedAt,
 		&role.ID, &role.Nama, &role.CreatedAt, &role.UpdatedAt,
 	)
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, err)
 	}
 	alumni.Role = role
 	
 	return alumni, nil
 }
+
+// pekerjaanLateralJoin mengagregasi pekerjaan aktif setiap alumni menjadi satu
+// kolom JSON sehingga relasi bisa diambil dalam satu query tanpa N+1.
+const pekerjaanLateralJoin = `
+		LEFT JOIN LATERAL (
+			SELECT COALESCE(json_agg(json_build_object(
+				'id', p.id,
+				'alumni_id', p.alumni_id,
+				'nama_perusahaan', p.nama_perusahaan,
+				'posisi_jabatan', p.posisi_jabatan,
+				'bidang_industri', p.bidang_industri,
+				'lokasi_kerja', p.lokasi_kerja,
+				'gaji_range', p.gaji_range,
+				'gaji_min', p.gaji_min,
+				'gaji_max', p.gaji_max,
+				'gaji_currency', p.gaji_currency,
+				'gaji_period', p.gaji_period,
+				'tanggal_mulai_kerja', p.tanggal_mulai_kerja::timestamptz,
+				'tanggal_selesai_kerja', p.tanggal_selesai_kerja::timestamptz,
+				'status_pekerjaan', p.status_pekerjaan,
+				'deskripsi_pekerjaan', p.deskripsi_pekerjaan,
+				'is_delete', p.is_delete::timestamptz,
+				'version', p.version,
+				'created_at', p.created_at::timestamptz,
+				'updated_at', p.updated_at::timestamptz
+			) ORDER BY p.tanggal_mulai_kerja DESC), '[]'::json) AS items
+			FROM pekerjaan_alumni p
+			WHERE p.alumni_id = a.id AND p.is_delete IS NULL
+		) pk ON true`
+
+func alumniDetailQuery(includePekerjaan bool) (string, string) {
+	if includePekerjaan {
+		return "pk.items", pekerjaanLateralJoin
+	}
+	return "'[]'::json", ""
+}
+
+func scanAlumniDetail(scanner interface{ Scan(dest ...interface{}) error }) (*model.AlumniDetail, error) {
+	detail := new(model.AlumniDetail)
+	role := new(model.Role)
+	var pekerjaanJSON []byte
+	err := scanner.Scan(
+		&detail.ID, &detail.NIM, &detail.Nama, &detail.Jurusan,
+		&detail.Angkatan, &detail.TahunLulus, &detail.Email,
+		&detail.NoTelepon, &detail.Alamat, &detail.RoleID, &detail.Version,
+		&detail.CreatedAt, &detail.UpdatedAt,
+		&role.ID, &role.Nama, &role.CreatedAt, &role.UpdatedAt,
+		&pekerjaanJSON,
+	)
+	if err != nil {
+		return nil, err
+	}
+	detail.Role = role
+
+	detail.Pekerjaan = []model.PekerjaanAlumni{}
+	if err := json.Unmarshal(pekerjaanJSON, &detail.Pekerjaan); err != nil {
+		return nil, err
+	}
+
+	return detail, nil
+}
+
+func GetAlumniDetailByID(ctx context.Context, db *sql.DB, id int, includePekerjaan bool) (*model.AlumniDetail, error) {
+	ctx, span := tracing.StartPostgres(ctx, "GetAlumniDetailByID")
+	defer span.End()
+
+	pekerjaanColumn, pekerjaanJoin := alumniDetailQuery(includePekerjaan)
+	query := fmt.Sprintf(`
+		SELECT a.id, a.nim, a.nama, a.jurusan, a.angkatan, a.tahun_lulus, a.email, 
+		       a.no_telepon, a.alamat, a.role_id, a.version, a.created_at, a.updated_at,
+		       r.id, r.nama, r.created_at, r.updated_at,
+		       %s
+		FROM alumni a
+		LEFT JOIN roles r ON a.role_id = r.id
+		%s
+		WHERE a.id = $1
+	`, pekerjaanColumn, pekerjaanJoin)
+
+	return scanAlumniDetail(db.QueryRowContext(ctx, query, id))
+}
+
+func GetAllAlumniDetail(ctx context.Context, db *sql.DB, search, sortBy, order string, limit, offset int, includePekerjaan bool) ([]model.AlumniDetail, error) {
+	ctx, span := tracing.StartPostgres(ctx, "GetAllAlumniDetail")
+	defer span.End()
+
+	sortColumn := alumniSortColumns[sortBy]
+	if sortColumn == "" {
+		sortColumn = "a.id"
+	}
+
+	pekerjaanColumn, pekerjaanJoin := alumniDetailQuery(includePekerjaan)
+	query := fmt.Sprintf(`
+		SELECT a.id, a.nim, a.nama, a.jurusan, a.angkatan, a.tahun_lulus, a.email, 
+		       a.no_telepon, a.alamat, a.role_id, a.version, a.created_at, a.updated_at,
+		       r.id, r.nama, r.created_at, r.updated_at,
+		       %s
+		FROM alumni a
+		LEFT JOIN roles r ON a.role_id = r.id
+		%s
+		WHERE a.nama ILIKE $1 OR a.nim ILIKE $1 OR a.email ILIKE $1 OR a.jurusan ILIKE $1
+		ORDER BY %s %s
+		LIMIT $2 OFFSET $3
+	`, pekerjaanColumn, pekerjaanJoin, sortColumn, order)
+
+	rows, err := db.QueryContext(ctx, query, "%"+search+"%", limit, offset)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	defer rows.Close()
+
+	alumniList := []model.AlumniDetail{}
+	for rows.Next() {
+		detail, err := scanAlumniDetail(rows)
+		if err != nil {
+			return nil, tracing.Error(span, err)
+		}
+		alumniList = append(alumniList, *detail)
+	}
+
+	return alumniList, rows.Err()
+}
+
+// FindAlumniIDs mengembalikan id alumni yang cocok dengan filter batch,
+// terurut, paling banyak limit data.
+func FindAlumniIDs(ctx context.Context, db DBTX, filter model.AlumniBatchFilter, limit int) ([]int, error) {
+	ctx, span := tracing.StartPostgres(ctx, "FindAlumniIDs")
+	defer span.End()
+
+	conditions := []string{"TRUE"}
+	var args []interface{}
+	if filter.Jurusan != "" {
+		args = append(args, filter.Jurusan)
+		conditions = append(conditions, fmt.Sprintf("jurusan = $%d", len(args)))
+	}
+	if filter.Angkatan != 0 {
+		args = append(args, filter.Angkatan)
+		conditions = append(conditions, fmt.Sprintf("angkatan = $%d", len(args)))
+	}
+	if filter.TahunLulus != 0 {
+		args = append(args, filter.TahunLulus)
+		conditions = append(conditions, fmt.Sprintf("tahun_lulus = $%d", len(args)))
+	}
+	args = append(args, limit)
+	query := fmt.Sprintf(`SELECT id FROM alumni WHERE %s ORDER BY id LIMIT $%d`, strings.Join(conditions, " AND "), len(args))
+
+	ids, err := scanIDs(ctx, db, query, args...)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	return ids, nil
+}
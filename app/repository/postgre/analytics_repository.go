@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	model "go-fiber/app/model/postgre"
+	"go-fiber/apperror"
+	"go-fiber/tracing"
+	"strings"
+)
+
+var analyticsGroupColumns = map[string]string{
+	"angkatan":    "a.angkatan::text",
+	"tahun_lulus": "a.tahun_lulus::text",
+	"jurusan":     "a.jurusan",
+}
+
+var analyticsDimensionColumns = map[string]string{
+	"bidang_industri": "p.bidang_industri",
+	"lokasi_kerja":    "p.lokasi_kerja",
+}
+
+// analyticsGroupExpr mengembalikan ekspresi pengelompokan. Tanpa group_by
+// semua alumni masuk ke satu kelompok "semua".
+func analyticsGroupExpr(groupBy string) string {
+	if column, ok := analyticsGroupColumns[groupBy]; ok {
+		return column
+	}
+	return "'semua'"
+}
+
+// analyticsWhere menyusun kondisi filter alumni. Placeholder dimulai dari
+// nomor argumen berikutnya setelah args yang sudah ada.
+func analyticsWhere(filter model.AnalyticsFilter, args []interface{}) (string, []interface{}) {
+	conditions := []string{"TRUE"}
+	if filter.Angkatan != nil {
+		args = append(args, *filter.Angkatan)
+		conditions = append(conditions, fmt.Sprintf("a.angkatan = $%d", len(args)))
+	}
+	if filter.TahunLulus != nil {
+		args = append(args, *filter.TahunLulus)
+		conditions = append(conditions, fmt.Sprintf("a.tahun_lulus = $%d", len(args)))
+	}
+	if filter.Jurusan != "" {
+		args = append(args, filter.Jurusan)
+		conditions = append(conditions, fmt.Sprintf("a.jurusan ILIKE $%d", len(args)))
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+func GetEmploymentRate(ctx context.Context, db *sql.DB, filter model.AnalyticsFilter) ([]model.EmploymentRate, error) {
+	ctx, span := tracing.StartPostgres(ctx, "GetEmploymentRate")
+	defer span.End()
+
+	where, args := analyticsWhere(filter, nil)
+	query := fmt.Sprintf(`
+		SELECT %s AS grp,
+		       COUNT(*) AS total,
+		       COUNT(*) FILTER (WHERE EXISTS (
+		           SELECT 1 FROM pekerjaan_alumni p
+		           WHERE p.alumni_id = a.id AND p.status_pekerjaan = 'aktif' AND p.is_delete IS NULL
+		       )) AS employed
+		FROM alumni a
+		WHERE %s
+		GROUP BY grp
+		ORDER BY grp
+	`, analyticsGroupExpr(filter.GroupBy), where)
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	defer rows.Close()
+
+	result := []model.EmploymentRate{}
+	for rows.Next() {
+		var item model.EmploymentRate
+		if err := rows.Scan(&item.Group, &item.TotalAlumni, &item.EmployedAlumni); err != nil {
+			return nil, tracing.Error(span, err)
+		}
+		result = append(result, item)
+	}
+
+	return result, rows.Err()
+}
+
+// GetTimeToEmployment menghitung median jarak hari antara kelulusan dan
+// pekerjaan pertama. Tanggal lulus diasumsikan 1 Januari tahun_lulus karena
+// hanya tahun kelulusan yang disimpan.
+func GetTimeToEmployment(ctx context.Context, db *sql.DB, filter model.AnalyticsFilter) ([]model.TimeToEmployment, error) {
+	ctx, span := tracing.StartPostgres(ctx, "GetTimeToEmployment")
+	defer span.End()
+
+	where, args := analyticsWhere(filter, nil)
+	query := fmt.Sprintf(`
+		WITH first_job AS (
+			SELECT p.alumni_id, MIN(p.tanggal_mulai_kerja) AS mulai
+			FROM pekerjaan_alumni p
+			WHERE p.is_delete IS NULL
+			GROUP BY p.alumni_id
+		)
+		SELECT %s AS grp,
+		       COUNT(*) AS total,
+		       percentile_cont(0.5) WITHIN GROUP (ORDER BY f.mulai - make_date(a.tahun_lulus, 1, 1)) AS median_days
+		FROM alumni a
+		JOIN first_job f ON f.alumni_id = a.id
+		WHERE %s
+		GROUP BY grp
+		ORDER BY grp
+	`, analyticsGroupExpr(filter.GroupBy), where)
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	defer rows.Close()
+
+	result := []model.TimeToEmployment{}
+	for rows.Next() {
+		var item model.TimeToEmployment
+		if err := rows.Scan(&item.Group, &item.TotalAlumni, &item.MedianDays); err != nil {
+			return nil, tracing.Error(span, err)
+		}
+		result = append(result, item)
+	}
+
+	return result, rows.Err()
+}
+
+// GetEmploymentDistribution mengelompokkan pekerjaan berdasarkan dimension
+// (bidang_industri atau lokasi_kerja). Status kosong berarti semua status.
+func GetEmploymentDistribution(ctx context.Context, db *sql.DB, filter model.AnalyticsFilter, dimension, status string) ([]model.DistributionItem, error) {
+	ctx, span := tracing.StartPostgres(ctx, "GetEmploymentDistribution")
+	defer span.End()
+
+	dimensionColumn, ok := analyticsDimensionColumns[dimension]
+	if !ok {
+		return nil, apperror.Validation("analytics.unsupported_dimension", dimension)
+	}
+
+	where, args := analyticsWhere(filter, nil)
+	if status != "" {
+		args = append(args, status)
+		where += fmt.Sprintf(" AND p.status_pekerjaan = $%d", len(args))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s AS grp, %s AS category, COUNT(*) AS total
+		FROM pekerjaan_alumni p
+		JOIN alumni a ON a.id = p.alumni_id
+		WHERE p.is_delete IS NULL AND %s
+		GROUP BY grp, category
+		ORDER BY grp, total DESC, category
+	`, analyticsGroupExpr(filter.GroupBy), dimensionColumn, where)
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	defer rows.Close()
+
+	result := []model.DistributionItem{}
+	for rows.Next() {
+		var item model.DistributionItem
+		if err := rows.Scan(&item.Group, &item.Category, &item.Total); err != nil {
+			return nil, tracing.Error(span, err)
+		}
+		result = append(result, item)
+	}
+
+	return result, rows.Err()
+}
+
+// GetTopEmployers mengembalikan perusahaan dengan jumlah alumni terbanyak,
+// maksimal limit perusahaan untuk setiap kelompok.
+func GetTopEmployers(ctx context.Context, db *sql.DB, filter model.AnalyticsFilter, limit int) ([]model.TopEmployer, error) {
+	ctx, span := tracing.StartPostgres(ctx, "GetTopEmployers")
+	defer span.End()
+
+	where, args := analyticsWhere(filter, nil)
+	args = append(args, limit)
+	query := fmt.Sprintf(`
+		SELECT grp, nama_perusahaan, total
+		FROM (
+			SELECT %s AS grp, p.nama_perusahaan,
+			       COUNT(DISTINCT p.alumni_id) AS total,
+			       ROW_NUMBER() OVER (
+			           PARTITION BY %s
+			           ORDER BY COUNT(DISTINCT p.alumni_id) DESC, p.nama_perusahaan
+			       ) AS peringkat
+			FROM pekerjaan_alumni p
+			JOIN alumni a ON a.id = p.alumni_id
+			WHERE p.is_delete IS NULL AND %s
+			GROUP BY grp, p.nama_perusahaan
+		) ranked
+		WHERE peringkat <= $%d
+		ORDER BY grp, total DESC, nama_perusahaan
+	`, analyticsGroupExpr(filter.GroupBy), analyticsGroupExpr(filter.GroupBy), where, len(args))
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	defer rows.Close()
+
+	result := []model.TopEmployer{}
+	for rows.Next() {
+		var item model.TopEmployer
+		if err := rows.Scan(&item.Group, &item.NamaPerusahaan, &item.TotalAlumni); err != nil {
+			return nil, tracing.Error(span, err)
+		}
+		result = append(result, item)
+	}
+
+	return result, rows.Err()
+}
+
+// GetSalaryStatistics menghitung persentil gaji bulanan (titik tengah
+// gaji_min dan gaji_max) per kelompok. TotalSampel dihitung per alumni agar
+// service dapat menyembunyikan kelompok yang terlalu kecil.
+func GetSalaryStatistics(ctx context.Context, db *sql.DB, filter model.AnalyticsFilter, currency string) ([]model.SalaryStatistic, error) {
+	ctx, span := tracing.StartPostgres(ctx, "GetSalaryStatistics")
+	defer span.End()
+
+	groupExpr := analyticsGroupExpr(filter.GroupBy)
+	if filter.GroupBy == "bidang_industri" {
+		groupExpr = "p.bidang_industri"
+	}
+
+	where, args := analyticsWhere(filter, nil)
+	args = append(args, currency)
+	query := fmt.Sprintf(`
+		WITH gaji AS (
+			SELECT %s AS grp, p.alumni_id,
+			       ((p.gaji_min + p.gaji_max) / 2.0) / CASE WHEN p.gaji_period = 'tahunan' THEN 12 ELSE 1 END AS bulanan
+			FROM pekerjaan_alumni p
+			JOIN alumni a ON a.id = p.alumni_id
+			WHERE p.is_delete IS NULL AND p.gaji_min IS NOT NULL AND %s AND p.gaji_currency = $%d
+		)
+		SELECT grp,
+		       COUNT(DISTINCT alumni_id) AS total,
+		       percentile_cont(0.25) WITHIN GROUP (ORDER BY bulanan) AS p25,
+		       percentile_cont(0.5) WITHIN GROUP (ORDER BY bulanan) AS median,
+		       percentile_cont(0.75) WITHIN GROUP (ORDER BY bulanan) AS p75
+		FROM gaji
+		GROUP BY grp
+		ORDER BY grp
+	`, groupExpr, where, len(args))
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	defer rows.Close()
+
+	result := []model.SalaryStatistic{}
+	for rows.Next() {
+		item := model.SalaryStatistic{Currency: currency}
+		if err := rows.Scan(&item.Group, &item.TotalSampel, &item.P25, &item.Median, &item.P75); err != nil {
+			return nil, tracing.Error(span, err)
+		}
+		result = append(result, item)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"go-fiber/apperror"
+	"sort"
+	"strings"
+)
+
+// DBTX dipenuhi oleh *sql.DB dan *sql.Tx sehingga fungsi repository yang
+// menerimanya bisa dipakai di dalam maupun di luar transaksi.
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// versionedResult mengubah result UPDATE/DELETE yang dibatasi version menjadi
+// apperror.VersionConflict jika tidak ada baris yang terkena.
+func versionedResult(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return apperror.VersionConflict()
+	}
+	return nil
+}
+
+// setClause menyusun isi SET untuk UPDATE parsial dari changes, terurut
+// berdasarkan nama kolom dengan placeholder mulai dari $1. Kolom di luar
+// allowed ditolak agar nama kolom tidak pernah berasal dari input client.
+func setClause(changes map[string]interface{}, allowed map[string]bool) (string, []interface{}, error) {
+	columns := make([]string, 0, len(changes))
+	for column := range changes {
+		if !allowed[column] {
+			return "", nil, fmt.Errorf("kolom %q tidak bisa diubah", column)
+		}
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	assignments := make([]string, len(columns))
+	args := make([]interface{}, len(columns))
+	for i, column := range columns {
+		assignments[i] = fmt.Sprintf("%s = $%d", column, i+1)
+		args[i] = changes[column]
+	}
+	return strings.Join(assignments, ", "), args, nil
+}
+
+// scanIDs menjalankan query yang hanya memilih kolom id.
+func scanIDs(ctx context.Context, db DBTX, query string, args ...interface{}) ([]int, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
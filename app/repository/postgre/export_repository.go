@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	model "go-fiber/app/model/postgre"
+	"go-fiber/tracing"
+)
+
+// StreamAlumni membaca seluruh alumni yang cocok dengan search dan memanggil
+// fn untuk setiap baris tanpa menampung hasilnya di memori.
+func StreamAlumni(ctx context.Context, db *sql.DB, search, sortBy, order string, fn func(model.Alumni) error) error {
+	ctx, span := tracing.StartPostgres(ctx, "StreamAlumni")
+	defer span.End()
+
+	sortColumn := alumniSortColumns[sortBy]
+	if sortColumn == "" {
+		sortColumn = "a.id"
+	}
+
+	query := fmt.Sprintf(`
+		SELECT a.id, a.nim, a.nama, a.jurusan, a.angkatan, a.tahun_lulus, a.email,
+		       a.no_telepon, a.alamat, a.role_id, a.created_at, a.updated_at
+		FROM alumni a
+		WHERE a.nama ILIKE $1 OR a.nim ILIKE $1 OR a.email ILIKE $1 OR a.jurusan ILIKE $1
+		ORDER BY %s %s
+	`, sortColumn, order)
+
+	rows, err := db.QueryContext(ctx, query, "%"+search+"%")
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var alumni model.Alumni
+		err := rows.Scan(
+			&alumni.ID, &alumni.NIM, &alumni.Nama, &alumni.Jurusan,
+			&alumni.Angkatan, &alumni.TahunLulus, &alumni.Email,
+			&alumni.NoTelepon, &alumni.Alamat, &alumni.RoleID,
+			&alumni.CreatedAt, &alumni.UpdatedAt,
+		)
+		if err != nil {
+			return tracing.Error(span, err)
+		}
+		if err := fn(alumni); err != nil {
+			return tracing.Error(span, err)
+		}
+	}
+
+	return rows.Err()
+}
+
+// StreamPekerjaanAlumni membaca seluruh pekerjaan alumni yang belum dihapus
+// dan memanggil fn untuk setiap baris.
+func StreamPekerjaanAlumni(ctx context.Context, db *sql.DB, search, sortBy, order string, fn func(model.PekerjaanAlumni) error) error {
+	ctx, span := tracing.StartPostgres(ctx, "StreamPekerjaanAlumni")
+	defer span.End()
+
+	query := fmt.Sprintf(`
+		SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri,
+		       lokasi_kerja, gaji_range, gaji_min, gaji_max, gaji_currency, gaji_period,
+		       tanggal_mulai_kerja, tanggal_selesai_kerja,
+		       status_pekerjaan, deskripsi_pekerjaan, is_delete, created_at, updated_at
+		FROM pekerjaan_alumni
+		WHERE (nama_perusahaan ILIKE $1 OR posisi_jabatan ILIKE $1 OR bidang_industri ILIKE $1 OR lokasi_kerja ILIKE $1)
+		AND is_delete IS NULL
+		ORDER BY %s %s
+	`, sortBy, order)
+
+	rows, err := db.QueryContext(ctx, query, "%"+search+"%")
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var pekerjaan model.PekerjaanAlumni
+		err := rows.Scan(
+			&pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan,
+			&pekerjaan.PosisiJabatan, &pekerjaan.BidangIndustri, &pekerjaan.LokasiKerja,
+			&pekerjaan.GajiRange, &pekerjaan.GajiMin, &pekerjaan.GajiMax, &pekerjaan.GajiCurrency, &pekerjaan.GajiPeriod,
+			&pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
+			&pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan, &pekerjaan.IsDelete,
+			&pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
+		)
+		if err != nil {
+			return tracing.Error(span, err)
+		}
+		if err := fn(pekerjaan); err != nil {
+			return tracing.Error(span, err)
+		}
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"go-fiber/tracing"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// FindExistingAlumniKeys mengembalikan NIM dan email (huruf kecil) yang sudah
+// terdaftar dari daftar yang diberikan.
+func FindExistingAlumniKeys(ctx context.Context, db DBTX, nims, emails []string) (map[string]bool, map[string]bool, error) {
+	ctx, span := tracing.StartPostgres(ctx, "FindExistingAlumniKeys")
+	defer span.End()
+
+	lowerEmails := make([]string, len(emails))
+	for i, email := range emails {
+		lowerEmails[i] = strings.ToLower(email)
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT nim, LOWER(email) FROM alumni
+		WHERE nim = ANY($1) OR LOWER(email) = ANY($2)
+	`, pq.Array(nims), pq.Array(lowerEmails))
+	if err != nil {
+		return nil, nil, tracing.Error(span, err)
+	}
+	defer rows.Close()
+
+	existingNIM := map[string]bool{}
+	existingEmail := map[string]bool{}
+	for rows.Next() {
+		var nim, email string
+		if err := rows.Scan(&nim, &email); err != nil {
+			return nil, nil, tracing.Error(span, err)
+		}
+		existingNIM[nim] = true
+		existingEmail[email] = true
+	}
+
+	return existingNIM, existingEmail, rows.Err()
+}
+
+// FindAlumniIDsByNIM memetakan NIM ke ID alumni untuk NIM yang ditemukan.
+func FindAlumniIDsByNIM(ctx context.Context, db DBTX, nims []string) (map[string]int, error) {
+	ctx, span := tracing.StartPostgres(ctx, "FindAlumniIDsByNIM")
+	defer span.End()
+
+	rows, err := db.QueryContext(ctx, `SELECT id, nim FROM alumni WHERE nim = ANY($1)`, pq.Array(nims))
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	defer rows.Close()
+
+	ids := map[string]int{}
+	for rows.Next() {
+		var id int
+		var nim string
+		if err := rows.Scan(&id, &nim); err != nil {
+			return nil, tracing.Error(span, err)
+		}
+		ids[nim] = id
+	}
+
+	return ids, rows.Err()
+}
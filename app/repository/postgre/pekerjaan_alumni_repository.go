@@ -1,17 +1,25 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	model "go-fiber/app/model/postgre"
+	"go-fiber/apperror"
+	"go-fiber/tracing"
+	"strings"
 	"time"
 )
 
-func GetAllPekerjaanAlumni(db *sql.DB, search, sortBy, order string, limit, offset int) ([]model.PekerjaanAlumni, error) {
+func GetAllPekerjaanAlumni(ctx context.Context, db *sql.DB, search, sortBy, order string, limit, offset int) ([]model.PekerjaanAlumni, error) {
+	ctx, span := tracing.StartPostgres(ctx, "GetAllPekerjaanAlumni")
+	defer span.End()
+
 	query := fmt.Sprintf(`
 		SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri,
-		       lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja,
-		       status_pekerjaan, deskripsi_pekerjaan, is_delete, created_at, updated_at
+		       lokasi_kerja, gaji_range, gaji_min, gaji_max, gaji_currency, gaji_period,
+		       tanggal_mulai_kerja, tanggal_selesai_kerja,
+		       status_pekerjaan, deskripsi_pekerjaan, is_delete, version, created_at, updated_at
 		FROM pekerjaan_alumni 
 		WHERE (nama_perusahaan ILIKE $1 OR posisi_jabatan ILIKE $1 OR bidang_industri ILIKE $1 OR lokasi_kerja ILIKE $1)
 		AND is_delete IS NULL
@@ -19,9 +27,9 @@ func GetAllPekerjaanAlumni(db *sql.DB, search, sortBy, order string, limit, offs
 		LIMIT $2 OFFSET $3
 	`, sortBy, order)
 	
-	rows, err := db.Query(query, "%"+search+"%", limit, offset)
+	rows, err := db.QueryContext(ctx, query, "%"+search+"%", limit, offset)
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, err)
 	}
 	defer rows.Close()
 
@@ -31,12 +39,13 @@ func GetAllPekerjaanAlumni(db *sql.DB, search, sortBy, order string, limit, offs
 		err := rows.Scan(
 			&pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan,
 			&pekerjaan.PosisiJabatan, &pekerjaan.BidangIndustri, &pekerjaan.LokasiKerja,
-			&pekerjaan.GajiRange, &pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
-			&pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan, &pekerjaan.IsDelete,
+			&pekerjaan.GajiRange, &pekerjaan.GajiMin, &pekerjaan.GajiMax, &pekerjaan.GajiCurrency, &pekerjaan.GajiPeriod,
+			&pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
+			&pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan, &pekerjaan.IsDelete, &pekerjaan.Version,
 			&pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
 		)
 		if err != nil {
-			return nil, err
+			return nil, tracing.Error(span, err)
 		}
 		pekerjaanList = append(pekerjaanList, pekerjaan)
 	}
@@ -44,53 +53,65 @@ func GetAllPekerjaanAlumni(db *sql.DB, search, sortBy, order string, limit, offs
 	return pekerjaanList, nil
 }
 
-func CountPekerjaanAlumni(db *sql.DB, search string) (int, error) {
+func CountPekerjaanAlumni(ctx context.Context, db *sql.DB, search string) (int, error) {
+	ctx, span := tracing.StartPostgres(ctx, "CountPekerjaanAlumni")
+	defer span.End()
+
 	var total int
 	countQuery := `SELECT COUNT(*) FROM pekerjaan_alumni WHERE (nama_perusahaan ILIKE $1 OR posisi_jabatan ILIKE $1 OR bidang_industri ILIKE $1 OR lokasi_kerja ILIKE $1) AND is_delete IS NULL`
-	err := db.QueryRow(countQuery, "%"+search+"%").Scan(&total)
+	err := db.QueryRowContext(ctx, countQuery, "%"+search+"%").Scan(&total)
 	if err != nil && err != sql.ErrNoRows {
-		return 0, err
+		return 0, tracing.Error(span, err)
 	}
 	return total, nil
 }
 
-func GetPekerjaanAlumniByID(db *sql.DB, id int) (*model.PekerjaanAlumni, error) {
+func GetPekerjaanAlumniByID(ctx context.Context, db *sql.DB, id int) (*model.PekerjaanAlumni, error) {
+	ctx, span := tracing.StartPostgres(ctx, "GetPekerjaanAlumniByID")
+	defer span.End()
+
 	query := `
 		SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri,
-		       lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja,
-		       status_pekerjaan, deskripsi_pekerjaan, is_delete, created_at, updated_at
+		       lokasi_kerja, gaji_range, gaji_min, gaji_max, gaji_currency, gaji_period,
+		       tanggal_mulai_kerja, tanggal_selesai_kerja,
+		       status_pekerjaan, deskripsi_pekerjaan, is_delete, version, created_at, updated_at
 		FROM pekerjaan_alumni 
 		WHERE id = $1 AND is_delete IS NULL
 	`
 	
 	pekerjaan := new(model.PekerjaanAlumni)
-	err := db.QueryRow(query, id).Scan(
+	err := db.QueryRowContext(ctx, query, id).Scan(
 		&pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan,
 		&pekerjaan.PosisiJabatan, &pekerjaan.BidangIndustri, &pekerjaan.LokasiKerja,
-		&pekerjaan.GajiRange, &pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
-		&pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan, &pekerjaan.IsDelete,
+		&pekerjaan.GajiRange, &pekerjaan.GajiMin, &pekerjaan.GajiMax, &pekerjaan.GajiCurrency, &pekerjaan.GajiPeriod,
+		&pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
+		&pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan, &pekerjaan.IsDelete, &pekerjaan.Version,
 		&pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
 	)
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, err)
 	}
 	
 	return pekerjaan, nil
 }
 
-func GetPekerjaanAlumniByAlumniID(db *sql.DB, alumniID int) ([]model.PekerjaanAlumni, error) {
+func GetPekerjaanAlumniByAlumniID(ctx context.Context, db *sql.DB, alumniID int) ([]model.PekerjaanAlumni, error) {
+	ctx, span := tracing.StartPostgres(ctx, "GetPekerjaanAlumniByAlumniID")
+	defer span.End()
+
 	query := `
 		SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri,
-		       lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja,
-		       status_pekerjaan, deskripsi_pekerjaan, is_delete, created_at, updated_at
+		       lokasi_kerja, gaji_range, gaji_min, gaji_max, gaji_currency, gaji_period,
+		       tanggal_mulai_kerja, tanggal_selesai_kerja,
+		       status_pekerjaan, deskripsi_pekerjaan, is_delete, version, created_at, updated_at
 		FROM pekerjaan_alumni 
 		WHERE alumni_id = $1 AND is_delete IS NULL
 		ORDER BY tanggal_mulai_kerja DESC
 	`
 	
-	rows, err := db.Query(query, alumniID)
+	rows, err := db.QueryContext(ctx, query, alumniID)
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, err)
 	}
 	defer rows.Close()
 
@@ -100,12 +121,13 @@ func GetPekerjaanAlumniByAlumniID(db *sql.DB, alumniID int) ([]model.PekerjaanAl
 		err := rows.Scan(
 			&pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan,
 			&pekerjaan.PosisiJabatan, &pekerjaan.BidangIndustri, &pekerjaan.LokasiKerja,
-			&pekerjaan.GajiRange, &pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
-			&pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan, &pekerjaan.IsDelete,
+			&pekerjaan.GajiRange, &pekerjaan.GajiMin, &pekerjaan.GajiMax, &pekerjaan.GajiCurrency, &pekerjaan.GajiPeriod,
+			&pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
+			&pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan, &pekerjaan.IsDelete, &pekerjaan.Version,
 			&pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
 		)
 		if err != nil {
-			return nil, err
+			return nil, tracing.Error(span, err)
 		}
 		pekerjaanList = append(pekerjaanList, pekerjaan)
 	}
@@ -113,125 +135,272 @@ func GetPekerjaanAlumniByAlumniID(db *sql.DB, alumniID int) ([]model.PekerjaanAl
 	return pekerjaanList, nil
 }
 
-func CreatePekerjaanAlumni(db *sql.DB, req model.CreatePekerjaanAlumniRepositoryRequest) (*model.PekerjaanAlumni, error) {
+func CreatePekerjaanAlumni(ctx context.Context, db DBTX, req model.CreatePekerjaanAlumniRepositoryRequest) (*model.PekerjaanAlumni, error) {
+	ctx, span := tracing.StartPostgres(ctx, "CreatePekerjaanAlumni")
+	defer span.End()
+
 	query := `
 		INSERT INTO pekerjaan_alumni (alumni_id, nama_perusahaan, posisi_jabatan, 
 		                             bidang_industri, lokasi_kerja, gaji_range,
+		                             gaji_min, gaji_max, gaji_currency, gaji_period,
 		                             tanggal_mulai_kerja, tanggal_selesai_kerja,
 		                             status_pekerjaan, deskripsi_pekerjaan, is_delete, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
 		RETURNING id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri,
-		          lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja,
-		          status_pekerjaan, deskripsi_pekerjaan, is_delete, created_at, updated_at
+		          lokasi_kerja, gaji_range, gaji_min, gaji_max, gaji_currency, gaji_period,
+		          tanggal_mulai_kerja, tanggal_selesai_kerja,
+		          status_pekerjaan, deskripsi_pekerjaan, is_delete, version, created_at, updated_at
 	`
 	
 	now := time.Now()
 	pekerjaan := new(model.PekerjaanAlumni)
-	err := db.QueryRow(query,
+	err := db.QueryRowContext(ctx, query,
 		req.AlumniID, req.NamaPerusahaan, req.PosisiJabatan, req.BidangIndustri,
-		req.LokasiKerja, req.GajiRange, req.TanggalMulaiKerja, req.TanggalSelesaiKerja,
+		req.LokasiKerja, req.GajiRange, req.GajiMin, req.GajiMax, req.GajiCurrency, req.GajiPeriod,
+		req.TanggalMulaiKerja, req.TanggalSelesaiKerja,
 		req.StatusPekerjaan, req.DeskripsiPekerjaan, nil, now, now,
 	).Scan(
 		&pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan,
 		&pekerjaan.PosisiJabatan, &pekerjaan.BidangIndustri, &pekerjaan.LokasiKerja,
-		&pekerjaan.GajiRange, &pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
-		&pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan, &pekerjaan.IsDelete,
+		&pekerjaan.GajiRange, &pekerjaan.GajiMin, &pekerjaan.GajiMax, &pekerjaan.GajiCurrency, &pekerjaan.GajiPeriod,
+		&pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
+		&pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan, &pekerjaan.IsDelete, &pekerjaan.Version,
 		&pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
 	)
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, apperror.FromDB(err))
 	}
 	
 	return pekerjaan, nil
 }
 
-func UpdatePekerjaanAlumni(db *sql.DB, id int, req model.UpdatePekerjaanAlumniRepositoryRequest) (*model.PekerjaanAlumni, error) {
+// UpdatePekerjaanAlumni hanya mengubah pekerjaan aktif yang version-nya masih
+// sama dengan version, lalu menaikkan version. Jika tidak ada baris yang
+// cocok, hasilnya apperror.VersionConflict; cek soft delete sebelum memanggil.
+func UpdatePekerjaanAlumni(ctx context.Context, db DBTX, id int, version int, req model.UpdatePekerjaanAlumniRepositoryRequest) (*model.PekerjaanAlumni, error) {
+	ctx, span := tracing.StartPostgres(ctx, "UpdatePekerjaanAlumni")
+	defer span.End()
+
 	query := `
 		UPDATE pekerjaan_alumni 
 		SET nama_perusahaan = $1, posisi_jabatan = $2, bidang_industri = $3,
-		    lokasi_kerja = $4, gaji_range = $5, tanggal_mulai_kerja = $6,
-		    tanggal_selesai_kerja = $7, status_pekerjaan = $8, deskripsi_pekerjaan = $9,
-		    updated_at = $10
-		WHERE id = $11 AND is_delete IS NULL
+		    lokasi_kerja = $4, gaji_range = $5, gaji_min = $6, gaji_max = $7,
+		    gaji_currency = $8, gaji_period = $9, tanggal_mulai_kerja = $10,
+		    tanggal_selesai_kerja = $11, status_pekerjaan = $12, deskripsi_pekerjaan = $13,
+		    updated_at = $14, version = version + 1
+		WHERE id = $15 AND is_delete IS NULL AND version = $16
 		RETURNING id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri,
-		          lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja,
-		          status_pekerjaan, deskripsi_pekerjaan, is_delete, created_at, updated_at
+		          lokasi_kerja, gaji_range, gaji_min, gaji_max, gaji_currency, gaji_period,
+		          tanggal_mulai_kerja, tanggal_selesai_kerja,
+		          status_pekerjaan, deskripsi_pekerjaan, is_delete, version, created_at, updated_at
 	`
 	
 	pekerjaan := new(model.PekerjaanAlumni)
-	err := db.QueryRow(query,
+	err := db.QueryRowContext(ctx, query,
 		req.NamaPerusahaan, req.PosisiJabatan, req.BidangIndustri, req.LokasiKerja,
-		req.GajiRange, req.TanggalMulaiKerja, req.TanggalSelesaiKerja,
-		req.StatusPekerjaan, req.DeskripsiPekerjaan, time.Now(), id,
+		req.GajiRange, req.GajiMin, req.GajiMax, req.GajiCurrency, req.GajiPeriod,
+		req.TanggalMulaiKerja, req.TanggalSelesaiKerja,
+		req.StatusPekerjaan, req.DeskripsiPekerjaan, time.Now(), id, version,
 	).Scan(
 		&pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan,
 		&pekerjaan.PosisiJabatan, &pekerjaan.BidangIndustri, &pekerjaan.LokasiKerja,
-		&pekerjaan.GajiRange, &pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
-		&pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan, &pekerjaan.IsDelete,
+		&pekerjaan.GajiRange, &pekerjaan.GajiMin, &pekerjaan.GajiMax, &pekerjaan.GajiCurrency, &pekerjaan.GajiPeriod,
+		&pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
+		&pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan, &pekerjaan.IsDelete, &pekerjaan.Version,
 		&pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
 	)
+	if err == sql.ErrNoRows {
+		return nil, apperror.VersionConflict()
+	}
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, apperror.FromDB(err))
 	}
 	
 	return pekerjaan, nil
 }
 
-func SoftDeletePekerjaanAlumni(db *sql.DB, id int) error {
-	query := `UPDATE pekerjaan_alumni SET is_delete = $1 WHERE id = $2`
-	_, err := db.Exec(query, time.Now(), id)
-	return err
+// pekerjaanPatchColumns adalah kolom yang boleh diubah PatchPekerjaanAlumni.
+var pekerjaanPatchColumns = map[string]bool{
+	"nama_perusahaan": true, "posisi_jabatan": true, "bidang_industri": true, "lokasi_kerja": true,
+	"gaji_range": true, "gaji_min": true, "gaji_max": true, "gaji_currency": true, "gaji_period": true,
+	"tanggal_mulai_kerja": true, "tanggal_selesai_kerja": true, "status_pekerjaan": true, "deskripsi_pekerjaan": true,
+}
+
+// PatchPekerjaanAlumni hanya mengubah kolom pada changes lalu menaikkan
+// version, dengan aturan yang sama seperti UpdatePekerjaanAlumni.
+func PatchPekerjaanAlumni(ctx context.Context, db DBTX, id int, version int, changes map[string]interface{}) (*model.PekerjaanAlumni, error) {
+	ctx, span := tracing.StartPostgres(ctx, "PatchPekerjaanAlumni")
+	defer span.End()
+
+	set, args, err := setClause(changes, pekerjaanPatchColumns)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	query := fmt.Sprintf(`
+		UPDATE pekerjaan_alumni
+		SET %s, updated_at = $%d, version = version + 1
+		WHERE id = $%d AND is_delete IS NULL AND version = $%d
+		RETURNING id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri,
+		          lokasi_kerja, gaji_range, gaji_min, gaji_max, gaji_currency, gaji_period,
+		          tanggal_mulai_kerja, tanggal_selesai_kerja,
+		          status_pekerjaan, deskripsi_pekerjaan, is_delete, version, created_at, updated_at
+	`, set, len(args)+1, len(args)+2, len(args)+3)
+	args = append(args, time.Now(), id, version)
+
+	pekerjaan := new(model.PekerjaanAlumni)
+	err = db.QueryRowContext(ctx, query, args...).Scan(
+		&pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan,
+		&pekerjaan.PosisiJabatan, &pekerjaan.BidangIndustri, &pekerjaan.LokasiKerja,
+		&pekerjaan.GajiRange, &pekerjaan.GajiMin, &pekerjaan.GajiMax, &pekerjaan.GajiCurrency, &pekerjaan.GajiPeriod,
+		&pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
+		&pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan, &pekerjaan.IsDelete, &pekerjaan.Version,
+		&pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, apperror.VersionConflict()
+	}
+	if err != nil {
+		return nil, tracing.Error(span, apperror.FromDB(err))
+	}
+
+	return pekerjaan, nil
+}
+
+func SoftDeletePekerjaanAlumni(ctx context.Context, db DBTX, id int, version int) error {
+	ctx, span := tracing.StartPostgres(ctx, "SoftDeletePekerjaanAlumni")
+	defer span.End()
+
+	query := `UPDATE pekerjaan_alumni SET is_delete = $1, version = version + 1 WHERE id = $2 AND version = $3`
+	result, err := db.ExecContext(ctx, query, time.Now(), id, version)
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+	return versionedResult(result)
 }
 
-func SoftDeletePekerjaanAlumniByAlumniID(db *sql.DB, id int, alumniID int) error {
-	query := `UPDATE pekerjaan_alumni SET is_delete = $1 WHERE id = $2 AND alumni_id = $3`
-	_, err := db.Exec(query, time.Now(), id, alumniID)
-	return err
+func SoftDeletePekerjaanAlumniByAlumniID(ctx context.Context, db DBTX, id int, alumniID int, version int) error {
+	ctx, span := tracing.StartPostgres(ctx, "SoftDeletePekerjaanAlumniByAlumniID")
+	defer span.End()
+
+	query := `UPDATE pekerjaan_alumni SET is_delete = $1, version = version + 1 WHERE id = $2 AND alumni_id = $3 AND version = $4`
+	result, err := db.ExecContext(ctx, query, time.Now(), id, alumniID, version)
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+	return versionedResult(result)
 }
 
-func GetPekerjaanAlumniByIDWithDeleted(db *sql.DB, id int) (*model.PekerjaanAlumni, error) {
+func GetPekerjaanAlumniByIDWithDeleted(ctx context.Context, db DBTX, id int) (*model.PekerjaanAlumni, error) {
+	ctx, span := tracing.StartPostgres(ctx, "GetPekerjaanAlumniByIDWithDeleted")
+	defer span.End()
+
 	query := `
 		SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri,
-		       lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja,
-		       status_pekerjaan, deskripsi_pekerjaan, is_delete, created_at, updated_at
+		       lokasi_kerja, gaji_range, gaji_min, gaji_max, gaji_currency, gaji_period,
+		       tanggal_mulai_kerja, tanggal_selesai_kerja,
+		       status_pekerjaan, deskripsi_pekerjaan, is_delete, version, created_at, updated_at
 		FROM pekerjaan_alumni 
 		WHERE id = $1
 	`
 	
 	pekerjaan := new(model.PekerjaanAlumni)
-	err := db.QueryRow(query, id).Scan(
+	err := db.QueryRowContext(ctx, query, id).Scan(
 		&pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan,
 		&pekerjaan.PosisiJabatan, &pekerjaan.BidangIndustri, &pekerjaan.LokasiKerja,
-		&pekerjaan.GajiRange, &pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
-		&pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan, &pekerjaan.IsDelete,
+		&pekerjaan.GajiRange, &pekerjaan.GajiMin, &pekerjaan.GajiMax, &pekerjaan.GajiCurrency, &pekerjaan.GajiPeriod,
+		&pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
+		&pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan, &pekerjaan.IsDelete, &pekerjaan.Version,
 		&pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
 	)
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, err)
 	}
 	
 	return pekerjaan, nil
 }
 
-func HardDeletePekerjaanAlumni(db *sql.DB, id int) error {
-	query := `DELETE FROM pekerjaan_alumni WHERE id = $1`
-	_, err := db.Exec(query, id)
-	return err
+// HardDeletePekerjaanAlumni menghapus pekerjaan hanya jika version-nya masih
+// sama, seperti UpdatePekerjaanAlumni.
+func HardDeletePekerjaanAlumni(ctx context.Context, db DBTX, id int, version int) error {
+	ctx, span := tracing.StartPostgres(ctx, "HardDeletePekerjaanAlumni")
+	defer span.End()
+
+	query := `DELETE FROM pekerjaan_alumni WHERE id = $1 AND version = $2`
+	result, err := db.ExecContext(ctx, query, id, version)
+	if err != nil {
+		return tracing.Error(span, apperror.FromDB(err))
+	}
+	return versionedResult(result)
 }
 
-func GetSoftDeletedPekerjaanAlumni(db *sql.DB, alumniID int) ([]model.PekerjaanAlumni, error) {
+// PurgeSoftDeletedPekerjaanAlumni menghapus permanen paling banyak limit
+// pekerjaan yang di-soft delete sebelum before dan mengembalikan data yang
+// dihapus. Baris yang sedang dikunci transaksi lain dilewati.
+func PurgeSoftDeletedPekerjaanAlumni(ctx context.Context, db DBTX, before time.Time, limit int) ([]model.PekerjaanAlumni, error) {
+	ctx, span := tracing.StartPostgres(ctx, "PurgeSoftDeletedPekerjaanAlumni")
+	defer span.End()
+
+	query := `
+		DELETE FROM pekerjaan_alumni
+		WHERE id IN (
+			SELECT id FROM pekerjaan_alumni
+			WHERE is_delete IS NOT NULL AND is_delete < $1
+			ORDER BY id
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri,
+		          lokasi_kerja, gaji_range, gaji_min, gaji_max, gaji_currency, gaji_period,
+		          tanggal_mulai_kerja, tanggal_selesai_kerja,
+		          status_pekerjaan, deskripsi_pekerjaan, is_delete, version, created_at, updated_at
+	`
+
+	rows, err := db.QueryContext(ctx, query, before, limit)
+	if err != nil {
+		return nil, tracing.Error(span, apperror.FromDB(err))
+	}
+	defer rows.Close()
+
+	var pekerjaanList []model.PekerjaanAlumni
+	for rows.Next() {
+		var pekerjaan model.PekerjaanAlumni
+		err := rows.Scan(
+			&pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan,
+			&pekerjaan.PosisiJabatan, &pekerjaan.BidangIndustri, &pekerjaan.LokasiKerja,
+			&pekerjaan.GajiRange, &pekerjaan.GajiMin, &pekerjaan.GajiMax, &pekerjaan.GajiCurrency, &pekerjaan.GajiPeriod,
+			&pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
+			&pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan, &pekerjaan.IsDelete, &pekerjaan.Version,
+			&pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
+		)
+		if err != nil {
+			return nil, tracing.Error(span, err)
+		}
+		pekerjaanList = append(pekerjaanList, pekerjaan)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	return pekerjaanList, nil
+}
+
+func GetSoftDeletedPekerjaanAlumni(ctx context.Context, db *sql.DB, alumniID int) ([]model.PekerjaanAlumni, error) {
+	ctx, span := tracing.StartPostgres(ctx, "GetSoftDeletedPekerjaanAlumni")
+	defer span.End()
+
 	query := `
 		SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri,
-		       lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja,
-		       status_pekerjaan, deskripsi_pekerjaan, is_delete, created_at, updated_at
+		       lokasi_kerja, gaji_range, gaji_min, gaji_max, gaji_currency, gaji_period,
+		       tanggal_mulai_kerja, tanggal_selesai_kerja,
+		       status_pekerjaan, deskripsi_pekerjaan, is_delete, version, created_at, updated_at
 		FROM pekerjaan_alumni 
 		WHERE alumni_id = $1 AND is_delete IS NOT NULL
 		ORDER BY is_delete DESC
 	`
 	
-	rows, err := db.Query(query, alumniID)
+	rows, err := db.QueryContext(ctx, query, alumniID)
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, err)
 	}
 	defer rows.Close()
 
@@ -241,12 +410,13 @@ func GetSoftDeletedPekerjaanAlumni(db *sql.DB, alumniID int) ([]model.PekerjaanA
 		err := rows.Scan(
 			&pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan,
 			&pekerjaan.PosisiJabatan, &pekerjaan.BidangIndustri, &pekerjaan.LokasiKerja,
-			&pekerjaan.GajiRange, &pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
-			&pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan, &pekerjaan.IsDelete,
+			&pekerjaan.GajiRange, &pekerjaan.GajiMin, &pekerjaan.GajiMax, &pekerjaan.GajiCurrency, &pekerjaan.GajiPeriod,
+			&pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
+			&pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan, &pekerjaan.IsDelete, &pekerjaan.Version,
 			&pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
 		)
 		if err != nil {
-			return nil, err
+			return nil, tracing.Error(span, err)
 		}
 		pekerjaanList = append(pekerjaanList, pekerjaan)
 	}
@@ -254,19 +424,23 @@ func GetSoftDeletedPekerjaanAlumni(db *sql.DB, alumniID int) ([]model.PekerjaanA
 	return pekerjaanList, nil
 }
 
-func GetAllSoftDeletedPekerjaanAlumni(db *sql.DB) ([]model.PekerjaanAlumni, error) {
+func GetAllSoftDeletedPekerjaanAlumni(ctx context.Context, db *sql.DB) ([]model.PekerjaanAlumni, error) {
+	ctx, span := tracing.StartPostgres(ctx, "GetAllSoftDeletedPekerjaanAlumni")
+	defer span.End()
+
 	query := `
 		SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri,
-		       lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja,
-		       status_pekerjaan, deskripsi_pekerjaan, is_delete, created_at, updated_at
+		       lokasi_kerja, gaji_range, gaji_min, gaji_max, gaji_currency, gaji_period,
+		       tanggal_mulai_kerja, tanggal_selesai_kerja,
+		       status_pekerjaan, deskripsi_pekerjaan, is_delete, version, created_at, updated_at
 		FROM pekerjaan_alumni 
 		WHERE is_delete IS NOT NULL
 		ORDER BY is_delete DESC
 	`
 	
-	rows, err := db.Query(query)
+	rows, err := db.QueryContext(ctx, query)
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, err)
 	}
 	defer rows.Close()
 
@@ -276,12 +450,13 @@ func GetAllSoftDeletedPekerjaanAlumni(db *sql.DB) ([]model.PekerjaanAlumni, erro
 		err := rows.Scan(
 			&pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan,
 			&pekerjaan.PosisiJabatan, &pekerjaan.BidangIndustri, &pekerjaan.LokasiKerja,
-			&pekerjaan.GajiRange, &pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
-			&pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan, &pekerjaan.IsDelete,
+			&pekerjaan.GajiRange, &pekerjaan.GajiMin, &pekerjaan.GajiMax, &pekerjaan.GajiCurrency, &pekerjaan.GajiPeriod,
+			&pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
+			&pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan, &pekerjaan.IsDelete, &pekerjaan.Version,
 			&pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
 		)
 		if err != nil {
-			return nil, err
+			return nil, tracing.Error(span, err)
 		}
 		pekerjaanList = append(pekerjaanList, pekerjaan)
 	}
@@ -289,8 +464,47 @@ func GetAllSoftDeletedPekerjaanAlumni(db *sql.DB) ([]model.PekerjaanAlumni, erro
 	return pekerjaanList, nil
 }
 
-func RestorePekerjaanAlumni(db *sql.DB, id int) error {
-	query := `UPDATE pekerjaan_alumni SET is_delete = NULL, updated_at = CURRENT_TIMESTAMP WHERE id = $1`
-	_, err := db.Exec(query, id)
-	return err
-}
\ No newline at end of file
+func RestorePekerjaanAlumni(ctx context.Context, db DBTX, id int, version int) error {
+	ctx, span := tracing.StartPostgres(ctx, "RestorePekerjaanAlumni")
+	defer span.End()
+
+	query := `UPDATE pekerjaan_alumni SET is_delete = NULL, updated_at = CURRENT_TIMESTAMP, version = version + 1 WHERE id = $1 AND version = $2`
+	result, err := db.ExecContext(ctx, query, id, version)
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+	return versionedResult(result)
+}
+// FindPekerjaanAlumniIDs mengembalikan id pekerjaan yang belum di-soft delete
+// dan cocok dengan filter batch, terurut, paling banyak limit data.
+func FindPekerjaanAlumniIDs(ctx context.Context, db DBTX, filter model.PekerjaanBatchFilter, limit int) ([]int, error) {
+	ctx, span := tracing.StartPostgres(ctx, "FindPekerjaanAlumniIDs")
+	defer span.End()
+
+	conditions := []string{"is_delete IS NULL"}
+	var args []interface{}
+	if filter.AlumniID != 0 {
+		args = append(args, filter.AlumniID)
+		conditions = append(conditions, fmt.Sprintf("alumni_id = $%d", len(args)))
+	}
+	if filter.NamaPerusahaan != "" {
+		args = append(args, filter.NamaPerusahaan)
+		conditions = append(conditions, fmt.Sprintf("LOWER(nama_perusahaan) = LOWER($%d)", len(args)))
+	}
+	if filter.BidangIndustri != "" {
+		args = append(args, filter.BidangIndustri)
+		conditions = append(conditions, fmt.Sprintf("bidang_industri = $%d", len(args)))
+	}
+	if filter.StatusPekerjaan != "" {
+		args = append(args, filter.StatusPekerjaan)
+		conditions = append(conditions, fmt.Sprintf("status_pekerjaan = $%d", len(args)))
+	}
+	args = append(args, limit)
+	query := fmt.Sprintf(`SELECT id FROM pekerjaan_alumni WHERE %s ORDER BY id LIMIT $%d`, strings.Join(conditions, " AND "), len(args))
+
+	ids, err := scanIDs(ctx, db, query, args...)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	return ids, nil
+}
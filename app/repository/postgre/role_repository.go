@@ -1,20 +1,26 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	model "go-fiber/app/model/postgre"
+	"go-fiber/apperror"
+	"go-fiber/tracing"
 )
 
-func GetAllRoles(db *sql.DB) ([]model.Role, error) {
+func GetAllRoles(ctx context.Context, db *sql.DB) ([]model.Role, error) {
+	ctx, span := tracing.StartPostgres(ctx, "GetAllRoles")
+	defer span.End()
+
 	query := `
 		SELECT id, nama, created_at, updated_at
 		FROM roles
 		ORDER BY id ASC
 	`
 	
-	rows, err := db.Query(query)
+	rows, err := db.QueryContext(ctx, query)
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, err)
 	}
 	defer rows.Close()
 
@@ -25,7 +31,7 @@ func GetAllRoles(db *sql.DB) ([]model.Role, error) {
 			&role.ID, &role.Nama, &role.CreatedAt, &role.UpdatedAt,
 		)
 		if err != nil {
-			return nil, err
+			return nil, tracing.Error(span, err)
 		}
 		roles = append(roles, role)
 	}
@@ -33,7 +39,10 @@ func GetAllRoles(db *sql.DB) ([]model.Role, error) {
 	return roles, nil
 }
 
-func GetRoleByID(db *sql.DB, id int) (*model.Role, error) {
+func GetRoleByID(ctx context.Context, db DBTX, id int) (*model.Role, error) {
+	ctx, span := tracing.StartPostgres(ctx, "GetRoleByID")
+	defer span.End()
+
 	query := `
 		SELECT id, nama, created_at, updated_at
 		FROM roles
@@ -41,17 +50,20 @@ func GetRoleByID(db *sql.DB, id int) (*model.Role, error) {
 	`
 	
 	role := new(model.Role)
-	err := db.QueryRow(query, id).Scan(
+	err := db.QueryRowContext(ctx, query, id).Scan(
 		&role.ID, &role.Nama, &role.CreatedAt, &role.UpdatedAt,
 	)
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, err)
 	}
 	
 	return role, nil
 }
 
-func GetRoleByName(db *sql.DB, nama string) (*model.Role, error) {
+func GetRoleByName(ctx context.Context, db *sql.DB, nama string) (*model.Role, error) {
+	ctx, span := tracing.StartPostgres(ctx, "GetRoleByName")
+	defer span.End()
+
 	query := `
 		SELECT id, nama, created_at, updated_at
 		FROM roles
@@ -59,17 +71,20 @@ func GetRoleByName(db *sql.DB, nama string) (*model.Role, error) {
 	`
 	
 	role := new(model.Role)
-	err := db.QueryRow(query, nama).Scan(
+	err := db.QueryRowContext(ctx, query, nama).Scan(
 		&role.ID, &role.Nama, &role.CreatedAt, &role.UpdatedAt,
 	)
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, err)
 	}
 	
 	return role, nil
 }
 
-func CreateRole(db *sql.DB, nama string) (*model.Role, error) {
+func CreateRole(ctx context.Context, db DBTX, nama string) (*model.Role, error) {
+	ctx, span := tracing.StartPostgres(ctx, "CreateRole")
+	defer span.End()
+
 	query := `
 		INSERT INTO roles (nama, created_at, updated_at)
 		VALUES ($1, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
@@ -77,17 +92,20 @@ func CreateRole(db *sql.DB, nama string) (*model.Role, error) {
 	`
 	
 	role := new(model.Role)
-	err := db.QueryRow(query, nama).Scan(
+	err := db.QueryRowContext(ctx, query, nama).Scan(
 		&role.ID, &role.Nama, &role.CreatedAt, &role.UpdatedAt,
 	)
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, apperror.FromDB(err))
 	}
 	
 	return role, nil
 }
 
-func UpdateRole(db *sql.DB, id int, nama string) (*model.Role, error) {
+func UpdateRole(ctx context.Context, db DBTX, id int, nama string) (*model.Role, error) {
+	ctx, span := tracing.StartPostgres(ctx, "UpdateRole")
+	defer span.End()
+
 	query := `
 		UPDATE roles 
 		SET nama = $1, updated_at = CURRENT_TIMESTAMP
@@ -96,18 +114,21 @@ func UpdateRole(db *sql.DB, id int, nama string) (*model.Role, error) {
 	`
 	
 	role := new(model.Role)
-	err := db.QueryRow(query, nama, id).Scan(
+	err := db.QueryRowContext(ctx, query, nama, id).Scan(
 		&role.ID, &role.Nama, &role.CreatedAt, &role.UpdatedAt,
 	)
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, apperror.FromDB(err))
 	}
 	
 	return role, nil
 }
 
-func DeleteRole(db *sql.DB, id int) error {
+func DeleteRole(ctx context.Context, db DBTX, id int) error {
+	ctx, span := tracing.StartPostgres(ctx, "DeleteRole")
+	defer span.End()
+
 	query := `DELETE FROM roles WHERE id = $1`
-	_, err := db.Exec(query, id)
-	return err
+	_, err := db.ExecContext(ctx, query, id)
+	return tracing.Error(span, apperror.FromDB(err))
 }
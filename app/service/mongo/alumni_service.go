@@ -4,19 +4,69 @@ import (
 	"context"
 	model "go-fiber/app/model/mongo"
 	repository "go-fiber/app/repository/mongo"
+	"go-fiber/apperror"
+	"go-fiber/audit"
+	"go-fiber/etag"
+	"go-fiber/helper"
+	"go-fiber/i18n"
+	"go-fiber/outbox"
+	"go-fiber/patch"
 	utilsmongo "go-fiber/utils/mongo"
-	"os"
+	"go-fiber/validation"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 type AlumniService struct {
-	repo repository.IAlumniRepository
+	repo           repository.IAlumniRepository
+	apiKey         string
+	requireIfMatch bool
+	auditLog       audit.Store
+	events         outbox.Transactor
 }
 
-func NewAlumniService(repo repository.IAlumniRepository) *AlumniService {
-	return &AlumniService{repo: repo}
+// NewAlumniService menerima requireIfMatch dari app.require_if_match untuk
+// menolak PUT/DELETE tanpa If-Match.
+func NewAlumniService(repo repository.IAlumniRepository, apiKey string, requireIfMatch bool, auditLog audit.Store, events outbox.Transactor) *AlumniService {
+	return &AlumniService{repo: repo, apiKey: apiKey, requireIfMatch: requireIfMatch, auditLog: auditLog, events: events}
+}
+
+var alumniFieldWhitelist = map[string]bool{"id": true, "nim": true, "nama": true, "jurusan": true, "angkatan": true, "tahun_lulus": true, "email": true, "no_telepon": true, "alamat": true, "role": true, "version": true, "created_at": true, "updated_at": true}
+
+var alumniIncludeWhitelist = map[string]bool{"role": true, "pekerjaan": true, "files": true}
+
+// parseAlumniProjection membaca query ?fields= dan ?include=. Nilai pertama
+// adalah field yang diproyeksikan di MongoDB, nilai kedua adalah key yang
+// disisakan di response. Role sudah tersimpan di dokumen alumni sehingga
+// include=role cukup memastikan field role ikut terproyeksi.
+func parseAlumniProjection(c *fiber.Ctx) ([]string, []string, []string, error) {
+	fields := helper.ParseListParam(c.Query("fields"))
+	if invalid := helper.InvalidItems(fields, alumniFieldWhitelist); len(invalid) > 0 {
+		return nil, nil, nil, apperror.Validation("query.invalid_fields", strings.Join(invalid, ", "))
+	}
+
+	include := helper.ParseListParam(c.Query("include"))
+	if invalid := helper.InvalidItems(include, alumniIncludeWhitelist); len(invalid) > 0 {
+		return nil, nil, nil, apperror.Validation("query.invalid_include", strings.Join(invalid, ", "), "role, pekerjaan, files")
+	}
+
+	projection := fields
+	if len(projection) > 0 {
+		projection = append(projection, include...)
+	}
+
+	responseFields := fields
+	if len(responseFields) == 0 {
+		for field := range alumniFieldWhitelist {
+			responseFields = append(responseFields, field)
+		}
+	}
+	responseFields = append(responseFields, include...)
+
+	return projection, include, responseFields, nil
 }
 
 // @Summary Dapatkan semua alumni
@@ -25,26 +75,42 @@ func NewAlumniService(repo repository.IAlumniRepository) *AlumniService {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {object} model.SuccessResponse{data=[]model.Alumni}
+// @Param fields query string false "Field yang ditampilkan, dipisah koma (contoh: nim,nama,email)"
+// @Param include query string false "Relasi yang di-embed, dipisah koma (role, pekerjaan, files)"
+// @Success 200 {object} model.SuccessResponse{data=[]model.AlumniDetail}
 // @Failure 401 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
 // @Router /alumni [get]
 func (s *AlumniService) GetAllAlumniService(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
 	defer cancel()
 
-	alumniList, err := s.repo.FindAllAlumni(ctx)
+	projection, include, responseFields, err := parseAlumniProjection(c)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengambil data alumni dari database. Detail: " + err.Error(),
-		})
+		return err
+	}
+
+	var data interface{}
+	if len(projection) == 0 && len(include) == 0 {
+		alumniList, err := s.repo.FindAllAlumni(ctx)
+		if err != nil {
+			return apperror.Wrap(err, "alumni.fetch_failed")
+		}
+		data = alumniList
+	} else {
+		alumniList, err := s.repo.FindAllAlumniWithRelations(ctx, projection, include)
+		if err != nil {
+			return apperror.Wrap(err, "alumni.fetch_failed")
+		}
+		if data, err = helper.SelectFields(alumniList, responseFields); err != nil {
+			return apperror.Wrap(err, "alumni.fields_failed")
+		}
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success": true,
-		"message": "Data alumni berhasil diambil dari database.",
-		"data":    alumniList,
+		"message": i18n.Msg(c, "alumni.fetched"),
+		"data":    data,
 	})
 }
 
@@ -55,7 +121,13 @@ func (s *AlumniService) GetAllAlumniService(c *fiber.Ctx) error {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Alumni ID (MongoDB ObjectID)"
-// @Success 200 {object} model.SuccessResponse{data=model.Alumni}
+// @Param fields query string false "Field yang ditampilkan, dipisah koma (contoh: nim,nama,email)"
+// @Param include query string false "Relasi yang di-embed, dipisah koma (role, pekerjaan, files)"
+// @Param as_of query string false "Tampilkan data pada waktu tertentu dari riwayat (RFC3339 atau YYYY-MM-DD); include diabaikan"
+// @Param If-None-Match header string false "ETag dari response sebelumnya; 304 jika data belum berubah (tanpa fields dan include)"
+// @Success 200 {object} model.SuccessResponse{data=model.AlumniDetail}
+// @Success 304 "Data belum berubah sejak ETag pada If-None-Match"
+// @Header 200 {string} ETag "Version data alumni (tanpa fields dan include)"
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
 // @Failure 404 {object} model.ErrorResponse
@@ -63,28 +135,64 @@ func (s *AlumniService) GetAllAlumniService(c *fiber.Ctx) error {
 // @Router /alumni/{id} [get]
 func (s *AlumniService) GetAlumniByIDService(c *fiber.Ctx) error {
 	id := c.Params("id")
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
 	defer cancel()
 
-	alumni, err := s.repo.FindAlumniByID(ctx, id)
+	projection, include, responseFields, err := parseAlumniProjection(c)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengambil data alumni dari database. Detail: " + err.Error(),
+		return err
+	}
+
+	if c.Query("as_of") != "" {
+		data, err := stateAsOf(c, s.auditLog, audit.ResourceAlumni, id, responseFields)
+		if err != nil {
+			return err
+		}
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"success": true,
+			"message": i18n.Msg(c, "alumni.fetched"),
+			"data":    data,
 		})
 	}
 
-	if alumni == nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"success": false,
-			"message": "Data alumni dengan ID tersebut tidak ditemukan di database.",
+	if len(projection) == 0 && len(include) == 0 {
+		alumni, err := s.repo.FindAlumniByID(ctx, id)
+		if err != nil {
+			return apperror.Wrap(err, "alumni.fetch_failed")
+		}
+
+		if alumni == nil {
+			return apperror.NotFound("alumni.not_found_id")
+		}
+		if etag.NotModified(c, alumni.Version) {
+			return c.SendStatus(fiber.StatusNotModified)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"success": true,
+			"message": i18n.Msg(c, "alumni.fetched"),
+			"data":    alumni,
 		})
 	}
 
+	alumni, err := s.repo.FindAlumniByIDWithRelations(ctx, id, projection, include)
+	if err != nil {
+		return apperror.Wrap(err, "alumni.fetch_failed")
+	}
+
+	if alumni == nil {
+		return apperror.NotFound("alumni.not_found_id")
+	}
+
+	data, err := helper.SelectFields(alumni, responseFields)
+	if err != nil {
+		return apperror.Wrap(err, "alumni.fields_failed")
+	}
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success": true,
-		"message": "Data alumni berhasil diambil dari database.",
-		"data":    alumni,
+		"message": i18n.Msg(c, "alumni.fetched"),
+		"data":    data,
 	})
 }
 
@@ -95,44 +203,29 @@ func (s *AlumniService) GetAlumniByIDService(c *fiber.Ctx) error {
 // @Produce json
 // @Security BearerAuth
 // @Param body body model.CreateAlumniRequest true "Alumni data"
+// @Param Idempotency-Key header string false "Key unik per request; retry dengan key dan body sama mengembalikan response pertama"
 // @Success 201 {object} model.SuccessResponse{data=model.Alumni}
+// @Header 201 {string} Idempotent-Replayed "true jika response dikirim ulang dari Idempotency-Key"
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
 // @Failure 403 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 422 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
 // @Router /alumni [post]
 func (s *AlumniService) CreateAlumniService(c *fiber.Ctx) error {
 	var req model.CreateAlumniRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Format request body tidak valid. Pastikan JSON format benar. Detail: " + err.Error(),
-		})
-	}
-
-	if req.NIM == "" || req.Nama == "" || req.Jurusan == "" || req.Email == "" || req.Password == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Field wajib tidak lengkap. NIM, nama, jurusan, email, dan password harus diisi.",
-		})
-	}
-
-	if req.Role != "admin" && req.Role != "user" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Role tidak valid. Gunakan 'admin' atau 'user'.",
-		})
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
 	}
+	req.Normalize()
 
 	passwordHash, err := utilsmongo.HashPassword(req.Password)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengenkripsi password. Detail: " + err.Error(),
-		})
+		return apperror.Wrap(err, "auth.password_hash_failed")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
 	defer cancel()
 
 	alumni := &model.Alumni{
@@ -148,17 +241,23 @@ func (s *AlumniService) CreateAlumniService(c *fiber.Ctx) error {
 		Role:         req.Role,
 	}
 
-	createdAlumni, err := s.repo.CreateAlumni(ctx, alumni)
+	var createdAlumni *model.Alumni
+	err = withEvents(ctx, s.events, s.auditLog, func(ctx context.Context) ([]audit.Entry, error) {
+		result, err := s.repo.CreateAlumni(ctx, alumni)
+		if err != nil {
+			return nil, apperror.Wrap(err, "alumni.create_failed")
+		}
+		createdAlumni = result
+		return []audit.Entry{audit.NewEntry(c, audit.ActionCreate, audit.ResourceAlumni, createdAlumni.ID, nil, createdAlumni)}, nil
+	})
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error menyimpan data alumni ke database. Detail: " + err.Error(),
-		})
+		return err
 	}
+	etag.Set(c, createdAlumni.Version)
 
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"success": true,
-		"message": "Data alumni berhasil disimpan ke database.",
+		"message": i18n.Msg(c, "alumni.created"),
 		"data":    createdAlumni,
 	})
 }
@@ -171,53 +270,39 @@ func (s *AlumniService) CreateAlumniService(c *fiber.Ctx) error {
 // @Security BearerAuth
 // @Param id path string true "Alumni ID (MongoDB ObjectID)"
 // @Param body body model.UpdateAlumniRequest true "Alumni data"
+// @Param If-Match header string false "ETag dari GET alumni; wajib jika require_if_match aktif"
 // @Success 200 {object} model.SuccessResponse{data=model.Alumni}
+// @Header 200 {string} ETag "Version baru data alumni"
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
 // @Failure 403 {object} model.ErrorResponse
 // @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 412 {object} model.ErrorResponse
+// @Failure 428 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
 // @Router /alumni/{id} [put]
 func (s *AlumniService) UpdateAlumniService(c *fiber.Ctx) error {
 	id := c.Params("id")
 	var req model.UpdateAlumniRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Format request body tidak valid. Pastikan JSON format benar. Detail: " + err.Error(),
-		})
-	}
-
-	if req.Nama == "" || req.Jurusan == "" || req.Email == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Field wajib tidak lengkap. Nama, jurusan, dan email harus diisi.",
-		})
-	}
-
-	if req.Role != "admin" && req.Role != "user" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Role tidak valid. Gunakan 'admin' atau 'user'.",
-		})
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
 	}
+	req.Normalize()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
 	defer cancel()
 
 	existingAlumni, err := s.repo.FindAlumniByID(ctx, id)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengambil data alumni dari database. Detail: " + err.Error(),
-		})
+		return apperror.Wrap(err, "alumni.fetch_failed")
 	}
 
 	if existingAlumni == nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"success": false,
-			"message": "Data alumni dengan ID tersebut tidak ditemukan di database.",
-		})
+		return apperror.NotFound("alumni.not_found_id")
+	}
+	if err := etag.CheckIfMatch(c, existingAlumni.Version, s.requireIfMatch); err != nil {
+		return err
 	}
 
 	alumni := &model.Alumni{
@@ -231,17 +316,106 @@ func (s *AlumniService) UpdateAlumniService(c *fiber.Ctx) error {
 		Role:       req.Role,
 	}
 
-	updatedAlumni, err := s.repo.UpdateAlumni(ctx, id, alumni)
+	var updatedAlumni *model.Alumni
+	err = withEvents(ctx, s.events, s.auditLog, func(ctx context.Context) ([]audit.Entry, error) {
+		result, err := s.repo.UpdateAlumni(ctx, id, existingAlumni.Version, alumni)
+		if err != nil {
+			return nil, apperror.Wrap(err, "alumni.update_failed")
+		}
+		updatedAlumni = result
+		return []audit.Entry{audit.NewEntry(c, audit.ActionUpdate, audit.ResourceAlumni, id, existingAlumni, updatedAlumni)}, nil
+	})
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengupdate data alumni di database. Detail: " + err.Error(),
+		return err
+	}
+	etag.Set(c, updatedAlumni.Version)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "alumni.updated"),
+		"data":    updatedAlumni,
+	})
+}
+
+// @Summary Patch alumni
+// @Description Mengubah sebagian field alumni dengan JSON Merge Patch (RFC 7396) atau JSON Patch (RFC 6902). Hanya field yang disentuh patch yang divalidasi dan diubah; field bernilai null dihapus (Admin only)
+// @Tags 2. Alumni
+// @Accept json
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Alumni ID (MongoDB ObjectID)"
+// @Param body body object true "Merge patch berupa object field, atau JSON Patch berupa array operasi"
+// @Param If-Match header string false "ETag dari GET alumni; wajib jika require_if_match aktif"
+// @Success 200 {object} model.SuccessResponse{data=model.Alumni}
+// @Header 200 {string} ETag "Version baru data alumni"
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 412 {object} model.ErrorResponse
+// @Failure 415 {object} model.ErrorResponse
+// @Failure 422 {object} model.ErrorResponse
+// @Failure 428 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /alumni/{id} [patch]
+func (s *AlumniService) PatchAlumniService(c *fiber.Ctx) error {
+	id := c.Params("id")
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	existingAlumni, err := s.repo.FindAlumniByID(ctx, id)
+	if err != nil {
+		return apperror.Wrap(err, "alumni.fetch_failed")
+	}
+
+	if existingAlumni == nil {
+		return apperror.NotFound("alumni.not_found_id")
+	}
+	if err := etag.CheckIfMatch(c, existingAlumni.Version, s.requireIfMatch); err != nil {
+		return err
+	}
+
+	var req model.UpdateAlumniRequest
+	touched, err := patch.Apply(c, model.UpdateAlumniRequest{
+		Nama:       existingAlumni.Nama,
+		Jurusan:    existingAlumni.Jurusan,
+		Angkatan:   existingAlumni.Angkatan,
+		TahunLulus: existingAlumni.TahunLulus,
+		Email:      existingAlumni.Email,
+		NoTelepon:  existingAlumni.NoTelepon,
+		Alamat:     existingAlumni.Alamat,
+		Role:       existingAlumni.Role,
+	}, &req)
+	if err != nil {
+		return err
+	}
+	req.Normalize()
+	if err := validation.Partial(i18n.Lang(c), &req, touched); err != nil {
+		return err
+	}
+
+	updatedAlumni := existingAlumni
+	if len(touched) > 0 {
+		err = withEvents(ctx, s.events, s.auditLog, func(ctx context.Context) ([]audit.Entry, error) {
+			result, err := s.repo.PatchAlumni(ctx, id, existingAlumni.Version, bson.M(patch.Values(&req, touched)))
+			if err != nil {
+				return nil, apperror.Wrap(err, "alumni.update_failed")
+			}
+			updatedAlumni = result
+			return []audit.Entry{audit.NewEntry(c, audit.ActionUpdate, audit.ResourceAlumni, id, existingAlumni, updatedAlumni)}, nil
 		})
+		if err != nil {
+			return err
+		}
 	}
+	etag.Set(c, updatedAlumni.Version)
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success": true,
-		"message": "Data alumni berhasil diupdate di database.",
+		"message": i18n.Msg(c, "alumni.updated"),
 		"data":    updatedAlumni,
 	})
 }
@@ -253,44 +427,128 @@ func (s *AlumniService) UpdateAlumniService(c *fiber.Ctx) error {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Alumni ID (MongoDB ObjectID)"
+// @Param If-Match header string false "ETag dari GET alumni; wajib jika require_if_match aktif"
 // @Success 200 {object} model.SuccessResponse
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
 // @Failure 403 {object} model.ErrorResponse
 // @Failure 404 {object} model.ErrorResponse
+// @Failure 412 {object} model.ErrorResponse
+// @Failure 428 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
 // @Router /alumni/{id} [delete]
 func (s *AlumniService) DeleteAlumniService(c *fiber.Ctx) error {
 	id := c.Params("id")
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
 	defer cancel()
 
 	alumni, err := s.repo.FindAlumniByID(ctx, id)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengambil data alumni dari database. Detail: " + err.Error(),
-		})
+		return apperror.Wrap(err, "alumni.fetch_failed")
 	}
 
 	if alumni == nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"success": false,
-			"message": "Data alumni dengan ID tersebut tidak ditemukan di database.",
-		})
+		return apperror.NotFound("alumni.not_found_id")
+	}
+	if err := etag.CheckIfMatch(c, alumni.Version, s.requireIfMatch); err != nil {
+		return err
 	}
 
-	err = s.repo.DeleteAlumni(ctx, id)
+	err = withEvents(ctx, s.events, s.auditLog, func(ctx context.Context) ([]audit.Entry, error) {
+		if err := s.repo.DeleteAlumni(ctx, id, alumni.Version); err != nil {
+			return nil, apperror.Wrap(err, "alumni.delete_failed")
+		}
+		return []audit.Entry{audit.NewEntry(c, audit.ActionDelete, audit.ResourceAlumni, id, alumni, nil)}, nil
+	})
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error menghapus data alumni dari database. Detail: " + err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success": true,
-		"message": "Data alumni berhasil dihapus dari database.",
+		"message": i18n.Msg(c, "alumni.deleted"),
+	})
+}
+
+// @Summary Riwayat versi alumni
+// @Description Mengambil semua versi data alumni beserta field yang berubah, terlama lebih dulu
+// @Tags 2. Alumni
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Alumni ID (MongoDB ObjectID)"
+// @Success 200 {object} model.SuccessResponse{data=[]audit.Version}
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /alumni/{id}/history [get]
+func (s *AlumniService) GetAlumniHistoryService(c *fiber.Ctx) error {
+	return getHistory(c, s.auditLog, audit.ResourceAlumni)
+}
+
+// @Summary Kembalikan alumni ke versi tertentu
+// @Description Mengembalikan data alumni ke versi dari riwayat. NIM dan password tidak ikut dikembalikan. Revert dicatat sebagai versi baru (Admin only)
+// @Tags 2. Alumni
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Alumni ID (MongoDB ObjectID)"
+// @Param version path int true "Nomor versi dari endpoint history"
+// @Param If-Match header string false "ETag dari GET alumni; wajib jika require_if_match aktif"
+// @Success 200 {object} model.SuccessResponse{data=model.Alumni}
+// @Header 200 {string} ETag "Version baru data alumni"
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 412 {object} model.ErrorResponse
+// @Failure 422 {object} model.ErrorResponse
+// @Failure 428 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /alumni/{id}/revert/{version} [post]
+func (s *AlumniService) RevertAlumniService(c *fiber.Ctx) error {
+	id := c.Params("id")
+	var target model.Alumni
+	version, err := versionTarget(c, s.auditLog, audit.ResourceAlumni, &target)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	existingAlumni, err := s.repo.FindAlumniByID(ctx, id)
+	if err != nil {
+		return apperror.Wrap(err, "alumni.fetch_failed")
+	}
+
+	if existingAlumni == nil {
+		return apperror.NotFound("alumni.not_found_id")
+	}
+	if err := etag.CheckIfMatch(c, existingAlumni.Version, s.requireIfMatch); err != nil {
+		return err
+	}
+
+	var updatedAlumni *model.Alumni
+	err = withEvents(ctx, s.events, s.auditLog, func(ctx context.Context) ([]audit.Entry, error) {
+		result, err := s.repo.UpdateAlumni(ctx, id, existingAlumni.Version, &target)
+		if err != nil {
+			return nil, apperror.Wrap(err, "history.revert_failed")
+		}
+		updatedAlumni = result
+		return []audit.Entry{audit.NewEntry(c, audit.ActionRevert, audit.ResourceAlumni, id, existingAlumni, updatedAlumni)}, nil
+	})
+	if err != nil {
+		return err
+	}
+	etag.Set(c, updatedAlumni.Version)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "history.reverted", version),
+		"data":    updatedAlumni,
 	})
 }
 
@@ -304,44 +562,32 @@ func (s *AlumniService) DeleteAlumniService(c *fiber.Ctx) error {
 // @Success 200 {object} model.SuccessResponse
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
+// @Failure 429 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
 // @Router /alumni/check/{key} [post]
 func (s *AlumniService) CheckAlumniService(c *fiber.Ctx) error {
 	key := c.Params("key")
-	validAPIKey := os.Getenv("API_KEY")
-	if validAPIKey == "" {
-		validAPIKey = "default-api-key-2024"
-	}
-	if key != validAPIKey {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"success": false,
-			"message": "API key tidak valid. Gunakan key yang benar untuk akses endpoint ini.",
-		})
+	if key != s.apiKey {
+		return apperror.Unauthorized("auth.invalid_api_key")
 	}
 
 	nim := c.FormValue("nim")
 	if nim == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Parameter NIM wajib diisi untuk pengecekan status alumni.",
-		})
+		return apperror.Validation("alumni.nim_required")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
 	defer cancel()
 
 	alumni, err := s.repo.FindAlumniByNIM(ctx, nim)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengecek status alumni di database. Detail: " + err.Error(),
-		})
+		return apperror.Wrap(err, "alumni.check_failed")
 	}
 
 	if alumni == nil {
 		return c.Status(fiber.StatusOK).JSON(fiber.Map{
 			"success":  true,
-			"message":  "Mahasiswa dengan NIM tersebut bukan alumni.",
+			"message":  i18n.Msg(c, "alumni.not_alumni"),
 			"isAlumni": false,
 			"data":     nil,
 		})
@@ -349,7 +595,7 @@ func (s *AlumniService) CheckAlumniService(c *fiber.Ctx) error {
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success":  true,
-		"message":  "Data alumni berhasil ditemukan di database.",
+		"message":  i18n.Msg(c, "alumni.found"),
 		"isAlumni": true,
 		"data":     alumni,
 	})
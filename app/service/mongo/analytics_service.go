@@ -0,0 +1,357 @@
+package service
+
+import (
+	"context"
+	model "go-fiber/app/model/mongo"
+	repository "go-fiber/app/repository/mongo"
+	"go-fiber/apperror"
+	"go-fiber/helper"
+	"go-fiber/i18n"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AnalyticsService struct {
+	repo repository.IAnalyticsRepository
+}
+
+func NewAnalyticsService(repo repository.IAnalyticsRepository) *AnalyticsService {
+	return &AnalyticsService{repo: repo}
+}
+
+var analyticsGroupByWhitelist = map[string]bool{"angkatan": true, "tahun_lulus": true, "jurusan": true}
+
+var salaryGroupByWhitelist = map[string]bool{"angkatan": true, "tahun_lulus": true, "jurusan": true, "bidang_industri": true}
+
+// minSalaryGroupSize adalah jumlah alumni minimum agar statistik gaji sebuah
+// kelompok ditampilkan, supaya gaji individu tidak bisa ditebak.
+const minSalaryGroupSize = 5
+
+var analyticsDimensionWhitelist = map[string]bool{"bidang_industri": true, "lokasi_kerja": true}
+
+var analyticsStatusWhitelist = map[string]bool{"aktif": true, "selesai": true, "resigned": true}
+
+func parseAnalyticsFilter(c *fiber.Ctx, groupByWhitelist map[string]bool) (model.AnalyticsFilter, error) {
+	filter := model.AnalyticsFilter{
+		GroupBy: strings.ToLower(c.Query("group_by")),
+		Jurusan: strings.TrimSpace(c.Query("jurusan")),
+	}
+
+	if filter.GroupBy != "" && !groupByWhitelist[filter.GroupBy] {
+		return filter, apperror.Validation("query.invalid_group_by", strings.Join(helper.SortedKeys(groupByWhitelist), ", "))
+	}
+
+	if value := c.Query("angkatan"); value != "" {
+		angkatan, err := strconv.Atoi(value)
+		if err != nil {
+			return filter, apperror.Validation("query.angkatan_not_number")
+		}
+		filter.Angkatan = &angkatan
+	}
+
+	if value := c.Query("tahun_lulus"); value != "" {
+		tahunLulus, err := strconv.Atoi(value)
+		if err != nil {
+			return filter, apperror.Validation("query.tahun_lulus_not_number")
+		}
+		filter.TahunLulus = &tahunLulus
+	}
+
+	return filter, nil
+}
+
+// parseSalaryQuery membaca mata uang dan ukuran kelompok minimum. Ukuran
+// kelompok hanya boleh dinaikkan dari minSalaryGroupSize, tidak diturunkan.
+func parseSalaryQuery(c *fiber.Ctx) (string, int, error) {
+	currency := strings.ToUpper(c.Query("currency", helper.DefaultCurrency))
+	if !helper.SalaryCurrencies[currency] {
+		return "", 0, apperror.Validation("query.invalid_currency", strings.Join(helper.SortedKeys(helper.SalaryCurrencies), ", "))
+	}
+
+	minGroupSize, err := strconv.Atoi(c.Query("min_group_size", strconv.Itoa(minSalaryGroupSize)))
+	if err != nil || minGroupSize < minSalaryGroupSize {
+		minGroupSize = minSalaryGroupSize
+	}
+
+	return currency, minGroupSize, nil
+}
+
+func parseDistributionQuery(c *fiber.Ctx) (string, string, error) {
+	dimension := strings.ToLower(c.Query("dimension", "bidang_industri"))
+	if !analyticsDimensionWhitelist[dimension] {
+		return "", "", apperror.Validation("query.invalid_dimension")
+	}
+
+	status := strings.ToLower(c.Query("status"))
+	if status != "" && !analyticsStatusWhitelist[status] {
+		return "", "", apperror.Validation("query.invalid_status")
+	}
+
+	return dimension, status, nil
+}
+
+func parseTopEmployersLimit(c *fiber.Ctx) int {
+	limit, err := strconv.Atoi(c.Query("limit", "10"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 10
+	}
+	return limit
+}
+
+func (s *AnalyticsService) employmentRates(ctx context.Context, filter model.AnalyticsFilter) ([]model.EmploymentRate, error) {
+	result, err := s.repo.GetEmploymentRate(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range result {
+		if result[i].TotalAlumni > 0 {
+			result[i].EmploymentRate = helper.Round2(float64(result[i].EmployedAlumni) / float64(result[i].TotalAlumni) * 100)
+		}
+	}
+	return result, nil
+}
+
+func (s *AnalyticsService) timeToEmployment(ctx context.Context, filter model.AnalyticsFilter) ([]model.TimeToEmployment, error) {
+	result, err := s.repo.GetTimeToEmployment(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range result {
+		result[i].TotalAlumni = len(result[i].Days)
+		result[i].MedianDays = helper.Round2(helper.Median(result[i].Days))
+		result[i].MedianMonths = helper.Round2(result[i].MedianDays / 30)
+	}
+	return result, nil
+}
+
+// salaryStatistics menghitung persentil setiap kelompok, membuang kelompok
+// dengan alumni kurang dari minGroupSize, dan mengembalikan jumlah kelompok
+// yang disembunyikan.
+func (s *AnalyticsService) salaryStatistics(ctx context.Context, filter model.AnalyticsFilter, currency string, minGroupSize int) ([]model.SalaryStatistic, int, error) {
+	groups, err := s.repo.GetSalaryStatistics(ctx, filter, currency)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := []model.SalaryStatistic{}
+	for _, group := range groups {
+		if group.TotalSampel < minGroupSize {
+			continue
+		}
+		group.P25 = helper.Round2(helper.Percentile(group.Values, 25))
+		group.Median = helper.Round2(helper.Percentile(group.Values, 50))
+		group.P75 = helper.Round2(helper.Percentile(group.Values, 75))
+		result = append(result, group)
+	}
+	return result, len(groups) - len(result), nil
+}
+
+// @Summary Tingkat keterserapan kerja alumni
+// @Description Menghitung persentase alumni yang memiliki pekerjaan berstatus aktif per kelompok (Admin only)
+// @Tags 5. Analytics
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param group_by query string false "Kelompokkan berdasarkan angkatan, tahun_lulus, atau jurusan"
+// @Param angkatan query int false "Filter angkatan"
+// @Param tahun_lulus query int false "Filter tahun lulus"
+// @Param jurusan query string false "Filter jurusan"
+// @Success 200 {object} model.SuccessResponse{data=[]model.EmploymentRate}
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /analytics/employment-rate [get]
+func (s *AnalyticsService) GetEmploymentRateService(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
+	defer cancel()
+
+	filter, err := parseAnalyticsFilter(c, analyticsGroupByWhitelist)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.employmentRates(ctx, filter)
+	if err != nil {
+		return apperror.Wrap(err, "analytics.employment_rate_failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "analytics.employment_rate"),
+		"data":    result,
+	})
+}
+
+// @Summary Median waktu tunggu kerja alumni
+// @Description Menghitung median jarak antara kelulusan (1 Januari tahun_lulus) dan pekerjaan pertama per kelompok (Admin only)
+// @Tags 5. Analytics
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param group_by query string false "Kelompokkan berdasarkan angkatan, tahun_lulus, atau jurusan"
+// @Param angkatan query int false "Filter angkatan"
+// @Param tahun_lulus query int false "Filter tahun lulus"
+// @Param jurusan query string false "Filter jurusan"
+// @Success 200 {object} model.SuccessResponse{data=[]model.TimeToEmployment}
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /analytics/time-to-employment [get]
+func (s *AnalyticsService) GetTimeToEmploymentService(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
+	defer cancel()
+
+	filter, err := parseAnalyticsFilter(c, analyticsGroupByWhitelist)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.timeToEmployment(ctx, filter)
+	if err != nil {
+		return apperror.Wrap(err, "analytics.time_to_employment_failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "analytics.time_to_employment"),
+		"data":    result,
+	})
+}
+
+// @Summary Distribusi pekerjaan alumni
+// @Description Menghitung jumlah pekerjaan alumni per bidang_industri atau lokasi_kerja untuk setiap kelompok (Admin only)
+// @Tags 5. Analytics
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param dimension query string false "bidang_industri (default) atau lokasi_kerja"
+// @Param status query string false "Filter status pekerjaan: aktif, selesai, atau resigned"
+// @Param group_by query string false "Kelompokkan berdasarkan angkatan, tahun_lulus, atau jurusan"
+// @Param angkatan query int false "Filter angkatan"
+// @Param tahun_lulus query int false "Filter tahun lulus"
+// @Param jurusan query string false "Filter jurusan"
+// @Success 200 {object} model.SuccessResponse{data=[]model.DistributionItem}
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /analytics/distribution [get]
+func (s *AnalyticsService) GetEmploymentDistributionService(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
+	defer cancel()
+
+	filter, err := parseAnalyticsFilter(c, analyticsGroupByWhitelist)
+	if err != nil {
+		return err
+	}
+
+	dimension, status, err := parseDistributionQuery(c)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.repo.GetEmploymentDistribution(ctx, filter, dimension, status)
+	if err != nil {
+		return apperror.Wrap(err, "analytics.distribution_failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "analytics.distribution", dimension),
+		"data":    result,
+	})
+}
+
+// @Summary Perusahaan dengan alumni terbanyak
+// @Description Mengambil perusahaan yang mempekerjakan alumni terbanyak untuk setiap kelompok (Admin only)
+// @Tags 5. Analytics
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Jumlah perusahaan per kelompok (default 10, maksimal 100)"
+// @Param group_by query string false "Kelompokkan berdasarkan angkatan, tahun_lulus, atau jurusan"
+// @Param angkatan query int false "Filter angkatan"
+// @Param tahun_lulus query int false "Filter tahun lulus"
+// @Param jurusan query string false "Filter jurusan"
+// @Success 200 {object} model.SuccessResponse{data=[]model.TopEmployer}
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /analytics/top-employers [get]
+func (s *AnalyticsService) GetTopEmployersService(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
+	defer cancel()
+
+	filter, err := parseAnalyticsFilter(c, analyticsGroupByWhitelist)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.repo.GetTopEmployers(ctx, filter, parseTopEmployersLimit(c))
+	if err != nil {
+		return apperror.Wrap(err, "analytics.top_employers_failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "analytics.top_employers"),
+		"data":    result,
+	})
+}
+
+// @Summary Statistik gaji alumni
+// @Description Menghitung persentil 25, median, dan persentil 75 gaji bulanan (titik tengah gaji_min dan gaji_max) per kelompok. Kelompok dengan alumni kurang dari min_group_size tidak ditampilkan (Admin only)
+// @Tags 5. Analytics
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param group_by query string false "Kelompokkan berdasarkan angkatan, tahun_lulus, jurusan, atau bidang_industri"
+// @Param currency query string false "Mata uang gaji (default IDR)"
+// @Param min_group_size query int false "Jumlah alumni minimum per kelompok (minimal 5)"
+// @Param angkatan query int false "Filter angkatan"
+// @Param tahun_lulus query int false "Filter tahun lulus"
+// @Param jurusan query string false "Filter jurusan"
+// @Success 200 {object} model.SuccessResponse{data=[]model.SalaryStatistic}
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /analytics/salary [get]
+func (s *AnalyticsService) GetSalaryStatisticsService(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
+	defer cancel()
+
+	filter, err := parseAnalyticsFilter(c, salaryGroupByWhitelist)
+	if err != nil {
+		return err
+	}
+
+	currency, minGroupSize, err := parseSalaryQuery(c)
+	if err != nil {
+		return err
+	}
+
+	result, suppressed, err := s.salaryStatistics(ctx, filter, currency, minGroupSize)
+	if err != nil {
+		return apperror.Wrap(err, "analytics.salary_failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "analytics.salary"),
+		"data":    result,
+		"meta": fiber.Map{
+			"currency":          currency,
+			"min_group_size":    minGroupSize,
+			"suppressed_groups": suppressed,
+		},
+	})
+}
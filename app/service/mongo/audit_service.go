@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"go-fiber/apperror"
+	"go-fiber/audit"
+	"go-fiber/i18n"
+	"go-fiber/outbox"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AuditService struct {
+	store audit.Store
+}
+
+func NewAuditService(store audit.Store) *AuditService {
+	return &AuditService{store: store}
+}
+
+// withEvents menjalankan write lalu menyimpan entry audit yang
+// dikembalikannya beserta domain event dari entry tersebut dalam satu
+// transaksi lewat events, seperti withAudit di stack PostgreSQL. Jika audit
+// gagal disimpan, transaksi dibatalkan dan request gagal. Dengan
+// outbox.Discard tidak ada transaksi, sehingga mutasi yang sudah ditulis
+// tidak ikut dibatalkan walaupun request gagal.
+func withEvents(ctx context.Context, events outbox.Transactor, auditLog audit.Recorder, write func(ctx context.Context) ([]audit.Entry, error)) error {
+	err := events.Transaction(ctx, func(ctx context.Context) ([]outbox.Event, error) {
+		entries, err := write(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := auditLog.Append(ctx, entries...); err != nil {
+			return nil, apperror.Wrap(err, "audit.write_failed")
+		}
+		return outbox.FromEntries(entries...), nil
+	})
+	if err != nil {
+		return apperror.Wrap(err, "outbox.write_failed")
+	}
+	return nil
+}
+
+// @Summary Dapatkan audit log
+// @Description Mengambil catatan audit perubahan data terbaru lebih dulu, bisa difilter berdasarkan actor, resource, dan rentang waktu (Admin only)
+// @Tags 7. Audit
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param actor query string false "ID atau email actor"
+// @Param resource query string false "Resource: alumni, pekerjaan, atau file"
+// @Param resource_id query string false "ID resource"
+// @Param action query string false "Aksi: create, update, delete, soft_delete, hard_delete, restore, atau import"
+// @Param from query string false "Waktu awal (RFC3339 atau YYYY-MM-DD)"
+// @Param to query string false "Waktu akhir (RFC3339 atau YYYY-MM-DD, tanggal saja mencakup seluruh hari)"
+// @Param page query int false "Halaman (default 1)"
+// @Param limit query int false "Jumlah per halaman, maksimal 100 (default 20)"
+// @Success 200 {object} model.SuccessResponse{data=[]audit.Entry}
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /audit-logs [get]
+func (s *AuditService) GetAuditLogsService(c *fiber.Ctx) error {
+	filter, err := audit.ParseFilter(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	entries, total, err := s.store.Query(ctx, filter)
+	if err != nil {
+		return apperror.Wrap(err, "audit.fetch_failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "audit.fetched"),
+		"data":    entries,
+		"meta":    audit.NewMeta(filter, total),
+	})
+}
@@ -2,10 +2,14 @@ package service
 
 import (
 	"context"
+	"go-fiber/apperror"
+	"go-fiber/validation"
 	"time"
 
 	modelMongo "go-fiber/app/model/mongo"
 	repository "go-fiber/app/repository/mongo"
+	"go-fiber/i18n"
+	"go-fiber/metrics"
 	utilsmongo "go-fiber/utils/mongo"
 
 	"github.com/gofiber/fiber/v2"
@@ -13,10 +17,13 @@ import (
 
 type AuthService struct {
 	alumniRepo repository.IAlumniRepository
+	jwtSecret  string
 }
 
-func NewAuthService(alumniRepo repository.IAlumniRepository) *AuthService {
-	return &AuthService{alumniRepo: alumniRepo}
+// NewAuthService menerima secret dari config.AuthConfig.JWTSecret untuk
+// menandatangani token login.
+func NewAuthService(alumniRepo repository.IAlumniRepository, jwtSecret string) *AuthService {
+	return &AuthService{alumniRepo: alumniRepo, jwtSecret: jwtSecret}
 }
 
 // @Summary Login pengguna
@@ -28,47 +35,31 @@ func NewAuthService(alumniRepo repository.IAlumniRepository) *AuthService {
 // @Success 200 {object} modelMongo.SuccessResponse{data=modelMongo.LoginResponse}
 // @Failure 400 {object} modelMongo.ErrorResponse
 // @Failure 401 {object} modelMongo.ErrorResponse
+// @Failure 429 {object} modelMongo.ErrorResponse
 // @Failure 500 {object} modelMongo.ErrorResponse
 // @Router /login [post]
-func (s *AuthService) LoginService(c *fiber.Ctx) error {
-	var req modelMongo.LoginRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Format request body tidak valid. Pastikan JSON format benar. Detail: " + err.Error(),
-		})
-	}
+func (s *AuthService) LoginService(c *fiber.Ctx) (err error) {
+	defer func() { metrics.ObserveLogin("mongo", apperror.ResponseStatus(c, err)) }()
 
-	if req.Email == "" || req.Password == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Field wajib tidak lengkap. Email dan password harus diisi.",
-		})
+	var req modelMongo.LoginRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
 	defer cancel()
 
 	alumni, err := s.alumniRepo.FindAlumniByEmail(ctx, req.Email)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengambil data alumni dari database. Detail: " + err.Error(),
-		})
+		return apperror.Wrap(err, "alumni.fetch_failed")
 	}
 
 	if alumni == nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"success": false,
-			"message": "Login gagal. Email atau password salah.",
-		})
+		return apperror.Unauthorized("auth.login_failed")
 	}
 
 	if !utilsmongo.CheckPassword(req.Password, alumni.PasswordHash) {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"success": false,
-			"message": "Login gagal. Email atau password salah.",
-		})
+		return apperror.Unauthorized("auth.login_failed")
 	}
 
 	alumniToken := utilsmongo.AlumniToken{
@@ -77,17 +68,14 @@ func (s *AuthService) LoginService(c *fiber.Ctx) error {
 		Role:   alumni.Role,
 	}
 
-	token, err := utilsmongo.GenerateToken(alumniToken)
+	token, err := utilsmongo.GenerateToken(s.jwtSecret, alumniToken)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error membuat JWT token. Detail: " + err.Error(),
-		})
+		return apperror.Wrap(err, "auth.token_create_failed")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success": true,
-		"message": "Login berhasil. Token JWT telah dibuat.",
+		"message": i18n.Msg(c, "auth.login_success"),
 		"data": fiber.Map{
 			"alumni": alumni,
 			"token":  token,
@@ -111,7 +99,7 @@ func (s *AuthService) GetProfileService(c *fiber.Ctx) error {
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success": true,
-		"message": "Data profile berhasil diambil dari JWT token.",
+		"message": i18n.Msg(c, "auth.profile_fetched"),
 		"data": fiber.Map{
 			"alumni_id": alumniID,
 			"email":     email,
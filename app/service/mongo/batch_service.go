@@ -0,0 +1,382 @@
+package service
+
+import (
+	"context"
+	model "go-fiber/app/model/mongo"
+	repository "go-fiber/app/repository/mongo"
+	"go-fiber/apperror"
+	"go-fiber/audit"
+	"go-fiber/helper"
+	"go-fiber/i18n"
+	"go-fiber/outbox"
+	"go-fiber/patch"
+	"go-fiber/validation"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type BatchService struct {
+	repo          repository.IBatchRepository
+	alumniRepo    repository.IAlumniRepository
+	pekerjaanRepo repository.IPekerjaanAlumniRepository
+	auditLog      audit.Recorder
+	events        outbox.Transactor
+}
+
+func NewBatchService(repo repository.IBatchRepository, alumniRepo repository.IAlumniRepository, pekerjaanRepo repository.IPekerjaanAlumniRepository, auditLog audit.Recorder, events outbox.Transactor) *BatchService {
+	return &BatchService{repo: repo, alumniRepo: alumniRepo, pekerjaanRepo: pekerjaanRepo, auditLog: auditLog, events: events}
+}
+
+// batchStep adalah langkah batch untuk satu item. ctx berasal dari
+// transaksi sehingga repository yang dipanggil ikut masuk transaksi.
+type batchStep struct {
+	find    func(ctx context.Context, limit int) ([]string, error)
+	prepare func(ctx context.Context, id string) error
+	write   func(ctx context.Context, result *helper.BatchResult, i int, id string) (*audit.Entry, error)
+}
+
+// run menjalankan batch dalam satu transaksi dua tahap. prepare membaca dan
+// memvalidasi setiap item tanpa menulis; jika ada item yang gagal tidak ada
+// yang ditulis. write lalu menyimpan item satu per satu, dan kegagalan
+// pertama membatalkan seluruh transaksi. Kesalahan client dari kedua tahap
+// dicatat per item dan dikembalikan sebagai 422 batch_failed. Audit log dan
+// domain event ditulis di transaksi yang sama; jika audit gagal disimpan,
+// seluruh batch dibatalkan.
+func (s *BatchService) run(c *fiber.Ctx, ids []string, hasFilter bool, step batchStep) (*helper.BatchResult, error) {
+	ctx, cancel := context.WithTimeout(c.UserContext(), 30*time.Second)
+	defer cancel()
+
+	lang := i18n.Lang(c)
+	var result *helper.BatchResult
+	var entries []audit.Entry
+	err := s.repo.WithTransaction(ctx, func(ctx context.Context) error {
+		entries = nil
+		ids, err := helper.BatchIDs(ids, hasFilter, func(limit int) ([]string, error) {
+			return step.find(ctx, limit)
+		})
+		if err != nil {
+			return err
+		}
+		result = helper.NewBatchResult(ids)
+
+		for i, id := range ids {
+			if err := step.prepare(ctx, id); err != nil && !result.Fail(i, lang, err) {
+				return err
+			}
+		}
+		if err := result.Err(); err != nil {
+			return err
+		}
+
+		for i, id := range ids {
+			entry, err := step.write(ctx, result, i, id)
+			if err != nil {
+				if !result.Fail(i, lang, err) {
+					return err
+				}
+				return result.Err()
+			}
+			if entry != nil {
+				entries = append(entries, *entry)
+			}
+		}
+		if err := s.auditLog.Append(ctx, entries...); err != nil {
+			return apperror.Wrap(err, "audit.write_failed")
+		}
+		return s.events.Transaction(ctx, func(context.Context) ([]outbox.Event, error) {
+			return outbox.FromEntries(entries...), nil
+		})
+	})
+	if err != nil {
+		return nil, apperror.Wrap(err, "batch.write_failed")
+	}
+	return result, nil
+}
+
+// @Summary Batch update alumni
+// @Description Menerapkan changes (JSON Merge Patch dengan field update alumni) pada alumni yang dipilih ids atau filter, maksimal 100 data, dalam satu transaksi. Jika satu item gagal tidak ada yang disimpan dan laporan per item dikirim di details response 422 (Admin only)
+// @Tags 2. Alumni
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body model.AlumniBatchUpdateRequest true "Pilih tepat salah satu dari ids atau filter"
+// @Success 200 {object} model.SuccessResponse{data=helper.BatchResult}
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 422 {object} model.ErrorResponse{details=helper.BatchResult}
+// @Failure 500 {object} model.ErrorResponse
+// @Router /alumni/batch/update [post]
+func (s *BatchService) BatchUpdateAlumniService(c *fiber.Ctx) error {
+	var req model.AlumniBatchUpdateRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+	if err := helper.CheckBatchChanges(req.Changes, model.UpdateAlumniRequest{}, &model.UpdateAlumniRequest{}); err != nil {
+		return err
+	}
+
+	befores := map[string]*model.Alumni{}
+	changes := map[string]bson.M{}
+	result, err := s.run(c, req.IDs, !req.Filter.IsEmpty(), batchStep{
+		find: func(ctx context.Context, limit int) ([]string, error) {
+			return s.repo.FindAlumniIDs(ctx, *req.Filter, limit)
+		},
+		prepare: func(ctx context.Context, id string) error {
+			before, err := s.alumniRepo.FindAlumniByID(ctx, id)
+			if err != nil {
+				return apperror.Wrap(err, "alumni.fetch_failed")
+			}
+			if before == nil {
+				return apperror.NotFound("alumni.not_found_id")
+			}
+
+			current := model.UpdateAlumniRequest{
+				Nama:       before.Nama,
+				Jurusan:    before.Jurusan,
+				Angkatan:   before.Angkatan,
+				TahunLulus: before.TahunLulus,
+				Email:      before.Email,
+				NoTelepon:  before.NoTelepon,
+				Alamat:     before.Alamat,
+				Role:       before.Role,
+			}
+			var update model.UpdateAlumniRequest
+			touched, err := patch.Merge(current, req.Changes, &update)
+			if err != nil {
+				return err
+			}
+			update.Normalize()
+			fields := patch.Changed(&current, &update, touched)
+			if err := validation.Partial(i18n.Lang(c), &update, fields); err != nil {
+				return err
+			}
+			befores[id] = before
+			changes[id] = nil
+			if len(fields) > 0 {
+				changes[id] = bson.M(patch.Values(&update, fields))
+			}
+			return nil
+		},
+		write: func(ctx context.Context, result *helper.BatchResult, i int, id string) (*audit.Entry, error) {
+			before := befores[id]
+			if changes[id] == nil {
+				result.Succeed(i, helper.BatchUnchanged, before.Version)
+				return nil, nil
+			}
+			updated, err := s.alumniRepo.PatchAlumni(ctx, id, before.Version, changes[id])
+			if err != nil {
+				return nil, apperror.Wrap(err, "alumni.update_failed")
+			}
+			result.Succeed(i, helper.BatchUpdated, updated.Version)
+			entry := audit.NewEntry(c, audit.ActionUpdate, audit.ResourceAlumni, id, before, updated)
+			return &entry, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "batch.updated", result.Total),
+		"data":    result,
+	})
+}
+
+// @Summary Batch delete alumni
+// @Description Menghapus alumni yang dipilih ids atau filter, maksimal 100 data, dalam satu transaksi. Jika satu item gagal tidak ada yang dihapus dan laporan per item dikirim di details response 422 (Admin only)
+// @Tags 2. Alumni
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body model.AlumniBatchDeleteRequest true "Pilih tepat salah satu dari ids atau filter"
+// @Success 200 {object} model.SuccessResponse{data=helper.BatchResult}
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 422 {object} model.ErrorResponse{details=helper.BatchResult}
+// @Failure 500 {object} model.ErrorResponse
+// @Router /alumni/batch/delete [post]
+func (s *BatchService) BatchDeleteAlumniService(c *fiber.Ctx) error {
+	var req model.AlumniBatchDeleteRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+
+	befores := map[string]*model.Alumni{}
+	result, err := s.run(c, req.IDs, !req.Filter.IsEmpty(), batchStep{
+		find: func(ctx context.Context, limit int) ([]string, error) {
+			return s.repo.FindAlumniIDs(ctx, *req.Filter, limit)
+		},
+		prepare: func(ctx context.Context, id string) error {
+			before, err := s.alumniRepo.FindAlumniByID(ctx, id)
+			if err != nil {
+				return apperror.Wrap(err, "alumni.fetch_failed")
+			}
+			if before == nil {
+				return apperror.NotFound("alumni.not_found_id")
+			}
+			befores[id] = before
+			return nil
+		},
+		write: func(ctx context.Context, result *helper.BatchResult, i int, id string) (*audit.Entry, error) {
+			before := befores[id]
+			if err := s.alumniRepo.DeleteAlumni(ctx, id, before.Version); err != nil {
+				return nil, apperror.Wrap(err, "alumni.delete_failed")
+			}
+			result.Succeed(i, helper.BatchDeleted, 0)
+			entry := audit.NewEntry(c, audit.ActionDelete, audit.ResourceAlumni, id, before, nil)
+			return &entry, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "batch.deleted", result.Total),
+		"data":    result,
+	})
+}
+
+// @Summary Batch update pekerjaan alumni
+// @Description Menerapkan changes (JSON Merge Patch dengan field update pekerjaan) pada pekerjaan yang dipilih ids atau filter, maksimal 100 data, dalam satu transaksi, misalnya menandai semua pekerjaan di perusahaan yang tutup sebagai selesai. Jika satu item gagal tidak ada yang disimpan dan laporan per item dikirim di details response 422 (Admin only)
+// @Tags 3. Pekerjaan Alumni
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body model.PekerjaanBatchUpdateRequest true "Pilih tepat salah satu dari ids atau filter"
+// @Success 200 {object} model.SuccessResponse{data=helper.BatchResult}
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 422 {object} model.ErrorResponse{details=helper.BatchResult}
+// @Failure 500 {object} model.ErrorResponse
+// @Router /pekerjaan/batch/update [post]
+func (s *BatchService) BatchUpdatePekerjaanAlumniService(c *fiber.Ctx) error {
+	var req model.PekerjaanBatchUpdateRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+	if err := helper.CheckBatchChanges(req.Changes, model.UpdatePekerjaanAlumniRequest{}, &model.UpdatePekerjaanAlumniRequest{}); err != nil {
+		return err
+	}
+
+	befores := map[string]*model.PekerjaanAlumni{}
+	changes := map[string]bson.M{}
+	result, err := s.run(c, req.IDs, !req.Filter.IsEmpty(), batchStep{
+		find: func(ctx context.Context, limit int) ([]string, error) {
+			return s.repo.FindPekerjaanAlumniIDs(ctx, *req.Filter, limit)
+		},
+		prepare: func(ctx context.Context, id string) error {
+			before, err := s.pekerjaanRepo.FindPekerjaanAlumniByID(ctx, id)
+			if err != nil {
+				return apperror.Wrap(err, "pekerjaan.fetch_failed")
+			}
+			if before == nil {
+				return apperror.NotFound("pekerjaan.not_found_id")
+			}
+
+			current := pekerjaanUpdateRequest(before)
+			var update model.UpdatePekerjaanAlumniRequest
+			touched, err := patch.Merge(current, req.Changes, &update)
+			if err != nil {
+				return err
+			}
+			fields := patch.Changed(&current, &update, touched)
+			if err := validation.Partial(i18n.Lang(c), &update, fields); err != nil {
+				return err
+			}
+			befores[id] = before
+			changes[id] = nil
+			if len(fields) > 0 {
+				changes[id], err = pekerjaanPatchFields(&update, fields)
+			}
+			return err
+		},
+		write: func(ctx context.Context, result *helper.BatchResult, i int, id string) (*audit.Entry, error) {
+			before := befores[id]
+			if changes[id] == nil {
+				result.Succeed(i, helper.BatchUnchanged, before.Version)
+				return nil, nil
+			}
+			updated, err := s.pekerjaanRepo.PatchPekerjaanAlumni(ctx, id, before.Version, changes[id])
+			if err != nil {
+				return nil, apperror.Wrap(err, "pekerjaan.update_failed")
+			}
+			result.Succeed(i, helper.BatchUpdated, updated.Version)
+			entry := audit.NewEntry(c, audit.ActionUpdate, audit.ResourcePekerjaan, id, before, updated)
+			return &entry, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "batch.updated", result.Total),
+		"data":    result,
+	})
+}
+
+// @Summary Batch delete pekerjaan alumni
+// @Description Menghapus pekerjaan yang dipilih ids atau filter, maksimal 100 data, dalam satu transaksi. Jika satu item gagal tidak ada yang dihapus dan laporan per item dikirim di details response 422 (Admin only)
+// @Tags 3. Pekerjaan Alumni
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body model.PekerjaanBatchDeleteRequest true "Pilih tepat salah satu dari ids atau filter"
+// @Success 200 {object} model.SuccessResponse{data=helper.BatchResult}
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 422 {object} model.ErrorResponse{details=helper.BatchResult}
+// @Failure 500 {object} model.ErrorResponse
+// @Router /pekerjaan/batch/delete [post]
+func (s *BatchService) BatchDeletePekerjaanAlumniService(c *fiber.Ctx) error {
+	var req model.PekerjaanBatchDeleteRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+
+	befores := map[string]*model.PekerjaanAlumni{}
+	result, err := s.run(c, req.IDs, !req.Filter.IsEmpty(), batchStep{
+		find: func(ctx context.Context, limit int) ([]string, error) {
+			return s.repo.FindPekerjaanAlumniIDs(ctx, *req.Filter, limit)
+		},
+		prepare: func(ctx context.Context, id string) error {
+			before, err := s.pekerjaanRepo.FindPekerjaanAlumniByID(ctx, id)
+			if err != nil {
+				return apperror.Wrap(err, "pekerjaan.fetch_failed")
+			}
+			if before == nil {
+				return apperror.NotFound("pekerjaan.not_found_id")
+			}
+			befores[id] = before
+			return nil
+		},
+		write: func(ctx context.Context, result *helper.BatchResult, i int, id string) (*audit.Entry, error) {
+			before := befores[id]
+			if err := s.pekerjaanRepo.DeletePekerjaanAlumni(ctx, id, before.Version); err != nil {
+				return nil, apperror.Wrap(err, "pekerjaan.delete_failed")
+			}
+			result.Succeed(i, helper.BatchDeleted, 0)
+			entry := audit.NewEntry(c, audit.ActionDelete, audit.ResourcePekerjaan, id, before, nil)
+			return &entry, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "batch.deleted", result.Total),
+		"data":    result,
+	})
+}
@@ -0,0 +1,445 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	model "go-fiber/app/model/mongo"
+	repository "go-fiber/app/repository/mongo"
+	"go-fiber/apperror"
+	"go-fiber/helper"
+	"go-fiber/queue"
+	"go-fiber/worker"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// exportAsyncThreshold adalah jumlah dokumen di atas mana export otomatis
+// dijalankan sebagai job background walaupun async=false.
+const exportAsyncThreshold = 10000
+
+// exportTimeout membatasi lama satu export berjalan, baik streaming langsung
+// maupun job background.
+const exportTimeout = 10 * time.Minute
+
+var tabularExportFormats = map[string]bool{"csv": true, "xlsx": true}
+
+type ExportService struct {
+	repo    repository.IExportRepository
+	exports *worker.Exporter
+}
+
+// NewExportService membuat service export. exports dibuat dengan
+// ExportBuilder dari repository yang sama.
+func NewExportService(repo repository.IExportRepository, exports *worker.Exporter) *ExportService {
+	return &ExportService{repo: repo, exports: exports}
+}
+
+// exportRequest adalah parameter export yang sudah divalidasi. Nilainya
+// disimpan di payload job sehingga export bisa dibuat ulang oleh worker.
+type exportRequest struct {
+	Kind         string                `json:"kind"`
+	Format       string                `json:"format"`
+	Columns      []string              `json:"columns,omitempty"`
+	Report       string                `json:"report,omitempty"`
+	Filter       model.AnalyticsFilter `json:"filter"`
+	Dimension    string                `json:"dimension,omitempty"`
+	Status       string                `json:"status,omitempty"`
+	Limit        int                   `json:"limit,omitempty"`
+	Currency     string                `json:"currency,omitempty"`
+	MinGroupSize int                   `json:"min_group_size,omitempty"`
+}
+
+func parseExportFormat(c *fiber.Ctx) (string, error) {
+	format := strings.ToLower(c.Query("format", "csv"))
+	if !tabularExportFormats[format] {
+		return "", apperror.Validation("query.invalid_format")
+	}
+	return format, nil
+}
+
+func exportColumns(v interface{}, c *fiber.Ctx, whitelist map[string]bool) ([]string, error) {
+	fields := helper.ParseListParam(c.Query("fields"))
+	if invalid := helper.InvalidItems(fields, whitelist); len(invalid) > 0 {
+		return nil, apperror.Validation("query.invalid_fields", strings.Join(invalid, ", "))
+	}
+	return helper.FilterColumns(helper.JSONColumns(v), fields, whitelist), nil
+}
+
+// @Summary Export data alumni
+// @Description Mengunduh seluruh data alumni sebagai CSV atau XLSX. Export besar atau async=true dijalankan sebagai job background (Admin only)
+// @Tags 6. Export
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param format query string false "Format file: csv atau xlsx" default(csv)
+// @Param fields query string false "Kolom yang diekspor, dipisah koma (contoh: nim,nama,email)"
+// @Param async query bool false "Jalankan export sebagai job background"
+// @Success 200 {file} file
+// @Success 202 {object} model.SuccessResponse{data=worker.ExportJob}
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Failure 503 {object} model.ErrorResponse
+// @Router /export/alumni [get]
+func (s *ExportService) ExportAlumniService(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	format, err := parseExportFormat(c)
+	if err != nil {
+		return err
+	}
+
+	columns, err := exportColumns(model.Alumni{}, c, alumniFieldWhitelist)
+	if err != nil {
+		return err
+	}
+
+	total, err := s.repo.CountAlumni(ctx)
+	if err != nil {
+		return apperror.Wrap(err, "export.alumni_count_failed")
+	}
+
+	request := exportRequest{Kind: "alumni", Format: format, Columns: columns}
+	return s.exports.Send(c, c.QueryBool("async") || (total > exportAsyncThreshold && s.exports.Async()), "alumni", format, request)
+}
+
+// @Summary Export data pekerjaan alumni
+// @Description Mengunduh seluruh data pekerjaan alumni sebagai CSV atau XLSX. Export besar atau async=true dijalankan sebagai job background (Admin only)
+// @Tags 6. Export
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param format query string false "Format file: csv atau xlsx" default(csv)
+// @Param fields query string false "Kolom yang diekspor, dipisah koma (contoh: nama_perusahaan,posisi_jabatan)"
+// @Param async query bool false "Jalankan export sebagai job background"
+// @Success 200 {file} file
+// @Success 202 {object} model.SuccessResponse{data=worker.ExportJob}
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Failure 503 {object} model.ErrorResponse
+// @Router /export/pekerjaan [get]
+func (s *ExportService) ExportPekerjaanAlumniService(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	format, err := parseExportFormat(c)
+	if err != nil {
+		return err
+	}
+
+	columns, err := exportColumns(model.PekerjaanAlumni{}, c, pekerjaanFieldWhitelist)
+	if err != nil {
+		return err
+	}
+
+	total, err := s.repo.CountPekerjaanAlumni(ctx)
+	if err != nil {
+		return apperror.Wrap(err, "export.pekerjaan_count_failed")
+	}
+
+	request := exportRequest{Kind: "pekerjaan", Format: format, Columns: columns}
+	return s.exports.Send(c, c.QueryBool("async") || (total > exportAsyncThreshold && s.exports.Async()), "pekerjaan-alumni", format, request)
+}
+
+// @Summary Export hasil analytics
+// @Description Mengunduh hasil salah satu endpoint analytics sebagai CSV atau XLSX dengan parameter query yang sama (Admin only)
+// @Tags 6. Export
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param report path string true "employment-rate, time-to-employment, distribution, top-employers, atau salary"
+// @Param format query string false "Format file: csv atau xlsx" default(csv)
+// @Param group_by query string false "Kelompokkan berdasarkan angkatan, tahun_lulus, atau jurusan (salary juga bidang_industri)"
+// @Param angkatan query int false "Filter angkatan"
+// @Param tahun_lulus query int false "Filter tahun lulus"
+// @Param jurusan query string false "Filter jurusan"
+// @Param async query bool false "Jalankan export sebagai job background"
+// @Success 200 {file} file
+// @Success 202 {object} model.SuccessResponse{data=worker.ExportJob}
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Failure 503 {object} model.ErrorResponse
+// @Router /export/analytics/{report} [get]
+func (s *ExportService) ExportAnalyticsService(c *fiber.Ctx) error {
+	report := c.Params("report")
+
+	groupByWhitelist := analyticsGroupByWhitelist
+	if report == "salary" {
+		groupByWhitelist = salaryGroupByWhitelist
+	}
+
+	filter, err := parseAnalyticsFilter(c, groupByWhitelist)
+	if err != nil {
+		return err
+	}
+
+	format, err := parseExportFormat(c)
+	if err != nil {
+		return err
+	}
+
+	request := exportRequest{Kind: "analytics", Format: format, Report: report, Filter: filter}
+	switch report {
+	case "employment-rate", "time-to-employment":
+	case "distribution":
+		request.Dimension, request.Status, err = parseDistributionQuery(c)
+	case "top-employers":
+		request.Limit = parseTopEmployersLimit(c)
+	case "salary":
+		request.Currency, request.MinGroupSize, err = parseSalaryQuery(c)
+	default:
+		return apperror.NotFound("analytics.report_not_found")
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.exports.Send(c, c.QueryBool("async"), "analytics-"+report, format, request)
+}
+
+// @Summary Laporan PDF tracer study
+// @Description Membuat laporan PDF yang merangkum keterserapan kerja, waktu tunggu, distribusi industri, perusahaan teratas, dan statistik gaji per kelompok (Admin only)
+// @Tags 6. Export
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param group_by query string false "Kelompokkan berdasarkan angkatan, tahun_lulus, atau jurusan" default(angkatan)
+// @Param angkatan query int false "Filter angkatan"
+// @Param tahun_lulus query int false "Filter tahun lulus"
+// @Param jurusan query string false "Filter jurusan"
+// @Param async query bool false "Jalankan export sebagai job background"
+// @Success 200 {file} file
+// @Success 202 {object} model.SuccessResponse{data=worker.ExportJob}
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Failure 503 {object} model.ErrorResponse
+// @Router /export/report/tracer-study [get]
+func (s *ExportService) ExportTracerStudyReportService(c *fiber.Ctx) error {
+	filter, err := parseAnalyticsFilter(c, analyticsGroupByWhitelist)
+	if err != nil {
+		return err
+	}
+	if filter.GroupBy == "" {
+		filter.GroupBy = "angkatan"
+	}
+
+	request := exportRequest{Kind: "tracer-study", Format: "pdf", Filter: filter}
+	return s.exports.Send(c, c.QueryBool("async"), "tracer-study", "pdf", request)
+}
+
+// @Summary Status job export
+// @Description Mengambil status job export background milik admin yang sedang login (Admin only)
+// @Tags 6. Export
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID job export"
+// @Success 200 {object} model.SuccessResponse{data=worker.ExportJob}
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Router /export/jobs/{id} [get]
+func (s *ExportService) GetExportJobService(c *fiber.Ctx) error {
+	return s.exports.JobStatusHandler(c)
+}
+
+// @Summary Unduh hasil job export
+// @Description Mengunduh file hasil job export yang sudah selesai (Admin only)
+// @Tags 6. Export
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param id path string true "ID job export"
+// @Success 200 {file} file
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Router /export/jobs/{id}/download [get]
+func (s *ExportService) DownloadExportJobService(c *fiber.Ctx) error {
+	return s.exports.DownloadHandler(c)
+}
+
+// ExportBuilder membuat export dari exportRequest, baik untuk request yang
+// dikirim langsung maupun job export di queue.
+func ExportBuilder(repo repository.IExportRepository, analytics *AnalyticsService) worker.ExportBuilder {
+	return func(ctx context.Context, data json.RawMessage) (worker.ExportFunc, error) {
+		var request exportRequest
+		if err := json.Unmarshal(data, &request); err != nil {
+			return nil, queue.Permanent(err)
+		}
+
+		switch request.Kind {
+		case "alumni":
+			return exportAlumni(repo, request), nil
+		case "pekerjaan":
+			return exportPekerjaanAlumni(repo, request), nil
+		case "analytics":
+			return exportAnalytics(ctx, analytics, request)
+		case "tracer-study":
+			return exportTracerStudyReport(ctx, analytics, request.Filter)
+		}
+		return nil, queue.Permanent(fmt.Errorf("jenis export %q tidak dikenal", request.Kind))
+	}
+}
+
+func exportAlumni(repo repository.IExportRepository, request exportRequest) worker.ExportFunc {
+	return func(ctx context.Context, w io.Writer) error {
+		ctx, cancel := context.WithTimeout(ctx, exportTimeout)
+		defer cancel()
+
+		writer, err := helper.NewTabularWriter(request.Format, w)
+		if err != nil {
+			return err
+		}
+		if err := writer.WriteRow(request.Columns); err != nil {
+			return err
+		}
+
+		err = repo.StreamAlumni(ctx, func(alumni model.Alumni) error {
+			values, err := helper.RowValues(alumni, request.Columns)
+			if err != nil {
+				return err
+			}
+			return writer.WriteRow(values)
+		})
+		if err != nil {
+			return err
+		}
+		return writer.Close()
+	}
+}
+
+func exportPekerjaanAlumni(repo repository.IExportRepository, request exportRequest) worker.ExportFunc {
+	return func(ctx context.Context, w io.Writer) error {
+		ctx, cancel := context.WithTimeout(ctx, exportTimeout)
+		defer cancel()
+
+		writer, err := helper.NewTabularWriter(request.Format, w)
+		if err != nil {
+			return err
+		}
+		if err := writer.WriteRow(request.Columns); err != nil {
+			return err
+		}
+
+		err = repo.StreamPekerjaanAlumni(ctx, func(pekerjaan model.PekerjaanAlumni) error {
+			values, err := helper.RowValues(pekerjaan, request.Columns)
+			if err != nil {
+				return err
+			}
+			return writer.WriteRow(values)
+		})
+		if err != nil {
+			return err
+		}
+		return writer.Close()
+	}
+}
+
+// exportAnalytics menghitung hasil report sebelum export ditulis sehingga
+// query yang gagal menjadi response error, bukan file kosong.
+func exportAnalytics(ctx context.Context, analytics *AnalyticsService, request exportRequest) (worker.ExportFunc, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var result interface{}
+	var err error
+	switch request.Report {
+	case "employment-rate":
+		result, err = analytics.employmentRates(ctx, request.Filter)
+	case "time-to-employment":
+		result, err = analytics.timeToEmployment(ctx, request.Filter)
+	case "distribution":
+		result, err = analytics.repo.GetEmploymentDistribution(ctx, request.Filter, request.Dimension, request.Status)
+	case "top-employers":
+		result, err = analytics.repo.GetTopEmployers(ctx, request.Filter, request.Limit)
+	case "salary":
+		result, _, err = analytics.salaryStatistics(ctx, request.Filter, request.Currency, request.MinGroupSize)
+	default:
+		return nil, apperror.NotFound("analytics.report_not_found")
+	}
+	if err != nil {
+		return nil, apperror.Wrap(err, "export.analytics_failed")
+	}
+
+	return func(ctx context.Context, w io.Writer) error {
+		return helper.WriteTable(request.Format, w, result)
+	}, nil
+}
+
+func exportTracerStudyReport(ctx context.Context, analytics *AnalyticsService, filter model.AnalyticsFilter) (worker.ExportFunc, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	rates, err := analytics.employmentRates(ctx, filter)
+	if err != nil {
+		return nil, tracerStudyReportError(err)
+	}
+	waiting, err := analytics.timeToEmployment(ctx, filter)
+	if err != nil {
+		return nil, tracerStudyReportError(err)
+	}
+	distribution, err := analytics.repo.GetEmploymentDistribution(ctx, filter, "bidang_industri", "aktif")
+	if err != nil {
+		return nil, tracerStudyReportError(err)
+	}
+	employers, err := analytics.repo.GetTopEmployers(ctx, filter, 5)
+	if err != nil {
+		return nil, tracerStudyReportError(err)
+	}
+	salaries, suppressed, err := analytics.salaryStatistics(ctx, filter, helper.DefaultCurrency, minSalaryGroupSize)
+	if err != nil {
+		return nil, tracerStudyReportError(err)
+	}
+
+	sections := []helper.ReportSection{}
+	for _, item := range []struct {
+		title string
+		note  string
+		data  interface{}
+	}{
+		{"Tingkat Keterserapan Kerja", "Persentase alumni yang memiliki pekerjaan aktif.", rates},
+		{"Waktu Tunggu Kerja", "Median hari dari 1 Januari tahun lulus sampai pekerjaan pertama.", waiting},
+		{"Distribusi Bidang Industri", "Pekerjaan dengan status aktif.", distribution},
+		{"Perusahaan dengan Alumni Terbanyak", "Lima perusahaan teratas per kelompok.", employers},
+		{"Statistik Gaji Bulanan (IDR)", fmt.Sprintf("Kelompok dengan kurang dari %d alumni disembunyikan (%d kelompok).", minSalaryGroupSize, suppressed), salaries},
+	} {
+		section, err := helper.NewReportSection(item.title, item.note, item.data)
+		if err != nil {
+			return nil, tracerStudyReportError(err)
+		}
+		sections = append(sections, section)
+	}
+
+	subtitle := "Dikelompokkan berdasarkan " + filter.GroupBy
+	if filter.Angkatan != nil {
+		subtitle += ", angkatan " + strconv.Itoa(*filter.Angkatan)
+	}
+	if filter.TahunLulus != nil {
+		subtitle += ", tahun lulus " + strconv.Itoa(*filter.TahunLulus)
+	}
+	if filter.Jurusan != "" {
+		subtitle += ", jurusan " + filter.Jurusan
+	}
+
+	return func(ctx context.Context, w io.Writer) error {
+		return helper.WritePDFReport(w, "Laporan Tracer Study Alumni", subtitle, sections)
+	}, nil
+}
+
+func tracerStudyReportError(err error) error {
+	return apperror.Wrap(err, "export.report_failed")
+}
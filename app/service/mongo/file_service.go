@@ -2,13 +2,19 @@ package service
 
 import (
 	"context"
-	"fmt"
+	"go-fiber/apperror"
+	"go-fiber/audit"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"time"
 
 	model "go-fiber/app/model/mongo"
 	repository "go-fiber/app/repository/mongo"
+	"go-fiber/config"
+	"go-fiber/i18n"
+	"go-fiber/metrics"
+	"go-fiber/outbox"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -16,16 +22,24 @@ import (
 )
 
 type FileService struct {
-	repo        repository.IFileRepository
-	alumniRepo  repository.IAlumniRepository
-	uploadPath  string
+	repo               repository.IFileRepository
+	alumniRepo         repository.IAlumniRepository
+	uploadPath         string
+	maxPhotoSize       int64
+	maxCertificateSize int64
+	auditLog           audit.Recorder
+	events             outbox.Transactor
 }
 
-func NewFileService(repo repository.IFileRepository, alumniRepo repository.IAlumniRepository, uploadPath string) *FileService {
+func NewFileService(repo repository.IFileRepository, alumniRepo repository.IAlumniRepository, cfg config.UploadConfig, auditLog audit.Recorder, events outbox.Transactor) *FileService {
 	return &FileService{
-		repo:       repo,
-		alumniRepo: alumniRepo,
-		uploadPath: uploadPath,
+		repo:               repo,
+		alumniRepo:         alumniRepo,
+		uploadPath:         cfg.Dir,
+		maxPhotoSize:       int64(cfg.MaxPhotoMB) * 1024 * 1024,
+		maxCertificateSize: int64(cfg.MaxCertificateMB) * 1024 * 1024,
+		auditLog:           auditLog,
+		events:             events,
 	}
 }
 
@@ -40,15 +54,12 @@ func NewFileService(repo repository.IFileRepository, alumniRepo repository.IAlum
 // @Failure 500 {object} model.ErrorResponse
 // @Router /files [get]
 func (s *FileService) GetAllFilesService(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
 	defer cancel()
 
 	filesList, err := s.repo.FindAllFiles(ctx)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengambil data file dari database. Detail: " + err.Error(),
-		})
+		return apperror.Wrap(err, "file.fetch_failed")
 	}
 
 	var responses []model.FileResponse
@@ -58,7 +69,7 @@ func (s *FileService) GetAllFilesService(c *fiber.Ctx) error {
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success": true,
-		"message": "Data file berhasil diambil dari database.",
+		"message": i18n.Msg(c, "file.fetched"),
 		"data":    responses,
 	})
 }
@@ -78,27 +89,21 @@ func (s *FileService) GetAllFilesService(c *fiber.Ctx) error {
 // @Router /files/{id} [get]
 func (s *FileService) GetFileByIDService(c *fiber.Ctx) error {
 	id := c.Params("id")
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
 	defer cancel()
 
 	file, err := s.repo.FindFileByID(ctx, id)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengambil data file dari database. Detail: " + err.Error(),
-		})
+		return apperror.Wrap(err, "file.fetch_failed")
 	}
 
 	if file == nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"success": false,
-			"message": "Data file dengan ID tersebut tidak ditemukan di database.",
-		})
+		return apperror.NotFound("file.not_found_id")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success": true,
-		"message": "Data file berhasil diambil dari database.",
+		"message": i18n.Msg(c, "file.fetched"),
 		"data":    s.toFileResponse(file),
 	})
 }
@@ -111,14 +116,19 @@ func (s *FileService) GetFileByIDService(c *fiber.Ctx) error {
 // @Security BearerAuth
 // @Param file formData file true "File foto (JPEG/JPG/PNG, max 1MB)"
 // @Param alumni_id formData string true "Alumni ID (MongoDB ObjectID)"
+// @Param Idempotency-Key header string false "Key unik per request; retry dengan key dan body sama mengembalikan response pertama"
 // @Success 201 {object} model.SuccessResponse{data=model.FileResponse}
+// @Header 201 {string} Idempotent-Replayed "true jika response dikirim ulang dari Idempotency-Key"
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
 // @Failure 403 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 422 {object} model.ErrorResponse
+// @Failure 429 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
 // @Router /files/upload/foto [post]
 func (s *FileService) UploadFotoService(c *fiber.Ctx) error {
-	return s.uploadFile(c, "foto", []string{"image/jpeg", "image/jpg", "image/png"}, 1*1024*1024)
+	return s.uploadFile(c, "foto", []string{"image/jpeg", "image/jpg", "image/png"}, s.maxPhotoSize)
 }
 
 // @Summary Upload sertifikat alumni
@@ -129,44 +139,36 @@ func (s *FileService) UploadFotoService(c *fiber.Ctx) error {
 // @Security BearerAuth
 // @Param file formData file true "File sertifikat (PDF, max 2MB)"
 // @Param alumni_id formData string true "Alumni ID (MongoDB ObjectID)"
+// @Param Idempotency-Key header string false "Key unik per request; retry dengan key dan body sama mengembalikan response pertama"
 // @Success 201 {object} model.SuccessResponse{data=model.FileResponse}
+// @Header 201 {string} Idempotent-Replayed "true jika response dikirim ulang dari Idempotency-Key"
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
 // @Failure 403 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 422 {object} model.ErrorResponse
+// @Failure 429 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
 // @Router /files/upload/sertifikat [post]
 func (s *FileService) UploadSertifikatService(c *fiber.Ctx) error {
-	return s.uploadFile(c, "sertifikat", []string{"application/pdf"}, 2*1024*1024)
+	return s.uploadFile(c, "sertifikat", []string{"application/pdf"}, s.maxCertificateSize)
 }
 
-func (s *FileService) uploadFile(c *fiber.Ctx, category string, allowedTypes []string, maxSize int64) error {
-	form, err := c.MultipartForm()
-	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Gagal parsing multipart form",
-			"error":   err.Error(),
-		})
-	}
+func (s *FileService) uploadFile(c *fiber.Ctx, category string, allowedTypes []string, maxSize int64) (err error) {
+	var uploadedSize int64
+	defer func() { metrics.ObserveUpload(category, apperror.ResponseStatus(c, err), uploadedSize) }()
 
-	fmt.Printf("Form values: %+v\n", form.Value)
-	fmt.Printf("Form files: %+v\n", form.File)
+	if _, err := c.MultipartForm(); err != nil {
+		return apperror.Validation("file.multipart_failed").WithCause(err)
+	}
 
 	fileHeader, err := c.FormFile("file")
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "File tidak ditemukan dalam request. Pastikan key 'file' ada di form-data",
-			"error":   err.Error(),
-			"debug":   "Gunakan key 'file' untuk upload file di Postman form-data",
-		})
+		return apperror.Validation("file.missing").WithCause(err)
 	}
 
 	if fileHeader.Size > maxSize {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": fmt.Sprintf("Ukuran file melebihi batas maksimal %d MB", maxSize/(1024*1024)),
-		})
+		return apperror.Validation("file.too_large", maxSize/(1024*1024))
 	}
 
 	contentType := fileHeader.Header.Get("Content-Type")
@@ -175,46 +177,30 @@ func (s *FileService) uploadFile(c *fiber.Ctx, category string, allowedTypes []s
 		if category == "sertifikat" {
 			expectedTypes = "PDF"
 		}
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": fmt.Sprintf("Tipe file tidak diizinkan. Untuk %s, gunakan format: %s", category, expectedTypes),
-			"received_type": contentType,
-		})
+		return apperror.Validation("file.unsupported_type", category, expectedTypes).WithCode("unsupported_file_type").WithDetails(fiber.Map{"received_type": contentType})
 	}
 
 	alumniID := c.FormValue("alumni_id")
 	if alumniID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Alumni ID wajib diisi",
-		})
+		return apperror.Validation("alumni.id_required")
 	}
 
 	alumniObjID, err := primitive.ObjectIDFromHex(alumniID)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Alumni ID tidak valid",
-		})
+		return apperror.Validation("alumni.invalid_id")
 	}
 
 	// Query alumni data untuk populate AlumniInfo
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
 	defer cancel()
 
 	alumni, err := s.alumniRepo.FindAlumniByID(ctx, alumniID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengambil data alumni. Detail: " + err.Error(),
-		})
+		return apperror.Wrap(err, "alumni.fetch_failed")
 	}
 
 	if alumni == nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"success": false,
-			"message": "Alumni tidak ditemukan",
-		})
+		return apperror.NotFound("alumni.not_found")
 	}
 
 	ext := filepath.Ext(fileHeader.Filename)
@@ -222,39 +208,23 @@ func (s *FileService) uploadFile(c *fiber.Ctx, category string, allowedTypes []s
 	filePath := filepath.Join(s.uploadPath, category, newFileName)
 
 	if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Gagal membuat direktori upload",
-			"error":   err.Error(),
-		})
+		return apperror.Wrap(err, "file.mkdir_failed")
 	}
 
 	file, err := fileHeader.Open()
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Gagal membuka file",
-			"error":   err.Error(),
-		})
+		return apperror.Wrap(err, "file.open_failed")
 	}
 	defer file.Close()
 
 	out, err := os.Create(filePath)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Gagal membuat file",
-			"error":   err.Error(),
-		})
+		return apperror.Wrap(err, "file.create_failed")
 	}
 	defer out.Close()
 
 	if _, err := out.ReadFrom(file); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Gagal menulis file",
-			"error":   err.Error(),
-		})
+		return apperror.Wrap(err, "file.write_failed")
 	}
 
 	fileModel := &model.File{
@@ -272,19 +242,24 @@ func (s *FileService) uploadFile(c *fiber.Ctx, category string, allowedTypes []s
 		Category:     category,
 	}
 
-	createdFile, err := s.repo.CreateFile(ctx, fileModel)
+	var createdFile *model.File
+	err = withEvents(ctx, s.events, s.auditLog, func(ctx context.Context) ([]audit.Entry, error) {
+		result, err := s.repo.CreateFile(ctx, fileModel)
+		if err != nil {
+			return nil, apperror.Wrap(err, "file.save_metadata_failed")
+		}
+		createdFile = result
+		return []audit.Entry{audit.NewEntry(c, audit.ActionCreate, audit.ResourceFile, createdFile.ID, nil, s.toFileResponse(createdFile))}, nil
+	})
 	if err != nil {
 		os.Remove(filePath)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Gagal menyimpan metadata file",
-			"error":   err.Error(),
-		})
+		return err
 	}
 
+	uploadedSize = fileHeader.Size
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"success": true,
-		"message": "File berhasil diupload",
+		"message": i18n.Msg(c, "file.uploaded"),
 		"data":    s.toFileResponse(createdFile),
 	})
 }
@@ -304,16 +279,12 @@ func (s *FileService) uploadFile(c *fiber.Ctx, category string, allowedTypes []s
 // @Router /files/alumni/{alumni_id} [get]
 func (s *FileService) GetFilesByAlumniIDService(c *fiber.Ctx) error {
 	alumniID := c.Params("alumni_id")
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
 	defer cancel()
 
 	files, err := s.repo.FindFilesByAlumniID(ctx, alumniID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengambil data files dari database",
-			"error":   err.Error(),
-		})
+		return apperror.Wrap(err, "file.list_fetch_failed")
 	}
 
 	var responses []model.FileResponse
@@ -323,7 +294,7 @@ func (s *FileService) GetFilesByAlumniIDService(c *fiber.Ctx) error {
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success": true,
-		"message": "Data files berhasil diambil dari database",
+		"message": i18n.Msg(c, "file.list_fetched"),
 		"data":    responses,
 	})
 }
@@ -343,40 +314,35 @@ func (s *FileService) GetFilesByAlumniIDService(c *fiber.Ctx) error {
 // @Router /files/{id} [delete]
 func (s *FileService) DeleteFileService(c *fiber.Ctx) error {
 	id := c.Params("id")
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
 	defer cancel()
 
 	file, err := s.repo.FindFileByID(ctx, id)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengambil data file dari database",
-			"error":   err.Error(),
-		})
+		return apperror.Wrap(err, "file.fetch_failed")
 	}
 
 	if file == nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"success": false,
-			"message": "File tidak ditemukan",
-		})
+		return apperror.NotFound("file.not_found")
 	}
 
 	if err := os.Remove(file.FilePath); err != nil {
-		fmt.Println("Warning: Gagal menghapus file dari storage:", err)
+		slog.Warn("Gagal menghapus file dari storage", "request_id", c.Locals("request_id"), "path", file.FilePath, "error", err)
 	}
 
-	if err := s.repo.DeleteFile(ctx, id); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error menghapus file dari database",
-			"error":   err.Error(),
-		})
+	err = withEvents(ctx, s.events, s.auditLog, func(ctx context.Context) ([]audit.Entry, error) {
+		if err := s.repo.DeleteFile(ctx, id); err != nil {
+			return nil, apperror.Wrap(err, "file.delete_failed")
+		}
+		return []audit.Entry{audit.NewEntry(c, audit.ActionDelete, audit.ResourceFile, id, s.toFileResponse(file), nil)}, nil
+	})
+	if err != nil {
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success": true,
-		"message": "File berhasil dihapus",
+		"message": i18n.Msg(c, "file.deleted"),
 	})
 }
 
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"go-fiber/apperror"
+	"go-fiber/audit"
+	"go-fiber/helper"
+	"go-fiber/i18n"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Riwayat versi diturunkan dari collection audit_logs: setiap entry menyimpan
+// snapshot lengkap setelah perubahan, sehingga versi ke-n adalah after entry
+// ke-n. Audit ditulis di transaksi yang sama dengan mutasinya (lihat
+// withEvents), sehingga riwayat tidak kehilangan versi selama outbox aktif.
+
+func getHistory(c *fiber.Ctx, store audit.Store, resource string) error {
+	id := c.Params("id")
+	if !primitive.IsValidObjectID(id) {
+		return apperror.InvalidID()
+	}
+
+	entries, err := store.History(c.UserContext(), resource, id)
+	if err != nil {
+		return apperror.Wrap(err, "history.fetch_failed")
+	}
+	if len(entries) == 0 {
+		return apperror.NotFound("history.not_found")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "history.fetched"),
+		"data":    audit.Versions(entries),
+	})
+}
+
+// stateAsOf merekonstruksi data resource pada waktu ?as_of= lalu memilih
+// field sesuai ?fields=.
+func stateAsOf(c *fiber.Ctx, store audit.Store, resource, id string, fields []string) (interface{}, error) {
+	if !primitive.IsValidObjectID(id) {
+		return nil, apperror.InvalidID()
+	}
+	at, err := audit.ParseAsOf(c.Query("as_of"))
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := store.History(c.UserContext(), resource, id)
+	if err != nil {
+		return nil, apperror.Wrap(err, "history.fetch_failed")
+	}
+	state, ok := audit.StateAt(entries, at)
+	if !ok {
+		return nil, apperror.NotFound("history.not_found_at", c.Query("as_of"))
+	}
+
+	data, err := helper.SelectFields(state, fields)
+	if err != nil {
+		return nil, apperror.Wrap(err, "history.fetch_failed")
+	}
+	return data, nil
+}
+
+// versionTarget membaca parameter :version lalu mengisi dest dengan data
+// resource pada versi tersebut.
+func versionTarget(c *fiber.Ctx, store audit.Store, resource string, dest interface{}) (int, error) {
+	id := c.Params("id")
+	if !primitive.IsValidObjectID(id) {
+		return 0, apperror.InvalidID()
+	}
+	version, err := strconv.Atoi(c.Params("version"))
+	if err != nil || version < 1 {
+		return 0, apperror.Validation("history.invalid_version")
+	}
+
+	entries, err := store.History(c.UserContext(), resource, id)
+	if err != nil {
+		return 0, apperror.Wrap(err, "history.fetch_failed")
+	}
+	data, err := audit.VersionData(entries, version)
+	if err != nil {
+		return 0, err
+	}
+	if err := audit.Decode(data, dest); err != nil {
+		return 0, apperror.Wrap(err, "history.revert_failed")
+	}
+	return version, nil
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"errors"
+	"go-fiber/apperror"
+	"go-fiber/i18n"
+	"go-fiber/queue"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type JobService struct {
+	queue *queue.Queue
+}
+
+func NewJobService(q *queue.Queue) *JobService {
+	return &JobService{queue: q}
+}
+
+// jobError mengubah error queue menjadi 404 atau 409 dengan invalidStateKey;
+// error lain dibungkus dengan key.
+func jobError(err error, invalidStateKey, key string) error {
+	switch {
+	case errors.Is(err, queue.ErrNotFound):
+		return apperror.NotFound("job.not_found")
+	case errors.Is(err, queue.ErrInvalidState) && invalidStateKey != "":
+		return apperror.Conflict(invalidStateKey)
+	}
+	return apperror.Wrap(err, key)
+}
+
+// @Summary Dapatkan semua job
+// @Description Mengambil background job, terbaru lebih dulu (Admin only)
+// @Tags 9. Jobs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param type query string false "Tipe job"
+// @Param status query string false "Status: pending, running, succeeded, failed, atau cancelled"
+// @Param page query int false "Halaman (default 1)"
+// @Param limit query int false "Jumlah per halaman, maksimal 100 (default 20)"
+// @Success 200 {object} model.SuccessResponse{data=[]queue.Job}
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /jobs [get]
+func (s *JobService) GetJobsService(c *fiber.Ctx) error {
+	filter, err := queue.ParseFilter(c)
+	if err != nil {
+		return err
+	}
+
+	jobs, total, err := s.queue.Store().List(c.UserContext(), filter)
+	if err != nil {
+		return apperror.Wrap(err, "job.list_fetch_failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "job.list_fetched"),
+		"data":    jobs,
+		"meta":    queue.NewMeta(filter, total),
+	})
+}
+
+// @Summary Dapatkan job berdasarkan ID
+// @Description Mengambil satu background job beserta error terakhirnya (Admin only)
+// @Tags 9. Jobs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Job ID (MongoDB ObjectID)"
+// @Success 200 {object} model.SuccessResponse{data=queue.Job}
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /jobs/{id} [get]
+func (s *JobService) GetJobService(c *fiber.Ctx) error {
+	job, err := s.queue.Store().Get(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return jobError(err, "", "job.fetch_failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "job.fetched"),
+		"data":    job,
+	})
+}
+
+// @Summary Jalankan ulang job
+// @Description Menjadwalkan ulang job failed atau cancelled untuk segera dijalankan dengan hitungan percobaan dari nol (Admin only)
+// @Tags 9. Jobs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Job ID (MongoDB ObjectID)"
+// @Success 202 {object} model.SuccessResponse{data=queue.Job}
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /jobs/{id}/retry [post]
+func (s *JobService) RetryJobService(c *fiber.Ctx) error {
+	job, err := s.queue.Store().Retry(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return jobError(err, "job.not_retryable", "job.retry_failed")
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "job.retried"),
+		"data":    job,
+	})
+}
+
+// @Summary Batalkan job
+// @Description Membatalkan job yang belum berjalan (Admin only)
+// @Tags 9. Jobs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Job ID (MongoDB ObjectID)"
+// @Success 200 {object} model.SuccessResponse{data=queue.Job}
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /jobs/{id}/cancel [post]
+func (s *JobService) CancelJobService(c *fiber.Ctx) error {
+	job, err := s.queue.Store().Cancel(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return jobError(err, "job.not_cancellable", "job.cancel_failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "job.cancelled"),
+		"data":    job,
+	})
+}
+
+// @Summary Dapatkan jadwal job
+// @Description Mengambil jadwal cron yang terdaftar beserta waktu jalan berikutnya dalam UTC (Admin only)
+// @Tags 9. Jobs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} model.SuccessResponse{data=[]queue.ScheduleInfo}
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Router /jobs/schedules [get]
+func (s *JobService) GetJobSchedulesService(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "job.schedules_fetched"),
+		"data":    s.queue.Schedules(),
+	})
+}
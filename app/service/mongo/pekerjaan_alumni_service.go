@@ -4,17 +4,55 @@ import (
 	"context"
 	model "go-fiber/app/model/mongo"
 	repository "go-fiber/app/repository/mongo"
+	"go-fiber/apperror"
+	"go-fiber/audit"
+	"go-fiber/etag"
+	"go-fiber/helper"
+	"go-fiber/i18n"
+	"go-fiber/outbox"
+	"go-fiber/patch"
+	"go-fiber/validation"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 type PekerjaanAlumniService struct {
-	repo repository.IPekerjaanAlumniRepository
+	repo           repository.IPekerjaanAlumniRepository
+	requireIfMatch bool
+	auditLog       audit.Store
+	events         outbox.Transactor
 }
 
-func NewPekerjaanAlumniService(repo repository.IPekerjaanAlumniRepository) *PekerjaanAlumniService {
-	return &PekerjaanAlumniService{repo: repo}
+// NewPekerjaanAlumniService menerima requireIfMatch dari
+// app.require_if_match untuk menolak PUT/DELETE tanpa If-Match.
+func NewPekerjaanAlumniService(repo repository.IPekerjaanAlumniRepository, requireIfMatch bool, auditLog audit.Store, events outbox.Transactor) *PekerjaanAlumniService {
+	return &PekerjaanAlumniService{repo: repo, requireIfMatch: requireIfMatch, auditLog: auditLog, events: events}
+}
+
+var pekerjaanFieldWhitelist = map[string]bool{"id": true, "alumni_info": true, "nama_perusahaan": true, "posisi_jabatan": true, "bidang_industri": true, "lokasi_kerja": true, "gaji_range": true, "gaji_min": true, "gaji_max": true, "gaji_currency": true, "gaji_period": true, "tanggal_mulai_kerja": true, "tanggal_selesai_kerja": true, "status_pekerjaan": true, "deskripsi_pekerjaan": true, "version": true, "created_at": true, "updated_at": true}
+
+func parsePekerjaanFields(c *fiber.Ctx) ([]string, error) {
+	fields := helper.ParseListParam(c.Query("fields"))
+	if invalid := helper.InvalidItems(fields, pekerjaanFieldWhitelist); len(invalid) > 0 {
+		return nil, apperror.Validation("query.invalid_fields", strings.Join(invalid, ", "))
+	}
+	return fields, nil
+}
+
+// salaryFields memecah gaji terstruktur menjadi field dokumen. Jika gaji_range
+// tidak dikirim, teksnya dibentuk dari nilai terstruktur.
+func salaryFields(gajiRange *string, salary *helper.Salary) (*string, *int64, *int64, *string, *string) {
+	if salary == nil {
+		return gajiRange, nil, nil, nil, nil
+	}
+	if gajiRange == nil || strings.TrimSpace(*gajiRange) == "" {
+		text := salary.String()
+		gajiRange = &text
+	}
+	return gajiRange, &salary.Min, &salary.Max, &salary.Currency, &salary.Period
 }
 
 // @Summary Dapatkan semua pekerjaan alumni
@@ -23,26 +61,35 @@ func NewPekerjaanAlumniService(repo repository.IPekerjaanAlumniRepository) *Peke
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param fields query string false "Field yang ditampilkan, dipisah koma (contoh: nama_perusahaan,posisi_jabatan)"
 // @Success 200 {object} model.SuccessResponse{data=[]model.PekerjaanAlumni}
+// @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
 // @Router /pekerjaan [get]
 func (s *PekerjaanAlumniService) GetAllPekerjaanAlumniService(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
 	defer cancel()
 
+	fields, err := parsePekerjaanFields(c)
+	if err != nil {
+		return err
+	}
+
 	pekerjaanList, err := s.repo.FindAllPekerjaanAlumni(ctx)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengambil data pekerjaan alumni dari database. Detail: " + err.Error(),
-		})
+		return apperror.Wrap(err, "pekerjaan.fetch_failed")
+	}
+
+	data, err := helper.SelectFields(pekerjaanList, fields)
+	if err != nil {
+		return apperror.Wrap(err, "pekerjaan.fields_failed")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success": true,
-		"message": "Data pekerjaan alumni berhasil diambil dari database.",
-		"data":    pekerjaanList,
+		"message": i18n.Msg(c, "pekerjaan.fetched"),
+		"data":    data,
 	})
 }
 
@@ -53,7 +100,12 @@ func (s *PekerjaanAlumniService) GetAllPekerjaanAlumniService(c *fiber.Ctx) erro
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Pekerjaan ID (MongoDB ObjectID)"
+// @Param fields query string false "Field yang ditampilkan, dipisah koma (contoh: nama_perusahaan,posisi_jabatan)"
+// @Param as_of query string false "Tampilkan data pada waktu tertentu dari riwayat (RFC3339 atau YYYY-MM-DD)"
+// @Param If-None-Match header string false "ETag dari response sebelumnya; 304 jika data belum berubah"
 // @Success 200 {object} model.SuccessResponse{data=model.PekerjaanAlumni}
+// @Success 304 "Data belum berubah sejak ETag pada If-None-Match"
+// @Header 200 {string} ETag "Version data pekerjaan"
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
 // @Failure 404 {object} model.ErrorResponse
@@ -61,28 +113,47 @@ func (s *PekerjaanAlumniService) GetAllPekerjaanAlumniService(c *fiber.Ctx) erro
 // @Router /pekerjaan/{id} [get]
 func (s *PekerjaanAlumniService) GetPekerjaanAlumniByIDService(c *fiber.Ctx) error {
 	id := c.Params("id")
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
 	defer cancel()
 
-	pekerjaan, err := s.repo.FindPekerjaanAlumniByID(ctx, id)
+	fields, err := parsePekerjaanFields(c)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengambil data pekerjaan alumni dari database. Detail: " + err.Error(),
+		return err
+	}
+
+	if c.Query("as_of") != "" {
+		data, err := stateAsOf(c, s.auditLog, audit.ResourcePekerjaan, id, fields)
+		if err != nil {
+			return err
+		}
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"success": true,
+			"message": i18n.Msg(c, "pekerjaan.fetched"),
+			"data":    data,
 		})
 	}
 
+	pekerjaan, err := s.repo.FindPekerjaanAlumniByID(ctx, id)
+	if err != nil {
+		return apperror.Wrap(err, "pekerjaan.fetch_failed")
+	}
+
 	if pekerjaan == nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"success": false,
-			"message": "Data pekerjaan alumni dengan ID tersebut tidak ditemukan di database.",
-		})
+		return apperror.NotFound("pekerjaan.not_found_id")
+	}
+	if etag.NotModified(c, pekerjaan.Version) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	data, err := helper.SelectFields(pekerjaan, fields)
+	if err != nil {
+		return apperror.Wrap(err, "pekerjaan.fields_failed")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success": true,
-		"message": "Data pekerjaan alumni berhasil diambil dari database.",
-		"data":    pekerjaan,
+		"message": i18n.Msg(c, "pekerjaan.fetched"),
+		"data":    data,
 	})
 }
 
@@ -101,20 +172,17 @@ func (s *PekerjaanAlumniService) GetPekerjaanAlumniByIDService(c *fiber.Ctx) err
 // @Router /pekerjaan/alumni/{alumni_id} [get]
 func (s *PekerjaanAlumniService) GetPekerjaanAlumniByAlumniIDService(c *fiber.Ctx) error {
 	alumniID := c.Params("alumni_id")
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
 	defer cancel()
 
 	pekerjaanList, err := s.repo.FindPekerjaanAlumniByAlumniID(ctx, alumniID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengambil data pekerjaan alumni berdasarkan Alumni ID dari database. Detail: " + err.Error(),
-		})
+		return apperror.Wrap(err, "pekerjaan.fetch_by_alumni_failed")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success": true,
-		"message": "Data pekerjaan alumni berdasarkan Alumni ID berhasil diambil dari database.",
+		"message": i18n.Msg(c, "pekerjaan.fetched_by_alumni"),
 		"data":    pekerjaanList,
 	})
 }
@@ -126,65 +194,43 @@ func (s *PekerjaanAlumniService) GetPekerjaanAlumniByAlumniIDService(c *fiber.Ct
 // @Produce json
 // @Security BearerAuth
 // @Param body body model.CreatePekerjaanAlumniRequest true "Pekerjaan alumni data"
+// @Param Idempotency-Key header string false "Key unik per request; retry dengan key dan body sama mengembalikan response pertama"
 // @Success 201 {object} model.SuccessResponse{data=model.PekerjaanAlumni}
+// @Header 201 {string} Idempotent-Replayed "true jika response dikirim ulang dari Idempotency-Key"
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
 // @Failure 403 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 422 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
 // @Router /pekerjaan [post]
 func (s *PekerjaanAlumniService) CreatePekerjaanAlumniService(c *fiber.Ctx) error {
 	var req model.CreatePekerjaanAlumniRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Format request body tidak valid. Pastikan JSON format benar. Detail: " + err.Error(),
-		})
-	}
-
-	if req.NamaPerusahaan == "" || req.PosisiJabatan == "" || req.BidangIndustri == "" || req.LokasiKerja == "" || req.StatusPekerjaan == "" || req.TanggalMulaiKerja == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Field wajib tidak lengkap. Nama perusahaan, posisi jabatan, bidang industri, lokasi kerja, status pekerjaan, dan tanggal mulai kerja harus diisi.",
-		})
-	}
-
-	// Validasi AlumniInfo
-	if req.AlumniInfo.NIM == "" || req.AlumniInfo.Nama == "" || req.AlumniInfo.Email == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "AlumniInfo tidak lengkap. NIM, Nama, dan Email harus diisi.",
-		})
-	}
-
-	validStatus := map[string]bool{"aktif": true, "selesai": true, "resigned": true}
-	if !validStatus[req.StatusPekerjaan] {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Status pekerjaan tidak valid. Gunakan 'aktif', 'selesai', atau 'resigned'.",
-		})
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
 	}
 
 	tanggalMulaiKerja, err := time.Parse("2006-01-02", req.TanggalMulaiKerja)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Format tanggal mulai kerja tidak valid. Gunakan format YYYY-MM-DD (contoh: 2025-01-15).",
-		})
+		return apperror.Validation("pekerjaan.invalid_start_date")
 	}
 
 	var tanggalSelesaiKerja *time.Time
 	if req.TanggalSelesaiKerja != nil && *req.TanggalSelesaiKerja != "" {
 		parsedTanggalSelesai, err := time.Parse("2006-01-02", *req.TanggalSelesaiKerja)
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"success": false,
-				"message": "Format tanggal selesai kerja tidak valid. Gunakan format YYYY-MM-DD (contoh: 2025-12-31).",
-			})
+			return apperror.Validation("pekerjaan.invalid_end_date")
 		}
 		tanggalSelesaiKerja = &parsedTanggalSelesai
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	salary, err := helper.NormalizeSalary(req.GajiRange, req.GajiMin, req.GajiMax, req.GajiCurrency, req.GajiPeriod)
+	if err != nil {
+		return apperror.Validation("pekerjaan.invalid_salary", err)
+	}
+	gajiRange, gajiMin, gajiMax, gajiCurrency, gajiPeriod := salaryFields(req.GajiRange, salary)
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
 	defer cancel()
 
 	now := time.Now()
@@ -194,7 +240,11 @@ func (s *PekerjaanAlumniService) CreatePekerjaanAlumniService(c *fiber.Ctx) erro
 		PosisiJabatan:       req.PosisiJabatan,
 		BidangIndustri:      req.BidangIndustri,
 		LokasiKerja:         req.LokasiKerja,
-		GajiRange:           req.GajiRange,
+		GajiRange:           gajiRange,
+		GajiMin:             gajiMin,
+		GajiMax:             gajiMax,
+		GajiCurrency:        gajiCurrency,
+		GajiPeriod:          gajiPeriod,
 		TanggalMulaiKerja:   tanggalMulaiKerja,
 		TanggalSelesaiKerja: tanggalSelesaiKerja,
 		StatusPekerjaan:     req.StatusPekerjaan,
@@ -203,17 +253,23 @@ func (s *PekerjaanAlumniService) CreatePekerjaanAlumniService(c *fiber.Ctx) erro
 		UpdatedAt:           now,
 	}
 
-	createdPekerjaan, err := s.repo.CreatePekerjaanAlumni(ctx, pekerjaan)
+	var createdPekerjaan *model.PekerjaanAlumni
+	err = withEvents(ctx, s.events, s.auditLog, func(ctx context.Context) ([]audit.Entry, error) {
+		result, err := s.repo.CreatePekerjaanAlumni(ctx, pekerjaan)
+		if err != nil {
+			return nil, apperror.Wrap(err, "pekerjaan.create_failed")
+		}
+		createdPekerjaan = result
+		return []audit.Entry{audit.NewEntry(c, audit.ActionCreate, audit.ResourcePekerjaan, createdPekerjaan.ID, nil, createdPekerjaan)}, nil
+	})
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error menyimpan data pekerjaan alumni ke database. Detail: " + err.Error(),
-		})
+		return err
 	}
+	etag.Set(c, createdPekerjaan.Version)
 
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"success": true,
-		"message": "Data pekerjaan alumni berhasil disimpan ke database.",
+		"message": i18n.Msg(c, "pekerjaan.created"),
 		"data":    createdPekerjaan,
 	})
 }
@@ -226,74 +282,57 @@ func (s *PekerjaanAlumniService) CreatePekerjaanAlumniService(c *fiber.Ctx) erro
 // @Security BearerAuth
 // @Param id path string true "Pekerjaan ID (MongoDB ObjectID)"
 // @Param body body model.UpdatePekerjaanAlumniRequest true "Pekerjaan alumni data"
+// @Param If-Match header string false "ETag dari GET pekerjaan; wajib jika require_if_match aktif"
 // @Success 200 {object} model.SuccessResponse{data=model.PekerjaanAlumni}
+// @Header 200 {string} ETag "Version baru data pekerjaan"
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
 // @Failure 403 {object} model.ErrorResponse
 // @Failure 404 {object} model.ErrorResponse
+// @Failure 412 {object} model.ErrorResponse
+// @Failure 428 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
 // @Router /pekerjaan/{id} [put]
 func (s *PekerjaanAlumniService) UpdatePekerjaanAlumniService(c *fiber.Ctx) error {
 	id := c.Params("id")
 	var req model.UpdatePekerjaanAlumniRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Format request body tidak valid. Pastikan JSON format benar. Detail: " + err.Error(),
-		})
-	}
-
-	if req.NamaPerusahaan == "" || req.PosisiJabatan == "" || req.BidangIndustri == "" || req.LokasiKerja == "" || req.StatusPekerjaan == "" || req.TanggalMulaiKerja == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Field wajib tidak lengkap. Nama perusahaan, posisi jabatan, bidang industri, lokasi kerja, status pekerjaan, dan tanggal mulai kerja harus diisi.",
-		})
-	}
-
-	validStatus := map[string]bool{"aktif": true, "selesai": true, "resigned": true}
-	if !validStatus[req.StatusPekerjaan] {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Status pekerjaan tidak valid. Gunakan 'aktif', 'selesai', atau 'resigned'.",
-		})
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
 	}
 
 	tanggalMulaiKerja, err := time.Parse("2006-01-02", req.TanggalMulaiKerja)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Format tanggal mulai kerja tidak valid. Gunakan format YYYY-MM-DD (contoh: 2025-01-15).",
-		})
+		return apperror.Validation("pekerjaan.invalid_start_date")
 	}
 
 	var tanggalSelesaiKerja *time.Time
 	if req.TanggalSelesaiKerja != nil && *req.TanggalSelesaiKerja != "" {
 		parsedTanggalSelesai, err := time.Parse("2006-01-02", *req.TanggalSelesaiKerja)
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"success": false,
-				"message": "Format tanggal selesai kerja tidak valid. Gunakan format YYYY-MM-DD (contoh: 2025-12-31).",
-			})
+			return apperror.Validation("pekerjaan.invalid_end_date")
 		}
 		tanggalSelesaiKerja = &parsedTanggalSelesai
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	salary, err := helper.NormalizeSalary(req.GajiRange, req.GajiMin, req.GajiMax, req.GajiCurrency, req.GajiPeriod)
+	if err != nil {
+		return apperror.Validation("pekerjaan.invalid_salary", err)
+	}
+	gajiRange, gajiMin, gajiMax, gajiCurrency, gajiPeriod := salaryFields(req.GajiRange, salary)
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
 	defer cancel()
 
 	existingPekerjaan, err := s.repo.FindPekerjaanAlumniByID(ctx, id)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengambil data pekerjaan alumni dari database. Detail: " + err.Error(),
-		})
+		return apperror.Wrap(err, "pekerjaan.fetch_failed")
 	}
 
 	if existingPekerjaan == nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"success": false,
-			"message": "Data pekerjaan alumni dengan ID tersebut tidak ditemukan di database.",
-		})
+		return apperror.NotFound("pekerjaan.not_found_id")
+	}
+	if err := etag.CheckIfMatch(c, existingPekerjaan.Version, s.requireIfMatch); err != nil {
+		return err
 	}
 
 	pekerjaan := &model.PekerjaanAlumni{
@@ -302,7 +341,11 @@ func (s *PekerjaanAlumniService) UpdatePekerjaanAlumniService(c *fiber.Ctx) erro
 		PosisiJabatan:       req.PosisiJabatan,
 		BidangIndustri:      req.BidangIndustri,
 		LokasiKerja:         req.LokasiKerja,
-		GajiRange:           req.GajiRange,
+		GajiRange:           gajiRange,
+		GajiMin:             gajiMin,
+		GajiMax:             gajiMax,
+		GajiCurrency:        gajiCurrency,
+		GajiPeriod:          gajiPeriod,
 		TanggalMulaiKerja:   tanggalMulaiKerja,
 		TanggalSelesaiKerja: tanggalSelesaiKerja,
 		StatusPekerjaan:     req.StatusPekerjaan,
@@ -310,17 +353,168 @@ func (s *PekerjaanAlumniService) UpdatePekerjaanAlumniService(c *fiber.Ctx) erro
 		UpdatedAt:           time.Now(),
 	}
 
-	updatedPekerjaan, err := s.repo.UpdatePekerjaanAlumni(ctx, id, pekerjaan)
+	var updatedPekerjaan *model.PekerjaanAlumni
+	err = withEvents(ctx, s.events, s.auditLog, func(ctx context.Context) ([]audit.Entry, error) {
+		result, err := s.repo.UpdatePekerjaanAlumni(ctx, id, existingPekerjaan.Version, pekerjaan)
+		if err != nil {
+			return nil, apperror.Wrap(err, "pekerjaan.update_failed")
+		}
+		updatedPekerjaan = result
+		return []audit.Entry{audit.NewEntry(c, audit.ActionUpdate, audit.ResourcePekerjaan, id, existingPekerjaan, updatedPekerjaan)}, nil
+	})
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengupdate data pekerjaan alumni di database. Detail: " + err.Error(),
+		return err
+	}
+	etag.Set(c, updatedPekerjaan.Version)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "pekerjaan.updated"),
+		"data":    updatedPekerjaan,
+	})
+}
+
+// pekerjaanUpdateRequest membentuk request update dari data pekerjaan saat
+// ini sebagai dokumen awal PATCH.
+func pekerjaanUpdateRequest(pekerjaan *model.PekerjaanAlumni) model.UpdatePekerjaanAlumniRequest {
+	req := model.UpdatePekerjaanAlumniRequest{
+		NamaPerusahaan:     pekerjaan.NamaPerusahaan,
+		PosisiJabatan:      pekerjaan.PosisiJabatan,
+		BidangIndustri:     pekerjaan.BidangIndustri,
+		LokasiKerja:        pekerjaan.LokasiKerja,
+		GajiRange:          pekerjaan.GajiRange,
+		GajiMin:            pekerjaan.GajiMin,
+		GajiMax:            pekerjaan.GajiMax,
+		GajiCurrency:       pekerjaan.GajiCurrency,
+		GajiPeriod:         pekerjaan.GajiPeriod,
+		TanggalMulaiKerja:  pekerjaan.TanggalMulaiKerja.Format("2006-01-02"),
+		StatusPekerjaan:    pekerjaan.StatusPekerjaan,
+		DeskripsiPekerjaan: pekerjaan.DeskripsiPekerjaan,
+	}
+	if pekerjaan.TanggalSelesaiKerja != nil {
+		tanggalSelesai := pekerjaan.TanggalSelesaiKerja.Format("2006-01-02")
+		req.TanggalSelesaiKerja = &tanggalSelesai
+	}
+	return req
+}
+
+// pekerjaanPatchFields mengubah field yang disentuh PATCH menjadi field
+// dokumen. Tanggal diparse, dan jika salah satu field gaji disentuh kelima
+// field gaji dihitung ulang seperti pada update penuh.
+func pekerjaanPatchFields(req *model.UpdatePekerjaanAlumniRequest, touched []string) (bson.M, error) {
+	fields := bson.M(patch.Values(req, touched))
+
+	if patch.Touches(touched, "tanggal_mulai_kerja") {
+		tanggalMulaiKerja, err := time.Parse("2006-01-02", req.TanggalMulaiKerja)
+		if err != nil {
+			return nil, apperror.Validation("pekerjaan.invalid_start_date")
+		}
+		fields["tanggal_mulai_kerja"] = tanggalMulaiKerja
+	}
+	if patch.Touches(touched, "tanggal_selesai_kerja") {
+		fields["tanggal_selesai_kerja"] = nil
+		if req.TanggalSelesaiKerja != nil && *req.TanggalSelesaiKerja != "" {
+			tanggalSelesaiKerja, err := time.Parse("2006-01-02", *req.TanggalSelesaiKerja)
+			if err != nil {
+				return nil, apperror.Validation("pekerjaan.invalid_end_date")
+			}
+			fields["tanggal_selesai_kerja"] = tanggalSelesaiKerja
+		}
+	}
+
+	structured := patch.Touches(touched, "gaji_min", "gaji_max", "gaji_currency", "gaji_period")
+	if structured || patch.Touches(touched, "gaji_range") {
+		if !structured {
+			req.GajiMin, req.GajiMax, req.GajiCurrency, req.GajiPeriod = nil, nil, nil, nil
+		} else if !patch.Touches(touched, "gaji_range") {
+			req.GajiRange = nil
+		}
+		salary, err := helper.NormalizeSalary(req.GajiRange, req.GajiMin, req.GajiMax, req.GajiCurrency, req.GajiPeriod)
+		if err != nil {
+			return nil, apperror.Validation("pekerjaan.invalid_salary", err)
+		}
+		gajiRange, gajiMin, gajiMax, gajiCurrency, gajiPeriod := salaryFields(req.GajiRange, salary)
+		fields["gaji_range"] = gajiRange
+		fields["gaji_min"] = gajiMin
+		fields["gaji_max"] = gajiMax
+		fields["gaji_currency"] = gajiCurrency
+		fields["gaji_period"] = gajiPeriod
+	}
+	return fields, nil
+}
+
+// @Summary Patch pekerjaan alumni
+// @Description Mengubah sebagian field pekerjaan dengan JSON Merge Patch (RFC 7396) atau JSON Patch (RFC 6902). Hanya field yang disentuh patch yang divalidasi dan diubah; field bernilai null dihapus (Admin only)
+// @Tags 3. Pekerjaan Alumni
+// @Accept json
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Pekerjaan ID (MongoDB ObjectID)"
+// @Param body body object true "Merge patch berupa object field, atau JSON Patch berupa array operasi"
+// @Param If-Match header string false "ETag dari GET pekerjaan; wajib jika require_if_match aktif"
+// @Success 200 {object} model.SuccessResponse{data=model.PekerjaanAlumni}
+// @Header 200 {string} ETag "Version baru data pekerjaan"
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 412 {object} model.ErrorResponse
+// @Failure 415 {object} model.ErrorResponse
+// @Failure 422 {object} model.ErrorResponse
+// @Failure 428 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /pekerjaan/{id} [patch]
+func (s *PekerjaanAlumniService) PatchPekerjaanAlumniService(c *fiber.Ctx) error {
+	id := c.Params("id")
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	existingPekerjaan, err := s.repo.FindPekerjaanAlumniByID(ctx, id)
+	if err != nil {
+		return apperror.Wrap(err, "pekerjaan.fetch_failed")
+	}
+
+	if existingPekerjaan == nil {
+		return apperror.NotFound("pekerjaan.not_found_id")
+	}
+	if err := etag.CheckIfMatch(c, existingPekerjaan.Version, s.requireIfMatch); err != nil {
+		return err
+	}
+
+	var req model.UpdatePekerjaanAlumniRequest
+	touched, err := patch.Apply(c, pekerjaanUpdateRequest(existingPekerjaan), &req)
+	if err != nil {
+		return err
+	}
+	if err := validation.Partial(i18n.Lang(c), &req, touched); err != nil {
+		return err
+	}
+
+	updatedPekerjaan := existingPekerjaan
+	if len(touched) > 0 {
+		fields, err := pekerjaanPatchFields(&req, touched)
+		if err != nil {
+			return err
+		}
+		err = withEvents(ctx, s.events, s.auditLog, func(ctx context.Context) ([]audit.Entry, error) {
+			result, err := s.repo.PatchPekerjaanAlumni(ctx, id, existingPekerjaan.Version, fields)
+			if err != nil {
+				return nil, apperror.Wrap(err, "pekerjaan.update_failed")
+			}
+			updatedPekerjaan = result
+			return []audit.Entry{audit.NewEntry(c, audit.ActionUpdate, audit.ResourcePekerjaan, id, existingPekerjaan, updatedPekerjaan)}, nil
 		})
+		if err != nil {
+			return err
+		}
 	}
+	etag.Set(c, updatedPekerjaan.Version)
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success": true,
-		"message": "Data pekerjaan alumni berhasil diupdate di database.",
+		"message": i18n.Msg(c, "pekerjaan.updated"),
 		"data":    updatedPekerjaan,
 	})
 }
@@ -332,43 +526,130 @@ func (s *PekerjaanAlumniService) UpdatePekerjaanAlumniService(c *fiber.Ctx) erro
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Pekerjaan ID (MongoDB ObjectID)"
+// @Param If-Match header string false "ETag dari GET pekerjaan; wajib jika require_if_match aktif"
 // @Success 200 {object} model.SuccessResponse
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 401 {object} model.ErrorResponse
 // @Failure 403 {object} model.ErrorResponse
 // @Failure 404 {object} model.ErrorResponse
+// @Failure 412 {object} model.ErrorResponse
+// @Failure 428 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
 // @Router /pekerjaan/{id} [delete]
 func (s *PekerjaanAlumniService) DeletePekerjaanAlumniService(c *fiber.Ctx) error {
 	id := c.Params("id")
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
 	defer cancel()
 
 	pekerjaan, err := s.repo.FindPekerjaanAlumniByID(ctx, id)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengambil data pekerjaan alumni dari database. Detail: " + err.Error(),
-		})
+		return apperror.Wrap(err, "pekerjaan.fetch_failed")
 	}
 
 	if pekerjaan == nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"success": false,
-			"message": "Data pekerjaan alumni dengan ID tersebut tidak ditemukan di database.",
-		})
+		return apperror.NotFound("pekerjaan.not_found_id")
+	}
+	if err := etag.CheckIfMatch(c, pekerjaan.Version, s.requireIfMatch); err != nil {
+		return err
 	}
 
-	err = s.repo.DeletePekerjaanAlumni(ctx, id)
+	err = withEvents(ctx, s.events, s.auditLog, func(ctx context.Context) ([]audit.Entry, error) {
+		if err := s.repo.DeletePekerjaanAlumni(ctx, id, pekerjaan.Version); err != nil {
+			return nil, apperror.Wrap(err, "pekerjaan.delete_failed")
+		}
+		return []audit.Entry{audit.NewEntry(c, audit.ActionDelete, audit.ResourcePekerjaan, id, pekerjaan, nil)}, nil
+	})
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error menghapus data pekerjaan alumni dari database. Detail: " + err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success": true,
-		"message": "Data pekerjaan alumni berhasil dihapus dari database.",
+		"message": i18n.Msg(c, "pekerjaan.deleted"),
+	})
+}
+
+// @Summary Riwayat versi pekerjaan alumni
+// @Description Mengambil semua versi data pekerjaan alumni beserta field yang berubah, terlama lebih dulu
+// @Tags 3. Pekerjaan Alumni
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Pekerjaan ID (MongoDB ObjectID)"
+// @Success 200 {object} model.SuccessResponse{data=[]audit.Version}
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /pekerjaan/{id}/history [get]
+func (s *PekerjaanAlumniService) GetPekerjaanAlumniHistoryService(c *fiber.Ctx) error {
+	return getHistory(c, s.auditLog, audit.ResourcePekerjaan)
+}
+
+// @Summary Kembalikan pekerjaan alumni ke versi tertentu
+// @Description Mengembalikan data pekerjaan alumni ke versi dari riwayat. Data alumni_info tetap mengikuti data saat ini. Revert dicatat sebagai versi baru (Admin only)
+// @Tags 3. Pekerjaan Alumni
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Pekerjaan ID (MongoDB ObjectID)"
+// @Param version path int true "Nomor versi dari endpoint history"
+// @Param If-Match header string false "ETag dari GET pekerjaan; wajib jika require_if_match aktif"
+// @Success 200 {object} model.SuccessResponse{data=model.PekerjaanAlumni}
+// @Header 200 {string} ETag "Version baru data pekerjaan"
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 412 {object} model.ErrorResponse
+// @Failure 422 {object} model.ErrorResponse
+// @Failure 428 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /pekerjaan/{id}/revert/{version} [post]
+func (s *PekerjaanAlumniService) RevertPekerjaanAlumniService(c *fiber.Ctx) error {
+	id := c.Params("id")
+	var target model.PekerjaanAlumni
+	version, err := versionTarget(c, s.auditLog, audit.ResourcePekerjaan, &target)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	existingPekerjaan, err := s.repo.FindPekerjaanAlumniByID(ctx, id)
+	if err != nil {
+		return apperror.Wrap(err, "pekerjaan.fetch_failed")
+	}
+
+	if existingPekerjaan == nil {
+		return apperror.NotFound("pekerjaan.not_found_id")
+	}
+
+	if err := etag.CheckIfMatch(c, existingPekerjaan.Version, s.requireIfMatch); err != nil {
+		return err
+	}
+
+	target.AlumniInfo = existingPekerjaan.AlumniInfo
+	target.UpdatedAt = time.Now()
+
+	var updatedPekerjaan *model.PekerjaanAlumni
+	err = withEvents(ctx, s.events, s.auditLog, func(ctx context.Context) ([]audit.Entry, error) {
+		result, err := s.repo.UpdatePekerjaanAlumni(ctx, id, existingPekerjaan.Version, &target)
+		if err != nil {
+			return nil, apperror.Wrap(err, "history.revert_failed")
+		}
+		updatedPekerjaan = result
+		return []audit.Entry{audit.NewEntry(c, audit.ActionRevert, audit.ResourcePekerjaan, id, existingPekerjaan, updatedPekerjaan)}, nil
+	})
+	if err != nil {
+		return err
+	}
+	etag.Set(c, updatedPekerjaan.Version)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "history.reverted", version),
+		"data":    updatedPekerjaan,
 	})
 }
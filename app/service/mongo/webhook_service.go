@@ -0,0 +1,355 @@
+package service
+
+import (
+	"errors"
+	"go-fiber/apperror"
+	"go-fiber/i18n"
+	"go-fiber/validation"
+	"go-fiber/webhook"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type WebhookService struct {
+	store webhook.Store
+}
+
+func NewWebhookService(store webhook.Store) *WebhookService {
+	return &WebhookService{store: store}
+}
+
+// webhookError mengubah webhook.ErrNotFound menjadi 404 dengan notFoundKey
+// dan webhook.ErrInvalidState menjadi 409; error lain dibungkus dengan key.
+func webhookError(err error, notFoundKey, key string) error {
+	switch {
+	case errors.Is(err, webhook.ErrNotFound):
+		return apperror.NotFound(notFoundKey)
+	case errors.Is(err, webhook.ErrInvalidState):
+		return apperror.Conflict("webhook.delivery_pending")
+	}
+	return apperror.Wrap(err, key)
+}
+
+// @Summary Dapatkan semua webhook
+// @Description Mengambil semua subscription webhook tanpa secret (Admin only)
+// @Tags 8. Webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} model.SuccessResponse{data=[]webhook.Subscription}
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /webhooks [get]
+func (s *WebhookService) GetWebhooksService(c *fiber.Ctx) error {
+	subscriptions, err := s.store.ListSubscriptions(c.UserContext())
+	if err != nil {
+		return apperror.Wrap(err, "webhook.list_fetch_failed")
+	}
+	for i := range subscriptions {
+		subscriptions[i] = subscriptions[i].WithoutSecret()
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "webhook.list_fetched"),
+		"data":    subscriptions,
+	})
+}
+
+// @Summary Dapatkan webhook berdasarkan ID
+// @Description Mengambil satu subscription webhook tanpa secret (Admin only)
+// @Tags 8. Webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook ID (MongoDB ObjectID)"
+// @Success 200 {object} model.SuccessResponse{data=webhook.Subscription}
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /webhooks/{id} [get]
+func (s *WebhookService) GetWebhookService(c *fiber.Ctx) error {
+	subscription, err := s.store.GetSubscription(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return webhookError(err, "webhook.not_found", "webhook.fetch_failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "webhook.fetched"),
+		"data":    subscription.WithoutSecret(),
+	})
+}
+
+// @Summary Daftarkan webhook
+// @Description Mendaftarkan URL penerima untuk tipe event tertentu (alumni.created, alumni.*, atau *). Secret kosong dibuatkan otomatis dan hanya ditampilkan di response ini (Admin only)
+// @Tags 8. Webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body webhook.SubscriptionRequest true "Data webhook"
+// @Success 201 {object} model.SuccessResponse{data=webhook.Subscription}
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /webhooks [post]
+func (s *WebhookService) CreateWebhookService(c *fiber.Ctx) error {
+	var req webhook.SubscriptionRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+
+	subscription, err := webhook.NewSubscription(req)
+	if err != nil {
+		return apperror.Wrap(err, "webhook.create_failed")
+	}
+	if err := s.store.CreateSubscription(c.UserContext(), &subscription); err != nil {
+		return apperror.Wrap(err, "webhook.create_failed")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "webhook.created"),
+		"data":    subscription,
+	})
+}
+
+// @Summary Update webhook
+// @Description Mengubah URL, tipe event, deskripsi, status aktif, atau secret webhook. Secret kosong berarti secret lama tetap dipakai (Admin only)
+// @Tags 8. Webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook ID (MongoDB ObjectID)"
+// @Param body body webhook.SubscriptionRequest true "Data webhook"
+// @Success 200 {object} model.SuccessResponse{data=webhook.Subscription}
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /webhooks/{id} [put]
+func (s *WebhookService) UpdateWebhookService(c *fiber.Ctx) error {
+	subscription, err := s.store.GetSubscription(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return webhookError(err, "webhook.not_found", "webhook.fetch_failed")
+	}
+
+	var req webhook.SubscriptionRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+	req.Apply(subscription)
+	if err := s.store.UpdateSubscription(c.UserContext(), subscription); err != nil {
+		return webhookError(err, "webhook.not_found", "webhook.update_failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "webhook.updated"),
+		"data":    subscription.WithoutSecret(),
+	})
+}
+
+// @Summary Hapus webhook
+// @Description Menghapus subscription webhook beserta semua log delivery-nya (Admin only)
+// @Tags 8. Webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook ID (MongoDB ObjectID)"
+// @Success 200 {object} model.SuccessResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /webhooks/{id} [delete]
+func (s *WebhookService) DeleteWebhookService(c *fiber.Ctx) error {
+	if err := s.store.DeleteSubscription(c.UserContext(), c.Params("id")); err != nil {
+		return webhookError(err, "webhook.not_found", "webhook.delete_failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "webhook.deleted"),
+	})
+}
+
+// @Summary Kirim event test
+// @Description Menjadwalkan event webhook.test ke webhook untuk memeriksa URL dan verifikasi signature. Hasilnya terlihat di log delivery (Admin only)
+// @Tags 8. Webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook ID (MongoDB ObjectID)"
+// @Success 202 {object} model.SuccessResponse{data=outbox.Event}
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 422 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /webhooks/{id}/test [post]
+func (s *WebhookService) TestWebhookService(c *fiber.Ctx) error {
+	subscription, err := s.store.GetSubscription(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return webhookError(err, "webhook.not_found", "webhook.fetch_failed")
+	}
+	if !subscription.Active {
+		return apperror.Unprocessable("webhook.inactive")
+	}
+
+	event := webhook.NewTestEvent(c, *subscription)
+	if err := s.store.EnqueueDeliveries(c.UserContext(), webhook.NewDelivery(subscription.ID, event)); err != nil {
+		return apperror.Wrap(err, "webhook.test_failed")
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "webhook.test_queued"),
+		"data":    event,
+	})
+}
+
+// @Summary Log delivery webhook
+// @Description Mengambil delivery satu webhook beserta log setiap percobaan kirim, terbaru lebih dulu (Admin only)
+// @Tags 8. Webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook ID (MongoDB ObjectID)"
+// @Param status query string false "Status: pending, succeeded, atau dead"
+// @Param page query int false "Halaman (default 1)"
+// @Param limit query int false "Jumlah per halaman, maksimal 100 (default 20)"
+// @Success 200 {object} model.SuccessResponse{data=[]webhook.Delivery}
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /webhooks/{id}/deliveries [get]
+func (s *WebhookService) GetWebhookDeliveriesService(c *fiber.Ctx) error {
+	if _, err := s.store.GetSubscription(c.UserContext(), c.Params("id")); err != nil {
+		return webhookError(err, "webhook.not_found", "webhook.fetch_failed")
+	}
+	return s.listDeliveries(c, c.Params("id"), "")
+}
+
+// @Summary Dapatkan semua delivery webhook
+// @Description Mengambil delivery semua webhook, terbaru lebih dulu (Admin only)
+// @Tags 8. Webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Status: pending, succeeded, atau dead"
+// @Param page query int false "Halaman (default 1)"
+// @Param limit query int false "Jumlah per halaman, maksimal 100 (default 20)"
+// @Success 200 {object} model.SuccessResponse{data=[]webhook.Delivery}
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /webhooks/deliveries [get]
+func (s *WebhookService) GetDeliveriesService(c *fiber.Ctx) error {
+	return s.listDeliveries(c, "", "")
+}
+
+// @Summary Dead-letter webhook
+// @Description Mengambil delivery yang berhenti dicoba karena gagal sampai batas percobaan atau webhook-nya tidak aktif (Admin only)
+// @Tags 8. Webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Halaman (default 1)"
+// @Param limit query int false "Jumlah per halaman, maksimal 100 (default 20)"
+// @Success 200 {object} model.SuccessResponse{data=[]webhook.Delivery}
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /webhooks/dead-letters [get]
+func (s *WebhookService) GetDeadLettersService(c *fiber.Ctx) error {
+	return s.listDeliveries(c, "", webhook.StatusDead)
+}
+
+func (s *WebhookService) listDeliveries(c *fiber.Ctx, subscriptionID, status string) error {
+	filter, err := webhook.ParseDeliveryFilter(c)
+	if err != nil {
+		return err
+	}
+	filter.SubscriptionID = subscriptionID
+	if status != "" {
+		filter.Status = status
+	}
+
+	deliveries, total, err := s.store.ListDeliveries(c.UserContext(), filter)
+	if err != nil {
+		return apperror.Wrap(err, "webhook.delivery_list_fetch_failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "webhook.delivery_list_fetched"),
+		"data":    deliveries,
+		"meta":    webhook.NewMeta(filter, total),
+	})
+}
+
+// @Summary Dapatkan delivery webhook
+// @Description Mengambil satu delivery beserta payload event dan log percobaannya (Admin only)
+// @Tags 8. Webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param deliveryId path string true "Delivery ID (MongoDB ObjectID)"
+// @Success 200 {object} model.SuccessResponse{data=webhook.Delivery}
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /webhooks/deliveries/{deliveryId} [get]
+func (s *WebhookService) GetDeliveryService(c *fiber.Ctx) error {
+	delivery, err := s.store.GetDelivery(c.UserContext(), c.Params("deliveryId"))
+	if err != nil {
+		return webhookError(err, "webhook.delivery_not_found", "webhook.delivery_fetch_failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "webhook.delivery_fetched"),
+		"data":    delivery,
+	})
+}
+
+// @Summary Kirim ulang delivery
+// @Description Menjadwalkan ulang delivery yang sudah berhasil atau ada di dead-letter untuk segera dikirim dengan hitungan percobaan dari nol. Delivery yang masih pending ditolak dengan 409 (Admin only)
+// @Tags 8. Webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param deliveryId path string true "Delivery ID (MongoDB ObjectID)"
+// @Success 202 {object} model.SuccessResponse{data=webhook.Delivery}
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /webhooks/deliveries/{deliveryId}/redeliver [post]
+func (s *WebhookService) RedeliverService(c *fiber.Ctx) error {
+	delivery, err := s.store.Redeliver(c.UserContext(), c.Params("deliveryId"))
+	if err != nil {
+		return webhookError(err, "webhook.delivery_not_found", "webhook.redeliver_failed")
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "webhook.redelivery_queued"),
+		"data":    delivery,
+	})
+}
@@ -4,14 +4,57 @@ import (
 	"database/sql"
 	model "go-fiber/app/model/postgre"
 	repository "go-fiber/app/repository/postgre"
+	"go-fiber/apperror"
+	"go-fiber/audit"
+	"go-fiber/etag"
+	"go-fiber/helper"
+	"go-fiber/i18n"
+	"go-fiber/patch"
 	utilspostgre "go-fiber/utils/postgre"
-	"os"
+	"go-fiber/validation"
 	"strconv"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
 )
 
+var alumniFieldWhitelist = map[string]bool{"id": true, "nim": true, "nama": true, "jurusan": true, "angkatan": true, "tahun_lulus": true, "email": true, "no_telepon": true, "alamat": true, "role_id": true, "version": true, "created_at": true, "updated_at": true}
+
+// Relasi yang bisa di-embed lewat ?include=. Data files hanya tersedia di stack MongoDB.
+var alumniIncludeWhitelist = map[string]bool{"role": true, "pekerjaan": true}
+
+// parseAlumniProjection membaca query ?fields= dan ?include=. Tanpa ?include=
+// relasi role tetap disertakan seperti perilaku sebelumnya.
+func parseAlumniProjection(c *fiber.Ctx) ([]string, []string, error) {
+	fields := helper.ParseListParam(c.Query("fields"))
+	if invalid := helper.InvalidItems(fields, alumniFieldWhitelist); len(invalid) > 0 {
+		return nil, nil, apperror.Validation("query.invalid_fields", strings.Join(invalid, ", "))
+	}
+
+	include := helper.ParseListParam(c.Query("include", "role"))
+	if invalid := helper.InvalidItems(include, alumniIncludeWhitelist); len(invalid) > 0 {
+		return nil, nil, apperror.Validation("query.invalid_include", strings.Join(invalid, ", "), "role, pekerjaan")
+	}
+
+	if len(fields) == 0 {
+		for field := range alumniFieldWhitelist {
+			fields = append(fields, field)
+		}
+	}
+	fields = append(fields, include...)
+
+	return fields, include, nil
+}
+
+func containsItem(items []string, target string) bool {
+	for _, item := range items {
+		if item == target {
+			return true
+		}
+	}
+	return false
+}
+
 func GetAllAlumniService(c *fiber.Ctx, db *sql.DB) error {
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	limit, _ := strconv.Atoi(c.Query("limit", "10"))
@@ -36,20 +79,19 @@ func GetAllAlumniService(c *fiber.Ctx, db *sql.DB) error {
 		limit = 10
 	}
 
-	alumniList, err := repository.GetAllAlumni(db, search, sortBy, order, limit, offset)
+	fields, include, err := parseAlumniProjection(c)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengambil data alumni dari database. Detail: " + err.Error(),
-		})
+		return err
 	}
 
-	total, err := repository.CountAlumni(db, search)
+	alumniList, err := repository.GetAllAlumniDetail(c.UserContext(), db, search, sortBy, order, limit, offset, containsItem(include, "pekerjaan"))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error menghitung total data alumni untuk pagination. Detail: " + err.Error(),
-		})
+		return apperror.Wrap(err, "alumni.fetch_failed")
+	}
+
+	total, err := repository.CountAlumni(c.UserContext(), db, search)
+	if err != nil {
+		return apperror.Wrap(err, "alumni.count_failed")
 	}
 
 	pages := (total + limit - 1) / limit
@@ -57,8 +99,13 @@ func GetAllAlumniService(c *fiber.Ctx, db *sql.DB) error {
 		pages = 1
 	}
 
+	data, err := helper.SelectFields(alumniList, fields)
+	if err != nil {
+		return apperror.Wrap(err, "alumni.fields_failed")
+	}
+
 	response := model.AlumniResponse{
-		Data: alumniList,
+		Data: data,
 		Meta: model.MetaInfo{
 			Page:    page,
 			Limit:   limit,
@@ -77,205 +124,271 @@ func GetAlumniByIDService(c *fiber.Ctx, db *sql.DB) error {
 	idStr := c.Params("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Parameter ID tidak valid. ID harus berupa angka positif.",
+		return apperror.Validation("error.invalid_id_param").WithCode("invalid_id")
+	}
+
+	fields, include, err := parseAlumniProjection(c)
+	if err != nil {
+		return err
+	}
+
+	if c.Query("as_of") != "" {
+		data, err := stateAsOf(c, db, audit.ResourceAlumni, id, fields)
+		if err != nil {
+			return err
+		}
+		return c.Status(fiber.StatusOK).JSON(model.GetAlumniByIDResponse{
+			Success: true,
+			Message: i18n.Msg(c, "alumni.fetched"),
+			Data:    data,
 		})
 	}
 
-	alumni, err := repository.GetAlumniByID(db, id)
+	withPekerjaan := containsItem(include, "pekerjaan")
+	alumni, err := repository.GetAlumniDetailByID(c.UserContext(), db, id, withPekerjaan)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"success": false,
-				"message": "Data alumni dengan ID tersebut tidak ditemukan di database.",
-			})
+			return apperror.NotFound("alumni.not_found_id")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengambil data alumni dari database. Detail: " + err.Error(),
-		})
+		return apperror.Wrap(err, "alumni.fetch_failed")
+	}
+	// Daftar pekerjaan bisa berubah tanpa menaikkan version alumni, jadi
+	// ETag hanya dikirim untuk response tanpa embed pekerjaan.
+	if !withPekerjaan && etag.NotModified(c, alumni.Version) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	data, err := helper.SelectFields(alumni, fields)
+	if err != nil {
+		return apperror.Wrap(err, "alumni.fields_failed")
 	}
 
 	response := model.GetAlumniByIDResponse{
 		Success: true,
-		Message: "Data alumni berhasil diambil dari database.",
-		Data:    *alumni,
+		Message: i18n.Msg(c, "alumni.fetched"),
+		Data:    data,
 	}
 
 	return c.Status(fiber.StatusOK).JSON(response)
 }
 
-func CreateAlumniService(c *fiber.Ctx, db *sql.DB) error {
+func CreateAlumniService(c *fiber.Ctx, db *sql.DB, opts Options) error {
 	var req model.CreateAlumniRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Format request body tidak valid. Pastikan JSON format benar. Detail: " + err.Error(),
-		})
-	}
-
-	if req.NIM == "" || req.Nama == "" || req.Jurusan == "" || req.Email == "" || req.Password == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Field wajib tidak lengkap. NIM, nama, jurusan, email, dan password harus diisi.",
-		})
-	}
-
-	if req.RoleID != 1 && req.RoleID != 2 {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Role ID tidak valid. Gunakan 1 untuk admin atau 2 untuk user.",
-		})
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
 	}
+	req.Normalize()
 
 	passwordHash, err := utilspostgre.HashPassword(req.Password)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengenkripsi password. Detail: " + err.Error(),
-		})
+		return apperror.Wrap(err, "auth.password_hash_failed")
 	}
 
-	alumni, err := repository.CreateAlumni(db, req, passwordHash)
+	var alumni *model.Alumni
+	err = withAudit(c, db, opts, func(tx *sql.Tx) ([]audit.Entry, error) {
+		created, err := repository.CreateAlumni(c.UserContext(), tx, req, passwordHash)
+		if err != nil {
+			return nil, apperror.Wrap(err, "alumni.create_failed")
+		}
+		alumni = created
+		return []audit.Entry{audit.NewEntry(c, audit.ActionCreate, audit.ResourceAlumni, created.ID, nil, created)}, nil
+	})
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error menyimpan data alumni ke database. Detail: " + err.Error(),
-		})
+		return err
 	}
 
+	etag.Set(c, alumni.Version)
 	response := model.CreateAlumniResponse{
 		Success: true,
-		Message: "Data alumni berhasil disimpan ke database.",
+		Message: i18n.Msg(c, "alumni.created"),
 		Data:    *alumni,
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(response)
 }
 
-func UpdateAlumniService(c *fiber.Ctx, db *sql.DB) error {
+func UpdateAlumniService(c *fiber.Ctx, db *sql.DB, opts Options) error {
 	idStr := c.Params("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Parameter ID tidak valid. ID harus berupa angka positif.",
-		})
+		return apperror.Validation("error.invalid_id_param").WithCode("invalid_id")
 	}
 
 	var req model.UpdateAlumniRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Format request body tidak valid. Pastikan JSON format benar. Detail: " + err.Error(),
-		})
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
 	}
+	req.Normalize()
+
+	var alumni *model.Alumni
+	err = withAudit(c, db, opts, func(tx *sql.Tx) ([]audit.Entry, error) {
+		before, err := repository.GetAlumniByID(c.UserContext(), tx, id)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, apperror.NotFound("alumni.not_found_id")
+			}
+			return nil, apperror.Wrap(err, "alumni.fetch_failed")
+		}
+		// Relasi role tidak dicatat; perubahan role terlihat dari role_id.
+		before.Role = nil
+		if err := etag.CheckIfMatch(c, before.Version, opts.RequireIfMatch); err != nil {
+			return nil, err
+		}
 
-	if req.Nama == "" || req.Jurusan == "" || req.Email == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Field wajib tidak lengkap. Nama, jurusan, dan email harus diisi.",
-		})
+		updated, err := repository.UpdateAlumni(c.UserContext(), tx, id, before.Version, req)
+		if err != nil {
+			return nil, apperror.Wrap(err, "alumni.update_failed")
+		}
+		alumni = updated
+		return []audit.Entry{audit.NewEntry(c, audit.ActionUpdate, audit.ResourceAlumni, id, before, updated)}, nil
+	})
+	if err != nil {
+		return err
 	}
 
-	if req.RoleID != 1 && req.RoleID != 2 {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Role ID tidak valid. Gunakan 1 untuk admin atau 2 untuk user.",
-		})
+	etag.Set(c, alumni.Version)
+	response := model.UpdateAlumniResponse{
+		Success: true,
+		Message: i18n.Msg(c, "alumni.updated"),
+		Data:    *alumni,
 	}
 
-	alumni, err := repository.UpdateAlumni(db, id, req)
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// PatchAlumniService mengubah sebagian field alumni dari JSON Merge Patch
+// atau JSON Patch. Hanya field yang disentuh patch yang divalidasi dan
+// di-UPDATE; patch kosong mengembalikan data saat ini tanpa perubahan.
+func PatchAlumniService(c *fiber.Ctx, db *sql.DB, opts Options) error {
+	idStr := c.Params("id")
+	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"success": false,
-				"message": "Data alumni dengan ID tersebut tidak ditemukan di database.",
-			})
+		return apperror.Validation("error.invalid_id_param").WithCode("invalid_id")
+	}
+
+	var alumni *model.Alumni
+	err = withAudit(c, db, opts, func(tx *sql.Tx) ([]audit.Entry, error) {
+		before, err := repository.GetAlumniByID(c.UserContext(), tx, id)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, apperror.NotFound("alumni.not_found_id")
+			}
+			return nil, apperror.Wrap(err, "alumni.fetch_failed")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengupdate data alumni di database. Detail: " + err.Error(),
-		})
+		before.Role = nil
+		if err := etag.CheckIfMatch(c, before.Version, opts.RequireIfMatch); err != nil {
+			return nil, err
+		}
+
+		var req model.UpdateAlumniRequest
+		touched, err := patch.Apply(c, model.UpdateAlumniRequest{
+			Nama:       before.Nama,
+			Jurusan:    before.Jurusan,
+			Angkatan:   before.Angkatan,
+			TahunLulus: before.TahunLulus,
+			Email:      before.Email,
+			NoTelepon:  before.NoTelepon,
+			Alamat:     before.Alamat,
+			RoleID:     before.RoleID,
+		}, &req)
+		if err != nil {
+			return nil, err
+		}
+		req.Normalize()
+		if err := validation.Partial(i18n.Lang(c), &req, touched); err != nil {
+			return nil, err
+		}
+		if len(touched) == 0 {
+			alumni = before
+			return nil, nil
+		}
+
+		updated, err := repository.PatchAlumni(c.UserContext(), tx, id, before.Version, patch.Values(&req, touched))
+		if err != nil {
+			return nil, apperror.Wrap(err, "alumni.update_failed")
+		}
+		alumni = updated
+		return []audit.Entry{audit.NewEntry(c, audit.ActionUpdate, audit.ResourceAlumni, id, before, updated)}, nil
+	})
+	if err != nil {
+		return err
 	}
 
+	etag.Set(c, alumni.Version)
 	response := model.UpdateAlumniResponse{
 		Success: true,
-		Message: "Data alumni berhasil diupdate di database.",
+		Message: i18n.Msg(c, "alumni.updated"),
 		Data:    *alumni,
 	}
 
 	return c.Status(fiber.StatusOK).JSON(response)
 }
 
-func DeleteAlumniService(c *fiber.Ctx, db *sql.DB) error {
+func DeleteAlumniService(c *fiber.Ctx, db *sql.DB, opts Options) error {
 	idStr := c.Params("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Parameter ID tidak valid. ID harus berupa angka positif.",
-		})
+		return apperror.Validation("error.invalid_id_param").WithCode("invalid_id")
 	}
 
-	err = repository.DeleteAlumni(db, id)
+	err = withAudit(c, db, opts, func(tx *sql.Tx) ([]audit.Entry, error) {
+		before, err := repository.GetAlumniByID(c.UserContext(), tx, id)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, apperror.NotFound("alumni.not_found_id")
+			}
+			return nil, apperror.Wrap(err, "alumni.fetch_failed")
+		}
+		before.Role = nil
+		if err := etag.CheckIfMatch(c, before.Version, opts.RequireIfMatch); err != nil {
+			return nil, err
+		}
+
+		if err := repository.DeleteAlumni(c.UserContext(), tx, id, before.Version); err != nil {
+			return nil, apperror.Wrap(err, "alumni.delete_failed")
+		}
+		return []audit.Entry{audit.NewEntry(c, audit.ActionDelete, audit.ResourceAlumni, id, before, nil)}, nil
+	})
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error menghapus data alumni dari database. Detail: " + err.Error(),
-		})
+		return err
 	}
 
 	response := model.DeleteAlumniResponse{
 		Success: true,
-		Message: "Data alumni berhasil dihapus dari database.",
+		Message: i18n.Msg(c, "alumni.deleted"),
 	}
 
 	return c.Status(fiber.StatusOK).JSON(response)
 }
 
-func CheckAlumniService(c *fiber.Ctx, db *sql.DB) error {
+func CheckAlumniService(c *fiber.Ctx, db *sql.DB, apiKey string) error {
 	key := c.Params("key")
-	validAPIKey := os.Getenv("API_KEY")
-	if validAPIKey == "" {
-		validAPIKey = "default-api-key-2024"
-	}
-	if key != validAPIKey {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"success": false,
-			"message": "API key tidak valid. Gunakan key yang benar untuk akses endpoint ini.",
-		})
+	if key != apiKey {
+		return apperror.Unauthorized("auth.invalid_api_key")
 	}
 	
 	nim := c.FormValue("nim")
 	if nim == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Parameter NIM wajib diisi untuk pengecekan status alumni.",
-		})
+		return apperror.Validation("alumni.nim_required")
 	}
 	
-	alumni, err := repository.CheckAlumniByNim(db, nim)
+	alumni, err := repository.CheckAlumniByNim(c.UserContext(), db, nim)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			response := model.CheckAlumniResponse{
 				Success:  true,
-				Message:  "Mahasiswa dengan NIM tersebut bukan alumni.",
+				Message:  i18n.Msg(c, "alumni.not_alumni"),
 				IsAlumni: false,
 				Data:     nil,
 			}
 			return c.Status(fiber.StatusOK).JSON(response)
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengecek status alumni di database. Detail: " + err.Error(),
-		})
+		return apperror.Wrap(err, "alumni.check_failed")
 	}
 	
 	response := model.CheckAlumniResponse{
 		Success:  true,
-		Message:  "Data alumni berhasil ditemukan di database.",
+		Message:  i18n.Msg(c, "alumni.found"),
 		IsAlumni: true,
 		Data:     alumni,
 	}
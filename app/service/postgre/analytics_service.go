@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	model "go-fiber/app/model/postgre"
+	repository "go-fiber/app/repository/postgre"
+	"go-fiber/apperror"
+	"go-fiber/helper"
+	"go-fiber/i18n"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var analyticsGroupByWhitelist = map[string]bool{"angkatan": true, "tahun_lulus": true, "jurusan": true}
+
+var salaryGroupByWhitelist = map[string]bool{"angkatan": true, "tahun_lulus": true, "jurusan": true, "bidang_industri": true}
+
+// minSalaryGroupSize adalah jumlah alumni minimum agar statistik gaji sebuah
+// kelompok ditampilkan, supaya gaji individu tidak bisa ditebak.
+const minSalaryGroupSize = 5
+
+var analyticsDimensionWhitelist = map[string]bool{"bidang_industri": true, "lokasi_kerja": true}
+
+var analyticsStatusWhitelist = map[string]bool{"aktif": true, "selesai": true, "resigned": true}
+
+func parseAnalyticsFilter(c *fiber.Ctx, groupByWhitelist map[string]bool) (model.AnalyticsFilter, error) {
+	filter := model.AnalyticsFilter{
+		GroupBy: strings.ToLower(c.Query("group_by")),
+		Jurusan: strings.TrimSpace(c.Query("jurusan")),
+	}
+
+	if filter.GroupBy != "" && !groupByWhitelist[filter.GroupBy] {
+		return filter, apperror.Validation("query.invalid_group_by", strings.Join(helper.SortedKeys(groupByWhitelist), ", "))
+	}
+
+	if value := c.Query("angkatan"); value != "" {
+		angkatan, err := strconv.Atoi(value)
+		if err != nil {
+			return filter, apperror.Validation("query.angkatan_not_number")
+		}
+		filter.Angkatan = &angkatan
+	}
+
+	if value := c.Query("tahun_lulus"); value != "" {
+		tahunLulus, err := strconv.Atoi(value)
+		if err != nil {
+			return filter, apperror.Validation("query.tahun_lulus_not_number")
+		}
+		filter.TahunLulus = &tahunLulus
+	}
+
+	return filter, nil
+}
+
+// parseSalaryQuery membaca mata uang dan ukuran kelompok minimum. Ukuran
+// kelompok hanya boleh dinaikkan dari minSalaryGroupSize, tidak diturunkan.
+func parseSalaryQuery(c *fiber.Ctx) (string, int, error) {
+	currency := strings.ToUpper(c.Query("currency", helper.DefaultCurrency))
+	if !helper.SalaryCurrencies[currency] {
+		return "", 0, apperror.Validation("query.invalid_currency", strings.Join(helper.SortedKeys(helper.SalaryCurrencies), ", "))
+	}
+
+	minGroupSize, err := strconv.Atoi(c.Query("min_group_size", strconv.Itoa(minSalaryGroupSize)))
+	if err != nil || minGroupSize < minSalaryGroupSize {
+		minGroupSize = minSalaryGroupSize
+	}
+
+	return currency, minGroupSize, nil
+}
+
+func parseDistributionQuery(c *fiber.Ctx) (string, string, error) {
+	dimension := strings.ToLower(c.Query("dimension", "bidang_industri"))
+	if !analyticsDimensionWhitelist[dimension] {
+		return "", "", apperror.Validation("query.invalid_dimension")
+	}
+
+	status := strings.ToLower(c.Query("status"))
+	if status != "" && !analyticsStatusWhitelist[status] {
+		return "", "", apperror.Validation("query.invalid_status")
+	}
+
+	return dimension, status, nil
+}
+
+func parseTopEmployersLimit(c *fiber.Ctx) int {
+	limit, err := strconv.Atoi(c.Query("limit", "10"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 10
+	}
+	return limit
+}
+
+func employmentRates(ctx context.Context, db *sql.DB, filter model.AnalyticsFilter) ([]model.EmploymentRate, error) {
+	result, err := repository.GetEmploymentRate(ctx, db, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range result {
+		if result[i].TotalAlumni > 0 {
+			result[i].EmploymentRate = helper.Round2(float64(result[i].EmployedAlumni) / float64(result[i].TotalAlumni) * 100)
+		}
+	}
+	return result, nil
+}
+
+func timeToEmployment(ctx context.Context, db *sql.DB, filter model.AnalyticsFilter) ([]model.TimeToEmployment, error) {
+	result, err := repository.GetTimeToEmployment(ctx, db, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range result {
+		result[i].MedianDays = helper.Round2(result[i].MedianDays)
+		result[i].MedianMonths = helper.Round2(result[i].MedianDays / 30)
+	}
+	return result, nil
+}
+
+// salaryStatistics membuang kelompok dengan alumni kurang dari minGroupSize
+// dan mengembalikan jumlah kelompok yang disembunyikan.
+func salaryStatistics(ctx context.Context, db *sql.DB, filter model.AnalyticsFilter, currency string, minGroupSize int) ([]model.SalaryStatistic, int, error) {
+	groups, err := repository.GetSalaryStatistics(ctx, db, filter, currency)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := []model.SalaryStatistic{}
+	for _, group := range groups {
+		if group.TotalSampel < minGroupSize {
+			continue
+		}
+		group.P25 = helper.Round2(group.P25)
+		group.Median = helper.Round2(group.Median)
+		group.P75 = helper.Round2(group.P75)
+		result = append(result, group)
+	}
+	return result, len(groups) - len(result), nil
+}
+
+func GetEmploymentRateService(c *fiber.Ctx, db *sql.DB) error {
+	filter, err := parseAnalyticsFilter(c, analyticsGroupByWhitelist)
+	if err != nil {
+		return err
+	}
+
+	result, err := employmentRates(c.UserContext(), db, filter)
+	if err != nil {
+		return apperror.Wrap(err, "analytics.employment_rate_failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "analytics.employment_rate"),
+		"data":    result,
+	})
+}
+
+func GetTimeToEmploymentService(c *fiber.Ctx, db *sql.DB) error {
+	filter, err := parseAnalyticsFilter(c, analyticsGroupByWhitelist)
+	if err != nil {
+		return err
+	}
+
+	result, err := timeToEmployment(c.UserContext(), db, filter)
+	if err != nil {
+		return apperror.Wrap(err, "analytics.time_to_employment_failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "analytics.time_to_employment"),
+		"data":    result,
+	})
+}
+
+func GetEmploymentDistributionService(c *fiber.Ctx, db *sql.DB) error {
+	filter, err := parseAnalyticsFilter(c, analyticsGroupByWhitelist)
+	if err != nil {
+		return err
+	}
+
+	dimension, status, err := parseDistributionQuery(c)
+	if err != nil {
+		return err
+	}
+
+	result, err := repository.GetEmploymentDistribution(c.UserContext(), db, filter, dimension, status)
+	if err != nil {
+		return apperror.Wrap(err, "analytics.distribution_failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "analytics.distribution", dimension),
+		"data":    result,
+	})
+}
+
+func GetTopEmployersService(c *fiber.Ctx, db *sql.DB) error {
+	filter, err := parseAnalyticsFilter(c, analyticsGroupByWhitelist)
+	if err != nil {
+		return err
+	}
+
+	result, err := repository.GetTopEmployers(c.UserContext(), db, filter, parseTopEmployersLimit(c))
+	if err != nil {
+		return apperror.Wrap(err, "analytics.top_employers_failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "analytics.top_employers"),
+		"data":    result,
+	})
+}
+
+func GetSalaryStatisticsService(c *fiber.Ctx, db *sql.DB) error {
+	filter, err := parseAnalyticsFilter(c, salaryGroupByWhitelist)
+	if err != nil {
+		return err
+	}
+
+	currency, minGroupSize, err := parseSalaryQuery(c)
+	if err != nil {
+		return err
+	}
+
+	result, suppressed, err := salaryStatistics(c.UserContext(), db, filter, currency, minGroupSize)
+	if err != nil {
+		return apperror.Wrap(err, "analytics.salary_failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "analytics.salary"),
+		"data":    result,
+		"meta": fiber.Map{
+			"currency":          currency,
+			"min_group_size":    minGroupSize,
+			"suppressed_groups": suppressed,
+		},
+	})
+}
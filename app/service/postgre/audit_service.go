@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"go-fiber/apperror"
+	"go-fiber/audit"
+	"go-fiber/i18n"
+	"go-fiber/outbox"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// withAudit menjalankan mutate di dalam transaksi lalu menyimpan entry audit
+// yang dikembalikannya sebelum commit, sehingga perubahan data tidak pernah
+// tersimpan tanpa jejak audit. Jika opts.Outbox aktif, domain event dari
+// entry yang sama ikut ditulis di transaksi ini.
+func withAudit(c *fiber.Ctx, db *sql.DB, opts Options, mutate func(tx *sql.Tx) ([]audit.Entry, error)) error {
+	return withAuditContext(c.UserContext(), db, opts, mutate)
+}
+
+// withAuditContext sama dengan withAudit untuk pemanggil tanpa request,
+// misalnya handler background job.
+func withAuditContext(ctx context.Context, db *sql.DB, opts Options, mutate func(tx *sql.Tx) ([]audit.Entry, error)) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return apperror.Wrap(err, "audit.begin_failed")
+	}
+	defer tx.Rollback()
+
+	entries, err := mutate(tx)
+	if err != nil {
+		return err
+	}
+
+	if err := audit.NewPostgresStore(tx).Append(ctx, entries...); err != nil {
+		return apperror.Wrap(err, "audit.write_failed")
+	}
+	if opts.Outbox {
+		if err := outbox.NewPostgresStore(tx).Append(ctx, outbox.FromEntries(entries...)...); err != nil {
+			return apperror.Wrap(err, "outbox.write_failed")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperror.Wrap(err, "audit.commit_failed")
+	}
+	return nil
+}
+
+func GetAuditLogsService(c *fiber.Ctx, db *sql.DB) error {
+	filter, err := audit.ParseFilter(c)
+	if err != nil {
+		return err
+	}
+
+	entries, total, err := audit.NewPostgresStore(db).Query(c.UserContext(), filter)
+	if err != nil {
+		return apperror.Wrap(err, "audit.fetch_failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "audit.fetched"),
+		"data":    entries,
+		"meta":    audit.NewMeta(filter, total),
+	})
+}
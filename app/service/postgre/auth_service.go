@@ -4,59 +4,45 @@ import (
 	"database/sql"
 	model "go-fiber/app/model/postgre"
 	repository "go-fiber/app/repository/postgre"
+	"go-fiber/apperror"
+	"go-fiber/i18n"
+	"go-fiber/metrics"
 	utilspostgre "go-fiber/utils/postgre"
+	"go-fiber/validation"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func LoginService(c *fiber.Ctx, db *sql.DB) error {
-	var req model.LoginRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Format request body tidak valid. Pastikan JSON format benar. Detail: " + err.Error(),
-		})
-	}
+// LoginService menandatangani token dengan jwtSecret dari
+// config.AuthConfig.JWTSecret.
+func LoginService(c *fiber.Ctx, db *sql.DB, jwtSecret string) (err error) {
+	defer func() { metrics.ObserveLogin("postgre", apperror.ResponseStatus(c, err)) }()
 
-	if req.Email == "" || req.Password == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Field wajib tidak lengkap. Email dan password harus diisi.",
-		})
+	var req model.LoginRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
 	}
 
-	alumni, err := repository.GetAlumniByEmail(db, req.Email)
+	alumni, err := repository.GetAlumniByEmail(c.UserContext(), db, req.Email)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"success": false,
-				"message": "Login gagal. Email atau password salah.",
-			})
+			return apperror.Unauthorized("auth.login_failed")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengambil data alumni dari database. Detail: " + err.Error(),
-		})
+		return apperror.Wrap(err, "alumni.fetch_failed")
 	}
 
 	if !utilspostgre.CheckPassword(req.Password, alumni.PasswordHash) {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"success": false,
-			"message": "Login gagal. Email atau password salah.",
-		})
+		return apperror.Unauthorized("auth.login_failed")
 	}
 
-	token, err := utilspostgre.GenerateToken(*alumni, alumni.Role.Nama)
+	token, err := utilspostgre.GenerateToken(jwtSecret, *alumni, alumni.Role.Nama)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error membuat JWT token. Detail: " + err.Error(),
-		})
+		return apperror.Wrap(err, "auth.token_create_failed")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success": true,
-		"message": "Login berhasil. Token JWT telah dibuat.",
+		"message": i18n.Msg(c, "auth.login_success"),
 		"data": fiber.Map{
 			"alumni": alumni,
 			"token":  token,
@@ -71,7 +57,7 @@ func GetProfileService(c *fiber.Ctx, db *sql.DB) error {
 
 	response := model.GetProfileResponse{
 		Success: true,
-		Message: "Data profile berhasil diambil dari JWT token.",
+		Message: i18n.Msg(c, "auth.profile_fetched"),
 	}
 	response.Data.AlumniID = alumniID
 	response.Data.Email = email
@@ -0,0 +1,318 @@
+package service
+
+import (
+	"database/sql"
+
+	model "go-fiber/app/model/postgre"
+	repository "go-fiber/app/repository/postgre"
+	"go-fiber/apperror"
+	"go-fiber/audit"
+	"go-fiber/helper"
+	"go-fiber/i18n"
+	"go-fiber/patch"
+	"go-fiber/validation"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// batchStep adalah langkah batch untuk satu item, dijalankan di dalam
+// transaksi tx.
+type batchStep struct {
+	find    func(tx *sql.Tx, limit int) ([]int, error)
+	prepare func(tx *sql.Tx, id int) error
+	write   func(tx *sql.Tx, result *helper.BatchResult, i, id int) (*audit.Entry, error)
+}
+
+// runBatch menjalankan batch dalam satu transaksi dua tahap. prepare membaca
+// dan memvalidasi setiap item tanpa menulis; jika ada item yang gagal tidak
+// ada yang ditulis. write lalu menyimpan item satu per satu, dan kegagalan
+// pertama membatalkan seluruh transaksi. Kesalahan client dari kedua tahap
+// dicatat per item dan dikembalikan sebagai 422 batch_failed.
+func runBatch(c *fiber.Ctx, db *sql.DB, opts Options, ids []int, hasFilter bool, step batchStep) (*helper.BatchResult, error) {
+	lang := i18n.Lang(c)
+	var result *helper.BatchResult
+	err := withAudit(c, db, opts, func(tx *sql.Tx) ([]audit.Entry, error) {
+		ids, err := helper.BatchIDs(ids, hasFilter, func(limit int) ([]int, error) {
+			return step.find(tx, limit)
+		})
+		if err != nil {
+			return nil, err
+		}
+		result = helper.NewBatchResult(ids)
+
+		for i, id := range ids {
+			if err := step.prepare(tx, id); err != nil && !result.Fail(i, lang, err) {
+				return nil, err
+			}
+		}
+		if err := result.Err(); err != nil {
+			return nil, err
+		}
+
+		var entries []audit.Entry
+		for i, id := range ids {
+			entry, err := step.write(tx, result, i, id)
+			if err != nil {
+				if !result.Fail(i, lang, err) {
+					return nil, err
+				}
+				return nil, result.Err()
+			}
+			if entry != nil {
+				entries = append(entries, *entry)
+			}
+		}
+		return entries, nil
+	})
+	return result, err
+}
+
+// BatchUpdateAlumniService menerapkan changes pada alumni yang dipilih ids
+// atau filter dalam satu transaksi. Alumni yang tidak berubah dilaporkan
+// unchanged tanpa menaikkan version.
+func BatchUpdateAlumniService(c *fiber.Ctx, db *sql.DB, opts Options) error {
+	var req model.AlumniBatchUpdateRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+	if err := helper.CheckBatchChanges(req.Changes, model.UpdateAlumniRequest{}, &model.UpdateAlumniRequest{}); err != nil {
+		return err
+	}
+
+	befores := map[int]*model.Alumni{}
+	updates := map[int]*model.UpdateAlumniRequest{}
+	changed := map[int][]string{}
+	result, err := runBatch(c, db, opts, req.IDs, !req.Filter.IsEmpty(), batchStep{
+		find: func(tx *sql.Tx, limit int) ([]int, error) {
+			return repository.FindAlumniIDs(c.UserContext(), tx, *req.Filter, limit)
+		},
+		prepare: func(tx *sql.Tx, id int) error {
+			before, err := repository.GetAlumniByID(c.UserContext(), tx, id)
+			if err != nil {
+				if err == sql.ErrNoRows {
+					return apperror.NotFound("alumni.not_found_id")
+				}
+				return apperror.Wrap(err, "alumni.fetch_failed")
+			}
+			before.Role = nil
+
+			current := model.UpdateAlumniRequest{
+				Nama:       before.Nama,
+				Jurusan:    before.Jurusan,
+				Angkatan:   before.Angkatan,
+				TahunLulus: before.TahunLulus,
+				Email:      before.Email,
+				NoTelepon:  before.NoTelepon,
+				Alamat:     before.Alamat,
+				RoleID:     before.RoleID,
+			}
+			update := new(model.UpdateAlumniRequest)
+			touched, err := patch.Merge(current, req.Changes, update)
+			if err != nil {
+				return err
+			}
+			update.Normalize()
+			fields := patch.Changed(&current, update, touched)
+			if err := validation.Partial(i18n.Lang(c), update, fields); err != nil {
+				return err
+			}
+			befores[id], updates[id], changed[id] = before, update, fields
+			return nil
+		},
+		write: func(tx *sql.Tx, result *helper.BatchResult, i, id int) (*audit.Entry, error) {
+			before := befores[id]
+			if len(changed[id]) == 0 {
+				result.Succeed(i, helper.BatchUnchanged, before.Version)
+				return nil, nil
+			}
+			updated, err := repository.PatchAlumni(c.UserContext(), tx, id, before.Version, patch.Values(updates[id], changed[id]))
+			if err != nil {
+				return nil, apperror.Wrap(err, "alumni.update_failed")
+			}
+			result.Succeed(i, helper.BatchUpdated, updated.Version)
+			entry := audit.NewEntry(c, audit.ActionUpdate, audit.ResourceAlumni, id, before, updated)
+			return &entry, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "batch.updated", result.Total),
+		"data":    result,
+	})
+}
+
+// BatchDeleteAlumniService menghapus alumni yang dipilih ids atau filter
+// dalam satu transaksi.
+func BatchDeleteAlumniService(c *fiber.Ctx, db *sql.DB, opts Options) error {
+	var req model.AlumniBatchDeleteRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+
+	befores := map[int]*model.Alumni{}
+	result, err := runBatch(c, db, opts, req.IDs, !req.Filter.IsEmpty(), batchStep{
+		find: func(tx *sql.Tx, limit int) ([]int, error) {
+			return repository.FindAlumniIDs(c.UserContext(), tx, *req.Filter, limit)
+		},
+		prepare: func(tx *sql.Tx, id int) error {
+			before, err := repository.GetAlumniByID(c.UserContext(), tx, id)
+			if err != nil {
+				if err == sql.ErrNoRows {
+					return apperror.NotFound("alumni.not_found_id")
+				}
+				return apperror.Wrap(err, "alumni.fetch_failed")
+			}
+			before.Role = nil
+			befores[id] = before
+			return nil
+		},
+		write: func(tx *sql.Tx, result *helper.BatchResult, i, id int) (*audit.Entry, error) {
+			before := befores[id]
+			if err := repository.DeleteAlumni(c.UserContext(), tx, id, before.Version); err != nil {
+				return nil, apperror.Wrap(err, "alumni.delete_failed")
+			}
+			result.Succeed(i, helper.BatchDeleted, 0)
+			entry := audit.NewEntry(c, audit.ActionDelete, audit.ResourceAlumni, id, before, nil)
+			return &entry, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "batch.deleted", result.Total),
+		"data":    result,
+	})
+}
+
+// BatchUpdatePekerjaanAlumniService menerapkan changes pada pekerjaan yang
+// dipilih ids atau filter dalam satu transaksi, dengan aturan field yang
+// sama seperti PATCH. Pekerjaan di trash dilaporkan not_found.
+func BatchUpdatePekerjaanAlumniService(c *fiber.Ctx, db *sql.DB, opts Options) error {
+	var req model.PekerjaanBatchUpdateRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+	if err := helper.CheckBatchChanges(req.Changes, model.UpdatePekerjaanAlumniRequest{}, &model.UpdatePekerjaanAlumniRequest{}); err != nil {
+		return err
+	}
+
+	befores := map[int]*model.PekerjaanAlumni{}
+	columns := map[int]map[string]interface{}{}
+	result, err := runBatch(c, db, opts, req.IDs, !req.Filter.IsEmpty(), batchStep{
+		find: func(tx *sql.Tx, limit int) ([]int, error) {
+			return repository.FindPekerjaanAlumniIDs(c.UserContext(), tx, *req.Filter, limit)
+		},
+		prepare: func(tx *sql.Tx, id int) error {
+			before, err := repository.GetPekerjaanAlumniByIDWithDeleted(c.UserContext(), tx, id)
+			if err != nil {
+				if err == sql.ErrNoRows {
+					return apperror.NotFound("pekerjaan.not_found_id")
+				}
+				return apperror.Wrap(err, "pekerjaan.fetch_failed")
+			}
+			if before.IsDelete != nil {
+				return apperror.NotFound("pekerjaan.not_found_id")
+			}
+
+			current := pekerjaanUpdateRequest(before)
+			var update model.UpdatePekerjaanAlumniRequest
+			touched, err := patch.Merge(current, req.Changes, &update)
+			if err != nil {
+				return err
+			}
+			fields := patch.Changed(&current, &update, touched)
+			if err := validation.Partial(i18n.Lang(c), &update, fields); err != nil {
+				return err
+			}
+			befores[id] = before
+			if len(fields) == 0 {
+				return nil
+			}
+			columns[id], err = pekerjaanPatchColumns(&update, fields)
+			return err
+		},
+		write: func(tx *sql.Tx, result *helper.BatchResult, i, id int) (*audit.Entry, error) {
+			before := befores[id]
+			if columns[id] == nil {
+				result.Succeed(i, helper.BatchUnchanged, before.Version)
+				return nil, nil
+			}
+			updated, err := repository.PatchPekerjaanAlumni(c.UserContext(), tx, id, before.Version, columns[id])
+			if err != nil {
+				return nil, apperror.Wrap(err, "pekerjaan.update_failed")
+			}
+			result.Succeed(i, helper.BatchUpdated, updated.Version)
+			entry := audit.NewEntry(c, audit.ActionUpdate, audit.ResourcePekerjaan, id, before, updated)
+			return &entry, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "batch.updated", result.Total),
+		"data":    result,
+	})
+}
+
+// BatchSoftDeletePekerjaanAlumniService memindahkan pekerjaan yang dipilih
+// ids atau filter ke trash dalam satu transaksi. Pekerjaan yang sudah di
+// trash dilaporkan gagal, sama seperti soft delete satu per satu.
+func BatchSoftDeletePekerjaanAlumniService(c *fiber.Ctx, db *sql.DB, opts Options) error {
+	var req model.PekerjaanBatchDeleteRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+
+	befores := map[int]*model.PekerjaanAlumni{}
+	result, err := runBatch(c, db, opts, req.IDs, !req.Filter.IsEmpty(), batchStep{
+		find: func(tx *sql.Tx, limit int) ([]int, error) {
+			return repository.FindPekerjaanAlumniIDs(c.UserContext(), tx, *req.Filter, limit)
+		},
+		prepare: func(tx *sql.Tx, id int) error {
+			before, err := repository.GetPekerjaanAlumniByIDWithDeleted(c.UserContext(), tx, id)
+			if err != nil {
+				if err == sql.ErrNoRows {
+					return apperror.NotFound("pekerjaan.not_found_id")
+				}
+				return apperror.Wrap(err, "pekerjaan.fetch_failed")
+			}
+			if before.IsDelete != nil {
+				return apperror.Validation("pekerjaan.already_soft_deleted")
+			}
+			befores[id] = before
+			return nil
+		},
+		write: func(tx *sql.Tx, result *helper.BatchResult, i, id int) (*audit.Entry, error) {
+			before := befores[id]
+			if err := repository.SoftDeletePekerjaanAlumni(c.UserContext(), tx, id, before.Version); err != nil {
+				return nil, apperror.Wrap(err, "pekerjaan.delete_failed")
+			}
+			after, err := repository.GetPekerjaanAlumniByIDWithDeleted(c.UserContext(), tx, id)
+			if err != nil {
+				return nil, apperror.Wrap(err, "pekerjaan.fetch_failed")
+			}
+			result.Succeed(i, helper.BatchSoftDeleted, after.Version)
+			entry := audit.NewEntry(c, audit.ActionSoftDelete, audit.ResourcePekerjaan, id, before, after)
+			return &entry, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "batch.deleted", result.Total),
+		"data":    result,
+	})
+}
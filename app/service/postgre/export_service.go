@@ -0,0 +1,340 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	model "go-fiber/app/model/postgre"
+	repository "go-fiber/app/repository/postgre"
+	"go-fiber/apperror"
+	"go-fiber/helper"
+	"go-fiber/queue"
+	"go-fiber/worker"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// exportAsyncThreshold adalah jumlah baris di atas mana export otomatis
+// dijalankan sebagai job background walaupun async=false.
+const exportAsyncThreshold = 10000
+
+var tabularExportFormats = map[string]bool{"csv": true, "xlsx": true}
+
+func parseExportFormat(c *fiber.Ctx) (string, error) {
+	format := strings.ToLower(c.Query("format", "csv"))
+	if !tabularExportFormats[format] {
+		return "", apperror.Validation("query.invalid_format")
+	}
+	return format, nil
+}
+
+func exportColumns(v interface{}, c *fiber.Ctx, whitelist map[string]bool) ([]string, error) {
+	fields := helper.ParseListParam(c.Query("fields"))
+	if invalid := helper.InvalidItems(fields, whitelist); len(invalid) > 0 {
+		return nil, apperror.Validation("query.invalid_fields", strings.Join(invalid, ", "))
+	}
+	return helper.FilterColumns(helper.JSONColumns(v), fields, whitelist), nil
+}
+
+// exportRequest adalah parameter export yang sudah divalidasi. Nilainya
+// disimpan di payload job sehingga export bisa dibuat ulang oleh worker.
+type exportRequest struct {
+	Kind         string                `json:"kind"`
+	Format       string                `json:"format"`
+	Columns      []string              `json:"columns,omitempty"`
+	Search       string                `json:"search,omitempty"`
+	SortBy       string                `json:"sort_by,omitempty"`
+	Order        string                `json:"order,omitempty"`
+	Report       string                `json:"report,omitempty"`
+	Filter       model.AnalyticsFilter `json:"filter"`
+	Dimension    string                `json:"dimension,omitempty"`
+	Status       string                `json:"status,omitempty"`
+	Limit        int                   `json:"limit,omitempty"`
+	Currency     string                `json:"currency,omitempty"`
+	MinGroupSize int                   `json:"min_group_size,omitempty"`
+}
+
+func ExportAlumniService(c *fiber.Ctx, db *sql.DB, exports *worker.Exporter) error {
+	sortBy := c.Query("sortBy", "id")
+	order := c.Query("order", "asc")
+	search := c.Query("search", "")
+
+	sortByWhitelist := map[string]bool{"id": true, "nim": true, "nama": true, "email": true, "jurusan": true, "angkatan": true, "tahun_lulus": true, "created_at": true}
+	if !sortByWhitelist[sortBy] {
+		sortBy = "id"
+	}
+
+	if strings.ToLower(order) != "desc" {
+		order = "asc"
+	}
+
+	format, err := parseExportFormat(c)
+	if err != nil {
+		return err
+	}
+
+	columns, err := exportColumns(model.Alumni{}, c, alumniFieldWhitelist)
+	if err != nil {
+		return err
+	}
+
+	total, err := repository.CountAlumni(c.UserContext(), db, search)
+	if err != nil {
+		return apperror.Wrap(err, "export.alumni_count_failed")
+	}
+
+	request := exportRequest{Kind: "alumni", Format: format, Columns: columns, Search: search, SortBy: sortBy, Order: order}
+	return exports.Send(c, c.QueryBool("async") || (total > exportAsyncThreshold && exports.Async()), "alumni", format, request)
+}
+
+func ExportPekerjaanAlumniService(c *fiber.Ctx, db *sql.DB, exports *worker.Exporter) error {
+	sortBy := c.Query("sortBy", "id")
+	order := c.Query("order", "asc")
+	search := c.Query("search", "")
+
+	sortByWhitelist := map[string]bool{"id": true, "alumni_id": true, "nama_perusahaan": true, "posisi_jabatan": true, "bidang_industri": true, "lokasi_kerja": true, "tanggal_mulai_kerja": true, "status_pekerjaan": true, "created_at": true}
+	if !sortByWhitelist[sortBy] {
+		sortBy = "id"
+	}
+
+	if strings.ToLower(order) != "desc" {
+		order = "asc"
+	}
+
+	format, err := parseExportFormat(c)
+	if err != nil {
+		return err
+	}
+
+	columns, err := exportColumns(model.PekerjaanAlumni{}, c, pekerjaanFieldWhitelist)
+	if err != nil {
+		return err
+	}
+
+	total, err := repository.CountPekerjaanAlumni(c.UserContext(), db, search)
+	if err != nil {
+		return apperror.Wrap(err, "export.pekerjaan_count_failed")
+	}
+
+	request := exportRequest{Kind: "pekerjaan", Format: format, Columns: columns, Search: search, SortBy: sortBy, Order: order}
+	return exports.Send(c, c.QueryBool("async") || (total > exportAsyncThreshold && exports.Async()), "pekerjaan-alumni", format, request)
+}
+
+// ExportAnalyticsService mengekspor hasil salah satu endpoint analytics
+// (employment-rate, time-to-employment, distribution, top-employers, salary)
+// dengan parameter query yang sama.
+func ExportAnalyticsService(c *fiber.Ctx, exports *worker.Exporter) error {
+	report := c.Params("report")
+
+	groupByWhitelist := analyticsGroupByWhitelist
+	if report == "salary" {
+		groupByWhitelist = salaryGroupByWhitelist
+	}
+
+	filter, err := parseAnalyticsFilter(c, groupByWhitelist)
+	if err != nil {
+		return err
+	}
+
+	format, err := parseExportFormat(c)
+	if err != nil {
+		return err
+	}
+
+	request := exportRequest{Kind: "analytics", Format: format, Report: report, Filter: filter}
+	switch report {
+	case "employment-rate", "time-to-employment":
+	case "distribution":
+		request.Dimension, request.Status, err = parseDistributionQuery(c)
+	case "top-employers":
+		request.Limit = parseTopEmployersLimit(c)
+	case "salary":
+		request.Currency, request.MinGroupSize, err = parseSalaryQuery(c)
+	default:
+		return apperror.NotFound("analytics.report_not_found")
+	}
+	if err != nil {
+		return err
+	}
+
+	return exports.Send(c, c.QueryBool("async"), "analytics-"+report, format, request)
+}
+
+// ExportTracerStudyReportService membuat laporan PDF tracer study yang
+// merangkum seluruh analytics dengan pengelompokan group_by (default angkatan).
+func ExportTracerStudyReportService(c *fiber.Ctx, exports *worker.Exporter) error {
+	filter, err := parseAnalyticsFilter(c, analyticsGroupByWhitelist)
+	if err != nil {
+		return err
+	}
+	if filter.GroupBy == "" {
+		filter.GroupBy = "angkatan"
+	}
+
+	request := exportRequest{Kind: "tracer-study", Format: "pdf", Filter: filter}
+	return exports.Send(c, c.QueryBool("async"), "tracer-study", "pdf", request)
+}
+
+// ExportBuilder membuat export dari exportRequest, baik untuk request yang
+// dikirim langsung maupun job export di queue.
+func ExportBuilder(db *sql.DB) worker.ExportBuilder {
+	return func(ctx context.Context, data json.RawMessage) (worker.ExportFunc, error) {
+		var request exportRequest
+		if err := json.Unmarshal(data, &request); err != nil {
+			return nil, queue.Permanent(err)
+		}
+
+		switch request.Kind {
+		case "alumni":
+			return exportAlumni(db, request), nil
+		case "pekerjaan":
+			return exportPekerjaanAlumni(db, request), nil
+		case "analytics":
+			return exportAnalytics(ctx, db, request)
+		case "tracer-study":
+			return exportTracerStudyReport(ctx, db, request.Filter)
+		}
+		return nil, queue.Permanent(fmt.Errorf("jenis export %q tidak dikenal", request.Kind))
+	}
+}
+
+func exportAlumni(db *sql.DB, request exportRequest) worker.ExportFunc {
+	return func(ctx context.Context, w io.Writer) error {
+		writer, err := helper.NewTabularWriter(request.Format, w)
+		if err != nil {
+			return err
+		}
+		if err := writer.WriteRow(request.Columns); err != nil {
+			return err
+		}
+
+		err = repository.StreamAlumni(ctx, db, request.Search, request.SortBy, request.Order, func(alumni model.Alumni) error {
+			values, err := helper.RowValues(alumni, request.Columns)
+			if err != nil {
+				return err
+			}
+			return writer.WriteRow(values)
+		})
+		if err != nil {
+			return err
+		}
+		return writer.Close()
+	}
+}
+
+func exportPekerjaanAlumni(db *sql.DB, request exportRequest) worker.ExportFunc {
+	return func(ctx context.Context, w io.Writer) error {
+		writer, err := helper.NewTabularWriter(request.Format, w)
+		if err != nil {
+			return err
+		}
+		if err := writer.WriteRow(request.Columns); err != nil {
+			return err
+		}
+
+		err = repository.StreamPekerjaanAlumni(ctx, db, request.Search, request.SortBy, request.Order, func(pekerjaan model.PekerjaanAlumni) error {
+			values, err := helper.RowValues(pekerjaan, request.Columns)
+			if err != nil {
+				return err
+			}
+			return writer.WriteRow(values)
+		})
+		if err != nil {
+			return err
+		}
+		return writer.Close()
+	}
+}
+
+// exportAnalytics menghitung hasil report sebelum export ditulis sehingga
+// query yang gagal menjadi response error, bukan file kosong.
+func exportAnalytics(ctx context.Context, db *sql.DB, request exportRequest) (worker.ExportFunc, error) {
+	var result interface{}
+	var err error
+	switch request.Report {
+	case "employment-rate":
+		result, err = employmentRates(ctx, db, request.Filter)
+	case "time-to-employment":
+		result, err = timeToEmployment(ctx, db, request.Filter)
+	case "distribution":
+		result, err = repository.GetEmploymentDistribution(ctx, db, request.Filter, request.Dimension, request.Status)
+	case "top-employers":
+		result, err = repository.GetTopEmployers(ctx, db, request.Filter, request.Limit)
+	case "salary":
+		result, _, err = salaryStatistics(ctx, db, request.Filter, request.Currency, request.MinGroupSize)
+	default:
+		return nil, apperror.NotFound("analytics.report_not_found")
+	}
+	if err != nil {
+		return nil, apperror.Wrap(err, "export.analytics_failed")
+	}
+
+	return func(ctx context.Context, w io.Writer) error {
+		return helper.WriteTable(request.Format, w, result)
+	}, nil
+}
+
+func exportTracerStudyReport(ctx context.Context, db *sql.DB, filter model.AnalyticsFilter) (worker.ExportFunc, error) {
+	rates, err := employmentRates(ctx, db, filter)
+	if err != nil {
+		return nil, tracerStudyReportError(err)
+	}
+	waiting, err := timeToEmployment(ctx, db, filter)
+	if err != nil {
+		return nil, tracerStudyReportError(err)
+	}
+	distribution, err := repository.GetEmploymentDistribution(ctx, db, filter, "bidang_industri", "aktif")
+	if err != nil {
+		return nil, tracerStudyReportError(err)
+	}
+	employers, err := repository.GetTopEmployers(ctx, db, filter, 5)
+	if err != nil {
+		return nil, tracerStudyReportError(err)
+	}
+	salaries, suppressed, err := salaryStatistics(ctx, db, filter, helper.DefaultCurrency, minSalaryGroupSize)
+	if err != nil {
+		return nil, tracerStudyReportError(err)
+	}
+
+	sections := []helper.ReportSection{}
+	for _, item := range []struct {
+		title string
+		note  string
+		data  interface{}
+	}{
+		{"Tingkat Keterserapan Kerja", "Persentase alumni yang memiliki pekerjaan aktif.", rates},
+		{"Waktu Tunggu Kerja", "Median hari dari 1 Januari tahun lulus sampai pekerjaan pertama.", waiting},
+		{"Distribusi Bidang Industri", "Pekerjaan dengan status aktif.", distribution},
+		{"Perusahaan dengan Alumni Terbanyak", "Lima perusahaan teratas per kelompok.", employers},
+		{"Statistik Gaji Bulanan (IDR)", fmt.Sprintf("Kelompok dengan kurang dari %d alumni disembunyikan (%d kelompok).", minSalaryGroupSize, suppressed), salaries},
+	} {
+		section, err := helper.NewReportSection(item.title, item.note, item.data)
+		if err != nil {
+			return nil, tracerStudyReportError(err)
+		}
+		sections = append(sections, section)
+	}
+
+	subtitle := "Dikelompokkan berdasarkan " + filter.GroupBy
+	if filter.Angkatan != nil {
+		subtitle += ", angkatan " + strconv.Itoa(*filter.Angkatan)
+	}
+	if filter.TahunLulus != nil {
+		subtitle += ", tahun lulus " + strconv.Itoa(*filter.TahunLulus)
+	}
+	if filter.Jurusan != "" {
+		subtitle += ", jurusan " + filter.Jurusan
+	}
+
+	return func(ctx context.Context, w io.Writer) error {
+		return helper.WritePDFReport(w, "Laporan Tracer Study Alumni", subtitle, sections)
+	}, nil
+}
+
+func tracerStudyReportError(err error) error {
+	return apperror.Wrap(err, "export.report_failed")
+}
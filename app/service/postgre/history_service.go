@@ -0,0 +1,218 @@
+package service
+
+import (
+	"database/sql"
+	model "go-fiber/app/model/postgre"
+	repository "go-fiber/app/repository/postgre"
+	"go-fiber/apperror"
+	"go-fiber/audit"
+	"go-fiber/etag"
+	"go-fiber/helper"
+	"go-fiber/i18n"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Riwayat versi diturunkan dari audit_logs: setiap entry menyimpan snapshot
+// lengkap setelah perubahan, sehingga versi ke-n adalah after entry ke-n.
+
+func getHistory(c *fiber.Ctx, db *sql.DB, resource string) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return apperror.Validation("error.invalid_id_param").WithCode("invalid_id")
+	}
+
+	entries, err := audit.NewPostgresStore(db).History(c.UserContext(), resource, strconv.Itoa(id))
+	if err != nil {
+		return apperror.Wrap(err, "history.fetch_failed")
+	}
+	if len(entries) == 0 {
+		return apperror.NotFound("history.not_found")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "history.fetched"),
+		"data":    audit.Versions(entries),
+	})
+}
+
+// stateAsOf merekonstruksi data resource pada waktu ?as_of= lalu memilih
+// field sesuai ?fields=.
+func stateAsOf(c *fiber.Ctx, db *sql.DB, resource string, id int, fields []string) (interface{}, error) {
+	at, err := audit.ParseAsOf(c.Query("as_of"))
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := audit.NewPostgresStore(db).History(c.UserContext(), resource, strconv.Itoa(id))
+	if err != nil {
+		return nil, apperror.Wrap(err, "history.fetch_failed")
+	}
+	state, ok := audit.StateAt(entries, at)
+	if !ok {
+		return nil, apperror.NotFound("history.not_found_at", c.Query("as_of"))
+	}
+
+	data, err := helper.SelectFields(state, fields)
+	if err != nil {
+		return nil, apperror.Wrap(err, "history.fetch_failed")
+	}
+	return data, nil
+}
+
+func parseRevertParams(c *fiber.Ctx) (int, int, error) {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return 0, 0, apperror.Validation("error.invalid_id_param").WithCode("invalid_id")
+	}
+	version, err := strconv.Atoi(c.Params("version"))
+	if err != nil || version < 1 {
+		return 0, 0, apperror.Validation("history.invalid_version")
+	}
+	return id, version, nil
+}
+
+func GetAlumniHistoryService(c *fiber.Ctx, db *sql.DB) error {
+	return getHistory(c, db, audit.ResourceAlumni)
+}
+
+func GetPekerjaanAlumniHistoryService(c *fiber.Ctx, db *sql.DB) error {
+	return getHistory(c, db, audit.ResourcePekerjaan)
+}
+
+// RevertAlumniService mengembalikan field alumni ke data versi tertentu.
+// Revert dicatat sebagai versi baru sehingga bisa di-revert lagi. NIM dan
+// password tidak ikut dikembalikan.
+func RevertAlumniService(c *fiber.Ctx, db *sql.DB, opts Options) error {
+	id, version, err := parseRevertParams(c)
+	if err != nil {
+		return err
+	}
+
+	var alumni *model.Alumni
+	err = withAudit(c, db, opts, func(tx *sql.Tx) ([]audit.Entry, error) {
+		entries, err := audit.NewPostgresStore(tx).History(c.UserContext(), audit.ResourceAlumni, strconv.Itoa(id))
+		if err != nil {
+			return nil, apperror.Wrap(err, "history.fetch_failed")
+		}
+		data, err := audit.VersionData(entries, version)
+		if err != nil {
+			return nil, err
+		}
+		var target model.Alumni
+		if err := audit.Decode(data, &target); err != nil {
+			return nil, apperror.Wrap(err, "history.revert_failed")
+		}
+
+		before, err := repository.GetAlumniByID(c.UserContext(), tx, id)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, apperror.NotFound("alumni.not_found_id")
+			}
+			return nil, apperror.Wrap(err, "alumni.fetch_failed")
+		}
+		before.Role = nil
+		if err := etag.CheckIfMatch(c, before.Version, opts.RequireIfMatch); err != nil {
+			return nil, err
+		}
+
+		updated, err := repository.UpdateAlumni(c.UserContext(), tx, id, before.Version, model.UpdateAlumniRequest{
+			Nama:       target.Nama,
+			Jurusan:    target.Jurusan,
+			Angkatan:   target.Angkatan,
+			TahunLulus: target.TahunLulus,
+			Email:      target.Email,
+			NoTelepon:  target.NoTelepon,
+			Alamat:     target.Alamat,
+			RoleID:     target.RoleID,
+		})
+		if err != nil {
+			return nil, apperror.Wrap(err, "history.revert_failed")
+		}
+		alumni = updated
+		return []audit.Entry{audit.NewEntry(c, audit.ActionRevert, audit.ResourceAlumni, id, before, updated)}, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	etag.Set(c, alumni.Version)
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "history.reverted", version),
+		"data":    alumni,
+	})
+}
+
+// RevertPekerjaanAlumniService mengembalikan field pekerjaan ke data versi
+// tertentu. Status soft delete tidak ikut berubah; pekerjaan di trash harus
+// di-restore dulu sebelum bisa di-revert.
+func RevertPekerjaanAlumniService(c *fiber.Ctx, db *sql.DB, opts Options) error {
+	id, version, err := parseRevertParams(c)
+	if err != nil {
+		return err
+	}
+
+	var pekerjaan *model.PekerjaanAlumni
+	err = withAudit(c, db, opts, func(tx *sql.Tx) ([]audit.Entry, error) {
+		entries, err := audit.NewPostgresStore(tx).History(c.UserContext(), audit.ResourcePekerjaan, strconv.Itoa(id))
+		if err != nil {
+			return nil, apperror.Wrap(err, "history.fetch_failed")
+		}
+		data, err := audit.VersionData(entries, version)
+		if err != nil {
+			return nil, err
+		}
+		var target model.PekerjaanAlumni
+		if err := audit.Decode(data, &target); err != nil {
+			return nil, apperror.Wrap(err, "history.revert_failed")
+		}
+
+		before, err := repository.GetPekerjaanAlumniByIDWithDeleted(c.UserContext(), tx, id)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, apperror.NotFound("pekerjaan.not_found_id")
+			}
+			return nil, apperror.Wrap(err, "pekerjaan.fetch_failed")
+		}
+		if before.IsDelete != nil {
+			return nil, apperror.NotFound("pekerjaan.not_found_id")
+		}
+		if err := etag.CheckIfMatch(c, before.Version, opts.RequireIfMatch); err != nil {
+			return nil, err
+		}
+
+		updated, err := repository.UpdatePekerjaanAlumni(c.UserContext(), tx, id, before.Version, model.UpdatePekerjaanAlumniRepositoryRequest{
+			NamaPerusahaan:      target.NamaPerusahaan,
+			PosisiJabatan:       target.PosisiJabatan,
+			BidangIndustri:      target.BidangIndustri,
+			LokasiKerja:         target.LokasiKerja,
+			GajiRange:           target.GajiRange,
+			GajiMin:             target.GajiMin,
+			GajiMax:             target.GajiMax,
+			GajiCurrency:        target.GajiCurrency,
+			GajiPeriod:          target.GajiPeriod,
+			TanggalMulaiKerja:   target.TanggalMulaiKerja,
+			TanggalSelesaiKerja: target.TanggalSelesaiKerja,
+			StatusPekerjaan:     target.StatusPekerjaan,
+			DeskripsiPekerjaan:  target.DeskripsiPekerjaan,
+		})
+		if err != nil {
+			return nil, apperror.Wrap(err, "history.revert_failed")
+		}
+		pekerjaan = updated
+		return []audit.Entry{audit.NewEntry(c, audit.ActionRevert, audit.ResourcePekerjaan, id, before, updated)}, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	etag.Set(c, pekerjaan.Version)
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "history.reverted", version),
+		"data":    pekerjaan,
+	})
+}
@@ -0,0 +1,357 @@
+package service
+
+import (
+	"database/sql"
+	model "go-fiber/app/model/postgre"
+	repository "go-fiber/app/repository/postgre"
+	"go-fiber/apperror"
+	"go-fiber/audit"
+	"go-fiber/helper"
+	"go-fiber/i18n"
+	utilspostgre "go-fiber/utils/postgre"
+	"go-fiber/validation"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// generatedPasswordLength adalah panjang password awal untuk alumni hasil
+// import yang tidak menyertakan kolom password.
+const generatedPasswordLength = 12
+
+var alumniImportFields = []string{"nim", "nama", "jurusan", "angkatan", "tahun_lulus", "email", "password", "no_telepon", "alamat", "role_id"}
+
+var pekerjaanImportFields = []string{"nim", "nama_perusahaan", "posisi_jabatan", "bidang_industri", "lokasi_kerja", "gaji_range", "gaji_min", "gaji_max", "gaji_currency", "gaji_period", "tanggal_mulai_kerja", "tanggal_selesai_kerja", "status_pekerjaan", "deskripsi_pekerjaan"}
+
+// parseImportRequest membaca file upload, mapping kolom, dan mode dry-run.
+// Tanpa dry_run=false data hanya divalidasi dan tidak disimpan.
+func parseImportRequest(c *fiber.Ctx, fields []string) ([]helper.ImportRecord, bool, error) {
+	dryRun, err := strconv.ParseBool(c.Query("dry_run", "true"))
+	if err != nil {
+		return nil, false, apperror.Validation("query.invalid_dry_run")
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return nil, false, apperror.Validation("import.file_required")
+	}
+
+	records, err := helper.ReadImportFile(fileHeader, c.FormValue("mapping"), fields)
+	if err != nil {
+		return nil, false, apperror.Validation("import.file_invalid", err)
+	}
+	if len(records) == 0 {
+		return nil, false, apperror.Validation("import.empty")
+	}
+
+	return records, dryRun, nil
+}
+
+func importRowError(record helper.ImportRecord, field, message string) helper.ImportRowError {
+	return helper.ImportRowError{Row: record.Row, Field: field, Message: message}
+}
+
+// appendValidationErrors menjalankan tag validate pada req dan menambahkan
+// kesalahannya ke errs, kecuali untuk field yang sudah punya kesalahan parsing.
+// except berisi field Go yang diperiksa sendiri oleh pemanggil, misalnya
+// Password karena alumni tanpa password mendapat password awal acak.
+func appendValidationErrors(lang string, record helper.ImportRecord, req interface{}, errs []helper.ImportRowError, except ...string) []helper.ImportRowError {
+	reported := make(map[string]bool, len(errs))
+	for _, rowErr := range errs {
+		reported[rowErr.Field] = true
+	}
+	for _, fieldErr := range validation.FieldsExcept(lang, req, except...) {
+		if !reported[fieldErr.Field] {
+			errs = append(errs, importRowError(record, fieldErr.Field, fieldErr.Message))
+		}
+	}
+	return errs
+}
+
+// sendImportResult mengirim hasil validasi. Jika ada baris yang tidak valid
+// tidak ada data yang disimpan, termasuk saat dry_run=false. resourceKey
+// adalah key katalog untuk nama data yang diimport.
+func sendImportResult(c *fiber.Ctx, result model.ImportResult, resourceKey string) error {
+	resource := i18n.Msg(c, resourceKey)
+	if len(result.Errors) > 0 {
+		return apperror.Unprocessable("import.failed", len(result.Errors), resource).WithCode("import_failed").WithDetails(result)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "import.validated", result.ValidRows, resource),
+		"data":    result,
+	})
+}
+
+func validateAlumniImportRow(lang string, record helper.ImportRecord) (model.CreateAlumniRequest, []helper.ImportRowError) {
+	var errs []helper.ImportRowError
+	req := model.CreateAlumniRequest{
+		NIM:       record.Get("nim"),
+		Nama:      record.Get("nama"),
+		Jurusan:   record.Get("jurusan"),
+		Email:     record.Get("email"),
+		Password:  record.Get("password"),
+		NoTelepon: record.Optional("no_telepon"),
+		Alamat:    record.Optional("alamat"),
+		RoleID:    2,
+	}
+	req.Normalize()
+
+	for _, field := range []string{"angkatan", "tahun_lulus"} {
+		value, err := record.Int(field)
+		if err != nil {
+			errs = append(errs, importRowError(record, field, i18n.Text(lang, err)))
+			continue
+		}
+		if value == nil {
+			continue
+		}
+		if field == "angkatan" {
+			req.Angkatan = int(*value)
+		} else {
+			req.TahunLulus = int(*value)
+		}
+	}
+
+	roleID, err := record.Int("role_id")
+	if err != nil {
+		errs = append(errs, importRowError(record, "role_id", i18n.Text(lang, err)))
+	} else if roleID != nil {
+		req.RoleID = int(*roleID)
+	}
+
+	return req, appendValidationErrors(lang, record, req, errs, "Password")
+}
+
+func ImportAlumniService(c *fiber.Ctx, db *sql.DB) error {
+	records, dryRun, err := parseImportRequest(c, alumniImportFields)
+	if err != nil {
+		return err
+	}
+	lang := i18n.Lang(c)
+
+	result := model.ImportResult{DryRun: dryRun, TotalRows: len(records), Errors: []helper.ImportRowError{}}
+	requests := make([]model.CreateAlumniRequest, len(records))
+	seenNIM := map[string]int{}
+	seenEmail := map[string]int{}
+	var nims, emails []string
+
+	for i, record := range records {
+		req, errs := validateAlumniImportRow(lang, record)
+		if row, ok := seenNIM[req.NIM]; ok && req.NIM != "" {
+			errs = append(errs, importRowError(record, "nim", i18n.T(lang, "import.row.nim_duplicate", row)))
+		}
+		email := strings.ToLower(req.Email)
+		if row, ok := seenEmail[email]; ok && email != "" {
+			errs = append(errs, importRowError(record, "email", i18n.T(lang, "import.row.email_duplicate", row)))
+		}
+		seenNIM[req.NIM] = record.Row
+		seenEmail[email] = record.Row
+		nims = append(nims, req.NIM)
+		emails = append(emails, req.Email)
+
+		requests[i] = req
+		result.Errors = append(result.Errors, errs...)
+	}
+
+	existingNIM, existingEmail, err := repository.FindExistingAlumniKeys(c.UserContext(), db, nims, emails)
+	if err != nil {
+		return apperror.Wrap(err, "import.check_existing_failed")
+	}
+
+	invalidRows := map[int]bool{}
+	for _, rowErr := range result.Errors {
+		invalidRows[rowErr.Row] = true
+	}
+	for i, req := range requests {
+		if existingNIM[req.NIM] {
+			result.Errors = append(result.Errors, importRowError(records[i], "nim", i18n.T(lang, "import.row.nim_exists")))
+			invalidRows[records[i].Row] = true
+		}
+		if existingEmail[strings.ToLower(req.Email)] {
+			result.Errors = append(result.Errors, importRowError(records[i], "email", i18n.T(lang, "import.row.email_exists")))
+			invalidRows[records[i].Row] = true
+		}
+	}
+	result.ValidRows = len(records) - len(invalidRows)
+
+	if dryRun || len(result.Errors) > 0 {
+		return sendImportResult(c, result, "import.resource.alumni")
+	}
+
+	tx, err := db.BeginTx(c.UserContext(), nil)
+	if err != nil {
+		return apperror.Wrap(err, "import.alumni_begin_failed")
+	}
+	defer tx.Rollback()
+
+	entries := make([]audit.Entry, 0, len(requests))
+	for i, req := range requests {
+		if req.Password == "" {
+			req.Password, err = helper.GeneratePassword(generatedPasswordLength)
+			if err != nil {
+				return apperror.Wrap(err, "import.generate_password_failed")
+			}
+			result.Credentials = append(result.Credentials, model.ImportCredential{
+				Row:      records[i].Row,
+				NIM:      req.NIM,
+				Email:    req.Email,
+				Password: req.Password,
+			})
+		}
+
+		passwordHash, err := utilspostgre.HashPassword(req.Password)
+		if err != nil {
+			return apperror.Wrap(err, "auth.password_hash_failed")
+		}
+
+		alumni, err := repository.CreateAlumni(c.UserContext(), tx, req, passwordHash)
+		if err != nil {
+			return apperror.Wrap(err, "import.alumni_row_failed", records[i].Row)
+		}
+		entries = append(entries, audit.NewEntry(c, audit.ActionImport, audit.ResourceAlumni, alumni.ID, nil, alumni))
+	}
+
+	if err := audit.NewPostgresStore(tx).Append(c.UserContext(), entries...); err != nil {
+		return apperror.Wrap(err, "audit.write_failed")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperror.Wrap(err, "import.alumni_commit_failed")
+	}
+	result.Imported = len(requests)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "import.alumni_imported", result.Imported),
+		"data":    result,
+	})
+}
+
+// validatePekerjaanImportRow memvalidasi baris dengan tag validate
+// CreatePekerjaanAlumniRequest seperti endpoint create. AlumniID dilewati
+// karena diisi pemanggil dari lookup nim, dan gaji diperiksa terpisah karena
+// berasal dari beberapa kolom.
+func validatePekerjaanImportRow(lang string, record helper.ImportRecord) (model.CreatePekerjaanAlumniRepositoryRequest, []helper.ImportRowError) {
+	var errs []helper.ImportRowError
+	if record.Get("nim") == "" {
+		errs = append(errs, importRowError(record, "nim", i18n.T(lang, "validation.required", "nim")))
+	}
+
+	req := model.CreatePekerjaanAlumniRequest{
+		NamaPerusahaan:      record.Get("nama_perusahaan"),
+		PosisiJabatan:       record.Get("posisi_jabatan"),
+		BidangIndustri:      record.Get("bidang_industri"),
+		LokasiKerja:         record.Get("lokasi_kerja"),
+		TanggalMulaiKerja:   record.Get("tanggal_mulai_kerja"),
+		TanggalSelesaiKerja: record.Optional("tanggal_selesai_kerja"),
+		StatusPekerjaan:     strings.ToLower(record.Get("status_pekerjaan")),
+		DeskripsiPekerjaan:  record.Optional("deskripsi_pekerjaan"),
+	}
+	errs = appendValidationErrors(lang, record, req, errs, "AlumniID")
+
+	pekerjaan := model.CreatePekerjaanAlumniRepositoryRequest{
+		NamaPerusahaan:     req.NamaPerusahaan,
+		PosisiJabatan:      req.PosisiJabatan,
+		BidangIndustri:     req.BidangIndustri,
+		LokasiKerja:        req.LokasiKerja,
+		StatusPekerjaan:    req.StatusPekerjaan,
+		DeskripsiPekerjaan: req.DeskripsiPekerjaan,
+	}
+	// Tanggal sudah lolos tag date jika tidak ada kesalahan pada field-nya.
+	if tanggalMulaiKerja, err := record.Date("tanggal_mulai_kerja"); err == nil && tanggalMulaiKerja != nil {
+		pekerjaan.TanggalMulaiKerja = *tanggalMulaiKerja
+	}
+	if tanggalSelesaiKerja, err := record.Date("tanggal_selesai_kerja"); err == nil {
+		pekerjaan.TanggalSelesaiKerja = tanggalSelesaiKerja
+	}
+
+	gajiMin, errMin := record.Int("gaji_min")
+	gajiMax, errMax := record.Int("gaji_max")
+	if errMin != nil || errMax != nil {
+		errs = append(errs, importRowError(record, "gaji_min", i18n.T(lang, "import.row.salary_not_number")))
+		return pekerjaan, errs
+	}
+
+	salary, err := helper.NormalizeSalary(record.Optional("gaji_range"), gajiMin, gajiMax, record.Optional("gaji_currency"), record.Optional("gaji_period"))
+	if err != nil {
+		errs = append(errs, importRowError(record, "gaji_range", i18n.T(lang, "import.row.invalid_salary", err)))
+		return pekerjaan, errs
+	}
+	pekerjaan.GajiRange, pekerjaan.GajiMin, pekerjaan.GajiMax, pekerjaan.GajiCurrency, pekerjaan.GajiPeriod = salaryColumns(record.Optional("gaji_range"), salary)
+
+	return pekerjaan, errs
+}
+
+func ImportPekerjaanAlumniService(c *fiber.Ctx, db *sql.DB) error {
+	records, dryRun, err := parseImportRequest(c, pekerjaanImportFields)
+	if err != nil {
+		return err
+	}
+	lang := i18n.Lang(c)
+
+	var nims []string
+	for _, record := range records {
+		nims = append(nims, record.Get("nim"))
+	}
+
+	alumniIDs, err := repository.FindAlumniIDsByNIM(c.UserContext(), db, nims)
+	if err != nil {
+		return apperror.Wrap(err, "import.lookup_nim_failed")
+	}
+
+	result := model.ImportResult{DryRun: dryRun, TotalRows: len(records), Errors: []helper.ImportRowError{}}
+	requests := make([]model.CreatePekerjaanAlumniRepositoryRequest, len(records))
+	for i, record := range records {
+		req, errs := validatePekerjaanImportRow(lang, record)
+		if nim := record.Get("nim"); nim != "" {
+			alumniID, ok := alumniIDs[nim]
+			if !ok {
+				errs = append(errs, importRowError(record, "nim", i18n.T(lang, "import.row.alumni_not_found", nim)))
+			}
+			req.AlumniID = alumniID
+		}
+
+		if len(errs) == 0 {
+			result.ValidRows++
+		}
+		requests[i] = req
+		result.Errors = append(result.Errors, errs...)
+	}
+
+	if dryRun || len(result.Errors) > 0 {
+		return sendImportResult(c, result, "import.resource.pekerjaan")
+	}
+
+	tx, err := db.BeginTx(c.UserContext(), nil)
+	if err != nil {
+		return apperror.Wrap(err, "import.pekerjaan_begin_failed")
+	}
+	defer tx.Rollback()
+
+	entries := make([]audit.Entry, 0, len(requests))
+	for i, req := range requests {
+		pekerjaan, err := repository.CreatePekerjaanAlumni(c.UserContext(), tx, req)
+		if err != nil {
+			return apperror.Wrap(err, "import.pekerjaan_row_failed", records[i].Row)
+		}
+		entries = append(entries, audit.NewEntry(c, audit.ActionImport, audit.ResourcePekerjaan, pekerjaan.ID, nil, pekerjaan))
+	}
+
+	if err := audit.NewPostgresStore(tx).Append(c.UserContext(), entries...); err != nil {
+		return apperror.Wrap(err, "audit.write_failed")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperror.Wrap(err, "import.pekerjaan_commit_failed")
+	}
+	result.Imported = len(requests)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "import.pekerjaan_imported", result.Imported),
+		"data":    result,
+	})
+}
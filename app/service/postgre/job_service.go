@@ -0,0 +1,95 @@
+package service
+
+import (
+	"errors"
+	"go-fiber/apperror"
+	"go-fiber/i18n"
+	"go-fiber/queue"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// jobError mengubah error queue menjadi 404 atau 409 dengan invalidStateKey;
+// error lain dibungkus dengan key.
+func jobError(err error, invalidStateKey, key string) error {
+	switch {
+	case errors.Is(err, queue.ErrNotFound):
+		return apperror.NotFound("job.not_found")
+	case errors.Is(err, queue.ErrInvalidState) && invalidStateKey != "":
+		return apperror.Conflict(invalidStateKey)
+	}
+	return apperror.Wrap(err, key)
+}
+
+// GetJobsService menampilkan job terbaru lebih dulu, bisa difilter dengan
+// query type dan status.
+func GetJobsService(c *fiber.Ctx, q *queue.Queue) error {
+	filter, err := queue.ParseFilter(c)
+	if err != nil {
+		return err
+	}
+
+	jobs, total, err := q.Store().List(c.UserContext(), filter)
+	if err != nil {
+		return apperror.Wrap(err, "job.list_fetch_failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "job.list_fetched"),
+		"data":    jobs,
+		"meta":    queue.NewMeta(filter, total),
+	})
+}
+
+func GetJobService(c *fiber.Ctx, q *queue.Queue) error {
+	job, err := q.Store().Get(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return jobError(err, "", "job.fetch_failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "job.fetched"),
+		"data":    job,
+	})
+}
+
+// RetryJobService menjadwalkan ulang job failed atau cancelled untuk segera
+// dijalankan dengan hitungan percobaan dari nol.
+func RetryJobService(c *fiber.Ctx, q *queue.Queue) error {
+	job, err := q.Store().Retry(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return jobError(err, "job.not_retryable", "job.retry_failed")
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "job.retried"),
+		"data":    job,
+	})
+}
+
+// CancelJobService membatalkan job yang belum berjalan.
+func CancelJobService(c *fiber.Ctx, q *queue.Queue) error {
+	job, err := q.Store().Cancel(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return jobError(err, "job.not_cancellable", "job.cancel_failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "job.cancelled"),
+		"data":    job,
+	})
+}
+
+// GetJobSchedulesService menampilkan jadwal cron yang terdaftar beserta
+// waktu jalan berikutnya.
+func GetJobSchedulesService(c *fiber.Ctx, q *queue.Queue) error {
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "job.schedules_fetched"),
+		"data":    q.Schedules(),
+	})
+}
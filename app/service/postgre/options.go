@@ -0,0 +1,12 @@
+package service
+
+// Options berisi pengaturan dari config yang memengaruhi service yang
+// mengubah data. Dibuat sekali di main lalu diteruskan lewat route.
+type Options struct {
+	// RequireIfMatch menolak PUT/DELETE tanpa If-Match dengan 428
+	// (app.require_if_match).
+	RequireIfMatch bool
+	// Outbox menulis domain event ke outbox di transaksi yang sama dengan
+	// audit (outbox.enabled).
+	Outbox bool
+}
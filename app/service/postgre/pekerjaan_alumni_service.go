@@ -4,6 +4,13 @@ import (
 	"database/sql"
 	model "go-fiber/app/model/postgre"
 	repository "go-fiber/app/repository/postgre"
+	"go-fiber/apperror"
+	"go-fiber/audit"
+	"go-fiber/etag"
+	"go-fiber/helper"
+	"go-fiber/i18n"
+	"go-fiber/patch"
+	"go-fiber/validation"
 	"strconv"
 	"strings"
 	"time"
@@ -11,6 +18,29 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+var pekerjaanFieldWhitelist = map[string]bool{"id": true, "alumni_id": true, "nama_perusahaan": true, "posisi_jabatan": true, "bidang_industri": true, "lokasi_kerja": true, "gaji_range": true, "gaji_min": true, "gaji_max": true, "gaji_currency": true, "gaji_period": true, "tanggal_mulai_kerja": true, "tanggal_selesai_kerja": true, "status_pekerjaan": true, "deskripsi_pekerjaan": true, "is_delete": true, "version": true, "created_at": true, "updated_at": true}
+
+func parsePekerjaanFields(c *fiber.Ctx) ([]string, error) {
+	fields := helper.ParseListParam(c.Query("fields"))
+	if invalid := helper.InvalidItems(fields, pekerjaanFieldWhitelist); len(invalid) > 0 {
+		return nil, apperror.Validation("query.invalid_fields", strings.Join(invalid, ", "))
+	}
+	return fields, nil
+}
+
+// salaryColumns memecah gaji terstruktur menjadi nilai kolom nullable. Jika
+// gaji_range tidak dikirim, teksnya dibentuk dari nilai terstruktur.
+func salaryColumns(gajiRange *string, salary *helper.Salary) (*string, *int64, *int64, *string, *string) {
+	if salary == nil {
+		return gajiRange, nil, nil, nil, nil
+	}
+	if gajiRange == nil || strings.TrimSpace(*gajiRange) == "" {
+		text := salary.String()
+		gajiRange = &text
+	}
+	return gajiRange, &salary.Min, &salary.Max, &salary.Currency, &salary.Period
+}
+
 func GetAllPekerjaanAlumniService(c *fiber.Ctx, db *sql.DB) error {
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	limit, _ := strconv.Atoi(c.Query("limit", "10"))
@@ -35,20 +65,19 @@ func GetAllPekerjaanAlumniService(c *fiber.Ctx, db *sql.DB) error {
 		limit = 10
 	}
 
-	pekerjaanList, err := repository.GetAllPekerjaanAlumni(db, search, sortBy, order, limit, offset)
+	fields, err := parsePekerjaanFields(c)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengambil data pekerjaan alumni dari database. Detail: " + err.Error(),
-		})
+		return err
 	}
 
-	total, err := repository.CountPekerjaanAlumni(db, search)
+	pekerjaanList, err := repository.GetAllPekerjaanAlumni(c.UserContext(), db, search, sortBy, order, limit, offset)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error menghitung total data pekerjaan alumni untuk pagination. Detail: " + err.Error(),
-		})
+		return apperror.Wrap(err, "pekerjaan.fetch_failed")
+	}
+
+	total, err := repository.CountPekerjaanAlumni(c.UserContext(), db, search)
+	if err != nil {
+		return apperror.Wrap(err, "pekerjaan.count_failed")
 	}
 
 	pages := (total + limit - 1) / limit
@@ -56,8 +85,13 @@ func GetAllPekerjaanAlumniService(c *fiber.Ctx, db *sql.DB) error {
 		pages = 1
 	}
 
+	data, err := helper.SelectFields(pekerjaanList, fields)
+	if err != nil {
+		return apperror.Wrap(err, "pekerjaan.fields_failed")
+	}
+
 	response := model.PekerjaanAlumniResponse{
-		Data: pekerjaanList,
+		Data: data,
 		Meta: model.MetaInfo{
 			Page:    page,
 			Limit:   limit,
@@ -76,30 +110,46 @@ func GetPekerjaanAlumniByIDService(c *fiber.Ctx, db *sql.DB) error {
 	idStr := c.Params("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Parameter ID tidak valid. ID harus berupa angka positif.",
+		return apperror.Validation("error.invalid_id_param").WithCode("invalid_id")
+	}
+
+	fields, err := parsePekerjaanFields(c)
+	if err != nil {
+		return err
+	}
+
+	if c.Query("as_of") != "" {
+		data, err := stateAsOf(c, db, audit.ResourcePekerjaan, id, fields)
+		if err != nil {
+			return err
+		}
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"success": true,
+			"message": i18n.Msg(c, "pekerjaan.fetched"),
+			"data":    data,
 		})
 	}
 
-	pekerjaan, err := repository.GetPekerjaanAlumniByID(db, id)
+	pekerjaan, err := repository.GetPekerjaanAlumniByID(c.UserContext(), db, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"success": false,
-				"message": "Data pekerjaan alumni dengan ID tersebut tidak ditemukan di database.",
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengambil data pekerjaan alumni dari database. Detail: " + err.Error(),
-		})
+			return apperror.NotFound("pekerjaan.not_found_id")
+		}
+		return apperror.Wrap(err, "pekerjaan.fetch_failed")
+	}
+	if etag.NotModified(c, pekerjaan.Version) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	data, err := helper.SelectFields(pekerjaan, fields)
+	if err != nil {
+		return apperror.Wrap(err, "pekerjaan.fields_failed")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success": true,
-		"message": "Data pekerjaan alumni berhasil diambil dari database.",
-		"data":    pekerjaan,
+		"message": i18n.Msg(c, "pekerjaan.fetched"),
+		"data":    data,
 	})
 }
 
@@ -107,307 +157,405 @@ func GetPekerjaanAlumniByAlumniIDService(c *fiber.Ctx, db *sql.DB) error {
 	alumniIDStr := c.Params("alumni_id")
 	alumniID, err := strconv.Atoi(alumniIDStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Parameter alumni_id tidak valid. Alumni ID harus berupa angka positif.",
-		})
+		return apperror.Validation("query.invalid_alumni_id")
 	}
 
-	pekerjaanList, err := repository.GetPekerjaanAlumniByAlumniID(db, alumniID)
+	pekerjaanList, err := repository.GetPekerjaanAlumniByAlumniID(c.UserContext(), db, alumniID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengambil data pekerjaan alumni berdasarkan Alumni ID dari database. Detail: " + err.Error(),
-		})
+		return apperror.Wrap(err, "pekerjaan.fetch_by_alumni_failed")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success": true,
-		"message": "Data pekerjaan alumni berdasarkan Alumni ID berhasil diambil dari database.",
+		"message": i18n.Msg(c, "pekerjaan.fetched_by_alumni"),
 		"data":    pekerjaanList,
 	})
 }
 
-func CreatePekerjaanAlumniService(c *fiber.Ctx, db *sql.DB) error {
+func CreatePekerjaanAlumniService(c *fiber.Ctx, db *sql.DB, opts Options) error {
 	var req model.CreatePekerjaanAlumniRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Format request body tidak valid. Pastikan JSON format benar. Detail: " + err.Error(),
-		})
-	}
-
-	if req.NamaPerusahaan == "" || req.PosisiJabatan == "" || req.BidangIndustri == "" || req.LokasiKerja == "" || req.StatusPekerjaan == "" || req.TanggalMulaiKerja == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Field wajib tidak lengkap. Nama perusahaan, posisi jabatan, bidang industri, lokasi kerja, status pekerjaan, dan tanggal mulai kerja harus diisi.",
-		})
-	}
-
-	validStatus := map[string]bool{"aktif": true, "selesai": true, "resigned": true}
-	if !validStatus[req.StatusPekerjaan] {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Status pekerjaan tidak valid. Gunakan 'aktif', 'selesai', atau 'resigned'.",
-		})
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
 	}
 
 	tanggalMulaiKerja, err := time.Parse("2006-01-02", req.TanggalMulaiKerja)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Format tanggal mulai kerja tidak valid. Gunakan format YYYY-MM-DD (contoh: 2025-01-15).",
-		})
+		return apperror.Validation("pekerjaan.invalid_start_date")
 	}
 
 	var tanggalSelesaiKerja *time.Time
 	if req.TanggalSelesaiKerja != nil && *req.TanggalSelesaiKerja != "" {
 		parsedTanggalSelesai, err := time.Parse("2006-01-02", *req.TanggalSelesaiKerja)
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"success": false,
-				"message": "Format tanggal selesai kerja tidak valid. Gunakan format YYYY-MM-DD (contoh: 2025-12-31).",
-			})
+			return apperror.Validation("pekerjaan.invalid_end_date")
 		}
 		tanggalSelesaiKerja = &parsedTanggalSelesai
 	}
 
+	salary, err := helper.NormalizeSalary(req.GajiRange, req.GajiMin, req.GajiMax, req.GajiCurrency, req.GajiPeriod)
+	if err != nil {
+		return apperror.Validation("pekerjaan.invalid_salary", err)
+	}
+	gajiRange, gajiMin, gajiMax, gajiCurrency, gajiPeriod := salaryColumns(req.GajiRange, salary)
+
 	pekerjaanRequest := model.CreatePekerjaanAlumniRepositoryRequest{
 		AlumniID:            req.AlumniID,
 		NamaPerusahaan:      req.NamaPerusahaan,
 		PosisiJabatan:       req.PosisiJabatan,
 		BidangIndustri:      req.BidangIndustri,
 		LokasiKerja:         req.LokasiKerja,
-		GajiRange:           req.GajiRange,
+		GajiRange:           gajiRange,
+		GajiMin:             gajiMin,
+		GajiMax:             gajiMax,
+		GajiCurrency:        gajiCurrency,
+		GajiPeriod:          gajiPeriod,
 		TanggalMulaiKerja:   tanggalMulaiKerja,
 		TanggalSelesaiKerja: tanggalSelesaiKerja,
 		StatusPekerjaan:     req.StatusPekerjaan,
 		DeskripsiPekerjaan:  req.DeskripsiPekerjaan,
 	}
 
-	pekerjaan, err := repository.CreatePekerjaanAlumni(db, pekerjaanRequest)
+	var pekerjaan *model.PekerjaanAlumni
+	err = withAudit(c, db, opts, func(tx *sql.Tx) ([]audit.Entry, error) {
+		created, err := repository.CreatePekerjaanAlumni(c.UserContext(), tx, pekerjaanRequest)
+		if err != nil {
+			return nil, apperror.Wrap(err, "pekerjaan.create_failed")
+		}
+		pekerjaan = created
+		return []audit.Entry{audit.NewEntry(c, audit.ActionCreate, audit.ResourcePekerjaan, created.ID, nil, created)}, nil
+	})
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error menyimpan data pekerjaan alumni ke database. Detail: " + err.Error(),
-		})
+		return err
 	}
 
+	etag.Set(c, pekerjaan.Version)
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"success": true,
-		"message": "Data pekerjaan alumni berhasil disimpan ke database.",
+		"message": i18n.Msg(c, "pekerjaan.created"),
 		"data":    pekerjaan,
 	})
 }
 
-func UpdatePekerjaanAlumniService(c *fiber.Ctx, db *sql.DB) error {
+func UpdatePekerjaanAlumniService(c *fiber.Ctx, db *sql.DB, opts Options) error {
 	idStr := c.Params("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Parameter ID tidak valid. ID harus berupa angka positif.",
-		})
+		return apperror.Validation("error.invalid_id_param").WithCode("invalid_id")
 	}
 
 	var req model.UpdatePekerjaanAlumniRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Format request body tidak valid. Pastikan JSON format benar. Detail: " + err.Error(),
-		})
-	}
-
-	if req.NamaPerusahaan == "" || req.PosisiJabatan == "" || req.BidangIndustri == "" || req.LokasiKerja == "" || req.StatusPekerjaan == "" || req.TanggalMulaiKerja == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Field wajib tidak lengkap. Nama perusahaan, posisi jabatan, bidang industri, lokasi kerja, status pekerjaan, dan tanggal mulai kerja harus diisi.",
-		})
-	}
-
-	validStatus := map[string]bool{"aktif": true, "selesai": true, "resigned": true}
-	if !validStatus[req.StatusPekerjaan] {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Status pekerjaan tidak valid. Gunakan 'aktif', 'selesai', atau 'resigned'.",
-		})
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
 	}
 
 	tanggalMulaiKerja, err := time.Parse("2006-01-02", req.TanggalMulaiKerja)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Format tanggal mulai kerja tidak valid. Gunakan format YYYY-MM-DD (contoh: 2025-01-15).",
-		})
+		return apperror.Validation("pekerjaan.invalid_start_date")
 	}
 
 	var tanggalSelesaiKerja *time.Time
 	if req.TanggalSelesaiKerja != nil && *req.TanggalSelesaiKerja != "" {
 		parsedTanggalSelesai, err := time.Parse("2006-01-02", *req.TanggalSelesaiKerja)
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"success": false,
-				"message": "Format tanggal selesai kerja tidak valid. Gunakan format YYYY-MM-DD (contoh: 2025-12-31).",
-			})
+			return apperror.Validation("pekerjaan.invalid_end_date")
 		}
 		tanggalSelesaiKerja = &parsedTanggalSelesai
 	}
 
+	salary, err := helper.NormalizeSalary(req.GajiRange, req.GajiMin, req.GajiMax, req.GajiCurrency, req.GajiPeriod)
+	if err != nil {
+		return apperror.Validation("pekerjaan.invalid_salary", err)
+	}
+	gajiRange, gajiMin, gajiMax, gajiCurrency, gajiPeriod := salaryColumns(req.GajiRange, salary)
+
 	pekerjaanRequest := model.UpdatePekerjaanAlumniRepositoryRequest{
 		NamaPerusahaan:      req.NamaPerusahaan,
 		PosisiJabatan:       req.PosisiJabatan,
 		BidangIndustri:      req.BidangIndustri,
 		LokasiKerja:         req.LokasiKerja,
-		GajiRange:           req.GajiRange,
+		GajiRange:           gajiRange,
+		GajiMin:             gajiMin,
+		GajiMax:             gajiMax,
+		GajiCurrency:        gajiCurrency,
+		GajiPeriod:          gajiPeriod,
 		TanggalMulaiKerja:   tanggalMulaiKerja,
 		TanggalSelesaiKerja: tanggalSelesaiKerja,
 		StatusPekerjaan:     req.StatusPekerjaan,
 		DeskripsiPekerjaan:  req.DeskripsiPekerjaan,
 	}
 
-	pekerjaan, err := repository.UpdatePekerjaanAlumni(db, id, pekerjaanRequest)
+	var pekerjaan *model.PekerjaanAlumni
+	err = withAudit(c, db, opts, func(tx *sql.Tx) ([]audit.Entry, error) {
+		before, err := repository.GetPekerjaanAlumniByIDWithDeleted(c.UserContext(), tx, id)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, apperror.NotFound("pekerjaan.not_found_id")
+			}
+			return nil, apperror.Wrap(err, "pekerjaan.fetch_failed")
+		}
+		if before.IsDelete != nil {
+			return nil, apperror.NotFound("pekerjaan.not_found_id")
+		}
+		if err := etag.CheckIfMatch(c, before.Version, opts.RequireIfMatch); err != nil {
+			return nil, err
+		}
+
+		updated, err := repository.UpdatePekerjaanAlumni(c.UserContext(), tx, id, before.Version, pekerjaanRequest)
+		if err != nil {
+			return nil, apperror.Wrap(err, "pekerjaan.update_failed")
+		}
+		pekerjaan = updated
+		return []audit.Entry{audit.NewEntry(c, audit.ActionUpdate, audit.ResourcePekerjaan, id, before, updated)}, nil
+	})
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"success": false,
-				"message": "Data pekerjaan alumni dengan ID tersebut tidak ditemukan di database.",
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengupdate data pekerjaan alumni di database. Detail: " + err.Error(),
-		})
+		return err
 	}
 
+	etag.Set(c, pekerjaan.Version)
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success": true,
-		"message": "Data pekerjaan alumni berhasil diupdate di database.",
+		"message": i18n.Msg(c, "pekerjaan.updated"),
 		"data":    pekerjaan,
 	})
 }
 
-func SoftDeletePekerjaanAlumniService(c *fiber.Ctx, db *sql.DB) error {
+// pekerjaanUpdateRequest mengubah data pekerjaan menjadi request update,
+// dipakai sebagai dokumen awal PATCH.
+func pekerjaanUpdateRequest(pekerjaan *model.PekerjaanAlumni) model.UpdatePekerjaanAlumniRequest {
+	req := model.UpdatePekerjaanAlumniRequest{
+		NamaPerusahaan:     pekerjaan.NamaPerusahaan,
+		PosisiJabatan:      pekerjaan.PosisiJabatan,
+		BidangIndustri:     pekerjaan.BidangIndustri,
+		LokasiKerja:        pekerjaan.LokasiKerja,
+		GajiRange:          pekerjaan.GajiRange,
+		GajiMin:            pekerjaan.GajiMin,
+		GajiMax:            pekerjaan.GajiMax,
+		GajiCurrency:       pekerjaan.GajiCurrency,
+		GajiPeriod:         pekerjaan.GajiPeriod,
+		TanggalMulaiKerja:  pekerjaan.TanggalMulaiKerja.Format("2006-01-02"),
+		StatusPekerjaan:    pekerjaan.StatusPekerjaan,
+		DeskripsiPekerjaan: pekerjaan.DeskripsiPekerjaan,
+	}
+	if pekerjaan.TanggalSelesaiKerja != nil {
+		tanggalSelesai := pekerjaan.TanggalSelesaiKerja.Format("2006-01-02")
+		req.TanggalSelesaiKerja = &tanggalSelesai
+	}
+	return req
+}
+
+// pekerjaanPatchColumns mengubah field yang disentuh PATCH menjadi nilai
+// kolom. Tanggal diparse, dan jika salah satu field gaji disentuh kelima
+// kolom gaji dihitung ulang: gaji_range saja berarti teks baru yang diparse,
+// sedangkan field terstruktur saja membuat teks gaji_range dibentuk ulang.
+func pekerjaanPatchColumns(req *model.UpdatePekerjaanAlumniRequest, touched []string) (map[string]interface{}, error) {
+	columns := patch.Values(req, touched)
+
+	if patch.Touches(touched, "tanggal_mulai_kerja") {
+		tanggalMulaiKerja, err := time.Parse("2006-01-02", req.TanggalMulaiKerja)
+		if err != nil {
+			return nil, apperror.Validation("pekerjaan.invalid_start_date")
+		}
+		columns["tanggal_mulai_kerja"] = tanggalMulaiKerja
+	}
+	if patch.Touches(touched, "tanggal_selesai_kerja") {
+		columns["tanggal_selesai_kerja"] = nil
+		if req.TanggalSelesaiKerja != nil && *req.TanggalSelesaiKerja != "" {
+			tanggalSelesaiKerja, err := time.Parse("2006-01-02", *req.TanggalSelesaiKerja)
+			if err != nil {
+				return nil, apperror.Validation("pekerjaan.invalid_end_date")
+			}
+			columns["tanggal_selesai_kerja"] = tanggalSelesaiKerja
+		}
+	}
+
+	structured := patch.Touches(touched, "gaji_min", "gaji_max", "gaji_currency", "gaji_period")
+	if structured || patch.Touches(touched, "gaji_range") {
+		if !structured {
+			req.GajiMin, req.GajiMax, req.GajiCurrency, req.GajiPeriod = nil, nil, nil, nil
+		} else if !patch.Touches(touched, "gaji_range") {
+			req.GajiRange = nil
+		}
+		salary, err := helper.NormalizeSalary(req.GajiRange, req.GajiMin, req.GajiMax, req.GajiCurrency, req.GajiPeriod)
+		if err != nil {
+			return nil, apperror.Validation("pekerjaan.invalid_salary", err)
+		}
+		gajiRange, gajiMin, gajiMax, gajiCurrency, gajiPeriod := salaryColumns(req.GajiRange, salary)
+		columns["gaji_range"] = gajiRange
+		columns["gaji_min"] = gajiMin
+		columns["gaji_max"] = gajiMax
+		columns["gaji_currency"] = gajiCurrency
+		columns["gaji_period"] = gajiPeriod
+	}
+	return columns, nil
+}
+
+// PatchPekerjaanAlumniService mengubah sebagian field pekerjaan dari JSON
+// Merge Patch atau JSON Patch. Pekerjaan di trash harus di-restore dulu.
+func PatchPekerjaanAlumniService(c *fiber.Ctx, db *sql.DB, opts Options) error {
 	idStr := c.Params("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Parameter ID tidak valid. ID harus berupa angka positif.",
-		})
+		return apperror.Validation("error.invalid_id_param").WithCode("invalid_id")
 	}
 
-	alumniID := c.Locals("alumni_id").(int)
-	role := c.Locals("role").(string)
+	var pekerjaan *model.PekerjaanAlumni
+	err = withAudit(c, db, opts, func(tx *sql.Tx) ([]audit.Entry, error) {
+		before, err := repository.GetPekerjaanAlumniByIDWithDeleted(c.UserContext(), tx, id)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, apperror.NotFound("pekerjaan.not_found_id")
+			}
+			return nil, apperror.Wrap(err, "pekerjaan.fetch_failed")
+		}
+		if before.IsDelete != nil {
+			return nil, apperror.NotFound("pekerjaan.not_found_id")
+		}
+		if err := etag.CheckIfMatch(c, before.Version, opts.RequireIfMatch); err != nil {
+			return nil, err
+		}
 
-	pekerjaan, err := repository.GetPekerjaanAlumniByIDWithDeleted(db, id)
+		var req model.UpdatePekerjaanAlumniRequest
+		touched, err := patch.Apply(c, pekerjaanUpdateRequest(before), &req)
+		if err != nil {
+			return nil, err
+		}
+		if err := validation.Partial(i18n.Lang(c), &req, touched); err != nil {
+			return nil, err
+		}
+		if len(touched) == 0 {
+			pekerjaan = before
+			return nil, nil
+		}
+
+		columns, err := pekerjaanPatchColumns(&req, touched)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := repository.PatchPekerjaanAlumni(c.UserContext(), tx, id, before.Version, columns)
+		if err != nil {
+			return nil, apperror.Wrap(err, "pekerjaan.update_failed")
+		}
+		pekerjaan = updated
+		return []audit.Entry{audit.NewEntry(c, audit.ActionUpdate, audit.ResourcePekerjaan, id, before, updated)}, nil
+	})
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"success": false,
-				"message": "Data pekerjaan alumni dengan ID tersebut tidak ditemukan di database.",
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengambil data pekerjaan alumni dari database. Detail: " + err.Error(),
-		})
+		return err
 	}
 
-	// Cek apakah data sudah di-soft delete
-	if pekerjaan.IsDelete != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Data pekerjaan alumni sudah di-soft delete sebelumnya.",
-		})
+	etag.Set(c, pekerjaan.Version)
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "pekerjaan.updated"),
+		"data":    pekerjaan,
+	})
+}
+
+func SoftDeletePekerjaanAlumniService(c *fiber.Ctx, db *sql.DB, opts Options) error {
+	idStr := c.Params("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		return apperror.Validation("error.invalid_id_param").WithCode("invalid_id")
 	}
 
-	// Validasi permission berdasarkan role
-	if role == "admin" {
-		err = repository.SoftDeletePekerjaanAlumni(db, id)
+	alumniID := c.Locals("alumni_id").(int)
+	role := c.Locals("role").(string)
+
+	err = withAudit(c, db, opts, func(tx *sql.Tx) ([]audit.Entry, error) {
+		pekerjaan, err := repository.GetPekerjaanAlumniByIDWithDeleted(c.UserContext(), tx, id)
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"success": false,
-				"message": "Error menghapus data pekerjaan alumni dari database. Detail: " + err.Error(),
-			})
+			if err == sql.ErrNoRows {
+				return nil, apperror.NotFound("pekerjaan.not_found_id")
+			}
+			return nil, apperror.Wrap(err, "pekerjaan.fetch_failed")
 		}
-	} else {
-		// User hanya bisa soft delete pekerjaan alumni miliknya sendiri
-		if pekerjaan.AlumniID != alumniID {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"message": "Akses ditolak. Anda hanya bisa menghapus pekerjaan alumni milik Anda sendiri.",
-			})
-		}
-		
-		err = repository.SoftDeletePekerjaanAlumniByAlumniID(db, id, alumniID)
+
+		// Cek apakah data sudah di-soft delete
+		if pekerjaan.IsDelete != nil {
+			return nil, apperror.Validation("pekerjaan.already_soft_deleted")
+		}
+		if err := etag.CheckIfMatch(c, pekerjaan.Version, opts.RequireIfMatch); err != nil {
+			return nil, err
+		}
+
+		// Validasi permission berdasarkan role
+		if role == "admin" {
+			err = repository.SoftDeletePekerjaanAlumni(c.UserContext(), tx, id, pekerjaan.Version)
+			if err != nil {
+				return nil, apperror.Wrap(err, "pekerjaan.delete_failed")
+			}
+		} else {
+			// User hanya bisa soft delete pekerjaan alumni miliknya sendiri
+			if pekerjaan.AlumniID != alumniID {
+				return nil, apperror.Forbidden("pekerjaan.forbidden_delete")
+			}
+
+			err = repository.SoftDeletePekerjaanAlumniByAlumniID(c.UserContext(), tx, id, alumniID, pekerjaan.Version)
+			if err != nil {
+				return nil, apperror.Wrap(err, "pekerjaan.delete_failed")
+			}
+		}
+
+		after, err := repository.GetPekerjaanAlumniByIDWithDeleted(c.UserContext(), tx, id)
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"success": false,
-				"message": "Error menghapus data pekerjaan alumni dari database. Detail: " + err.Error(),
-			})
+			return nil, apperror.Wrap(err, "pekerjaan.fetch_failed")
 		}
+		return []audit.Entry{audit.NewEntry(c, audit.ActionSoftDelete, audit.ResourcePekerjaan, id, pekerjaan, after)}, nil
+	})
+	if err != nil {
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success": true,
-		"message": "Data pekerjaan alumni berhasil di-soft delete (ditandai sebagai terhapus).",
+		"message": i18n.Msg(c, "pekerjaan.soft_deleted"),
 	})
 }
 
-func HardDeletePekerjaanAlumniService(c *fiber.Ctx, db *sql.DB) error {
+func HardDeletePekerjaanAlumniService(c *fiber.Ctx, db *sql.DB, opts Options) error {
 	idStr := c.Params("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Parameter ID tidak valid. ID harus berupa angka positif.",
-		})
+		return apperror.Validation("error.invalid_id_param").WithCode("invalid_id")
 	}
 
 	alumniID := c.Locals("alumni_id").(int)
 	role := c.Locals("role").(string)
 
-	pekerjaan, err := repository.GetPekerjaanAlumniByIDWithDeleted(db, id)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"success": false,
-				"message": "Data pekerjaan alumni dengan ID tersebut tidak ditemukan di database.",
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengambil data pekerjaan alumni dari database. Detail: " + err.Error(),
-		})
-	}
+	err = withAudit(c, db, opts, func(tx *sql.Tx) ([]audit.Entry, error) {
+		pekerjaan, err := repository.GetPekerjaanAlumniByIDWithDeleted(c.UserContext(), tx, id)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, apperror.NotFound("pekerjaan.not_found_id")
+			}
+			return nil, apperror.Wrap(err, "pekerjaan.fetch_failed")
+		}
 
-	if pekerjaan.IsDelete == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Data pekerjaan alumni harus di-soft delete terlebih dahulu sebelum bisa dihapus permanen.",
-		})
-	}
+		if pekerjaan.IsDelete == nil {
+			return nil, apperror.Validation("pekerjaan.must_soft_delete_first")
+		}
 
-	if role != "admin" {
-		if pekerjaan.AlumniID != alumniID {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"message": "Akses ditolak. Anda hanya bisa menghapus permanen pekerjaan alumni milik Anda sendiri.",
-			})
+		if role != "admin" {
+			if pekerjaan.AlumniID != alumniID {
+				return nil, apperror.Forbidden("pekerjaan.forbidden_hard_delete")
+			}
+		}
+		if err := etag.CheckIfMatch(c, pekerjaan.Version, opts.RequireIfMatch); err != nil {
+			return nil, err
 		}
-	}
 
-	err = repository.HardDeletePekerjaanAlumni(db, id)
+		err = repository.HardDeletePekerjaanAlumni(c.UserContext(), tx, id, pekerjaan.Version)
+		if err != nil {
+			return nil, apperror.Wrap(err, "pekerjaan.hard_delete_failed")
+		}
+		return []audit.Entry{audit.NewEntry(c, audit.ActionHardDelete, audit.ResourcePekerjaan, id, pekerjaan, nil)}, nil
+	})
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error menghapus data pekerjaan alumni secara permanen dari database. Detail: " + err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success": true,
-		"message": "Data pekerjaan alumni berhasil dihapus secara permanen dari database.",
+		"message": i18n.Msg(c, "pekerjaan.hard_deleted"),
 	})
 }
 
@@ -419,86 +567,79 @@ func GetSoftDeletedPekerjaanAlumniService(c *fiber.Ctx, db *sql.DB) error {
 	var err error
 
 	if role == "admin" {
-		pekerjaanList, err = repository.GetAllSoftDeletedPekerjaanAlumni(db)
+		pekerjaanList, err = repository.GetAllSoftDeletedPekerjaanAlumni(c.UserContext(), db)
 	} else {
-		pekerjaanList, err = repository.GetSoftDeletedPekerjaanAlumni(db, alumniID)
+		pekerjaanList, err = repository.GetSoftDeletedPekerjaanAlumni(c.UserContext(), db, alumniID)
 	}
 
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengambil data pekerjaan alumni yang di-soft delete dari database. Detail: " + err.Error(),
-		})
+		return apperror.Wrap(err, "pekerjaan.trash_fetch_failed")
 	}
 
 	if len(pekerjaanList) == 0 {
 		return c.Status(fiber.StatusOK).JSON(fiber.Map{
 			"success": true,
-			"message": "Belum ada data pekerjaan alumni yang di-soft delete.",
+			"message": i18n.Msg(c, "pekerjaan.trash_empty"),
 			"data":    []model.PekerjaanAlumni{},
 		})
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success": true,
-		"message": "Data pekerjaan alumni yang di-soft delete berhasil diambil dari database.",
+		"message": i18n.Msg(c, "pekerjaan.trash_fetched"),
 		"data":    pekerjaanList,
 	})
 }
 
-func RestorePekerjaanAlumniService(c *fiber.Ctx, db *sql.DB) error {
+func RestorePekerjaanAlumniService(c *fiber.Ctx, db *sql.DB, opts Options) error {
 	idStr := c.Params("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Parameter ID tidak valid. ID harus berupa angka positif.",
-		})
+		return apperror.Validation("error.invalid_id_param").WithCode("invalid_id")
 	}
 
 	alumniID := c.Locals("alumni_id").(int)
 	role := c.Locals("role").(string)
 
-	pekerjaan, err := repository.GetPekerjaanAlumniByIDWithDeleted(db, id)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"success": false,
-				"message": "Data pekerjaan alumni dengan ID tersebut tidak ditemukan di database.",
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengambil data pekerjaan alumni dari database. Detail: " + err.Error(),
-		})
-	}
+	err = withAudit(c, db, opts, func(tx *sql.Tx) ([]audit.Entry, error) {
+		pekerjaan, err := repository.GetPekerjaanAlumniByIDWithDeleted(c.UserContext(), tx, id)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, apperror.NotFound("pekerjaan.not_found_id")
+			}
+			return nil, apperror.Wrap(err, "pekerjaan.fetch_failed")
+		}
 
-	if pekerjaan.IsDelete == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Data pekerjaan alumni belum di-soft delete, tidak bisa di-restore.",
-		})
-	}
+		if pekerjaan.IsDelete == nil {
+			return nil, apperror.Validation("pekerjaan.not_soft_deleted")
+		}
 
-	if role != "admin" {
-		if pekerjaan.AlumniID != alumniID {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"message": "Akses ditolak. Anda hanya bisa restore pekerjaan alumni milik Anda sendiri.",
-			})
+		if role != "admin" {
+			if pekerjaan.AlumniID != alumniID {
+				return nil, apperror.Forbidden("pekerjaan.forbidden_restore")
+			}
+		}
+		if err := etag.CheckIfMatch(c, pekerjaan.Version, opts.RequireIfMatch); err != nil {
+			return nil, err
+		}
+
+		err = repository.RestorePekerjaanAlumni(c.UserContext(), tx, id, pekerjaan.Version)
+		if err != nil {
+			return nil, apperror.Wrap(err, "pekerjaan.restore_failed")
 		}
-	}
 
-	err = repository.RestorePekerjaanAlumni(db, id)
+		after, err := repository.GetPekerjaanAlumniByIDWithDeleted(c.UserContext(), tx, id)
+		if err != nil {
+			return nil, apperror.Wrap(err, "pekerjaan.fetch_failed")
+		}
+		return []audit.Entry{audit.NewEntry(c, audit.ActionRestore, audit.ResourcePekerjaan, id, pekerjaan, after)}, nil
+	})
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error restore data pekerjaan alumni dari database. Detail: " + err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success": true,
-		"message": "Data pekerjaan alumni berhasil di-restore.",
+		"message": i18n.Msg(c, "pekerjaan.restored"),
 	})
 }
\ No newline at end of file
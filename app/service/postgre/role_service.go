@@ -4,23 +4,24 @@ import (
 	"database/sql"
 	model "go-fiber/app/model/postgre"
 	repository "go-fiber/app/repository/postgre"
+	"go-fiber/apperror"
+	"go-fiber/audit"
+	"go-fiber/i18n"
+	"go-fiber/validation"
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 func GetAllRolesService(c *fiber.Ctx, db *sql.DB) error {
-	roles, err := repository.GetAllRoles(db)
+	roles, err := repository.GetAllRoles(c.UserContext(), db)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengambil data roles dari database. Detail: " + err.Error(),
-		})
+		return apperror.Wrap(err, "role.list_fetch_failed")
 	}
 
 	response := model.GetAllRolesResponse{
 		Success: true,
-		Message: "Data roles berhasil diambil dari database.",
+		Message: i18n.Msg(c, "role.list_fetched"),
 		Data:    roles,
 	}
 
@@ -31,139 +32,124 @@ func GetRoleByIDService(c *fiber.Ctx, db *sql.DB) error {
 	idStr := c.Params("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Parameter ID tidak valid. ID harus berupa angka positif.",
-		})
+		return apperror.Validation("error.invalid_id_param").WithCode("invalid_id")
 	}
 
-	role, err := repository.GetRoleByID(db, id)
+	role, err := repository.GetRoleByID(c.UserContext(), db, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"success": false,
-				"message": "Data role dengan ID tersebut tidak ditemukan di database.",
-			})
+			return apperror.NotFound("role.not_found_id")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengambil data role dari database. Detail: " + err.Error(),
-		})
+		return apperror.Wrap(err, "role.fetch_failed")
 	}
 
 	response := model.GetRoleByIDResponse{
 		Success: true,
-		Message: "Data role berhasil diambil dari database.",
+		Message: i18n.Msg(c, "role.fetched"),
 		Data:    *role,
 	}
 
 	return c.Status(fiber.StatusOK).JSON(response)
 }
 
-func CreateRoleService(c *fiber.Ctx, db *sql.DB) error {
+func CreateRoleService(c *fiber.Ctx, db *sql.DB, opts Options) error {
 	var req model.CreateRoleRequest
-	
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Format request body tidak valid. Pastikan JSON format benar. Detail: " + err.Error(),
-		})
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
 	}
 
-	if req.Nama == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Field nama role wajib diisi.",
-		})
-	}
-
-	role, err := repository.CreateRole(db, req.Nama)
+	var role *model.Role
+	err := withAudit(c, db, opts, func(tx *sql.Tx) ([]audit.Entry, error) {
+		created, err := repository.CreateRole(c.UserContext(), tx, req.Nama)
+		if err != nil {
+			return nil, apperror.Wrap(err, "role.create_failed")
+		}
+		role = created
+		return []audit.Entry{audit.NewEntry(c, audit.ActionCreate, audit.ResourceRole, created.ID, nil, created)}, nil
+	})
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error menyimpan data role ke database. Detail: " + err.Error(),
-		})
+		return err
 	}
 
 	response := model.CreateRoleResponse{
 		Success: true,
-		Message: "Data role berhasil disimpan ke database.",
+		Message: i18n.Msg(c, "role.created"),
 		Data:    *role,
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(response)
 }
 
-func UpdateRoleService(c *fiber.Ctx, db *sql.DB) error {
+func UpdateRoleService(c *fiber.Ctx, db *sql.DB, opts Options) error {
 	idStr := c.Params("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Parameter ID tidak valid. ID harus berupa angka positif.",
-		})
+		return apperror.Validation("error.invalid_id_param").WithCode("invalid_id")
 	}
 
 	var req model.UpdateRoleRequest
-	
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Format request body tidak valid. Pastikan JSON format benar. Detail: " + err.Error(),
-		})
-	}
-
-	if req.Nama == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Field nama role wajib diisi.",
-		})
-	}
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+
+	var role *model.Role
+	err = withAudit(c, db, opts, func(tx *sql.Tx) ([]audit.Entry, error) {
+		before, err := repository.GetRoleByID(c.UserContext(), tx, id)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, apperror.NotFound("role.not_found_id")
+			}
+			return nil, apperror.Wrap(err, "role.fetch_failed")
+		}
 
-	role, err := repository.UpdateRole(db, id, req.Nama)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"success": false,
-				"message": "Data role dengan ID tersebut tidak ditemukan di database.",
-			})
+		updated, err := repository.UpdateRole(c.UserContext(), tx, id, req.Nama)
+		if err != nil {
+			return nil, apperror.Wrap(err, "role.update_failed")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error mengupdate data role di database. Detail: " + err.Error(),
-		})
+		role = updated
+		return []audit.Entry{audit.NewEntry(c, audit.ActionUpdate, audit.ResourceRole, id, before, updated)}, nil
+	})
+	if err != nil {
+		return err
 	}
 
 	response := model.UpdateRoleResponse{
 		Success: true,
-		Message: "Data role berhasil diupdate di database.",
+		Message: i18n.Msg(c, "role.updated"),
 		Data:    *role,
 	}
 
 	return c.Status(fiber.StatusOK).JSON(response)
 }
 
-func DeleteRoleService(c *fiber.Ctx, db *sql.DB) error {
+func DeleteRoleService(c *fiber.Ctx, db *sql.DB, opts Options) error {
 	idStr := c.Params("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Parameter ID tidak valid. ID harus berupa angka positif.",
-		})
+		return apperror.Validation("error.invalid_id_param").WithCode("invalid_id")
 	}
 
-	err = repository.DeleteRole(db, id)
+	err = withAudit(c, db, opts, func(tx *sql.Tx) ([]audit.Entry, error) {
+		before, err := repository.GetRoleByID(c.UserContext(), tx, id)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, apperror.NotFound("role.not_found_id")
+			}
+			return nil, apperror.Wrap(err, "role.fetch_failed")
+		}
+
+		if err := repository.DeleteRole(c.UserContext(), tx, id); err != nil {
+			return nil, apperror.Wrap(err, "role.delete_failed")
+		}
+		return []audit.Entry{audit.NewEntry(c, audit.ActionDelete, audit.ResourceRole, id, before, nil)}, nil
+	})
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Error menghapus data role dari database. Detail: " + err.Error(),
-		})
+		return err
 	}
 
 	response := model.DeleteRoleResponse{
 		Success: true,
-		Message: "Data role berhasil dihapus dari database.",
+		Message: i18n.Msg(c, "role.deleted"),
 	}
 
 	return c.Status(fiber.StatusOK).JSON(response)
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	repository "go-fiber/app/repository/postgre"
+	"go-fiber/audit"
+	"go-fiber/queue"
+	"log/slog"
+	"time"
+)
+
+// PurgeTrashJobType menghapus permanen pekerjaan yang sudah di-soft delete
+// lebih lama dari retensi.
+const PurgeTrashJobType = "pekerjaan.purge_trash"
+
+// purgeTrashBatchSize membatasi jumlah pekerjaan yang dihapus per transaksi.
+const purgeTrashBatchSize = 500
+
+// RegisterPurgeTrashJob mendaftarkan job PurgeTrashJobType ke q dan
+// menjadwalkannya setiap hari untuk pekerjaan yang di-soft delete lebih dari
+// retention yang lalu.
+func RegisterPurgeTrashJob(q *queue.Queue, db *sql.DB, opts Options, retention time.Duration) error {
+	queue.Register(q, PurgeTrashJobType, func(ctx context.Context, _ struct{}) error {
+		purged, err := PurgeTrashPekerjaanAlumni(ctx, db, opts, time.Now().Add(-retention))
+		if purged > 0 {
+			slog.InfoContext(ctx, "Pekerjaan di trash dihapus permanen", "purged", purged)
+		}
+		return err
+	}, queue.HandlerOptions{Concurrency: 1})
+	return q.Schedule(PurgeTrashJobType, "@daily", PurgeTrashJobType, struct{}{})
+}
+
+// PurgeTrashPekerjaanAlumni menghapus permanen pekerjaan yang di-soft delete
+// sebelum before, per batch dalam transaksi terpisah. Setiap pekerjaan dicatat
+// di audit log sebagai hard_delete dengan actor PurgeTrashJobType. Hasilnya
+// adalah jumlah pekerjaan yang sudah dihapus, termasuk jika batch berikutnya
+// gagal.
+func PurgeTrashPekerjaanAlumni(ctx context.Context, db *sql.DB, opts Options, before time.Time) (int, error) {
+	purged := 0
+	for {
+		batch := 0
+		err := withAuditContext(ctx, db, opts, func(tx *sql.Tx) ([]audit.Entry, error) {
+			deleted, err := repository.PurgeSoftDeletedPekerjaanAlumni(ctx, tx, before, purgeTrashBatchSize)
+			if err != nil {
+				return nil, err
+			}
+			batch = len(deleted)
+
+			entries := make([]audit.Entry, 0, len(deleted))
+			for i := range deleted {
+				entries = append(entries, audit.NewSystemEntry(PurgeTrashJobType, audit.ActionHardDelete, audit.ResourcePekerjaan, deleted[i].ID, deleted[i], nil))
+			}
+			return entries, nil
+		})
+		if err != nil {
+			return purged, err
+		}
+		purged += batch
+		if batch < purgeTrashBatchSize {
+			return purged, nil
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"go-fiber/apperror"
+	"go-fiber/i18n"
+	"go-fiber/validation"
+	"go-fiber/webhook"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// webhookError mengubah webhook.ErrNotFound menjadi 404 dengan notFoundKey
+// dan webhook.ErrInvalidState menjadi 409; error lain dibungkus dengan key.
+func webhookError(err error, notFoundKey, key string) error {
+	switch {
+	case errors.Is(err, webhook.ErrNotFound):
+		return apperror.NotFound(notFoundKey)
+	case errors.Is(err, webhook.ErrInvalidState):
+		return apperror.Conflict("webhook.delivery_pending")
+	}
+	return apperror.Wrap(err, key)
+}
+
+func GetWebhooksService(c *fiber.Ctx, db *sql.DB) error {
+	subscriptions, err := webhook.NewPostgresStore(db).ListSubscriptions(c.UserContext())
+	if err != nil {
+		return apperror.Wrap(err, "webhook.list_fetch_failed")
+	}
+	for i := range subscriptions {
+		subscriptions[i] = subscriptions[i].WithoutSecret()
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "webhook.list_fetched"),
+		"data":    subscriptions,
+	})
+}
+
+func GetWebhookService(c *fiber.Ctx, db *sql.DB) error {
+	subscription, err := webhook.NewPostgresStore(db).GetSubscription(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return webhookError(err, "webhook.not_found", "webhook.fetch_failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "webhook.fetched"),
+		"data":    subscription.WithoutSecret(),
+	})
+}
+
+// CreateWebhookService adalah satu-satunya response yang berisi secret
+// subscription; simpan secret ini untuk memverifikasi signature.
+func CreateWebhookService(c *fiber.Ctx, db *sql.DB) error {
+	var req webhook.SubscriptionRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+
+	subscription, err := webhook.NewSubscription(req)
+	if err != nil {
+		return apperror.Wrap(err, "webhook.create_failed")
+	}
+	if err := webhook.NewPostgresStore(db).CreateSubscription(c.UserContext(), &subscription); err != nil {
+		return apperror.Wrap(err, "webhook.create_failed")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "webhook.created"),
+		"data":    subscription,
+	})
+}
+
+func UpdateWebhookService(c *fiber.Ctx, db *sql.DB) error {
+	store := webhook.NewPostgresStore(db)
+	subscription, err := store.GetSubscription(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return webhookError(err, "webhook.not_found", "webhook.fetch_failed")
+	}
+
+	var req webhook.SubscriptionRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+	req.Apply(subscription)
+	if err := store.UpdateSubscription(c.UserContext(), subscription); err != nil {
+		return webhookError(err, "webhook.not_found", "webhook.update_failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "webhook.updated"),
+		"data":    subscription.WithoutSecret(),
+	})
+}
+
+func DeleteWebhookService(c *fiber.Ctx, db *sql.DB) error {
+	if err := webhook.NewPostgresStore(db).DeleteSubscription(c.UserContext(), c.Params("id")); err != nil {
+		return webhookError(err, "webhook.not_found", "webhook.delete_failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "webhook.deleted"),
+	})
+}
+
+// TestWebhookService menjadwalkan event webhook.test ke subscription. Hasil
+// pengirimannya dilihat di log delivery yang dikembalikan.
+func TestWebhookService(c *fiber.Ctx, db *sql.DB) error {
+	store := webhook.NewPostgresStore(db)
+	subscription, err := store.GetSubscription(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return webhookError(err, "webhook.not_found", "webhook.fetch_failed")
+	}
+	if !subscription.Active {
+		return apperror.Unprocessable("webhook.inactive")
+	}
+
+	event := webhook.NewTestEvent(c, *subscription)
+	if err := store.EnqueueDeliveries(c.UserContext(), webhook.NewDelivery(subscription.ID, event)); err != nil {
+		return apperror.Wrap(err, "webhook.test_failed")
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "webhook.test_queued"),
+		"data":    event,
+	})
+}
+
+// GetWebhookDeliveriesService menampilkan log delivery satu subscription,
+// terbaru lebih dulu.
+func GetWebhookDeliveriesService(c *fiber.Ctx, db *sql.DB) error {
+	store := webhook.NewPostgresStore(db)
+	if _, err := store.GetSubscription(c.UserContext(), c.Params("id")); err != nil {
+		return webhookError(err, "webhook.not_found", "webhook.fetch_failed")
+	}
+	return listDeliveries(c, store, c.Params("id"), "")
+}
+
+// GetDeliveriesService menampilkan delivery semua subscription, bisa
+// difilter dengan query status.
+func GetDeliveriesService(c *fiber.Ctx, db *sql.DB) error {
+	return listDeliveries(c, webhook.NewPostgresStore(db), "", "")
+}
+
+// GetDeadLettersService menampilkan delivery yang berhenti dicoba.
+func GetDeadLettersService(c *fiber.Ctx, db *sql.DB) error {
+	return listDeliveries(c, webhook.NewPostgresStore(db), "", webhook.StatusDead)
+}
+
+func listDeliveries(c *fiber.Ctx, store webhook.Store, subscriptionID, status string) error {
+	filter, err := webhook.ParseDeliveryFilter(c)
+	if err != nil {
+		return err
+	}
+	filter.SubscriptionID = subscriptionID
+	if status != "" {
+		filter.Status = status
+	}
+
+	deliveries, total, err := store.ListDeliveries(c.UserContext(), filter)
+	if err != nil {
+		return apperror.Wrap(err, "webhook.delivery_list_fetch_failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "webhook.delivery_list_fetched"),
+		"data":    deliveries,
+		"meta":    webhook.NewMeta(filter, total),
+	})
+}
+
+func GetDeliveryService(c *fiber.Ctx, db *sql.DB) error {
+	delivery, err := webhook.NewPostgresStore(db).GetDelivery(c.UserContext(), c.Params("deliveryId"))
+	if err != nil {
+		return webhookError(err, "webhook.delivery_not_found", "webhook.delivery_fetch_failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "webhook.delivery_fetched"),
+		"data":    delivery,
+	})
+}
+
+// RedeliverService menjadwalkan ulang delivery yang sudah berhasil atau ada
+// di dead-letter untuk segera dikirim. Delivery yang masih pending ditolak
+// dengan 409.
+func RedeliverService(c *fiber.Ctx, db *sql.DB) error {
+	delivery, err := webhook.NewPostgresStore(db).Redeliver(c.UserContext(), c.Params("deliveryId"))
+	if err != nil {
+		return webhookError(err, "webhook.delivery_not_found", "webhook.redeliver_failed")
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "webhook.redelivery_queued"),
+		"data":    delivery,
+	})
+}
@@ -0,0 +1,213 @@
+package apperror
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"strings"
+
+	"go-fiber/i18n"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/lib/pq"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Error adalah error domain yang dikembalikan repository, service, dan
+// middleware. Handler mengubahnya menjadi response problem+json dengan Status
+// dan Code. Err hanya dicatat di log dan tidak pernah dikirim ke client.
+type Error struct {
+	Status int
+	Code   string
+	// Key adalah key katalog i18n untuk pesan yang dikirim ke client dan
+	// Args argumen format-nya. Pesan diterjemahkan Handler sesuai bahasa
+	// request.
+	Key  string
+	Args []interface{}
+	// Details ditampilkan apa adanya di response, misalnya daftar kesalahan
+	// baris import.
+	Details interface{}
+	Err     error
+}
+
+func (e *Error) Error() string {
+	message := e.Message(i18n.Default)
+	if e.Err != nil {
+		return message + ": " + e.Err.Error()
+	}
+	return message
+}
+
+// Message mengembalikan pesan untuk client dalam bahasa lang.
+func (e *Error) Message(lang string) string {
+	return i18n.T(lang, e.Key, e.Args...)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is membuat errors.Is(err, apperror.ErrNotFound) bernilai true untuk setiap
+// Error dengan status yang sama, apa pun pesannya.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Status == e.Status
+}
+
+// WithCode mengganti kode default dengan kode yang lebih spesifik.
+func (e *Error) WithCode(code string) *Error {
+	e.Code = code
+	return e
+}
+
+// WithDetails menambahkan data pendukung yang ikut dikirim ke client.
+func (e *Error) WithDetails(details interface{}) *Error {
+	e.Details = details
+	return e
+}
+
+// WithCause menyimpan error asli untuk log.
+func (e *Error) WithCause(err error) *Error {
+	e.Err = err
+	return e
+}
+
+// Sentinel untuk errors.Is.
+var (
+	ErrValidation   = &Error{Status: fiber.StatusBadRequest, Code: "validation_failed"}
+	ErrUnauthorized = &Error{Status: fiber.StatusUnauthorized, Code: "unauthorized"}
+	ErrForbidden    = &Error{Status: fiber.StatusForbidden, Code: "forbidden"}
+	ErrNotFound     = &Error{Status: fiber.StatusNotFound, Code: "not_found"}
+	ErrConflict     = &Error{Status: fiber.StatusConflict, Code: "conflict"}
+	ErrInternal     = &Error{Status: fiber.StatusInternalServerError, Code: "internal_error"}
+)
+
+// New membuat Error dengan pesan dari key katalog i18n dan argumen format
+// args. Constructor di bawah memakai pola yang sama.
+func New(status int, code, key string, args ...interface{}) *Error {
+	return &Error{Status: status, Code: code, Key: key, Args: args}
+}
+
+// Validation menandakan input client tidak valid (400).
+func Validation(key string, args ...interface{}) *Error {
+	return New(fiber.StatusBadRequest, "validation_failed", key, args...)
+}
+
+// Unauthorized menandakan client belum terautentikasi (401).
+func Unauthorized(key string, args ...interface{}) *Error {
+	return New(fiber.StatusUnauthorized, "unauthorized", key, args...)
+}
+
+// Forbidden menandakan client tidak berhak mengakses resource (403).
+func Forbidden(key string, args ...interface{}) *Error {
+	return New(fiber.StatusForbidden, "forbidden", key, args...)
+}
+
+// NotFound menandakan resource tidak ada (404).
+func NotFound(key string, args ...interface{}) *Error {
+	return New(fiber.StatusNotFound, "not_found", key, args...)
+}
+
+// Conflict menandakan request bentrok dengan data yang ada (409).
+func Conflict(key string, args ...interface{}) *Error {
+	return New(fiber.StatusConflict, "conflict", key, args...)
+}
+
+// Unprocessable menandakan isi request terbaca tetapi tidak bisa diproses,
+// misalnya file import yang barisnya tidak valid (422).
+func Unprocessable(key string, args ...interface{}) *Error {
+	return New(fiber.StatusUnprocessableEntity, "unprocessable_entity", key, args...)
+}
+
+// Internal menandakan kegagalan di sisi server (500). Pesan dari key dikirim
+// ke client, err hanya dicatat di log.
+func Internal(key string, err error) *Error {
+	return New(fiber.StatusInternalServerError, "internal_error", key).WithCause(err)
+}
+
+// InvalidID dipakai saat parameter ID tidak bisa diparse.
+func InvalidID() *Error {
+	return Validation("error.invalid_id").WithCode("invalid_id")
+}
+
+// VersionConflict dipakai saat data sudah diubah request lain sejak versi
+// yang diharapkan client, baik dari header If-Match maupun saat write (412).
+func VersionConflict() *Error {
+	return New(fiber.StatusPreconditionFailed, "version_conflict", "error.version_conflict")
+}
+
+// PreconditionRequired dipakai saat header If-Match wajib tetapi tidak
+// dikirim (428).
+func PreconditionRequired() *Error {
+	return New(fiber.StatusPreconditionRequired, "precondition_required", "error.if_match_required")
+}
+
+// InvalidBody dipakai saat body request gagal diparse.
+func InvalidBody(err error) *Error {
+	return Validation("error.invalid_body").WithCode("invalid_body").WithCause(err)
+}
+
+// Wrap mengembalikan err apa adanya jika sudah berupa Error, mengubah data
+// kosong menjadi NotFound dan error database yang dikenal lewat FromDB, dan
+// selain itu menjadi Internal dengan pesan dari key dan args.
+func Wrap(err error, key string, args ...interface{}) error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return err
+	}
+	if errors.Is(err, sql.ErrNoRows) || errors.Is(err, mongo.ErrNoDocuments) {
+		return NotFound("error.not_found").WithCause(err)
+	}
+	if dbErr := FromDB(err); dbErr != err {
+		return dbErr
+	}
+	return New(fiber.StatusInternalServerError, "internal_error", key, args...).WithCause(err)
+}
+
+var mongoDuplicateIndex = regexp.MustCompile(`index: (\w+?)(?:_-?1)*\s`)
+
+// FromDB memetakan error driver PostgreSQL dan MongoDB ke Error: pelanggaran
+// unique index menjadi Conflict dengan kode duplicate_<field> dan pelanggaran
+// foreign key menjadi Conflict. Error lain, termasuk sql.ErrNoRows yang masih
+// diperiksa repository, dikembalikan apa adanya.
+func FromDB(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "23505":
+			return duplicate(postgresConstraintField(pqErr), err)
+		case "23503":
+			return Conflict("error.foreign_key").WithCode("foreign_key_violation").WithCause(err)
+		}
+	}
+
+	if mongo.IsDuplicateKeyError(err) {
+		field := ""
+		if match := mongoDuplicateIndex.FindStringSubmatch(err.Error()); match != nil {
+			field = match[1]
+		}
+		return duplicate(field, err)
+	}
+	return err
+}
+
+// postgresConstraintField mengambil nama kolom dari constraint bawaan
+// PostgreSQL seperti alumni_email_key.
+func postgresConstraintField(err *pq.Error) string {
+	field := strings.TrimSuffix(err.Constraint, "_key")
+	return strings.TrimPrefix(field, err.Table+"_")
+}
+
+func duplicate(field string, err error) *Error {
+	if field == "" {
+		return Conflict("error.duplicate").WithCode("duplicate_key").WithCause(err)
+	}
+	return Conflict("error.duplicate_field", field).
+		WithCode("duplicate_" + field).
+		WithDetails(fiber.Map{"field": field}).
+		WithCause(err)
+}
@@ -0,0 +1,119 @@
+package apperror
+
+import (
+	"errors"
+	"net/http"
+
+	"go-fiber/i18n"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ContentType adalah media type response error (RFC 7807).
+const ContentType = "application/problem+json"
+
+// Problem adalah body response error sesuai RFC 7807. Code adalah kode stabil
+// yang bisa dipakai client untuk membedakan jenis error tanpa membaca Detail.
+type Problem struct {
+	Type      string      `json:"type"`
+	Title     string      `json:"title"`
+	Status    int         `json:"status"`
+	Detail    string      `json:"detail"`
+	Instance  string      `json:"instance"`
+	Code      string      `json:"code"`
+	RequestID string      `json:"request_id,omitempty"`
+	Details   interface{} `json:"details,omitempty"`
+	Success   bool        `json:"success"`
+}
+
+// StatusCode mengembalikan status HTTP untuk err. Error selain Error dan
+// fiber.Error dianggap 500.
+func StatusCode(err error) int {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Status
+	}
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Code
+	}
+	return fiber.StatusInternalServerError
+}
+
+// ResponseStatus mengembalikan status yang akan diterima client untuk request
+// yang handler-nya mengembalikan err. Dipakai middleware yang berjalan sebelum
+// Handler menulis response, misalnya logger dan metrics.
+func ResponseStatus(c *fiber.Ctx, err error) int {
+	if err != nil {
+		return StatusCode(err)
+	}
+	return c.Response().StatusCode()
+}
+
+// Handler adalah fiber ErrorHandler yang mengubah setiap error menjadi
+// response problem+json dengan Detail dalam bahasa request. Pesan error yang
+// tidak dikenal tidak dikirim ke client agar detail driver database tidak
+// bocor; error lengkapnya sudah dicatat oleh LoggerMiddleware.
+func Handler(c *fiber.Ctx, err error) error {
+	lang := i18n.Lang(c)
+	problem := Problem{
+		Type:     "about:blank",
+		Instance: c.OriginalURL(),
+	}
+	problem.RequestID, _ = c.Locals("request_id").(string)
+
+	var appErr *Error
+	var fiberErr *fiber.Error
+	switch {
+	case errors.As(err, &appErr):
+		problem.Status = appErr.Status
+		problem.Code = appErr.Code
+		problem.Detail = appErr.Message(lang)
+		problem.Details = appErr.Details
+	case errors.As(err, &fiberErr):
+		problem.Status = fiberErr.Code
+		problem.Code = codeForStatus(fiberErr.Code)
+		problem.Detail = fiberErr.Message
+		// Pesan bawaan fiber seperti "Cannot GET /x" hanya berbahasa
+		// Inggris, jadi status yang ada di katalog diterjemahkan.
+		if text, ok := i18n.Lookup(lang, "http."+problem.Code); ok {
+			problem.Detail = text
+		}
+	default:
+		problem.Status = fiber.StatusInternalServerError
+		problem.Code = "internal_error"
+		problem.Detail = i18n.T(lang, "error.internal")
+	}
+	problem.Title = http.StatusText(problem.Status)
+
+	return c.Status(problem.Status).JSON(problem, ContentType)
+}
+
+func codeForStatus(status int) string {
+	switch status {
+	case fiber.StatusBadRequest:
+		return "bad_request"
+	case fiber.StatusUnauthorized:
+		return "unauthorized"
+	case fiber.StatusForbidden:
+		return "forbidden"
+	case fiber.StatusNotFound:
+		return "not_found"
+	case fiber.StatusMethodNotAllowed:
+		return "method_not_allowed"
+	case fiber.StatusConflict:
+		return "conflict"
+	case fiber.StatusRequestEntityTooLarge:
+		return "payload_too_large"
+	case fiber.StatusUnprocessableEntity:
+		return "unprocessable_entity"
+	case fiber.StatusTooManyRequests:
+		return "too_many_requests"
+	case fiber.StatusServiceUnavailable:
+		return "service_unavailable"
+	}
+	if status >= fiber.StatusInternalServerError {
+		return "internal_error"
+	}
+	return "error"
+}
@@ -0,0 +1,181 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Aksi yang dicatat untuk setiap mutasi data.
+const (
+	ActionCreate     = "create"
+	ActionUpdate     = "update"
+	ActionDelete     = "delete"
+	ActionSoftDelete = "soft_delete"
+	ActionHardDelete = "hard_delete"
+	ActionRestore    = "restore"
+	ActionImport     = "import"
+	ActionRevert     = "revert"
+)
+
+// Resource yang perubahannya dicatat.
+const (
+	ResourceAlumni    = "alumni"
+	ResourcePekerjaan = "pekerjaan"
+	ResourceRole      = "role"
+	ResourceFile      = "file"
+)
+
+// redactedFields tidak pernah disimpan di snapshot walaupun muncul di JSON.
+var redactedFields = []string{"password", "password_hash"}
+
+// Change adalah satu field yang nilainya berbeda antara before dan after.
+type Change struct {
+	Field  string      `json:"field" bson:"field"`
+	Before interface{} `json:"before" bson:"before"`
+	After  interface{} `json:"after" bson:"after"`
+}
+
+// Entry adalah satu catatan audit. Before kosong untuk create dan After kosong
+// untuk delete; Changes hanya diisi jika keduanya ada.
+type Entry struct {
+	ID         string                 `json:"id" bson:"-"`
+	ActorID    string                 `json:"actor_id" bson:"actor_id"`
+	ActorEmail string                 `json:"actor_email" bson:"actor_email"`
+	ActorRole  string                 `json:"actor_role" bson:"actor_role"`
+	Action     string                 `json:"action" bson:"action"`
+	Resource   string                 `json:"resource" bson:"resource"`
+	ResourceID string                 `json:"resource_id" bson:"resource_id"`
+	Before     map[string]interface{} `json:"before,omitempty" bson:"before,omitempty"`
+	After      map[string]interface{} `json:"after,omitempty" bson:"after,omitempty"`
+	Changes    []Change               `json:"changes,omitempty" bson:"changes,omitempty"`
+	IP         string                 `json:"ip" bson:"ip"`
+	RequestID  string                 `json:"request_id" bson:"request_id"`
+	CreatedAt  time.Time              `json:"created_at" bson:"created_at"`
+}
+
+// Recorder menyimpan entry audit. Tidak ada operasi ubah atau hapus.
+type Recorder interface {
+	Append(ctx context.Context, entries ...Entry) error
+}
+
+// Store adalah Recorder yang juga bisa dicari untuk endpoint admin.
+type Store interface {
+	Recorder
+	// Query mengembalikan entry sesuai filter, terbaru lebih dulu, beserta
+	// jumlah total entry yang cocok.
+	Query(ctx context.Context, filter Filter) ([]Entry, int64, error)
+	// History mengembalikan semua entry satu resource, terlama lebih dulu.
+	History(ctx context.Context, resource, resourceID string) ([]Entry, error)
+}
+
+// NewEntry membentuk entry dari request yang sedang berjalan. Actor diambil
+// dari locals yang diisi middleware AuthRequired, request id dari middleware
+// RequestID. before dan after boleh nil.
+func NewEntry(c *fiber.Ctx, action, resource string, resourceID interface{}, before, after interface{}) Entry {
+	entry := Entry{
+		Action:     action,
+		Resource:   resource,
+		ResourceID: formatID(resourceID),
+		Before:     Snapshot(before),
+		After:      Snapshot(after),
+		IP:         c.IP(),
+		CreatedAt:  time.Now().UTC(),
+	}
+	if alumniID := c.Locals("alumni_id"); alumniID != nil {
+		entry.ActorID = formatID(alumniID)
+	}
+	entry.ActorEmail, _ = c.Locals("email").(string)
+	entry.ActorRole, _ = c.Locals("role").(string)
+	entry.RequestID, _ = c.Locals("request_id").(string)
+	if entry.Before != nil && entry.After != nil {
+		entry.Changes = Diff(entry.Before, entry.After)
+	}
+	return entry
+}
+
+// RoleSystem adalah ActorRole entry yang dibuat background job.
+const RoleSystem = "system"
+
+// NewSystemEntry membentuk entry untuk perubahan yang dibuat background job,
+// bukan request user. actor, misalnya tipe job, dicatat sebagai ActorID.
+func NewSystemEntry(actor, action, resource string, resourceID interface{}, before, after interface{}) Entry {
+	entry := Entry{
+		ActorID:    actor,
+		ActorRole:  RoleSystem,
+		Action:     action,
+		Resource:   resource,
+		ResourceID: formatID(resourceID),
+		Before:     Snapshot(before),
+		After:      Snapshot(after),
+		CreatedAt:  time.Now().UTC(),
+	}
+	if entry.Before != nil && entry.After != nil {
+		entry.Changes = Diff(entry.Before, entry.After)
+	}
+	return entry
+}
+
+// formatID menulis ObjectID MongoDB sebagai hex dan ID lain apa adanya.
+func formatID(id interface{}) string {
+	if hexID, ok := id.(interface{ Hex() string }); ok {
+		return hexID.Hex()
+	}
+	return fmt.Sprint(id)
+}
+
+// Snapshot mengubah v menjadi map sesuai representasi JSON-nya, tanpa field
+// rahasia seperti password. Mengembalikan nil jika v nil atau tidak bisa
+// di-encode.
+func Snapshot(v interface{}) map[string]interface{} {
+	if v == nil {
+		return nil
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var snapshot map[string]interface{}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil
+	}
+	for _, field := range redactedFields {
+		delete(snapshot, field)
+	}
+	return snapshot
+}
+
+// Diff mengembalikan field yang berbeda antara before dan after, urut nama
+// field.
+func Diff(before, after map[string]interface{}) []Change {
+	fields := map[string]bool{}
+	for field := range before {
+		fields[field] = true
+	}
+	for field := range after {
+		fields[field] = true
+	}
+
+	names := make([]string, 0, len(fields))
+	for field := range fields {
+		names = append(names, field)
+	}
+	sort.Strings(names)
+
+	var changes []Change
+	for _, field := range names {
+		if !reflect.DeepEqual(before[field], after[field]) {
+			changes = append(changes, Change{Field: field, Before: before[field], After: after[field]})
+		}
+	}
+	return changes
+}
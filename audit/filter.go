@@ -0,0 +1,98 @@
+package audit
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"go-fiber/apperror"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Filter membatasi entry yang dikembalikan Query. Field kosong tidak
+// membatasi apa pun.
+type Filter struct {
+	// Actor dicocokkan dengan actor_id atau actor_email.
+	Actor      string
+	Resource   string
+	ResourceID string
+	Action     string
+	From       *time.Time
+	To         *time.Time
+	Page       int
+	Limit      int
+}
+
+// Offset adalah jumlah entry yang dilewati untuk halaman Page.
+func (f Filter) Offset() int {
+	return (f.Page - 1) * f.Limit
+}
+
+// Meta adalah informasi pagination pada response endpoint audit.
+type Meta struct {
+	Page  int   `json:"page"`
+	Limit int   `json:"limit"`
+	Total int64 `json:"total"`
+	Pages int64 `json:"pages"`
+}
+
+// NewMeta menghitung jumlah halaman dari total entry.
+func NewMeta(filter Filter, total int64) Meta {
+	pages := (total + int64(filter.Limit) - 1) / int64(filter.Limit)
+	if pages == 0 {
+		pages = 1
+	}
+	return Meta{Page: filter.Page, Limit: filter.Limit, Total: total, Pages: pages}
+}
+
+// ParseFilter membaca query actor, resource, resource_id, action, from, to,
+// page, dan limit. from dan to menerima RFC3339 atau YYYY-MM-DD; to berupa
+// tanggal saja mencakup seluruh hari tersebut.
+func ParseFilter(c *fiber.Ctx) (Filter, error) {
+	filter := Filter{
+		Actor:      strings.TrimSpace(c.Query("actor")),
+		Resource:   strings.ToLower(strings.TrimSpace(c.Query("resource"))),
+		ResourceID: strings.TrimSpace(c.Query("resource_id")),
+		Action:     strings.ToLower(strings.TrimSpace(c.Query("action"))),
+	}
+
+	var err error
+	if filter.From, err = parseTime(c.Query("from"), false); err != nil {
+		return Filter{}, apperror.Validation("audit.invalid_from")
+	}
+	if filter.To, err = parseTime(c.Query("to"), true); err != nil {
+		return Filter{}, apperror.Validation("audit.invalid_to")
+	}
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		return Filter{}, apperror.Validation("audit.invalid_range")
+	}
+
+	filter.Page, _ = strconv.Atoi(c.Query("page", "1"))
+	filter.Limit, _ = strconv.Atoi(c.Query("limit", "20"))
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Limit < 1 || filter.Limit > 100 {
+		filter.Limit = 20
+	}
+	return filter, nil
+}
+
+func parseTime(value string, endOfDay bool) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, err
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return &t, nil
+}
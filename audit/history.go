@@ -0,0 +1,91 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+
+	"go-fiber/apperror"
+)
+
+// Version adalah state satu resource setelah satu perubahan. Data kosong
+// berarti resource dihapus pada versi tersebut.
+type Version struct {
+	Version    int                    `json:"version"`
+	Action     string                 `json:"action"`
+	ActorID    string                 `json:"actor_id"`
+	ActorEmail string                 `json:"actor_email"`
+	Data       map[string]interface{} `json:"data"`
+	Changes    []Change               `json:"changes"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+// Versions menomori entry riwayat satu resource mulai dari 1. entries harus
+// urut dari yang terlama seperti hasil Store.History. Changes create dan
+// delete berisi seluruh field yang muncul atau hilang.
+func Versions(entries []Entry) []Version {
+	versions := make([]Version, len(entries))
+	for i, entry := range entries {
+		changes := entry.Changes
+		if changes == nil {
+			changes = Diff(entry.Before, entry.After)
+		}
+		if changes == nil {
+			changes = []Change{}
+		}
+		versions[i] = Version{
+			Version:    i + 1,
+			Action:     entry.Action,
+			ActorID:    entry.ActorID,
+			ActorEmail: entry.ActorEmail,
+			Data:       entry.After,
+			Changes:    changes,
+			CreatedAt:  entry.CreatedAt,
+		}
+	}
+	return versions
+}
+
+// StateAt mengembalikan data resource pada waktu at, yaitu data setelah
+// perubahan terakhir sebelum atau tepat pada at. false jika resource belum
+// tercatat atau sudah dihapus pada waktu tersebut.
+func StateAt(entries []Entry, at time.Time) (map[string]interface{}, bool) {
+	var state map[string]interface{}
+	for _, entry := range entries {
+		if entry.CreatedAt.After(at) {
+			break
+		}
+		state = entry.After
+	}
+	return state, state != nil
+}
+
+// VersionData mengembalikan data resource pada versi n untuk revert.
+func VersionData(entries []Entry, n int) (map[string]interface{}, error) {
+	if n < 1 || n > len(entries) {
+		return nil, apperror.NotFound("history.version_not_found", n)
+	}
+	data := entries[n-1].After
+	if data == nil {
+		return nil, apperror.Unprocessable("history.version_deleted", n)
+	}
+	return data, nil
+}
+
+// Decode mengisi dest (pointer ke model) dari data snapshot.
+func Decode(data map[string]interface{}, dest interface{}) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, dest)
+}
+
+// ParseAsOf membaca query as_of dengan format yang sama seperti filter to:
+// tanggal saja berarti akhir hari tersebut.
+func ParseAsOf(value string) (time.Time, error) {
+	at, err := parseTime(value, true)
+	if err != nil || at == nil {
+		return time.Time{}, apperror.Validation("history.invalid_as_of")
+	}
+	return *at, nil
+}
@@ -0,0 +1,121 @@
+package audit
+
+import (
+	"context"
+	"regexp"
+
+	"go-fiber/tracing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoStore menyimpan entry di collection audit_logs. Index-nya dibuat oleh
+// RunMigrations. Store hanya melakukan insert dan find.
+type MongoStore struct {
+	collection *mongo.Collection
+}
+
+func NewMongoStore(db *mongo.Database) *MongoStore {
+	return &MongoStore{collection: db.Collection("audit_logs")}
+}
+
+type mongoEntry struct {
+	ID    primitive.ObjectID `bson:"_id,omitempty"`
+	Entry `bson:",inline"`
+}
+
+func (s *MongoStore) Append(ctx context.Context, entries ...Entry) error {
+	ctx, span := tracing.StartMongo(ctx, "audit.MongoStore.Append")
+	defer span.End()
+
+	if len(entries) == 0 {
+		return nil
+	}
+	documents := make([]interface{}, len(entries))
+	for i, entry := range entries {
+		documents[i] = mongoEntry{Entry: entry}
+	}
+	_, err := s.collection.InsertMany(ctx, documents)
+	return tracing.Error(span, err)
+}
+
+func (s *MongoStore) Query(ctx context.Context, filter Filter) ([]Entry, int64, error) {
+	ctx, span := tracing.StartMongo(ctx, "audit.MongoStore.Query")
+	defer span.End()
+
+	query := bson.M{}
+	if filter.Actor != "" {
+		query["$or"] = bson.A{
+			bson.M{"actor_id": filter.Actor},
+			bson.M{"actor_email": primitive.Regex{Pattern: "^" + regexp.QuoteMeta(filter.Actor) + "$", Options: "i"}},
+		}
+	}
+	if filter.Resource != "" {
+		query["resource"] = filter.Resource
+	}
+	if filter.ResourceID != "" {
+		query["resource_id"] = filter.ResourceID
+	}
+	if filter.Action != "" {
+		query["action"] = filter.Action
+	}
+	if filter.From != nil || filter.To != nil {
+		createdAt := bson.M{}
+		if filter.From != nil {
+			createdAt["$gte"] = *filter.From
+		}
+		if filter.To != nil {
+			createdAt["$lte"] = *filter.To
+		}
+		query["created_at"] = createdAt
+	}
+
+	total, err := s.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, tracing.Error(span, err)
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(filter.Offset())).
+		SetLimit(int64(filter.Limit))
+	entries, err := s.find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, tracing.Error(span, err)
+	}
+	return entries, total, nil
+}
+
+func (s *MongoStore) History(ctx context.Context, resource, resourceID string) ([]Entry, error) {
+	ctx, span := tracing.StartMongo(ctx, "audit.MongoStore.History")
+	defer span.End()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+	entries, err := s.find(ctx, bson.M{"resource": resource, "resource_id": resourceID}, opts)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	return entries, nil
+}
+
+func (s *MongoStore) find(ctx context.Context, query bson.M, opts *options.FindOptions) ([]Entry, error) {
+	cursor, err := s.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	entries := []Entry{}
+	for cursor.Next(ctx) {
+		var document mongoEntry
+		if err := cursor.Decode(&document); err != nil {
+			return nil, err
+		}
+		document.Entry.ID = document.ID.Hex()
+		entries = append(entries, document.Entry)
+	}
+	return entries, cursor.Err()
+}
@@ -0,0 +1,197 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go-fiber/tracing"
+)
+
+// DBTX dipenuhi oleh *sql.DB dan *sql.Tx.
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// PostgresStore menyimpan entry di tabel audit_logs (dibuat oleh
+// RunPostgresMigrations). Dengan *sql.Tx entry ikut tersimpan atau batal
+// bersama perubahan datanya.
+type PostgresStore struct {
+	db DBTX
+}
+
+func NewPostgresStore(db DBTX) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+func (s *PostgresStore) Append(ctx context.Context, entries ...Entry) error {
+	ctx, span := tracing.StartPostgres(ctx, "audit.PostgresStore.Append")
+	defer span.End()
+
+	query := `
+		INSERT INTO audit_logs (actor_id, actor_email, actor_role, action, resource, resource_id,
+		                        before_data, after_data, changes, ip, request_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+	for _, entry := range entries {
+		before, err := jsonColumn(entry.Before)
+		if err != nil {
+			return tracing.Error(span, err)
+		}
+		after, err := jsonColumn(entry.After)
+		if err != nil {
+			return tracing.Error(span, err)
+		}
+		changes, err := jsonColumn(entry.Changes)
+		if err != nil {
+			return tracing.Error(span, err)
+		}
+
+		_, err = s.db.ExecContext(ctx, query,
+			entry.ActorID, entry.ActorEmail, entry.ActorRole, entry.Action, entry.Resource, entry.ResourceID,
+			before, after, changes, entry.IP, entry.RequestID, entry.CreatedAt,
+		)
+		if err != nil {
+			return tracing.Error(span, err)
+		}
+	}
+	return nil
+}
+
+func (s *PostgresStore) Query(ctx context.Context, filter Filter) ([]Entry, int64, error) {
+	ctx, span := tracing.StartPostgres(ctx, "audit.PostgresStore.Query")
+	defer span.End()
+
+	var conditions []string
+	var args []interface{}
+	where := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, strings.ReplaceAll(condition, "?", "$"+strconv.Itoa(len(args))))
+	}
+	if filter.Actor != "" {
+		where("(actor_id = ? OR LOWER(actor_email) = LOWER(?))", filter.Actor)
+	}
+	if filter.Resource != "" {
+		where("resource = ?", filter.Resource)
+	}
+	if filter.ResourceID != "" {
+		where("resource_id = ?", filter.ResourceID)
+	}
+	if filter.Action != "" {
+		where("action = ?", filter.Action)
+	}
+	if filter.From != nil {
+		where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		where("created_at <= ?", *filter.To)
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int64
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM audit_logs `+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, tracing.Error(span, err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, actor_id, actor_email, actor_role, action, resource, resource_id,
+		       before_data, after_data, changes, ip, request_id, created_at
+		FROM audit_logs
+		%s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d OFFSET $%d
+	`, whereClause, len(args)+1, len(args)+2)
+
+	entries, err := s.queryEntries(ctx, query, append(args, filter.Limit, filter.Offset())...)
+	if err != nil {
+		return nil, 0, tracing.Error(span, err)
+	}
+	return entries, total, nil
+}
+
+func (s *PostgresStore) History(ctx context.Context, resource, resourceID string) ([]Entry, error) {
+	ctx, span := tracing.StartPostgres(ctx, "audit.PostgresStore.History")
+	defer span.End()
+
+	query := `
+		SELECT id, actor_id, actor_email, actor_role, action, resource, resource_id,
+		       before_data, after_data, changes, ip, request_id, created_at
+		FROM audit_logs
+		WHERE resource = $1 AND resource_id = $2
+		ORDER BY created_at ASC, id ASC
+	`
+	entries, err := s.queryEntries(ctx, query, resource, resourceID)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	return entries, nil
+}
+
+func (s *PostgresStore) queryEntries(ctx context.Context, query string, args ...interface{}) ([]Entry, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []Entry{}
+	for rows.Next() {
+		var entry Entry
+		var id int64
+		var before, after, changes []byte
+		err := rows.Scan(
+			&id, &entry.ActorID, &entry.ActorEmail, &entry.ActorRole, &entry.Action, &entry.Resource, &entry.ResourceID,
+			&before, &after, &changes, &entry.IP, &entry.RequestID, &entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entry.ID = strconv.FormatInt(id, 10)
+		if err := scanJSON(before, &entry.Before); err != nil {
+			return nil, err
+		}
+		if err := scanJSON(after, &entry.After); err != nil {
+			return nil, err
+		}
+		if err := scanJSON(changes, &entry.Changes); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// jsonColumn meng-encode v untuk kolom JSONB. Nilai kosong disimpan NULL.
+func jsonColumn(v interface{}) (interface{}, error) {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		if len(value) == 0 {
+			return nil, nil
+		}
+	case []Change:
+		if len(value) == 0 {
+			return nil, nil
+		}
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func scanJSON(data []byte, dest interface{}) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, dest)
+}
@@ -0,0 +1,59 @@
+// Command webhook-receiver adalah penerima webhook lokal untuk mencoba
+// subscription: setiap request diverifikasi signature-nya dengan secret
+// subscription lalu dicetak ke stdout.
+//
+//	go run ./cmd/webhook-receiver -addr :9000 -secret <secret>
+//
+// Daftarkan http://localhost:9000/ sebagai URL webhook. Flag -fail membuat
+// receiver membalas 500 untuk mencoba retry dan dead-letter.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"log"
+	"net/http"
+
+	"go-fiber/outbox"
+	"go-fiber/webhook"
+)
+
+func main() {
+	addr := flag.String("addr", ":9000", "alamat listen")
+	secret := flag.String("secret", "", "secret subscription; kosong berarti signature tidak diperiksa")
+	fail := flag.Bool("fail", false, "balas 500 untuk setiap request")
+	flag.Parse()
+
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if *secret != "" {
+			if err := webhook.Verify(*secret, r.Header, body, webhook.DefaultTolerance); err != nil {
+				log.Printf("ditolak: %v", err)
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+		}
+
+		var event outbox.Event
+		if err := json.Unmarshal(body, &event); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("%s %s delivery=%s attempt=%s resource_id=%s",
+			event.Type, event.ID, r.Header.Get(webhook.HeaderDeliveryID), r.Header.Get(webhook.HeaderAttempt), event.ResourceID)
+
+		if *fail {
+			http.Error(w, "gagal disengaja", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	log.Printf("webhook receiver listen di %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
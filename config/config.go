@@ -0,0 +1,678 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-fiber/ratelimit"
+
+	"github.com/BurntSushi/toml"
+	"github.com/joho/godotenv"
+	"go.yaml.in/yaml/v3"
+)
+
+// Config adalah seluruh konfigurasi aplikasi. Setiap field bisa diisi dari
+// file YAML/TOML (tag yaml/toml), environment variable (tag env), dan flag
+// command line (tag flag), dengan urutan prioritas default < file < env < flag.
+// Field bertag secret disamarkan saat config dicetak atau ditulis ke log.
+type Config struct {
+	App         AppConfig         `yaml:"app" toml:"app"`
+	Postgres    PostgresConfig    `yaml:"postgres" toml:"postgres"`
+	Mongo       MongoConfig       `yaml:"mongo" toml:"mongo"`
+	Auth        AuthConfig        `yaml:"auth" toml:"auth"`
+	Upload      UploadConfig      `yaml:"upload" toml:"upload"`
+	Export      ExportConfig      `yaml:"export" toml:"export"`
+	Log         LogConfig         `yaml:"log" toml:"log"`
+	RateLimit   RateLimitConfig   `yaml:"rate_limit" toml:"rate_limit"`
+	Idempotency IdempotencyConfig `yaml:"idempotency" toml:"idempotency"`
+	Outbox      OutboxConfig      `yaml:"outbox" toml:"outbox"`
+	Webhooks    WebhooksConfig    `yaml:"webhooks" toml:"webhooks"`
+	Jobs        JobsConfig        `yaml:"jobs" toml:"jobs"`
+}
+
+type AppConfig struct {
+	Port            string        `yaml:"port" toml:"port" env:"APP_PORT" flag:"port" usage:"port HTTP server"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" flag:"shutdown-timeout" usage:"batas waktu graceful shutdown"`
+	BodyLimitMB     int           `yaml:"body_limit_mb" toml:"body_limit_mb" env:"APP_BODY_LIMIT_MB" flag:"body-limit-mb" usage:"ukuran maksimal body request (MB)"`
+	RequireIfMatch  bool          `yaml:"require_if_match" toml:"require_if_match" env:"APP_REQUIRE_IF_MATCH" flag:"require-if-match" usage:"wajibkan header If-Match pada PUT dan DELETE alumni dan pekerjaan"`
+}
+
+// PostgresConfig dan MongoConfig masing-masing punya Enabled sehingga satu
+// stack bisa dimatikan tanpa harus menyediakan database-nya.
+type PostgresConfig struct {
+	Enabled bool   `yaml:"enabled" toml:"enabled" env:"POSTGRES_ENABLED" flag:"postgres-enabled" usage:"jalankan stack /go-fiber-postgre"`
+	DSN     string `yaml:"dsn" toml:"dsn" env:"DB_DSN" secret:"url"`
+}
+
+type MongoConfig struct {
+	Enabled  bool   `yaml:"enabled" toml:"enabled" env:"MONGODB_ENABLED" flag:"mongodb-enabled" usage:"jalankan stack /go-fiber-mongo"`
+	URI      string `yaml:"uri" toml:"uri" env:"MONGODB_URI" secret:"url"`
+	Database string `yaml:"database" toml:"database" env:"MONGODB_DATABASE" flag:"mongodb-database" usage:"nama database MongoDB"`
+}
+
+type AuthConfig struct {
+	JWTSecret string `yaml:"jwt_secret" toml:"jwt_secret" env:"JWT_SECRET" secret:"true"`
+	APIKey    string `yaml:"api_key" toml:"api_key" env:"API_KEY" secret:"true"`
+}
+
+type UploadConfig struct {
+	Dir              string `yaml:"dir" toml:"dir" env:"UPLOAD_DIR" flag:"upload-dir" usage:"folder penyimpanan file upload"`
+	MaxPhotoMB       int    `yaml:"max_photo_mb" toml:"max_photo_mb" env:"UPLOAD_MAX_PHOTO_MB" flag:"upload-max-photo-mb" usage:"ukuran maksimal foto (MB)"`
+	MaxCertificateMB int    `yaml:"max_certificate_mb" toml:"max_certificate_mb" env:"UPLOAD_MAX_CERTIFICATE_MB" flag:"upload-max-certificate-mb" usage:"ukuran maksimal sertifikat (MB)"`
+}
+
+type ExportConfig struct {
+	Dir         string        `yaml:"dir" toml:"dir" env:"EXPORT_DIR" flag:"export-dir" usage:"folder hasil export background"`
+	Concurrency int           `yaml:"concurrency" toml:"concurrency" env:"EXPORT_CONCURRENCY" flag:"export-concurrency" usage:"jumlah job export yang berjalan bersamaan"`
+	TTL         time.Duration `yaml:"ttl" toml:"ttl" env:"EXPORT_TTL" usage:"lama job dan file export background disimpan sebelum dihapus"`
+}
+
+type LogConfig struct {
+	Level      string `yaml:"level" toml:"level" env:"LOG_LEVEL" flag:"log-level" usage:"debug, info, warn, atau error"`
+	Format     string `yaml:"format" toml:"format" env:"LOG_FORMAT" flag:"log-format" usage:"json atau text"`
+	File       string `yaml:"file" toml:"file" env:"LOG_FILE" flag:"log-file" usage:"file log, \"-\" untuk stdout saja"`
+	MaxSizeMB  int    `yaml:"max_size_mb" toml:"max_size_mb" env:"LOG_MAX_SIZE_MB"`
+	MaxBackups int    `yaml:"max_backups" toml:"max_backups" env:"LOG_MAX_BACKUPS"`
+	MaxAgeDays int    `yaml:"max_age_days" toml:"max_age_days" env:"LOG_MAX_AGE_DAYS"`
+}
+
+// RateLimitConfig mengatur rate limit route login, pengecekan API key, dan
+// upload. Policy berformat key:jumlah/window, lihat ratelimit.ParsePolicy.
+type RateLimitConfig struct {
+	Enabled bool   `yaml:"enabled" toml:"enabled" env:"RATE_LIMIT_ENABLED" flag:"rate-limit-enabled" usage:"aktifkan rate limit"`
+	Store   string `yaml:"store" toml:"store" env:"RATE_LIMIT_STORE" flag:"rate-limit-store" usage:"penyimpanan counter: memory, postgres, atau mongo"`
+	Login   string `yaml:"login" toml:"login" env:"RATE_LIMIT_LOGIN" usage:"policy route login"`
+	Check   string `yaml:"check" toml:"check" env:"RATE_LIMIT_CHECK" usage:"policy route pengecekan API key, tidak boleh memakai api_key"`
+	Upload  string `yaml:"upload" toml:"upload" env:"RATE_LIMIT_UPLOAD" usage:"policy route upload file"`
+}
+
+// IdempotencyConfig mengatur penyimpanan Idempotency-Key pada endpoint
+// create dan upload, lihat package idempotency.
+type IdempotencyConfig struct {
+	Enabled bool          `yaml:"enabled" toml:"enabled" env:"IDEMPOTENCY_ENABLED" flag:"idempotency-enabled" usage:"aktifkan header Idempotency-Key"`
+	Store   string        `yaml:"store" toml:"store" env:"IDEMPOTENCY_STORE" flag:"idempotency-store" usage:"penyimpanan key: memory, postgres, atau mongo"`
+	TTL     time.Duration `yaml:"ttl" toml:"ttl" env:"IDEMPOTENCY_TTL" flag:"idempotency-ttl" usage:"lama response disimpan untuk retry"`
+}
+
+// OutboxConfig mengatur domain event dan relay yang mengirimnya ke sink,
+// lihat package outbox. Sinks berisi daftar dipisah koma dari bus, nats,
+// kafka, dan webhook.
+type OutboxConfig struct {
+	Enabled           bool          `yaml:"enabled" toml:"enabled" env:"OUTBOX_ENABLED" flag:"outbox-enabled" usage:"tulis domain event ke outbox dan jalankan relay"`
+	Interval          time.Duration `yaml:"interval" toml:"interval" env:"OUTBOX_INTERVAL" flag:"outbox-interval" usage:"jeda pemeriksaan outbox oleh relay"`
+	BatchSize         int           `yaml:"batch_size" toml:"batch_size" env:"OUTBOX_BATCH_SIZE" usage:"jumlah event yang dikirim per putaran relay"`
+	MaxAttempts       int           `yaml:"max_attempts" toml:"max_attempts" env:"OUTBOX_MAX_ATTEMPTS" usage:"batas percobaan kirim sebelum event ditandai dead"`
+	Retention         time.Duration `yaml:"retention" toml:"retention" env:"OUTBOX_RETENTION" usage:"lama event published disimpan, 0 untuk selamanya"`
+	Sinks             string        `yaml:"sinks" toml:"sinks" env:"OUTBOX_SINKS" flag:"outbox-sinks" usage:"sink dipisah koma: bus, nats, kafka, webhook"`
+	NATSURL           string        `yaml:"nats_url" toml:"nats_url" env:"OUTBOX_NATS_URL" secret:"url"`
+	NATSSubjectPrefix string        `yaml:"nats_subject_prefix" toml:"nats_subject_prefix" env:"OUTBOX_NATS_SUBJECT_PREFIX" usage:"prefix subject NATS"`
+	KafkaRESTURL      string        `yaml:"kafka_rest_url" toml:"kafka_rest_url" env:"OUTBOX_KAFKA_REST_URL" usage:"URL Kafka REST Proxy"`
+	KafkaTopic        string        `yaml:"kafka_topic" toml:"kafka_topic" env:"OUTBOX_KAFKA_TOPIC" usage:"topic Kafka tujuan event"`
+	WebhookURL        string        `yaml:"webhook_url" toml:"webhook_url" env:"OUTBOX_WEBHOOK_URL" usage:"URL tujuan webhook event"`
+	WebhookSecret     string        `yaml:"webhook_secret" toml:"webhook_secret" env:"OUTBOX_WEBHOOK_SECRET" secret:"true"`
+}
+
+// WebhooksConfig mengatur subscription webhook yang dikelola admin, lihat
+// package webhook. Event diambil dari outbox, jadi outbox juga harus aktif.
+type WebhooksConfig struct {
+	Enabled     bool          `yaml:"enabled" toml:"enabled" env:"WEBHOOKS_ENABLED" flag:"webhooks-enabled" usage:"aktifkan subscription webhook dan pengirimnya"`
+	Interval    time.Duration `yaml:"interval" toml:"interval" env:"WEBHOOKS_INTERVAL" usage:"jeda pemeriksaan delivery webhook"`
+	BatchSize   int           `yaml:"batch_size" toml:"batch_size" env:"WEBHOOKS_BATCH_SIZE" usage:"jumlah delivery yang dikirim bersamaan per putaran"`
+	MaxAttempts int           `yaml:"max_attempts" toml:"max_attempts" env:"WEBHOOKS_MAX_ATTEMPTS" usage:"batas percobaan kirim sebelum delivery masuk dead-letter"`
+	Timeout     time.Duration `yaml:"timeout" toml:"timeout" env:"WEBHOOKS_TIMEOUT" usage:"batas waktu satu request ke penerima webhook"`
+}
+
+// JobsConfig mengatur worker background job, lihat package queue.
+type JobsConfig struct {
+	Enabled     bool          `yaml:"enabled" toml:"enabled" env:"JOBS_ENABLED" flag:"jobs-enabled" usage:"aktifkan worker dan scheduler background job"`
+	Interval    time.Duration `yaml:"interval" toml:"interval" env:"JOBS_INTERVAL" usage:"jeda pemeriksaan job yang siap dijalankan"`
+	Concurrency int           `yaml:"concurrency" toml:"concurrency" env:"JOBS_CONCURRENCY" usage:"jumlah job yang berjalan bersamaan per instance"`
+	MaxAttempts int           `yaml:"max_attempts" toml:"max_attempts" env:"JOBS_MAX_ATTEMPTS" usage:"batas percobaan default sebelum job menjadi failed"`
+	Timeout     time.Duration `yaml:"timeout" toml:"timeout" env:"JOBS_TIMEOUT" usage:"batas waktu default satu job"`
+	Retention   time.Duration `yaml:"retention" toml:"retention" env:"JOBS_RETENTION" usage:"lama job selesai disimpan sebelum dihapus, 0 untuk menyimpan selamanya"`
+	// TrashRetention 0 mematikan penghapusan permanen pekerjaan yang sudah
+	// di-soft delete.
+	TrashRetention time.Duration `yaml:"trash_retention" toml:"trash_retention" env:"JOBS_TRASH_RETENTION" usage:"lama pekerjaan yang di-soft delete disimpan sebelum dihapus permanen, 0 untuk menyimpan selamanya"`
+}
+
+// SinkNames mengembalikan isi Sinks tanpa spasi dan item kosong.
+func (c OutboxConfig) SinkNames() []string {
+	var names []string
+	for _, name := range strings.Split(c.Sinks, ",") {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Default mengembalikan nilai bawaan untuk field yang tidak wajib diisi.
+func Default() Config {
+	return Config{
+		App: AppConfig{
+			Port:            "3000",
+			ShutdownTimeout: 15 * time.Second,
+			BodyLimitMB:     2,
+		},
+		Postgres: PostgresConfig{
+			Enabled: true,
+		},
+		Mongo: MongoConfig{
+			Enabled:  true,
+			Database: "alumni_db",
+		},
+		Upload: UploadConfig{
+			Dir:              "./uploads",
+			MaxPhotoMB:       1,
+			MaxCertificateMB: 2,
+		},
+		Export: ExportConfig{
+			Dir:         "./exports",
+			Concurrency: 2,
+			TTL:         24 * time.Hour,
+		},
+		Log: LogConfig{
+			Level:      "info",
+			Format:     "json",
+			File:       "logs/app.log",
+			MaxSizeMB:  10,
+			MaxBackups: 5,
+			MaxAgeDays: 30,
+		},
+		RateLimit: RateLimitConfig{
+			Enabled: true,
+			Store:   "memory",
+			Login:   "ip:5/1m",
+			Check:   "ip:10/1m",
+			Upload:  "user:20/1m",
+		},
+		Idempotency: IdempotencyConfig{
+			Enabled: true,
+			Store:   "memory",
+			TTL:     24 * time.Hour,
+		},
+		Outbox: OutboxConfig{
+			Interval:          time.Second,
+			BatchSize:         100,
+			MaxAttempts:       10,
+			Retention:         7 * 24 * time.Hour,
+			Sinks:             "bus",
+			NATSSubjectPrefix: "go-fiber.events",
+		},
+		Webhooks: WebhooksConfig{
+			Interval:    time.Second,
+			BatchSize:   20,
+			MaxAttempts: 8,
+			Timeout:     10 * time.Second,
+		},
+		Jobs: JobsConfig{
+			Enabled:     true,
+			Interval:    time.Second,
+			Concurrency: 4,
+			MaxAttempts: 5,
+			Timeout:     5 * time.Minute,
+			Retention:   7 * 24 * time.Hour,
+		},
+	}
+}
+
+// ValidationError berisi seluruh masalah konfigurasi sekaligus, agar semua
+// bisa diperbaiki dalam satu kali deploy.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "konfigurasi tidak valid:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// LoadEnv membaca file .env jika ada. File yang tidak ada bukan error karena
+// di container konfigurasi biasanya diberikan langsung lewat environment.
+func LoadEnv() error {
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("file .env tidak bisa dibaca: %w", err)
+	}
+	return nil
+}
+
+// Load membangun Config dari default, file konfigurasi (flag -config atau
+// CONFIG_FILE, format .yaml/.yml/.toml), environment (termasuk .env), lalu
+// flag pada args. Semua kesalahan parsing dan validasi dikumpulkan menjadi
+// satu ValidationError.
+func Load(args []string) (Config, error) {
+	cfg := Default()
+	fields := configFields(&cfg)
+
+	flags := flag.NewFlagSet("go-fiber", flag.ContinueOnError)
+	configFile := flags.String("config", "", "file konfigurasi YAML atau TOML")
+	flagValues := map[string]string{}
+	for _, field := range fields {
+		if field.flag == "" {
+			continue
+		}
+		name := field.flag
+		setFlag := func(value string) error {
+			flagValues[name] = value
+			return nil
+		}
+		// Flag bool boleh ditulis tanpa nilai (-mongodb-enabled) atau
+		// dengan nilai (-mongodb-enabled=false).
+		if field.value.Kind() == reflect.Bool {
+			flags.BoolFunc(name, field.usage, setFlag)
+		} else {
+			flags.Func(name, field.usage, setFlag)
+		}
+	}
+	if err := flags.Parse(args); err != nil {
+		return cfg, err
+	}
+
+	var problems []string
+	if err := LoadEnv(); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	path := *configFile
+	if path == "" {
+		path = os.Getenv("CONFIG_FILE")
+	}
+	if path != "" {
+		if err := loadFile(path, &cfg); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	for _, field := range fields {
+		if value, ok := os.LookupEnv(field.env); ok && value != "" {
+			if err := field.set(value); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %v", field.env, err))
+			}
+		}
+	}
+	for _, field := range fields {
+		if value, ok := flagValues[field.flag]; ok {
+			if err := field.set(value); err != nil {
+				problems = append(problems, fmt.Sprintf("-%s: %v", field.flag, err))
+			}
+		}
+	}
+
+	problems = append(problems, cfg.Validate()...)
+	if len(problems) > 0 {
+		return cfg, &ValidationError{Problems: problems}
+	}
+	return cfg, nil
+}
+
+func loadFile(path string, cfg *Config) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("file konfigurasi %s tidak bisa dibaca: %v", path, err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("file konfigurasi %s tidak valid: %v", path, err)
+		}
+	case ".toml":
+		metadata, err := toml.Decode(string(content), cfg)
+		if err != nil {
+			return fmt.Errorf("file konfigurasi %s tidak valid: %v", path, err)
+		}
+		if undecoded := metadata.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("file konfigurasi %s berisi key yang tidak dikenal: %v", path, undecoded)
+		}
+	default:
+		return fmt.Errorf("format file konfigurasi %s tidak didukung. Gunakan .yaml, .yml, atau .toml", path)
+	}
+	return nil
+}
+
+// Validate mengembalikan seluruh masalah pada cfg, atau nil jika valid.
+func (c Config) Validate() []string {
+	var problems []string
+	add := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if port, err := strconv.Atoi(c.App.Port); err != nil || port < 1 || port > 65535 {
+		add("app.port (APP_PORT) harus berupa angka 1-65535, bukan %q", c.App.Port)
+	}
+	if c.App.ShutdownTimeout <= 0 {
+		add("app.shutdown_timeout (SHUTDOWN_TIMEOUT) harus lebih dari 0")
+	}
+	if c.App.BodyLimitMB < 1 {
+		add("app.body_limit_mb (APP_BODY_LIMIT_MB) minimal 1")
+	}
+
+	if !c.Postgres.Enabled && !c.Mongo.Enabled {
+		add("minimal satu stack harus aktif: postgres.enabled (POSTGRES_ENABLED) atau mongo.enabled (MONGODB_ENABLED)")
+	}
+	if c.Postgres.Enabled && c.Postgres.DSN == "" {
+		add("postgres.dsn (DB_DSN) wajib diisi selama postgres.enabled aktif")
+	}
+	if c.Mongo.Enabled && c.Mongo.URI == "" {
+		add("mongo.uri (MONGODB_URI) wajib diisi selama mongo.enabled aktif")
+	}
+	if c.Mongo.Enabled && c.Mongo.Database == "" {
+		add("mongo.database (MONGODB_DATABASE) wajib diisi selama mongo.enabled aktif")
+	}
+
+	if len(c.Auth.JWTSecret) < 32 {
+		add("auth.jwt_secret (JWT_SECRET) wajib diisi minimal 32 karakter")
+	}
+	if c.Auth.APIKey == "" {
+		add("auth.api_key (API_KEY) wajib diisi")
+	}
+
+	if c.Upload.Dir == "" {
+		add("upload.dir (UPLOAD_DIR) wajib diisi")
+	}
+	if c.Upload.MaxPhotoMB < 1 || c.Upload.MaxCertificateMB < 1 {
+		add("upload.max_photo_mb dan upload.max_certificate_mb minimal 1")
+	}
+	if c.Upload.MaxPhotoMB > c.App.BodyLimitMB || c.Upload.MaxCertificateMB > c.App.BodyLimitMB {
+		add("batas ukuran upload tidak boleh melebihi app.body_limit_mb (%d MB)", c.App.BodyLimitMB)
+	}
+
+	if c.Export.Dir == "" {
+		add("export.dir (EXPORT_DIR) wajib diisi")
+	}
+	if c.Export.Concurrency < 1 {
+		add("export.concurrency (EXPORT_CONCURRENCY) minimal 1")
+	}
+	if c.Export.TTL <= 0 {
+		add("export.ttl (EXPORT_TTL) harus lebih dari 0")
+	}
+
+	switch strings.ToLower(c.Log.Level) {
+	case "debug", "info", "warn", "warning", "error":
+	default:
+		add("log.level (LOG_LEVEL) harus debug, info, warn, atau error, bukan %q", c.Log.Level)
+	}
+	switch strings.ToLower(c.Log.Format) {
+	case "json", "text":
+	default:
+		add("log.format (LOG_FORMAT) harus json atau text, bukan %q", c.Log.Format)
+	}
+	if c.Log.File == "" {
+		add("log.file (LOG_FILE) wajib diisi, gunakan \"-\" untuk stdout saja")
+	}
+	if c.Log.MaxSizeMB < 1 || c.Log.MaxBackups < 0 || c.Log.MaxAgeDays < 0 {
+		add("pengaturan rotasi log tidak valid")
+	}
+
+	if c.RateLimit.Enabled {
+		switch c.RateLimit.Store {
+		case "memory":
+		case "postgres":
+			if !c.Postgres.Enabled {
+				add("rate_limit.store (RATE_LIMIT_STORE) postgres membutuhkan postgres.enabled")
+			}
+		case "mongo":
+			if !c.Mongo.Enabled {
+				add("rate_limit.store (RATE_LIMIT_STORE) mongo membutuhkan mongo.enabled")
+			}
+		default:
+			add("rate_limit.store (RATE_LIMIT_STORE) harus memory, postgres, atau mongo, bukan %q", c.RateLimit.Store)
+		}
+		for _, policy := range []struct {
+			env   string
+			spec  string
+			parse func(name, spec string) (ratelimit.Policy, error)
+		}{
+			{"RATE_LIMIT_LOGIN", c.RateLimit.Login, ratelimit.ParsePolicy},
+			{"RATE_LIMIT_CHECK", c.RateLimit.Check, ratelimit.ParseCheckPolicy},
+			{"RATE_LIMIT_UPLOAD", c.RateLimit.Upload, ratelimit.ParsePolicy},
+		} {
+			if _, err := policy.parse(policy.env, policy.spec); err != nil {
+				add("%s: %v", policy.env, err)
+			}
+		}
+	}
+
+	if c.Idempotency.Enabled {
+		switch c.Idempotency.Store {
+		case "memory":
+		case "postgres":
+			if !c.Postgres.Enabled {
+				add("idempotency.store (IDEMPOTENCY_STORE) postgres membutuhkan postgres.enabled")
+			}
+		case "mongo":
+			if !c.Mongo.Enabled {
+				add("idempotency.store (IDEMPOTENCY_STORE) mongo membutuhkan mongo.enabled")
+			}
+		default:
+			add("idempotency.store (IDEMPOTENCY_STORE) harus memory, postgres, atau mongo, bukan %q", c.Idempotency.Store)
+		}
+		if c.Idempotency.TTL < time.Minute {
+			add("idempotency.ttl (IDEMPOTENCY_TTL) minimal 1m")
+		}
+	}
+
+	if c.Outbox.Enabled {
+		if c.Outbox.Interval <= 0 {
+			add("outbox.interval (OUTBOX_INTERVAL) harus lebih dari 0")
+		}
+		if c.Outbox.BatchSize < 1 {
+			add("outbox.batch_size (OUTBOX_BATCH_SIZE) minimal 1")
+		}
+		if c.Outbox.MaxAttempts < 1 {
+			add("outbox.max_attempts (OUTBOX_MAX_ATTEMPTS) minimal 1")
+		}
+		if c.Outbox.Retention < 0 {
+			add("outbox.retention (OUTBOX_RETENTION) tidak boleh negatif")
+		}
+		sinks := c.Outbox.SinkNames()
+		if len(sinks) == 0 {
+			add("outbox.sinks (OUTBOX_SINKS) wajib diisi selama outbox.enabled aktif")
+		}
+		for _, sink := range sinks {
+			switch sink {
+			case "bus":
+			case "nats":
+				if c.Outbox.NATSURL == "" {
+					add("outbox.nats_url (OUTBOX_NATS_URL) wajib diisi untuk sink nats")
+				}
+			case "kafka":
+				if c.Outbox.KafkaRESTURL == "" || c.Outbox.KafkaTopic == "" {
+					add("outbox.kafka_rest_url (OUTBOX_KAFKA_REST_URL) dan outbox.kafka_topic (OUTBOX_KAFKA_TOPIC) wajib diisi untuk sink kafka")
+				}
+			case "webhook":
+				if c.Outbox.WebhookURL == "" {
+					add("outbox.webhook_url (OUTBOX_WEBHOOK_URL) wajib diisi untuk sink webhook")
+				}
+			default:
+				add("outbox.sinks (OUTBOX_SINKS) berisi sink tidak dikenal %q, gunakan bus, nats, kafka, atau webhook", sink)
+			}
+		}
+	}
+
+	if c.Webhooks.Enabled {
+		if !c.Outbox.Enabled {
+			add("webhooks.enabled (WEBHOOKS_ENABLED) membutuhkan outbox.enabled (OUTBOX_ENABLED)")
+		}
+		if c.Webhooks.Interval <= 0 {
+			add("webhooks.interval (WEBHOOKS_INTERVAL) harus lebih dari 0")
+		}
+		if c.Webhooks.BatchSize < 1 {
+			add("webhooks.batch_size (WEBHOOKS_BATCH_SIZE) minimal 1")
+		}
+		if c.Webhooks.MaxAttempts < 1 {
+			add("webhooks.max_attempts (WEBHOOKS_MAX_ATTEMPTS) minimal 1")
+		}
+		if c.Webhooks.Timeout <= 0 {
+			add("webhooks.timeout (WEBHOOKS_TIMEOUT) harus lebih dari 0")
+		}
+	}
+
+	if c.Jobs.Enabled {
+		if c.Jobs.Interval <= 0 {
+			add("jobs.interval (JOBS_INTERVAL) harus lebih dari 0")
+		}
+		if c.Jobs.Concurrency < 1 {
+			add("jobs.concurrency (JOBS_CONCURRENCY) minimal 1")
+		}
+		if c.Jobs.MaxAttempts < 1 {
+			add("jobs.max_attempts (JOBS_MAX_ATTEMPTS) minimal 1")
+		}
+		if c.Jobs.Timeout <= 0 {
+			add("jobs.timeout (JOBS_TIMEOUT) harus lebih dari 0")
+		}
+		if c.Jobs.Retention < 0 {
+			add("jobs.retention (JOBS_RETENTION) tidak boleh negatif")
+		}
+		if c.Jobs.TrashRetention < 0 {
+			add("jobs.trash_retention (JOBS_TRASH_RETENTION) tidak boleh negatif")
+		}
+	}
+
+	return problems
+}
+
+// Redacted mengembalikan isi config sebagai map bersarang dengan nilai secret
+// disamarkan. Password di dalam DSN/URI diganti xxxxx sehingga host dan
+// database tetap terlihat.
+func (c Config) Redacted() map[string]interface{} {
+	return redactStruct(reflect.ValueOf(c))
+}
+
+// String menampilkan config dalam format YAML tanpa secret.
+func (c Config) String() string {
+	content, err := yaml.Marshal(c.Redacted())
+	if err != nil {
+		return err.Error()
+	}
+	return string(content)
+}
+
+// LogValue membuat config aman ditulis ke log, misalnya
+// slog.Info("Configuration loaded", "config", cfg).
+func (c Config) LogValue() slog.Value {
+	return slog.AnyValue(c.Redacted())
+}
+
+const redactedValue = "[REDACTED]"
+
+var dsnPasswordPattern = regexp.MustCompile(`(password=)\S+`)
+
+func redactStruct(value reflect.Value) map[string]interface{} {
+	result := map[string]interface{}{}
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		fieldValue := value.Field(i)
+
+		if field.Type.Kind() == reflect.Struct && field.Type != reflect.TypeOf(time.Duration(0)) {
+			result[name] = redactStruct(fieldValue)
+			continue
+		}
+
+		switch field.Tag.Get("secret") {
+		case "true":
+			if fieldValue.String() != "" {
+				result[name] = redactedValue
+			} else {
+				result[name] = ""
+			}
+		case "url":
+			result[name] = redactURL(fieldValue.String())
+		default:
+			if duration, ok := fieldValue.Interface().(time.Duration); ok {
+				result[name] = duration.String()
+			} else {
+				result[name] = fieldValue.Interface()
+			}
+		}
+	}
+	return result
+}
+
+func redactURL(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	if parsed, err := url.Parse(raw); err == nil && parsed.Scheme != "" {
+		return parsed.Redacted()
+	}
+	return dsnPasswordPattern.ReplaceAllString(raw, "${1}xxxxx")
+}
+
+// configField adalah satu field Config yang bisa diisi dari env atau flag.
+type configField struct {
+	env   string
+	flag  string
+	usage string
+	value reflect.Value
+}
+
+func configFields(cfg *Config) []configField {
+	var fields []configField
+	var walk func(value reflect.Value)
+	walk = func(value reflect.Value) {
+		valueType := value.Type()
+		for i := 0; i < valueType.NumField(); i++ {
+			field := valueType.Field(i)
+			if field.Type.Kind() == reflect.Struct {
+				walk(value.Field(i))
+				continue
+			}
+			if env := field.Tag.Get("env"); env != "" {
+				fields = append(fields, configField{
+					env:   env,
+					flag:  field.Tag.Get("flag"),
+					usage: field.Tag.Get("usage"),
+					value: value.Field(i),
+				})
+			}
+		}
+	}
+	walk(reflect.ValueOf(cfg).Elem())
+	return fields
+}
+
+func (f configField) set(raw string) error {
+	raw = strings.TrimSpace(raw)
+	switch f.value.Interface().(type) {
+	case time.Duration:
+		duration, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("durasi %q tidak valid, contoh 15s atau 1m", raw)
+		}
+		f.value.SetInt(int64(duration))
+	case string:
+		f.value.SetString(raw)
+	case int:
+		number, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("%q harus berupa angka", raw)
+		}
+		f.value.SetInt(int64(number))
+	case bool:
+		enabled, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("%q harus true atau false", raw)
+		}
+		f.value.SetBool(enabled)
+	default:
+		return fmt.Errorf("tipe %s tidak didukung", f.value.Type())
+	}
+	return nil
+}
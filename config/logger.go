@@ -1,15 +1,72 @@
 package config
 
 import (
-	"log"
+	"io"
+	"log/slog"
 	"os"
+	"strings"
+
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
-func GetLogger() *log.Logger {
-	logFile, err := os.OpenFile("logs/app.log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
-	if err != nil {
-		log.Fatal(err)
+// redactedKeys adalah nama atribut log yang nilainya selalu disamarkan.
+var redactedKeys = map[string]bool{
+	"password":      true,
+	"password_hash": true,
+	"token":         true,
+	"authorization": true,
+	"jwt_secret":    true,
+	"api_key":       true,
+	"x-api-key":     true,
+	"secret":        true,
+}
+
+// NewLogger membuat logger slog sesuai LogConfig. Log selalu ditulis ke
+// stdout, dan juga ke cfg.File dengan rotasi kecuali File bernilai "-".
+func NewLogger(cfg LogConfig) *slog.Logger {
+	var output io.Writer = os.Stdout
+	if cfg.File != "-" {
+		output = io.MultiWriter(os.Stdout, &lumberjack.Logger{
+			Filename:   cfg.File,
+			MaxSize:    cfg.MaxSizeMB,
+			MaxBackups: cfg.MaxBackups,
+			MaxAge:     cfg.MaxAgeDays,
+			Compress:   true,
+		})
+	}
+
+	options := &slog.HandlerOptions{
+		Level:       ParseLogLevel(cfg.Level),
+		ReplaceAttr: RedactAttr,
+	}
+
+	var handler slog.Handler = slog.NewJSONHandler(output, options)
+	if strings.ToLower(cfg.Format) == "text" {
+		handler = slog.NewTextHandler(output, options)
+	}
+	return slog.New(handler)
+}
+
+// ParseLogLevel mengubah nama level menjadi slog.Level. Nilai tidak dikenal
+// dianggap info.
+func ParseLogLevel(value string) slog.Level {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
 	}
-	return log.New(logFile, "INFO: ", log.Ldate|log.Ltime|log.Lshortfile)
 }
 
+// RedactAttr menyamarkan atribut sensitif seperti password dan token,
+// termasuk yang berada di dalam group.
+func RedactAttr(groups []string, attr slog.Attr) slog.Attr {
+	if redactedKeys[strings.ToLower(attr.Key)] {
+		return slog.String(attr.Key, "[REDACTED]")
+	}
+	return attr
+}
@@ -1,22 +1,21 @@
 package config
 
 import (
+	"go-fiber/apperror"
+
 	"github.com/gofiber/fiber/v2"
 )
 
-func NewApp() *fiber.App {
+// NewApp membuat aplikasi fiber dengan batas body bodyLimitMB dan menyajikan
+// file upload dari uploadDir di /uploads.
+func NewApp(bodyLimitMB int, uploadDir string) *fiber.App {
 	app := fiber.New(fiber.Config{
-		BodyLimit: 2 * 1024 * 1024, // 2MB limit for file uploads
-		ErrorHandler: func(c *fiber.Ctx, err error) error {
-			return c.Status(500).JSON(fiber.Map{
-				"success": false,
-				"message": "Internal server error: " + err.Error(),
-			})
-		},
+		BodyLimit:    bodyLimitMB * 1024 * 1024,
+		ErrorHandler: apperror.Handler,
 	})
 	
 	// Serve static files from uploads directory
-	app.Static("/uploads", "./uploads")
+	app.Static("/uploads", uploadDir)
 	
 	return app
 }
@@ -2,9 +2,11 @@ package database
 
 import (
 	"context"
-	"log"
+	"fmt"
+	"log/slog"
 	"time"
 
+	"go-fiber/helper"
 	utilsmongo "go-fiber/utils/mongo"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -13,10 +15,10 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-func RunMigrations(db *mongo.Database) error {
-	log.Println("Starting MongoDB migrations...")
+func RunMigrations(ctx context.Context, db *mongo.Database) error {
+	slog.Info("Starting MongoDB migrations...")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	if err := dropCollections(ctx, db); err != nil {
@@ -31,29 +33,35 @@ func RunMigrations(db *mongo.Database) error {
 		return err
 	}
 
-	log.Println("MongoDB migrations completed successfully!")
+	if err := migrateGajiRange(ctx, db); err != nil {
+		return err
+	}
+
+	slog.Info("MongoDB migrations completed successfully!")
 	return nil
 }
 
 func dropCollections(ctx context.Context, db *mongo.Database) error {
-	log.Println("Dropping existing collections...")
+	slog.Info("Dropping existing collections...")
 
-	collections := []string{"alumni", "pekerjaan_alumni", "files"}
+	// idempotency_keys ikut di-drop karena response yang tersimpan merujuk
+	// data yang baru saja dihapus.
+	collections := []string{"alumni", "pekerjaan_alumni", "files", "idempotency_keys"}
 	
 	for _, collectionName := range collections {
 		collection := db.Collection(collectionName)
 		
 		names, err := db.ListCollectionNames(ctx, bson.M{"name": collectionName})
 		if err != nil {
-			log.Printf("Warning: Could not check collection %s: %v", collectionName, err)
+			slog.Warn("Could not check collection", "collection", collectionName, "error", err)
 			continue
 		}
 		
 		if len(names) > 0 {
 			if err := collection.Drop(ctx); err != nil {
-				log.Printf("Warning: Could not drop collection %s: %v", collectionName, err)
+				slog.Warn("Could not drop collection", "collection", collectionName, "error", err)
 			} else {
-				log.Printf("Dropped collection: %s", collectionName)
+				slog.Info("Dropped collection", "collection", collectionName)
 			}
 		}
 	}
@@ -62,7 +70,7 @@ func dropCollections(ctx context.Context, db *mongo.Database) error {
 }
 
 func createIndexes(ctx context.Context, db *mongo.Database) error {
-	log.Println("Creating indexes...")
+	slog.Info("Creating indexes...")
 
 
 	alumniCollection := db.Collection("alumni")
@@ -82,7 +90,7 @@ func createIndexes(ctx context.Context, db *mongo.Database) error {
 	if _, err := alumniCollection.Indexes().CreateMany(ctx, alumniIndexes); err != nil {
 		return err
 	}
-	log.Println("Created indexes for alumni collection")
+	slog.Info("Created indexes for alumni collection")
 
 	pekerjaanCollection := db.Collection("pekerjaan_alumni")
 	pekerjaanIndexes := []mongo.IndexModel{
@@ -96,7 +104,7 @@ func createIndexes(ctx context.Context, db *mongo.Database) error {
 	if _, err := pekerjaanCollection.Indexes().CreateMany(ctx, pekerjaanIndexes); err != nil {
 		return err
 	}
-	log.Println("Created indexes for pekerjaan_alumni collection")
+	slog.Info("Created indexes for pekerjaan_alumni collection")
 
 	filesCollection := db.Collection("files")
 	filesIndexes := []mongo.IndexModel{
@@ -113,13 +121,103 @@ func createIndexes(ctx context.Context, db *mongo.Database) error {
 	if _, err := filesCollection.Indexes().CreateMany(ctx, filesIndexes); err != nil {
 		return err
 	}
-	log.Println("Created indexes for files collection")
+	slog.Info("Created indexes for files collection")
+
+	// Counter rate limit dihapus otomatis oleh MongoDB setelah expires_at.
+	rateLimitIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	}
+	if _, err := db.Collection("rate_limits").Indexes().CreateOne(ctx, rateLimitIndex); err != nil {
+		return err
+	}
+	slog.Info("Created indexes for rate_limits collection")
+
+	// Idempotency key dihapus otomatis oleh MongoDB setelah expires_at.
+	idempotencyIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	}
+	if _, err := db.Collection("idempotency_keys").Indexes().CreateOne(ctx, idempotencyIndex); err != nil {
+		return err
+	}
+	slog.Info("Created indexes for idempotency_keys collection")
+
+	// audit_logs tidak ikut di-drop agar riwayat audit tetap ada antar restart.
+	auditIndexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "created_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "actor_id", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "resource", Value: 1}, {Key: "resource_id", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+	}
+	if _, err := db.Collection("audit_logs").Indexes().CreateMany(ctx, auditIndexes); err != nil {
+		return err
+	}
+	slog.Info("Created indexes for audit_logs collection")
+
+	// Index outbox_events juga membuat collection-nya lebih dulu, karena
+	// insert pertama terjadi di dalam transaksi.
+	outboxIndexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "published_at", Value: 1}},
+		},
+	}
+	if _, err := db.Collection("outbox_events").Indexes().CreateMany(ctx, outboxIndexes); err != nil {
+		return err
+	}
+	slog.Info("Created indexes for outbox_events collection")
+
+	// Index unik delivery mencegah event yang dikirim ulang relay outbox
+	// membuat delivery ganda untuk subscription yang sama.
+	webhookIndexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "subscription_id", Value: 1}, {Key: "event._id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "subscription_id", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+	}
+	if _, err := db.Collection("webhook_deliveries").Indexes().CreateMany(ctx, webhookIndexes); err != nil {
+		return err
+	}
+	slog.Info("Created indexes for webhook_deliveries collection")
+
+	// unique_key sparse karena hanya job terjadwal dan job yang meminta
+	// deduplikasi yang mengisinya.
+	jobIndexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "unique_key", Value: 1}},
+			Options: options.Index().SetUnique(true).SetSparse(true),
+		},
+		{
+			Keys: bson.D{{Key: "type", Value: 1}, {Key: "status", Value: 1}, {Key: "run_at", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+	}
+	if _, err := db.Collection("jobs").Indexes().CreateMany(ctx, jobIndexes); err != nil {
+		return err
+	}
+	slog.Info("Created indexes for jobs collection")
 
 	return nil
 }
 
 func seedData(ctx context.Context, db *mongo.Database) error {
-	log.Println("Seeding initial data...")
+	slog.Info("Seeding initial data...")
 
 	alumniIDs, err := seedAlumni(ctx, db)
 	if err != nil {
@@ -130,13 +228,13 @@ func seedData(ctx context.Context, db *mongo.Database) error {
 		return err
 	}
 
-	log.Println("Data seeding completed successfully!")
+	slog.Info("Data seeding completed successfully!")
 	return nil
 }
 
 
 func seedAlumni(ctx context.Context, db *mongo.Database) ([]primitive.ObjectID, error) {
-	log.Println("Seeding alumni...")
+	slog.Info("Seeding alumni...")
 
 	passwordHash, err := utilsmongo.HashPassword("123456")
 	if err != nil {
@@ -155,6 +253,7 @@ func seedAlumni(ctx context.Context, db *mongo.Database) ([]primitive.ObjectID,
 			"no_telepon":    "081359528944",
 			"alamat":        "JL BIBIS TAMA 1A NO 22",
 			"role":          "admin",
+			"version":       1,
 			"created_at":    time.Now(),
 			"updated_at":    time.Now(),
 		},
@@ -169,6 +268,7 @@ func seedAlumni(ctx context.Context, db *mongo.Database) ([]primitive.ObjectID,
 			"no_telepon":    "081234567891",
 			"alamat":        "Jl. Diponegoro No. 2, Malang",
 			"role":          "user",
+			"version":       1,
 			"created_at":    time.Now(),
 			"updated_at":    time.Now(),
 		},
@@ -183,6 +283,7 @@ func seedAlumni(ctx context.Context, db *mongo.Database) ([]primitive.ObjectID,
 			"no_telepon":    "081234567892",
 			"alamat":        "Jl. Sudirman No. 3, Jakarta",
 			"role":          "user",
+			"version":       1,
 			"created_at":    time.Now(),
 			"updated_at":    time.Now(),
 		},
@@ -197,6 +298,7 @@ func seedAlumni(ctx context.Context, db *mongo.Database) ([]primitive.ObjectID,
 			"no_telepon":    "081234567893",
 			"alamat":        "Jl. Gatot Subroto No. 4, Bandung",
 			"role":          "user",
+			"version":       1,
 			"created_at":    time.Now(),
 			"updated_at":    time.Now(),
 		},
@@ -211,6 +313,7 @@ func seedAlumni(ctx context.Context, db *mongo.Database) ([]primitive.ObjectID,
 			"no_telepon":    "081234567894",
 			"alamat":        "Jl. Thamrin No. 5, Medan",
 			"role":          "user",
+			"version":       1,
 			"created_at":    time.Now(),
 			"updated_at":    time.Now(),
 		},
@@ -227,12 +330,12 @@ func seedAlumni(ctx context.Context, db *mongo.Database) ([]primitive.ObjectID,
 		alumniIDs[i] = id.(primitive.ObjectID)
 	}
 
-	log.Printf("Inserted %d alumni", len(result.InsertedIDs))
+	slog.Info("Inserted alumni", "total", len(result.InsertedIDs))
 	return alumniIDs, nil
 }
 
 func seedPekerjaanAlumni(ctx context.Context, db *mongo.Database, alumniIDs []primitive.ObjectID) error {
-	log.Println("Seeding pekerjaan alumni...")
+	slog.Info("Seeding pekerjaan alumni...")
 
 	pekerjaan := []interface{}{
 		bson.M{
@@ -250,6 +353,7 @@ func seedPekerjaanAlumni(ctx context.Context, db *mongo.Database, alumniIDs []pr
 			"tanggal_mulai_kerja":  time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
 			"status_pekerjaan":     "aktif",
 			"deskripsi_pekerjaan":  "Mengembangkan aplikasi web menggunakan Go dan React",
+			"version":              1,
 			"created_at":           time.Now(),
 			"updated_at":           time.Now(),
 		},
@@ -268,6 +372,7 @@ func seedPekerjaanAlumni(ctx context.Context, db *mongo.Database, alumniIDs []pr
 			"tanggal_mulai_kerja":  time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
 			"status_pekerjaan":     "aktif",
 			"deskripsi_pekerjaan":  "Menganalisis kebutuhan sistem dan merancang solusi IT",
+			"version":              1,
 			"created_at":           time.Now(),
 			"updated_at":           time.Now(),
 		},
@@ -286,6 +391,7 @@ func seedPekerjaanAlumni(ctx context.Context, db *mongo.Database, alumniIDs []pr
 			"tanggal_mulai_kerja":  time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC),
 			"status_pekerjaan":     "aktif",
 			"deskripsi_pekerjaan":  "Menganalisis data besar untuk insights bisnis",
+			"version":              1,
 			"created_at":           time.Now(),
 			"updated_at":           time.Now(),
 		},
@@ -304,6 +410,7 @@ func seedPekerjaanAlumni(ctx context.Context, db *mongo.Database, alumniIDs []pr
 			"tanggal_mulai_kerja":  time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
 			"status_pekerjaan":     "aktif",
 			"deskripsi_pekerjaan":  "Mengelola infrastruktur cloud dan CI/CD pipeline",
+			"version":              1,
 			"created_at":           time.Now(),
 			"updated_at":           time.Now(),
 		},
@@ -322,6 +429,7 @@ func seedPekerjaanAlumni(ctx context.Context, db *mongo.Database, alumniIDs []pr
 			"tanggal_mulai_kerja":  time.Date(2025, 2, 15, 0, 0, 0, 0, time.UTC),
 			"status_pekerjaan":     "aktif",
 			"deskripsi_pekerjaan":  "Mengembangkan aplikasi mobile menggunakan Flutter",
+			"version":              1,
 			"created_at":           time.Now(),
 			"updated_at":           time.Now(),
 		},
@@ -333,6 +441,78 @@ func seedPekerjaanAlumni(ctx context.Context, db *mongo.Database, alumniIDs []pr
 		return err
 	}
 
-	log.Printf("Inserted %d pekerjaan alumni", len(result.InsertedIDs))
+	slog.Info("Inserted pekerjaan alumni", "total", len(result.InsertedIDs))
+	return nil
+}
+
+// migrateGajiRange mengisi gaji_min, gaji_max, gaji_currency, dan gaji_period
+// dari gaji_range teks bebas untuk dokumen yang belum memilikinya.
+func migrateGajiRange(ctx context.Context, db *mongo.Database) error {
+	slog.Info("Migrating gaji_range to structured salary fields...")
+
+	collection := db.Collection("pekerjaan_alumni")
+	filter := bson.M{"gaji_range": bson.M{"$type": "string"}, "gaji_min": bson.M{"$exists": false}}
+	cursor, err := collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"gaji_range": 1}))
+	if err != nil {
+		return err
+	}
+
+	var documents []struct {
+		ID        primitive.ObjectID `bson:"_id"`
+		GajiRange string             `bson:"gaji_range"`
+	}
+	if err := cursor.All(ctx, &documents); err != nil {
+		return err
+	}
+
+	migrated := 0
+	for _, document := range documents {
+		salary, err := helper.ParseGajiRange(document.GajiRange)
+		if err != nil {
+			slog.Warn("gaji_range pekerjaan tidak dapat dimigrasi", "id", document.ID.Hex(), "error", err)
+			continue
+		}
+
+		_, err = collection.UpdateByID(ctx, document.ID, bson.M{"$set": bson.M{
+			"gaji_min":      salary.Min,
+			"gaji_max":      salary.Max,
+			"gaji_currency": salary.Currency,
+			"gaji_period":   salary.Period,
+		}})
+		if err != nil {
+			return err
+		}
+		migrated++
+	}
+
+	slog.Info("Migrated gaji_range for pekerjaan alumni", "total", migrated)
+	return nil
+}
+
+// CheckMigrations memastikan index unik alumni yang dibuat RunMigrations
+// sudah ada. Dipakai oleh readiness probe.
+func CheckMigrations(ctx context.Context, db *mongo.Database) error {
+	cursor, err := db.Collection("alumni").Indexes().List(ctx)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var indexes []bson.M
+	if err := cursor.All(ctx, &indexes); err != nil {
+		return err
+	}
+
+	found := map[string]bool{}
+	for _, index := range indexes {
+		if name, ok := index["name"].(string); ok {
+			found[name] = true
+		}
+	}
+	for _, name := range []string{"nim_1", "email_1"} {
+		if !found[name] {
+			return fmt.Errorf("migrasi MongoDB belum lengkap: index %s pada alumni tidak ditemukan", name)
+		}
+	}
 	return nil
 }
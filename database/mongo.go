@@ -3,26 +3,19 @@ package database
 import (
 	"context"
 	"fmt"
+	"go-fiber/config"
+	"go-fiber/metrics"
 	"log"
-	"os"
+	"log/slog"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-func ConnectMongoDB() *mongo.Database {
-	mongoURI := os.Getenv("MONGODB_URI")
-	if mongoURI == "" {
-		log.Fatal("MONGODB_URI environment variable is required")
-	}
-
-	databaseName := os.Getenv("MONGODB_DATABASE")
-	if databaseName == "" {
-		databaseName = "alumni_db"
-	}
-
-	clientOptions := options.Client().ApplyURI(mongoURI)
+func ConnectMongoDB(cfg config.MongoConfig) *mongo.Database {
+	clientOptions := options.Client().ApplyURI(cfg.URI).SetMonitor(metrics.MongoCommandMonitor())
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -36,7 +29,24 @@ func ConnectMongoDB() *mongo.Database {
 		log.Fatal("Failed to ping MongoDB:", err)
 	}
 
-	fmt.Println("Successfully connected to MongoDB database")
-	return client.Database(databaseName)
+	slog.Info("Successfully connected to MongoDB database")
+	return client.Database(cfg.Database)
 }
 
+// CheckMongoTransactions memastikan server MongoDB mendukung transaksi, yaitu
+// anggota replica set atau mongos di depan sharded cluster. Mongod standalone
+// menolak setiap transaksi, jadi fitur yang menulis di dalam transaksi seperti
+// outbox harus memeriksa ini saat startup.
+func CheckMongoTransactions(ctx context.Context, db *mongo.Database) error {
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	if err := db.Client().Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return err
+	}
+	if hello.SetName == "" && hello.Msg != "isdbgrid" {
+		return fmt.Errorf("MongoDB berjalan standalone; transaksi membutuhkan replica set atau sharded cluster")
+	}
+	return nil
+}
@@ -1,29 +1,29 @@
 package database
 
 import (
+	"context"
 	"database/sql"
-	"fmt"
+	"go-fiber/config"
 	"log"
-	"os"
+	"log/slog"
+	"time"
 
 	_ "github.com/lib/pq"
 )
 
-func ConnectDB() *sql.DB {
-	dsn := os.Getenv("DB_DSN")
-	if dsn == "" {
-		log.Fatal("DB_DSN environment variable is required")
-	}
-	
-	db, err := sql.Open("postgres", dsn)
+func ConnectDB(cfg config.PostgresConfig) *sql.DB {
+	db, err := sql.Open("postgres", cfg.DSN)
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
 	
-	if err = db.Ping(); err != nil {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err = db.PingContext(ctx); err != nil {
 		log.Fatal("Failed to ping database:", err)
 	}
 	
-	fmt.Println("Successfully connected to PostgreSQL database")
+	slog.Info("Successfully connected to PostgreSQL database")
 	return db
 }
@@ -0,0 +1,269 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"go-fiber/helper"
+	"log/slog"
+
+	"github.com/lib/pq"
+)
+
+// postgresSchema adalah setiap tabel beserta kolom yang ditambahkan atau
+// dibuat RunPostgresMigrations, diperiksa oleh CheckPostgresMigrations.
+// Perbarui daftar ini setiap kali migrasi menambah kolom atau tabel.
+var postgresSchema = []struct {
+	table   string
+	columns []string
+}{
+	{"alumni", []string{"version"}},
+	{"pekerjaan_alumni", []string{"gaji_min", "gaji_max", "gaji_currency", "gaji_period", "version"}},
+	{"rate_limits", []string{"key", "window_start", "count", "expires_at"}},
+	{"idempotency_keys", []string{"key", "fingerprint", "status", "headers", "body", "expires_at"}},
+	{"audit_logs", []string{"id", "actor_id", "actor_email", "actor_role", "action", "resource", "resource_id", "before_data", "after_data", "changes", "ip", "request_id", "created_at"}},
+	{"outbox_events", []string{"id", "type", "resource", "resource_id", "payload", "status", "attempts", "last_error", "occurred_at", "next_attempt_at", "published_at"}},
+	{"webhook_subscriptions", []string{"id", "url", "event_types", "description", "active", "secret", "created_at", "updated_at"}},
+	{"webhook_deliveries", []string{"id", "subscription_id", "event_id", "event_type", "payload", "status", "attempts", "last_status_code", "last_error", "next_attempt_at", "created_at", "delivered_at", "attempt_log"}},
+	{"jobs", []string{"id", "type", "payload", "status", "attempts", "max_attempts", "last_error", "unique_key", "run_at", "locked_until", "created_at", "started_at", "finished_at"}},
+}
+
+// RunPostgresMigrations menyiapkan kolom gaji terstruktur pada database yang
+// dibuat dari setup.sql versi lama, lalu mengisinya dari gaji_range. Kolom
+// version untuk optimistic concurrency juga ditambahkan ke alumni dan
+// pekerjaan_alumni; data lama mulai dari version 1. Tabel
+// rate_limits untuk ratelimit.PostgresStore, idempotency_keys untuk
+// idempotency.PostgresStore, audit_logs untuk audit.PostgresStore,
+// outbox_events untuk outbox.PostgresStore, webhook_subscriptions dan
+// webhook_deliveries untuk webhook.PostgresStore, serta jobs untuk
+// queue.PostgresStore juga dibuat di sini.
+func RunPostgresMigrations(ctx context.Context, db *sql.DB) error {
+	slog.Info("Starting PostgreSQL migrations...")
+
+	statements := []string{
+		`ALTER TABLE pekerjaan_alumni ADD COLUMN IF NOT EXISTS gaji_min BIGINT`,
+		`ALTER TABLE pekerjaan_alumni ADD COLUMN IF NOT EXISTS gaji_max BIGINT`,
+		`ALTER TABLE pekerjaan_alumni ADD COLUMN IF NOT EXISTS gaji_currency VARCHAR(3)`,
+		`ALTER TABLE pekerjaan_alumni ADD COLUMN IF NOT EXISTS gaji_period VARCHAR(10)`,
+		`ALTER TABLE alumni ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1`,
+		`ALTER TABLE pekerjaan_alumni ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1`,
+		`CREATE TABLE IF NOT EXISTS rate_limits (
+			key VARCHAR(255) NOT NULL,
+			window_start TIMESTAMPTZ NOT NULL,
+			count BIGINT NOT NULL DEFAULT 0,
+			expires_at TIMESTAMPTZ NOT NULL,
+			PRIMARY KEY (key, window_start)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_rate_limits_expires_at ON rate_limits (expires_at)`,
+		`CREATE TABLE IF NOT EXISTS idempotency_keys (
+			key VARCHAR(64) PRIMARY KEY,
+			fingerprint VARCHAR(64) NOT NULL,
+			status INTEGER,
+			headers JSONB,
+			body BYTEA,
+			expires_at TIMESTAMPTZ NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at)`,
+		`CREATE TABLE IF NOT EXISTS audit_logs (
+			id BIGSERIAL PRIMARY KEY,
+			actor_id VARCHAR(64) NOT NULL DEFAULT '',
+			actor_email VARCHAR(255) NOT NULL DEFAULT '',
+			actor_role VARCHAR(50) NOT NULL DEFAULT '',
+			action VARCHAR(20) NOT NULL,
+			resource VARCHAR(50) NOT NULL,
+			resource_id VARCHAR(64) NOT NULL DEFAULT '',
+			before_data JSONB,
+			after_data JSONB,
+			changes JSONB,
+			ip VARCHAR(64) NOT NULL DEFAULT '',
+			request_id VARCHAR(64) NOT NULL DEFAULT '',
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs (created_at DESC)`,
+		`CREATE INDEX IF NOT EXISTS idx_audit_logs_actor ON audit_logs (actor_id, created_at DESC)`,
+		`CREATE INDEX IF NOT EXISTS idx_audit_logs_resource ON audit_logs (resource, resource_id, created_at DESC)`,
+		// audit_logs append-only: UPDATE dan DELETE ditolak oleh database.
+		`CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION 'audit_logs bersifat append-only';
+		END;
+		$$ LANGUAGE plpgsql`,
+		`DROP TRIGGER IF EXISTS audit_logs_append_only ON audit_logs`,
+		`CREATE TRIGGER audit_logs_append_only BEFORE UPDATE OR DELETE ON audit_logs
+			FOR EACH ROW EXECUTE PROCEDURE audit_logs_append_only()`,
+		`CREATE TABLE IF NOT EXISTS outbox_events (
+			id VARCHAR(36) PRIMARY KEY,
+			type VARCHAR(100) NOT NULL,
+			resource VARCHAR(50) NOT NULL,
+			resource_id VARCHAR(64) NOT NULL DEFAULT '',
+			payload JSONB NOT NULL,
+			status VARCHAR(20) NOT NULL DEFAULT 'pending',
+			attempts INTEGER NOT NULL DEFAULT 0,
+			last_error TEXT,
+			occurred_at TIMESTAMPTZ NOT NULL,
+			next_attempt_at TIMESTAMPTZ NOT NULL,
+			published_at TIMESTAMPTZ
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON outbox_events (next_attempt_at) WHERE status = 'pending'`,
+		`CREATE INDEX IF NOT EXISTS idx_outbox_events_published_at ON outbox_events (published_at) WHERE status = 'published'`,
+		`CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+			id BIGSERIAL PRIMARY KEY,
+			url VARCHAR(2048) NOT NULL,
+			event_types TEXT[] NOT NULL,
+			description VARCHAR(255) NOT NULL DEFAULT '',
+			active BOOLEAN NOT NULL DEFAULT TRUE,
+			secret VARCHAR(255) NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS webhook_deliveries (
+			id BIGSERIAL PRIMARY KEY,
+			subscription_id BIGINT NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+			event_id VARCHAR(36) NOT NULL,
+			event_type VARCHAR(100) NOT NULL,
+			payload JSONB NOT NULL,
+			status VARCHAR(20) NOT NULL DEFAULT 'pending',
+			attempts INTEGER NOT NULL DEFAULT 0,
+			last_status_code INTEGER,
+			last_error TEXT,
+			next_attempt_at TIMESTAMPTZ,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			delivered_at TIMESTAMPTZ,
+			attempt_log JSONB NOT NULL DEFAULT '[]',
+			UNIQUE (subscription_id, event_id)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_pending ON webhook_deliveries (next_attempt_at) WHERE status = 'pending'`,
+		`CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_status ON webhook_deliveries (status, created_at DESC)`,
+		`CREATE TABLE IF NOT EXISTS jobs (
+			id BIGSERIAL PRIMARY KEY,
+			type VARCHAR(100) NOT NULL,
+			payload JSONB NOT NULL DEFAULT '{}',
+			status VARCHAR(20) NOT NULL DEFAULT 'pending',
+			attempts INTEGER NOT NULL DEFAULT 0,
+			max_attempts INTEGER NOT NULL DEFAULT 0,
+			last_error TEXT,
+			unique_key VARCHAR(255) UNIQUE,
+			run_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			locked_until TIMESTAMPTZ,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			started_at TIMESTAMPTZ,
+			finished_at TIMESTAMPTZ
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_jobs_ready ON jobs (type, run_at) WHERE status IN ('pending', 'running')`,
+		`CREATE INDEX IF NOT EXISTS idx_jobs_status ON jobs (status, created_at DESC)`,
+	}
+	for _, statement := range statements {
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+
+	if err := backfillGajiPostgres(ctx, db); err != nil {
+		return err
+	}
+
+	slog.Info("PostgreSQL migrations completed successfully!")
+	return nil
+}
+
+// backfillGajiPostgres memparse gaji_range yang belum memiliki gaji_min.
+// Nilai yang tidak dikenali dibiarkan kosong agar bisa diperbaiki manual.
+// version tidak dinaikkan karena isi yang terlihat klien tidak berubah,
+// sehingga ETag yang sudah dipegang klien tetap berlaku.
+func backfillGajiPostgres(ctx context.Context, db *sql.DB) error {
+	rows, err := db.QueryContext(ctx, `SELECT id, gaji_range FROM pekerjaan_alumni WHERE gaji_range IS NOT NULL AND gaji_min IS NULL`)
+	if err != nil {
+		return err
+	}
+
+	pending := map[int]string{}
+	for rows.Next() {
+		var id int
+		var gajiRange string
+		if err := rows.Scan(&id, &gajiRange); err != nil {
+			rows.Close()
+			return err
+		}
+		pending[id] = gajiRange
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	migrated := 0
+	for id, gajiRange := range pending {
+		salary, err := helper.ParseGajiRange(gajiRange)
+		if err != nil {
+			slog.Warn("gaji_range pekerjaan tidak dapat dimigrasi", "id", id, "error", err)
+			continue
+		}
+
+		_, err = db.ExecContext(ctx,
+			`UPDATE pekerjaan_alumni SET gaji_min = $1, gaji_max = $2, gaji_currency = $3, gaji_period = $4 WHERE id = $5`,
+			salary.Min, salary.Max, salary.Currency, salary.Period, id,
+		)
+		if err != nil {
+			return err
+		}
+		migrated++
+	}
+
+	slog.Info("Migrated gaji_range for pekerjaan alumni", "total", migrated)
+	return nil
+}
+
+// CheckPostgresMigrations memastikan setiap tabel dan kolom hasil
+// RunPostgresMigrations serta trigger append-only audit_logs sudah ada,
+// sehingga database yang baru sebagian termigrasi tidak dilaporkan siap.
+// Dipakai oleh readiness probe.
+func CheckPostgresMigrations(ctx context.Context, db *sql.DB) error {
+	tables := make([]string, 0, len(postgresSchema))
+	for _, table := range postgresSchema {
+		tables = append(tables, table.table)
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT table_name, column_name FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = ANY($1)
+	`, pq.Array(tables))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	existing := map[string]bool{}
+	for rows.Next() {
+		var table, column string
+		if err := rows.Scan(&table, &column); err != nil {
+			return err
+		}
+		existing[table+"."+column] = true
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, table := range postgresSchema {
+		for _, column := range table.columns {
+			if !existing[table.table+"."+column] {
+				return fmt.Errorf("migrasi PostgreSQL belum lengkap: kolom %s.%s tidak ditemukan", table.table, column)
+			}
+		}
+	}
+
+	var trigger bool
+	err = db.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM pg_trigger
+			WHERE tgname = 'audit_logs_append_only' AND tgrelid = to_regclass('audit_logs')
+		)
+	`).Scan(&trigger)
+	if err != nil {
+		return err
+	}
+	if !trigger {
+		return fmt.Errorf("migrasi PostgreSQL belum lengkap: trigger audit_logs_append_only tidak ditemukan")
+	}
+	return nil
+}
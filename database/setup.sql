@@ -31,6 +31,7 @@ CREATE TABLE alumni (
     no_telepon VARCHAR(15),
     alamat TEXT,
     role_id INTEGER NOT NULL DEFAULT 2,
+    version INTEGER NOT NULL DEFAULT 1,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     FOREIGN KEY (role_id) REFERENCES roles(id) ON DELETE RESTRICT
@@ -45,11 +46,16 @@ CREATE TABLE pekerjaan_alumni (
     bidang_industri VARCHAR(50) NOT NULL,
     lokasi_kerja VARCHAR(100) NOT NULL,
     gaji_range VARCHAR(50),
+    gaji_min BIGINT CHECK (gaji_min > 0),
+    gaji_max BIGINT CHECK (gaji_max >= gaji_min),
+    gaji_currency VARCHAR(3),
+    gaji_period VARCHAR(10) CHECK (gaji_period IN ('bulanan', 'tahunan')),
     tanggal_mulai_kerja DATE NOT NULL,
     tanggal_selesai_kerja DATE,
     status_pekerjaan VARCHAR(20) DEFAULT 'aktif' CHECK (status_pekerjaan IN ('aktif', 'selesai', 'resigned')),
     deskripsi_pekerjaan TEXT,
     is_delete TIMESTAMP,
+    version INTEGER NOT NULL DEFAULT 1,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     FOREIGN KEY (alumni_id) REFERENCES alumni(id) ON DELETE CASCADE
@@ -77,3 +83,6 @@ CREATE INDEX IF NOT EXISTS idx_alumni_email ON alumni(email);
 CREATE INDEX IF NOT EXISTS idx_alumni_role_id ON alumni(role_id);
 CREATE INDEX IF NOT EXISTS idx_pekerjaan_alumni_id ON pekerjaan_alumni(alumni_id);
 CREATE INDEX IF NOT EXISTS idx_pekerjaan_status ON pekerjaan_alumni(status_pekerjaan);
+
+-- gaji_min, gaji_max, gaji_currency, dan gaji_period diisi dari gaji_range
+-- oleh database.RunPostgresMigrations saat aplikasi dijalankan
@@ -17,11 +17,6 @@ const docTemplate = `{
     "paths": {
         "/alumni": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
                 "description": "Mengambil daftar semua alumni dari database",
                 "consumes": [
                     "application/json"
@@ -33,6 +28,20 @@ const docTemplate = `{
                     "2. Alumni"
                 ],
                 "summary": "Dapatkan semua alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Field yang ditampilkan, dipisah koma (contoh: nim,nama,email)",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Relasi yang di-embed, dipisah koma (role, pekerjaan, files)",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -47,7 +56,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/go-fiber_app_model_mongo.Alumni"
+                                                "$ref": "#/definitions/go-fiber_app_model_mongo.AlumniDetail"
                                             }
                                         }
                                     }
@@ -67,14 +76,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            },
+            "post": {
                 "description": "Membuat data alumni baru (Admin only)",
                 "consumes": [
                     "application/json"
@@ -95,6 +104,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/go-fiber_app_model_mongo.CreateAlumniRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key unik per request; retry dengan key dan body sama mengembalikan response pertama",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -114,6 +129,12 @@ const docTemplate = `{
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "Idempotent-Replayed": {
+                                "type": "string",
+                                "description": "true jika response dikirim ulang dari Idempotency-Key"
+                            }
                         }
                     },
                     "400": {
@@ -134,59 +155,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/alumni/check/{key}": {
-            "post": {
-                "description": "Mengecek apakah NIM terdaftar sebagai alumni (memerlukan API key)",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "2. Alumni"
-                ],
-                "summary": "Cek status alumni berdasarkan NIM",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "API Key untuk validasi akses",
-                        "name": "key",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "NIM mahasiswa yang akan dicek",
-                        "name": "nim",
-                        "in": "formData",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/model.SuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -197,17 +173,17 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/alumni/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Mengambil data alumni spesifik berdasarkan ID",
+                ]
+            }
+        },
+        "/alumni/batch/delete": {
+            "post": {
+                "description": "Menghapus alumni yang dipilih ids atau filter, maksimal 100 data, dalam satu transaksi. Jika satu item gagal tidak ada yang dihapus dan laporan per item dikirim di details response 422 (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -217,14 +193,16 @@ const docTemplate = `{
                 "tags": [
                     "2. Alumni"
                 ],
-                "summary": "Dapatkan alumni berdasarkan ID",
+                "summary": "Batch delete alumni",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Alumni ID (MongoDB ObjectID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Pilih tepat salah satu dari ids atau filter",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go-fiber_app_model_mongo.AlumniBatchDeleteRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -239,7 +217,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/go-fiber_app_model_mongo.Alumni"
+                                            "$ref": "#/definitions/helper.BatchResult"
                                         }
                                     }
                                 }
@@ -258,27 +236,47 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "details": {
+                                            "$ref": "#/definitions/helper.BatchResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                }
-            },
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Memperbarui data alumni berdasarkan ID (Admin only)",
+                ]
+            }
+        },
+        "/alumni/batch/update": {
+            "post": {
+                "description": "Menerapkan changes (JSON Merge Patch dengan field update alumni) pada alumni yang dipilih ids atau filter, maksimal 100 data, dalam satu transaksi. Jika satu item gagal tidak ada yang disimpan dan laporan per item dikirim di details response 422 (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -288,22 +286,15 @@ const docTemplate = `{
                 "tags": [
                     "2. Alumni"
                 ],
-                "summary": "Update alumni",
+                "summary": "Batch update alumni",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Alumni ID (MongoDB ObjectID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Alumni data",
+                        "description": "Pilih tepat salah satu dari ids atau filter",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/go-fiber_app_model_mongo.UpdateAlumniRequest"
+                            "$ref": "#/definitions/go-fiber_app_model_mongo.AlumniBatchUpdateRequest"
                         }
                     }
                 ],
@@ -319,7 +310,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/go-fiber_app_model_mongo.Alumni"
+                                            "$ref": "#/definitions/helper.BatchResult"
                                         }
                                     }
                                 }
@@ -344,10 +335,22 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "details": {
+                                            "$ref": "#/definitions/helper.BatchResult"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "500": {
@@ -356,15 +359,17 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Menghapus alumni berdasarkan ID (Admin only)",
+                ]
+            }
+        },
+        "/alumni/check/{key}": {
+            "post": {
+                "description": "Mengecek apakah NIM terdaftar sebagai alumni (memerlukan API key)",
                 "consumes": [
                     "application/json"
                 ],
@@ -374,14 +379,21 @@ const docTemplate = `{
                 "tags": [
                     "2. Alumni"
                 ],
-                "summary": "Hapus alumni",
+                "summary": "Cek status alumni berdasarkan NIM",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Alumni ID (MongoDB ObjectID)",
-                        "name": "id",
+                        "description": "API Key untuk validasi akses",
+                        "name": "key",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "NIM mahasiswa yang akan dicek",
+                        "name": "nim",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -403,14 +415,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -424,24 +430,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/files": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Mengambil daftar semua file dari database",
+        "/alumni/import": {
+            "post": {
+                "description": "Memvalidasi dan menyimpan banyak alumni sekaligus dari file CSV/XLSX. Default dry_run=true hanya memvalidasi. Semua baris disimpan dalam satu transaksi; alumni tanpa kolom password mendapat password awal acak yang dikembalikan sekali di credentials (Admin only)",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "4. Files"
+                    "2. Alumni"
+                ],
+                "summary": "Import alumni dari CSV/XLSX",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "File .csv atau .xlsx dengan baris pertama sebagai header",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Mapping field ke header file dalam JSON, contoh {\\",
+                        "name": "mapping",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Hanya validasi tanpa menyimpan",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Dapatkan semua file",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -454,39 +477,84 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/model.FileResponse"
-                                            }
+                                            "$ref": "#/definitions/go-fiber_app_model_mongo.ImportResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/go-fiber_app_model_mongo.ImportResult"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/go-fiber_app_model_mongo.ImportResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/files/alumni/{alumni_id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Mengambil semua file dari alumni tertentu",
+                ]
+            }
+        },
+        "/alumni/{id}": {
+            "get": {
+                "description": "Mengambil data alumni spesifik berdasarkan ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -494,16 +562,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "4. Files"
+                    "2. Alumni"
                 ],
-                "summary": "Dapatkan file berdasarkan Alumni ID",
+                "summary": "Dapatkan alumni berdasarkan ID",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Alumni ID (MongoDB ObjectID)",
-                        "name": "alumni_id",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field yang ditampilkan, dipisah koma (contoh: nim,nama,email)",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Relasi yang di-embed, dipisah koma (role, pekerjaan, files)",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tampilkan data pada waktu tertentu dari riwayat (RFC3339 atau YYYY-MM-DD); include diabaikan",
+                        "name": "as_of",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari response sebelumnya; 304 jika data belum berubah (tanpa fields dan include)",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -518,16 +610,22 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/model.FileResponse"
-                                            }
+                                            "$ref": "#/definitions/go-fiber_app_model_mongo.AlumniDetail"
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version data alumni (tanpa fields dan include)"
+                            }
                         }
                     },
+                    "304": {
+                        "description": "Data belum berubah sejak ETag pada If-None-Match"
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
@@ -540,8 +638,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -552,46 +650,52 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/files/upload/foto": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Upload file foto (JPEG/JPG/PNG, max 1MB)",
+                ]
+            },
+            "put": {
+                "description": "Memperbarui data alumni berdasarkan ID (Admin only)",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "4. Files"
+                    "2. Alumni"
                 ],
-                "summary": "Upload foto alumni",
+                "summary": "Update alumni",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "File foto (JPEG/JPG/PNG, max 1MB)",
-                        "name": "file",
-                        "in": "formData",
+                        "type": "string",
+                        "description": "Alumni ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
+                    {
+                        "description": "Alumni data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go-fiber_app_model_mongo.UpdateAlumniRequest"
+                        }
+                    },
                     {
                         "type": "string",
-                        "description": "Alumni ID (MongoDB ObjectID)",
-                        "name": "alumni_id",
-                        "in": "formData",
-                        "required": true
+                        "description": "ETag dari GET alumni; wajib jika require_if_match aktif",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -601,11 +705,17 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.FileResponse"
+                                            "$ref": "#/definitions/go-fiber_app_model_mongo.Alumni"
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version baru data alumni"
+                            }
                         }
                     },
                     "400": {
@@ -626,66 +736,75 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/files/upload/sertifikat": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Upload file sertifikat (PDF, max 2MB)",
+                ]
+            },
+            "delete": {
+                "description": "Menghapus alumni berdasarkan ID (Admin only)",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "4. Files"
+                    "2. Alumni"
                 ],
-                "summary": "Upload sertifikat alumni",
+                "summary": "Hapus alumni",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "File sertifikat (PDF, max 2MB)",
-                        "name": "file",
-                        "in": "formData",
+                        "type": "string",
+                        "description": "Alumni ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Alumni ID (MongoDB ObjectID)",
-                        "name": "alumni_id",
-                        "in": "formData",
-                        "required": true
+                        "description": "ETag dari GET alumni; wajib jika require_if_match aktif",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/model.SuccessResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/model.FileResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/model.SuccessResponse"
                         }
                     },
                     "400": {
@@ -706,40 +825,73 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/files/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Mengambil data file spesifik berdasarkan ID",
+                ]
+            },
+            "patch": {
+                "description": "Mengubah sebagian field alumni dengan JSON Merge Patch (RFC 7396) atau JSON Patch (RFC 6902). Hanya field yang disentuh patch yang divalidasi dan diubah; field bernilai null dihapus (Admin only)",
                 "consumes": [
-                    "application/json"
+                    "application/json",
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "4. Files"
+                    "2. Alumni"
                 ],
-                "summary": "Dapatkan file berdasarkan ID",
+                "summary": "Patch alumni",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "File ID (MongoDB ObjectID)",
+                        "description": "Alumni ID (MongoDB ObjectID)",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Merge patch berupa object field, atau JSON Patch berupa array operasi",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET alumni; wajib jika require_if_match aktif",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -754,11 +906,17 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.FileResponse"
+                                            "$ref": "#/definitions/go-fiber_app_model_mongo.Alumni"
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version baru data alumni"
+                            }
                         }
                     },
                     "400": {
@@ -773,27 +931,65 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Menghapus file berdasarkan ID dari database dan storage",
+                ]
+            }
+        },
+        "/alumni/{id}/history": {
+            "get": {
+                "description": "Mengambil semua versi data alumni beserta field yang berubah, terlama lebih dulu",
                 "consumes": [
                     "application/json"
                 ],
@@ -801,13 +997,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "4. Files"
+                    "2. Alumni"
                 ],
-                "summary": "Hapus file",
+                "summary": "Riwayat versi alumni",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "File ID (MongoDB ObjectID)",
+                        "description": "Alumni ID (MongoDB ObjectID)",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -817,7 +1013,22 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/audit.Version"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -844,12 +1055,17 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/login": {
+        "/alumni/{id}/revert/{version}": {
             "post": {
-                "description": "Login dengan email dan password untuk mendapatkan JWT token",
+                "description": "Mengembalikan data alumni ke versi dari riwayat. NIM dan password tidak ikut dikembalikan. Revert dicatat sebagai versi baru (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -857,18 +1073,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "1. Authentication"
+                    "2. Alumni"
                 ],
-                "summary": "Login pengguna",
+                "summary": "Kembalikan alumni ke versi tertentu",
                 "parameters": [
                     {
-                        "description": "Login credentials",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/go-fiber_app_model_mongo.LoginRequest"
-                        }
+                        "type": "string",
+                        "description": "Alumni ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Nomor versi dari endpoint history",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET alumni; wajib jika require_if_match aktif",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -883,11 +1110,17 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/go-fiber_app_model_mongo.LoginResponse"
+                                            "$ref": "#/definitions/go-fiber_app_model_mongo.Alumni"
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version baru data alumni"
+                            }
                         }
                     },
                     "400": {
@@ -902,23 +1135,59 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/pekerjaan": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Mengambil daftar semua pekerjaan alumni dari database",
+                ]
+            }
+        },
+        "/analytics/distribution": {
+            "get": {
+                "description": "Menghitung jumlah pekerjaan alumni per bidang_industri atau lokasi_kerja untuk setiap kelompok (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -926,9 +1195,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "3. Pekerjaan Alumni"
+                    "5. Analytics"
+                ],
+                "summary": "Distribusi pekerjaan alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bidang_industri (default) atau lokasi_kerja",
+                        "name": "dimension",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter status pekerjaan: aktif, selesai, atau resigned",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kelompokkan berdasarkan angkatan, tahun_lulus, atau jurusan",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter angkatan",
+                        "name": "angkatan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter tahun lulus",
+                        "name": "tahun_lulus",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter jurusan",
+                        "name": "jurusan",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Dapatkan semua pekerjaan alumni",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -943,7 +1250,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/go-fiber_app_model_mongo.PekerjaanAlumni"
+                                                "$ref": "#/definitions/go-fiber_app_model_mongo.DistributionItem"
                                             }
                                         }
                                     }
@@ -951,27 +1258,41 @@ const docTemplate = `{
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Membuat data pekerjaan alumni baru (Admin only)",
+                ]
+            }
+        },
+        "/analytics/employment-rate": {
+            "get": {
+                "description": "Menghitung persentase alumni yang memiliki pekerjaan berstatus aktif per kelompok (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -979,23 +1300,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "3. Pekerjaan Alumni"
+                    "5. Analytics"
                 ],
-                "summary": "Buat pekerjaan alumni baru",
+                "summary": "Tingkat keterserapan kerja alumni",
                 "parameters": [
                     {
-                        "description": "Pekerjaan alumni data",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/go-fiber_app_model_mongo.CreatePekerjaanAlumniRequest"
-                        }
+                        "type": "string",
+                        "description": "Kelompokkan berdasarkan angkatan, tahun_lulus, atau jurusan",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter angkatan",
+                        "name": "angkatan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter tahun lulus",
+                        "name": "tahun_lulus",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter jurusan",
+                        "name": "jurusan",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -1005,7 +1341,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/go-fiber_app_model_mongo.PekerjaanAlumni"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/go-fiber_app_model_mongo.EmploymentRate"
+                                            }
                                         }
                                     }
                                 }
@@ -1036,17 +1375,17 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/pekerjaan/alumni/{alumni_id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Mengambil semua pekerjaan dari alumni tertentu (Admin only)",
+                ]
+            }
+        },
+        "/analytics/salary": {
+            "get": {
+                "description": "Menghitung persentil 25, median, dan persentil 75 gaji bulanan (titik tengah gaji_min dan gaji_max) per kelompok. Kelompok dengan alumni kurang dari min_group_size tidak ditampilkan (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1054,16 +1393,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "3. Pekerjaan Alumni"
+                    "5. Analytics"
                 ],
-                "summary": "Dapatkan pekerjaan berdasarkan Alumni ID",
+                "summary": "Statistik gaji alumni",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Alumni ID (MongoDB ObjectID)",
-                        "name": "alumni_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Kelompokkan berdasarkan angkatan, tahun_lulus, jurusan, atau bidang_industri",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Mata uang gaji (default IDR)",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah alumni minimum per kelompok (minimal 5)",
+                        "name": "min_group_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter angkatan",
+                        "name": "angkatan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter tahun lulus",
+                        "name": "tahun_lulus",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter jurusan",
+                        "name": "jurusan",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1080,7 +1448,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/go-fiber_app_model_mongo.PekerjaanAlumni"
+                                                "$ref": "#/definitions/go-fiber_app_model_mongo.SalaryStatistic"
                                             }
                                         }
                                     }
@@ -1112,17 +1480,17 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/pekerjaan/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Mengambil data pekerjaan alumni spesifik berdasarkan ID",
+                ]
+            }
+        },
+        "/analytics/time-to-employment": {
+            "get": {
+                "description": "Menghitung median jarak antara kelulusan (1 Januari tahun_lulus) dan pekerjaan pertama per kelompok (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1130,16 +1498,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "3. Pekerjaan Alumni"
+                    "5. Analytics"
                 ],
-                "summary": "Dapatkan pekerjaan alumni berdasarkan ID",
+                "summary": "Median waktu tunggu kerja alumni",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Pekerjaan ID (MongoDB ObjectID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Kelompokkan berdasarkan angkatan, tahun_lulus, atau jurusan",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter angkatan",
+                        "name": "angkatan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter tahun lulus",
+                        "name": "tahun_lulus",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter jurusan",
+                        "name": "jurusan",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1154,7 +1539,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/go-fiber_app_model_mongo.PekerjaanAlumni"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/go-fiber_app_model_mongo.TimeToEmployment"
+                                            }
                                         }
                                     }
                                 }
@@ -1173,8 +1561,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -1185,15 +1573,17 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                }
-            },
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Memperbarui data pekerjaan alumni berdasarkan ID (Admin only)",
+                ]
+            }
+        },
+        "/analytics/top-employers": {
+            "get": {
+                "description": "Mengambil perusahaan yang mempekerjakan alumni terbanyak untuk setiap kelompok (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1201,25 +1591,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "3. Pekerjaan Alumni"
+                    "5. Analytics"
                 ],
-                "summary": "Update pekerjaan alumni",
+                "summary": "Perusahaan dengan alumni terbanyak",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Jumlah perusahaan per kelompok (default 10, maksimal 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Pekerjaan ID (MongoDB ObjectID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Kelompokkan berdasarkan angkatan, tahun_lulus, atau jurusan",
+                        "name": "group_by",
+                        "in": "query"
                     },
                     {
-                        "description": "Pekerjaan alumni data",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/go-fiber_app_model_mongo.UpdatePekerjaanAlumniRequest"
-                        }
+                        "type": "integer",
+                        "description": "Filter angkatan",
+                        "name": "angkatan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter tahun lulus",
+                        "name": "tahun_lulus",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter jurusan",
+                        "name": "jurusan",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1234,7 +1638,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/go-fiber_app_model_mongo.PekerjaanAlumni"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/go-fiber_app_model_mongo.TopEmployer"
+                                            }
                                         }
                                     }
                                 }
@@ -1259,27 +1666,23 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Menghapus pekerjaan alumni berdasarkan ID (Admin only)",
+                ]
+            }
+        },
+        "/audit-logs": {
+            "get": {
+                "description": "Mengambil catatan audit perubahan data terbaru lebih dulu, bisa difilter berdasarkan actor, resource, dan rentang waktu (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1287,28 +1690,293 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "3. Pekerjaan Alumni"
+                    "7. Audit"
                 ],
-                "summary": "Hapus pekerjaan alumni",
+                "summary": "Dapatkan audit log",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Pekerjaan ID (MongoDB ObjectID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/model.SuccessResponse"
-                        }
+                        "description": "ID atau email actor",
+                        "name": "actor",
+                        "in": "query"
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
+                    {
+                        "type": "string",
+                        "description": "Resource: alumni, pekerjaan, atau file",
+                        "name": "resource",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID resource",
+                        "name": "resource_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Aksi: create, update, delete, soft_delete, hard_delete, restore, atau import",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Waktu awal (RFC3339 atau YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Waktu akhir (RFC3339 atau YYYY-MM-DD, tanggal saja mencakup seluruh hari)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Halaman (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah per halaman, maksimal 100 (default 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/audit.Entry"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/export/alumni": {
+            "get": {
+                "description": "Mengunduh seluruh data alumni sebagai CSV atau XLSX. Export besar atau async=true dijalankan sebagai job background (Admin only)",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "6. Export"
+                ],
+                "summary": "Export data alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "csv",
+                        "description": "Format file: csv atau xlsx",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kolom yang diekspor, dipisah koma (contoh: nim,nama,email)",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Jalankan export sebagai job background",
+                        "name": "async",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/worker.ExportJob"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/export/analytics/{report}": {
+            "get": {
+                "description": "Mengunduh hasil salah satu endpoint analytics sebagai CSV atau XLSX dengan parameter query yang sama (Admin only)",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "6. Export"
+                ],
+                "summary": "Export hasil analytics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "employment-rate, time-to-employment, distribution, top-employers, atau salary",
+                        "name": "report",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "csv",
+                        "description": "Format file: csv atau xlsx",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kelompokkan berdasarkan angkatan, tahun_lulus, atau jurusan (salary juga bidang_industri)",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter angkatan",
+                        "name": "angkatan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter tahun lulus",
+                        "name": "tahun_lulus",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter jurusan",
+                        "name": "jurusan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Jalankan export sebagai job background",
+                        "name": "async",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/worker.ExportJob"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
@@ -1335,96 +2003,4032 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/export/jobs/{id}": {
+            "get": {
+                "description": "Mengambil status job export background milik admin yang sedang login (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "6. Export"
+                ],
+                "summary": "Status job export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID job export",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/worker.ExportJob"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/export/jobs/{id}/download": {
+            "get": {
+                "description": "Mengunduh file hasil job export yang sudah selesai (Admin only)",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "6. Export"
+                ],
+                "summary": "Unduh hasil job export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID job export",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/export/pekerjaan": {
+            "get": {
+                "description": "Mengunduh seluruh data pekerjaan alumni sebagai CSV atau XLSX. Export besar atau async=true dijalankan sebagai job background (Admin only)",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "6. Export"
+                ],
+                "summary": "Export data pekerjaan alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "csv",
+                        "description": "Format file: csv atau xlsx",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kolom yang diekspor, dipisah koma (contoh: nama_perusahaan,posisi_jabatan)",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Jalankan export sebagai job background",
+                        "name": "async",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/worker.ExportJob"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/export/report/tracer-study": {
+            "get": {
+                "description": "Membuat laporan PDF yang merangkum keterserapan kerja, waktu tunggu, distribusi industri, perusahaan teratas, dan statistik gaji per kelompok (Admin only)",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "6. Export"
+                ],
+                "summary": "Laporan PDF tracer study",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "angkatan",
+                        "description": "Kelompokkan berdasarkan angkatan, tahun_lulus, atau jurusan",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter angkatan",
+                        "name": "angkatan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter tahun lulus",
+                        "name": "tahun_lulus",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter jurusan",
+                        "name": "jurusan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Jalankan export sebagai job background",
+                        "name": "async",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/worker.ExportJob"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/files": {
+            "get": {
+                "description": "Mengambil daftar semua file dari database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "4. Files"
+                ],
+                "summary": "Dapatkan semua file",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.FileResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/files/alumni/{alumni_id}": {
+            "get": {
+                "description": "Mengambil semua file dari alumni tertentu",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "4. Files"
+                ],
+                "summary": "Dapatkan file berdasarkan Alumni ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Alumni ID (MongoDB ObjectID)",
+                        "name": "alumni_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.FileResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/files/upload/foto": {
+            "post": {
+                "description": "Upload file foto (JPEG/JPG/PNG, max 1MB)",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "4. Files"
+                ],
+                "summary": "Upload foto alumni",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "File foto (JPEG/JPG/PNG, max 1MB)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alumni ID (MongoDB ObjectID)",
+                        "name": "alumni_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key unik per request; retry dengan key dan body sama mengembalikan response pertama",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.FileResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "Idempotent-Replayed": {
+                                "type": "string",
+                                "description": "true jika response dikirim ulang dari Idempotency-Key"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/files/upload/sertifikat": {
+            "post": {
+                "description": "Upload file sertifikat (PDF, max 2MB)",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "4. Files"
+                ],
+                "summary": "Upload sertifikat alumni",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "File sertifikat (PDF, max 2MB)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alumni ID (MongoDB ObjectID)",
+                        "name": "alumni_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key unik per request; retry dengan key dan body sama mengembalikan response pertama",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.FileResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "Idempotent-Replayed": {
+                                "type": "string",
+                                "description": "true jika response dikirim ulang dari Idempotency-Key"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/files/{id}": {
+            "get": {
+                "description": "Mengambil data file spesifik berdasarkan ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "4. Files"
+                ],
+                "summary": "Dapatkan file berdasarkan ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.FileResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Menghapus file berdasarkan ID dari database dan storage",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "4. Files"
+                ],
+                "summary": "Hapus file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/jobs": {
+            "get": {
+                "description": "Mengambil background job, terbaru lebih dulu (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "9. Jobs"
+                ],
+                "summary": "Dapatkan semua job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tipe job",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Status: pending, running, succeeded, failed, atau cancelled",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Halaman (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah per halaman, maksimal 100 (default 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/queue.Job"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/jobs/schedules": {
+            "get": {
+                "description": "Mengambil jadwal cron yang terdaftar beserta waktu jalan berikutnya dalam UTC (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "9. Jobs"
+                ],
+                "summary": "Dapatkan jadwal job",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/queue.ScheduleInfo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/jobs/{id}": {
+            "get": {
+                "description": "Mengambil satu background job beserta error terakhirnya (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "9. Jobs"
+                ],
+                "summary": "Dapatkan job berdasarkan ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/queue.Job"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/jobs/{id}/cancel": {
+            "post": {
+                "description": "Membatalkan job yang belum berjalan (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "9. Jobs"
+                ],
+                "summary": "Batalkan job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/queue.Job"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/jobs/{id}/retry": {
+            "post": {
+                "description": "Menjadwalkan ulang job failed atau cancelled untuk segera dijalankan dengan hitungan percobaan dari nol (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "9. Jobs"
+                ],
+                "summary": "Jalankan ulang job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/queue.Job"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Login dengan email dan password untuk mendapatkan JWT token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "1. Authentication"
+                ],
+                "summary": "Login pengguna",
+                "parameters": [
+                    {
+                        "description": "Login credentials",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go-fiber_app_model_mongo.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/go-fiber_app_model_mongo.LoginResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pekerjaan": {
+            "get": {
+                "description": "Mengambil daftar semua pekerjaan alumni dari database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "3. Pekerjaan Alumni"
+                ],
+                "summary": "Dapatkan semua pekerjaan alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Field yang ditampilkan, dipisah koma (contoh: nama_perusahaan,posisi_jabatan)",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/go-fiber_app_model_mongo.PekerjaanAlumni"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Membuat data pekerjaan alumni baru (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "3. Pekerjaan Alumni"
+                ],
+                "summary": "Buat pekerjaan alumni baru",
+                "parameters": [
+                    {
+                        "description": "Pekerjaan alumni data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go-fiber_app_model_mongo.CreatePekerjaanAlumniRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key unik per request; retry dengan key dan body sama mengembalikan response pertama",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/go-fiber_app_model_mongo.PekerjaanAlumni"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "Idempotent-Replayed": {
+                                "type": "string",
+                                "description": "true jika response dikirim ulang dari Idempotency-Key"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/pekerjaan/alumni/{alumni_id}": {
+            "get": {
+                "description": "Mengambil semua pekerjaan dari alumni tertentu (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "3. Pekerjaan Alumni"
+                ],
+                "summary": "Dapatkan pekerjaan berdasarkan Alumni ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Alumni ID (MongoDB ObjectID)",
+                        "name": "alumni_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/go-fiber_app_model_mongo.PekerjaanAlumni"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/pekerjaan/batch/delete": {
+            "post": {
+                "description": "Menghapus pekerjaan yang dipilih ids atau filter, maksimal 100 data, dalam satu transaksi. Jika satu item gagal tidak ada yang dihapus dan laporan per item dikirim di details response 422 (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "3. Pekerjaan Alumni"
+                ],
+                "summary": "Batch delete pekerjaan alumni",
+                "parameters": [
+                    {
+                        "description": "Pilih tepat salah satu dari ids atau filter",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go-fiber_app_model_mongo.PekerjaanBatchDeleteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/helper.BatchResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "details": {
+                                            "$ref": "#/definitions/helper.BatchResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/pekerjaan/batch/update": {
+            "post": {
+                "description": "Menerapkan changes (JSON Merge Patch dengan field update pekerjaan) pada pekerjaan yang dipilih ids atau filter, maksimal 100 data, dalam satu transaksi, misalnya menandai semua pekerjaan di perusahaan yang tutup sebagai selesai. Jika satu item gagal tidak ada yang disimpan dan laporan per item dikirim di details response 422 (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "3. Pekerjaan Alumni"
+                ],
+                "summary": "Batch update pekerjaan alumni",
+                "parameters": [
+                    {
+                        "description": "Pilih tepat salah satu dari ids atau filter",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go-fiber_app_model_mongo.PekerjaanBatchUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/helper.BatchResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "details": {
+                                            "$ref": "#/definitions/helper.BatchResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/pekerjaan/import": {
+            "post": {
+                "description": "Memvalidasi dan menyimpan banyak pekerjaan alumni sekaligus. Setiap baris merujuk alumni lewat kolom nim. Default dry_run=true hanya memvalidasi; penyimpanan berjalan dalam satu transaksi (Admin only)",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "3. Pekerjaan Alumni"
+                ],
+                "summary": "Import pekerjaan alumni dari CSV/XLSX",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "File .csv atau .xlsx dengan baris pertama sebagai header",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Mapping field ke header file dalam JSON, contoh {\\",
+                        "name": "mapping",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Hanya validasi tanpa menyimpan",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/go-fiber_app_model_mongo.ImportResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/go-fiber_app_model_mongo.ImportResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/go-fiber_app_model_mongo.ImportResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/pekerjaan/{id}": {
+            "get": {
+                "description": "Mengambil data pekerjaan alumni spesifik berdasarkan ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "3. Pekerjaan Alumni"
+                ],
+                "summary": "Dapatkan pekerjaan alumni berdasarkan ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pekerjaan ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field yang ditampilkan, dipisah koma (contoh: nama_perusahaan,posisi_jabatan)",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tampilkan data pada waktu tertentu dari riwayat (RFC3339 atau YYYY-MM-DD)",
+                        "name": "as_of",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari response sebelumnya; 304 jika data belum berubah",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/go-fiber_app_model_mongo.PekerjaanAlumni"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version data pekerjaan"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Data belum berubah sejak ETag pada If-None-Match"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Memperbarui data pekerjaan alumni berdasarkan ID (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "3. Pekerjaan Alumni"
+                ],
+                "summary": "Update pekerjaan alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pekerjaan ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pekerjaan alumni data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go-fiber_app_model_mongo.UpdatePekerjaanAlumniRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET pekerjaan; wajib jika require_if_match aktif",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/go-fiber_app_model_mongo.PekerjaanAlumni"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version baru data pekerjaan"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Menghapus pekerjaan alumni berdasarkan ID (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "3. Pekerjaan Alumni"
+                ],
+                "summary": "Hapus pekerjaan alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pekerjaan ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET pekerjaan; wajib jika require_if_match aktif",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "patch": {
+                "description": "Mengubah sebagian field pekerjaan dengan JSON Merge Patch (RFC 7396) atau JSON Patch (RFC 6902). Hanya field yang disentuh patch yang divalidasi dan diubah; field bernilai null dihapus (Admin only)",
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "3. Pekerjaan Alumni"
+                ],
+                "summary": "Patch pekerjaan alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pekerjaan ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Merge patch berupa object field, atau JSON Patch berupa array operasi",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET pekerjaan; wajib jika require_if_match aktif",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/go-fiber_app_model_mongo.PekerjaanAlumni"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version baru data pekerjaan"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/pekerjaan/{id}/history": {
+            "get": {
+                "description": "Mengambil semua versi data pekerjaan alumni beserta field yang berubah, terlama lebih dulu",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "3. Pekerjaan Alumni"
+                ],
+                "summary": "Riwayat versi pekerjaan alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pekerjaan ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/audit.Version"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/pekerjaan/{id}/revert/{version}": {
+            "post": {
+                "description": "Mengembalikan data pekerjaan alumni ke versi dari riwayat. Data alumni_info tetap mengikuti data saat ini. Revert dicatat sebagai versi baru (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "3. Pekerjaan Alumni"
+                ],
+                "summary": "Kembalikan pekerjaan alumni ke versi tertentu",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pekerjaan ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Nomor versi dari endpoint history",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET pekerjaan; wajib jika require_if_match aktif",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/go-fiber_app_model_mongo.PekerjaanAlumni"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version baru data pekerjaan"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/profile": {
+            "get": {
+                "description": "Mendapatkan informasi profil dari JWT token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "1. Authentication"
+                ],
+                "summary": "Dapatkan profil pengguna",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/go-fiber_app_model_mongo.GetProfileResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "description": "Mengambil semua subscription webhook tanpa secret (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "8. Webhooks"
+                ],
+                "summary": "Dapatkan semua webhook",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/webhook.Subscription"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Mendaftarkan URL penerima untuk tipe event tertentu (alumni.created, alumni.*, atau *). Secret kosong dibuatkan otomatis dan hanya ditampilkan di response ini (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "8. Webhooks"
+                ],
+                "summary": "Daftarkan webhook",
+                "parameters": [
+                    {
+                        "description": "Data webhook",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/webhook.SubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/webhook.Subscription"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/webhooks/dead-letters": {
+            "get": {
+                "description": "Mengambil delivery yang berhenti dicoba karena gagal sampai batas percobaan atau webhook-nya tidak aktif (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "8. Webhooks"
+                ],
+                "summary": "Dead-letter webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Halaman (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah per halaman, maksimal 100 (default 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/webhook.Delivery"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/webhooks/deliveries": {
+            "get": {
+                "description": "Mengambil delivery semua webhook, terbaru lebih dulu (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "8. Webhooks"
+                ],
+                "summary": "Dapatkan semua delivery webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Status: pending, succeeded, atau dead",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Halaman (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah per halaman, maksimal 100 (default 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/webhook.Delivery"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/webhooks/deliveries/{deliveryId}": {
+            "get": {
+                "description": "Mengambil satu delivery beserta payload event dan log percobaannya (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "8. Webhooks"
+                ],
+                "summary": "Dapatkan delivery webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Delivery ID (MongoDB ObjectID)",
+                        "name": "deliveryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/webhook.Delivery"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/webhooks/deliveries/{deliveryId}/redeliver": {
+            "post": {
+                "description": "Menjadwalkan ulang delivery yang sudah berhasil atau ada di dead-letter untuk segera dikirim dengan hitungan percobaan dari nol. Delivery yang masih pending ditolak dengan 409 (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "8. Webhooks"
+                ],
+                "summary": "Kirim ulang delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Delivery ID (MongoDB ObjectID)",
+                        "name": "deliveryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/webhook.Delivery"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "description": "Mengambil satu subscription webhook tanpa secret (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "8. Webhooks"
+                ],
+                "summary": "Dapatkan webhook berdasarkan ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/webhook.Subscription"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Mengubah URL, tipe event, deskripsi, status aktif, atau secret webhook. Secret kosong berarti secret lama tetap dipakai (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "8. Webhooks"
+                ],
+                "summary": "Update webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data webhook",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/webhook.SubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/webhook.Subscription"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Menghapus subscription webhook beserta semua log delivery-nya (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "8. Webhooks"
+                ],
+                "summary": "Hapus webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "description": "Mengambil delivery satu webhook beserta log setiap percobaan kirim, terbaru lebih dulu (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "8. Webhooks"
+                ],
+                "summary": "Log delivery webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Status: pending, succeeded, atau dead",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Halaman (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah per halaman, maksimal 100 (default 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/webhook.Delivery"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/webhooks/{id}/test": {
+            "post": {
+                "description": "Menjadwalkan event webhook.test ke webhook untuk memeriksa URL dan verifikasi signature. Hasilnya terlihat di log delivery (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "8. Webhooks"
+                ],
+                "summary": "Kirim event test",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID (MongoDB ObjectID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/outbox.Event"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        }
+    },
+    "definitions": {
+        "audit.Change": {
+            "type": "object",
+            "properties": {
+                "after": {},
+                "before": {},
+                "field": {
+                    "type": "string"
+                }
+            }
+        },
+        "audit.Entry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor_email": {
+                    "type": "string"
+                },
+                "actor_id": {
+                    "type": "string"
+                },
+                "actor_role": {
+                    "type": "string"
+                },
+                "after": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "before": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/audit.Change"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "resource": {
+                    "type": "string"
+                },
+                "resource_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "audit.Version": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor_email": {
+                    "type": "string"
+                },
+                "actor_id": {
+                    "type": "string"
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/audit.Change"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "data": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "go-fiber_app_model_mongo.Alumni": {
+            "type": "object",
+            "properties": {
+                "alamat": {
+                    "type": "string"
+                },
+                "angkatan": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "jurusan": {
+                    "type": "string"
+                },
+                "nama": {
+                    "type": "string"
+                },
+                "nim": {
+                    "type": "string"
+                },
+                "no_telepon": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "tahun_lulus": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "go-fiber_app_model_mongo.AlumniBatchDeleteRequest": {
+            "type": "object",
+            "properties": {
+                "filter": {
+                    "$ref": "#/definitions/go-fiber_app_model_mongo.AlumniBatchFilter"
+                },
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "6718f0c2a1b2c3d4e5f60718"
+                    ]
+                }
+            }
+        },
+        "go-fiber_app_model_mongo.AlumniBatchFilter": {
+            "type": "object",
+            "properties": {
+                "angkatan": {
+                    "type": "integer",
+                    "example": 2019
+                },
+                "jurusan": {
+                    "type": "string",
+                    "example": "Teknik Informatika"
+                },
+                "tahun_lulus": {
+                    "type": "integer",
+                    "example": 2023
+                }
+            }
+        },
+        "go-fiber_app_model_mongo.AlumniBatchUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "object"
+                },
+                "filter": {
+                    "$ref": "#/definitions/go-fiber_app_model_mongo.AlumniBatchFilter"
+                },
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "6718f0c2a1b2c3d4e5f60718"
+                    ]
+                }
+            }
+        },
+        "go-fiber_app_model_mongo.AlumniDetail": {
+            "type": "object",
+            "properties": {
+                "alamat": {
+                    "type": "string"
+                },
+                "angkatan": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "files": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.File"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "jurusan": {
+                    "type": "string"
+                },
+                "nama": {
+                    "type": "string"
+                },
+                "nim": {
+                    "type": "string"
+                },
+                "no_telepon": {
+                    "type": "string"
+                },
+                "pekerjaan": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go-fiber_app_model_mongo.PekerjaanAlumni"
+                    }
+                },
+                "role": {
+                    "type": "string"
+                },
+                "tahun_lulus": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "go-fiber_app_model_mongo.CreateAlumniRequest": {
+            "type": "object",
+            "required": [
+                "angkatan",
+                "email",
+                "jurusan",
+                "nama",
+                "nim",
+                "password",
+                "role",
+                "tahun_lulus"
+            ],
+            "properties": {
+                "alamat": {
+                    "type": "string"
+                },
+                "angkatan": {
+                    "type": "integer"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "jurusan": {
+                    "type": "string"
+                },
+                "nama": {
+                    "type": "string"
+                },
+                "nim": {
+                    "type": "string"
+                },
+                "no_telepon": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "user"
+                    ]
+                },
+                "tahun_lulus": {
+                    "type": "integer"
+                }
+            }
+        },
+        "go-fiber_app_model_mongo.CreatePekerjaanAlumniRequest": {
+            "type": "object",
+            "required": [
+                "alumni_info",
+                "bidang_industri",
+                "lokasi_kerja",
+                "nama_perusahaan",
+                "posisi_jabatan",
+                "status_pekerjaan",
+                "tanggal_mulai_kerja"
+            ],
+            "properties": {
+                "alumni_info": {
+                    "$ref": "#/definitions/model.AlumniInfo"
+                },
+                "bidang_industri": {
+                    "type": "string"
+                },
+                "deskripsi_pekerjaan": {
+                    "type": "string"
+                },
+                "gaji_currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "gaji_max": {
+                    "type": "integer"
+                },
+                "gaji_min": {
+                    "type": "integer"
+                },
+                "gaji_period": {
+                    "type": "string",
+                    "example": "bulanan"
+                },
+                "gaji_range": {
+                    "type": "string"
+                },
+                "lokasi_kerja": {
+                    "type": "string"
+                },
+                "nama_perusahaan": {
+                    "type": "string"
+                },
+                "posisi_jabatan": {
+                    "type": "string"
+                },
+                "status_pekerjaan": {
+                    "type": "string",
+                    "enum": [
+                        "aktif",
+                        "selesai",
+                        "resigned"
+                    ]
+                },
+                "tanggal_mulai_kerja": {
+                    "type": "string"
+                },
+                "tanggal_selesai_kerja": {
+                    "type": "string"
+                }
+            }
+        },
+        "go-fiber_app_model_mongo.DistributionItem": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "go-fiber_app_model_mongo.EmploymentRate": {
+            "type": "object",
+            "properties": {
+                "employed_alumni": {
+                    "type": "integer"
+                },
+                "employment_rate": {
+                    "type": "number"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "total_alumni": {
+                    "type": "integer"
+                }
+            }
+        },
+        "go-fiber_app_model_mongo.GetProfileResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object",
+                    "properties": {
+                        "alumni_id": {
+                            "type": "string"
+                        },
+                        "email": {
+                            "type": "string"
+                        },
+                        "role": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "go-fiber_app_model_mongo.ImportCredential": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "nim": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "go-fiber_app_model_mongo.ImportResult": {
+            "type": "object",
+            "properties": {
+                "credentials": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go-fiber_app_model_mongo.ImportCredential"
+                    }
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/helper.ImportRowError"
+                    }
+                },
+                "imported": {
+                    "type": "integer"
+                },
+                "total_rows": {
+                    "type": "integer"
+                },
+                "valid_rows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "go-fiber_app_model_mongo.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "go-fiber_app_model_mongo.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "alumni": {
+                    "$ref": "#/definitions/go-fiber_app_model_mongo.Alumni"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "go-fiber_app_model_mongo.PekerjaanAlumni": {
+            "type": "object",
+            "properties": {
+                "alumni_info": {
+                    "$ref": "#/definitions/model.AlumniInfo"
+                },
+                "bidang_industri": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deskripsi_pekerjaan": {
+                    "type": "string"
+                },
+                "gaji_currency": {
+                    "type": "string"
+                },
+                "gaji_max": {
+                    "type": "integer"
+                },
+                "gaji_min": {
+                    "type": "integer"
+                },
+                "gaji_period": {
+                    "type": "string"
+                },
+                "gaji_range": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lokasi_kerja": {
+                    "type": "string"
+                },
+                "nama_perusahaan": {
+                    "type": "string"
+                },
+                "posisi_jabatan": {
+                    "type": "string"
+                },
+                "status_pekerjaan": {
+                    "type": "string"
+                },
+                "tanggal_mulai_kerja": {
+                    "type": "string"
+                },
+                "tanggal_selesai_kerja": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "go-fiber_app_model_mongo.PekerjaanBatchDeleteRequest": {
+            "type": "object",
+            "properties": {
+                "filter": {
+                    "$ref": "#/definitions/go-fiber_app_model_mongo.PekerjaanBatchFilter"
+                },
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "6718f0c2a1b2c3d4e5f60718"
+                    ]
+                }
+            }
+        },
+        "go-fiber_app_model_mongo.PekerjaanBatchFilter": {
+            "type": "object",
+            "properties": {
+                "alumni_id": {
+                    "type": "string",
+                    "example": "6718f0c2a1b2c3d4e5f60718"
+                },
+                "bidang_industri": {
+                    "type": "string"
+                },
+                "nama_perusahaan": {
+                    "type": "string",
+                    "example": "PT Maju Jaya"
+                },
+                "status_pekerjaan": {
+                    "type": "string",
+                    "enum": [
+                        "aktif",
+                        "selesai",
+                        "resigned"
+                    ],
+                    "example": "aktif"
                 }
             }
         },
-        "/profile": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Mendapatkan informasi profil dari JWT token",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "1. Authentication"
-                ],
-                "summary": "Dapatkan profil pengguna",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/go-fiber_app_model_mongo.GetProfileResponse"
-                        }
+        "go-fiber_app_model_mongo.PekerjaanBatchUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "object"
+                },
+                "filter": {
+                    "$ref": "#/definitions/go-fiber_app_model_mongo.PekerjaanBatchFilter"
+                },
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
-                        }
-                    }
+                    "example": [
+                        "6718f0c2a1b2c3d4e5f60718"
+                    ]
                 }
             }
-        }
-    },
-    "definitions": {
-        "go-fiber_app_model_mongo.Alumni": {
+        },
+        "go-fiber_app_model_mongo.SalaryStatistic": {
             "type": "object",
             "properties": {
-                "alamat": {
+                "currency": {
                     "type": "string"
                 },
-                "angkatan": {
-                    "type": "integer"
-                },
-                "created_at": {
+                "group": {
                     "type": "string"
                 },
-                "email": {
-                    "type": "string"
+                "median": {
+                    "type": "number"
                 },
-                "id": {
-                    "type": "string"
+                "p25": {
+                    "type": "number"
                 },
-                "jurusan": {
-                    "type": "string"
+                "p75": {
+                    "type": "number"
                 },
-                "nama": {
+                "total_sampel": {
+                    "type": "integer"
+                }
+            }
+        },
+        "go-fiber_app_model_mongo.TimeToEmployment": {
+            "type": "object",
+            "properties": {
+                "group": {
                     "type": "string"
                 },
-                "nim": {
-                    "type": "string"
+                "median_days": {
+                    "type": "number"
                 },
-                "no_telepon": {
+                "median_months": {
+                    "type": "number"
+                },
+                "total_alumni": {
+                    "type": "integer"
+                }
+            }
+        },
+        "go-fiber_app_model_mongo.TopEmployer": {
+            "type": "object",
+            "properties": {
+                "group": {
                     "type": "string"
                 },
-                "role": {
+                "nama_perusahaan": {
                     "type": "string"
                 },
-                "tahun_lulus": {
+                "total_alumni": {
                     "type": "integer"
-                },
-                "updated_at": {
-                    "type": "string"
                 }
             }
         },
-        "go-fiber_app_model_mongo.CreateAlumniRequest": {
+        "go-fiber_app_model_mongo.UpdateAlumniRequest": {
             "type": "object",
             "required": [
                 "angkatan",
                 "email",
                 "jurusan",
                 "nama",
-                "nim",
-                "password",
                 "role",
                 "tahun_lulus"
             ],
@@ -1444,15 +6048,9 @@ const docTemplate = `{
                 "nama": {
                     "type": "string"
                 },
-                "nim": {
-                    "type": "string"
-                },
                 "no_telepon": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string"
-                },
                 "role": {
                     "type": "string",
                     "enum": [
@@ -1460,319 +6058,527 @@ const docTemplate = `{
                         "user"
                     ]
                 },
-                "tahun_lulus": {
-                    "type": "integer"
+                "tahun_lulus": {
+                    "type": "integer"
+                }
+            }
+        },
+        "go-fiber_app_model_mongo.UpdatePekerjaanAlumniRequest": {
+            "type": "object",
+            "required": [
+                "bidang_industri",
+                "lokasi_kerja",
+                "nama_perusahaan",
+                "posisi_jabatan",
+                "status_pekerjaan",
+                "tanggal_mulai_kerja"
+            ],
+            "properties": {
+                "bidang_industri": {
+                    "type": "string"
+                },
+                "deskripsi_pekerjaan": {
+                    "type": "string"
+                },
+                "gaji_currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "gaji_max": {
+                    "type": "integer"
+                },
+                "gaji_min": {
+                    "type": "integer"
+                },
+                "gaji_period": {
+                    "type": "string",
+                    "example": "bulanan"
+                },
+                "gaji_range": {
+                    "type": "string"
+                },
+                "lokasi_kerja": {
+                    "type": "string"
+                },
+                "nama_perusahaan": {
+                    "type": "string"
+                },
+                "posisi_jabatan": {
+                    "type": "string"
+                },
+                "status_pekerjaan": {
+                    "type": "string",
+                    "enum": [
+                        "aktif",
+                        "selesai",
+                        "resigned"
+                    ]
+                },
+                "tanggal_mulai_kerja": {
+                    "type": "string"
+                },
+                "tanggal_selesai_kerja": {
+                    "type": "string"
+                }
+            }
+        },
+        "helper.BatchItemResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {},
+                "id": {},
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "helper.BatchResult": {
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/helper.BatchItemResult"
+                    }
+                },
+                "succeeded": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "helper.ImportRowError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AlumniInfo": {
+            "type": "object",
+            "required": [
+                "email",
+                "nama",
+                "nim"
+            ],
+            "properties": {
+                "alumni_id": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "nama": {
+                    "type": "string"
+                },
+                "nim": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "not_found"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "Data alumni dengan ID tersebut tidak ditemukan di database."
+                },
+                "details": {},
+                "instance": {
+                    "type": "string",
+                    "example": "/go-fiber-mongo/alumni/6718f0c2a1b2c3d4e5f60718"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "0b8f5c1e-3a52-4c55-9a53-0d7c1f0f6e21"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Not Found"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "about:blank"
+                }
+            }
+        },
+        "model.File": {
+            "type": "object",
+            "properties": {
+                "alumni_info": {
+                    "$ref": "#/definitions/model.AlumniInfo"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "file_path": {
+                    "type": "string"
+                },
+                "file_size": {
+                    "type": "integer"
+                },
+                "file_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "original_name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "go-fiber_app_model_mongo.CreatePekerjaanAlumniRequest": {
+        "model.FileResponse": {
             "type": "object",
-            "required": [
-                "alumni_info",
-                "bidang_industri",
-                "lokasi_kerja",
-                "nama_perusahaan",
-                "posisi_jabatan",
-                "status_pekerjaan",
-                "tanggal_mulai_kerja"
-            ],
             "properties": {
                 "alumni_info": {
                     "$ref": "#/definitions/model.AlumniInfo"
                 },
-                "bidang_industri": {
+                "category": {
                     "type": "string"
                 },
-                "deskripsi_pekerjaan": {
+                "created_at": {
                     "type": "string"
                 },
-                "gaji_range": {
+                "file_name": {
                     "type": "string"
                 },
-                "lokasi_kerja": {
+                "file_path": {
                     "type": "string"
                 },
-                "nama_perusahaan": {
-                    "type": "string"
+                "file_size": {
+                    "type": "integer"
                 },
-                "posisi_jabatan": {
+                "file_type": {
                     "type": "string"
                 },
-                "status_pekerjaan": {
-                    "type": "string",
-                    "enum": [
-                        "aktif",
-                        "selesai",
-                        "resigned"
-                    ]
+                "id": {
+                    "type": "string"
                 },
-                "tanggal_mulai_kerja": {
+                "original_name": {
                     "type": "string"
                 },
-                "tanggal_selesai_kerja": {
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "go-fiber_app_model_mongo.GetProfileResponse": {
+        "model.SuccessResponse": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "object",
-                    "properties": {
-                        "alumni_id": {
-                            "type": "string"
-                        },
-                        "email": {
-                            "type": "string"
-                        },
-                        "role": {
-                            "type": "string"
-                        }
-                    }
-                },
+                "data": {},
                 "message": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "Operasi berhasil"
                 },
                 "success": {
-                    "type": "boolean"
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "go-fiber_app_model_mongo.LoginRequest": {
+        "outbox.Event": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
+                "action": {
                     "type": "string"
                 },
-                "password": {
+                "actor_id": {
                     "type": "string"
-                }
-            }
-        },
-        "go-fiber_app_model_mongo.LoginResponse": {
-            "type": "object",
-            "properties": {
-                "alumni": {
-                    "$ref": "#/definitions/go-fiber_app_model_mongo.Alumni"
                 },
-                "token": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/audit.Change"
+                    }
+                },
+                "data": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "id": {
+                    "type": "string"
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "resource": {
+                    "type": "string"
+                },
+                "resource_id": {
+                    "type": "string"
+                },
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "go-fiber_app_model_mongo.PekerjaanAlumni": {
+        "queue.Job": {
             "type": "object",
             "properties": {
-                "alumni_info": {
-                    "$ref": "#/definitions/model.AlumniInfo"
-                },
-                "bidang_industri": {
-                    "type": "string"
+                "attempts": {
+                    "type": "integer"
                 },
                 "created_at": {
                     "type": "string"
                 },
-                "deskripsi_pekerjaan": {
-                    "type": "string"
-                },
-                "gaji_range": {
+                "finished_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "lokasi_kerja": {
+                "last_error": {
                     "type": "string"
                 },
-                "nama_perusahaan": {
+                "locked_until": {
                     "type": "string"
                 },
-                "posisi_jabatan": {
+                "max_attempts": {
+                    "type": "integer"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "run_at": {
                     "type": "string"
                 },
-                "status_pekerjaan": {
+                "started_at": {
                     "type": "string"
                 },
-                "tanggal_mulai_kerja": {
+                "status": {
                     "type": "string"
                 },
-                "tanggal_selesai_kerja": {
+                "type": {
                     "type": "string"
                 },
-                "updated_at": {
+                "unique_key": {
                     "type": "string"
                 }
             }
         },
-        "go-fiber_app_model_mongo.UpdateAlumniRequest": {
+        "queue.ScheduleInfo": {
             "type": "object",
-            "required": [
-                "angkatan",
-                "email",
-                "jurusan",
-                "nama",
-                "role",
-                "tahun_lulus"
-            ],
             "properties": {
-                "alamat": {
+                "name": {
                     "type": "string"
                 },
-                "angkatan": {
-                    "type": "integer"
-                },
-                "email": {
+                "next_run_at": {
                     "type": "string"
                 },
-                "jurusan": {
+                "spec": {
                     "type": "string"
                 },
-                "nama": {
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "webhook.Attempt": {
+            "type": "object",
+            "properties": {
+                "at": {
                     "type": "string"
                 },
-                "no_telepon": {
+                "attempt": {
+                    "type": "integer"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "error": {
                     "type": "string"
                 },
-                "role": {
-                    "type": "string",
-                    "enum": [
-                        "admin",
-                        "user"
-                    ]
+                "response": {
+                    "type": "string"
                 },
-                "tahun_lulus": {
+                "status_code": {
                     "type": "integer"
                 }
             }
         },
-        "go-fiber_app_model_mongo.UpdatePekerjaanAlumniRequest": {
+        "webhook.Delivery": {
             "type": "object",
-            "required": [
-                "bidang_industri",
-                "lokasi_kerja",
-                "nama_perusahaan",
-                "posisi_jabatan",
-                "status_pekerjaan",
-                "tanggal_mulai_kerja"
-            ],
             "properties": {
-                "bidang_industri": {
-                    "type": "string"
+                "attempts": {
+                    "type": "integer"
                 },
-                "deskripsi_pekerjaan": {
+                "created_at": {
                     "type": "string"
                 },
-                "gaji_range": {
+                "delivered_at": {
                     "type": "string"
                 },
-                "lokasi_kerja": {
-                    "type": "string"
+                "event": {
+                    "$ref": "#/definitions/outbox.Event"
                 },
-                "nama_perusahaan": {
+                "id": {
                     "type": "string"
                 },
-                "posisi_jabatan": {
+                "last_error": {
                     "type": "string"
                 },
-                "status_pekerjaan": {
-                    "type": "string",
-                    "enum": [
-                        "aktif",
-                        "selesai",
-                        "resigned"
-                    ]
+                "last_status_code": {
+                    "type": "integer"
                 },
-                "tanggal_mulai_kerja": {
+                "log": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/webhook.Attempt"
+                    }
+                },
+                "next_attempt_at": {
                     "type": "string"
                 },
-                "tanggal_selesai_kerja": {
+                "status": {
+                    "type": "string"
+                },
+                "subscription_id": {
                     "type": "string"
                 }
             }
         },
-        "model.AlumniInfo": {
+        "webhook.Subscription": {
             "type": "object",
             "properties": {
-                "alumni_id": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "email": {
+                "description": {
                     "type": "string"
                 },
-                "nama": {
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
                     "type": "string"
                 },
-                "nim": {
+                "secret": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "model.ErrorResponse": {
+        "webhook.SubscriptionRequest": {
             "type": "object",
+            "required": [
+                "event_types",
+                "url"
+            ],
             "properties": {
-                "message": {
+                "active": {
+                    "type": "boolean"
+                },
+                "description": {
                     "type": "string",
-                    "example": "Error message"
+                    "maxLength": 255
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": false
+                "event_types": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 16
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048
                 }
             }
         },
-        "model.FileResponse": {
+        "worker.ExportJob": {
             "type": "object",
             "properties": {
-                "alumni_info": {
-                    "$ref": "#/definitions/model.AlumniInfo"
+                "created_at": {
+                    "type": "string"
                 },
-                "category": {
+                "created_by": {
                     "type": "string"
                 },
-                "created_at": {
+                "error": {
                     "type": "string"
                 },
                 "file_name": {
                     "type": "string"
                 },
-                "file_path": {
+                "finished_at": {
                     "type": "string"
                 },
-                "file_size": {
-                    "type": "integer"
-                },
-                "file_type": {
+                "format": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "original_name": {
+                "resource": {
                     "type": "string"
                 },
-                "updated_at": {
+                "status": {
                     "type": "string"
                 }
             }
-        },
-        "model.SuccessResponse": {
-            "type": "object",
-            "properties": {
-                "data": {},
-                "message": {
-                    "type": "string",
-                    "example": "Operasi berhasil"
-                },
-                "success": {
-                    "type": "boolean",
-                    "example": true
-                }
-            }
         }
     },
     "securityDefinitions": {
@@ -1799,6 +6605,26 @@ const docTemplate = `{
         {
             "description": "Operasi upload dan manajemen file",
             "name": "4. Files"
+        },
+        {
+            "description": "Statistik tracer study keterserapan kerja alumni (Admin only)",
+            "name": "5. Analytics"
+        },
+        {
+            "description": "Export data ke CSV, XLSX, dan laporan PDF (Admin only)",
+            "name": "6. Export"
+        },
+        {
+            "description": "Riwayat perubahan data untuk admin",
+            "name": "7. Audit"
+        },
+        {
+            "description": "Subscription webhook untuk domain event, log delivery, dan dead-letter (Admin only)",
+            "name": "8. Webhooks"
+        },
+        {
+            "description": "Background job, jadwal cron, retry, dan pembatalan (Admin only)",
+            "name": "9. Jobs"
         }
     ]
 }`
@@ -1813,6 +6639,8 @@ var SwaggerInfo = &swag.Spec{
 	Description:      "API untuk mengelola data alumni, pekerjaan, dan file menggunakan MongoDB dengan JWT Authentication",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
 }
 
 func init() {
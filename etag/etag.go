@@ -0,0 +1,62 @@
+// Package etag menerapkan optimistic concurrency control berbasis kolom
+// version pada alumni dan pekerjaan: ETag pada GET, If-None-Match untuk 304,
+// dan If-Match pada PUT/DELETE.
+package etag
+
+import (
+	"strconv"
+	"strings"
+
+	"go-fiber/apperror"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Format mengubah version menjadi ETag, misalnya 3 menjadi "3".
+func Format(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// Set menulis header ETag untuk version.
+func Set(c *fiber.Ctx, version int) {
+	c.Set(fiber.HeaderETag, Format(version))
+}
+
+// NotModified menulis header ETag lalu mengembalikan true jika If-None-Match
+// cocok dengan version, sehingga handler cukup membalas 304.
+func NotModified(c *fiber.Ctx, version int) bool {
+	Set(c, version)
+	header := c.Get(fiber.HeaderIfNoneMatch)
+	return header != "" && matches(header, version)
+}
+
+// CheckIfMatch memastikan If-Match cocok dengan version data saat ini.
+// Tanpa header, request diterima kecuali required (app.require_if_match)
+// bernilai true; "*" cocok dengan version apa pun karena datanya sudah pasti
+// ada.
+func CheckIfMatch(c *fiber.Ctx, version int, required bool) error {
+	header := c.Get(fiber.HeaderIfMatch)
+	if header == "" {
+		if required {
+			return apperror.PreconditionRequired()
+		}
+		return nil
+	}
+	if !matches(header, version) {
+		return apperror.VersionConflict()
+	}
+	return nil
+}
+
+// matches membandingkan daftar ETag dipisah koma dengan version. Prefix W/
+// diabaikan karena proxy yang mengompresi response bisa melemahkan ETag.
+func matches(header string, version int) bool {
+	want := Format(version)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == want {
+			return true
+		}
+	}
+	return false
+}
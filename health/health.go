@@ -0,0 +1,175 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go-fiber/i18n"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Check memeriksa satu dependency. Check harus berhenti saat ctx habis.
+type Check func(ctx context.Context) error
+
+// CheckResult adalah hasil satu Check pada response /readyz. Error hanya
+// dicatat di log karena pesan driver bisa berisi host, port, dan nama
+// database, sedangkan /readyz bisa diakses tanpa login.
+type CheckResult struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"-"`
+}
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Checker menyediakan endpoint liveness dan readiness. Setiap Check dijalankan
+// paralel dengan batas waktu timeout.
+type Checker struct {
+	timeout      time.Duration
+	checks       []namedCheck
+	shuttingDown atomic.Bool
+}
+
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout}
+}
+
+// Register menambahkan dependency yang diperiksa oleh /readyz.
+func (h *Checker) Register(name string, check Check) {
+	h.checks = append(h.checks, namedCheck{name: name, check: check})
+}
+
+// SetShuttingDown membuat /readyz selalu gagal sehingga orchestrator berhenti
+// mengirim traffic sebelum server dimatikan.
+func (h *Checker) SetShuttingDown() {
+	h.shuttingDown.Store(true)
+}
+
+// Run menjalankan seluruh Check dan mengembalikan hasil per dependency.
+func (h *Checker) Run(ctx context.Context) (map[string]CheckResult, bool) {
+	results := make(map[string]CheckResult, len(h.checks))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, item := range h.checks {
+		wg.Add(1)
+		go func(item namedCheck) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, h.timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := item.check(checkCtx)
+			result := CheckResult{
+				Status:    StatusUp,
+				LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+			}
+			if err != nil {
+				result.Status = StatusDown
+				result.Error = err.Error()
+			}
+
+			mu.Lock()
+			results[item.name] = result
+			mu.Unlock()
+		}(item)
+	}
+	wg.Wait()
+
+	ready := true
+	for _, result := range results {
+		if result.Status != StatusUp {
+			ready = false
+		}
+	}
+	return results, ready
+}
+
+// Liveness hanya menandakan proses masih berjalan dan tidak memeriksa
+// dependency, agar gangguan database tidak membuat container di-restart.
+func (h *Checker) Liveness(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "health.live"),
+		"data":    fiber.Map{"status": "alive"},
+	})
+}
+
+// Readiness mengembalikan 200 jika semua dependency siap, atau 503 beserta
+// status dependency yang gagal. Selama shutdown selalu 503.
+func (h *Checker) Readiness(c *fiber.Ctx) error {
+	if h.shuttingDown.Load() {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"success": false,
+			"message": i18n.Msg(c, "health.shutting_down"),
+			"data":    fiber.Map{"status": "shutting_down"},
+		})
+	}
+
+	results, ready := h.Run(c.UserContext())
+	if !ready {
+		for name, result := range results {
+			if result.Status != StatusUp {
+				slog.WarnContext(c.UserContext(), "Readiness check gagal", "check", name, "error", result.Error)
+			}
+		}
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"success": false,
+			"message": i18n.Msg(c, "health.not_ready"),
+			"data":    fiber.Map{"status": "not_ready", "checks": results},
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "health.ready"),
+		"data":    fiber.Map{"status": "ready", "checks": results},
+	})
+}
+
+// PingPostgres memeriksa koneksi ke PostgreSQL.
+func PingPostgres(db *sql.DB) Check {
+	return func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	}
+}
+
+// PingMongo memeriksa koneksi ke MongoDB.
+func PingMongo(client *mongo.Client) Check {
+	return func(ctx context.Context) error {
+		return client.Ping(ctx, nil)
+	}
+}
+
+// WritableDir memastikan file bisa dibuat di dir, misalnya folder upload.
+// Folder dibuat jika belum ada, sama seperti saat upload pertama.
+func WritableDir(dir string) Check {
+	return func(ctx context.Context) error {
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			return err
+		}
+		file, err := os.CreateTemp(dir, ".readyz-*")
+		if err != nil {
+			return err
+		}
+		name := file.Name()
+		file.Close()
+		return os.Remove(name)
+	}
+}
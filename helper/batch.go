@@ -0,0 +1,165 @@
+package helper
+
+import (
+	"encoding/json"
+	"errors"
+
+	"go-fiber/apperror"
+	"go-fiber/patch"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// BatchMaxItems membatasi jumlah data dalam satu request batch, baik dari
+// daftar ids maupun hasil filter.
+const BatchMaxItems = 100
+
+// Status hasil per item batch.
+const (
+	BatchUpdated     = "updated"
+	BatchUnchanged   = "unchanged"
+	BatchDeleted     = "deleted"
+	BatchSoftDeleted = "soft_deleted"
+	BatchFailed      = "failed"
+	// BatchSkipped menandai item yang valid tetapi tidak disimpan karena
+	// item lain gagal dan seluruh batch dibatalkan.
+	BatchSkipped = "skipped"
+)
+
+// BatchItemResult adalah hasil satu item batch. ID bertipe int pada stack
+// PostgreSQL dan string ObjectID pada stack MongoDB.
+type BatchItemResult struct {
+	ID      interface{} `json:"id"`
+	Status  string      `json:"status"`
+	Version int         `json:"version,omitempty"`
+	Code    string      `json:"code,omitempty"`
+	Message string      `json:"message,omitempty"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+// BatchResult adalah laporan per item dari request batch. Batch dijalankan
+// dalam satu transaksi: jika satu item gagal tidak ada yang disimpan.
+type BatchResult struct {
+	Total     int               `json:"total"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Items     []BatchItemResult `json:"items"`
+}
+
+// NewBatchResult menyiapkan laporan untuk ids dengan urutan yang sama.
+func NewBatchResult[T any](ids []T) *BatchResult {
+	result := &BatchResult{Total: len(ids), Items: make([]BatchItemResult, len(ids))}
+	for i, id := range ids {
+		result.Items[i].ID = id
+	}
+	return result
+}
+
+// Succeed mencatat item ke-i berhasil dengan status dan version barunya.
+func (r *BatchResult) Succeed(i int, status string, version int) {
+	r.Items[i].Status = status
+	r.Items[i].Version = version
+	r.Succeeded++
+}
+
+// Fail mencatat err sebagai kegagalan item ke-i dengan pesan dalam bahasa
+// lang. Hanya kesalahan client (status di bawah 500) yang dicatat per item;
+// untuk kesalahan lain Fail mengembalikan false dan pemanggil sebaiknya
+// membatalkan seluruh batch dengan err.
+func (r *BatchResult) Fail(i int, lang string, err error) bool {
+	var appErr *apperror.Error
+	if !errors.As(err, &appErr) || appErr.Status >= 500 {
+		return false
+	}
+	r.Items[i].Status = BatchFailed
+	r.Items[i].Code = appErr.Code
+	r.Items[i].Message = appErr.Message(lang)
+	r.Items[i].Details = appErr.Details
+	r.Failed++
+	return true
+}
+
+// Err mengembalikan error 422 batch_failed berisi laporan jika ada item yang
+// gagal, atau nil. Item lain ditandai skipped karena tidak ada yang disimpan.
+func (r *BatchResult) Err() error {
+	if r.Failed == 0 {
+		return nil
+	}
+	for i := range r.Items {
+		if r.Items[i].Status != BatchFailed {
+			r.Items[i].Status = BatchSkipped
+			r.Items[i].Version = 0
+		}
+	}
+	r.Succeeded = 0
+	return apperror.Unprocessable("batch.failed", r.Failed, r.Total).WithCode("batch_failed").WithDetails(r)
+}
+
+// UniqueIDs menghapus id ganda dengan tetap mempertahankan urutan.
+func UniqueIDs[T comparable](ids []T) []T {
+	seen := make(map[T]bool, len(ids))
+	unique := make([]T, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// BatchIDs menentukan id yang diproses batch: ids dari request tanpa
+// duplikat, atau hasil find jika request memakai filter. find diminta satu
+// data lebih dari batas agar filter yang terlalu luas bisa ditolak.
+func BatchIDs[T comparable](ids []T, hasFilter bool, find func(limit int) ([]T, error)) ([]T, error) {
+	if err := checkBatchSelection(len(ids), hasFilter); err != nil {
+		return nil, err
+	}
+	if hasFilter {
+		found, err := find(BatchMaxItems + 1)
+		if err != nil {
+			return nil, apperror.Wrap(err, "batch.fetch_failed")
+		}
+		ids = found
+	}
+	ids = UniqueIDs(ids)
+	if err := checkBatchSize(len(ids)); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// checkBatchSelection memastikan request batch memilih data dengan tepat
+// satu cara, daftar ids atau filter, agar filter kosong tidak pernah
+// diartikan sebagai semua data.
+func checkBatchSelection(idCount int, hasFilter bool) error {
+	if (idCount > 0) == hasFilter {
+		return apperror.Validation("batch.selection_required").WithCode("batch_selection_required")
+	}
+	return nil
+}
+
+// checkBatchSize menolak batch yang berisi lebih dari BatchMaxItems data.
+func checkBatchSize(count int) error {
+	if count > BatchMaxItems {
+		return apperror.New(fiber.StatusBadRequest, "batch_too_large", "batch.too_large", BatchMaxItems)
+	}
+	return nil
+}
+
+// CheckBatchChanges memeriksa changes sebagai merge patch terhadap request
+// update kosong bertipe sama dengan out, sebelum data apa pun dibaca. Field
+// yang tidak dikenal dan changes yang tidak mengubah apa pun ditolak.
+func CheckBatchChanges(changes json.RawMessage, empty, out interface{}) error {
+	if len(changes) == 0 {
+		return apperror.Validation("batch.changes_required").WithCode("batch_changes_required")
+	}
+	touched, err := patch.Merge(empty, changes, out)
+	if err != nil {
+		return err
+	}
+	if len(touched) == 0 {
+		return apperror.Validation("batch.changes_required").WithCode("batch_changes_required")
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package helper
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+var ExportContentTypes = map[string]string{
+	"csv":  "text/csv; charset=utf-8",
+	"xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	"pdf":  "application/pdf",
+}
+
+// TabularWriter menulis data baris per baris sehingga export tidak perlu
+// menampung seluruh data di memori.
+type TabularWriter interface {
+	WriteRow(values []string) error
+	Close() error
+}
+
+// NewTabularWriter membuat writer CSV atau XLSX yang menulis ke w. Untuk
+// XLSX, baris ditulis lewat stream writer excelize dan isi file baru dikirim
+// ke w saat Close.
+func NewTabularWriter(format string, w io.Writer) (TabularWriter, error) {
+	switch format {
+	case "csv":
+		return &csvTabularWriter{writer: csv.NewWriter(w)}, nil
+	case "xlsx":
+		file := excelize.NewFile()
+		stream, err := file.NewStreamWriter("Sheet1")
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		return &xlsxTabularWriter{file: file, stream: stream, out: w}, nil
+	default:
+		return nil, fmt.Errorf("format export %s tidak didukung", format)
+	}
+}
+
+type csvTabularWriter struct {
+	writer *csv.Writer
+}
+
+func (t *csvTabularWriter) WriteRow(values []string) error {
+	return t.writer.Write(values)
+}
+
+func (t *csvTabularWriter) Close() error {
+	t.writer.Flush()
+	return t.writer.Error()
+}
+
+type xlsxTabularWriter struct {
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	out    io.Writer
+	row    int
+}
+
+func (t *xlsxTabularWriter) WriteRow(values []string) error {
+	t.row++
+	cell, err := excelize.CoordinatesToCellName(1, t.row)
+	if err != nil {
+		return err
+	}
+
+	row := make([]interface{}, len(values))
+	for i, value := range values {
+		row[i] = value
+	}
+	return t.stream.SetRow(cell, row)
+}
+
+func (t *xlsxTabularWriter) Close() error {
+	defer t.file.Close()
+	if err := t.stream.Flush(); err != nil {
+		return err
+	}
+	_, err := t.file.WriteTo(t.out)
+	return err
+}
+
+// ExportFileName membentuk nama file unduhan, misalnya alumni-20250115.csv.
+func ExportFileName(resource, format string) string {
+	return fmt.Sprintf("%s-%s.%s", resource, time.Now().Format("20060102-150405"), format)
+}
+
+// JSONColumns mengembalikan nama key JSON dari struct v (atau elemen slice v)
+// sesuai urutan field, termasuk field dari struct yang di-embed.
+func JSONColumns(v interface{}) []string {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var columns []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if field.Anonymous && tag == "" {
+			columns = append(columns, JSONColumns(reflect.New(field.Type).Elem().Interface())...)
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		columns = append(columns, name)
+	}
+	return columns
+}
+
+// FilterColumns menyisakan kolom yang ada di fields (jika diisi) dan di
+// whitelist, dengan urutan mengikuti columns.
+func FilterColumns(columns, fields []string, whitelist map[string]bool) []string {
+	requested := map[string]bool{}
+	for _, field := range fields {
+		requested[field] = true
+	}
+
+	var result []string
+	for _, column := range columns {
+		if !whitelist[column] || (len(fields) > 0 && !requested[column]) {
+			continue
+		}
+		result = append(result, column)
+	}
+	return result
+}
+
+// RowValues mengubah v menjadi nilai teks untuk setiap kolom. Nilai kosong
+// menjadi string kosong, nilai bertingkat ditulis sebagai JSON, dan teks
+// yang bisa dibaca sebagai formula di-escape dengan EscapeFormula.
+func RowValues(v interface{}, columns []string) ([]string, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, errors.New("data export harus berupa object")
+	}
+
+	values := make([]string, len(columns))
+	for i, column := range columns {
+		switch value := data[column].(type) {
+		case nil:
+			values[i] = ""
+		case string:
+			values[i] = EscapeFormula(value)
+		case float64:
+			values[i] = strconv.FormatFloat(value, 'f', -1, 64)
+		case bool:
+			values[i] = strconv.FormatBool(value)
+		default:
+			nested, err := json.Marshal(value)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = string(nested)
+		}
+	}
+	return values, nil
+}
+
+// EscapeFormula menambahkan ' di depan teks yang diawali =, +, -, @, tab,
+// atau carriage return agar Excel dan LibreOffice menampilkannya sebagai
+// teks, bukan menjalankannya sebagai formula (CSV injection).
+func EscapeFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// WriteTable menulis header dan seluruh elemen slice items dalam satu kali
+// jalan. Dipakai untuk hasil analytics yang ukurannya kecil.
+func WriteTable(format string, w io.Writer, items interface{}) error {
+	writer, err := NewTabularWriter(format, w)
+	if err != nil {
+		return err
+	}
+
+	columns := JSONColumns(items)
+	if err := writer.WriteRow(columns); err != nil {
+		return err
+	}
+
+	list := reflect.ValueOf(items)
+	for i := 0; i < list.Len(); i++ {
+		values, err := RowValues(list.Index(i).Interface(), columns)
+		if err != nil {
+			return err
+		}
+		if err := writer.WriteRow(values); err != nil {
+			return err
+		}
+	}
+
+	return writer.Close()
+}
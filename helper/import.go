@@ -0,0 +1,242 @@
+package helper
+
+import (
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math/big"
+	"mime/multipart"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-fiber/i18n"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ImportMaxFileSize adalah ukuran maksimal file import (5 MB).
+const ImportMaxFileSize = 5 * 1024 * 1024
+
+// ImportMaxRows membatasi jumlah baris data dalam satu file import.
+const ImportMaxRows = 5000
+
+// ImportDateLayout sama dengan format tanggal pada service pekerjaan.
+const ImportDateLayout = "2006-01-02"
+
+// ImportRowError menjelaskan kesalahan pada satu baris file import. Row
+// mengikuti nomor baris di file, dengan header sebagai baris 1.
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// ImportRecord adalah satu baris data yang kolomnya sudah dipetakan ke nama
+// field tujuan.
+type ImportRecord struct {
+	Row    int
+	Values map[string]string
+}
+
+// Get mengembalikan nilai field yang sudah di-trim, atau string kosong.
+func (r ImportRecord) Get(field string) string {
+	return strings.TrimSpace(r.Values[field])
+}
+
+// Optional mengembalikan pointer ke nilai field, atau nil jika kosong.
+func (r ImportRecord) Optional(field string) *string {
+	value := r.Get(field)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// Int membaca field sebagai angka bulat. Nilai kosong menghasilkan nil.
+func (r ImportRecord) Int(field string) (*int64, error) {
+	value := r.Get(field)
+	if value == "" {
+		return nil, nil
+	}
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, i18n.NewError("import.not_a_number", field)
+	}
+	return &number, nil
+}
+
+// Date membaca field dengan format ImportDateLayout. Nilai kosong
+// menghasilkan nil.
+func (r ImportRecord) Date(field string) (*time.Time, error) {
+	value := r.Get(field)
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse(ImportDateLayout, value)
+	if err != nil {
+		return nil, i18n.NewError("validation.date", field)
+	}
+	return &date, nil
+}
+
+// ImportFormat menentukan format file dari ekstensinya.
+func ImportFormat(filename string) (string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return "csv", nil
+	case ".xlsx":
+		return "xlsx", nil
+	default:
+		return "", i18n.NewError("import.unsupported_file")
+	}
+}
+
+// ReadTable membaca seluruh baris CSV atau sheet pertama XLSX.
+func ReadTable(format string, r io.Reader) ([][]string, error) {
+	switch format {
+	case "csv":
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		return reader.ReadAll()
+	case "xlsx":
+		file, err := excelize.OpenReader(r)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		sheets := file.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, i18n.NewError("import.no_sheet")
+		}
+		return file.GetRows(sheets[0])
+	default:
+		return nil, i18n.NewError("import.unsupported_format", format)
+	}
+}
+
+// ParseColumnMapping membaca mapping kolom berbentuk JSON object
+// {"field_tujuan": "Header di file"}. String kosong menghasilkan mapping kosong.
+func ParseColumnMapping(raw string) (map[string]string, error) {
+	mapping := map[string]string{}
+	if strings.TrimSpace(raw) == "" {
+		return mapping, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &mapping); err != nil {
+		return nil, i18n.NewError("import.invalid_mapping")
+	}
+	return mapping, nil
+}
+
+// MapRecords mengubah tabel menjadi ImportRecord. Baris pertama dianggap
+// header. Field tanpa mapping dicocokkan dengan header yang bernama sama
+// (tidak peka huruf besar/kecil). Baris yang seluruhnya kosong dilewati.
+func MapRecords(table [][]string, fields []string, mapping map[string]string) ([]ImportRecord, error) {
+	if len(table) == 0 {
+		return nil, i18n.NewError("import.no_header")
+	}
+	if len(table)-1 > ImportMaxRows {
+		return nil, i18n.NewError("import.too_many_rows", ImportMaxRows)
+	}
+
+	allowed := map[string]bool{}
+	for _, field := range fields {
+		allowed[field] = true
+	}
+	for field := range mapping {
+		if !allowed[field] {
+			return nil, i18n.NewError("import.unknown_mapping_field", field)
+		}
+	}
+
+	headers := map[string]int{}
+	for i, header := range table[0] {
+		headers[strings.ToLower(strings.TrimSpace(header))] = i
+	}
+
+	columns := map[string]int{}
+	for _, field := range fields {
+		header, mapped := mapping[field]
+		if !mapped {
+			header = field
+		}
+		index, ok := headers[strings.ToLower(strings.TrimSpace(header))]
+		if !ok {
+			if mapped {
+				return nil, i18n.NewError("import.column_not_found", header, field)
+			}
+			continue
+		}
+		columns[field] = index
+	}
+
+	var records []ImportRecord
+	for i, row := range table[1:] {
+		record := ImportRecord{Row: i + 2, Values: map[string]string{}}
+		empty := true
+		for field, index := range columns {
+			if index < len(row) {
+				record.Values[field] = row[index]
+				if strings.TrimSpace(row[index]) != "" {
+					empty = false
+				}
+			}
+		}
+		if empty {
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+const passwordAlphabet = "abcdefghjkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// GeneratePassword membuat password acak untuk akun hasil import. Huruf dan
+// angka yang mudah tertukar (0/O, 1/l/I) tidak dipakai.
+func GeneratePassword(length int) (string, error) {
+	password := make([]byte, length)
+	for i := range password {
+		index, err := rand.Int(rand.Reader, big.NewInt(int64(len(passwordAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		password[i] = passwordAlphabet[index.Int64()]
+	}
+	return string(password), nil
+}
+
+// ReadImportFile membuka file upload, membaca tabelnya, lalu memetakan kolom
+// sesuai rawMapping ke daftar fields.
+func ReadImportFile(fileHeader *multipart.FileHeader, rawMapping string, fields []string) ([]ImportRecord, error) {
+	if fileHeader.Size > ImportMaxFileSize {
+		return nil, i18n.NewError("import.file_too_large", ImportMaxFileSize/(1024*1024))
+	}
+
+	format, err := ImportFormat(fileHeader.Filename)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping, err := ParseColumnMapping(rawMapping)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	table, err := ReadTable(format, file)
+	if err != nil {
+		return nil, i18n.NewError("import.unreadable", err)
+	}
+
+	return MapRecords(table, fields, mapping)
+}
@@ -0,0 +1,95 @@
+package helper
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// ReportSection adalah satu tabel pada laporan PDF.
+type ReportSection struct {
+	Title   string
+	Note    string
+	Columns []string
+	Rows    [][]string
+}
+
+// NewReportSection membentuk ReportSection dari slice struct, dengan kolom
+// mengikuti key JSON seperti export CSV/XLSX.
+func NewReportSection(title, note string, items interface{}) (ReportSection, error) {
+	section := ReportSection{Title: title, Note: note, Columns: JSONColumns(items)}
+
+	list := reflect.ValueOf(items)
+	for i := 0; i < list.Len(); i++ {
+		values, err := RowValues(list.Index(i).Interface(), section.Columns)
+		if err != nil {
+			return section, err
+		}
+		section.Rows = append(section.Rows, values)
+	}
+	return section, nil
+}
+
+// WritePDFReport menulis laporan PDF A4 dengan judul, subjudul, dan tabel
+// untuk setiap section. Halaman baru dibuat otomatis saat tabel panjang.
+func WritePDFReport(w io.Writer, title, subtitle string, sections []ReportSection) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetAutoPageBreak(true, 15)
+	translate := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.CellFormat(0, 6, fmt.Sprintf("Dibuat %s - Halaman %d", time.Now().Format("02 Jan 2006 15:04"), pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.CellFormat(0, 10, translate(title), "", 1, "L", false, 0, "")
+	if subtitle != "" {
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.MultiCell(0, 5, translate(subtitle), "", "L", false)
+	}
+	pdf.Ln(4)
+
+	pageWidth, _ := pdf.GetPageSize()
+	left, _, right, _ := pdf.GetMargins()
+	tableWidth := pageWidth - left - right
+
+	for _, section := range sections {
+		pdf.SetFont("Helvetica", "B", 12)
+		pdf.CellFormat(0, 8, translate(section.Title), "", 1, "L", false, 0, "")
+		if section.Note != "" {
+			pdf.SetFont("Helvetica", "I", 8)
+			pdf.MultiCell(0, 4, translate(section.Note), "", "L", false)
+		}
+
+		if len(section.Columns) == 0 || len(section.Rows) == 0 {
+			pdf.SetFont("Helvetica", "", 9)
+			pdf.CellFormat(0, 6, "Tidak ada data.", "", 1, "L", false, 0, "")
+			pdf.Ln(4)
+			continue
+		}
+
+		columnWidth := tableWidth / float64(len(section.Columns))
+		pdf.SetFont("Helvetica", "B", 8)
+		pdf.SetFillColor(230, 230, 230)
+		for _, column := range section.Columns {
+			pdf.CellFormat(columnWidth, 7, translate(column), "1", 0, "C", true, 0, "")
+		}
+		pdf.Ln(-1)
+
+		pdf.SetFont("Helvetica", "", 8)
+		for _, row := range section.Rows {
+			for _, value := range row {
+				pdf.CellFormat(columnWidth, 6, translate(value), "1", 0, "L", false, 0, "")
+			}
+			pdf.Ln(-1)
+		}
+		pdf.Ln(6)
+	}
+
+	return pdf.Output(w)
+}
@@ -0,0 +1,214 @@
+package helper
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go-fiber/i18n"
+)
+
+const (
+	SalaryPeriodBulanan = "bulanan"
+	SalaryPeriodTahunan = "tahunan"
+	DefaultCurrency     = "IDR"
+)
+
+var SalaryCurrencies = map[string]bool{"IDR": true, "USD": true, "SGD": true, "EUR": true}
+
+var SalaryPeriods = map[string]bool{SalaryPeriodBulanan: true, SalaryPeriodTahunan: true}
+
+var (
+	// salaryNumberPattern menangkap angka beserta satuan yang langsung
+	// mengikutinya, misalnya "1,2 juta", "500rb", atau "7k".
+	salaryNumberPattern    = regexp.MustCompile(`(\d+(?:[.,]\d+)*)\s*(miliar|milyar|juta|jt|ribu|rb|k\b)?`)
+	salaryThousandsPattern = regexp.MustCompile(`^\d{1,3}(?:[.,]\d{3})+$`)
+)
+
+// Salary adalah bentuk terstruktur dari gaji pekerjaan. Nilai Min dan Max
+// dalam satuan penuh mata uang (bukan juta/ribu).
+type Salary struct {
+	Min      int64
+	Max      int64
+	Currency string
+	Period   string
+}
+
+// ParseGajiRange mengubah gaji_range teks bebas seperti "5-7 juta",
+// "Rp 5.000.000 - 7.000.000", "7,5jt", "Rp 800.000 - 1,2 juta" atau
+// "USD 1,500 - 2,000 per tahun" menjadi Salary. Satuan (ribu, juta, miliar,
+// k) hanya berlaku untuk angka di depannya; angka tanpa satuan di bawah 1000
+// memakai satuan angka berikutnya seperti pada "5-7 juta". Nilai tunggal atau
+// terbuka ("10 juta+") menghasilkan Min sama dengan Max.
+func ParseGajiRange(value string) (*Salary, error) {
+	text := strings.ToLower(strings.TrimSpace(value))
+	if text == "" {
+		return nil, i18n.NewError("salary.range_empty")
+	}
+
+	matches := salaryNumberPattern.FindAllStringSubmatch(text, -1)
+	if len(matches) == 0 || len(matches) > 2 {
+		return nil, i18n.NewError("salary.range_unrecognized", value)
+	}
+
+	amounts := make([]int64, len(matches))
+	multiplier := 1.0
+	// Dibaca dari belakang agar angka tanpa satuan bisa memakai satuan
+	// angka sesudahnya.
+	for i := len(matches) - 1; i >= 0; i-- {
+		amount, err := parseSalaryNumber(matches[i][1])
+		if err != nil {
+			return nil, i18n.NewError("salary.range_unrecognized", value)
+		}
+		if unit := matches[i][2]; unit != "" {
+			multiplier = salaryMultiplier(unit)
+			amount *= multiplier
+		} else if amount < 1000 {
+			amount *= multiplier
+		}
+		amounts[i] = int64(amount)
+	}
+
+	salary := &Salary{
+		Min:      amounts[0],
+		Max:      amounts[len(amounts)-1],
+		Currency: salaryCurrency(text),
+		Period:   salaryPeriod(text),
+	}
+
+	if err := ValidateSalary(*salary); err != nil {
+		return nil, err
+	}
+	return salary, nil
+}
+
+// ValidateSalary memastikan nilai gaji positif, Min tidak melebihi Max, serta
+// mata uang dan periode dikenal.
+func ValidateSalary(salary Salary) error {
+	if salary.Min <= 0 || salary.Max <= 0 {
+		return i18n.NewError("salary.non_positive")
+	}
+	if salary.Min > salary.Max {
+		return i18n.NewError("salary.min_gt_max")
+	}
+	if !SalaryCurrencies[salary.Currency] {
+		return i18n.NewError("salary.invalid_currency")
+	}
+	if !SalaryPeriods[salary.Period] {
+		return i18n.NewError("salary.invalid_period")
+	}
+	return nil
+}
+
+// NormalizeSalary menentukan gaji terstruktur dari request. Field terstruktur
+// diutamakan; jika kosong, gaji_range diparse. Hasil nil berarti pekerjaan
+// tidak mencantumkan gaji.
+func NormalizeSalary(gajiRange *string, min, max *int64, currency, period *string) (*Salary, error) {
+	if min == nil && max == nil {
+		if gajiRange == nil || strings.TrimSpace(*gajiRange) == "" {
+			return nil, nil
+		}
+		return ParseGajiRange(*gajiRange)
+	}
+
+	if min == nil {
+		return nil, i18n.NewError("salary.min_required")
+	}
+
+	salary := Salary{Min: *min, Max: *min, Currency: DefaultCurrency, Period: SalaryPeriodBulanan}
+	if max != nil {
+		salary.Max = *max
+	}
+	if currency != nil && *currency != "" {
+		salary.Currency = strings.ToUpper(*currency)
+	}
+	if period != nil && *period != "" {
+		salary.Period = strings.ToLower(*period)
+	}
+
+	if err := ValidateSalary(salary); err != nil {
+		return nil, err
+	}
+	return &salary, nil
+}
+
+// MonthlyMidpoint mengembalikan titik tengah rentang gaji per bulan, dipakai
+// sebagai nilai tunggal pada statistik gaji.
+func (s Salary) MonthlyMidpoint() float64 {
+	midpoint := float64(s.Min+s.Max) / 2
+	if s.Period == SalaryPeriodTahunan {
+		return midpoint / 12
+	}
+	return midpoint
+}
+
+// String menghasilkan teks gaji_range yang konsisten, misalnya
+// "IDR 5.000.000 - 7.000.000 / bulan".
+func (s Salary) String() string {
+	unit := "bulan"
+	if s.Period == SalaryPeriodTahunan {
+		unit = "tahun"
+	}
+	if s.Min == s.Max {
+		return fmt.Sprintf("%s %s / %s", s.Currency, formatThousands(s.Min), unit)
+	}
+	return fmt.Sprintf("%s %s - %s / %s", s.Currency, formatThousands(s.Min), formatThousands(s.Max), unit)
+}
+
+func parseSalaryNumber(number string) (float64, error) {
+	if salaryThousandsPattern.MatchString(number) {
+		number = strings.NewReplacer(".", "", ",", "").Replace(number)
+	} else {
+		number = strings.ReplaceAll(number, ",", ".")
+	}
+	return strconv.ParseFloat(number, 64)
+}
+
+// salaryMultiplier mengembalikan pengali satuan hasil salaryNumberPattern.
+func salaryMultiplier(unit string) float64 {
+	switch unit {
+	case "miliar", "milyar":
+		return 1e9
+	case "juta", "jt":
+		return 1e6
+	case "ribu", "rb", "k":
+		return 1e3
+	default:
+		return 1
+	}
+}
+
+func salaryCurrency(text string) string {
+	switch {
+	case strings.Contains(text, "sgd") || strings.Contains(text, "s$"):
+		return "SGD"
+	case strings.Contains(text, "usd") || strings.Contains(text, "$"):
+		return "USD"
+	case strings.Contains(text, "eur") || strings.Contains(text, "€"):
+		return "EUR"
+	default:
+		return DefaultCurrency
+	}
+}
+
+func salaryPeriod(text string) string {
+	for _, keyword := range []string{"tahun", "/thn", "per year", "/year", "annual"} {
+		if strings.Contains(text, keyword) {
+			return SalaryPeriodTahunan
+		}
+	}
+	return SalaryPeriodBulanan
+}
+
+func formatThousands(value int64) string {
+	digits := strconv.FormatInt(value, 10)
+	var builder strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			builder.WriteByte('.')
+		}
+		builder.WriteRune(digit)
+	}
+	return builder.String()
+}
@@ -0,0 +1,38 @@
+package helper
+
+import (
+	"math"
+	"sort"
+)
+
+// Percentile menghitung persentil p (0-100) dengan interpolasi linear,
+// setara dengan percentile_cont di PostgreSQL. Slice values akan diurutkan.
+func Percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sort.Float64s(values)
+	if len(values) == 1 {
+		return values[0]
+	}
+
+	rank := p / 100 * float64(len(values)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if lower == upper {
+		return values[lower]
+	}
+
+	return values[lower] + (values[upper]-values[lower])*(rank-float64(lower))
+}
+
+// Median adalah Percentile ke-50.
+func Median(values []float64) float64 {
+	return Percentile(values, 50)
+}
+
+// Round2 membulatkan nilai ke dua angka di belakang koma.
+func Round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
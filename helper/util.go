@@ -1 +1,101 @@
 package helper
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// ParseListParam memecah query parameter berformat "a,b,c" menjadi slice
+// tanpa spasi dan tanpa elemen kosong maupun duplikat.
+func ParseListParam(value string) []string {
+	var result []string
+	seen := map[string]bool{}
+	for _, item := range strings.Split(value, ",") {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if item == "" || seen[item] {
+			continue
+		}
+		seen[item] = true
+		result = append(result, item)
+	}
+	return result
+}
+
+// InvalidItems mengembalikan elemen items yang tidak ada di whitelist.
+func InvalidItems(items []string, whitelist map[string]bool) []string {
+	var invalid []string
+	for _, item := range items {
+		if !whitelist[item] {
+			invalid = append(invalid, item)
+		}
+	}
+	return invalid
+}
+
+// SortedKeys mengembalikan key whitelist secara terurut, dipakai untuk pesan
+// error yang menyebutkan nilai yang diperbolehkan.
+func SortedKeys(whitelist map[string]bool) []string {
+	keys := make([]string, 0, len(whitelist))
+	for key := range whitelist {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// SelectFields mengubah v (struct atau slice of struct) menjadi bentuk JSON
+// generik dan hanya menyisakan key yang ada di fields. Jika fields kosong,
+// v dikembalikan apa adanya.
+func SelectFields(v interface{}, fields []string) (interface{}, error) {
+	if len(fields) == 0 {
+		return v, nil
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	keep := map[string]bool{}
+	for _, field := range fields {
+		keep[field] = true
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return nil, err
+	}
+
+	switch data := generic.(type) {
+	case map[string]interface{}:
+		return filterKeys(data, keep), nil
+	case []interface{}:
+		for i, item := range data {
+			if obj, ok := item.(map[string]interface{}); ok {
+				data[i] = filterKeys(obj, keep)
+			}
+		}
+		return data, nil
+	default:
+		return generic, nil
+	}
+}
+
+func filterKeys(obj map[string]interface{}, keep map[string]bool) map[string]interface{} {
+	for key := range obj {
+		if !keep[key] {
+			delete(obj, key)
+		}
+	}
+	return obj
+}
+
+var phoneSeparators = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "")
+
+// NormalizePhone membuang spasi, tanda hubung, dan kurung dari nomor telepon,
+// misalnya "+62 812-3456-7890" menjadi "+6281234567890". Bentuk ini yang
+// diperiksa rule phone dan disimpan, sehingga muat di kolom no_telepon.
+func NormalizePhone(phone string) string {
+	return phoneSeparators.Replace(phone)
+}
@@ -0,0 +1,157 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Bahasa yang didukung. Default dipakai jika Accept-Language kosong atau
+// tidak berisi bahasa yang dikenal.
+const (
+	Indonesian = "id"
+	English    = "en"
+	Default    = Indonesian
+)
+
+var catalogs = map[string]map[string]string{
+	Indonesian: messagesID,
+	English:    messagesEN,
+}
+
+// Languages mengembalikan kode bahasa yang punya katalog, terurut.
+func Languages() []string {
+	langs := make([]string, 0, len(catalogs))
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// Lookup mengembalikan teks key pada bahasa lang tanpa fallback.
+func Lookup(lang, key string) (string, bool) {
+	text, ok := catalogs[lang][key]
+	return text, ok
+}
+
+// Keys mengembalikan semua key pada katalog lang, terurut.
+func Keys(lang string) []string {
+	keys := make([]string, 0, len(catalogs[lang]))
+	for key := range catalogs[lang] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// T menerjemahkan key ke bahasa lang dan mengisi argumennya dengan
+// fmt.Sprintf. Key yang tidak ada di lang memakai katalog Default, dan key
+// yang tidak ada sama sekali dikembalikan apa adanya. Argumen berupa error
+// diganti teksnya lewat Text sehingga *Error di dalamnya ikut diterjemahkan.
+func T(lang, key string, args ...interface{}) string {
+	text, ok := Lookup(lang, key)
+	if !ok {
+		if text, ok = Lookup(Default, key); !ok {
+			text = key
+		}
+	}
+	if len(args) == 0 {
+		return text
+	}
+
+	localized := make([]interface{}, len(args))
+	for i, arg := range args {
+		if err, ok := arg.(error); ok {
+			arg = Text(lang, err)
+		}
+		localized[i] = arg
+	}
+	return fmt.Sprintf(text, localized...)
+}
+
+// Negotiate memilih bahasa dari header Accept-Language berdasarkan bobot q,
+// misalnya "en-US,en;q=0.9,id;q=0.8" menjadi "en". Subtag region diabaikan.
+func Negotiate(header string) string {
+	best, bestQ := Default, 0.0
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+
+		lang, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if lang == "*" {
+			lang = Default
+		}
+		if _, ok := catalogs[lang]; ok && q > bestQ {
+			best, bestQ = lang, q
+		}
+	}
+	return best
+}
+
+// Middleware menentukan bahasa request dari Accept-Language, menyimpannya di
+// Locals("lang"), dan mengirim header Content-Language.
+func Middleware(c *fiber.Ctx) error {
+	lang := Negotiate(c.Get(fiber.HeaderAcceptLanguage))
+	c.Locals("lang", lang)
+	c.Set(fiber.HeaderContentLanguage, lang)
+	c.Vary(fiber.HeaderAcceptLanguage)
+	return c.Next()
+}
+
+// Lang mengembalikan bahasa request. Jika Middleware belum berjalan, misalnya
+// pada unit test service, bahasa langsung dinegosiasikan dari header.
+func Lang(c *fiber.Ctx) string {
+	if lang, ok := c.Locals("lang").(string); ok {
+		return lang
+	}
+	return Negotiate(c.Get(fiber.HeaderAcceptLanguage))
+}
+
+// Msg menerjemahkan key ke bahasa request c.
+func Msg(c *fiber.Ctx, key string, args ...interface{}) string {
+	return T(Lang(c), key, args...)
+}
+
+// Error adalah error dengan pesan dari katalog, dipakai package seperti helper
+// yang pesannya diteruskan ke client tanpa tahu bahasa request.
+type Error struct {
+	Key  string
+	Args []interface{}
+}
+
+// NewError membuat Error untuk key dengan argumen format args.
+func NewError(key string, args ...interface{}) *Error {
+	return &Error{Key: key, Args: args}
+}
+
+// Error mengembalikan pesan dalam bahasa Default, misalnya untuk log.
+func (e *Error) Error() string {
+	return e.Text(Default)
+}
+
+// Text mengembalikan pesan dalam bahasa lang.
+func (e *Error) Text(lang string) string {
+	return T(lang, e.Key, e.Args...)
+}
+
+// Text menerjemahkan err jika berisi *Error, selain itu mengembalikan
+// err.Error().
+func Text(lang string, err error) string {
+	var localized *Error
+	if errors.As(err, &localized) {
+		return localized.Text(lang)
+	}
+	return err.Error()
+}
@@ -0,0 +1,330 @@
+package i18n
+
+// messagesEN adalah katalog bahasa Inggris. Setiap key di messagesID harus
+// ada di sini dengan jumlah argumen format yang sama.
+var messagesEN = map[string]string{
+	// Umum
+	"error.internal":          "An internal server error occurred.",
+	"error.not_found":         "Data not found.",
+	"error.invalid_id":        "Invalid ID.",
+	"error.invalid_id_param":  "Invalid ID parameter. ID must be a positive number.",
+	"error.invalid_body":      "Invalid request body. Make sure the JSON is well-formed.",
+	"error.duplicate":         "Data already exists.",
+	"error.duplicate_field":   "Data with this %s already exists.",
+	"error.foreign_key":       "The referenced data does not exist or is still used by other data.",
+	"error.rate_limited":      "Too many requests, try again in %s seconds.",
+	"error.version_conflict":  "The data was changed by another request. Fetch the latest data and apply the change again.",
+	"error.if_match_required": "The If-Match header with the data's ETag is required. Fetch the data first to get its ETag.",
+
+	// Error HTTP dari fiber
+	"http.bad_request":         "Bad request.",
+	"http.not_found":           "Endpoint or resource not found.",
+	"http.method_not_allowed":  "HTTP method not allowed for this endpoint.",
+	"http.payload_too_large":   "Request body exceeds the maximum size.",
+	"http.service_unavailable": "Service is temporarily unavailable.",
+
+	// Autentikasi dan otorisasi
+	"auth.token_required":       "Access token required. Add the header 'Authorization: Bearer YOUR_TOKEN'.",
+	"auth.token_format_invalid": "Invalid token format. Use 'Bearer YOUR_TOKEN'.",
+	"auth.token_invalid":        "The token is invalid or expired. Please log in again to get a new token.",
+	"auth.admin_only":           "Access denied. Only admins can access this endpoint.",
+	"auth.invalid_role":         "Access denied. Invalid role. Use role 'admin' or 'user'.",
+	"auth.invalid_api_key":      "Invalid API key. Use the correct key to access this endpoint.",
+	"auth.login_failed":         "Login failed. Incorrect email or password.",
+	"auth.login_success":        "Login successful. A JWT token has been issued.",
+	"auth.token_create_failed":  "Failed to create the JWT token.",
+	"auth.profile_fetched":      "Profile data retrieved from the JWT token.",
+	"auth.password_hash_failed": "Failed to hash the password.",
+
+	// Query parameter
+	"query.invalid_fields":         "Invalid fields parameter: %s.",
+	"query.invalid_include":        "Invalid include parameter: %s. Available options: %s.",
+	"query.invalid_group_by":       "Invalid group_by parameter. Use %s.",
+	"query.angkatan_not_number":    "The angkatan parameter must be a number.",
+	"query.tahun_lulus_not_number": "The tahun_lulus parameter must be a number.",
+	"query.invalid_currency":       "Invalid currency parameter. Use %s.",
+	"query.invalid_dimension":      "Invalid dimension parameter. Use bidang_industri or lokasi_kerja.",
+	"query.invalid_status":         "Invalid status parameter. Use aktif, selesai, or resigned.",
+	"query.invalid_format":         "Invalid format parameter. Use csv or xlsx.",
+	"query.invalid_dry_run":        "The dry_run parameter must be true or false.",
+	"query.invalid_alumni_id":      "Invalid alumni_id parameter. Alumni ID must be a positive number.",
+
+	// Alumni
+	"alumni.fetched":       "Alumni data retrieved successfully.",
+	"alumni.found":         "Alumni data found.",
+	"alumni.created":       "Alumni data saved successfully.",
+	"alumni.updated":       "Alumni data updated successfully.",
+	"alumni.deleted":       "Alumni data deleted successfully.",
+	"alumni.not_found":     "Alumni data not found.",
+	"alumni.not_found_id":  "No alumni found with that ID.",
+	"alumni.not_alumni":    "The student with that NIM is not an alumnus.",
+	"alumni.nim_required":  "The NIM parameter is required to check alumni status.",
+	"alumni.invalid_id":    "Invalid alumni ID.",
+	"alumni.id_required":   "Alumni ID is required.",
+	"alumni.fetch_failed":  "Failed to retrieve alumni data.",
+	"alumni.fields_failed": "Failed to process alumni fields.",
+	"alumni.count_failed":  "Failed to count alumni for pagination.",
+	"alumni.create_failed": "Failed to save alumni data.",
+	"alumni.update_failed": "Failed to update alumni data.",
+	"alumni.delete_failed": "Failed to delete alumni data.",
+	"alumni.check_failed":  "Failed to check alumni status.",
+
+	// Pekerjaan alumni
+	"pekerjaan.fetched":                "Alumni job data retrieved successfully.",
+	"pekerjaan.fetched_by_alumni":      "Alumni job data for the alumni ID retrieved successfully.",
+	"pekerjaan.created":                "Alumni job data saved successfully.",
+	"pekerjaan.updated":                "Alumni job data updated successfully.",
+	"pekerjaan.deleted":                "Alumni job data deleted successfully.",
+	"pekerjaan.soft_deleted":           "Alumni job data soft deleted (marked as deleted).",
+	"pekerjaan.hard_deleted":           "Alumni job data permanently deleted.",
+	"pekerjaan.restored":               "Alumni job data restored successfully.",
+	"pekerjaan.trash_fetched":          "Soft deleted alumni job data retrieved successfully.",
+	"pekerjaan.trash_empty":            "There is no soft deleted alumni job data yet.",
+	"pekerjaan.not_found":              "Alumni job data not found.",
+	"pekerjaan.not_found_id":           "No alumni job found with that ID.",
+	"pekerjaan.already_soft_deleted":   "The alumni job data has already been soft deleted.",
+	"pekerjaan.must_soft_delete_first": "The alumni job data must be soft deleted before it can be permanently deleted.",
+	"pekerjaan.not_soft_deleted":       "The alumni job data is not soft deleted and cannot be restored.",
+	"pekerjaan.forbidden_delete":       "Access denied. You can only delete your own alumni job data.",
+	"pekerjaan.forbidden_hard_delete":  "Access denied. You can only permanently delete your own alumni job data.",
+	"pekerjaan.forbidden_restore":      "Access denied. You can only restore your own alumni job data.",
+	"pekerjaan.invalid_start_date":     "Invalid start date format. Use YYYY-MM-DD (for example 2025-01-15).",
+	"pekerjaan.invalid_end_date":       "Invalid end date format. Use YYYY-MM-DD (for example 2025-12-31).",
+	"pekerjaan.invalid_salary":         "Invalid salary data. Fill in gaji_min/gaji_max or a gaji_range such as '5-7 juta'. Detail: %s",
+	"pekerjaan.fetch_failed":           "Failed to retrieve alumni job data.",
+	"pekerjaan.fetch_by_alumni_failed": "Failed to retrieve alumni job data for the alumni ID.",
+	"pekerjaan.fields_failed":          "Failed to process alumni job fields.",
+	"pekerjaan.count_failed":           "Failed to count alumni job data for pagination.",
+	"pekerjaan.create_failed":          "Failed to save alumni job data.",
+	"pekerjaan.update_failed":          "Failed to update alumni job data.",
+	"pekerjaan.delete_failed":          "Failed to delete alumni job data.",
+	"pekerjaan.hard_delete_failed":     "Failed to permanently delete alumni job data.",
+	"pekerjaan.restore_failed":         "Failed to restore alumni job data.",
+	"pekerjaan.trash_fetch_failed":     "Failed to retrieve soft deleted alumni job data.",
+
+	// Role
+	"role.fetched":           "Role data retrieved successfully.",
+	"role.list_fetched":      "Roles retrieved successfully.",
+	"role.created":           "Role saved successfully.",
+	"role.updated":           "Role updated successfully.",
+	"role.deleted":           "Role deleted successfully.",
+	"role.not_found_id":      "No role found with that ID.",
+	"role.fetch_failed":      "Failed to retrieve role data.",
+	"role.list_fetch_failed": "Failed to retrieve roles.",
+	"role.create_failed":     "Failed to save the role.",
+	"role.update_failed":     "Failed to update the role.",
+	"role.delete_failed":     "Failed to delete the role.",
+
+	// File upload
+	"file.fetched":              "File data retrieved successfully.",
+	"file.list_fetched":         "Files retrieved successfully.",
+	"file.uploaded":             "File uploaded successfully.",
+	"file.deleted":              "File deleted successfully.",
+	"file.not_found":            "File not found.",
+	"file.not_found_id":         "No file found with that ID.",
+	"file.multipart_failed":     "Failed to parse the multipart form.",
+	"file.missing":              "No file found in the request. Make sure the 'file' key is present in the form data.",
+	"file.too_large":            "File size exceeds the maximum of %d MB.",
+	"file.unsupported_type":     "File type not allowed. For %s, use: %s.",
+	"file.upload_forbidden":     "You can only upload your own photos and certificates.",
+	"file.fetch_failed":         "Failed to retrieve file data.",
+	"file.list_fetch_failed":    "Failed to retrieve files.",
+	"file.delete_failed":        "Failed to delete the file.",
+	"file.mkdir_failed":         "Failed to create the upload directory.",
+	"file.create_failed":        "Failed to create the file.",
+	"file.open_failed":          "Failed to open the file.",
+	"file.write_failed":         "Failed to write the file.",
+	"file.save_metadata_failed": "Failed to save file metadata.",
+
+	// Analytics
+	"analytics.employment_rate":           "Alumni employment rate calculated successfully.",
+	"analytics.time_to_employment":        "Median alumni time to employment calculated successfully.",
+	"analytics.distribution":              "Alumni job distribution by %s calculated successfully.",
+	"analytics.top_employers":             "Top alumni employers retrieved successfully.",
+	"analytics.salary":                    "Alumni salary statistics calculated successfully.",
+	"analytics.unsupported_dimension":     "Dimension %s is not supported.",
+	"analytics.report_not_found":          "Analytics report not found. Use employment-rate, time-to-employment, distribution, top-employers, or salary.",
+	"analytics.employment_rate_failed":    "Failed to calculate the alumni employment rate.",
+	"analytics.time_to_employment_failed": "Failed to calculate alumni time to employment.",
+	"analytics.distribution_failed":       "Failed to calculate the alumni job distribution.",
+	"analytics.top_employers_failed":      "Failed to retrieve top alumni employers.",
+	"analytics.salary_failed":             "Failed to calculate alumni salary statistics.",
+
+	// Export
+	"export.accepted":               "The export is being processed in the background. Check the job status and download the result once it is completed.",
+	"export.status_fetched":         "Export job status retrieved successfully.",
+	"export.job_not_found":          "No export job found with that ID.",
+	"export.job_not_ready":          "The export job is not finished yet. Current status: %s.",
+	"export.job_create_failed":      "Failed to create the export job.",
+	"export.job_fetch_failed":       "Failed to fetch the export job.",
+	"export.file_expired":           "The export file has expired and was deleted. Run the export again.",
+	"export.async_disabled":         "Background exports are unavailable because the job queue is disabled (JOBS_ENABLED=false). Run it without async=true.",
+	"export.alumni_count_failed":    "Failed to count alumni for export.",
+	"export.pekerjaan_count_failed": "Failed to count alumni job data for export.",
+	"export.analytics_failed":       "Failed to calculate analytics data for export.",
+	"export.report_failed":          "Failed to build the tracer study report.",
+
+	// Import
+	"import.resource.alumni":          "alumni",
+	"import.resource.pekerjaan":       "alumni job",
+	"import.file_required":            "An import file is required in the 'file' field.",
+	"import.file_invalid":             "Invalid import file. Detail: %s",
+	"import.empty":                    "The import file has no data rows.",
+	"import.failed":                   "Found %d errors in the %s import file. No data was saved.",
+	"import.validated":                "Validation succeeded. %d %s rows are ready to import. Send again with dry_run=false to save them.",
+	"import.alumni_imported":          "%d alumni imported successfully. Store the initial passwords in credentials because they are not shown again.",
+	"import.pekerjaan_imported":       "%d alumni jobs imported successfully.",
+	"import.check_existing_failed":    "Failed to check existing NIMs and emails.",
+	"import.lookup_nim_failed":        "Failed to look up alumni by NIM.",
+	"import.generate_password_failed": "Failed to generate the initial alumni password.",
+	"import.alumni_begin_failed":      "Failed to start the alumni import transaction.",
+	"import.alumni_commit_failed":     "Failed to commit the alumni import transaction.",
+	"import.alumni_save_failed":       "Failed to save the alumni import. No data was saved.",
+	"import.alumni_row_failed":        "Failed to save alumni on row %d. No data was saved.",
+	"import.pekerjaan_begin_failed":   "Failed to start the alumni job import transaction.",
+	"import.pekerjaan_commit_failed":  "Failed to commit the alumni job import transaction.",
+	"import.pekerjaan_save_failed":    "Failed to save the alumni job import. No data was saved.",
+	"import.pekerjaan_row_failed":     "Failed to save the alumni job on row %d. No data was saved.",
+	"import.row.nim_duplicate":        "NIM duplicates row %d",
+	"import.row.email_duplicate":      "email duplicates row %d",
+	"import.row.nim_exists":           "NIM is already registered",
+	"import.row.email_exists":         "email is already registered",
+	"import.row.alumni_not_found":     "no alumni found with NIM %s",
+	"import.row.salary_not_number":    "gaji_min and gaji_max must be numbers",
+	"import.row.invalid_salary":       "invalid salary data: %s",
+	"import.not_a_number":             "%s must be a number",
+	"import.unsupported_file":         "unsupported file format. Use .csv or .xlsx",
+	"import.unsupported_format":       "import format %s is not supported",
+	"import.no_sheet":                 "the XLSX file has no sheets",
+	"import.invalid_mapping":          "mapping must be a JSON object, for example {\"nim\": \"NIM Mahasiswa\"}",
+	"import.no_header":                "the file has no header row",
+	"import.too_many_rows":            "the number of rows exceeds the maximum of %d",
+	"import.unknown_mapping_field":    "unknown mapping field %s",
+	"import.column_not_found":         "column %s for field %s was not found in the file",
+	"import.file_too_large":           "file size exceeds the maximum of %d MB",
+	"import.unreadable":               "the file cannot be read: %v",
+
+	// Gaji
+	"salary.range_empty":        "gaji_range is empty",
+	"salary.range_unrecognized": "gaji_range %q is not recognized",
+	"salary.non_positive":       "gaji_min and gaji_max must be greater than 0",
+	"salary.min_gt_max":         "gaji_min must not be greater than gaji_max",
+	"salary.invalid_currency":   "gaji_currency must be one of IDR, USD, SGD, EUR",
+	"salary.invalid_period":     "gaji_period must be bulanan or tahunan",
+	"salary.min_required":       "gaji_min is required when gaji_max is set",
+
+	// Validasi request body
+	"validation.failed":     "Invalid request data. Check the fields listed in details.",
+	"validation.required":   "%s is required",
+	"validation.email":      "invalid email format",
+	"validation.oneof":      "invalid %s. Use one of: %s",
+	"validation.nim":        "invalid NIM format. Use letters, digits, dots, or dashes (at most 20 characters)",
+	"validation.phone":      "invalid phone number. Use an Indonesian number such as 081234567890 or +6281234567890",
+	"validation.date":       "invalid %s format. Use YYYY-MM-DD (for example 2025-01-15)",
+	"validation.after":      "%s must be after %s",
+	"validation.gtefield":   "%s must not be less than %s",
+	"validation.event_type": "invalid event type. Use resource.action, resource.*, or *",
+	"validation.invalid":    "invalid %s",
+
+	// Health check
+	"health.live":          "Service is running.",
+	"health.ready":         "Service is ready to accept requests.",
+	"health.not_ready":     "Service is not ready to accept requests.",
+	"health.shutting_down": "Service is shutting down.",
+
+	// Audit log
+	"audit.fetched":       "Audit log retrieved successfully.",
+	"audit.fetch_failed":  "Failed to retrieve the audit log.",
+	"audit.invalid_from":  "Invalid from parameter. Use RFC3339 or YYYY-MM-DD.",
+	"audit.invalid_to":    "Invalid to parameter. Use RFC3339 or YYYY-MM-DD.",
+	"audit.invalid_range": "The from parameter must not be after to.",
+	"audit.begin_failed":  "Failed to start the data change transaction.",
+	"audit.write_failed":  "Failed to write the audit log. The data change was rolled back.",
+	"audit.commit_failed": "Failed to save the data change.",
+
+	// Version history
+	"history.fetched":           "History retrieved successfully.",
+	"history.fetch_failed":      "Failed to retrieve the history.",
+	"history.not_found":         "History not found.",
+	"history.invalid_as_of":     "Invalid as_of parameter. Use RFC3339 or YYYY-MM-DD.",
+	"history.not_found_at":      "The record did not exist or was deleted at %s.",
+	"history.invalid_version":   "Invalid version number.",
+	"history.version_not_found": "Version %d not found.",
+	"history.version_deleted":   "Version %d is a deletion and cannot be reverted to.",
+	"history.reverted":          "Record reverted to version %d.",
+	"history.revert_failed":     "Failed to revert the record.",
+
+	// PATCH
+	"patch.unsupported_media_type": "PATCH Content-Type must be %s or %s.",
+	"patch.unknown_field":          "Field %s is unknown or cannot be changed.",
+	"patch.invalid_merge_patch":    "The merge patch body must be a JSON object.",
+	"patch.invalid_json_patch":     "The JSON Patch body must be an array of operations.",
+	"patch.invalid_operation":      "Operation %d (%s %s) is invalid: %s",
+	"patch.path_not_found":         "path not found",
+	"patch.invalid_index":          "invalid array index",
+	"patch.not_container":          "path does not point into an object or array",
+	"patch.invalid_path":           "path must be a JSON Pointer to a field",
+	"patch.value_required":         "value is required",
+	"patch.invalid_from":           "from must be a JSON Pointer to a field",
+	"patch.move_into_child":        "cannot move a value into itself",
+	"patch.unknown_op":             "op must be add, remove, replace, move, copy, or test",
+	"patch.test_failed":            "Test operation failed: value at %s does not match.",
+
+	// Idempotency-Key
+	"idempotency.invalid_key": "The Idempotency-Key header must be at most %d characters.",
+	"idempotency.key_reused":  "This Idempotency-Key was already used for a different request. Use a new key for a new request.",
+	"idempotency.in_progress": "A request with this Idempotency-Key is still being processed. Try again shortly.",
+
+	// Batch
+	"batch.selection_required": "Select data with exactly one of ids or filter.",
+	"batch.too_large":          "A batch may contain at most %d items. Narrow the ids or filter.",
+	"batch.changes_required":   "changes must be a JSON object that changes at least one field.",
+	"batch.failed":             "%d of %d items failed, so no changes were saved.",
+	"batch.fetch_failed":       "Failed to fetch batch data.",
+	"batch.write_failed":       "Failed to save the batch.",
+	"batch.updated":            "Batch update finished for %d items.",
+	"batch.deleted":            "Batch delete finished for %d items.",
+
+	// Outbox
+	"outbox.write_failed": "Failed to write the domain event. The data change was rolled back.",
+
+	// Webhook
+	"webhook.list_fetched":               "Webhooks fetched successfully.",
+	"webhook.list_fetch_failed":          "Error fetching webhooks.",
+	"webhook.fetched":                    "Webhook fetched successfully.",
+	"webhook.fetch_failed":               "Error fetching webhook.",
+	"webhook.not_found":                  "Webhook not found.",
+	"webhook.created":                    "Webhook registered successfully. Store the secret, it will not be shown again.",
+	"webhook.create_failed":              "Error registering webhook.",
+	"webhook.updated":                    "Webhook updated successfully.",
+	"webhook.update_failed":              "Error updating webhook.",
+	"webhook.deleted":                    "Webhook deleted successfully.",
+	"webhook.delete_failed":              "Error deleting webhook.",
+	"webhook.inactive":                   "Webhook is inactive. Activate it before sending a test event.",
+	"webhook.test_queued":                "Test event queued. Check the delivery log for the result.",
+	"webhook.test_failed":                "Error queuing test event.",
+	"webhook.invalid_status":             "Invalid delivery status %q. Use pending, succeeded, or dead.",
+	"webhook.delivery_list_fetched":      "Webhook deliveries fetched successfully.",
+	"webhook.delivery_list_fetch_failed": "Error fetching webhook deliveries.",
+	"webhook.delivery_fetched":           "Webhook delivery fetched successfully.",
+	"webhook.delivery_fetch_failed":      "Error fetching webhook delivery.",
+	"webhook.delivery_not_found":         "Webhook delivery not found.",
+	"webhook.delivery_pending":           "Webhook delivery is still pending or being sent. Only dead or succeeded deliveries can be redelivered.",
+	"webhook.redelivery_queued":          "Delivery queued for redelivery.",
+	"webhook.redeliver_failed":           "Error queuing redelivery.",
+
+	// Job
+	"job.list_fetched":      "Jobs fetched successfully.",
+	"job.list_fetch_failed": "Error fetching jobs.",
+	"job.fetched":           "Job fetched successfully.",
+	"job.fetch_failed":      "Error fetching job.",
+	"job.not_found":         "Job not found.",
+	"job.invalid_status":    "Invalid job status %q. Use pending, running, succeeded, failed, or cancelled.",
+	"job.retried":           "Job queued to run again.",
+	"job.retry_failed":      "Error retrying job.",
+	"job.not_retryable":     "Only failed or cancelled jobs can be retried.",
+	"job.cancelled":         "Job cancelled successfully.",
+	"job.cancel_failed":     "Error cancelling job.",
+	"job.not_cancellable":   "Only pending jobs can be cancelled.",
+	"job.schedules_fetched": "Job schedules fetched successfully.",
+}
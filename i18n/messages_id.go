@@ -0,0 +1,330 @@
+package i18n
+
+// messagesID adalah katalog bahasa Indonesia. Teks di sini juga menjadi
+// fallback untuk key yang belum diterjemahkan ke bahasa lain.
+var messagesID = map[string]string{
+	// Umum
+	"error.internal":          "Terjadi kesalahan pada server.",
+	"error.not_found":         "Data tidak ditemukan.",
+	"error.invalid_id":        "ID tidak valid.",
+	"error.invalid_id_param":  "Parameter ID tidak valid. ID harus berupa angka positif.",
+	"error.invalid_body":      "Format request body tidak valid. Pastikan JSON format benar.",
+	"error.duplicate":         "Data sudah terdaftar.",
+	"error.duplicate_field":   "Data dengan %s tersebut sudah terdaftar.",
+	"error.foreign_key":       "Data yang direferensikan tidak ada atau masih dipakai data lain.",
+	"error.rate_limited":      "Terlalu banyak request, coba lagi dalam %s detik.",
+	"error.version_conflict":  "Data sudah diubah oleh request lain. Ambil data terbaru lalu ulangi perubahan.",
+	"error.if_match_required": "Header If-Match wajib diisi dengan ETag data. Ambil data terlebih dahulu untuk mendapatkan ETag.",
+
+	// Error HTTP dari fiber
+	"http.bad_request":         "Request tidak valid.",
+	"http.not_found":           "Endpoint atau resource tidak ditemukan.",
+	"http.method_not_allowed":  "Method HTTP tidak diizinkan untuk endpoint ini.",
+	"http.payload_too_large":   "Ukuran request melebihi batas maksimal.",
+	"http.service_unavailable": "Service sedang tidak tersedia.",
+
+	// Autentikasi dan otorisasi
+	"auth.token_required":       "Token akses diperlukan. Tambahkan header 'Authorization: Bearer YOUR_TOKEN'.",
+	"auth.token_format_invalid": "Format token tidak valid. Gunakan format 'Bearer YOUR_TOKEN'.",
+	"auth.token_invalid":        "Token tidak valid atau sudah expired. Silakan login ulang untuk mendapatkan token baru.",
+	"auth.admin_only":           "Akses ditolak. Hanya admin yang dapat mengakses endpoint ini.",
+	"auth.invalid_role":         "Akses ditolak. Role tidak valid. Gunakan role 'admin' atau 'user'.",
+	"auth.invalid_api_key":      "API key tidak valid. Gunakan key yang benar untuk akses endpoint ini.",
+	"auth.login_failed":         "Login gagal. Email atau password salah.",
+	"auth.login_success":        "Login berhasil. Token JWT telah dibuat.",
+	"auth.token_create_failed":  "Error membuat JWT token.",
+	"auth.profile_fetched":      "Data profile berhasil diambil dari JWT token.",
+	"auth.password_hash_failed": "Error mengenkripsi password.",
+
+	// Query parameter
+	"query.invalid_fields":         "Parameter fields tidak valid: %s.",
+	"query.invalid_include":        "Parameter include tidak valid: %s. Pilihan yang tersedia: %s.",
+	"query.invalid_group_by":       "Parameter group_by tidak valid. Gunakan %s.",
+	"query.angkatan_not_number":    "Parameter angkatan harus berupa angka.",
+	"query.tahun_lulus_not_number": "Parameter tahun_lulus harus berupa angka.",
+	"query.invalid_currency":       "Parameter currency tidak valid. Gunakan %s.",
+	"query.invalid_dimension":      "Parameter dimension tidak valid. Gunakan bidang_industri atau lokasi_kerja.",
+	"query.invalid_status":         "Parameter status tidak valid. Gunakan aktif, selesai, atau resigned.",
+	"query.invalid_format":         "Parameter format tidak valid. Gunakan csv atau xlsx.",
+	"query.invalid_dry_run":        "Parameter dry_run harus bernilai true atau false.",
+	"query.invalid_alumni_id":      "Parameter alumni_id tidak valid. Alumni ID harus berupa angka positif.",
+
+	// Alumni
+	"alumni.fetched":       "Data alumni berhasil diambil dari database.",
+	"alumni.found":         "Data alumni berhasil ditemukan di database.",
+	"alumni.created":       "Data alumni berhasil disimpan ke database.",
+	"alumni.updated":       "Data alumni berhasil diupdate di database.",
+	"alumni.deleted":       "Data alumni berhasil dihapus dari database.",
+	"alumni.not_found":     "Data alumni tidak ditemukan.",
+	"alumni.not_found_id":  "Data alumni dengan ID tersebut tidak ditemukan di database.",
+	"alumni.not_alumni":    "Mahasiswa dengan NIM tersebut bukan alumni.",
+	"alumni.nim_required":  "Parameter NIM wajib diisi untuk pengecekan status alumni.",
+	"alumni.invalid_id":    "Alumni ID tidak valid.",
+	"alumni.id_required":   "Alumni ID wajib diisi.",
+	"alumni.fetch_failed":  "Error mengambil data alumni dari database.",
+	"alumni.fields_failed": "Error memproses field data alumni.",
+	"alumni.count_failed":  "Error menghitung total data alumni untuk pagination.",
+	"alumni.create_failed": "Error menyimpan data alumni ke database.",
+	"alumni.update_failed": "Error mengupdate data alumni di database.",
+	"alumni.delete_failed": "Error menghapus data alumni dari database.",
+	"alumni.check_failed":  "Error mengecek status alumni di database.",
+
+	// Pekerjaan alumni
+	"pekerjaan.fetched":                "Data pekerjaan alumni berhasil diambil dari database.",
+	"pekerjaan.fetched_by_alumni":      "Data pekerjaan alumni berdasarkan Alumni ID berhasil diambil dari database.",
+	"pekerjaan.created":                "Data pekerjaan alumni berhasil disimpan ke database.",
+	"pekerjaan.updated":                "Data pekerjaan alumni berhasil diupdate di database.",
+	"pekerjaan.deleted":                "Data pekerjaan alumni berhasil dihapus dari database.",
+	"pekerjaan.soft_deleted":           "Data pekerjaan alumni berhasil di-soft delete (ditandai sebagai terhapus).",
+	"pekerjaan.hard_deleted":           "Data pekerjaan alumni berhasil dihapus secara permanen dari database.",
+	"pekerjaan.restored":               "Data pekerjaan alumni berhasil di-restore.",
+	"pekerjaan.trash_fetched":          "Data pekerjaan alumni yang di-soft delete berhasil diambil dari database.",
+	"pekerjaan.trash_empty":            "Belum ada data pekerjaan alumni yang di-soft delete.",
+	"pekerjaan.not_found":              "Data pekerjaan alumni tidak ditemukan.",
+	"pekerjaan.not_found_id":           "Data pekerjaan alumni dengan ID tersebut tidak ditemukan di database.",
+	"pekerjaan.already_soft_deleted":   "Data pekerjaan alumni sudah di-soft delete sebelumnya.",
+	"pekerjaan.must_soft_delete_first": "Data pekerjaan alumni harus di-soft delete terlebih dahulu sebelum bisa dihapus permanen.",
+	"pekerjaan.not_soft_deleted":       "Data pekerjaan alumni belum di-soft delete, tidak bisa di-restore.",
+	"pekerjaan.forbidden_delete":       "Akses ditolak. Anda hanya bisa menghapus pekerjaan alumni milik Anda sendiri.",
+	"pekerjaan.forbidden_hard_delete":  "Akses ditolak. Anda hanya bisa menghapus permanen pekerjaan alumni milik Anda sendiri.",
+	"pekerjaan.forbidden_restore":      "Akses ditolak. Anda hanya bisa restore pekerjaan alumni milik Anda sendiri.",
+	"pekerjaan.invalid_start_date":     "Format tanggal mulai kerja tidak valid. Gunakan format YYYY-MM-DD (contoh: 2025-01-15).",
+	"pekerjaan.invalid_end_date":       "Format tanggal selesai kerja tidak valid. Gunakan format YYYY-MM-DD (contoh: 2025-12-31).",
+	"pekerjaan.invalid_salary":         "Data gaji tidak valid. Isi gaji_min/gaji_max atau gaji_range seperti '5-7 juta'. Detail: %s",
+	"pekerjaan.fetch_failed":           "Error mengambil data pekerjaan alumni dari database.",
+	"pekerjaan.fetch_by_alumni_failed": "Error mengambil data pekerjaan alumni berdasarkan Alumni ID dari database.",
+	"pekerjaan.fields_failed":          "Error memproses field data pekerjaan alumni.",
+	"pekerjaan.count_failed":           "Error menghitung total data pekerjaan alumni untuk pagination.",
+	"pekerjaan.create_failed":          "Error menyimpan data pekerjaan alumni ke database.",
+	"pekerjaan.update_failed":          "Error mengupdate data pekerjaan alumni di database.",
+	"pekerjaan.delete_failed":          "Error menghapus data pekerjaan alumni dari database.",
+	"pekerjaan.hard_delete_failed":     "Error menghapus data pekerjaan alumni secara permanen dari database.",
+	"pekerjaan.restore_failed":         "Error restore data pekerjaan alumni dari database.",
+	"pekerjaan.trash_fetch_failed":     "Error mengambil data pekerjaan alumni yang di-soft delete dari database.",
+
+	// Role
+	"role.fetched":           "Data role berhasil diambil dari database.",
+	"role.list_fetched":      "Data roles berhasil diambil dari database.",
+	"role.created":           "Data role berhasil disimpan ke database.",
+	"role.updated":           "Data role berhasil diupdate di database.",
+	"role.deleted":           "Data role berhasil dihapus dari database.",
+	"role.not_found_id":      "Data role dengan ID tersebut tidak ditemukan di database.",
+	"role.fetch_failed":      "Error mengambil data role dari database.",
+	"role.list_fetch_failed": "Error mengambil data roles dari database.",
+	"role.create_failed":     "Error menyimpan data role ke database.",
+	"role.update_failed":     "Error mengupdate data role di database.",
+	"role.delete_failed":     "Error menghapus data role dari database.",
+
+	// File upload
+	"file.fetched":              "Data file berhasil diambil dari database.",
+	"file.list_fetched":         "Data files berhasil diambil dari database.",
+	"file.uploaded":             "File berhasil diupload.",
+	"file.deleted":              "File berhasil dihapus.",
+	"file.not_found":            "File tidak ditemukan.",
+	"file.not_found_id":         "Data file dengan ID tersebut tidak ditemukan di database.",
+	"file.multipart_failed":     "Gagal parsing multipart form.",
+	"file.missing":              "File tidak ditemukan dalam request. Pastikan key 'file' ada di form-data.",
+	"file.too_large":            "Ukuran file melebihi batas maksimal %d MB.",
+	"file.unsupported_type":     "Tipe file tidak diizinkan. Untuk %s, gunakan format: %s.",
+	"file.upload_forbidden":     "Anda hanya bisa menambahkan foto dan sertifikat milik Anda sendiri.",
+	"file.fetch_failed":         "Error mengambil data file dari database.",
+	"file.list_fetch_failed":    "Error mengambil data files dari database.",
+	"file.delete_failed":        "Error menghapus file dari database.",
+	"file.mkdir_failed":         "Gagal membuat direktori upload.",
+	"file.create_failed":        "Gagal membuat file.",
+	"file.open_failed":          "Gagal membuka file.",
+	"file.write_failed":         "Gagal menulis file.",
+	"file.save_metadata_failed": "Gagal menyimpan metadata file.",
+
+	// Analytics
+	"analytics.employment_rate":           "Berhasil menghitung tingkat keterserapan kerja alumni.",
+	"analytics.time_to_employment":        "Berhasil menghitung median waktu tunggu kerja alumni.",
+	"analytics.distribution":              "Berhasil menghitung distribusi pekerjaan alumni berdasarkan %s.",
+	"analytics.top_employers":             "Berhasil mengambil perusahaan dengan alumni terbanyak.",
+	"analytics.salary":                    "Berhasil menghitung statistik gaji alumni.",
+	"analytics.unsupported_dimension":     "Dimension %s tidak didukung.",
+	"analytics.report_not_found":          "Laporan analytics tidak ditemukan. Gunakan employment-rate, time-to-employment, distribution, top-employers, atau salary.",
+	"analytics.employment_rate_failed":    "Error menghitung tingkat keterserapan kerja alumni.",
+	"analytics.time_to_employment_failed": "Error menghitung waktu tunggu kerja alumni.",
+	"analytics.distribution_failed":       "Error menghitung distribusi pekerjaan alumni.",
+	"analytics.top_employers_failed":      "Error mengambil perusahaan dengan alumni terbanyak.",
+	"analytics.salary_failed":             "Error menghitung statistik gaji alumni.",
+
+	// Export
+	"export.accepted":               "Export sedang diproses di background. Cek status job lalu unduh hasilnya setelah selesai.",
+	"export.status_fetched":         "Status job export berhasil diambil.",
+	"export.job_not_found":          "Job export dengan ID tersebut tidak ditemukan.",
+	"export.job_not_ready":          "Job export belum selesai. Status saat ini: %s.",
+	"export.job_create_failed":      "Error membuat job export.",
+	"export.job_fetch_failed":       "Error mengambil job export.",
+	"export.file_expired":           "File hasil export sudah kedaluwarsa dan dihapus. Jalankan export lagi.",
+	"export.async_disabled":         "Export background tidak tersedia karena job queue dimatikan (JOBS_ENABLED=false). Jalankan tanpa async=true.",
+	"export.alumni_count_failed":    "Error menghitung total data alumni untuk export.",
+	"export.pekerjaan_count_failed": "Error menghitung total data pekerjaan alumni untuk export.",
+	"export.analytics_failed":       "Error menghitung data analytics untuk export.",
+	"export.report_failed":          "Error menyusun laporan tracer study.",
+
+	// Import
+	"import.resource.alumni":          "alumni",
+	"import.resource.pekerjaan":       "pekerjaan alumni",
+	"import.file_required":            "File import wajib diisi pada field 'file'.",
+	"import.file_invalid":             "File import tidak valid. Detail: %s",
+	"import.empty":                    "File import tidak memiliki baris data.",
+	"import.failed":                   "Terdapat %d kesalahan pada file import %s. Tidak ada data yang disimpan.",
+	"import.validated":                "Validasi berhasil. %d baris %s siap diimport. Kirim ulang dengan dry_run=false untuk menyimpan.",
+	"import.alumni_imported":          "%d data alumni berhasil diimport. Simpan password awal pada credentials karena tidak ditampilkan lagi.",
+	"import.pekerjaan_imported":       "%d data pekerjaan alumni berhasil diimport.",
+	"import.check_existing_failed":    "Error memeriksa NIM dan email yang sudah terdaftar.",
+	"import.lookup_nim_failed":        "Error mencari alumni berdasarkan NIM.",
+	"import.generate_password_failed": "Error membuat password awal alumni.",
+	"import.alumni_begin_failed":      "Error memulai transaksi import alumni.",
+	"import.alumni_commit_failed":     "Error menyimpan transaksi import alumni.",
+	"import.alumni_save_failed":       "Error menyimpan import alumni. Tidak ada data yang disimpan.",
+	"import.alumni_row_failed":        "Error menyimpan alumni baris %d. Tidak ada data yang disimpan.",
+	"import.pekerjaan_begin_failed":   "Error memulai transaksi import pekerjaan alumni.",
+	"import.pekerjaan_commit_failed":  "Error menyimpan transaksi import pekerjaan alumni.",
+	"import.pekerjaan_save_failed":    "Error menyimpan import pekerjaan alumni. Tidak ada data yang disimpan.",
+	"import.pekerjaan_row_failed":     "Error menyimpan pekerjaan alumni baris %d. Tidak ada data yang disimpan.",
+	"import.row.nim_duplicate":        "NIM duplikat dengan baris %d",
+	"import.row.email_duplicate":      "email duplikat dengan baris %d",
+	"import.row.nim_exists":           "NIM sudah terdaftar",
+	"import.row.email_exists":         "email sudah terdaftar",
+	"import.row.alumni_not_found":     "alumni dengan NIM %s tidak ditemukan",
+	"import.row.salary_not_number":    "gaji_min dan gaji_max harus berupa angka",
+	"import.row.invalid_salary":       "data gaji tidak valid: %s",
+	"import.not_a_number":             "%s harus berupa angka",
+	"import.unsupported_file":         "format file tidak didukung. Gunakan .csv atau .xlsx",
+	"import.unsupported_format":       "format import %s tidak didukung",
+	"import.no_sheet":                 "file XLSX tidak memiliki sheet",
+	"import.invalid_mapping":          "mapping harus berupa JSON object, contoh {\"nim\": \"NIM Mahasiswa\"}",
+	"import.no_header":                "file tidak memiliki header",
+	"import.too_many_rows":            "jumlah baris melebihi batas maksimal %d",
+	"import.unknown_mapping_field":    "field mapping %s tidak dikenal",
+	"import.column_not_found":         "kolom %s untuk field %s tidak ditemukan di file",
+	"import.file_too_large":           "ukuran file melebihi batas maksimal %d MB",
+	"import.unreadable":               "file tidak bisa dibaca: %v",
+
+	// Gaji
+	"salary.range_empty":        "gaji_range kosong",
+	"salary.range_unrecognized": "gaji_range %q tidak dikenali",
+	"salary.non_positive":       "gaji_min dan gaji_max harus lebih dari 0",
+	"salary.min_gt_max":         "gaji_min tidak boleh lebih besar dari gaji_max",
+	"salary.invalid_currency":   "gaji_currency harus salah satu dari IDR, USD, SGD, EUR",
+	"salary.invalid_period":     "gaji_period harus bulanan atau tahunan",
+	"salary.min_required":       "gaji_min wajib diisi jika gaji_max diisi",
+
+	// Validasi request body
+	"validation.failed":     "Data request tidak valid. Periksa kembali field yang ditandai.",
+	"validation.required":   "%s wajib diisi",
+	"validation.email":      "format email tidak valid",
+	"validation.oneof":      "%s tidak valid. Gunakan salah satu dari: %s",
+	"validation.nim":        "format NIM tidak valid. Gunakan huruf, angka, titik, atau tanda hubung (maksimal 20 karakter)",
+	"validation.phone":      "format nomor telepon tidak valid. Gunakan nomor Indonesia seperti 081234567890 atau +6281234567890",
+	"validation.date":       "format %s tidak valid. Gunakan format YYYY-MM-DD (contoh: 2025-01-15)",
+	"validation.after":      "%s harus setelah %s",
+	"validation.gtefield":   "%s tidak boleh lebih kecil dari %s",
+	"validation.event_type": "tipe event tidak valid. Gunakan format resource.aksi, resource.*, atau *",
+	"validation.invalid":    "%s tidak valid",
+
+	// Health check
+	"health.live":          "Service berjalan.",
+	"health.ready":         "Service siap menerima request.",
+	"health.not_ready":     "Service belum siap menerima request.",
+	"health.shutting_down": "Service sedang dimatikan.",
+
+	// Audit log
+	"audit.fetched":       "Audit log berhasil diambil.",
+	"audit.fetch_failed":  "Error mengambil audit log.",
+	"audit.invalid_from":  "Parameter from tidak valid. Gunakan format RFC3339 atau YYYY-MM-DD.",
+	"audit.invalid_to":    "Parameter to tidak valid. Gunakan format RFC3339 atau YYYY-MM-DD.",
+	"audit.invalid_range": "Parameter from tidak boleh setelah to.",
+	"audit.begin_failed":  "Error memulai transaksi perubahan data.",
+	"audit.write_failed":  "Error menyimpan audit log. Perubahan data dibatalkan.",
+	"audit.commit_failed": "Error menyimpan perubahan data.",
+
+	// Riwayat versi
+	"history.fetched":           "Riwayat data berhasil diambil.",
+	"history.fetch_failed":      "Error mengambil riwayat data.",
+	"history.not_found":         "Riwayat data tidak ditemukan.",
+	"history.invalid_as_of":     "Parameter as_of tidak valid. Gunakan format RFC3339 atau YYYY-MM-DD.",
+	"history.not_found_at":      "Data belum ada atau sudah dihapus pada %s.",
+	"history.invalid_version":   "Nomor versi tidak valid.",
+	"history.version_not_found": "Versi %d tidak ditemukan.",
+	"history.version_deleted":   "Versi %d adalah penghapusan data dan tidak bisa dipulihkan dengan revert.",
+	"history.reverted":          "Data berhasil dikembalikan ke versi %d.",
+	"history.revert_failed":     "Error mengembalikan data ke versi sebelumnya.",
+
+	// PATCH
+	"patch.unsupported_media_type": "Content-Type PATCH harus %s atau %s.",
+	"patch.unknown_field":          "Field %s tidak dikenal atau tidak bisa diubah.",
+	"patch.invalid_merge_patch":    "Body merge patch harus berupa object JSON.",
+	"patch.invalid_json_patch":     "Body JSON Patch harus berupa array operasi.",
+	"patch.invalid_operation":      "Operasi ke-%d (%s %s) tidak valid: %s",
+	"patch.path_not_found":         "path tidak ditemukan",
+	"patch.invalid_index":          "indeks array tidak valid",
+	"patch.not_container":          "path tidak menunjuk ke object atau array",
+	"patch.invalid_path":           "path harus berupa JSON Pointer ke field",
+	"patch.value_required":         "value wajib diisi",
+	"patch.invalid_from":           "from harus berupa JSON Pointer ke field",
+	"patch.move_into_child":        "tidak bisa memindahkan nilai ke dalam dirinya sendiri",
+	"patch.unknown_op":             "op harus add, remove, replace, move, copy, atau test",
+	"patch.test_failed":            "Operasi test gagal: nilai %s tidak sesuai.",
+
+	// Idempotency-Key
+	"idempotency.invalid_key": "Header Idempotency-Key maksimal %d karakter.",
+	"idempotency.key_reused":  "Idempotency-Key sudah dipakai untuk request dengan isi berbeda. Gunakan key baru untuk request baru.",
+	"idempotency.in_progress": "Request dengan Idempotency-Key ini masih diproses. Coba lagi sebentar lagi.",
+
+	// Batch
+	"batch.selection_required": "Pilih data dengan tepat salah satu dari ids atau filter.",
+	"batch.too_large":          "Batch maksimal berisi %d data. Persempit ids atau filter.",
+	"batch.changes_required":   "changes wajib berupa object JSON yang mengubah minimal satu field.",
+	"batch.failed":             "%d dari %d data gagal diproses sehingga tidak ada perubahan yang disimpan.",
+	"batch.fetch_failed":       "Gagal mengambil data batch.",
+	"batch.write_failed":       "Gagal menyimpan batch.",
+	"batch.updated":            "Batch update selesai untuk %d data.",
+	"batch.deleted":            "Batch delete selesai untuk %d data.",
+
+	// Outbox
+	"outbox.write_failed": "Error menyimpan domain event. Perubahan data dibatalkan.",
+
+	// Webhook
+	"webhook.list_fetched":               "Data webhook berhasil diambil.",
+	"webhook.list_fetch_failed":          "Error mengambil data webhook.",
+	"webhook.fetched":                    "Webhook berhasil diambil.",
+	"webhook.fetch_failed":               "Error mengambil webhook.",
+	"webhook.not_found":                  "Webhook tidak ditemukan.",
+	"webhook.created":                    "Webhook berhasil didaftarkan. Simpan secret, karena tidak akan ditampilkan lagi.",
+	"webhook.create_failed":              "Error mendaftarkan webhook.",
+	"webhook.updated":                    "Webhook berhasil diupdate.",
+	"webhook.update_failed":              "Error mengupdate webhook.",
+	"webhook.deleted":                    "Webhook berhasil dihapus.",
+	"webhook.delete_failed":              "Error menghapus webhook.",
+	"webhook.inactive":                   "Webhook tidak aktif. Aktifkan dulu sebelum mengirim event test.",
+	"webhook.test_queued":                "Event test dijadwalkan. Lihat hasilnya di log delivery.",
+	"webhook.test_failed":                "Error menjadwalkan event test.",
+	"webhook.invalid_status":             "Status delivery %q tidak valid. Gunakan pending, succeeded, atau dead.",
+	"webhook.delivery_list_fetched":      "Data delivery webhook berhasil diambil.",
+	"webhook.delivery_list_fetch_failed": "Error mengambil data delivery webhook.",
+	"webhook.delivery_fetched":           "Delivery webhook berhasil diambil.",
+	"webhook.delivery_fetch_failed":      "Error mengambil delivery webhook.",
+	"webhook.delivery_not_found":         "Delivery webhook tidak ditemukan.",
+	"webhook.delivery_pending":           "Delivery webhook masih pending atau sedang dikirim. Hanya delivery dead atau succeeded yang bisa dikirim ulang.",
+	"webhook.redelivery_queued":          "Delivery dijadwalkan untuk dikirim ulang.",
+	"webhook.redeliver_failed":           "Error menjadwalkan ulang delivery.",
+
+	// Job
+	"job.list_fetched":      "Data job berhasil diambil.",
+	"job.list_fetch_failed": "Error mengambil data job.",
+	"job.fetched":           "Job berhasil diambil.",
+	"job.fetch_failed":      "Error mengambil job.",
+	"job.not_found":         "Job tidak ditemukan.",
+	"job.invalid_status":    "Status job %q tidak valid. Gunakan pending, running, succeeded, failed, atau cancelled.",
+	"job.retried":           "Job dijadwalkan untuk dijalankan ulang.",
+	"job.retry_failed":      "Error menjalankan ulang job.",
+	"job.not_retryable":     "Hanya job failed atau cancelled yang bisa dijalankan ulang.",
+	"job.cancelled":         "Job berhasil dibatalkan.",
+	"job.cancel_failed":     "Error membatalkan job.",
+	"job.not_cancellable":   "Hanya job pending yang bisa dibatalkan.",
+	"job.schedules_fetched": "Jadwal job berhasil diambil.",
+}
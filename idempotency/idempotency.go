@@ -0,0 +1,201 @@
+// Package idempotency membuat POST aman diulang dengan header
+// Idempotency-Key. Response request pertama disimpan selama TTL dan
+// dikirim ulang untuk retry dengan key dan body yang sama, sehingga client
+// di jaringan tidak stabil tidak membuat data atau file ganda.
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"sort"
+	"time"
+
+	"go-fiber/apperror"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// Header adalah header request yang berisi key dari client.
+	Header = "Idempotency-Key"
+	// ReplayedHeader bernilai true pada response yang dikirim ulang dari store.
+	ReplayedHeader = "Idempotent-Replayed"
+
+	maxKeyLength = 255
+	// lockTTL membatasi berapa lama key ditahan request yang belum selesai,
+	// agar key tidak terkunci sampai TTL jika instance mati di tengah request.
+	lockTTL = time.Minute
+)
+
+// storedHeaders adalah header response yang ikut disimpan dan dikirim ulang.
+var storedHeaders = []string{fiber.HeaderContentType, fiber.HeaderLocation, fiber.HeaderETag}
+
+// Response adalah response sukses yang disimpan untuk dikirim ulang.
+type Response struct {
+	Status  int
+	Headers map[string]string
+	Body    []byte
+}
+
+// Record adalah isi store untuk satu key. Response bernilai nil selama
+// request pertama masih diproses.
+type Record struct {
+	Fingerprint string
+	Response    *Response
+}
+
+// Store menyimpan key beserta response-nya. Implementasi bersama
+// (PostgreSQL, MongoDB) membuat retry ke instance lain tetap dikenali.
+type Store interface {
+	// Reserve menandai key sedang diproses oleh request dengan fingerprint
+	// sampai lockTTL. Jika key sudah ada dan belum kedaluwarsa, record yang
+	// ada dikembalikan dan key tidak diubah; nil berarti key berhasil dipesan.
+	Reserve(ctx context.Context, key, fingerprint string, lockTTL time.Duration) (*Record, error)
+	// Complete menyimpan response untuk key yang dipesan dengan fingerprint
+	// dan memperpanjang masa simpannya menjadi ttl.
+	Complete(ctx context.Context, key, fingerprint string, response Response, ttl time.Duration) error
+	// Release menghapus pesanan key yang belum punya response, sehingga
+	// request berikutnya dengan key yang sama diproses ulang.
+	Release(ctx context.Context, key, fingerprint string) error
+}
+
+// New membuat middleware Idempotency-Key. Dipasang setelah AuthRequired
+// karena key dipisah per user. Request tanpa header diteruskan seperti
+// biasa. Hanya response 2xx yang disimpan: request yang gagal tidak
+// mengubah data, jadi key dilepas dan boleh dipakai lagi. Jika store tidak
+// bisa dihubungi request tetap diteruskan tanpa perlindungan, sama seperti
+// rate limit.
+func New(store Store, ttl time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		idempotencyKey := c.Get(Header)
+		if idempotencyKey == "" {
+			return c.Next()
+		}
+		if len(idempotencyKey) > maxKeyLength {
+			return apperror.New(fiber.StatusBadRequest, "invalid_idempotency_key", "idempotency.invalid_key", maxKeyLength)
+		}
+
+		key := storageKey(c, idempotencyKey)
+		fingerprint, err := requestFingerprint(c)
+		if err != nil {
+			return apperror.InvalidBody(err)
+		}
+
+		ctx := c.UserContext()
+		existing, err := store.Reserve(ctx, key, fingerprint, lockTTL)
+		if err != nil {
+			slog.WarnContext(ctx, "Idempotency store tidak tersedia, request diteruskan", "error", err)
+			return c.Next()
+		}
+		if existing != nil {
+			return replay(c, existing, fingerprint)
+		}
+
+		err = c.Next()
+		status := c.Response().StatusCode()
+		if err != nil || status < 200 || status >= 300 {
+			if releaseErr := store.Release(ctx, key, fingerprint); releaseErr != nil {
+				slog.WarnContext(ctx, "Gagal melepas idempotency key", "error", releaseErr)
+			}
+			return err
+		}
+
+		response := Response{Status: status, Headers: map[string]string{}, Body: append([]byte(nil), c.Response().Body()...)}
+		for _, name := range storedHeaders {
+			if value := c.GetRespHeader(name); value != "" {
+				response.Headers[name] = value
+			}
+		}
+		if err := store.Complete(ctx, key, fingerprint, response, ttl); err != nil {
+			slog.WarnContext(ctx, "Gagal menyimpan response idempotency", "error", err)
+		}
+		return nil
+	}
+}
+
+// Disabled mengembalikan middleware yang meneruskan semua request.
+func Disabled() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return c.Next()
+	}
+}
+
+func replay(c *fiber.Ctx, record *Record, fingerprint string) error {
+	if record.Fingerprint != fingerprint {
+		return apperror.New(fiber.StatusUnprocessableEntity, "idempotency_key_reused", "idempotency.key_reused")
+	}
+	if record.Response == nil {
+		c.Set(fiber.HeaderRetryAfter, "1")
+		return apperror.New(fiber.StatusConflict, "idempotency_in_progress", "idempotency.in_progress")
+	}
+
+	for name, value := range record.Response.Headers {
+		c.Set(name, value)
+	}
+	c.Set(ReplayedHeader, "true")
+	return c.Status(record.Response.Status).Send(record.Response.Body)
+}
+
+// storageKey memisahkan key per user, lalu di-hash agar panjangnya tetap
+// dan key dari client tidak tersimpan apa adanya.
+func storageKey(c *fiber.Ctx, idempotencyKey string) string {
+	owner := "ip:" + c.IP()
+	if alumniID := c.Locals("alumni_id"); alumniID != nil {
+		owner = fmt.Sprintf("user:%v", alumniID)
+	}
+	sum := sha256.Sum256([]byte(owner + "|" + idempotencyKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// requestFingerprint meng-hash method, URL, dan body request. Body
+// multipart di-hash per field dan isi file karena boundary berbeda setiap
+// kali client menyusun ulang form, walaupun isinya sama.
+func requestFingerprint(c *fiber.Ctx) (string, error) {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s %s\n", c.Method(), c.OriginalURL())
+
+	mediaType, _, _ := mime.ParseMediaType(c.Get(fiber.HeaderContentType))
+	if mediaType != fiber.MIMEMultipartForm {
+		hash.Write(c.Body())
+		return hex.EncodeToString(hash.Sum(nil)), nil
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		return "", err
+	}
+	for _, name := range sortedKeys(form.Value) {
+		for _, value := range form.Value[name] {
+			fmt.Fprintf(hash, "value %s %d\n%s\n", name, len(value), value)
+		}
+	}
+	for _, name := range sortedKeys(form.File) {
+		for _, header := range form.File[name] {
+			fmt.Fprintf(hash, "file %s %s %d\n", name, header.Filename, header.Size)
+			file, err := header.Open()
+			if err != nil {
+				return "", err
+			}
+			_, err = io.Copy(hash, file)
+			file.Close()
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,81 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval adalah jeda minimal antar pembersihan key kedaluwarsa.
+const sweepInterval = time.Minute
+
+type memoryEntry struct {
+	record    Record
+	expiresAt time.Time
+}
+
+// MemoryStore menyimpan key di memori proses. Retry hanya dikenali jika
+// masuk ke instance yang sama, cocok untuk development atau deployment satu
+// instance.
+type MemoryStore struct {
+	mu        sync.Mutex
+	entries   map[string]*memoryEntry
+	lastSweep time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: map[string]*memoryEntry{}, lastSweep: time.Now()}
+}
+
+func (s *MemoryStore) Reserve(ctx context.Context, key, fingerprint string, lockTTL time.Duration) (*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.lastSweep) >= sweepInterval {
+		for id, entry := range s.entries {
+			if now.After(entry.expiresAt) {
+				delete(s.entries, id)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	if entry, ok := s.entries[key]; ok && now.Before(entry.expiresAt) {
+		record := entry.record
+		return &record, nil
+	}
+	s.entries[key] = &memoryEntry{record: Record{Fingerprint: fingerprint}, expiresAt: now.Add(lockTTL)}
+	return nil, nil
+}
+
+func (s *MemoryStore) Complete(ctx context.Context, key, fingerprint string, response Response, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry := s.pending(key, fingerprint); entry != nil {
+		entry.record.Response = &response
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+	return nil
+}
+
+func (s *MemoryStore) Release(ctx context.Context, key, fingerprint string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pending(key, fingerprint) != nil {
+		delete(s.entries, key)
+	}
+	return nil
+}
+
+// pending mengembalikan entry key yang masih dipesan oleh fingerprint, nil
+// jika pesanan sudah kedaluwarsa dan diambil alih request lain.
+func (s *MemoryStore) pending(key, fingerprint string) *memoryEntry {
+	entry, ok := s.entries[key]
+	if !ok || entry.record.Fingerprint != fingerprint || entry.record.Response != nil {
+		return nil
+	}
+	return entry
+}
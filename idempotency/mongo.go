@@ -0,0 +1,94 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"go-fiber/tracing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoStore menyimpan key di collection idempotency_keys sehingga retry
+// dikenali di semua instance. Key kedaluwarsa dihapus oleh TTL index pada
+// expires_at yang dibuat RunMigrations.
+type MongoStore struct {
+	collection *mongo.Collection
+}
+
+func NewMongoStore(db *mongo.Database) *MongoStore {
+	return &MongoStore{collection: db.Collection("idempotency_keys")}
+}
+
+type mongoRecord struct {
+	Key         string            `bson:"_id"`
+	Fingerprint string            `bson:"fingerprint"`
+	Status      int               `bson:"status,omitempty"`
+	Headers     map[string]string `bson:"headers,omitempty"`
+	Body        []byte            `bson:"body,omitempty"`
+	ExpiresAt   time.Time         `bson:"expires_at"`
+}
+
+// Reserve melakukan upsert yang hanya cocok dengan dokumen kedaluwarsa.
+// Jika key masih berlaku, upsert gagal karena _id duplikat dan dokumen yang
+// ada dibaca. TTL index MongoDB bisa terlambat sampai satu menit, jadi
+// dokumen kedaluwarsa yang belum terhapus ikut ditimpa di sini.
+func (s *MongoStore) Reserve(ctx context.Context, key, fingerprint string, lockTTL time.Duration) (*Record, error) {
+	ctx, span := tracing.StartMongo(ctx, "MongoStore.Reserve")
+	defer span.End()
+
+	now := time.Now()
+	filter := bson.M{"_id": key, "expires_at": bson.M{"$lt": now}}
+	reservation := mongoRecord{Key: key, Fingerprint: fingerprint, ExpiresAt: now.Add(lockTTL)}
+	_, err := s.collection.ReplaceOne(ctx, filter, reservation, options.Replace().SetUpsert(true))
+	if err == nil {
+		return nil, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return nil, tracing.Error(span, err)
+	}
+
+	var existing mongoRecord
+	if err := s.collection.FindOne(ctx, bson.M{"_id": key}).Decode(&existing); err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	record := &Record{Fingerprint: existing.Fingerprint}
+	if existing.Status != 0 {
+		record.Response = &Response{Status: existing.Status, Headers: existing.Headers, Body: existing.Body}
+	}
+	return record, nil
+}
+
+func (s *MongoStore) Complete(ctx context.Context, key, fingerprint string, response Response, ttl time.Duration) error {
+	ctx, span := tracing.StartMongo(ctx, "MongoStore.Complete")
+	defer span.End()
+
+	update := bson.M{"$set": bson.M{
+		"status":     response.Status,
+		"headers":    response.Headers,
+		"body":       response.Body,
+		"expires_at": time.Now().Add(ttl),
+	}}
+	if _, err := s.collection.UpdateOne(ctx, pendingFilter(key, fingerprint), update); err != nil {
+		return tracing.Error(span, err)
+	}
+	return nil
+}
+
+func (s *MongoStore) Release(ctx context.Context, key, fingerprint string) error {
+	ctx, span := tracing.StartMongo(ctx, "MongoStore.Release")
+	defer span.End()
+
+	if _, err := s.collection.DeleteOne(ctx, pendingFilter(key, fingerprint)); err != nil {
+		return tracing.Error(span, err)
+	}
+	return nil
+}
+
+// pendingFilter cocok dengan key yang masih dipesan oleh fingerprint dan
+// belum punya response.
+func pendingFilter(key, fingerprint string) bson.M {
+	return bson.M{"_id": key, "fingerprint": fingerprint, "status": bson.M{"$exists": false}}
+}
@@ -0,0 +1,119 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"go-fiber/tracing"
+)
+
+// PostgresStore menyimpan key di tabel idempotency_keys (dibuat oleh
+// RunPostgresMigrations) sehingga retry dikenali di semua instance.
+type PostgresStore struct {
+	db *sql.DB
+
+	mu        sync.Mutex
+	lastSweep time.Time
+}
+
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db, lastSweep: time.Now()}
+}
+
+// Reserve memakai satu INSERT agar dua request dengan key sama tidak bisa
+// sama-sama memesan. Baris yang sudah kedaluwarsa tetapi belum dibersihkan
+// ditimpa di statement yang sama.
+func (s *PostgresStore) Reserve(ctx context.Context, key, fingerprint string, lockTTL time.Duration) (*Record, error) {
+	ctx, span := tracing.StartPostgres(ctx, "PostgresStore.Reserve")
+	defer span.End()
+
+	s.sweep(ctx)
+
+	var reserved string
+	err := s.db.QueryRowContext(ctx, `
+		INSERT INTO idempotency_keys (key, fingerprint, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (key) DO UPDATE
+		SET fingerprint = EXCLUDED.fingerprint, status = NULL, headers = NULL, body = NULL, expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at < NOW()
+		RETURNING key
+	`, key, fingerprint, time.Now().Add(lockTTL).UTC()).Scan(&reserved)
+	if err == nil {
+		return nil, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, tracing.Error(span, err)
+	}
+
+	var record Record
+	var status sql.NullInt64
+	var headers []byte
+	var body []byte
+	err = s.db.QueryRowContext(ctx,
+		`SELECT fingerprint, status, headers, body FROM idempotency_keys WHERE key = $1`,
+		key,
+	).Scan(&record.Fingerprint, &status, &headers, &body)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	if status.Valid {
+		record.Response = &Response{Status: int(status.Int64), Body: body}
+		if err := json.Unmarshal(headers, &record.Response.Headers); err != nil {
+			return nil, tracing.Error(span, err)
+		}
+	}
+	return &record, nil
+}
+
+func (s *PostgresStore) Complete(ctx context.Context, key, fingerprint string, response Response, ttl time.Duration) error {
+	ctx, span := tracing.StartPostgres(ctx, "PostgresStore.Complete")
+	defer span.End()
+
+	headers, err := json.Marshal(response.Headers)
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+	_, err = s.db.ExecContext(ctx, `
+		UPDATE idempotency_keys SET status = $3, headers = $4, body = $5, expires_at = $6
+		WHERE key = $1 AND fingerprint = $2 AND status IS NULL
+	`, key, fingerprint, response.Status, headers, response.Body, time.Now().Add(ttl).UTC())
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+	return nil
+}
+
+func (s *PostgresStore) Release(ctx context.Context, key, fingerprint string) error {
+	ctx, span := tracing.StartPostgres(ctx, "PostgresStore.Release")
+	defer span.End()
+
+	_, err := s.db.ExecContext(ctx,
+		`DELETE FROM idempotency_keys WHERE key = $1 AND fingerprint = $2 AND status IS NULL`,
+		key, fingerprint,
+	)
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+	return nil
+}
+
+// sweep menghapus key kedaluwarsa paling sering sekali per sweepInterval
+// per instance. Kegagalan hanya dicatat karena Reserve tetap mengabaikan
+// baris yang kedaluwarsa.
+func (s *PostgresStore) sweep(ctx context.Context) {
+	s.mu.Lock()
+	if time.Since(s.lastSweep) < sweepInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.lastSweep = time.Now()
+	s.mu.Unlock()
+
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE expires_at < NOW()`); err != nil {
+		slog.WarnContext(ctx, "Gagal membersihkan idempotency_keys", "error", err)
+	}
+}
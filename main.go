@@ -3,19 +3,40 @@ package main
 import (
 	repositorymongo "go-fiber/app/repository/mongo"
 	servicemongo "go-fiber/app/service/mongo"
+	servicepostgre "go-fiber/app/service/postgre"
+	"go-fiber/audit"
 	"go-fiber/config"
 	configmongo "go-fiber/config/mongo"
 
 	"go-fiber/database"
 	_ "go-fiber/docs"
+	"go-fiber/health"
+	"go-fiber/i18n"
+	"go-fiber/idempotency"
+	"go-fiber/metrics"
 	"go-fiber/middleware"
+	middlewaremongo "go-fiber/middleware/mongo"
+	middlewarepostgre "go-fiber/middleware/postgre"
+	"go-fiber/outbox"
+	"go-fiber/queue"
+	"go-fiber/ratelimit"
 	routemongo "go-fiber/route/mongo"
 
 	routepostgre "go-fiber/route/postgre"
+	"go-fiber/tracing"
+	"go-fiber/webhook"
+	"go-fiber/worker"
+	"context"
+	"database/sql"
 	"log"
+	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	fiberSwagger "github.com/swaggo/fiber-swagger"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 // @title Alumni Management System API - MongoDB
@@ -42,48 +63,335 @@ import (
 // @tag.name 4. Files
 // @tag.description Operasi upload dan manajemen file
 
+// @tag.name 5. Analytics
+// @tag.description Statistik tracer study keterserapan kerja alumni (Admin only)
+
+// @tag.name 6. Export
+// @tag.description Export data ke CSV, XLSX, dan laporan PDF (Admin only)
+
+// @tag.name 7. Audit
+// @tag.description Riwayat perubahan data untuk admin
+
+// @tag.name 8. Webhooks
+// @tag.description Subscription webhook untuk domain event, log delivery, dan dead-letter (Admin only)
+
+// @tag.name 9. Jobs
+// @tag.description Background job, jadwal cron, retry, dan pembatalan (Admin only)
+
 func main() {
-	config.LoadEnv()
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+	slog.SetDefault(config.NewLogger(cfg.Log))
+	slog.Info("Configuration loaded", "config", cfg)
 	
-	postgresDB := database.ConnectDB()
-	defer postgresDB.Close()
+	// ctx dibatalkan saat SIGINT/SIGTERM diterima, termasuk selama migrasi.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 	
-	mongoDB := database.ConnectMongoDB()
+	shutdownTracing, err := tracing.Init(ctx)
+	if err != nil {
+		log.Fatalf("Tracing setup failed: %v", err)
+	}
 	
-	// Run MongoDB migrations
-	if err := database.RunMigrations(mongoDB); err != nil {
-		log.Fatalf("Migration failed: %v", err)
+	var postgresDB *sql.DB
+	if cfg.Postgres.Enabled {
+		postgresDB = database.ConnectDB(cfg.Postgres)
+		
+		if err := metrics.RegisterDBStats(postgresDB, "postgres"); err != nil {
+			slog.Warn("Gagal mendaftarkan metric pool PostgreSQL", "error", err)
+		}
+		
+		if err := database.RunPostgresMigrations(ctx, postgresDB); err != nil {
+			log.Fatalf("PostgreSQL migration failed: %v", err)
+		}
+	} else {
+		slog.Info("PostgreSQL stack disabled")
 	}
 	
+	var mongoDB *mongo.Database
+	if cfg.Mongo.Enabled {
+		mongoDB = database.ConnectMongoDB(cfg.Mongo)
+		
+		// Run MongoDB migrations
+		if err := database.RunMigrations(ctx, mongoDB); err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+	} else {
+		slog.Info("MongoDB stack disabled")
+	}
 	
-	alumniRepo := repositorymongo.NewAlumniRepository(mongoDB)
-	alumniService := servicemongo.NewAlumniService(alumniRepo)
+	// Domain event ditulis ke outbox di transaksi yang sama dengan perubahan
+	// data, lalu relay per database mengirimnya ke sink.
+	eventBus := outbox.NewBus()
+	eventBus.Subscribe("*", func(ctx context.Context, event outbox.Event) error {
+		slog.DebugContext(ctx, "Domain event diterbitkan", "event_id", event.ID, "type", event.Type, "resource_id", event.ResourceID)
+		return nil
+	})
+	mongoEvents := outbox.Discard
+	var relays []*outbox.Relay
+	var deliverers []*webhook.Deliverer
+	if cfg.Outbox.Enabled {
+		sinks, err := outbox.NewSinks(cfg.Outbox, eventBus)
+		if err != nil {
+			log.Fatalf("Outbox setup failed: %v", err)
+		}
+		// Subscription webhook disimpan di database masing-masing stack, jadi
+		// dispatcher-nya hanya ditambahkan ke relay database yang sama.
+		withWebhooks := func(name string, store webhook.Store) []outbox.Sink {
+			if !cfg.Webhooks.Enabled {
+				return sinks
+			}
+			deliverers = append(deliverers, webhook.NewDeliverer(name, store, cfg.Webhooks.BatchSize, cfg.Webhooks.MaxAttempts, cfg.Webhooks.Timeout))
+			return append(append([]outbox.Sink{}, sinks...), webhook.NewDispatcher(store))
+		}
+		if postgresDB != nil {
+			postgresSinks := withWebhooks("postgres", webhook.NewPostgresStore(postgresDB))
+			relays = append(relays, outbox.NewRelay("postgres", outbox.NewPostgresStore(postgresDB), postgresSinks, cfg.Outbox.BatchSize, cfg.Outbox.MaxAttempts, cfg.Outbox.Retention))
+		}
+		if mongoDB != nil {
+			// Setiap tulis MongoDB berjalan di transaksi selama outbox aktif,
+			// jadi gagal sekarang daripada menolak semua request nanti.
+			if err := database.CheckMongoTransactions(ctx, mongoDB); err != nil {
+				log.Fatalf("outbox.enabled requires a MongoDB replica set: %v", err)
+			}
+			store := outbox.NewMongoStore(mongoDB)
+			mongoEvents = store
+			mongoSinks := withWebhooks("mongo", webhook.NewMongoStore(mongoDB))
+			relays = append(relays, outbox.NewRelay("mongo", store, mongoSinks, cfg.Outbox.BatchSize, cfg.Outbox.MaxAttempts, cfg.Outbox.Retention))
+		}
+		for _, relay := range relays {
+			relay.Start(cfg.Outbox.Interval)
+		}
+		for _, deliverer := range deliverers {
+			deliverer.Start(cfg.Webhooks.Interval)
+		}
+	}
+	
+	// Background job disimpan di database masing-masing stack. Handler
+	// didaftarkan bersama route di bawah, lalu queue dijalankan setelahnya.
+	var postgresJobs, mongoJobs *queue.Queue
+	var queues []*queue.Queue
+	if cfg.Jobs.Enabled {
+		options := queue.Options{
+			Concurrency: cfg.Jobs.Concurrency,
+			MaxAttempts: cfg.Jobs.MaxAttempts,
+			Timeout:     cfg.Jobs.Timeout,
+			Retention:   cfg.Jobs.Retention,
+		}
+		var err error
+		if postgresDB != nil {
+			if postgresJobs, err = queue.New("postgres", queue.NewPostgresStore(postgresDB), options); err != nil {
+				log.Fatalf("Job setup failed: %v", err)
+			}
+			queues = append(queues, postgresJobs)
+		}
+		if mongoDB != nil {
+			if mongoJobs, err = queue.New("mongo", queue.NewMongoStore(mongoDB), options); err != nil {
+				log.Fatalf("Job setup failed: %v", err)
+			}
+			queues = append(queues, mongoJobs)
+		}
+	}
 	
-	authService := servicemongo.NewAuthService(alumniRepo)
+	rateLimits := ratelimit.Disabled()
+	if cfg.RateLimit.Enabled {
+		var store ratelimit.Store
+		switch cfg.RateLimit.Store {
+		case "postgres":
+			store = ratelimit.NewPostgresStore(postgresDB)
+		case "mongo":
+			store = ratelimit.NewMongoStore(mongoDB)
+		default:
+			store = ratelimit.NewMemoryStore()
+		}
+		rateLimits, err = ratelimit.NewRouteLimits(ratelimit.NewLimiter(store), cfg.RateLimit.Login, cfg.RateLimit.Check, cfg.RateLimit.Upload)
+		if err != nil {
+			log.Fatalf("Rate limit setup failed: %v", err)
+		}
+	}
 	
-	pekerjaanRepo := repositorymongo.NewPekerjaanAlumniRepository(mongoDB)
-	pekerjaanService := servicemongo.NewPekerjaanAlumniService(pekerjaanRepo)
+	idempotent := idempotency.Disabled()
+	if cfg.Idempotency.Enabled {
+		var store idempotency.Store
+		switch cfg.Idempotency.Store {
+		case "postgres":
+			store = idempotency.NewPostgresStore(postgresDB)
+		case "mongo":
+			store = idempotency.NewMongoStore(mongoDB)
+		default:
+			store = idempotency.NewMemoryStore()
+		}
+		idempotent = idempotency.New(store, cfg.Idempotency.TTL)
+	}
+	
+	healthChecker := health.NewChecker(2 * time.Second)
+	if postgresDB != nil {
+		healthChecker.Register("postgres", health.PingPostgres(postgresDB))
+		healthChecker.Register("postgres_migrations", func(ctx context.Context) error {
+			return database.CheckPostgresMigrations(ctx, postgresDB)
+		})
+	}
+	if mongoDB != nil {
+		healthChecker.Register("mongodb", health.PingMongo(mongoDB.Client()))
+		healthChecker.Register("upload_storage", health.WritableDir(cfg.Upload.Dir))
+		healthChecker.Register("mongodb_migrations", func(ctx context.Context) error {
+			return database.CheckMigrations(ctx, mongoDB)
+		})
+	}
 	
-	fileRepo := repositorymongo.NewFileRepository(mongoDB)
-	fileService := servicemongo.NewFileService(fileRepo, alumniRepo, "./uploads")
+	// requestCtx dibatalkan jika request masih berjalan setelah batas waktu
+	// shutdown habis, agar query database ikut berhenti.
+	requestCtx, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
 	
-	app := configmongo.NewApp()
+	app := configmongo.NewApp(cfg.App.BodyLimitMB, cfg.Upload.Dir)
+	app.Use(middleware.RequestID)
+	app.Use(i18n.Middleware)
+	app.Use(middleware.RequestContext(requestCtx))
+	app.Use(tracing.Middleware)
 	app.Use(middleware.LoggerMiddleware)
+	app.Use(metrics.Middleware)
+	
+	if postgresDB != nil {
+		authRequired := middlewarepostgre.AuthRequired(cfg.Auth.JWTSecret)
+		opts := servicepostgre.Options{RequireIfMatch: cfg.App.RequireIfMatch, Outbox: cfg.Outbox.Enabled}
+		
+		routepostgre.AlumniRoutes(app, postgresDB, cfg.Auth, rateLimits, idempotent, opts, authRequired)
+		routepostgre.PekerjaanRoutes(app, postgresDB, idempotent, opts, authRequired)
+		routepostgre.AnalyticsRoutes(app, postgresDB, authRequired)
+		routepostgre.ImportRoutes(app, postgresDB, authRequired)
+		exports, err := worker.NewExporter(postgresJobs, cfg.Export.Dir, cfg.Export.TTL, servicepostgre.ExportBuilder(postgresDB), queue.HandlerOptions{Concurrency: cfg.Export.Concurrency})
+		if err != nil {
+			log.Fatalf("Job setup failed: %v", err)
+		}
+		routepostgre.ExportRoutes(app, postgresDB, exports, authRequired)
+		routepostgre.AuditRoutes(app, postgresDB, authRequired)
+		routepostgre.BatchRoutes(app, postgresDB, opts, authRequired)
+		if cfg.Webhooks.Enabled {
+			routepostgre.WebhookRoutes(app, postgresDB, authRequired)
+		}
+		if postgresJobs != nil {
+			if cfg.Jobs.TrashRetention > 0 {
+				if err := servicepostgre.RegisterPurgeTrashJob(postgresJobs, postgresDB, opts, cfg.Jobs.TrashRetention); err != nil {
+					log.Fatalf("Job setup failed: %v", err)
+				}
+			}
+			routepostgre.JobRoutes(app, postgresJobs, authRequired)
+		}
+	}
+	
+	if mongoDB != nil {
+		auditStore := audit.NewMongoStore(mongoDB)
+		auditService := servicemongo.NewAuditService(auditStore)
+		
+		alumniRepo := repositorymongo.NewAlumniRepository(mongoDB)
+		alumniService := servicemongo.NewAlumniService(alumniRepo, cfg.Auth.APIKey, cfg.App.RequireIfMatch, auditStore, mongoEvents)
+		
+		authService := servicemongo.NewAuthService(alumniRepo, cfg.Auth.JWTSecret)
+		
+		pekerjaanRepo := repositorymongo.NewPekerjaanAlumniRepository(mongoDB)
+		pekerjaanService := servicemongo.NewPekerjaanAlumniService(pekerjaanRepo, cfg.App.RequireIfMatch, auditStore, mongoEvents)
+		
+		fileRepo := repositorymongo.NewFileRepository(mongoDB)
+		fileService := servicemongo.NewFileService(fileRepo, alumniRepo, cfg.Upload, auditStore, mongoEvents)
+		
+		analyticsRepo := repositorymongo.NewAnalyticsRepository(mongoDB)
+		analyticsService := servicemongo.NewAnalyticsService(analyticsRepo)
+		
+		importRepo := repositorymongo.NewImportRepository(mongoDB)
+		importService := servicemongo.NewImportService(importRepo, auditStore, mongoEvents)
+		
+		batchRepo := repositorymongo.NewBatchRepository(mongoDB)
+		batchService := servicemongo.NewBatchService(batchRepo, alumniRepo, pekerjaanRepo, auditStore, mongoEvents)
+		
+		exportRepo := repositorymongo.NewExportRepository(mongoDB)
+		exports, err := worker.NewExporter(mongoJobs, cfg.Export.Dir, cfg.Export.TTL, servicemongo.ExportBuilder(exportRepo, analyticsService), queue.HandlerOptions{Concurrency: cfg.Export.Concurrency})
+		if err != nil {
+			log.Fatalf("Job setup failed: %v", err)
+		}
+		exportService := servicemongo.NewExportService(exportRepo, exports)
+		
+		authRequired := middlewaremongo.AuthRequired(cfg.Auth.JWTSecret)
+		routemongo.AlumniRoutes(app, alumniService, authService, rateLimits, idempotent, authRequired)
+		routemongo.PekerjaanRoutes(app, pekerjaanService, idempotent, authRequired)
+		routemongo.FileRoutes(app, fileService, rateLimits, idempotent, authRequired)
+		routemongo.AnalyticsRoutes(app, analyticsService, authRequired)
+		routemongo.ImportRoutes(app, importService, authRequired)
+		routemongo.BatchRoutes(app, batchService, authRequired)
+		routemongo.ExportRoutes(app, exportService, authRequired)
+		routemongo.AuditRoutes(app, auditService, authRequired)
+		if cfg.Webhooks.Enabled {
+			routemongo.WebhookRoutes(app, servicemongo.NewWebhookService(webhook.NewMongoStore(mongoDB)), authRequired)
+		}
+		if mongoJobs != nil {
+			routemongo.JobRoutes(app, servicemongo.NewJobService(mongoJobs), authRequired)
+		}
+		
+		// Dokumentasi Swagger hanya berisi endpoint /go-fiber-mongo, jadi
+		// hanya disajikan saat stack MongoDB aktif.
+		app.Get("/swagger/*", fiberSwagger.WrapHandler)
+	}
+	
+	for _, q := range queues {
+		q.Start(cfg.Jobs.Interval)
+	}
 	
-	routepostgre.AlumniRoutes(app, postgresDB)
-	routepostgre.PekerjaanRoutes(app, postgresDB)
+	app.Get("/metrics", metrics.Handler())
+	app.Get("/healthz", healthChecker.Liveness)
+	app.Get("/readyz", healthChecker.Readiness)
 	
-	routemongo.AlumniRoutes(app, alumniService, authService)
-	routemongo.PekerjaanRoutes(app, pekerjaanService)
-	routemongo.FileRoutes(app, fileService)
+	go func() {
+		slog.Info("Server starting", "port", cfg.App.Port)
+		if err := app.Listen(":" + cfg.App.Port); err != nil {
+			log.Fatalf("Server failed: %v", err)
+		}
+	}()
 	
-	app.Get("/swagger/*", fiberSwagger.WrapHandler)
+	<-ctx.Done()
+	stop()
+	slog.Info("Shutdown signal received", "timeout", cfg.App.ShutdownTimeout.String())
 	
-	port := os.Getenv("APP_PORT")
-	if port == "" {
-		port = "3000"
+	// Readiness gagal lebih dulu supaya load balancer berhenti mengirim
+	// traffic, lalu request yang sedang berjalan ditunggu sampai timeout.
+	healthChecker.SetShuttingDown()
+	if err := app.ShutdownWithTimeout(cfg.App.ShutdownTimeout); err != nil {
+		slog.Error("Server shutdown timed out, cancelling in-flight requests", "error", err)
+	}
+	cancelRequests()
+	
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.App.ShutdownTimeout)
+	defer cancel()
+	
+	for _, relay := range relays {
+		if err := relay.Shutdown(shutdownCtx); err != nil {
+			slog.Warn("Outbox relay did not finish before shutdown", "error", err)
+		}
+	}
+	for _, deliverer := range deliverers {
+		if err := deliverer.Shutdown(shutdownCtx); err != nil {
+			slog.Warn("Webhook deliverer did not finish before shutdown", "error", err)
+		}
+	}
+	for _, q := range queues {
+		if err := q.Shutdown(shutdownCtx); err != nil {
+			slog.Warn("Background jobs did not finish before shutdown", "error", err)
+		}
+	}
+	if mongoDB != nil {
+		if err := mongoDB.Client().Disconnect(shutdownCtx); err != nil {
+			slog.Error("Failed to disconnect MongoDB", "error", err)
+		}
+	}
+	if postgresDB != nil {
+		if err := postgresDB.Close(); err != nil {
+			slog.Error("Failed to close PostgreSQL", "error", err)
+		}
+	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Error("Failed to flush traces", "error", err)
 	}
 	
-	log.Printf("Server starting on port %s", port)
-	log.Fatal(app.Listen(":" + port))
+	slog.Info("Server stopped")
 }
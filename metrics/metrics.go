@@ -0,0 +1,130 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	"go-fiber/apperror"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+var (
+	httpRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Jumlah request HTTP per method, route, dan status.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Lama pemrosesan request HTTP per method, route, dan status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	mongoCommandDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "mongodb_command_duration_seconds",
+		Help:    "Lama eksekusi command MongoDB per nama command dan hasil.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"command", "result"})
+
+	uploadsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "file_uploads_total",
+		Help: "Jumlah upload file per kategori dan hasil.",
+	}, []string{"category", "result"})
+
+	uploadBytesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "file_upload_bytes_total",
+		Help: "Total byte file yang berhasil diupload per kategori.",
+	}, []string{"category"})
+
+	loginsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "auth_logins_total",
+		Help: "Jumlah percobaan login per stack dan hasil.",
+	}, []string{"stack", "result"})
+
+	rateLimitTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rate_limit_requests_total",
+		Help: "Jumlah request yang diperiksa rate limiter per policy dan hasil.",
+	}, []string{"policy", "result"})
+)
+
+// Middleware mencatat jumlah dan latency request dengan label template route
+// (misalnya /go-fiber-mongo/alumni/:id) agar kardinalitas label tetap kecil.
+func Middleware(c *fiber.Ctx) error {
+	start := time.Now()
+	err := c.Next()
+
+	status := apperror.ResponseStatus(c, err)
+
+	route := c.Route().Path
+	if status == fiber.StatusNotFound && route == "/" && c.Path() != "/" {
+		route = "unmatched"
+	}
+
+	labels := prometheus.Labels{"method": c.Method(), "route": route, "status": strconv.Itoa(status)}
+	httpRequestsTotal.With(labels).Inc()
+	httpRequestDuration.With(labels).Observe(time.Since(start).Seconds())
+
+	return err
+}
+
+// Handler mengekspos seluruh metric dalam format Prometheus.
+func Handler() fiber.Handler {
+	return adaptor.HTTPHandler(promhttp.Handler())
+}
+
+// RegisterDBStats mengekspos statistik pool koneksi database/sql dengan
+// label db_name.
+func RegisterDBStats(db *sql.DB, name string) error {
+	return prometheus.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// MongoCommandMonitor mencatat durasi setiap command MongoDB. Dipasang lewat
+// options.Client().SetMonitor.
+func MongoCommandMonitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Succeeded: func(_ context.Context, evt *event.CommandSucceededEvent) {
+			mongoCommandDuration.WithLabelValues(evt.CommandName, "success").Observe(evt.Duration.Seconds())
+		},
+		Failed: func(_ context.Context, evt *event.CommandFailedEvent) {
+			mongoCommandDuration.WithLabelValues(evt.CommandName, "failure").Observe(evt.Duration.Seconds())
+		},
+	}
+}
+
+// ObserveUpload mencatat hasil upload berdasarkan status response. size hanya
+// ditambahkan untuk upload yang berhasil.
+func ObserveUpload(category string, status int, size int64) {
+	if status >= 200 && status < 300 {
+		uploadsTotal.WithLabelValues(category, "success").Inc()
+		uploadBytesTotal.WithLabelValues(category).Add(float64(size))
+		return
+	}
+	uploadsTotal.WithLabelValues(category, "failure").Inc()
+}
+
+// ObserveLogin mencatat login berhasil (status 200) atau gagal.
+func ObserveLogin(stack string, status int) {
+	result := "failure"
+	if status == fiber.StatusOK {
+		result = "success"
+	}
+	loginsTotal.WithLabelValues(stack, result).Inc()
+}
+
+// ObserveRateLimit mencatat request yang diizinkan atau ditolak rate limiter.
+func ObserveRateLimit(policy string, allowed bool) {
+	result := "rejected"
+	if allowed {
+		result = "allowed"
+	}
+	rateLimitTotal.WithLabelValues(policy, result).Inc()
+}
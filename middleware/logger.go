@@ -1,11 +1,56 @@
 package middleware
 
 import (
-	"fmt"
+	"go-fiber/apperror"
+	"go-fiber/tracing"
+	"log/slog"
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 )
 
+// LoggerMiddleware menulis access log terstruktur untuk setiap request,
+// termasuk status, latency, dan user yang login. Dipasang setelah RequestID
+// dan tracing.Middleware agar request_id dan trace_id ikut tercatat.
 func LoggerMiddleware(c *fiber.Ctx) error {
-	fmt.Println("Request:", c.Method(), c.Path())
-	return c.Next()
+	start := time.Now()
+	err := c.Next()
+
+	status := apperror.ResponseStatus(c, err)
+
+	attrs := []slog.Attr{
+		slog.String("request_id", GetRequestID(c)),
+		slog.String("method", c.Method()),
+		slog.String("path", c.Path()),
+		slog.Int("status", status),
+		slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+		slog.String("ip", c.IP()),
+	}
+	// Body stream (misalnya export) tidak dibaca agar tidak dimuat ke memori.
+	if !c.Response().IsBodyStream() {
+		attrs = append(attrs, slog.Int("bytes", len(c.Response().Body())))
+	}
+	if traceID := tracing.TraceID(c.UserContext()); traceID != "" {
+		attrs = append(attrs, slog.String("trace_id", traceID))
+	}
+	if userID := c.Locals("alumni_id"); userID != nil {
+		attrs = append(attrs, slog.Any("user_id", userID))
+	}
+	if role, ok := c.Locals("role").(string); ok {
+		attrs = append(attrs, slog.String("role", role))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	level := slog.LevelInfo
+	switch {
+	case status >= 500:
+		level = slog.LevelError
+	case status >= 400:
+		level = slog.LevelWarn
+	}
+	slog.LogAttrs(c.UserContext(), level, "request", attrs...)
+
+	return err
 }
@@ -1,35 +1,29 @@
 package middleware
 
 import (
+	"go-fiber/apperror"
 	utilsmongo "go-fiber/utils/mongo"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func AuthRequired() fiber.Handler {
+// AuthRequired memvalidasi token Bearer dengan secret dari
+// config.AuthConfig.JWTSecret lalu mengisi locals alumni_id, email, dan role.
+func AuthRequired(secret string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"success": false,
-				"message": "Token akses diperlukan. Tambahkan header 'Authorization: Bearer YOUR_TOKEN'.",
-			})
+			return apperror.Unauthorized("auth.token_required")
 		}
 
 		tokenString := utilsmongo.ExtractTokenFromHeader(authHeader)
 		if tokenString == "" {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"success": false,
-				"message": "Format token tidak valid. Gunakan format 'Bearer YOUR_TOKEN'.",
-			})
+			return apperror.Unauthorized("auth.token_format_invalid")
 		}
 
-		claims, err := utilsmongo.ValidateToken(tokenString)
+		claims, err := utilsmongo.ValidateToken(secret, tokenString)
 		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"success": false,
-				"message": "Token tidak valid atau sudah expired. Silakan login ulang untuk mendapatkan token baru.",
-			})
+			return apperror.Unauthorized("auth.token_invalid")
 		}
 
 		c.Locals("alumni_id", claims.AlumniID)
@@ -44,10 +38,7 @@ func AdminOnly() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		role := c.Locals("role").(string)
 		if role != "admin" {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"message": "Akses ditolak. Hanya admin yang dapat mengakses endpoint ini.",
-			})
+			return apperror.Forbidden("auth.admin_only")
 		}
 		return c.Next()
 	}
@@ -57,10 +48,7 @@ func UserOrAdmin() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		role := c.Locals("role").(string)
 		if role != "admin" && role != "user" {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"message": "Akses ditolak. Role tidak valid. Gunakan role 'admin' atau 'user'.",
-			})
+			return apperror.Forbidden("auth.invalid_role")
 		}
 		return c.Next()
 	}
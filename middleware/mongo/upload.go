@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"go-fiber/apperror"
 )
 
 func ValidateAlumniAccess() fiber.Handler {
@@ -18,18 +19,12 @@ func ValidateAlumniAccess() fiber.Handler {
 		if role == "user" {
 			// Cek dari URL parameter (untuk GET /files/alumni/:alumni_id)
 			if alumniIDFromParam != "" && alumniIDFromParam != alumniIDFromToken {
-				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-					"success": false,
-					"message": "Anda hanya bisa menambahkan foto dan sertifikat sendiri",
-				})
+				return apperror.Forbidden("file.upload_forbidden")
 			}
 
 			// Cek dari form data (untuk upload file)
 			if alumniIDFromForm != "" && alumniIDFromForm != alumniIDFromToken {
-				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-					"success": false,
-					"message": "Anda hanya bisa menambahkan foto dan sertifikat diri anda sendiri",
-				})
+				return apperror.Forbidden("file.upload_forbidden")
 			}
 		}
 
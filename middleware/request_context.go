@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequestContext memberi setiap request context yang dibatalkan saat handler
+// selesai atau saat base dibatalkan (misalnya ketika batas waktu shutdown
+// habis), sehingga query yang masih berjalan ikut dihentikan. Service membaca
+// context ini lewat c.UserContext().
+//
+// Client yang memutus koneksi tidak membatalkan context ini: fasthttp baru
+// mengetahui koneksi putus saat response ditulis, setelah handler selesai.
+// Query dari request yang ditinggal client tetap berjalan sampai selesai atau
+// sampai shutdown.
+func RequestContext(base context.Context) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx, cancel := context.WithCancel(c.UserContext())
+		defer cancel()
+
+		stop := context.AfterFunc(base, cancel)
+		defer stop()
+
+		c.SetUserContext(ctx)
+		return c.Next()
+	}
+}
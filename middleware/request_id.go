@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"regexp"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDPattern membatasi request ID dari client agar aman ditulis ke log.
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+// RequestID memakai header X-Request-ID dari client jika valid atau membuat
+// UUID baru, lalu menyimpannya di Locals("request_id") dan header response.
+func RequestID(c *fiber.Ctx) error {
+	requestID := c.Get(RequestIDHeader)
+	if !requestIDPattern.MatchString(requestID) {
+		requestID = uuid.New().String()
+	}
+
+	c.Locals("request_id", requestID)
+	c.Set(RequestIDHeader, requestID)
+	return c.Next()
+}
+
+// GetRequestID mengembalikan request ID yang disimpan middleware RequestID.
+func GetRequestID(c *fiber.Ctx) string {
+	requestID, _ := c.Locals("request_id").(string)
+	return requestID
+}
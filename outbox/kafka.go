@@ -0,0 +1,77 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// KafkaSink mengirim event ke satu topic lewat Kafka REST Proxy (API v2),
+// yang juga didukung Redpanda. Key record berisi resource dan resource_id
+// sehingga event satu resource masuk ke partisi yang sama.
+type KafkaSink struct {
+	endpoint string
+	client   *http.Client
+}
+
+func NewKafkaSink(baseURL, topic string) *KafkaSink {
+	endpoint := strings.TrimRight(baseURL, "/") + "/topics/" + url.PathEscape(topic)
+	return &KafkaSink{endpoint: endpoint, client: &http.Client{Timeout: httpTimeout}}
+}
+
+func (s *KafkaSink) Name() string {
+	return "kafka"
+}
+
+type kafkaRecord struct {
+	Key   string `json:"key"`
+	Value Event  `json:"value"`
+}
+
+type kafkaResponse struct {
+	Offsets []struct {
+		ErrorCode *int   `json:"error_code"`
+		Error     string `json:"error"`
+	} `json:"offsets"`
+}
+
+func (s *KafkaSink) Publish(ctx context.Context, event Event) error {
+	body, err := json.Marshal(map[string][]kafkaRecord{
+		"records": {{Key: event.Resource + ":" + event.ResourceID, Value: event}},
+	})
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/vnd.kafka.json.v2+json")
+	request.Header.Set("Accept", "application/vnd.kafka.v2+json")
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("kafka rest proxy membalas %d", response.StatusCode)
+	}
+
+	// REST Proxy bisa membalas 200 walaupun record tertentu gagal ditulis;
+	// kegagalannya ada di error_code per offset.
+	var result kafkaResponse
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return fmt.Errorf("response kafka rest proxy tidak valid: %w", err)
+	}
+	for _, offset := range result.Offsets {
+		if offset.ErrorCode != nil {
+			return fmt.Errorf("kafka menolak record (%d): %s", *offset.ErrorCode, offset.Error)
+		}
+	}
+	return nil
+}
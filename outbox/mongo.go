@@ -0,0 +1,145 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-fiber/tracing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoStore menyimpan event di collection outbox_events. Index-nya dibuat
+// oleh RunMigrations. Transaction membutuhkan replica set atau sharded
+// cluster, sama seperti import dan batch.
+type MongoStore struct {
+	client     *mongo.Client
+	collection *mongo.Collection
+}
+
+func NewMongoStore(db *mongo.Database) *MongoStore {
+	return &MongoStore{client: db.Client(), collection: db.Collection("outbox_events")}
+}
+
+type mongoEvent struct {
+	Event         `bson:",inline"`
+	Status        string     `bson:"status"`
+	Attempts      int        `bson:"attempts"`
+	LastError     string     `bson:"last_error,omitempty"`
+	NextAttemptAt time.Time  `bson:"next_attempt_at"`
+	PublishedAt   *time.Time `bson:"published_at,omitempty"`
+}
+
+func (s *MongoStore) Append(ctx context.Context, events ...Event) error {
+	ctx, span := tracing.StartMongo(ctx, "outbox.MongoStore.Append")
+	defer span.End()
+
+	if len(events) == 0 {
+		return nil
+	}
+	documents := make([]interface{}, len(events))
+	for i, event := range events {
+		documents[i] = mongoEvent{Event: event, Status: StatusPending, NextAttemptAt: event.OccurredAt}
+	}
+	_, err := s.collection.InsertMany(ctx, documents)
+	return tracing.Error(span, err)
+}
+
+// Transaction menjalankan fn dan menyimpan event-nya dalam satu transaksi.
+// Jika ctx sudah berada di dalam transaksi (misalnya batch), fn dan event
+// ikut transaksi tersebut. fn bisa dijalankan ulang jika transaksi gagal
+// sementara, jadi tidak boleh menyimpan state di luar selain hasil akhirnya.
+func (s *MongoStore) Transaction(ctx context.Context, fn func(ctx context.Context) ([]Event, error)) error {
+	run := func(ctx context.Context) error {
+		events, err := fn(ctx)
+		if err != nil {
+			return err
+		}
+		return s.Append(ctx, events...)
+	}
+	if session := mongo.SessionFromContext(ctx); session != nil {
+		return run(mongo.NewSessionContext(ctx, session))
+	}
+
+	session, err := s.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, run(sc)
+	})
+	return err
+}
+
+// Claim mengambil event satu per satu dengan FindOneAndUpdate sehingga
+// setiap event hanya didapat oleh satu relay selama lease.
+func (s *MongoStore) Claim(ctx context.Context, limit int, lease time.Duration) ([]Record, error) {
+	ctx, span := tracing.StartMongo(ctx, "outbox.MongoStore.Claim")
+	defer span.End()
+
+	now := time.Now()
+	filter := bson.M{"status": StatusPending, "next_attempt_at": bson.M{"$lte": now}}
+	update := bson.M{"$set": bson.M{"next_attempt_at": now.Add(lease)}}
+	opts := options.FindOneAndUpdate().SetSort(bson.D{{Key: "occurred_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	var records []Record
+	for len(records) < limit {
+		var document mongoEvent
+		err := s.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&document)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			break
+		}
+		if err != nil {
+			return records, tracing.Error(span, err)
+		}
+		records = append(records, Record{Event: document.Event, Attempts: document.Attempts})
+	}
+	return records, nil
+}
+
+func (s *MongoStore) MarkPublished(ctx context.Context, id string) error {
+	ctx, span := tracing.StartMongo(ctx, "outbox.MongoStore.MarkPublished")
+	defer span.End()
+
+	update := bson.M{
+		"$set":   bson.M{"status": StatusPublished, "published_at": time.Now()},
+		"$inc":   bson.M{"attempts": 1},
+		"$unset": bson.M{"last_error": ""},
+	}
+	_, err := s.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	return tracing.Error(span, err)
+}
+
+func (s *MongoStore) MarkFailed(ctx context.Context, id string, attempts int, next time.Time, lastError string, dead bool) error {
+	ctx, span := tracing.StartMongo(ctx, "outbox.MongoStore.MarkFailed")
+	defer span.End()
+
+	status := StatusPending
+	if dead {
+		status = StatusDead
+	}
+	update := bson.M{"$set": bson.M{
+		"status":          status,
+		"attempts":        attempts,
+		"next_attempt_at": next,
+		"last_error":      lastError,
+	}}
+	_, err := s.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	return tracing.Error(span, err)
+}
+
+func (s *MongoStore) Purge(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := tracing.StartMongo(ctx, "outbox.MongoStore.Purge")
+	defer span.End()
+
+	result, err := s.collection.DeleteMany(ctx, bson.M{"status": StatusPublished, "published_at": bson.M{"$lt": before}})
+	if err != nil {
+		return 0, tracing.Error(span, err)
+	}
+	return result.DeletedCount, nil
+}
@@ -0,0 +1,227 @@
+package outbox
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// natsTimeout dipakai jika ctx Publish tidak punya deadline.
+const natsTimeout = 10 * time.Second
+
+// NATSSink menerbitkan event ke NATS dengan protokol teks NATS langsung,
+// tanpa library client. Subject berupa prefix diikuti tipe event, misalnya
+// go-fiber.events.alumni.created. Setiap publish diakhiri PING dan menunggu
+// PONG sehingga error dari server (misalnya izin subject) ikut terbaca. Jika
+// server mendukung header, Nats-Msg-Id diisi ID event agar JetStream bisa
+// membuang duplikat dari pengiriman ulang.
+type NATSSink struct {
+	url    *url.URL
+	prefix string
+
+	mu      sync.Mutex
+	conn    net.Conn
+	reader  *bufio.Reader
+	headers bool
+}
+
+// NewNATSSink menerima URL nats://[user:pass@]host[:port] atau tls://...
+// Koneksi dibuat saat event pertama dikirim.
+func NewNATSSink(rawURL, subjectPrefix string) (*NATSSink, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Scheme != "nats" && parsed.Scheme != "tls" {
+		return nil, fmt.Errorf("skema URL NATS harus nats atau tls, bukan %q", parsed.Scheme)
+	}
+	if parsed.Port() == "" {
+		parsed.Host = net.JoinHostPort(parsed.Hostname(), "4222")
+	}
+	return &NATSSink{url: parsed, prefix: strings.Trim(subjectPrefix, ".")}, nil
+}
+
+func (s *NATSSink) Name() string {
+	return "nats"
+}
+
+// Subject mengembalikan subject NATS untuk tipe event.
+func (s *NATSSink) Subject(eventType string) string {
+	if s.prefix == "" {
+		return eventType
+	}
+	return s.prefix + "." + eventType
+}
+
+func (s *NATSSink) Publish(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		if err := s.connect(ctx); err != nil {
+			return err
+		}
+	}
+	s.setDeadline(ctx)
+
+	var message []byte
+	subject := s.Subject(event.Type)
+	if s.headers {
+		header := "NATS/1.0\r\nNats-Msg-Id: " + event.ID + "\r\n\r\n"
+		message = fmt.Appendf(nil, "HPUB %s %d %d\r\n%s", subject, len(header), len(header)+len(payload), header)
+	} else {
+		message = fmt.Appendf(nil, "PUB %s %d\r\n", subject, len(payload))
+	}
+	message = append(message, payload...)
+	message = append(message, "\r\nPING\r\n"...)
+
+	if _, err := s.conn.Write(message); err != nil {
+		s.closeConn()
+		return err
+	}
+	if err := s.waitPong(); err != nil {
+		s.closeConn()
+		return err
+	}
+	return nil
+}
+
+// Close menutup koneksi ke server jika ada.
+func (s *NATSSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closeConn()
+}
+
+type natsInfo struct {
+	Headers     bool `json:"headers"`
+	TLSRequired bool `json:"tls_required"`
+}
+
+type natsConnect struct {
+	Verbose  bool   `json:"verbose"`
+	Pedantic bool   `json:"pedantic"`
+	Name     string `json:"name"`
+	Lang     string `json:"lang"`
+	Version  string `json:"version"`
+	Protocol int    `json:"protocol"`
+	Headers  bool   `json:"headers"`
+	User     string `json:"user,omitempty"`
+	Pass     string `json:"pass,omitempty"`
+	Token    string `json:"auth_token,omitempty"`
+}
+
+func (s *NATSSink) connect(ctx context.Context) error {
+	dialer := net.Dialer{Timeout: natsTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", s.url.Host)
+	if err != nil {
+		return err
+	}
+	s.conn = conn
+	s.reader = bufio.NewReader(conn)
+	s.setDeadline(ctx)
+
+	line, err := s.reader.ReadString('\n')
+	if err != nil {
+		s.closeConn()
+		return err
+	}
+	infoJSON, ok := strings.CutPrefix(strings.TrimSpace(line), "INFO ")
+	if !ok {
+		s.closeConn()
+		return fmt.Errorf("server NATS tidak mengirim INFO: %q", line)
+	}
+	var info natsInfo
+	if err := json.Unmarshal([]byte(infoJSON), &info); err != nil {
+		s.closeConn()
+		return err
+	}
+
+	if s.url.Scheme == "tls" || info.TLSRequired {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: s.url.Hostname(), MinVersion: tls.VersionTLS12})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			s.closeConn()
+			return err
+		}
+		s.conn = tlsConn
+		s.reader = bufio.NewReader(tlsConn)
+	}
+	s.headers = info.Headers
+
+	options := natsConnect{Name: "go-fiber", Lang: "go", Version: "1.0.0", Protocol: 1, Headers: info.Headers}
+	if user := s.url.User; user != nil {
+		if pass, ok := user.Password(); ok {
+			options.User, options.Pass = user.Username(), pass
+		} else {
+			options.Token = user.Username()
+		}
+	}
+	connectJSON, err := json.Marshal(options)
+	if err != nil {
+		s.closeConn()
+		return err
+	}
+	if _, err := fmt.Fprintf(s.conn, "CONNECT %s\r\nPING\r\n", connectJSON); err != nil {
+		s.closeConn()
+		return err
+	}
+	// PONG pertama memastikan CONNECT diterima, termasuk autentikasinya.
+	if err := s.waitPong(); err != nil {
+		s.closeConn()
+		return err
+	}
+	return nil
+}
+
+// waitPong membaca balasan server sampai PONG. PING dari server dibalas,
+// INFO dan +OK diabaikan, -ERR dikembalikan sebagai error.
+func (s *NATSSink) waitPong() error {
+	for {
+		line, err := s.reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := s.conn.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return errors.New("server NATS: " + strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "-ERR")), "'"))
+		}
+	}
+}
+
+func (s *NATSSink) setDeadline(ctx context.Context) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(natsTimeout)
+	}
+	s.conn.SetDeadline(deadline)
+}
+
+func (s *NATSSink) closeConn() error {
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	s.reader = nil
+	return err
+}
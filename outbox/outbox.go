@@ -0,0 +1,137 @@
+// Package outbox menerbitkan domain event seperti alumni.created atau
+// pekerjaan.updated ke sistem lain. Event ditulis ke tabel/collection
+// outbox_events dalam transaksi yang sama dengan perubahan datanya, lalu
+// Relay mengirimnya ke sink (bus in-process, NATS, Kafka REST Proxy, webhook)
+// di background. Pengiriman bersifat at-least-once: event yang gagal dikirim
+// diulang dengan backoff, jadi consumer harus mengabaikan ID yang sudah
+// pernah diterima.
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"go-fiber/audit"
+
+	"github.com/google/uuid"
+)
+
+// Status event di outbox.
+const (
+	StatusPending   = "pending"
+	StatusPublished = "published"
+	// StatusDead menandai event yang tetap gagal setelah MaxAttempts dan
+	// tidak dicoba lagi. Event ini tetap tersimpan untuk ditelusuri.
+	StatusDead = "dead"
+)
+
+// Event adalah satu domain event. Type berformat resource.aksi, misalnya
+// alumni.created. Data berisi snapshot resource setelah perubahan, atau
+// sebelum dihapus untuk event deleted.
+type Event struct {
+	ID         string                 `json:"id" bson:"_id"`
+	Type       string                 `json:"type" bson:"type"`
+	Resource   string                 `json:"resource" bson:"resource"`
+	ResourceID string                 `json:"resource_id" bson:"resource_id"`
+	Action     string                 `json:"action" bson:"action"`
+	Data       map[string]interface{} `json:"data,omitempty" bson:"data,omitempty"`
+	Changes    []audit.Change         `json:"changes,omitempty" bson:"changes,omitempty"`
+	ActorID    string                 `json:"actor_id,omitempty" bson:"actor_id,omitempty"`
+	RequestID  string                 `json:"request_id,omitempty" bson:"request_id,omitempty"`
+	OccurredAt time.Time              `json:"occurred_at" bson:"occurred_at"`
+}
+
+// Record adalah event yang diambil Relay beserta jumlah percobaan kirim
+// sebelumnya.
+type Record struct {
+	Event
+	Attempts int
+}
+
+// Store menyimpan event dan status pengirimannya.
+type Store interface {
+	Append(ctx context.Context, events ...Event) error
+	// Claim mengambil paling banyak limit event pending yang sudah waktunya
+	// dikirim, terlama lebih dulu, dan menahannya selama lease agar tidak
+	// diambil relay lain. Event yang tidak ditandai sebelum lease habis
+	// akan diambil lagi.
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]Record, error)
+	MarkPublished(ctx context.Context, id string) error
+	// MarkFailed mencatat percobaan yang gagal. Event dicoba lagi pada next,
+	// atau berhenti dengan status dead jika dead bernilai true.
+	MarkFailed(ctx context.Context, id string, attempts int, next time.Time, lastError string, dead bool) error
+	// Purge menghapus event published yang lebih lama dari before.
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Transactor menjalankan fn lalu menyimpan event yang dikembalikannya dalam
+// satu transaksi, sehingga event tidak pernah tersimpan tanpa perubahan
+// datanya atau sebaliknya. Dipakai stack MongoDB; stack PostgreSQL menulis
+// event langsung di transaksi audit.
+type Transactor interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) ([]Event, error)) error
+}
+
+// Discard adalah Transactor untuk outbox yang tidak aktif: fn dijalankan
+// tanpa transaksi dan event-nya dibuang.
+var Discard Transactor = discard{}
+
+type discard struct{}
+
+func (discard) Transaction(ctx context.Context, fn func(ctx context.Context) ([]Event, error)) error {
+	_, err := fn(ctx)
+	return err
+}
+
+// eventVerbs memetakan aksi audit ke akhiran tipe event. Import dan revert
+// diterbitkan sebagai created dan updated karena bagi consumer hasilnya sama;
+// aksi aslinya tetap ada di field Action.
+var eventVerbs = map[string]string{
+	audit.ActionCreate:     "created",
+	audit.ActionUpdate:     "updated",
+	audit.ActionDelete:     "deleted",
+	audit.ActionSoftDelete: "soft_deleted",
+	audit.ActionHardDelete: "deleted",
+	audit.ActionRestore:    "restored",
+	audit.ActionImport:     "created",
+	audit.ActionRevert:     "updated",
+}
+
+// EventType mengembalikan tipe event untuk aksi audit pada resource,
+// misalnya alumni.created. File yang dibuat diterbitkan sebagai
+// file.uploaded.
+func EventType(resource, action string) string {
+	if resource == audit.ResourceFile && action == audit.ActionCreate {
+		return "file.uploaded"
+	}
+	verb, ok := eventVerbs[action]
+	if !ok {
+		verb = action
+	}
+	return resource + "." + verb
+}
+
+// FromEntries membentuk event dari entry audit sebuah perubahan. Snapshot
+// entry sudah tanpa field rahasia, jadi aman dikirim ke luar.
+func FromEntries(entries ...audit.Entry) []Event {
+	events := make([]Event, 0, len(entries))
+	for _, entry := range entries {
+		data := entry.After
+		if data == nil {
+			data = entry.Before
+		}
+		events = append(events, Event{
+			ID:         uuid.New().String(),
+			Type:       EventType(entry.Resource, entry.Action),
+			Resource:   entry.Resource,
+			ResourceID: entry.ResourceID,
+			Action:     entry.Action,
+			Data:       data,
+			Changes:    entry.Changes,
+			ActorID:    entry.ActorID,
+			RequestID:  entry.RequestID,
+			OccurredAt: entry.CreatedAt,
+		})
+	}
+	return events
+}
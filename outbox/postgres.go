@@ -0,0 +1,136 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"go-fiber/tracing"
+)
+
+// DBTX dipenuhi oleh *sql.DB dan *sql.Tx.
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// PostgresStore menyimpan event di tabel outbox_events (dibuat oleh
+// RunPostgresMigrations). Dengan *sql.Tx event ikut tersimpan atau batal
+// bersama perubahan datanya.
+type PostgresStore struct {
+	db DBTX
+}
+
+func NewPostgresStore(db DBTX) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+func (s *PostgresStore) Append(ctx context.Context, events ...Event) error {
+	ctx, span := tracing.StartPostgres(ctx, "outbox.PostgresStore.Append")
+	defer span.End()
+
+	query := `
+		INSERT INTO outbox_events (id, type, resource, resource_id, payload, occurred_at, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)
+	`
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return tracing.Error(span, err)
+		}
+		_, err = s.db.ExecContext(ctx, query, event.ID, event.Type, event.Resource, event.ResourceID, payload, event.OccurredAt)
+		if err != nil {
+			return tracing.Error(span, err)
+		}
+	}
+	return nil
+}
+
+// Claim memakai FOR UPDATE SKIP LOCKED sehingga beberapa instance bisa
+// menjalankan relay bersamaan tanpa mengambil event yang sama. Lease
+// disimpan dengan memundurkan next_attempt_at.
+func (s *PostgresStore) Claim(ctx context.Context, limit int, lease time.Duration) ([]Record, error) {
+	ctx, span := tracing.StartPostgres(ctx, "outbox.PostgresStore.Claim")
+	defer span.End()
+
+	rows, err := s.db.QueryContext(ctx, `
+		UPDATE outbox_events SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM outbox_events
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY occurred_at, id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING payload, attempts
+	`, limit, time.Now().Add(lease).UTC())
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	defer rows.Close()
+
+	var records []Record
+	for rows.Next() {
+		var payload []byte
+		var record Record
+		if err := rows.Scan(&payload, &record.Attempts); err != nil {
+			return nil, tracing.Error(span, err)
+		}
+		if err := json.Unmarshal(payload, &record.Event); err != nil {
+			return nil, tracing.Error(span, err)
+		}
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	// RETURNING tidak menjamin urutan, jadi diurutkan ulang.
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].OccurredAt.Before(records[j].OccurredAt)
+	})
+	return records, nil
+}
+
+func (s *PostgresStore) MarkPublished(ctx context.Context, id string) error {
+	ctx, span := tracing.StartPostgres(ctx, "outbox.PostgresStore.MarkPublished")
+	defer span.End()
+
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE outbox_events SET status = 'published', attempts = attempts + 1, last_error = NULL, published_at = NOW()
+		WHERE id = $1
+	`, id)
+	return tracing.Error(span, err)
+}
+
+func (s *PostgresStore) MarkFailed(ctx context.Context, id string, attempts int, next time.Time, lastError string, dead bool) error {
+	ctx, span := tracing.StartPostgres(ctx, "outbox.PostgresStore.MarkFailed")
+	defer span.End()
+
+	status := StatusPending
+	if dead {
+		status = StatusDead
+	}
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE outbox_events SET status = $2, attempts = $3, next_attempt_at = $4, last_error = $5
+		WHERE id = $1
+	`, id, status, attempts, next.UTC(), lastError)
+	return tracing.Error(span, err)
+}
+
+func (s *PostgresStore) Purge(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := tracing.StartPostgres(ctx, "outbox.PostgresStore.Purge")
+	defer span.End()
+
+	result, err := s.db.ExecContext(ctx,
+		`DELETE FROM outbox_events WHERE status = 'published' AND published_at < $1`,
+		before.UTC(),
+	)
+	if err != nil {
+		return 0, tracing.Error(span, err)
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,179 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const (
+	// claimLease harus lebih lama dari waktu kirim satu batch; event yang
+	// belum ditandai setelah lease habis (misalnya instance mati) diambil lagi.
+	claimLease    = 5 * time.Minute
+	purgeInterval = time.Hour
+	maxBackoff    = time.Hour
+)
+
+// Relay mengambil event pending dari Store dan mengirimnya ke semua sink.
+// Event baru ditandai published setelah semua sink menerimanya; jika satu
+// sink gagal, event dikirim ulang ke semua sink pada percobaan berikutnya.
+type Relay struct {
+	name        string
+	store       Store
+	sinks       []Sink
+	batchSize   int
+	maxAttempts int
+	retention   time.Duration
+
+	ctx       context.Context
+	cancel    context.CancelFunc
+	stop      chan struct{}
+	done      chan struct{}
+	lastPurge time.Time
+}
+
+// NewRelay membuat relay untuk store. name (misalnya postgres atau mongo)
+// hanya dipakai di log. Event published yang lebih lama dari retention
+// dihapus berkala; retention 0 berarti tidak pernah dihapus.
+func NewRelay(name string, store Store, sinks []Sink, batchSize, maxAttempts int, retention time.Duration) *Relay {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Relay{
+		name:        name,
+		store:       store,
+		sinks:       sinks,
+		batchSize:   batchSize,
+		maxAttempts: maxAttempts,
+		retention:   retention,
+		ctx:         ctx,
+		cancel:      cancel,
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+		lastPurge:   time.Now(),
+	}
+}
+
+// Start menjalankan relay di goroutine terpisah. Outbox diperiksa setiap
+// interval, atau langsung lagi jika batch sebelumnya penuh.
+func (r *Relay) Start(interval time.Duration) {
+	go func() {
+		defer close(r.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			published, err := r.Flush(r.ctx)
+			if err != nil && r.ctx.Err() == nil {
+				slog.Warn("Outbox relay gagal mengambil event", "store", r.name, "error", err)
+			}
+			r.purge()
+			if err == nil && published == r.batchSize {
+				select {
+				case <-r.stop:
+					return
+				default:
+					continue
+				}
+			}
+			select {
+			case <-r.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Shutdown menghentikan relay setelah batch yang sedang dikirim selesai atau
+// ctx habis. Jika waktu habis, pengiriman dibatalkan; event yang belum
+// ditandai akan dikirim ulang setelah lease-nya habis.
+func (r *Relay) Shutdown(ctx context.Context) error {
+	close(r.stop)
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		r.cancel()
+		return ctx.Err()
+	}
+}
+
+// Flush mengirim satu batch event dan mengembalikan jumlah event yang
+// diambil. Kegagalan kirim dicatat di store dan tidak dikembalikan sebagai
+// error.
+func (r *Relay) Flush(ctx context.Context) (int, error) {
+	records, err := r.store.Claim(ctx, r.batchSize, claimLease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, record := range records {
+		if err := r.publish(ctx, record.Event); err != nil {
+			r.fail(ctx, record, err)
+			continue
+		}
+		if err := r.store.MarkPublished(ctx, record.ID); err != nil {
+			slog.WarnContext(ctx, "Gagal menandai event outbox published", "store", r.name, "event_id", record.ID, "error", err)
+		}
+	}
+	return len(records), nil
+}
+
+func (r *Relay) publish(ctx context.Context, event Event) error {
+	var errs []error
+	for _, sink := range r.sinks {
+		if err := sink.Publish(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sink.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Relay) fail(ctx context.Context, record Record, err error) {
+	attempts := record.Attempts + 1
+	dead := attempts >= r.maxAttempts
+	next := time.Now().Add(Backoff(attempts))
+
+	logArgs := []interface{}{"store", r.name, "event_id", record.ID, "type", record.Type, "attempts", attempts, "error", err}
+	if dead {
+		slog.ErrorContext(ctx, "Event outbox gagal dikirim dan tidak dicoba lagi", logArgs...)
+	} else {
+		slog.WarnContext(ctx, "Event outbox gagal dikirim", append(logArgs, "next_attempt_at", next)...)
+	}
+
+	if err := r.store.MarkFailed(ctx, record.ID, attempts, next, err.Error(), dead); err != nil {
+		slog.WarnContext(ctx, "Gagal mencatat kegagalan event outbox", "store", r.name, "event_id", record.ID, "error", err)
+	}
+}
+
+// purge menghapus event published yang sudah melewati retention, paling
+// sering sekali per purgeInterval.
+func (r *Relay) purge() {
+	if r.retention <= 0 || time.Since(r.lastPurge) < purgeInterval {
+		return
+	}
+	r.lastPurge = time.Now()
+
+	deleted, err := r.store.Purge(r.ctx, time.Now().Add(-r.retention))
+	if err != nil {
+		slog.Warn("Gagal membersihkan outbox", "store", r.name, "error", err)
+		return
+	}
+	if deleted > 0 {
+		slog.Info("Outbox dibersihkan", "store", r.name, "deleted", deleted)
+	}
+}
+
+// Backoff mengembalikan jeda sebelum percobaan berikutnya setelah attempts
+// kali gagal: 1 detik, lalu dua kali lipat setiap kegagalan sampai paling
+// lama 1 jam.
+func Backoff(attempts int) time.Duration {
+	delay := time.Second
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return delay
+}
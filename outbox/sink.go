@@ -0,0 +1,105 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"go-fiber/config"
+)
+
+// Sink adalah tujuan pengiriman event. Publish harus mengembalikan nil hanya
+// jika event sudah diterima tujuan; error membuat event dicoba lagi.
+type Sink interface {
+	Name() string
+	Publish(ctx context.Context, event Event) error
+}
+
+// Handler memproses event yang diterima Bus.
+type Handler func(ctx context.Context, event Event) error
+
+type subscription struct {
+	pattern string
+	handler Handler
+}
+
+// Bus adalah sink in-process untuk komponen di aplikasi yang sama. Handler
+// dijalankan berurutan di goroutine relay; error dari handler mana pun
+// membuat event dikirim ulang ke semua handler, jadi handler harus
+// idempotent terhadap Event.ID.
+type Bus struct {
+	mu            sync.RWMutex
+	subscriptions []subscription
+}
+
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+func (b *Bus) Name() string {
+	return "bus"
+}
+
+// Subscribe mendaftarkan handler untuk event yang tipenya cocok dengan
+// pattern: tipe persis (alumni.created), semua aksi satu resource
+// (alumni.*), atau semua event (*).
+func (b *Bus) Subscribe(pattern string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscriptions = append(b.subscriptions, subscription{pattern: pattern, handler: handler})
+}
+
+func (b *Bus) Publish(ctx context.Context, event Event) error {
+	b.mu.RLock()
+	subscriptions := append([]subscription(nil), b.subscriptions...)
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, sub := range subscriptions {
+		if !MatchType(sub.pattern, event.Type) {
+			continue
+		}
+		if err := sub.handler(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("handler %s: %w", sub.pattern, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// MatchType melaporkan apakah tipe event cocok dengan pattern Subscribe.
+func MatchType(pattern, eventType string) bool {
+	if pattern == "*" || pattern == eventType {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(pattern, ".*"); ok {
+		return strings.HasPrefix(eventType, prefix+".")
+	}
+	return false
+}
+
+// NewSinks membuat sink sesuai cfg.Sinks. Sink bus memakai bus yang
+// diberikan agar komponen lain bisa Subscribe sebelum relay berjalan.
+func NewSinks(cfg config.OutboxConfig, bus *Bus) ([]Sink, error) {
+	var sinks []Sink
+	for _, name := range cfg.SinkNames() {
+		switch name {
+		case "bus":
+			sinks = append(sinks, bus)
+		case "nats":
+			sink, err := NewNATSSink(cfg.NATSURL, cfg.NATSSubjectPrefix)
+			if err != nil {
+				return nil, err
+			}
+			sinks = append(sinks, sink)
+		case "kafka":
+			sinks = append(sinks, NewKafkaSink(cfg.KafkaRESTURL, cfg.KafkaTopic))
+		case "webhook":
+			sinks = append(sinks, NewWebhookSink(cfg.WebhookURL, cfg.WebhookSecret))
+		default:
+			return nil, fmt.Errorf("sink outbox tidak dikenal: %s", name)
+		}
+	}
+	return sinks, nil
+}
@@ -0,0 +1,90 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// httpTimeout membatasi satu request sink HTTP agar relay tidak tertahan
+// oleh tujuan yang lambat.
+const httpTimeout = 10 * time.Second
+
+// Header request webhook.
+const (
+	HeaderEventID   = "X-Event-ID"
+	HeaderEventType = "X-Event-Type"
+	HeaderTimestamp = "X-Event-Timestamp"
+	HeaderSignature = "X-Event-Signature"
+)
+
+// WebhookSink mengirim setiap event sebagai POST JSON ke satu URL. Jika
+// secret diisi, body ditandatangani dengan HMAC-SHA256, lihat Sign.
+type WebhookSink struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+func NewWebhookSink(url, secret string) *WebhookSink {
+	return &WebhookSink{url: url, secret: secret, client: &http.Client{Timeout: httpTimeout}}
+}
+
+func (s *WebhookSink) Name() string {
+	return "webhook"
+}
+
+func (s *WebhookSink) Publish(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	timestamp := time.Now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(HeaderEventID, event.ID)
+	request.Header.Set(HeaderEventType, event.Type)
+	request.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	if s.secret != "" {
+		request.Header.Set(HeaderSignature, Sign(s.secret, timestamp, body))
+	}
+	return send(s.client, request)
+}
+
+// Sign menghitung header X-Event-Signature: "sha256=" diikuti HMAC-SHA256
+// hex dari "<timestamp>.<body>". Penerima menghitung ulang dengan secret yang
+// sama dan menolak timestamp yang terlalu lama untuk mencegah replay.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// send menjalankan request dan menganggap status selain 2xx sebagai error.
+// Sebagian body response ikut di pesan error untuk membantu penelusuran.
+func send(client *http.Client, request *http.Request) error {
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(response.Body, 512))
+		return fmt.Errorf("%s membalas %d: %s", request.URL.Redacted(), response.StatusCode, bytes.TrimSpace(snippet))
+	}
+	io.Copy(io.Discard, response.Body)
+	return nil
+}
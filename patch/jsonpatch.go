@@ -0,0 +1,266 @@
+package patch
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go-fiber/apperror"
+	"go-fiber/i18n"
+)
+
+// operation adalah satu operasi JSON Patch. Value disimpan mentah agar value
+// yang tidak dikirim bisa dibedakan dari value null.
+type operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+var (
+	errPathNotFound = i18n.NewError("patch.path_not_found")
+	errInvalidIndex = i18n.NewError("patch.invalid_index")
+	errNotContainer = i18n.NewError("patch.not_container")
+)
+
+// applyJSONPatch menjalankan operasi secara berurutan pada doc. Jika satu
+// operasi gagal, seluruh patch dibatalkan. Field yang disentuh adalah segmen
+// pertama path, ditambah segmen pertama from untuk move.
+func applyJSONPatch(doc map[string]interface{}, body []byte) (interface{}, []string, error) {
+	var operations []operation
+	if err := json.Unmarshal(body, &operations); err != nil {
+		return nil, nil, apperror.Validation("patch.invalid_json_patch").WithCode("invalid_patch")
+	}
+
+	var result interface{} = doc
+	touchedSet := map[string]bool{}
+	for i, op := range operations {
+		path, err := parsePointer(op.Path)
+		if err != nil || len(path) == 0 {
+			return nil, nil, invalidOperation(i, op, i18n.NewError("patch.invalid_path"))
+		}
+
+		switch op.Op {
+		case "add", "replace", "test":
+			if op.Value == nil {
+				return nil, nil, invalidOperation(i, op, i18n.NewError("patch.value_required"))
+			}
+			var value interface{}
+			if err := json.Unmarshal(op.Value, &value); err != nil {
+				return nil, nil, invalidOperation(i, op, err)
+			}
+			if op.Op == "test" {
+				current, err := get(result, path)
+				if err != nil || !reflect.DeepEqual(current, value) {
+					return nil, nil, apperror.Unprocessable("patch.test_failed", op.Path).WithCode("patch_test_failed")
+				}
+				continue
+			}
+			if op.Op == "add" {
+				result, err = mutate(result, path, addFunc(value))
+			} else {
+				result, err = mutate(result, path, replaceFunc(value))
+			}
+		case "remove":
+			result, err = mutate(result, path, removeFunc)
+		case "move", "copy":
+			from, fromErr := parsePointer(op.From)
+			if fromErr != nil || len(from) == 0 {
+				return nil, nil, invalidOperation(i, op, i18n.NewError("patch.invalid_from"))
+			}
+			if op.Op == "move" && strings.HasPrefix(op.Path+"/", op.From+"/") {
+				return nil, nil, invalidOperation(i, op, i18n.NewError("patch.move_into_child"))
+			}
+			var value interface{}
+			value, err = get(result, from)
+			if err != nil {
+				break
+			}
+			if op.Op == "move" {
+				touchedSet[from[0]] = true
+				result, err = mutate(result, from, removeFunc)
+			} else {
+				value = deepCopy(value)
+			}
+			if err == nil {
+				result, err = mutate(result, path, addFunc(value))
+			}
+		default:
+			return nil, nil, invalidOperation(i, op, i18n.NewError("patch.unknown_op"))
+		}
+		if err != nil {
+			return nil, nil, invalidOperation(i, op, err)
+		}
+		touchedSet[path[0]] = true
+	}
+
+	touched := make([]string, 0, len(touchedSet))
+	for field := range touchedSet {
+		touched = append(touched, field)
+	}
+	sort.Strings(touched)
+	return result, touched, nil
+}
+
+func invalidOperation(index int, op operation, err error) error {
+	return apperror.Validation("patch.invalid_operation", index, op.Op, op.Path, err).WithCode("invalid_patch")
+}
+
+// parsePointer memecah JSON Pointer (RFC 6901) menjadi token. Pointer
+// kosong menunjuk seluruh dokumen dan menghasilkan nol token.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, i18n.NewError("patch.invalid_path")
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func get(doc interface{}, path []string) (interface{}, error) {
+	for _, token := range path {
+		var err error
+		if doc, err = child(doc, token); err != nil {
+			return nil, err
+		}
+	}
+	return doc, nil
+}
+
+// mutate menjalankan fn pada container induk token terakhir path lalu
+// mengembalikan dokumen yang sudah diubah. fn mengembalikan container baru
+// karena slice bisa berpindah alamat saat elemen ditambah atau dihapus.
+func mutate(doc interface{}, path []string, fn func(container interface{}, token string) (interface{}, error)) (interface{}, error) {
+	if len(path) == 1 {
+		return fn(doc, path[0])
+	}
+	next, err := child(doc, path[0])
+	if err != nil {
+		return nil, err
+	}
+	updated, err := mutate(next, path[1:], fn)
+	if err != nil {
+		return nil, err
+	}
+	switch container := doc.(type) {
+	case map[string]interface{}:
+		container[path[0]] = updated
+	case []interface{}:
+		index, _ := strconv.Atoi(path[0])
+		container[index] = updated
+	}
+	return doc, nil
+}
+
+func child(doc interface{}, token string) (interface{}, error) {
+	switch container := doc.(type) {
+	case map[string]interface{}:
+		value, ok := container[token]
+		if !ok {
+			return nil, errPathNotFound
+		}
+		return value, nil
+	case []interface{}:
+		index, err := arrayIndex(token, len(container)-1)
+		if err != nil {
+			return nil, err
+		}
+		return container[index], nil
+	default:
+		return nil, errNotContainer
+	}
+}
+
+// arrayIndex memparse token indeks array dengan batas atas max.
+func arrayIndex(token string, max int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, errInvalidIndex
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || index > max {
+		return 0, errInvalidIndex
+	}
+	return index, nil
+}
+
+func addFunc(value interface{}) func(interface{}, string) (interface{}, error) {
+	return func(doc interface{}, token string) (interface{}, error) {
+		switch container := doc.(type) {
+		case map[string]interface{}:
+			container[token] = value
+			return container, nil
+		case []interface{}:
+			if token == "-" {
+				return append(container, value), nil
+			}
+			index, err := arrayIndex(token, len(container))
+			if err != nil {
+				return nil, err
+			}
+			container = append(container, nil)
+			copy(container[index+1:], container[index:])
+			container[index] = value
+			return container, nil
+		default:
+			return nil, errNotContainer
+		}
+	}
+}
+
+func replaceFunc(value interface{}) func(interface{}, string) (interface{}, error) {
+	return func(doc interface{}, token string) (interface{}, error) {
+		if _, err := child(doc, token); err != nil {
+			return nil, err
+		}
+		switch container := doc.(type) {
+		case map[string]interface{}:
+			container[token] = value
+		case []interface{}:
+			index, _ := strconv.Atoi(token)
+			container[index] = value
+		}
+		return doc, nil
+	}
+}
+
+func removeFunc(doc interface{}, token string) (interface{}, error) {
+	if _, err := child(doc, token); err != nil {
+		return nil, err
+	}
+	switch container := doc.(type) {
+	case map[string]interface{}:
+		delete(container, token)
+		return container, nil
+	case []interface{}:
+		index, _ := strconv.Atoi(token)
+		return append(container[:index], container[index+1:]...), nil
+	}
+	return doc, nil
+}
+
+func deepCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			copied[key] = deepCopy(item)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = deepCopy(item)
+		}
+		return copied
+	default:
+		return v
+	}
+}
@@ -0,0 +1,170 @@
+// Package patch menerapkan body PATCH pada data alumni dan pekerjaan. Body
+// berupa JSON Merge Patch (RFC 7396) atau JSON Patch (RFC 6902) yang dipilih
+// dari header Content-Type.
+package patch
+
+import (
+	"encoding/json"
+	"mime"
+	"reflect"
+	"sort"
+	"strings"
+
+	"go-fiber/apperror"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	MergePatchType = "application/merge-patch+json"
+	JSONPatchType  = "application/json-patch+json"
+)
+
+// Apply menerapkan body PATCH pada current lalu mengisi out dengan hasilnya.
+// current biasanya request update yang diisi data saat ini, dan out bertipe
+// sama. Nilai kembalian berisi nama field JSON tingkat atas yang disentuh
+// patch, terurut; field di luar current ditolak dengan kode unknown_field.
+// Content-Type application/json diperlakukan sebagai merge patch.
+func Apply(c *fiber.Ctx, current, out interface{}) ([]string, error) {
+	mediaType, _, _ := mime.ParseMediaType(c.Get(fiber.HeaderContentType))
+	switch mediaType {
+	case MergePatchType, fiber.MIMEApplicationJSON:
+		return apply(current, out, c.Body(), applyMergePatch)
+	case JSONPatchType:
+		return apply(current, out, c.Body(), applyJSONPatch)
+	default:
+		return nil, apperror.New(fiber.StatusUnsupportedMediaType, "unsupported_media_type", "patch.unsupported_media_type", MergePatchType, JSONPatchType)
+	}
+}
+
+// Merge sama seperti Apply untuk body merge patch yang tidak berasal dari
+// body request, misalnya field changes pada endpoint batch.
+func Merge(current interface{}, body []byte, out interface{}) ([]string, error) {
+	return apply(current, out, body, applyMergePatch)
+}
+
+func apply(current, out interface{}, body []byte, fn func(map[string]interface{}, []byte) (interface{}, []string, error)) ([]string, error) {
+	raw, err := json.Marshal(current)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool, len(doc))
+	for field := range doc {
+		known[field] = true
+	}
+
+	result, touched, err := fn(doc, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range touched {
+		if !known[field] {
+			return nil, apperror.Validation("patch.unknown_field", field).WithCode("unknown_field")
+		}
+	}
+
+	raw, err = json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return nil, apperror.InvalidBody(err)
+	}
+	return touched, nil
+}
+
+// Values mengambil nilai field JSON pada fields dari struct s. Pointer nil
+// menjadi nil dan pointer lain diambil nilainya, sehingga hasilnya bisa
+// langsung dipakai sebagai nilai kolom atau $set/$unset.
+func Values(s interface{}, fields []string) map[string]interface{} {
+	v := reflect.Indirect(reflect.ValueOf(s))
+	t := v.Type()
+	wanted := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		wanted[field] = true
+	}
+
+	values := make(map[string]interface{}, len(fields))
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if !wanted[name] {
+			continue
+		}
+		field := v.Field(i)
+		if field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				values[name] = nil
+				continue
+			}
+			field = field.Elem()
+		}
+		values[name] = field.Interface()
+	}
+	return values
+}
+
+// Changed mengembalikan field pada fields yang nilainya berbeda antara
+// before dan after, yang bertipe sama. Dipakai agar patch yang tidak mengubah
+// apa pun tidak menaikkan version.
+func Changed(before, after interface{}, fields []string) []string {
+	beforeValues := Values(before, fields)
+	afterValues := Values(after, fields)
+	changed := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if !reflect.DeepEqual(beforeValues[field], afterValues[field]) {
+			changed = append(changed, field)
+		}
+	}
+	return changed
+}
+
+// Touches melaporkan apakah salah satu names ada di touched.
+func Touches(touched []string, names ...string) bool {
+	for _, field := range touched {
+		for _, name := range names {
+			if field == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func applyMergePatch(doc map[string]interface{}, body []byte) (interface{}, []string, error) {
+	var patch map[string]interface{}
+	if err := json.Unmarshal(body, &patch); err != nil {
+		return nil, nil, apperror.Validation("patch.invalid_merge_patch").WithCode("invalid_patch")
+	}
+	touched := make([]string, 0, len(patch))
+	for field := range patch {
+		touched = append(touched, field)
+	}
+	sort.Strings(touched)
+	return mergePatch(doc, patch), touched, nil
+}
+
+// mergePatch mengikuti algoritma MergePatch pada RFC 7396: null menghapus
+// member dan object digabung secara rekursif.
+func mergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+			continue
+		}
+		targetObject[name] = mergePatch(targetObject[name], value)
+	}
+	return targetObject
+}
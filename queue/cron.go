@@ -0,0 +1,173 @@
+package queue
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron adalah jadwal hasil ParseCron. Semua waktu dihitung dalam UTC agar
+// setiap instance menghasilkan waktu jalan yang sama.
+type Cron interface {
+	// Next mengembalikan waktu jalan pertama setelah t.
+	Next(t time.Time) time.Time
+}
+
+// cronAliases adalah singkatan ekspresi cron yang umum.
+var cronAliases = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseCron menerima ekspresi cron lima field (menit jam tanggal bulan
+// hari-dalam-minggu) dengan *, daftar (1,15), rentang (1-5), dan langkah
+// (*/10), singkatan seperti @daily, atau @every <durasi> seperti @every 15m.
+// Hari-dalam-minggu memakai 0-6 dengan 0 dan 7 untuk Minggu.
+func ParseCron(spec string) (Cron, error) {
+	spec = strings.TrimSpace(spec)
+	if alias, ok := cronAliases[spec]; ok {
+		spec = alias
+	}
+	if rest, ok := strings.CutPrefix(spec, "@every "); ok {
+		interval, err := time.ParseDuration(strings.TrimSpace(rest))
+		if err != nil || interval < time.Second {
+			return nil, fmt.Errorf("interval @every tidak valid: %q", rest)
+		}
+		return every(interval), nil
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("ekspresi cron harus berisi 5 field, bukan %d: %q", len(fields), spec)
+	}
+	var schedule cronSchedule
+	var err error
+	if schedule.minute, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, fmt.Errorf("field menit: %w", err)
+	}
+	if schedule.hour, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, fmt.Errorf("field jam: %w", err)
+	}
+	if schedule.dom, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, fmt.Errorf("field tanggal: %w", err)
+	}
+	if schedule.month, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, fmt.Errorf("field bulan: %w", err)
+	}
+	if schedule.dow, err = parseCronField(fields[4], 0, 7); err != nil {
+		return nil, fmt.Errorf("field hari: %w", err)
+	}
+	// 7 adalah Minggu, sama dengan 0.
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow |= 1
+	}
+	schedule.domAny = fields[2] == "*"
+	schedule.dowAny = fields[4] == "*"
+	return schedule, nil
+}
+
+// every berjalan pada kelipatan interval sejak Unix epoch, sehingga semua
+// instance mendapat waktu yang sama tanpa koordinasi.
+type every time.Duration
+
+func (e every) Next(t time.Time) time.Time {
+	interval := time.Duration(e)
+	return t.UTC().Truncate(interval).Add(interval)
+}
+
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool
+}
+
+func (s cronSchedule) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	// Ekspresi seperti 0 0 30 2 * tidak pernah cocok; pencarian dibatasi
+	// lima tahun agar tidak berputar selamanya.
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches mengikuti aturan cron: jika tanggal dan hari sama-sama dibatasi,
+// salah satu yang cocok sudah cukup.
+func (s cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dowMatch
+	case s.dowAny:
+		return domMatch
+	}
+	return domMatch || dowMatch
+}
+
+func parseCronField(field string, min, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepPart); err != nil || step < 1 {
+				return 0, fmt.Errorf("langkah tidak valid: %q", part)
+			}
+		}
+
+		start, end := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			low, high, _ := strings.Cut(rangePart, "-")
+			var err1, err2 error
+			start, err1 = strconv.Atoi(low)
+			end, err2 = strconv.Atoi(high)
+			if err1 != nil || err2 != nil {
+				return 0, fmt.Errorf("rentang tidak valid: %q", part)
+			}
+		default:
+			value, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, fmt.Errorf("nilai tidak valid: %q", part)
+			}
+			start = value
+			if !hasStep {
+				end = value
+			}
+		}
+		if start < min || end > max || start > end {
+			return 0, fmt.Errorf("nilai %q di luar %d-%d", part, min, max)
+		}
+		for value := start; value <= end; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+	return bits, nil
+}
@@ -0,0 +1,316 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"go-fiber/apperror"
+	"go-fiber/tracing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoStore menyimpan job di collection jobs. Index-nya, termasuk index
+// unik sparse untuk unique_key, dibuat oleh RunMigrations.
+type MongoStore struct {
+	collection *mongo.Collection
+}
+
+func NewMongoStore(db *mongo.Database) *MongoStore {
+	return &MongoStore{collection: db.Collection("jobs")}
+}
+
+// mongoJob menyimpan payload sebagai dokumen BSON agar bisa dibaca langsung
+// di database, bukan sebagai string JSON.
+type mongoJob struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	Type        string             `bson:"type"`
+	Payload     interface{}        `bson:"payload"`
+	Status      string             `bson:"status"`
+	Attempts    int                `bson:"attempts"`
+	MaxAttempts int                `bson:"max_attempts"`
+	LastError   string             `bson:"last_error,omitempty"`
+	UniqueKey   string             `bson:"unique_key,omitempty"`
+	RunAt       time.Time          `bson:"run_at"`
+	LockedUntil *time.Time         `bson:"locked_until,omitempty"`
+	CreatedAt   time.Time          `bson:"created_at"`
+	StartedAt   *time.Time         `bson:"started_at,omitempty"`
+	FinishedAt  *time.Time         `bson:"finished_at,omitempty"`
+}
+
+func (d mongoJob) job() (Job, error) {
+	payload, err := bson.MarshalExtJSON(bson.M{"v": d.Payload}, false, false)
+	if err != nil {
+		return Job{}, err
+	}
+	var wrapped struct {
+		V json.RawMessage `json:"v"`
+	}
+	if err := json.Unmarshal(payload, &wrapped); err != nil {
+		return Job{}, err
+	}
+	return Job{
+		ID:          d.ID.Hex(),
+		Type:        d.Type,
+		Payload:     wrapped.V,
+		Status:      d.Status,
+		Attempts:    d.Attempts,
+		MaxAttempts: d.MaxAttempts,
+		LastError:   d.LastError,
+		UniqueKey:   d.UniqueKey,
+		RunAt:       d.RunAt,
+		LockedUntil: d.LockedUntil,
+		CreatedAt:   d.CreatedAt,
+		StartedAt:   d.StartedAt,
+		FinishedAt:  d.FinishedAt,
+	}, nil
+}
+
+func objectID(id string) (primitive.ObjectID, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return primitive.NilObjectID, apperror.InvalidID()
+	}
+	return objectID, nil
+}
+
+func (s *MongoStore) Enqueue(ctx context.Context, job *Job) error {
+	ctx, span := tracing.StartMongo(ctx, "queue.MongoStore.Enqueue")
+	defer span.End()
+
+	var payload interface{}
+	if len(job.Payload) > 0 {
+		if err := bson.UnmarshalExtJSON(job.Payload, false, &payload); err != nil {
+			return tracing.Error(span, err)
+		}
+	}
+	document := mongoJob{
+		ID:          primitive.NewObjectID(),
+		Type:        job.Type,
+		Payload:     payload,
+		Status:      StatusPending,
+		MaxAttempts: job.MaxAttempts,
+		UniqueKey:   job.UniqueKey,
+		RunAt:       job.RunAt,
+		CreatedAt:   time.Now().UTC(),
+	}
+	if _, err := s.collection.InsertOne(ctx, document); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrDuplicate
+		}
+		return tracing.Error(span, err)
+	}
+	job.ID = document.ID.Hex()
+	job.Status = StatusPending
+	job.CreatedAt = document.CreatedAt
+	return nil
+}
+
+// Claim mengambil job satu per satu dengan FindOneAndUpdate sehingga setiap
+// job hanya didapat oleh satu worker selama lease.
+func (s *MongoStore) Claim(ctx context.Context, jobType string, limit int, lease time.Duration) ([]Job, error) {
+	ctx, span := tracing.StartMongo(ctx, "queue.MongoStore.Claim")
+	defer span.End()
+
+	now := time.Now()
+	filter := bson.M{"type": jobType, "$or": bson.A{
+		bson.M{"status": StatusPending, "run_at": bson.M{"$lte": now}},
+		bson.M{"status": StatusRunning, "locked_until": bson.M{"$lt": now}},
+	}}
+	update := bson.M{
+		"$set": bson.M{"status": StatusRunning, "started_at": now, "locked_until": now.Add(lease)},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "run_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var jobs []Job
+	for len(jobs) < limit {
+		var document mongoJob
+		err := s.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&document)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			break
+		}
+		if err != nil {
+			return jobs, tracing.Error(span, err)
+		}
+		job, err := document.job()
+		if err != nil {
+			return jobs, tracing.Error(span, err)
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+func (s *MongoStore) Complete(ctx context.Context, id string, attempt int) error {
+	ctx, span := tracing.StartMongo(ctx, "queue.MongoStore.Complete")
+	defer span.End()
+
+	jobID, err := objectID(id)
+	if err != nil {
+		return err
+	}
+	update := bson.M{
+		"$set":   bson.M{"status": StatusSucceeded, "finished_at": time.Now()},
+		"$unset": bson.M{"last_error": "", "locked_until": ""},
+	}
+	result, err := s.collection.UpdateOne(ctx, bson.M{"_id": jobID, "status": StatusRunning, "attempts": attempt}, update)
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+	if result.MatchedCount == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func (s *MongoStore) Fail(ctx context.Context, id string, attempt int, lastError string, next time.Time, final bool) error {
+	ctx, span := tracing.StartMongo(ctx, "queue.MongoStore.Fail")
+	defer span.End()
+
+	jobID, err := objectID(id)
+	if err != nil {
+		return err
+	}
+	set := bson.M{"status": StatusPending, "last_error": lastError, "run_at": next}
+	if final {
+		set["status"] = StatusFailed
+		set["finished_at"] = time.Now()
+	}
+	update := bson.M{"$set": set, "$unset": bson.M{"locked_until": ""}}
+	result, err := s.collection.UpdateOne(ctx, bson.M{"_id": jobID, "status": StatusRunning, "attempts": attempt}, update)
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+	if result.MatchedCount == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func (s *MongoStore) List(ctx context.Context, filter Filter) ([]Job, int64, error) {
+	ctx, span := tracing.StartMongo(ctx, "queue.MongoStore.List")
+	defer span.End()
+
+	query := bson.M{}
+	if filter.Type != "" {
+		query["type"] = filter.Type
+	}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+
+	total, err := s.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, tracing.Error(span, err)
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(filter.Offset())).
+		SetLimit(int64(filter.Limit))
+	cursor, err := s.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, tracing.Error(span, err)
+	}
+	var documents []mongoJob
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, 0, tracing.Error(span, err)
+	}
+	jobs := make([]Job, 0, len(documents))
+	for _, document := range documents {
+		job, err := document.job()
+		if err != nil {
+			return nil, 0, tracing.Error(span, err)
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, total, nil
+}
+
+func (s *MongoStore) Get(ctx context.Context, id string) (*Job, error) {
+	ctx, span := tracing.StartMongo(ctx, "queue.MongoStore.Get")
+	defer span.End()
+
+	jobID, err := objectID(id)
+	if err != nil {
+		return nil, err
+	}
+	var document mongoJob
+	err = s.collection.FindOne(ctx, bson.M{"_id": jobID}).Decode(&document)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	job, err := document.job()
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	return &job, nil
+}
+
+func (s *MongoStore) Retry(ctx context.Context, id string) (*Job, error) {
+	update := bson.M{
+		"$set":   bson.M{"status": StatusPending, "attempts": 0, "run_at": time.Now()},
+		"$unset": bson.M{"locked_until": "", "finished_at": ""},
+	}
+	return s.transition(ctx, "queue.MongoStore.Retry", id, []string{StatusFailed, StatusCancelled}, update)
+}
+
+func (s *MongoStore) Cancel(ctx context.Context, id string) (*Job, error) {
+	update := bson.M{"$set": bson.M{"status": StatusCancelled, "finished_at": time.Now()}}
+	return s.transition(ctx, "queue.MongoStore.Cancel", id, []string{StatusPending}, update)
+}
+
+// transition mengubah job yang statusnya salah satu dari from. Jika tidak
+// ada yang berubah, job dibaca lagi untuk membedakan job yang tidak ada dari
+// status yang tidak sesuai.
+func (s *MongoStore) transition(ctx context.Context, name, id string, from []string, update bson.M) (*Job, error) {
+	ctx, span := tracing.StartMongo(ctx, name)
+	defer span.End()
+
+	jobID, err := objectID(id)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.M{"_id": jobID, "status": bson.M{"$in": from}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var document mongoJob
+	err = s.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&document)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		if _, err := s.Get(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidState
+	}
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	job, err := document.job()
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	return &job, nil
+}
+
+func (s *MongoStore) Purge(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := tracing.StartMongo(ctx, "queue.MongoStore.Purge")
+	defer span.End()
+
+	result, err := s.collection.DeleteMany(ctx, bson.M{
+		"status":      bson.M{"$in": bson.A{StatusSucceeded, StatusCancelled}},
+		"finished_at": bson.M{"$lt": before},
+	})
+	if err != nil {
+		return 0, tracing.Error(span, err)
+	}
+	return result.DeletedCount, nil
+}
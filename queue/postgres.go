@@ -0,0 +1,276 @@
+package queue
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"go-fiber/apperror"
+	"go-fiber/outbox"
+	"go-fiber/tracing"
+)
+
+// PostgresStore menyimpan job di tabel jobs (dibuat oleh
+// RunPostgresMigrations). Dengan *sql.Tx job ikut tersimpan atau batal
+// bersama transaksi pemanggil.
+type PostgresStore struct {
+	db outbox.DBTX
+}
+
+func NewPostgresStore(db outbox.DBTX) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+func parseID(id string) (int64, error) {
+	value, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || value <= 0 {
+		return 0, apperror.InvalidID()
+	}
+	return value, nil
+}
+
+const jobColumns = `id, type, payload, status, attempts, max_attempts, last_error, unique_key,
+	run_at, locked_until, created_at, started_at, finished_at`
+
+func scanJob(row interface{ Scan(...interface{}) error }) (*Job, error) {
+	var job Job
+	var id int64
+	var payload []byte
+	var lastError, uniqueKey sql.NullString
+	var lockedUntil, startedAt, finishedAt sql.NullTime
+	err := row.Scan(&id, &job.Type, &payload, &job.Status, &job.Attempts, &job.MaxAttempts, &lastError, &uniqueKey,
+		&job.RunAt, &lockedUntil, &job.CreatedAt, &startedAt, &finishedAt)
+	if err != nil {
+		return nil, err
+	}
+	job.ID = strconv.FormatInt(id, 10)
+	job.Payload = payload
+	job.LastError = lastError.String
+	job.UniqueKey = uniqueKey.String
+	if lockedUntil.Valid {
+		job.LockedUntil = &lockedUntil.Time
+	}
+	if startedAt.Valid {
+		job.StartedAt = &startedAt.Time
+	}
+	if finishedAt.Valid {
+		job.FinishedAt = &finishedAt.Time
+	}
+	return &job, nil
+}
+
+func scanJobs(rows *sql.Rows) ([]Job, error) {
+	defer rows.Close()
+	jobs := []Job{}
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, *job)
+	}
+	return jobs, rows.Err()
+}
+
+func (s *PostgresStore) Enqueue(ctx context.Context, job *Job) error {
+	ctx, span := tracing.StartPostgres(ctx, "queue.PostgresStore.Enqueue")
+	defer span.End()
+
+	var id int64
+	err := s.db.QueryRowContext(ctx, `
+		INSERT INTO jobs (type, payload, status, max_attempts, unique_key, run_at, created_at)
+		VALUES ($1, $2, 'pending', $3, NULLIF($4, ''), $5, NOW())
+		ON CONFLICT (unique_key) DO NOTHING
+		RETURNING id, created_at
+	`, job.Type, []byte(job.Payload), job.MaxAttempts, job.UniqueKey, job.RunAt).Scan(&id, &job.CreatedAt)
+	// ON CONFLICT DO NOTHING tidak mengembalikan baris dan, berbeda dengan
+	// unique violation, tidak membatalkan transaksi pemanggil.
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrDuplicate
+	}
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+	job.ID = strconv.FormatInt(id, 10)
+	job.Status = StatusPending
+	return nil
+}
+
+// Claim memakai FOR UPDATE SKIP LOCKED sehingga beberapa instance bisa
+// menjalankan worker bersamaan tanpa mengambil job yang sama.
+func (s *PostgresStore) Claim(ctx context.Context, jobType string, limit int, lease time.Duration) ([]Job, error) {
+	ctx, span := tracing.StartPostgres(ctx, "queue.PostgresStore.Claim")
+	defer span.End()
+
+	rows, err := s.db.QueryContext(ctx, `
+		UPDATE jobs SET status = 'running', attempts = attempts + 1, started_at = NOW(), locked_until = $3
+		WHERE id IN (
+			SELECT id FROM jobs
+			WHERE type = $1 AND (
+				(status = 'pending' AND run_at <= NOW()) OR
+				(status = 'running' AND locked_until < NOW())
+			)
+			ORDER BY run_at, id
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+jobColumns,
+		jobType, limit, time.Now().Add(lease).UTC())
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	jobs, err := scanJobs(rows)
+	return jobs, tracing.Error(span, err)
+}
+
+func (s *PostgresStore) Complete(ctx context.Context, id string, attempt int) error {
+	ctx, span := tracing.StartPostgres(ctx, "queue.PostgresStore.Complete")
+	defer span.End()
+
+	jobID, err := parseID(id)
+	if err != nil {
+		return err
+	}
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE jobs SET status = 'succeeded', last_error = NULL, locked_until = NULL, finished_at = NOW()
+		WHERE id = $1 AND status = 'running' AND attempts = $2
+	`, jobID, attempt)
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+	return leaseResult(result)
+}
+
+func (s *PostgresStore) Fail(ctx context.Context, id string, attempt int, lastError string, next time.Time, final bool) error {
+	ctx, span := tracing.StartPostgres(ctx, "queue.PostgresStore.Fail")
+	defer span.End()
+
+	jobID, err := parseID(id)
+	if err != nil {
+		return err
+	}
+	status := StatusPending
+	var finishedAt *time.Time
+	if final {
+		status = StatusFailed
+		now := time.Now().UTC()
+		finishedAt = &now
+	}
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE jobs SET status = $2, last_error = $3, run_at = $4, locked_until = NULL, finished_at = $5
+		WHERE id = $1 AND status = 'running' AND attempts = $6
+	`, jobID, status, lastError, next.UTC(), finishedAt, attempt)
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+	return leaseResult(result)
+}
+
+// leaseResult mengubah update Complete/Fail yang tidak mengenai baris apa pun
+// menjadi ErrLeaseLost.
+func leaseResult(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func (s *PostgresStore) List(ctx context.Context, filter Filter) ([]Job, int64, error) {
+	ctx, span := tracing.StartPostgres(ctx, "queue.PostgresStore.List")
+	defer span.End()
+
+	where := `WHERE ($1 = '' OR type = $1) AND ($2 = '' OR status = $2)`
+	var total int64
+	err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM jobs `+where, filter.Type, filter.Status).Scan(&total)
+	if err != nil {
+		return nil, 0, tracing.Error(span, err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+jobColumns+` FROM jobs `+where+`
+		ORDER BY created_at DESC, id DESC LIMIT $3 OFFSET $4`,
+		filter.Type, filter.Status, filter.Limit, filter.Offset())
+	if err != nil {
+		return nil, 0, tracing.Error(span, err)
+	}
+	jobs, err := scanJobs(rows)
+	if err != nil {
+		return nil, 0, tracing.Error(span, err)
+	}
+	return jobs, total, nil
+}
+
+func (s *PostgresStore) Get(ctx context.Context, id string) (*Job, error) {
+	ctx, span := tracing.StartPostgres(ctx, "queue.PostgresStore.Get")
+	defer span.End()
+
+	jobID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+	job, err := scanJob(s.db.QueryRowContext(ctx, `SELECT `+jobColumns+` FROM jobs WHERE id = $1`, jobID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	return job, nil
+}
+
+func (s *PostgresStore) Retry(ctx context.Context, id string) (*Job, error) {
+	return s.transition(ctx, "queue.PostgresStore.Retry", id, `
+		UPDATE jobs SET status = 'pending', attempts = 0, run_at = NOW(), locked_until = NULL, finished_at = NULL
+		WHERE id = $1 AND status IN ('failed', 'cancelled')
+		RETURNING `+jobColumns)
+}
+
+func (s *PostgresStore) Cancel(ctx context.Context, id string) (*Job, error) {
+	return s.transition(ctx, "queue.PostgresStore.Cancel", id, `
+		UPDATE jobs SET status = 'cancelled', finished_at = NOW()
+		WHERE id = $1 AND status = 'pending'
+		RETURNING `+jobColumns)
+}
+
+// transition menjalankan update status yang hanya berlaku untuk status
+// tertentu. Jika tidak ada baris yang berubah, job dibaca lagi untuk
+// membedakan job yang tidak ada dari status yang tidak sesuai.
+func (s *PostgresStore) transition(ctx context.Context, name, id, query string) (*Job, error) {
+	ctx, span := tracing.StartPostgres(ctx, name)
+	defer span.End()
+
+	jobID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+	job, err := scanJob(s.db.QueryRowContext(ctx, query, jobID))
+	if errors.Is(err, sql.ErrNoRows) {
+		if _, err := s.Get(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidState
+	}
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	return job, nil
+}
+
+func (s *PostgresStore) Purge(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := tracing.StartPostgres(ctx, "queue.PostgresStore.Purge")
+	defer span.End()
+
+	result, err := s.db.ExecContext(ctx,
+		`DELETE FROM jobs WHERE status IN ('succeeded', 'cancelled') AND finished_at < $1`,
+		before.UTC(),
+	)
+	if err != nil {
+		return 0, tracing.Error(span, err)
+	}
+	return result.RowsAffected()
+}
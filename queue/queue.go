@@ -0,0 +1,201 @@
+// Package queue menjalankan pekerjaan di background di luar request handler,
+// misalnya membuat thumbnail, export, membersihkan data lama, atau mengirim
+// email. Job disimpan di tabel/collection jobs sehingga tetap ada saat
+// aplikasi restart, diambil worker dengan FOR UPDATE SKIP LOCKED (PostgreSQL)
+// atau FindOneAndUpdate (MongoDB), dicoba lagi dengan backoff jika gagal, dan
+// bisa dijadwalkan berkala dengan ekspresi cron.
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-fiber/apperror"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Status job.
+const (
+	StatusPending   = "pending"
+	StatusRunning   = "running"
+	StatusSucceeded = "succeeded"
+	// StatusFailed menandai job yang tetap gagal setelah batas percobaan atau
+	// gagal permanen. Job ini tidak dicoba lagi kecuali di-retry admin.
+	StatusFailed    = "failed"
+	StatusCancelled = "cancelled"
+)
+
+var (
+	// ErrNotFound dikembalikan Store jika job tidak ada.
+	ErrNotFound = errors.New("queue: job tidak ditemukan")
+	// ErrDuplicate dikembalikan Enqueue jika sudah ada job dengan UniqueKey
+	// yang sama.
+	ErrDuplicate = errors.New("queue: job dengan unique key yang sama sudah ada")
+	// ErrInvalidState dikembalikan Retry dan Cancel jika status job tidak
+	// mengizinkan aksi tersebut.
+	ErrInvalidState = errors.New("queue: status job tidak mengizinkan aksi ini")
+	// ErrLeaseLost dikembalikan Complete dan Fail jika job sudah tidak
+	// dipegang oleh percobaan tersebut, misalnya lease-nya habis lalu job
+	// diambil worker lain. Hasil percobaan lama tidak dicatat.
+	ErrLeaseLost = errors.New("queue: lease job sudah diambil alih")
+)
+
+// Job adalah satu pekerjaan di queue. Payload berisi JSON yang dibaca oleh
+// handler tipe job tersebut. MaxAttempts 0 berarti memakai batas handler
+// atau batas default queue.
+type Job struct {
+	ID          string          `json:"id"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload" swaggertype:"object"`
+	Status      string          `json:"status"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"max_attempts"`
+	LastError   string          `json:"last_error,omitempty"`
+	UniqueKey   string          `json:"unique_key,omitempty"`
+	RunAt       time.Time       `json:"run_at"`
+	LockedUntil *time.Time      `json:"locked_until,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	StartedAt   *time.Time      `json:"started_at,omitempty"`
+	FinishedAt  *time.Time      `json:"finished_at,omitempty"`
+}
+
+// Store menyimpan job. ID yang formatnya salah menghasilkan
+// apperror.InvalidID, ID yang tidak ada menghasilkan ErrNotFound.
+type Store interface {
+	// Enqueue menyimpan job pending dan mengisi ID serta CreatedAt-nya.
+	Enqueue(ctx context.Context, job *Job) error
+	// Claim mengambil paling banyak limit job bertipe jobType yang sudah
+	// waktunya dijalankan, menandainya running, menambah Attempts, dan
+	// menahannya selama lease. Job running yang lease-nya habis (misalnya
+	// instance mati) ikut diambil lagi.
+	Claim(ctx context.Context, jobType string, limit int, lease time.Duration) ([]Job, error)
+	// Complete menandai job succeeded. attempt adalah Attempts hasil Claim
+	// dan berfungsi sebagai token lease: jika job sudah diambil lagi atau
+	// tidak lagi running, tidak ada yang berubah dan hasilnya ErrLeaseLost.
+	Complete(ctx context.Context, id string, attempt int) error
+	// Fail mencatat percobaan attempt yang gagal dengan aturan lease yang
+	// sama seperti Complete. Job dijalankan lagi pada next, atau berhenti
+	// dengan status failed jika final bernilai true.
+	Fail(ctx context.Context, id string, attempt int, lastError string, next time.Time, final bool) error
+	List(ctx context.Context, filter Filter) ([]Job, int64, error)
+	Get(ctx context.Context, id string) (*Job, error)
+	// Retry menjadwalkan ulang job failed atau cancelled untuk segera
+	// dijalankan dengan hitungan percobaan dari nol.
+	Retry(ctx context.Context, id string) (*Job, error)
+	// Cancel membatalkan job pending. Job yang sedang berjalan tidak bisa
+	// dibatalkan.
+	Cancel(ctx context.Context, id string) (*Job, error)
+	// Purge menghapus job succeeded dan cancelled yang selesai sebelum before.
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
+
+// EnqueueOptions mengatur job yang dibuat Enqueue. RunAt kosong berarti
+// segera. UniqueKey mencegah job ganda, misalnya dari scheduler di beberapa
+// instance.
+type EnqueueOptions struct {
+	RunAt       time.Time
+	MaxAttempts int
+	UniqueKey   string
+}
+
+// Enqueue menyimpan job jobType dengan payload yang di-encode sebagai JSON.
+func Enqueue[T any](ctx context.Context, store Store, jobType string, payload T, opts EnqueueOptions) (*Job, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	runAt := opts.RunAt
+	if runAt.IsZero() {
+		runAt = time.Now()
+	}
+	job := &Job{
+		Type:        jobType,
+		Payload:     data,
+		Status:      StatusPending,
+		MaxAttempts: opts.MaxAttempts,
+		UniqueKey:   opts.UniqueKey,
+		RunAt:       runAt.UTC(),
+	}
+	if err := store.Enqueue(ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent menandai error handler yang tidak akan berhasil walaupun
+// dicoba lagi, misalnya payload tidak valid. Job langsung menjadi failed.
+func Permanent(err error) error {
+	return permanentError{err: err}
+}
+
+// IsPermanent melaporkan apakah err dibuat dengan Permanent.
+func IsPermanent(err error) bool {
+	var permanent permanentError
+	return errors.As(err, &permanent)
+}
+
+// Filter membatasi job yang dikembalikan List. Field kosong tidak membatasi
+// apa pun.
+type Filter struct {
+	Type   string
+	Status string
+	Page   int
+	Limit  int
+}
+
+// Offset adalah jumlah job yang dilewati untuk halaman Page.
+func (f Filter) Offset() int {
+	return (f.Page - 1) * f.Limit
+}
+
+// ParseFilter membaca query type, status, page, dan limit.
+func ParseFilter(c *fiber.Ctx) (Filter, error) {
+	filter := Filter{
+		Type:   strings.TrimSpace(c.Query("type")),
+		Status: strings.ToLower(strings.TrimSpace(c.Query("status"))),
+	}
+	switch filter.Status {
+	case "", StatusPending, StatusRunning, StatusSucceeded, StatusFailed, StatusCancelled:
+	default:
+		return Filter{}, apperror.Validation("job.invalid_status", filter.Status)
+	}
+
+	filter.Page, _ = strconv.Atoi(c.Query("page", "1"))
+	filter.Limit, _ = strconv.Atoi(c.Query("limit", "20"))
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Limit < 1 || filter.Limit > 100 {
+		filter.Limit = 20
+	}
+	return filter, nil
+}
+
+// Meta adalah informasi pagination pada response daftar job.
+type Meta struct {
+	Page  int   `json:"page"`
+	Limit int   `json:"limit"`
+	Total int64 `json:"total"`
+	Pages int64 `json:"pages"`
+}
+
+// NewMeta menghitung jumlah halaman dari total job.
+func NewMeta(filter Filter, total int64) Meta {
+	pages := (total + int64(filter.Limit) - 1) / int64(filter.Limit)
+	if pages == 0 {
+		pages = 1
+	}
+	return Meta{Page: filter.Page, Limit: filter.Limit, Total: total, Pages: pages}
+}
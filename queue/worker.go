@@ -0,0 +1,411 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"go-fiber/outbox"
+)
+
+// PurgeJobType adalah job bawaan yang menghapus job succeeded dan cancelled
+// yang lebih lama dari Options.Retention.
+const PurgeJobType = "queue.purge"
+
+// leaseMargin ditambahkan ke timeout handler sebagai lease job, sehingga job
+// hanya diambil instance lain jika instance yang menjalankannya mati.
+const leaseMargin = time.Minute
+
+// Options adalah batas default Queue. Concurrency membatasi jumlah job yang
+// berjalan bersamaan di instance ini untuk semua tipe.
+type Options struct {
+	Concurrency int
+	MaxAttempts int
+	Timeout     time.Duration
+	// Retention 0 mematikan job PurgeJobType.
+	Retention time.Duration
+}
+
+// HandlerOptions mengatur satu tipe job. Nilai 0 memakai nilai Options.
+type HandlerOptions struct {
+	Concurrency int
+	MaxAttempts int
+	Timeout     time.Duration
+}
+
+type handler struct {
+	run         func(ctx context.Context, payload json.RawMessage) error
+	concurrency int
+	maxAttempts int
+	timeout     time.Duration
+	running     int
+}
+
+type schedule struct {
+	name    string
+	spec    string
+	cron    Cron
+	jobType string
+	payload json.RawMessage
+	// next adalah waktu jalan terakhir yang sudah dibuatkan job.
+	next time.Time
+}
+
+// ScheduleInfo menjelaskan jadwal yang terdaftar untuk admin API.
+type ScheduleInfo struct {
+	Name      string    `json:"name"`
+	Spec      string    `json:"spec"`
+	Type      string    `json:"type"`
+	NextRunAt time.Time `json:"next_run_at"`
+}
+
+// Queue menjalankan handler untuk job di Store dan membuat job dari jadwal
+// cron. Setiap instance aplikasi menjalankan Queue sendiri; Store menjamin
+// satu job hanya diambil satu instance.
+type Queue struct {
+	name    string
+	store   Store
+	options Options
+
+	mu        sync.Mutex
+	handlers  map[string]*handler
+	schedules []*schedule
+	running   int
+
+	dispatchMu sync.Mutex
+	jobs       sync.WaitGroup
+	wake       chan struct{}
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+// New membuat Queue untuk store. name (misalnya postgres atau mongo) hanya
+// dipakai di log. Jika Retention diisi, job PurgeJobType didaftarkan dan
+// dijadwalkan setiap jam; error dari penjadwalan itu dikembalikan.
+func New(name string, store Store, options Options) (*Queue, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	q := &Queue{
+		name:     name,
+		store:    store,
+		options:  options,
+		handlers: map[string]*handler{},
+		wake:     make(chan struct{}, 1),
+		ctx:      ctx,
+		cancel:   cancel,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	if options.Retention > 0 {
+		Register(q, PurgeJobType, q.purge, HandlerOptions{Concurrency: 1, MaxAttempts: 1})
+		if err := q.Schedule(PurgeJobType, "@hourly", PurgeJobType, struct{}{}); err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+// Store mengembalikan store queue, misalnya untuk Enqueue dari service.
+func (q *Queue) Store() Store {
+	return q.store
+}
+
+// Register mendaftarkan handler untuk jobType. Payload job di-decode ke T;
+// payload yang tidak bisa di-decode membuat job langsung failed. Handler
+// yang mengembalikan error dicoba lagi dengan outbox.Backoff sampai batas
+// percobaan, kecuali error-nya dibuat dengan Permanent. Register panic jika
+// jobType sudah terdaftar.
+func Register[T any](q *Queue, jobType string, fn func(ctx context.Context, payload T) error, opts HandlerOptions) {
+	run := func(ctx context.Context, data json.RawMessage) error {
+		var payload T
+		if len(data) > 0 {
+			if err := json.Unmarshal(data, &payload); err != nil {
+				return Permanent(fmt.Errorf("payload tidak valid: %w", err))
+			}
+		}
+		return fn(ctx, payload)
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = q.options.Concurrency
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = q.options.MaxAttempts
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = q.options.Timeout
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if _, ok := q.handlers[jobType]; ok {
+		panic(fmt.Sprintf("queue: handler %q sudah terdaftar", jobType))
+	}
+	q.handlers[jobType] = &handler{
+		run:         run,
+		concurrency: opts.Concurrency,
+		maxAttempts: opts.MaxAttempts,
+		timeout:     opts.Timeout,
+	}
+}
+
+// Schedule membuat job jobType dengan payload setiap kali jadwal spec (lihat
+// ParseCron) jatuh tempo. Job jadwal memakai unique key dari name dan waktu
+// jalannya sehingga beberapa instance tidak membuat job ganda.
+func (q *Queue) Schedule(name, spec, jobType string, payload interface{}) error {
+	cron, err := ParseCron(spec)
+	if err != nil {
+		return err
+	}
+	if cron.Next(time.Now()).IsZero() {
+		return fmt.Errorf("jadwal %q tidak pernah berjalan", spec)
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, existing := range q.schedules {
+		if existing.name == name {
+			return fmt.Errorf("jadwal %q sudah terdaftar", name)
+		}
+	}
+	q.schedules = append(q.schedules, &schedule{name: name, spec: spec, cron: cron, jobType: jobType, payload: data})
+	return nil
+}
+
+// Schedules mengembalikan jadwal yang terdaftar, urut berdasarkan nama.
+func (q *Queue) Schedules() []ScheduleInfo {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	now := time.Now()
+	schedules := make([]ScheduleInfo, 0, len(q.schedules))
+	for _, s := range q.schedules {
+		schedules = append(schedules, ScheduleInfo{Name: s.name, Spec: s.spec, Type: s.jobType, NextRunAt: s.cron.Next(now)})
+	}
+	sort.Slice(schedules, func(i, j int) bool { return schedules[i].Name < schedules[j].Name })
+	return schedules
+}
+
+// Start menjalankan worker di goroutine terpisah. Job diperiksa setiap
+// interval, setiap kali ada job yang selesai, atau langsung lagi jika
+// pengambilan sebelumnya mendapat job.
+func (q *Queue) Start(interval time.Duration) {
+	go func() {
+		defer close(q.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			claimed, err := q.dispatch(q.ctx)
+			if err != nil && q.ctx.Err() == nil {
+				slog.Warn("Queue gagal mengambil job", "store", q.name, "error", err)
+			}
+			if err == nil && claimed > 0 {
+				select {
+				case <-q.stop:
+					return
+				default:
+					continue
+				}
+			}
+			select {
+			case <-q.stop:
+				return
+			case <-q.wake:
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Shutdown menghentikan worker dan menunggu job yang sedang berjalan selesai
+// atau ctx habis. Jika waktu habis, context handler dibatalkan; job yang
+// belum tercatat diambil lagi setelah lease-nya habis.
+func (q *Queue) Shutdown(ctx context.Context) error {
+	close(q.stop)
+	finished := make(chan struct{})
+	go func() {
+		<-q.done
+		q.jobs.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+		return nil
+	case <-ctx.Done():
+		q.cancel()
+		return ctx.Err()
+	}
+}
+
+// Flush membuat job dari jadwal yang jatuh tempo, menjalankan job yang siap
+// sesuai batas concurrency, dan menunggu semuanya selesai. Hasilnya adalah
+// jumlah job yang diambil; hasil setiap job dicatat di store.
+func (q *Queue) Flush(ctx context.Context) (int, error) {
+	claimed, err := q.dispatch(ctx)
+	q.jobs.Wait()
+	return claimed, err
+}
+
+// dispatch mengambil job untuk setiap tipe yang masih punya slot dan
+// menjalankannya tanpa menunggu.
+func (q *Queue) dispatch(ctx context.Context) (int, error) {
+	q.dispatchMu.Lock()
+	defer q.dispatchMu.Unlock()
+
+	q.enqueueSchedules(ctx)
+
+	q.mu.Lock()
+	types := make([]string, 0, len(q.handlers))
+	for jobType := range q.handlers {
+		types = append(types, jobType)
+	}
+	q.mu.Unlock()
+	sort.Strings(types)
+
+	claimed := 0
+	var errs []error
+	for _, jobType := range types {
+		q.mu.Lock()
+		h := q.handlers[jobType]
+		limit := min(h.concurrency-h.running, q.options.Concurrency-q.running)
+		q.mu.Unlock()
+		if limit <= 0 {
+			continue
+		}
+
+		jobs, err := q.store.Claim(ctx, jobType, limit, h.timeout+leaseMargin)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", jobType, err))
+		}
+		q.mu.Lock()
+		h.running += len(jobs)
+		q.running += len(jobs)
+		q.mu.Unlock()
+		for _, job := range jobs {
+			q.jobs.Add(1)
+			go q.run(job, h)
+		}
+		claimed += len(jobs)
+	}
+	return claimed, errors.Join(errs...)
+}
+
+// enqueueSchedules membuat job untuk waktu jalan berikutnya dari setiap
+// jadwal. Job dibuat sebelum waktunya dengan RunAt, jadi hanya sekali per
+// waktu jalan untuk setiap instance.
+func (q *Queue) enqueueSchedules(ctx context.Context) {
+	q.mu.Lock()
+	schedules := append([]*schedule(nil), q.schedules...)
+	q.mu.Unlock()
+
+	now := time.Now()
+	for _, s := range schedules {
+		next := s.cron.Next(now)
+		if next.IsZero() || next.Equal(s.next) {
+			continue
+		}
+		job := &Job{
+			Type:      s.jobType,
+			Payload:   s.payload,
+			Status:    StatusPending,
+			UniqueKey: fmt.Sprintf("schedule:%s:%d", s.name, next.Unix()),
+			RunAt:     next,
+		}
+		err := q.store.Enqueue(ctx, job)
+		if err != nil && !errors.Is(err, ErrDuplicate) {
+			slog.WarnContext(ctx, "Queue gagal membuat job terjadwal", "store", q.name, "schedule", s.name, "error", err)
+			continue
+		}
+		s.next = next
+	}
+}
+
+func (q *Queue) run(job Job, h *handler) {
+	defer q.jobs.Done()
+	defer func() {
+		q.mu.Lock()
+		h.running--
+		q.running--
+		q.mu.Unlock()
+		select {
+		case q.wake <- struct{}{}:
+		default:
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(q.ctx, h.timeout)
+	started := time.Now()
+	err := call(ctx, h, job.Payload)
+	cancel()
+
+	if err == nil {
+		q.record(job, q.store.Complete(q.ctx, job.ID, job.Attempts), "Gagal mencatat job selesai")
+		return
+	}
+
+	// Shutdown yang membatalkan handler bukan kegagalan job; job dibiarkan
+	// dan diambil lagi setelah lease habis.
+	if q.ctx.Err() != nil {
+		return
+	}
+
+	maxAttempts := job.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = h.maxAttempts
+	}
+	final := IsPermanent(err) || job.Attempts >= maxAttempts
+	next := time.Now()
+	logArgs := []interface{}{"store", q.name, "job_id", job.ID, "type", job.Type, "attempts", job.Attempts,
+		"duration_ms", time.Since(started).Milliseconds(), "error", err}
+	if final {
+		slog.Error("Job gagal dan tidak dicoba lagi", logArgs...)
+	} else {
+		next = next.Add(outbox.Backoff(job.Attempts))
+		slog.Warn("Job gagal", append(logArgs, "next_run_at", next)...)
+	}
+	q.record(job, q.store.Fail(q.ctx, job.ID, job.Attempts, err.Error(), next, final), "Gagal mencatat job gagal")
+}
+
+// record mencatat error dari Complete atau Fail. ErrLeaseLost berarti job
+// sudah diambil worker lain setelah lease habis, sehingga hasil percobaan ini
+// dibuang dan percobaan yang baru yang menentukan status job.
+func (q *Queue) record(job Job, err error, message string) {
+	if errors.Is(err, ErrLeaseLost) {
+		slog.Warn("Lease job hilang, hasil percobaan dibuang", "store", q.name, "job_id", job.ID, "type", job.Type, "attempts", job.Attempts)
+		return
+	}
+	if err != nil {
+		slog.Warn(message, "store", q.name, "job_id", job.ID, "error", err)
+	}
+}
+
+// call menjalankan handler dan mengubah panic menjadi error agar satu job
+// tidak menghentikan worker.
+func call(ctx context.Context, h *handler, payload json.RawMessage) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("panic: %v", recovered)
+		}
+	}()
+	return h.run(ctx, payload)
+}
+
+func (q *Queue) purge(ctx context.Context, _ struct{}) error {
+	deleted, err := q.store.Purge(ctx, time.Now().Add(-q.options.Retention))
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		slog.InfoContext(ctx, "Job lama dihapus", "store", q.name, "deleted", deleted)
+	}
+	return nil
+}
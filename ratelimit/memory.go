@@ -0,0 +1,66 @@
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// sweepInterval adalah jeda minimal antar pembersihan counter kedaluwarsa.
+const sweepInterval = time.Minute
+
+type memoryEntry struct {
+	count     int64
+	expiresAt time.Time
+}
+
+// MemoryStore menyimpan counter di memori proses. Batas hanya berlaku per
+// instance, cocok untuk development atau deployment satu instance.
+type MemoryStore struct {
+	mu        sync.Mutex
+	entries   map[string]*memoryEntry
+	lastSweep time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: map[string]*memoryEntry{}, lastSweep: time.Now()}
+}
+
+func counterID(key string, start time.Time) string {
+	return key + "|" + strconv.FormatInt(start.UnixNano(), 10)
+}
+
+func (s *MemoryStore) Increment(ctx context.Context, key string, start time.Time, ttl time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.lastSweep) >= sweepInterval {
+		for id, entry := range s.entries {
+			if now.After(entry.expiresAt) {
+				delete(s.entries, id)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	id := counterID(key, start)
+	entry, ok := s.entries[id]
+	if !ok {
+		entry = &memoryEntry{expiresAt: start.Add(ttl)}
+		s.entries[id] = entry
+	}
+	entry.count++
+	return entry.count, nil
+}
+
+func (s *MemoryStore) Count(ctx context.Context, key string, start time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry, ok := s.entries[counterID(key, start)]; ok {
+		return entry.count, nil
+	}
+	return 0, nil
+}
@@ -0,0 +1,60 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"go-fiber/tracing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoStore menyimpan counter di collection rate_limits sehingga batas
+// berlaku di semua instance. Counter kedaluwarsa dihapus oleh TTL index pada
+// expires_at yang dibuat RunMigrations.
+type MongoStore struct {
+	collection *mongo.Collection
+}
+
+func NewMongoStore(db *mongo.Database) *MongoStore {
+	return &MongoStore{collection: db.Collection("rate_limits")}
+}
+
+type mongoCounter struct {
+	Count int64 `bson:"count"`
+}
+
+func (s *MongoStore) Increment(ctx context.Context, key string, start time.Time, ttl time.Duration) (int64, error) {
+	ctx, span := tracing.StartMongo(ctx, "MongoStore.Increment")
+	defer span.End()
+
+	update := bson.M{
+		"$inc":         bson.M{"count": 1},
+		"$setOnInsert": bson.M{"key": key, "window_start": start, "expires_at": start.Add(ttl)},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var counter mongoCounter
+	err := s.collection.FindOneAndUpdate(ctx, bson.M{"_id": counterID(key, start)}, update, opts).Decode(&counter)
+	if err != nil {
+		return 0, tracing.Error(span, err)
+	}
+	return counter.Count, nil
+}
+
+func (s *MongoStore) Count(ctx context.Context, key string, start time.Time) (int64, error) {
+	ctx, span := tracing.StartMongo(ctx, "MongoStore.Count")
+	defer span.End()
+
+	var counter mongoCounter
+	err := s.collection.FindOne(ctx, bson.M{"_id": counterID(key, start)}).Decode(&counter)
+	if err == mongo.ErrNoDocuments {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, tracing.Error(span, err)
+	}
+	return counter.Count, nil
+}
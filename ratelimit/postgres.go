@@ -0,0 +1,77 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"sync"
+	"time"
+
+	"go-fiber/tracing"
+)
+
+// PostgresStore menyimpan counter di tabel rate_limits (dibuat oleh
+// RunPostgresMigrations) sehingga batas berlaku di semua instance.
+type PostgresStore struct {
+	db *sql.DB
+
+	mu        sync.Mutex
+	lastSweep time.Time
+}
+
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db, lastSweep: time.Now()}
+}
+
+func (s *PostgresStore) Increment(ctx context.Context, key string, start time.Time, ttl time.Duration) (int64, error) {
+	ctx, span := tracing.StartPostgres(ctx, "PostgresStore.Increment")
+	defer span.End()
+
+	s.sweep(ctx)
+
+	var count int64
+	err := s.db.QueryRowContext(ctx, `
+		INSERT INTO rate_limits (key, window_start, count, expires_at)
+		VALUES ($1, $2, 1, $3)
+		ON CONFLICT (key, window_start) DO UPDATE SET count = rate_limits.count + 1
+		RETURNING count
+	`, key, start.UTC(), start.Add(ttl).UTC()).Scan(&count)
+	if err != nil {
+		return 0, tracing.Error(span, err)
+	}
+	return count, nil
+}
+
+func (s *PostgresStore) Count(ctx context.Context, key string, start time.Time) (int64, error) {
+	ctx, span := tracing.StartPostgres(ctx, "PostgresStore.Count")
+	defer span.End()
+
+	var count int64
+	err := s.db.QueryRowContext(ctx,
+		`SELECT count FROM rate_limits WHERE key = $1 AND window_start = $2`,
+		key, start.UTC(),
+	).Scan(&count)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, tracing.Error(span, err)
+	}
+	return count, nil
+}
+
+// sweep menghapus counter kedaluwarsa paling sering sekali per sweepInterval
+// per instance. Kegagalan hanya dicatat karena tidak memengaruhi limit.
+func (s *PostgresStore) sweep(ctx context.Context) {
+	s.mu.Lock()
+	if time.Since(s.lastSweep) < sweepInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.lastSweep = time.Now()
+	s.mu.Unlock()
+
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM rate_limits WHERE expires_at < NOW()`); err != nil {
+		slog.WarnContext(ctx, "Gagal membersihkan rate_limits", "error", err)
+	}
+}
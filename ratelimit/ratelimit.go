@@ -0,0 +1,233 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-fiber/apperror"
+	"go-fiber/metrics"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Store menyimpan counter request per key dan window. Implementasi bersama
+// (PostgreSQL, MongoDB) membuat batas berlaku di semua instance.
+type Store interface {
+	// Increment menambah counter key pada window yang dimulai di start dan
+	// mengembalikan nilai setelah ditambah. Counter boleh dihapus setelah ttl.
+	Increment(ctx context.Context, key string, start time.Time, ttl time.Duration) (int64, error)
+	// Count mengembalikan counter key pada window start, 0 jika belum ada.
+	Count(ctx context.Context, key string, start time.Time) (int64, error)
+}
+
+// KeyFunc menentukan identitas client yang dibatasi.
+type KeyFunc func(c *fiber.Ctx) string
+
+// KeyByIP membatasi per alamat IP client.
+func KeyByIP(c *fiber.Ctx) string {
+	return "ip:" + c.IP()
+}
+
+// KeyByUser membatasi per alumni yang login. Dipasang setelah AuthRequired;
+// request tanpa user dibatasi per IP.
+func KeyByUser(c *fiber.Ctx) string {
+	if alumniID := c.Locals("alumni_id"); alumniID != nil {
+		return fmt.Sprintf("user:%v", alumniID)
+	}
+	return KeyByIP(c)
+}
+
+// KeyByAPIKey membatasi per API key dari header X-API-Key; request tanpa
+// header dibatasi per IP. Key disimpan sebagai hash agar API key tidak
+// tersimpan di store. Key belum diverifikasi saat dibatasi, sehingga
+// client yang menebak key mendapat bucket baru untuk setiap tebakan; karena
+// itu api_key tidak boleh dipakai di route pengecekan API key, lihat
+// ParseCheckPolicy.
+func KeyByAPIKey(c *fiber.Ctx) string {
+	apiKey := c.Get("X-API-Key")
+	if apiKey == "" {
+		return KeyByIP(c)
+	}
+	sum := sha256.Sum256([]byte(apiKey))
+	return "api_key:" + hex.EncodeToString(sum[:8])
+}
+
+var keyFuncs = map[string]KeyFunc{
+	"ip":      KeyByIP,
+	"user":    KeyByUser,
+	"api_key": KeyByAPIKey,
+}
+
+// ParseCheckPolicy sama dengan ParsePolicy untuk route pengecekan API key,
+// tetapi menolak key api_key agar tebakan key tetap dibatasi per client.
+func ParseCheckPolicy(name, spec string) (Policy, error) {
+	policy, err := ParsePolicy(name, spec)
+	if err != nil {
+		return Policy{}, err
+	}
+	if strings.HasPrefix(strings.TrimSpace(spec), "api_key:") {
+		return Policy{}, fmt.Errorf("policy %q tidak boleh memakai api_key karena key yang ditebak belum diverifikasi; gunakan ip", spec)
+	}
+	return policy, nil
+}
+
+// Policy adalah batas Limit request per Window untuk setiap key.
+type Policy struct {
+	Name   string
+	Limit  int
+	Window time.Duration
+	Key    KeyFunc
+}
+
+// ParsePolicy membaca policy berformat key:jumlah/window, misalnya
+// "ip:5/1m" atau "user:20/1h". key adalah ip, user, atau api_key.
+func ParsePolicy(name, spec string) (Policy, error) {
+	keyName, rule, ok := strings.Cut(strings.TrimSpace(spec), ":")
+	if !ok {
+		return Policy{}, fmt.Errorf("policy %q harus berformat key:jumlah/window, contoh ip:5/1m", spec)
+	}
+	key, ok := keyFuncs[keyName]
+	if !ok {
+		return Policy{}, fmt.Errorf("key %q pada policy %q harus ip, user, atau api_key", keyName, spec)
+	}
+
+	limitText, windowText, ok := strings.Cut(rule, "/")
+	if !ok {
+		return Policy{}, fmt.Errorf("policy %q harus berformat key:jumlah/window, contoh ip:5/1m", spec)
+	}
+	limit, err := strconv.Atoi(limitText)
+	if err != nil || limit < 1 {
+		return Policy{}, fmt.Errorf("jumlah request pada policy %q harus angka minimal 1", spec)
+	}
+	window, err := time.ParseDuration(windowText)
+	if err != nil || window < time.Second {
+		return Policy{}, fmt.Errorf("window pada policy %q harus durasi minimal 1s, contoh 1m", spec)
+	}
+
+	return Policy{Name: name, Limit: limit, Window: window, Key: key}, nil
+}
+
+// Result adalah keputusan Limiter untuk satu request.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset adalah sisa waktu sampai window berjalan berakhir.
+	Reset time.Duration
+}
+
+// Limiter menerapkan sliding window counter: jumlah request pada window
+// sebelumnya dihitung proporsional dengan bagian window yang masih tumpang
+// tindih, sehingga tidak ada lonjakan dua kali lipat di pergantian window.
+// Request yang ditolak tetap dihitung, sehingga client yang terus mencoba
+// baru lolos setelah berhenti sejenak.
+type Limiter struct {
+	store Store
+}
+
+func NewLimiter(store Store) *Limiter {
+	return &Limiter{store: store}
+}
+
+// Allow mencatat satu request untuk key dan menentukan apakah request itu
+// masih di bawah batas policy.
+func (l *Limiter) Allow(ctx context.Context, key string, policy Policy) (Result, error) {
+	now := time.Now()
+	start := now.Truncate(policy.Window)
+
+	previous, err := l.store.Count(ctx, key, start.Add(-policy.Window))
+	if err != nil {
+		return Result{}, err
+	}
+	// Counter disimpan dua window karena masih dipakai sebagai window
+	// sebelumnya pada window berikutnya.
+	current, err := l.store.Increment(ctx, key, start, 2*policy.Window)
+	if err != nil {
+		return Result{}, err
+	}
+
+	elapsed := now.Sub(start)
+	weight := 1 - float64(elapsed)/float64(policy.Window)
+	estimate := float64(previous)*weight + float64(current)
+
+	return Result{
+		Allowed:   estimate <= float64(policy.Limit),
+		Limit:     policy.Limit,
+		Remaining: max(policy.Limit-int(math.Ceil(estimate)), 0),
+		Reset:     policy.Window - elapsed,
+	}, nil
+}
+
+// Handler membatasi request sesuai policy dan menulis header RateLimit-*.
+// Request yang melewati batas mendapat 429 dengan header Retry-After. Jika
+// store tidak bisa dihubungi request tetap diteruskan, agar gangguan store
+// tidak membuat seluruh API ikut mati.
+func (l *Limiter) Handler(policy Policy) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		result, err := l.Allow(c.UserContext(), policy.Name+":"+policy.Key(c), policy)
+		if err != nil {
+			slog.WarnContext(c.UserContext(), "Rate limit store tidak tersedia, request diteruskan",
+				"policy", policy.Name, "error", err)
+			return c.Next()
+		}
+		metrics.ObserveRateLimit(policy.Name, result.Allowed)
+
+		reset := strconv.Itoa(int(math.Ceil(result.Reset.Seconds())))
+		c.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Set("RateLimit-Reset", reset)
+		c.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", policy.Limit, int(policy.Window.Seconds())))
+
+		if !result.Allowed {
+			c.Set(fiber.HeaderRetryAfter, reset)
+			return apperror.New(fiber.StatusTooManyRequests, "rate_limited", "error.rate_limited", reset)
+		}
+		return c.Next()
+	}
+}
+
+// RouteLimits berisi middleware rate limit untuk route yang rawan
+// disalahgunakan: login, pengecekan API key, dan upload file.
+type RouteLimits struct {
+	Login  fiber.Handler
+	Check  fiber.Handler
+	Upload fiber.Handler
+}
+
+// Disabled mengembalikan RouteLimits yang meneruskan semua request.
+func Disabled() RouteLimits {
+	next := func(c *fiber.Ctx) error {
+		return c.Next()
+	}
+	return RouteLimits{Login: next, Check: next, Upload: next}
+}
+
+// NewRouteLimits membuat RouteLimits dari policy berformat ParsePolicy.
+// Policy check dibaca dengan ParseCheckPolicy.
+func NewRouteLimits(limiter *Limiter, login, check, upload string) (RouteLimits, error) {
+	var limits RouteLimits
+	for _, route := range []struct {
+		name    string
+		spec    string
+		parse   func(name, spec string) (Policy, error)
+		handler *fiber.Handler
+	}{
+		{"login", login, ParsePolicy, &limits.Login},
+		{"check", check, ParseCheckPolicy, &limits.Check},
+		{"upload", upload, ParsePolicy, &limits.Upload},
+	} {
+		policy, err := route.parse(route.name, route.spec)
+		if err != nil {
+			return RouteLimits{}, err
+		}
+		*route.handler = limiter.Handler(policy)
+	}
+	return limits, nil
+}
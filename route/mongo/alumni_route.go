@@ -3,22 +3,23 @@ package route
 import (
 	service "go-fiber/app/service/mongo"
 	middleware "go-fiber/middleware/mongo"
+	"go-fiber/ratelimit"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func AlumniRoutes(app *fiber.App, alumniService *service.AlumniService, authService *service.AuthService) {
+func AlumniRoutes(app *fiber.App, alumniService *service.AlumniService, authService *service.AuthService, limits ratelimit.RouteLimits, idempotent fiber.Handler, authRequired fiber.Handler) {
 	api := app.Group("/go-fiber-mongo")
 
-	api.Post("/login", func(c *fiber.Ctx) error {
+	api.Post("/login", limits.Login, func(c *fiber.Ctx) error {
 		return authService.LoginService(c)
 	})
 
-	api.Post("/alumni/check/:key", func(c *fiber.Ctx) error {
+	api.Post("/alumni/check/:key", limits.Check, func(c *fiber.Ctx) error {
 		return alumniService.CheckAlumniService(c)
 	})
 
-	protected := api.Group("", middleware.AuthRequired())
+	protected := api.Group("", authRequired)
 
 	protected.Get("/profile", func(c *fiber.Ctx) error {
 		return authService.GetProfileService(c)
@@ -31,13 +32,22 @@ func AlumniRoutes(app *fiber.App, alumniService *service.AlumniService, authServ
 	alumni.Get("/:id", middleware.UserOrAdmin(), func(c *fiber.Ctx) error {
 		return alumniService.GetAlumniByIDService(c)
 	})
-	alumni.Post("/", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+	alumni.Get("/:id/history", middleware.UserOrAdmin(), func(c *fiber.Ctx) error {
+		return alumniService.GetAlumniHistoryService(c)
+	})
+	alumni.Post("/", middleware.AdminOnly(), idempotent, func(c *fiber.Ctx) error {
 		return alumniService.CreateAlumniService(c)
 	})
 	alumni.Put("/:id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
 		return alumniService.UpdateAlumniService(c)
 	})
+	alumni.Patch("/:id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return alumniService.PatchAlumniService(c)
+	})
 	alumni.Delete("/:id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
 		return alumniService.DeleteAlumniService(c)
 	})
+	alumni.Post("/:id/revert/:version", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return alumniService.RevertAlumniService(c)
+	})
 }
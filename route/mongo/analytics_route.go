@@ -0,0 +1,29 @@
+package route
+
+import (
+	service "go-fiber/app/service/mongo"
+	middleware "go-fiber/middleware/mongo"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func AnalyticsRoutes(app *fiber.App, analyticsService *service.AnalyticsService, authRequired fiber.Handler) {
+	api := app.Group("/go-fiber-mongo")
+
+	analytics := api.Group("/analytics", authRequired, middleware.AdminOnly())
+	analytics.Get("/employment-rate", func(c *fiber.Ctx) error {
+		return analyticsService.GetEmploymentRateService(c)
+	})
+	analytics.Get("/time-to-employment", func(c *fiber.Ctx) error {
+		return analyticsService.GetTimeToEmploymentService(c)
+	})
+	analytics.Get("/distribution", func(c *fiber.Ctx) error {
+		return analyticsService.GetEmploymentDistributionService(c)
+	})
+	analytics.Get("/top-employers", func(c *fiber.Ctx) error {
+		return analyticsService.GetTopEmployersService(c)
+	})
+	analytics.Get("/salary", func(c *fiber.Ctx) error {
+		return analyticsService.GetSalaryStatisticsService(c)
+	})
+}
@@ -0,0 +1,17 @@
+package route
+
+import (
+	service "go-fiber/app/service/mongo"
+	middleware "go-fiber/middleware/mongo"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func AuditRoutes(app *fiber.App, auditService *service.AuditService, authRequired fiber.Handler) {
+	api := app.Group("/go-fiber-mongo")
+
+	auditLogs := api.Group("/audit-logs", authRequired, middleware.AdminOnly())
+	auditLogs.Get("/", func(c *fiber.Ctx) error {
+		return auditService.GetAuditLogsService(c)
+	})
+}
@@ -0,0 +1,25 @@
+package route
+
+import (
+	service "go-fiber/app/service/mongo"
+	middleware "go-fiber/middleware/mongo"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func BatchRoutes(app *fiber.App, batchService *service.BatchService, authRequired fiber.Handler) {
+	api := app.Group("/go-fiber-mongo")
+
+	api.Post("/alumni/batch/update", authRequired, middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return batchService.BatchUpdateAlumniService(c)
+	})
+	api.Post("/alumni/batch/delete", authRequired, middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return batchService.BatchDeleteAlumniService(c)
+	})
+	api.Post("/pekerjaan/batch/update", authRequired, middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return batchService.BatchUpdatePekerjaanAlumniService(c)
+	})
+	api.Post("/pekerjaan/batch/delete", authRequired, middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return batchService.BatchDeletePekerjaanAlumniService(c)
+	})
+}
@@ -0,0 +1,32 @@
+package route
+
+import (
+	service "go-fiber/app/service/mongo"
+	middleware "go-fiber/middleware/mongo"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func ExportRoutes(app *fiber.App, exportService *service.ExportService, authRequired fiber.Handler) {
+	api := app.Group("/go-fiber-mongo")
+
+	export := api.Group("/export", authRequired, middleware.AdminOnly())
+	export.Get("/alumni", func(c *fiber.Ctx) error {
+		return exportService.ExportAlumniService(c)
+	})
+	export.Get("/pekerjaan", func(c *fiber.Ctx) error {
+		return exportService.ExportPekerjaanAlumniService(c)
+	})
+	export.Get("/analytics/:report", func(c *fiber.Ctx) error {
+		return exportService.ExportAnalyticsService(c)
+	})
+	export.Get("/report/tracer-study", func(c *fiber.Ctx) error {
+		return exportService.ExportTracerStudyReportService(c)
+	})
+	export.Get("/jobs/:id", func(c *fiber.Ctx) error {
+		return exportService.GetExportJobService(c)
+	})
+	export.Get("/jobs/:id/download", func(c *fiber.Ctx) error {
+		return exportService.DownloadExportJobService(c)
+	})
+}
@@ -3,25 +3,26 @@ package route
 import (
 	service "go-fiber/app/service/mongo"
 	middleware "go-fiber/middleware/mongo"
+	"go-fiber/ratelimit"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func FileRoutes(app *fiber.App, fileService *service.FileService) {
+func FileRoutes(app *fiber.App, fileService *service.FileService, limits ratelimit.RouteLimits, idempotent fiber.Handler, authRequired fiber.Handler) {
 	api := app.Group("/go-fiber-mongo")
 
-	files := api.Group("/files", middleware.AuthRequired())
+	files := api.Group("/files", authRequired)
 	files.Get("/", middleware.UserOrAdmin(), func(c *fiber.Ctx) error {
 		return fileService.GetAllFilesService(c)
 	})
 	files.Get("/:id", middleware.UserOrAdmin(), func(c *fiber.Ctx) error {
 		return fileService.GetFileByIDService(c)
 	})
-	files.Post("/upload/foto", middleware.ValidateAlumniAccess(), func(c *fiber.Ctx) error {
+	files.Post("/upload/foto", limits.Upload, middleware.ValidateAlumniAccess(), idempotent, func(c *fiber.Ctx) error {
 		return fileService.UploadFotoService(c)
 	})
 
-	files.Post("/upload/sertifikat", middleware.ValidateAlumniAccess(), func(c *fiber.Ctx) error {
+	files.Post("/upload/sertifikat", limits.Upload, middleware.ValidateAlumniAccess(), idempotent, func(c *fiber.Ctx) error {
 		return fileService.UploadSertifikatService(c)
 	})
 
@@ -0,0 +1,19 @@
+package route
+
+import (
+	service "go-fiber/app/service/mongo"
+	middleware "go-fiber/middleware/mongo"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func ImportRoutes(app *fiber.App, importService *service.ImportService, authRequired fiber.Handler) {
+	api := app.Group("/go-fiber-mongo")
+
+	api.Post("/alumni/import", authRequired, middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return importService.ImportAlumniService(c)
+	})
+	api.Post("/pekerjaan/import", authRequired, middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return importService.ImportPekerjaanAlumniService(c)
+	})
+}
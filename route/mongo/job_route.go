@@ -0,0 +1,31 @@
+package route
+
+import (
+	service "go-fiber/app/service/mongo"
+	middleware "go-fiber/middleware/mongo"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func JobRoutes(app *fiber.App, jobService *service.JobService, authRequired fiber.Handler) {
+	api := app.Group("/go-fiber-mongo")
+
+	jobs := api.Group("/jobs", authRequired, middleware.AdminOnly())
+
+	// /schedules didaftarkan sebelum /:id agar tidak dibaca sebagai ID job.
+	jobs.Get("/schedules", func(c *fiber.Ctx) error {
+		return jobService.GetJobSchedulesService(c)
+	})
+	jobs.Get("/", func(c *fiber.Ctx) error {
+		return jobService.GetJobsService(c)
+	})
+	jobs.Get("/:id", func(c *fiber.Ctx) error {
+		return jobService.GetJobService(c)
+	})
+	jobs.Post("/:id/retry", func(c *fiber.Ctx) error {
+		return jobService.RetryJobService(c)
+	})
+	jobs.Post("/:id/cancel", func(c *fiber.Ctx) error {
+		return jobService.CancelJobService(c)
+	})
+}
@@ -7,25 +7,34 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func PekerjaanRoutes(app *fiber.App, pekerjaanService *service.PekerjaanAlumniService) {
+func PekerjaanRoutes(app *fiber.App, pekerjaanService *service.PekerjaanAlumniService, idempotent fiber.Handler, authRequired fiber.Handler) {
 	api := app.Group("/go-fiber-mongo")
 	
-	pekerjaan := api.Group("/pekerjaan", middleware.AuthRequired())
+	pekerjaan := api.Group("/pekerjaan", authRequired)
 	pekerjaan.Get("/", middleware.UserOrAdmin(), func(c *fiber.Ctx) error {
 		return pekerjaanService.GetAllPekerjaanAlumniService(c)
 	})
 	pekerjaan.Get("/:id", middleware.UserOrAdmin(), func(c *fiber.Ctx) error {
 		return pekerjaanService.GetPekerjaanAlumniByIDService(c)
 	})
+	pekerjaan.Get("/:id/history", middleware.UserOrAdmin(), func(c *fiber.Ctx) error {
+		return pekerjaanService.GetPekerjaanAlumniHistoryService(c)
+	})
 	pekerjaan.Get("/alumni/:alumni_id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
 		return pekerjaanService.GetPekerjaanAlumniByAlumniIDService(c)
 	})
-	pekerjaan.Post("/", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+	pekerjaan.Post("/", middleware.AdminOnly(), idempotent, func(c *fiber.Ctx) error {
 		return pekerjaanService.CreatePekerjaanAlumniService(c)
 	})
 	pekerjaan.Put("/:id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
 		return pekerjaanService.UpdatePekerjaanAlumniService(c)
 	})
+	pekerjaan.Patch("/:id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return pekerjaanService.PatchPekerjaanAlumniService(c)
+	})
+	pekerjaan.Post("/:id/revert/:version", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return pekerjaanService.RevertPekerjaanAlumniService(c)
+	})
 	pekerjaan.Delete("/:id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
 		return pekerjaanService.DeletePekerjaanAlumniService(c)
 	})
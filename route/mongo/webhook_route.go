@@ -0,0 +1,51 @@
+package route
+
+import (
+	service "go-fiber/app/service/mongo"
+	middleware "go-fiber/middleware/mongo"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func WebhookRoutes(app *fiber.App, webhookService *service.WebhookService, authRequired fiber.Handler) {
+	api := app.Group("/go-fiber-mongo")
+
+	webhooks := api.Group("/webhooks", authRequired, middleware.AdminOnly())
+
+	// Route delivery didaftarkan sebelum /:id agar "deliveries" dan
+	// "dead-letters" tidak dibaca sebagai ID subscription.
+	webhooks.Get("/deliveries", func(c *fiber.Ctx) error {
+		return webhookService.GetDeliveriesService(c)
+	})
+	webhooks.Get("/dead-letters", func(c *fiber.Ctx) error {
+		return webhookService.GetDeadLettersService(c)
+	})
+	webhooks.Get("/deliveries/:deliveryId", func(c *fiber.Ctx) error {
+		return webhookService.GetDeliveryService(c)
+	})
+	webhooks.Post("/deliveries/:deliveryId/redeliver", func(c *fiber.Ctx) error {
+		return webhookService.RedeliverService(c)
+	})
+
+	webhooks.Get("/", func(c *fiber.Ctx) error {
+		return webhookService.GetWebhooksService(c)
+	})
+	webhooks.Post("/", func(c *fiber.Ctx) error {
+		return webhookService.CreateWebhookService(c)
+	})
+	webhooks.Get("/:id", func(c *fiber.Ctx) error {
+		return webhookService.GetWebhookService(c)
+	})
+	webhooks.Put("/:id", func(c *fiber.Ctx) error {
+		return webhookService.UpdateWebhookService(c)
+	})
+	webhooks.Delete("/:id", func(c *fiber.Ctx) error {
+		return webhookService.DeleteWebhookService(c)
+	})
+	webhooks.Get("/:id/deliveries", func(c *fiber.Ctx) error {
+		return webhookService.GetWebhookDeliveriesService(c)
+	})
+	webhooks.Post("/:id/test", func(c *fiber.Ctx) error {
+		return webhookService.TestWebhookService(c)
+	})
+}
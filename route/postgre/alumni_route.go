@@ -2,20 +2,22 @@ package route
 
 import (
 	"database/sql"
+	"go-fiber/config"
 	postgre "go-fiber/app/service/postgre"
 	middleware "go-fiber/middleware/postgre"
+	"go-fiber/ratelimit"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func AlumniRoutes(app *fiber.App, db *sql.DB) {
+func AlumniRoutes(app *fiber.App, db *sql.DB, auth config.AuthConfig, limits ratelimit.RouteLimits, idempotent fiber.Handler, opts postgre.Options, authRequired fiber.Handler) {
 	api := app.Group("/go-fiber-postgre")
 
-	api.Post("/login", func(c *fiber.Ctx) error {
-		return postgre.LoginService(c, db)
+	api.Post("/login", limits.Login, func(c *fiber.Ctx) error {
+		return postgre.LoginService(c, db, auth.JWTSecret)
 	})
 
-	protected := api.Group("", middleware.AuthRequired())
+	protected := api.Group("", authRequired)
 	
 	protected.Get("/profile", func(c *fiber.Ctx) error {
 		return postgre.GetProfileService(c, db)
@@ -28,17 +30,26 @@ func AlumniRoutes(app *fiber.App, db *sql.DB) {
 	alumni.Get("/:id", middleware.UserOrAdmin(), func(c *fiber.Ctx) error {
 		return postgre.GetAlumniByIDService(c, db)
 	})
-	alumni.Post("/", middleware.AdminOnly(), func(c *fiber.Ctx) error {
-		return postgre.CreateAlumniService(c, db)
+	alumni.Get("/:id/history", middleware.UserOrAdmin(), func(c *fiber.Ctx) error {
+		return postgre.GetAlumniHistoryService(c, db)
+	})
+	alumni.Post("/", middleware.AdminOnly(), idempotent, func(c *fiber.Ctx) error {
+		return postgre.CreateAlumniService(c, db, opts)
 	})
 	alumni.Put("/:id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
-		return postgre.UpdateAlumniService(c, db)
+		return postgre.UpdateAlumniService(c, db, opts)
+	})
+	alumni.Patch("/:id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return postgre.PatchAlumniService(c, db, opts)
 	})
 	alumni.Delete("/:id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
-		return postgre.DeleteAlumniService(c, db)
+		return postgre.DeleteAlumniService(c, db, opts)
+	})
+	alumni.Post("/:id/revert/:version", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return postgre.RevertAlumniService(c, db, opts)
 	})
-	alumni.Post("/check/:key", func(c *fiber.Ctx) error {
-		return postgre.CheckAlumniService(c, db)
+	alumni.Post("/check/:key", limits.Check, func(c *fiber.Ctx) error {
+		return postgre.CheckAlumniService(c, db, auth.APIKey)
 	})
 
 	roles := protected.Group("/roles")
@@ -49,12 +60,12 @@ func AlumniRoutes(app *fiber.App, db *sql.DB) {
 		return postgre.GetRoleByIDService(c, db)
 	})
 	roles.Post("/", middleware.AdminOnly(), func(c *fiber.Ctx) error {
-		return postgre.CreateRoleService(c, db)
+		return postgre.CreateRoleService(c, db, opts)
 	})
 	roles.Put("/:id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
-		return postgre.UpdateRoleService(c, db)
+		return postgre.UpdateRoleService(c, db, opts)
 	})
 	roles.Delete("/:id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
-		return postgre.DeleteRoleService(c, db)
+		return postgre.DeleteRoleService(c, db, opts)
 	})
 }
@@ -0,0 +1,31 @@
+package route
+
+import (
+	"database/sql"
+	postgre "go-fiber/app/service/postgre"
+	middleware "go-fiber/middleware/postgre"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func AnalyticsRoutes(app *fiber.App, db *sql.DB, authRequired fiber.Handler) {
+	api := app.Group("/go-fiber-postgre")
+	protected := api.Group("", authRequired)
+
+	analytics := protected.Group("/analytics", middleware.AdminOnly())
+	analytics.Get("/employment-rate", func(c *fiber.Ctx) error {
+		return postgre.GetEmploymentRateService(c, db)
+	})
+	analytics.Get("/time-to-employment", func(c *fiber.Ctx) error {
+		return postgre.GetTimeToEmploymentService(c, db)
+	})
+	analytics.Get("/distribution", func(c *fiber.Ctx) error {
+		return postgre.GetEmploymentDistributionService(c, db)
+	})
+	analytics.Get("/top-employers", func(c *fiber.Ctx) error {
+		return postgre.GetTopEmployersService(c, db)
+	})
+	analytics.Get("/salary", func(c *fiber.Ctx) error {
+		return postgre.GetSalaryStatisticsService(c, db)
+	})
+}
@@ -0,0 +1,19 @@
+package route
+
+import (
+	"database/sql"
+	postgre "go-fiber/app/service/postgre"
+	middleware "go-fiber/middleware/postgre"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func AuditRoutes(app *fiber.App, db *sql.DB, authRequired fiber.Handler) {
+	api := app.Group("/go-fiber-postgre")
+	protected := api.Group("", authRequired)
+
+	auditLogs := protected.Group("/audit-logs", middleware.AdminOnly())
+	auditLogs.Get("/", func(c *fiber.Ctx) error {
+		return postgre.GetAuditLogsService(c, db)
+	})
+}
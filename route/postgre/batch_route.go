@@ -0,0 +1,27 @@
+package route
+
+import (
+	"database/sql"
+	postgre "go-fiber/app/service/postgre"
+	middleware "go-fiber/middleware/postgre"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func BatchRoutes(app *fiber.App, db *sql.DB, opts postgre.Options, authRequired fiber.Handler) {
+	api := app.Group("/go-fiber-postgre")
+	protected := api.Group("", authRequired)
+
+	protected.Post("/alumni/batch/update", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return postgre.BatchUpdateAlumniService(c, db, opts)
+	})
+	protected.Post("/alumni/batch/delete", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return postgre.BatchDeleteAlumniService(c, db, opts)
+	})
+	protected.Post("/pekerjaan/batch/update", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return postgre.BatchUpdatePekerjaanAlumniService(c, db, opts)
+	})
+	protected.Post("/pekerjaan/batch/delete", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return postgre.BatchSoftDeletePekerjaanAlumniService(c, db, opts)
+	})
+}
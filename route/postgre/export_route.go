@@ -0,0 +1,31 @@
+package route
+
+import (
+	"database/sql"
+	postgre "go-fiber/app/service/postgre"
+	middleware "go-fiber/middleware/postgre"
+	"go-fiber/worker"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func ExportRoutes(app *fiber.App, db *sql.DB, exports *worker.Exporter, authRequired fiber.Handler) {
+	api := app.Group("/go-fiber-postgre")
+	protected := api.Group("", authRequired)
+
+	export := protected.Group("/export", middleware.AdminOnly())
+	export.Get("/alumni", func(c *fiber.Ctx) error {
+		return postgre.ExportAlumniService(c, db, exports)
+	})
+	export.Get("/pekerjaan", func(c *fiber.Ctx) error {
+		return postgre.ExportPekerjaanAlumniService(c, db, exports)
+	})
+	export.Get("/analytics/:report", func(c *fiber.Ctx) error {
+		return postgre.ExportAnalyticsService(c, exports)
+	})
+	export.Get("/report/tracer-study", func(c *fiber.Ctx) error {
+		return postgre.ExportTracerStudyReportService(c, exports)
+	})
+	export.Get("/jobs/:id", exports.JobStatusHandler)
+	export.Get("/jobs/:id/download", exports.DownloadHandler)
+}
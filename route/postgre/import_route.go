@@ -0,0 +1,21 @@
+package route
+
+import (
+	"database/sql"
+	postgre "go-fiber/app/service/postgre"
+	middleware "go-fiber/middleware/postgre"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func ImportRoutes(app *fiber.App, db *sql.DB, authRequired fiber.Handler) {
+	api := app.Group("/go-fiber-postgre")
+	protected := api.Group("", authRequired)
+
+	protected.Post("/alumni/import", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return postgre.ImportAlumniService(c, db)
+	})
+	protected.Post("/pekerjaan/import", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return postgre.ImportPekerjaanAlumniService(c, db)
+	})
+}
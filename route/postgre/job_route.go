@@ -0,0 +1,33 @@
+package route
+
+import (
+	postgre "go-fiber/app/service/postgre"
+	middleware "go-fiber/middleware/postgre"
+	"go-fiber/queue"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func JobRoutes(app *fiber.App, q *queue.Queue, authRequired fiber.Handler) {
+	api := app.Group("/go-fiber-postgre")
+	protected := api.Group("", authRequired)
+
+	jobs := protected.Group("/jobs", middleware.AdminOnly())
+
+	// /schedules didaftarkan sebelum /:id agar tidak dibaca sebagai ID job.
+	jobs.Get("/schedules", func(c *fiber.Ctx) error {
+		return postgre.GetJobSchedulesService(c, q)
+	})
+	jobs.Get("/", func(c *fiber.Ctx) error {
+		return postgre.GetJobsService(c, q)
+	})
+	jobs.Get("/:id", func(c *fiber.Ctx) error {
+		return postgre.GetJobService(c, q)
+	})
+	jobs.Post("/:id/retry", func(c *fiber.Ctx) error {
+		return postgre.RetryJobService(c, q)
+	})
+	jobs.Post("/:id/cancel", func(c *fiber.Ctx) error {
+		return postgre.CancelJobService(c, q)
+	})
+}
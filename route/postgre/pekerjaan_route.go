@@ -8,9 +8,9 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func PekerjaanRoutes(app *fiber.App, db *sql.DB) {
+func PekerjaanRoutes(app *fiber.App, db *sql.DB, idempotent fiber.Handler, opts postgre.Options, authRequired fiber.Handler) {
 	api := app.Group("/go-fiber-postgre")
-	protected := api.Group("", middleware.AuthRequired())
+	protected := api.Group("", authRequired)
 
 	pekerjaan := protected.Group("/pekerjaan")
 	pekerjaan.Get("/", middleware.UserOrAdmin(), func(c *fiber.Ctx) error {
@@ -22,22 +22,31 @@ func PekerjaanRoutes(app *fiber.App, db *sql.DB) {
 	pekerjaan.Get("/:id", middleware.UserOrAdmin(), func(c *fiber.Ctx) error {
 		return postgre.GetPekerjaanAlumniByIDService(c, db)
 	})
+	pekerjaan.Get("/:id/history", middleware.UserOrAdmin(), func(c *fiber.Ctx) error {
+		return postgre.GetPekerjaanAlumniHistoryService(c, db)
+	})
 	pekerjaan.Get("/alumni/:alumni_id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
 		return postgre.GetPekerjaanAlumniByAlumniIDService(c, db)
 	})
-	pekerjaan.Post("/", middleware.AdminOnly(), func(c *fiber.Ctx) error {
-		return postgre.CreatePekerjaanAlumniService(c, db)
+	pekerjaan.Post("/", middleware.AdminOnly(), idempotent, func(c *fiber.Ctx) error {
+		return postgre.CreatePekerjaanAlumniService(c, db, opts)
 	})
 	pekerjaan.Put("/:id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
-		return postgre.UpdatePekerjaanAlumniService(c, db)
+		return postgre.UpdatePekerjaanAlumniService(c, db, opts)
+	})
+	pekerjaan.Patch("/:id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return postgre.PatchPekerjaanAlumniService(c, db, opts)
+	})
+	pekerjaan.Post("/:id/revert/:version", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return postgre.RevertPekerjaanAlumniService(c, db, opts)
 	})
 	pekerjaan.Put("/soft-delete/:id", middleware.UserOrAdmin(), func(c *fiber.Ctx) error {
-		return postgre.SoftDeletePekerjaanAlumniService(c, db)
+		return postgre.SoftDeletePekerjaanAlumniService(c, db, opts)
 	})
 	pekerjaan.Put("/soft-delete-restore/:id", middleware.UserOrAdmin(), func(c *fiber.Ctx) error {
-		return postgre.RestorePekerjaanAlumniService(c, db)
+		return postgre.RestorePekerjaanAlumniService(c, db, opts)
 	})
 	pekerjaan.Delete("/:id", middleware.UserOrAdmin(), func(c *fiber.Ctx) error {
-		return postgre.HardDeletePekerjaanAlumniService(c, db)
+		return postgre.HardDeletePekerjaanAlumniService(c, db, opts)
 	})
 }
\ No newline at end of file
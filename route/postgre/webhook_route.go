@@ -0,0 +1,53 @@
+package route
+
+import (
+	"database/sql"
+	postgre "go-fiber/app/service/postgre"
+	middleware "go-fiber/middleware/postgre"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func WebhookRoutes(app *fiber.App, db *sql.DB, authRequired fiber.Handler) {
+	api := app.Group("/go-fiber-postgre")
+	protected := api.Group("", authRequired)
+
+	webhooks := protected.Group("/webhooks", middleware.AdminOnly())
+
+	// Route delivery didaftarkan sebelum /:id agar "deliveries" dan
+	// "dead-letters" tidak dibaca sebagai ID subscription.
+	webhooks.Get("/deliveries", func(c *fiber.Ctx) error {
+		return postgre.GetDeliveriesService(c, db)
+	})
+	webhooks.Get("/dead-letters", func(c *fiber.Ctx) error {
+		return postgre.GetDeadLettersService(c, db)
+	})
+	webhooks.Get("/deliveries/:deliveryId", func(c *fiber.Ctx) error {
+		return postgre.GetDeliveryService(c, db)
+	})
+	webhooks.Post("/deliveries/:deliveryId/redeliver", func(c *fiber.Ctx) error {
+		return postgre.RedeliverService(c, db)
+	})
+
+	webhooks.Get("/", func(c *fiber.Ctx) error {
+		return postgre.GetWebhooksService(c, db)
+	})
+	webhooks.Post("/", func(c *fiber.Ctx) error {
+		return postgre.CreateWebhookService(c, db)
+	})
+	webhooks.Get("/:id", func(c *fiber.Ctx) error {
+		return postgre.GetWebhookService(c, db)
+	})
+	webhooks.Put("/:id", func(c *fiber.Ctx) error {
+		return postgre.UpdateWebhookService(c, db)
+	})
+	webhooks.Delete("/:id", func(c *fiber.Ctx) error {
+		return postgre.DeleteWebhookService(c, db)
+	})
+	webhooks.Get("/:id/deliveries", func(c *fiber.Ctx) error {
+		return postgre.GetWebhookDeliveriesService(c, db)
+	})
+	webhooks.Post("/:id/test", func(c *fiber.Ctx) error {
+		return postgre.TestWebhookService(c, db)
+	})
+}
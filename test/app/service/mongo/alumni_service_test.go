@@ -2,21 +2,27 @@ package service_test
 
 import (
 	"context"
+	"encoding/json"
 	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
-	service "go-fiber/app/service/mongo"
 	model "go-fiber/app/model/mongo"
+	service "go-fiber/app/service/mongo"
+	"go-fiber/apperror"
+	"go-fiber/audit"
+	"go-fiber/outbox"
 
 	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 type mockAlumniRepo struct {
-	byID *model.Alumni
-	all  []model.Alumni
-	err  error
+	byID    *model.Alumni
+	all     []model.Alumni
+	changes bson.M
+	err     error
 }
 
 func (m *mockAlumniRepo) CreateAlumni(ctx context.Context, alumni *model.Alumni) (*model.Alumni, error) {
@@ -30,11 +36,84 @@ func (m *mockAlumniRepo) FindAlumniByEmail(ctx context.Context, email string) (*
 }
 func (m *mockAlumniRepo) FindAlumniByNIM(ctx context.Context, nim string) (*model.Alumni, error) { return nil, nil }
 func (m *mockAlumniRepo) FindAllAlumni(ctx context.Context) ([]model.Alumni, error)               { return m.all, m.err }
-func (m *mockAlumniRepo) UpdateAlumni(ctx context.Context, id string, alumni *model.Alumni) (*model.Alumni, error) {
+func (m *mockAlumniRepo) UpdateAlumni(ctx context.Context, id string, version int, alumni *model.Alumni) (*model.Alumni, error) {
+	alumni.Version = version + 1
 	alumni.UpdatedAt = time.Now()
 	return alumni, m.err
 }
-func (m *mockAlumniRepo) DeleteAlumni(ctx context.Context, id string) error { return m.err }
+func (m *mockAlumniRepo) PatchAlumni(ctx context.Context, id string, version int, changes bson.M) (*model.Alumni, error) {
+	m.changes = changes
+	patched := *m.byID
+	patched.Version = version + 1
+	return &patched, m.err
+}
+func (m *mockAlumniRepo) DeleteAlumni(ctx context.Context, id string, version int) error { return m.err }
+func (m *mockAlumniRepo) FindAlumniByIDWithRelations(ctx context.Context, id string, fields, include []string) (*model.AlumniDetail, error) {
+	if m.byID == nil {
+		return nil, m.err
+	}
+	return &model.AlumniDetail{Alumni: *m.byID}, m.err
+}
+func (m *mockAlumniRepo) FindAllAlumniWithRelations(ctx context.Context, fields, include []string) ([]model.AlumniDetail, error) {
+	var details []model.AlumniDetail
+	for _, a := range m.all {
+		details = append(details, model.AlumniDetail{Alumni: a})
+	}
+	return details, m.err
+}
+
+type mockAuditStore struct {
+	entries []audit.Entry
+	err     error
+}
+
+func (m *mockAuditStore) Append(ctx context.Context, entries ...audit.Entry) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.entries = append(m.entries, entries...)
+	return nil
+}
+func (m *mockAuditStore) Query(ctx context.Context, filter audit.Filter) ([]audit.Entry, int64, error) {
+	return m.entries, int64(len(m.entries)), m.err
+}
+func (m *mockAuditStore) History(ctx context.Context, resource, resourceID string) ([]audit.Entry, error) {
+	var entries []audit.Entry
+	for _, entry := range m.entries {
+		if entry.Resource == resource && entry.ResourceID == resourceID {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, m.err
+}
+
+// mockEvents menjalankan fn seperti transaksi dan menyimpan event-nya jika
+// commit berhasil. err mensimulasikan kegagalan menulis outbox. Entry yang
+// ditulis ke audit selama fn ikut dibatalkan jika transaksi gagal.
+type mockEvents struct {
+	events []outbox.Event
+	audit  *mockAuditStore
+	err    error
+}
+
+func (m *mockEvents) Transaction(ctx context.Context, fn func(ctx context.Context) ([]outbox.Event, error)) error {
+	written := 0
+	if m.audit != nil {
+		written = len(m.audit.entries)
+	}
+	events, err := fn(ctx)
+	if err == nil {
+		err = m.err
+	}
+	if err != nil {
+		if m.audit != nil {
+			m.audit.entries = m.audit.entries[:written]
+		}
+		return err
+	}
+	m.events = append(m.events, events...)
+	return nil
+}
 
 func TestGetAllAlumniService(t *testing.T) {
 	repo := &mockAlumniRepo{
@@ -43,8 +122,8 @@ func TestGetAllAlumniService(t *testing.T) {
 			{NIM: "2", Nama: "B", Jurusan: "SI", Angkatan: 2020, TahunLulus: 2024, Email: "b@b.com", Role: "admin", CreatedAt: time.Now(), UpdatedAt: time.Now()},
 		},
 	}
-	svc := service.NewAlumniService(repo)
-	app := fiber.New()
+	svc := service.NewAlumniService(repo, "test-api-key", false, &mockAuditStore{}, outbox.Discard)
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
 	app.Get("/", func(c *fiber.Ctx) error { return svc.GetAllAlumniService(c) })
 	req := httptest.NewRequest("GET", "/", nil)
 	resp, _ := app.Test(req)
@@ -55,8 +134,8 @@ func TestGetAllAlumniService(t *testing.T) {
 
 func TestUpdateAlumniServiceBadRole(t *testing.T) {
 	repo := &mockAlumniRepo{byID: &model.Alumni{}}
-	svc := service.NewAlumniService(repo)
-	app := fiber.New()
+	svc := service.NewAlumniService(repo, "test-api-key", false, &mockAuditStore{}, outbox.Discard)
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
 	app.Put("/:id", func(c *fiber.Ctx) error { return svc.UpdateAlumniService(c) })
 	body := `{"nama":"A","jurusan":"TI","angkatan":2021,"tahun_lulus":2025,"email":"a@a.com","role":"guest"}`
 	req := httptest.NewRequest("PUT", "/507f1f77bcf86cd799439011", strings.NewReader(body))
@@ -67,4 +146,352 @@ func TestUpdateAlumniServiceBadRole(t *testing.T) {
 	}
 }
 
+func TestUpdateAlumniServiceRecordsAudit(t *testing.T) {
+	repo := &mockAlumniRepo{byID: &model.Alumni{NIM: "1", Nama: "A", Jurusan: "TI", Angkatan: 2021, TahunLulus: 2025, Email: "lama@a.com", PasswordHash: "hash", Role: "user"}}
+	recorder := &mockAuditStore{}
+	svc := service.NewAlumniService(repo, "test-api-key", false, recorder, outbox.Discard)
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Put("/:id", func(c *fiber.Ctx) error {
+		c.Locals("alumni_id", "507f1f77bcf86cd799439099")
+		c.Locals("email", "admin@example.com")
+		c.Locals("role", "admin")
+		c.Locals("request_id", "req-1")
+		return svc.UpdateAlumniService(c)
+	})
+	body := `{"nama":"A","jurusan":"TI","angkatan":2021,"tahun_lulus":2025,"email":"baru@a.com","role":"user"}`
+	req := httptest.NewRequest("PUT", "/507f1f77bcf86cd799439011", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+	if resp.StatusCode != 200 {
+		t.Fatalf("status got %d want %d", resp.StatusCode, 200)
+	}
+
+	if len(recorder.entries) != 1 {
+		t.Fatalf("got %d audit entries want 1", len(recorder.entries))
+	}
+	entry := recorder.entries[0]
+	if entry.Action != audit.ActionUpdate || entry.Resource != audit.ResourceAlumni || entry.ResourceID != "507f1f77bcf86cd799439011" {
+		t.Fatalf("unexpected entry %+v", entry)
+	}
+	if entry.ActorID != "507f1f77bcf86cd799439099" || entry.ActorEmail != "admin@example.com" || entry.RequestID != "req-1" {
+		t.Fatalf("unexpected actor %+v", entry)
+	}
+	var emailChanged bool
+	for _, change := range entry.Changes {
+		if change.Field == "email" {
+			emailChanged = change.Before == "lama@a.com" && change.After == "baru@a.com"
+		}
+	}
+	if !emailChanged {
+		t.Fatalf("email change missing from %+v", entry.Changes)
+	}
+}
+
+func TestCreateAlumniServiceWritesEvent(t *testing.T) {
+	recorder := &mockAuditStore{}
+	events := &mockEvents{}
+	svc := service.NewAlumniService(&mockAlumniRepo{}, "test-api-key", false, recorder, events)
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Post("/", func(c *fiber.Ctx) error {
+		c.Locals("request_id", "req-1")
+		return svc.CreateAlumniService(c)
+	})
+	body := `{"nim":"1","nama":"A","jurusan":"TI","angkatan":2021,"tahun_lulus":2025,"email":"a@a.com","password":"rahasia123","role":"user"}`
+	req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+	if resp.StatusCode != 201 {
+		t.Fatalf("status got %d want %d", resp.StatusCode, 201)
+	}
+
+	if len(events.events) != 1 {
+		t.Fatalf("got %d events want 1", len(events.events))
+	}
+	event := events.events[0]
+	if event.Type != "alumni.created" || event.RequestID != "req-1" || event.Data["nim"] != "1" {
+		t.Fatalf("unexpected event %+v", event)
+	}
+	if _, ok := event.Data["password_hash"]; ok {
+		t.Fatalf("event leaks password hash: %+v", event.Data)
+	}
+	if len(recorder.entries) != 1 {
+		t.Fatalf("got %d audit entries want 1", len(recorder.entries))
+	}
+}
+
+func TestCreateAlumniServiceStoresNormalizedPhone(t *testing.T) {
+	svc := service.NewAlumniService(&mockAlumniRepo{}, "test-api-key", false, &mockAuditStore{}, outbox.Discard)
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Post("/", func(c *fiber.Ctx) error { return svc.CreateAlumniService(c) })
+	body := `{"nim":"1","nama":"A","jurusan":"TI","angkatan":2021,"tahun_lulus":2025,"email":"a@a.com","password":"rahasia123","no_telepon":"+62 812-3456-7890","role":"user"}`
+	req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+	if resp.StatusCode != 201 {
+		t.Fatalf("status got %d want %d", resp.StatusCode, 201)
+	}
+
+	var response struct {
+		Data model.Alumni `json:"data"`
+	}
+	json.NewDecoder(resp.Body).Decode(&response)
+	if response.Data.NoTelepon == nil || *response.Data.NoTelepon != "+6281234567890" {
+		t.Fatalf("no_telepon got %v want +6281234567890", response.Data.NoTelepon)
+	}
+}
+
+func TestUpdateAlumniServiceEventFailureFailsRequest(t *testing.T) {
+	repo := &mockAlumniRepo{byID: &model.Alumni{NIM: "1", Nama: "A", Role: "user"}}
+	recorder := &mockAuditStore{}
+	svc := service.NewAlumniService(repo, "test-api-key", false, recorder, &mockEvents{audit: recorder, err: context.DeadlineExceeded})
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Put("/:id", func(c *fiber.Ctx) error { return svc.UpdateAlumniService(c) })
+	body := `{"nama":"A","jurusan":"TI","angkatan":2021,"tahun_lulus":2025,"email":"a@a.com","role":"user"}`
+	req := httptest.NewRequest("PUT", "/507f1f77bcf86cd799439011", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+	if resp.StatusCode != 500 {
+		t.Fatalf("status got %d want %d", resp.StatusCode, 500)
+	}
+	if len(recorder.entries) != 0 {
+		t.Fatalf("rolled back change must not be audited: %+v", recorder.entries)
+	}
+}
+
+func TestDeleteAlumniServiceAuditFailureFailsRequest(t *testing.T) {
+	repo := &mockAlumniRepo{byID: &model.Alumni{NIM: "1", Nama: "A"}}
+	recorder := &mockAuditStore{err: context.DeadlineExceeded}
+	events := &mockEvents{audit: recorder}
+	svc := service.NewAlumniService(repo, "test-api-key", false, recorder, events)
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Delete("/:id", func(c *fiber.Ctx) error { return svc.DeleteAlumniService(c) })
+	req := httptest.NewRequest("DELETE", "/507f1f77bcf86cd799439011", nil)
+	resp, _ := app.Test(req)
+	if resp.StatusCode != 500 {
+		t.Fatalf("status got %d want %d", resp.StatusCode, 500)
+	}
+	if len(events.events) != 0 {
+		t.Fatalf("rolled back delete must not publish events: %+v", events.events)
+	}
+}
+
+func TestGetAlumniByIDServiceFieldsAndInclude(t *testing.T) {
+	repo := &mockAlumniRepo{byID: &model.Alumni{NIM: "1", Nama: "A", Email: "a@a.com", Role: "user"}}
+	svc := service.NewAlumniService(repo, "test-api-key", false, &mockAuditStore{}, outbox.Discard)
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Get("/:id", func(c *fiber.Ctx) error { return svc.GetAlumniByIDService(c) })
+	req := httptest.NewRequest("GET", "/507f1f77bcf86cd799439011?fields=nim,nama&include=pekerjaan", nil)
+	resp, _ := app.Test(req)
+	if resp.StatusCode != 200 {
+		t.Fatalf("status got %d want %d", resp.StatusCode, 200)
+	}
+	var body struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode error %v", err)
+	}
+	for _, key := range []string{"nim", "nama", "pekerjaan"} {
+		if _, ok := body.Data[key]; !ok {
+			t.Fatalf("key %s missing from %v", key, body.Data)
+		}
+	}
+	if _, ok := body.Data["email"]; ok {
+		t.Fatalf("email should not be projected: %v", body.Data)
+	}
+}
+
+func TestGetAlumniByIDServiceInvalidInclude(t *testing.T) {
+	repo := &mockAlumniRepo{byID: &model.Alumni{}}
+	svc := service.NewAlumniService(repo, "test-api-key", false, &mockAuditStore{}, outbox.Discard)
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Get("/:id", func(c *fiber.Ctx) error { return svc.GetAlumniByIDService(c) })
+	req := httptest.NewRequest("GET", "/507f1f77bcf86cd799439011?include=gaji", nil)
+	resp, _ := app.Test(req)
+	if resp.StatusCode != 400 {
+		t.Fatalf("status got %d want %d", resp.StatusCode, 400)
+	}
+}
+
+func alumniHistory() *mockAuditStore {
+	id := "507f1f77bcf86cd799439011"
+	created := audit.Entry{Action: audit.ActionCreate, Resource: audit.ResourceAlumni, ResourceID: id,
+		After:     map[string]interface{}{"nim": "1", "nama": "A", "jurusan": "TI", "angkatan": 2021, "tahun_lulus": 2025, "email": "lama@a.com", "alamat": "Surabaya", "role": "user"},
+		CreatedAt: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)}
+	updated := audit.Entry{Action: audit.ActionUpdate, Resource: audit.ResourceAlumni, ResourceID: id,
+		Before:    created.After,
+		After:     map[string]interface{}{"nim": "1", "nama": "A", "jurusan": "TI", "angkatan": 2021, "tahun_lulus": 2025, "email": "baru@a.com", "alamat": "Malang", "role": "user"},
+		CreatedAt: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)}
+	return &mockAuditStore{entries: []audit.Entry{created, updated}}
+}
+
+func TestGetAlumniByIDServiceAsOf(t *testing.T) {
+	svc := service.NewAlumniService(&mockAlumniRepo{}, "test-api-key", false, alumniHistory(), outbox.Discard)
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Get("/:id", func(c *fiber.Ctx) error { return svc.GetAlumniByIDService(c) })
+
+	req := httptest.NewRequest("GET", "/507f1f77bcf86cd799439011?as_of=2024-06-01&fields=alamat,email", nil)
+	resp, _ := app.Test(req)
+	if resp.StatusCode != 200 {
+		t.Fatalf("status got %d want %d", resp.StatusCode, 200)
+	}
+	var body struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode error %v", err)
+	}
+	if body.Data["alamat"] != "Surabaya" || body.Data["email"] != "lama@a.com" || len(body.Data) != 2 {
+		t.Fatalf("unexpected state %v", body.Data)
+	}
 
+	req = httptest.NewRequest("GET", "/507f1f77bcf86cd799439011?as_of=2023-12-31", nil)
+	resp, _ = app.Test(req)
+	if resp.StatusCode != 404 {
+		t.Fatalf("status before creation got %d want %d", resp.StatusCode, 404)
+	}
+}
+
+func TestRevertAlumniService(t *testing.T) {
+	store := alumniHistory()
+	repo := &mockAlumniRepo{byID: &model.Alumni{NIM: "1", Nama: "A", Jurusan: "TI", Angkatan: 2021, TahunLulus: 2025, Email: "baru@a.com", Role: "user"}}
+	svc := service.NewAlumniService(repo, "test-api-key", false, store, outbox.Discard)
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Post("/:id/revert/:version", func(c *fiber.Ctx) error { return svc.RevertAlumniService(c) })
+
+	resp, _ := app.Test(httptest.NewRequest("POST", "/507f1f77bcf86cd799439011/revert/1", nil))
+	if resp.StatusCode != 200 {
+		t.Fatalf("status got %d want %d", resp.StatusCode, 200)
+	}
+	if len(store.entries) != 3 {
+		t.Fatalf("got %d audit entries want 3", len(store.entries))
+	}
+	entry := store.entries[2]
+	if entry.Action != audit.ActionRevert || entry.After["email"] != "lama@a.com" || entry.After["alamat"] != "Surabaya" {
+		t.Fatalf("unexpected revert entry %+v", entry)
+	}
+
+	resp, _ = app.Test(httptest.NewRequest("POST", "/507f1f77bcf86cd799439011/revert/9", nil))
+	if resp.StatusCode != 404 {
+		t.Fatalf("unknown version status got %d want %d", resp.StatusCode, 404)
+	}
+}
+
+func TestUpdateAlumniServiceIfMatch(t *testing.T) {
+	repo := &mockAlumniRepo{byID: &model.Alumni{NIM: "1", Nama: "A", Role: "user", Version: 3}}
+	body := `{"nama":"A","jurusan":"TI","angkatan":2021,"tahun_lulus":2025,"email":"a@a.com","role":"user"}`
+
+	tests := []struct {
+		name     string
+		required bool
+		ifMatch  string
+		want     int
+	}{
+		{"stale version", false, `"2"`, 412},
+		{"current version", false, `"3"`, 200},
+		{"weak current version", false, `W/"3"`, 200},
+		{"missing header optional", false, "", 200},
+		{"missing header required", true, "", 428},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := service.NewAlumniService(repo, "test-api-key", tt.required, &mockAuditStore{}, outbox.Discard)
+			app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+			app.Put("/:id", func(c *fiber.Ctx) error { return svc.UpdateAlumniService(c) })
+
+			req := httptest.NewRequest("PUT", "/507f1f77bcf86cd799439011", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			resp, _ := app.Test(req)
+			if resp.StatusCode != tt.want {
+				t.Fatalf("status got %d want %d", resp.StatusCode, tt.want)
+			}
+			if tt.want == 200 && resp.Header.Get("ETag") != `"4"` {
+				t.Fatalf("ETag got %q want %q", resp.Header.Get("ETag"), `"4"`)
+			}
+		})
+	}
+}
+
+func TestGetAlumniByIDServiceNotModified(t *testing.T) {
+	repo := &mockAlumniRepo{byID: &model.Alumni{NIM: "1", Nama: "A", Version: 2}}
+	svc := service.NewAlumniService(repo, "test-api-key", false, &mockAuditStore{}, outbox.Discard)
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Get("/:id", func(c *fiber.Ctx) error { return svc.GetAlumniByIDService(c) })
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/507f1f77bcf86cd799439011", nil))
+	if resp.StatusCode != 200 || resp.Header.Get("ETag") != `"2"` {
+		t.Fatalf("got status %d ETag %q", resp.StatusCode, resp.Header.Get("ETag"))
+	}
+
+	req := httptest.NewRequest("GET", "/507f1f77bcf86cd799439011", nil)
+	req.Header.Set("If-None-Match", `"2"`)
+	resp, _ = app.Test(req)
+	if resp.StatusCode != 304 {
+		t.Fatalf("status got %d want %d", resp.StatusCode, 304)
+	}
+
+	req = httptest.NewRequest("GET", "/507f1f77bcf86cd799439011", nil)
+	req.Header.Set("If-None-Match", `"1"`)
+	resp, _ = app.Test(req)
+	if resp.StatusCode != 200 {
+		t.Fatalf("stale ETag status got %d want %d", resp.StatusCode, 200)
+	}
+}
+
+func TestPatchAlumniService(t *testing.T) {
+	alamat := "Jl. Lama"
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        int
+		wantCode    string
+		wantChanges bson.M
+	}{
+		{"merge patch", "application/merge-patch+json", `{"nama":"B"}`, 200, "", bson.M{"nama": "B"}},
+		{"merge patch null unsets", "application/merge-patch+json", `{"alamat":null}`, 200, "", bson.M{"alamat": nil}},
+		{"plain json as merge patch", "application/json", `{"angkatan":2020}`, 200, "", bson.M{"angkatan": 2020}},
+		{"empty merge patch", "application/merge-patch+json", `{}`, 200, "", nil},
+		{"json patch replace", "application/json-patch+json", `[{"op":"test","path":"/nama","value":"A"},{"op":"replace","path":"/email","value":"b@b.com"}]`, 200, "", bson.M{"email": "b@b.com"}},
+		{"json patch test failed", "application/json-patch+json", `[{"op":"test","path":"/nama","value":"X"},{"op":"replace","path":"/nama","value":"B"}]`, 422, "patch_test_failed", nil},
+		{"touched field validated", "application/merge-patch+json", `{"email":"bukan-email"}`, 400, "validation_failed", nil},
+		{"cross field validated", "application/merge-patch+json", `{"angkatan":2030}`, 400, "validation_failed", nil},
+		{"unknown field", "application/merge-patch+json", `{"nim":"123"}`, 400, "unknown_field", nil},
+		{"invalid merge patch", "application/merge-patch+json", `[1]`, 400, "invalid_patch", nil},
+		{"unsupported media type", "text/plain", `nama=B`, 415, "unsupported_media_type", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockAlumniRepo{byID: &model.Alumni{NIM: "1", Nama: "A", Email: "a@a.com", Angkatan: 2021, TahunLulus: 2025, Alamat: &alamat, Role: "user", Version: 3}}
+			svc := service.NewAlumniService(repo, "test-api-key", false, &mockAuditStore{}, outbox.Discard)
+			app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+			app.Patch("/:id", func(c *fiber.Ctx) error { return svc.PatchAlumniService(c) })
+
+			req := httptest.NewRequest("PATCH", "/507f1f77bcf86cd799439011", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			resp, _ := app.Test(req)
+			if resp.StatusCode != tt.want {
+				t.Fatalf("status got %d want %d", resp.StatusCode, tt.want)
+			}
+			if tt.wantCode != "" {
+				var body map[string]interface{}
+				json.NewDecoder(resp.Body).Decode(&body)
+				if body["code"] != tt.wantCode {
+					t.Fatalf("code got %v want %s", body["code"], tt.wantCode)
+				}
+				return
+			}
+			if len(repo.changes) != len(tt.wantChanges) {
+				t.Fatalf("changes got %v want %v", repo.changes, tt.wantChanges)
+			}
+			for field, want := range tt.wantChanges {
+				if got, ok := repo.changes[field]; !ok || got != want {
+					t.Fatalf("changes[%s] got %v want %v", field, got, want)
+				}
+			}
+		})
+	}
+}
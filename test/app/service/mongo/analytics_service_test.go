@@ -0,0 +1,90 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	testing "testing"
+
+	model "go-fiber/app/model/mongo"
+	service "go-fiber/app/service/mongo"
+	"go-fiber/apperror"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type mockAnalyticsRepo struct {
+	rates  []model.EmploymentRate
+	times  []model.TimeToEmployment
+	salary []model.SalaryStatistic
+	err    error
+}
+
+func (m *mockAnalyticsRepo) GetEmploymentRate(ctx context.Context, f model.AnalyticsFilter) ([]model.EmploymentRate, error) {
+	return m.rates, m.err
+}
+func (m *mockAnalyticsRepo) GetTimeToEmployment(ctx context.Context, f model.AnalyticsFilter) ([]model.TimeToEmployment, error) {
+	return m.times, m.err
+}
+func (m *mockAnalyticsRepo) GetEmploymentDistribution(ctx context.Context, f model.AnalyticsFilter, dimension, status string) ([]model.DistributionItem, error) {
+	return nil, m.err
+}
+func (m *mockAnalyticsRepo) GetTopEmployers(ctx context.Context, f model.AnalyticsFilter, limit int) ([]model.TopEmployer, error) {
+	return nil, m.err
+}
+
+func (m *mockAnalyticsRepo) GetSalaryStatistics(ctx context.Context, f model.AnalyticsFilter, currency string) ([]model.SalaryStatistic, error) {
+	return m.salary, m.err
+}
+
+func TestGetEmploymentRateServiceInvalidGroupBy(t *testing.T) {
+	svc := service.NewAnalyticsService(&mockAnalyticsRepo{})
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Get("/", func(c *fiber.Ctx) error { return svc.GetEmploymentRateService(c) })
+	req := httptest.NewRequest("GET", "/?group_by=nama", nil)
+	resp, _ := app.Test(req)
+	if resp.StatusCode != 400 {
+		t.Fatalf("status got %d want %d", resp.StatusCode, 400)
+	}
+}
+
+func TestGetTimeToEmploymentServiceMedian(t *testing.T) {
+	repo := &mockAnalyticsRepo{times: []model.TimeToEmployment{{Group: "2025", Days: []float64{90, 30, 60}}}}
+	svc := service.NewAnalyticsService(repo)
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Get("/", func(c *fiber.Ctx) error { return svc.GetTimeToEmploymentService(c) })
+	req := httptest.NewRequest("GET", "/?group_by=tahun_lulus", nil)
+	resp, _ := app.Test(req)
+	if resp.StatusCode != 200 {
+		t.Fatalf("status got %d want %d", resp.StatusCode, 200)
+	}
+	var body struct {
+		Data []model.TimeToEmployment `json:"data"`
+	}
+	json.NewDecoder(resp.Body).Decode(&body)
+	if len(body.Data) != 1 || body.Data[0].MedianDays != 60 || body.Data[0].MedianMonths != 2 || body.Data[0].TotalAlumni != 3 {
+		t.Fatalf("unexpected data %+v", body.Data)
+	}
+}
+
+func TestGetSalaryStatisticsServiceSuppressesSmallGroups(t *testing.T) {
+	repo := &mockAnalyticsRepo{salary: []model.SalaryStatistic{
+		{Group: "Teknologi", TotalSampel: 5, Values: []float64{5e6, 6e6, 7e6, 8e6, 9e6}},
+		{Group: "Keuangan", TotalSampel: 2, Values: []float64{10e6, 12e6}},
+	}}
+	svc := service.NewAnalyticsService(repo)
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Get("/", func(c *fiber.Ctx) error { return svc.GetSalaryStatisticsService(c) })
+	req := httptest.NewRequest("GET", "/?group_by=bidang_industri&min_group_size=1", nil)
+	resp, _ := app.Test(req)
+	if resp.StatusCode != 200 {
+		t.Fatalf("status got %d want %d", resp.StatusCode, 200)
+	}
+	var body struct {
+		Data []model.SalaryStatistic `json:"data"`
+	}
+	json.NewDecoder(resp.Body).Decode(&body)
+	if len(body.Data) != 1 || body.Data[0].Group != "Teknologi" || body.Data[0].Median != 7e6 {
+		t.Fatalf("unexpected data %+v", body.Data)
+	}
+}
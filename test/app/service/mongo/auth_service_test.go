@@ -5,15 +5,16 @@ import (
 	"context"
 	"encoding/json"
 	"net/http/httptest"
-	"os"
 	"testing"
 	"time"
 
-	service "go-fiber/app/service/mongo"
 	model "go-fiber/app/model/mongo"
+	service "go-fiber/app/service/mongo"
+	"go-fiber/apperror"
 	utilsmongo "go-fiber/utils/mongo"
 
 	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 type mockAlumniRepoAuth struct {
@@ -26,13 +27,21 @@ func (m *mockAlumniRepoAuth) FindAlumniByID(ctx context.Context, id string) (*mo
 func (m *mockAlumniRepoAuth) FindAlumniByEmail(ctx context.Context, email string) (*model.Alumni, error)    { return m.alumni, m.err }
 func (m *mockAlumniRepoAuth) FindAlumniByNIM(ctx context.Context, nim string) (*model.Alumni, error)        { return nil, nil }
 func (m *mockAlumniRepoAuth) FindAllAlumni(ctx context.Context) ([]model.Alumni, error)                     { return nil, nil }
-func (m *mockAlumniRepoAuth) UpdateAlumni(ctx context.Context, id string, alumni *model.Alumni) (*model.Alumni, error) {
+func (m *mockAlumniRepoAuth) UpdateAlumni(ctx context.Context, id string, version int, alumni *model.Alumni) (*model.Alumni, error) {
+	return nil, nil
+}
+func (m *mockAlumniRepoAuth) PatchAlumni(ctx context.Context, id string, version int, changes bson.M) (*model.Alumni, error) {
+	return nil, nil
+}
+func (m *mockAlumniRepoAuth) DeleteAlumni(ctx context.Context, id string, version int) error { return nil }
+func (m *mockAlumniRepoAuth) FindAlumniByIDWithRelations(ctx context.Context, id string, fields, include []string) (*model.AlumniDetail, error) {
+	return nil, nil
+}
+func (m *mockAlumniRepoAuth) FindAllAlumniWithRelations(ctx context.Context, fields, include []string) ([]model.AlumniDetail, error) {
 	return nil, nil
 }
-func (m *mockAlumniRepoAuth) DeleteAlumni(ctx context.Context, id string) error { return nil }
 
 func TestLoginServiceSuccess(t *testing.T) {
-	os.Setenv("JWT_SECRET", "secret-for-test-32-chars-minimum-123")
 	hash, _ := utilsmongo.HashPassword("secret123")
 	repo := &mockAlumniRepoAuth{
 		alumni: &model.Alumni{
@@ -48,8 +57,8 @@ func TestLoginServiceSuccess(t *testing.T) {
 			UpdatedAt:    time.Now(),
 		},
 	}
-	svc := service.NewAuthService(repo)
-	app := fiber.New()
+	svc := service.NewAuthService(repo, "secret-for-test-32-chars-minimum-123")
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
 	app.Post("/login", func(c *fiber.Ctx) error { return svc.LoginService(c) })
 	body, _ := json.Marshal(map[string]string{"email": "a@a.com", "password": "secret123"})
 	req := httptest.NewRequest("POST", "/login", bytes.NewReader(body))
@@ -65,8 +74,8 @@ func TestLoginServiceWrongPassword(t *testing.T) {
 	repo := &mockAlumniRepoAuth{
 		alumni: &model.Alumni{Email: "a@a.com", PasswordHash: hash, Role: "user", CreatedAt: time.Now(), UpdatedAt: time.Now()},
 	}
-	svc := service.NewAuthService(repo)
-	app := fiber.New()
+	svc := service.NewAuthService(repo, "secret-for-test-32-chars-minimum-123")
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
 	app.Post("/login", func(c *fiber.Ctx) error { return svc.LoginService(c) })
 	body, _ := json.Marshal(map[string]string{"email": "a@a.com", "password": "wrong"})
 	req := httptest.NewRequest("POST", "/login", bytes.NewReader(body))
@@ -0,0 +1,208 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	model "go-fiber/app/model/mongo"
+	service "go-fiber/app/service/mongo"
+	"go-fiber/apperror"
+	"go-fiber/helper"
+	"go-fiber/outbox"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type mockBatchRepo struct {
+	ids          []string
+	filter       model.PekerjaanBatchFilter
+	transactions int
+}
+
+func (m *mockBatchRepo) FindAlumniIDs(ctx context.Context, filter model.AlumniBatchFilter, limit int) ([]string, error) {
+	return m.ids, nil
+}
+func (m *mockBatchRepo) FindPekerjaanAlumniIDs(ctx context.Context, filter model.PekerjaanBatchFilter, limit int) ([]string, error) {
+	m.filter = filter
+	return m.ids, nil
+}
+func (m *mockBatchRepo) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.transactions++
+	return fn(ctx)
+}
+
+// batchPekerjaanRepo menyimpan pekerjaan per id dan mencatat id yang diubah
+// atau dihapus.
+type batchPekerjaanRepo struct {
+	mockPekerjaanRepo
+	byIDs   map[string]*model.PekerjaanAlumni
+	written []string
+	failID  string
+}
+
+func (m *batchPekerjaanRepo) FindPekerjaanAlumniByID(ctx context.Context, id string) (*model.PekerjaanAlumni, error) {
+	return m.byIDs[id], nil
+}
+func (m *batchPekerjaanRepo) PatchPekerjaanAlumni(ctx context.Context, id string, version int, changes bson.M) (*model.PekerjaanAlumni, error) {
+	if id == m.failID {
+		return nil, apperror.VersionConflict()
+	}
+	m.written = append(m.written, id)
+	patched := *m.byIDs[id]
+	if status, ok := changes["status_pekerjaan"].(string); ok {
+		patched.StatusPekerjaan = status
+	}
+	patched.Version = version + 1
+	return &patched, nil
+}
+func (m *batchPekerjaanRepo) DeletePekerjaanAlumni(ctx context.Context, id string, version int) error {
+	m.written = append(m.written, id)
+	return nil
+}
+
+const (
+	batchID1 = "507f1f77bcf86cd799439011"
+	batchID2 = "507f1f77bcf86cd799439012"
+	batchID3 = "507f1f77bcf86cd799439013"
+)
+
+func newBatchPekerjaanRepo() *batchPekerjaanRepo {
+	mulai := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	return &batchPekerjaanRepo{byIDs: map[string]*model.PekerjaanAlumni{
+		batchID1: {NamaPerusahaan: "PT Tutup", PosisiJabatan: "Dev", BidangIndustri: "IT", LokasiKerja: "Bandung", TanggalMulaiKerja: mulai, StatusPekerjaan: "aktif", Version: 2},
+		batchID2: {NamaPerusahaan: "PT Tutup", PosisiJabatan: "QA", BidangIndustri: "IT", LokasiKerja: "Bandung", TanggalMulaiKerja: mulai, StatusPekerjaan: "selesai", Version: 5},
+		batchID3: {NamaPerusahaan: "PT Tutup", PosisiJabatan: "PM", BidangIndustri: "IT", LokasiKerja: "Bandung", TanggalMulaiKerja: mulai, StatusPekerjaan: "aktif", Version: 1},
+	}}
+}
+
+func sendBatch(t *testing.T, handler fiber.Handler, body string) (int, map[string]interface{}) {
+	t.Helper()
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Post("/", handler)
+	req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request error %v", err)
+	}
+	var decoded map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&decoded)
+	return resp.StatusCode, decoded
+}
+
+func batchStatuses(t *testing.T, result interface{}) []string {
+	t.Helper()
+	raw, _ := json.Marshal(result)
+	var decoded helper.BatchResult
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("decode batch result: %v", err)
+	}
+	statuses := make([]string, len(decoded.Items))
+	for i, item := range decoded.Items {
+		statuses[i] = item.Status
+	}
+	return statuses
+}
+
+func TestBatchUpdatePekerjaanAlumniService(t *testing.T) {
+	repo := newBatchPekerjaanRepo()
+	batchRepo := &mockBatchRepo{ids: []string{batchID1, batchID2, batchID3}}
+	auditStore := &mockAuditStore{}
+	svc := service.NewBatchService(batchRepo, &mockAlumniRepo{}, repo, auditStore, outbox.Discard)
+
+	status, body := sendBatch(t, svc.BatchUpdatePekerjaanAlumniService,
+		`{"filter":{"nama_perusahaan":"pt tutup"},"changes":{"status_pekerjaan":"selesai"}}`)
+	if status != 200 {
+		t.Fatalf("status got %d want 200, body %v", status, body)
+	}
+	if batchRepo.filter.NamaPerusahaan != "pt tutup" || batchRepo.transactions != 1 {
+		t.Fatalf("unexpected filter %+v or transactions %d", batchRepo.filter, batchRepo.transactions)
+	}
+	got := strings.Join(batchStatuses(t, body["data"]), ",")
+	if got != "updated,unchanged,updated" {
+		t.Fatalf("statuses got %s", got)
+	}
+	if strings.Join(repo.written, ",") != batchID1+","+batchID3 {
+		t.Fatalf("expected unchanged pekerjaan not to be written, got %v", repo.written)
+	}
+	if len(auditStore.entries) != 2 {
+		t.Fatalf("expected 2 audit entries, got %d", len(auditStore.entries))
+	}
+}
+
+func TestBatchUpdatePekerjaanAlumniServiceRejectsBatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		failID     string
+		want       int
+		wantCode   string
+		wantStatus string
+	}{
+		{"missing pekerjaan", `{"ids":["` + batchID1 + `","507f1f77bcf86cd799439099"],"changes":{"status_pekerjaan":"selesai"}}`, "", 422, "batch_failed", "skipped,failed"},
+		{"invalid item", `{"ids":["` + batchID1 + `"],"changes":{"tanggal_selesai_kerja":"2000-01-01"}}`, "", 422, "batch_failed", "failed"},
+		{"write conflict", `{"ids":["` + batchID1 + `","` + batchID3 + `"],"changes":{"status_pekerjaan":"selesai"}}`, batchID3, 422, "batch_failed", "skipped,failed"},
+		{"ids and filter", `{"ids":["` + batchID1 + `"],"filter":{"bidang_industri":"IT"},"changes":{"status_pekerjaan":"selesai"}}`, "", 400, "batch_selection_required", ""},
+		{"empty changes", `{"ids":["` + batchID1 + `"],"changes":{}}`, "", 400, "batch_changes_required", ""},
+		{"unknown field", `{"ids":["` + batchID1 + `"],"changes":{"alumni_info":{}}}`, "", 400, "unknown_field", ""},
+		{"invalid filter", `{"filter":{"status_pekerjaan":"pensiun"},"changes":{"status_pekerjaan":"selesai"}}`, "", 400, "validation_failed", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newBatchPekerjaanRepo()
+			repo.failID = tt.failID
+			auditStore := &mockAuditStore{}
+			svc := service.NewBatchService(&mockBatchRepo{}, &mockAlumniRepo{}, repo, auditStore, outbox.Discard)
+
+			status, body := sendBatch(t, svc.BatchUpdatePekerjaanAlumniService, tt.body)
+			if status != tt.want || body["code"] != tt.wantCode {
+				t.Fatalf("got %d %v want %d %s", status, body["code"], tt.want, tt.wantCode)
+			}
+			if tt.wantStatus != "" {
+				if got := strings.Join(batchStatuses(t, body["details"]), ","); got != tt.wantStatus {
+					t.Fatalf("statuses got %s want %s", got, tt.wantStatus)
+				}
+			}
+			if len(auditStore.entries) != 0 {
+				t.Fatalf("expected no audit entries for rejected batch, got %d", len(auditStore.entries))
+			}
+		})
+	}
+}
+
+func TestBatchDeletePekerjaanAlumniService(t *testing.T) {
+	repo := newBatchPekerjaanRepo()
+	auditStore := &mockAuditStore{}
+	svc := service.NewBatchService(&mockBatchRepo{}, &mockAlumniRepo{}, repo, auditStore, outbox.Discard)
+
+	status, body := sendBatch(t, svc.BatchDeletePekerjaanAlumniService, `{"ids":["`+batchID2+`","`+batchID1+`","`+batchID2+`"]}`)
+	if status != 200 {
+		t.Fatalf("status got %d want 200, body %v", status, body)
+	}
+	if got := strings.Join(batchStatuses(t, body["data"]), ","); got != "deleted,deleted" {
+		t.Fatalf("expected duplicate id to be processed once, got %s", got)
+	}
+	if strings.Join(repo.written, ",") != batchID2+","+batchID1 || len(auditStore.entries) != 2 {
+		t.Fatalf("unexpected deletes %v or audit entries %d", repo.written, len(auditStore.entries))
+	}
+}
+
+func TestBatchDeletePekerjaanAlumniServiceAuditFailureFailsBatch(t *testing.T) {
+	repo := newBatchPekerjaanRepo()
+	auditStore := &mockAuditStore{err: context.DeadlineExceeded}
+	events := &mockEvents{}
+	svc := service.NewBatchService(&mockBatchRepo{}, &mockAlumniRepo{}, repo, auditStore, events)
+
+	status, body := sendBatch(t, svc.BatchDeletePekerjaanAlumniService, `{"ids":["`+batchID1+`"]}`)
+	if status != 500 {
+		t.Fatalf("status got %d want 500, body %v", status, body)
+	}
+	if len(events.events) != 0 {
+		t.Fatalf("failed batch must not publish events: %+v", events.events)
+	}
+}
@@ -0,0 +1,143 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http/httptest"
+	testing "testing"
+
+	model "go-fiber/app/model/mongo"
+	service "go-fiber/app/service/mongo"
+	"go-fiber/apperror"
+	"go-fiber/outbox"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type mockImportRepo struct {
+	existingEmails    map[string]bool
+	alumniByNIM       map[string]model.Alumni
+	inserted          []model.Alumni
+	insertedPekerjaan []model.PekerjaanAlumni
+}
+
+func (m *mockImportRepo) FindExistingAlumniKeys(ctx context.Context, nims, emails []string) (map[string]bool, map[string]bool, error) {
+	return map[string]bool{}, m.existingEmails, nil
+}
+func (m *mockImportRepo) FindAlumniByNIMs(ctx context.Context, nims []string) (map[string]model.Alumni, error) {
+	if m.alumniByNIM == nil {
+		return map[string]model.Alumni{}, nil
+	}
+	return m.alumniByNIM, nil
+}
+func (m *mockImportRepo) InsertAlumni(ctx context.Context, alumni []model.Alumni) error {
+	m.inserted = alumni
+	return nil
+}
+func (m *mockImportRepo) InsertPekerjaanAlumni(ctx context.Context, pekerjaan []model.PekerjaanAlumni) error {
+	m.insertedPekerjaan = pekerjaan
+	return nil
+}
+
+func newImportBody(content string) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", "alumni.csv")
+	part.Write([]byte(content))
+	writer.Close()
+	return body, writer.FormDataContentType()
+}
+
+func TestImportAlumniServiceDryRunReportsRowErrors(t *testing.T) {
+	repo := &mockImportRepo{existingEmails: map[string]bool{"lama@example.com": true}}
+	svc := service.NewImportService(repo, &mockAuditStore{}, outbox.Discard)
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Post("/", func(c *fiber.Ctx) error { return svc.ImportAlumniService(c) })
+
+	csv := "nim,nama,jurusan,angkatan,tahun_lulus,email\n" +
+		"1,Budi,TI,2020,2024,budi@example.com\n" +
+		"1,Ani,TI,2020,2024,ani@example.com\n" +
+		"2,Citra,TI,dua ribu,2024,LAMA@example.com\n"
+	body, contentType := newImportBody(csv)
+	req := httptest.NewRequest("POST", "/", body)
+	req.Header.Set("Content-Type", contentType)
+	resp, _ := app.Test(req)
+	if resp.StatusCode != 422 {
+		t.Fatalf("status got %d want %d", resp.StatusCode, 422)
+	}
+
+	var result struct {
+		Details model.ImportResult `json:"details"`
+	}
+	json.NewDecoder(resp.Body).Decode(&result)
+	if result.Details.TotalRows != 3 || result.Details.ValidRows != 1 || len(result.Details.Errors) != 3 {
+		t.Fatalf("unexpected result %+v", result.Details)
+	}
+	if repo.inserted != nil {
+		t.Fatal("dry run must not insert data")
+	}
+}
+
+func TestImportAlumniServiceCommitGeneratesPasswords(t *testing.T) {
+	repo := &mockImportRepo{existingEmails: map[string]bool{}}
+	svc := service.NewImportService(repo, &mockAuditStore{}, outbox.Discard)
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Post("/", func(c *fiber.Ctx) error { return svc.ImportAlumniService(c) })
+
+	body, contentType := newImportBody("nim,nama,jurusan,angkatan,tahun_lulus,email\n1,Budi,TI,2020,2024,budi@example.com\n")
+	req := httptest.NewRequest("POST", "/?dry_run=false", body)
+	req.Header.Set("Content-Type", contentType)
+	resp, _ := app.Test(req, -1)
+	if resp.StatusCode != 201 {
+		t.Fatalf("status got %d want %d", resp.StatusCode, 201)
+	}
+
+	var result struct {
+		Data model.ImportResult `json:"data"`
+	}
+	json.NewDecoder(resp.Body).Decode(&result)
+	if result.Data.Imported != 1 || len(result.Data.Credentials) != 1 || result.Data.Credentials[0].Password == "" {
+		t.Fatalf("unexpected result %+v", result.Data)
+	}
+	if len(repo.inserted) != 1 || repo.inserted[0].Role != "user" || repo.inserted[0].PasswordHash == "" {
+		t.Fatalf("unexpected inserted alumni %+v", repo.inserted)
+	}
+}
+
+func TestImportPekerjaanAlumniServiceUsesRequestValidation(t *testing.T) {
+	repo := &mockImportRepo{alumniByNIM: map[string]model.Alumni{"1": {NIM: "1", Nama: "Budi"}}}
+	svc := service.NewImportService(repo, &mockAuditStore{}, outbox.Discard)
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Post("/", func(c *fiber.Ctx) error { return svc.ImportPekerjaanAlumniService(c) })
+
+	csv := "nim,nama_perusahaan,posisi_jabatan,bidang_industri,lokasi_kerja,tanggal_mulai_kerja,tanggal_selesai_kerja,status_pekerjaan\n" +
+		"1,PT A,Engineer,IT,Jakarta,2024-01-01,2024-06-30,selesai\n" +
+		"1,PT B,Engineer,IT,Jakarta,2024-06-01,2024-01-31,selesai\n" +
+		"1,PT C,Engineer,IT,Jakarta,2024-06-01,,pensiun\n"
+	body, contentType := newImportBody(csv)
+	req := httptest.NewRequest("POST", "/?dry_run=false", body)
+	req.Header.Set("Content-Type", contentType)
+	resp, _ := app.Test(req)
+	if resp.StatusCode != 422 {
+		t.Fatalf("status got %d want %d", resp.StatusCode, 422)
+	}
+
+	var result struct {
+		Details model.ImportResult `json:"details"`
+	}
+	json.NewDecoder(resp.Body).Decode(&result)
+	if result.Details.ValidRows != 1 || len(result.Details.Errors) != 2 {
+		t.Fatalf("unexpected result %+v", result.Details)
+	}
+	if got := result.Details.Errors[0]; got.Row != 3 || got.Field != "tanggal_selesai_kerja" {
+		t.Fatalf("unexpected end date error %+v", got)
+	}
+	if got := result.Details.Errors[1]; got.Row != 4 || got.Field != "status_pekerjaan" {
+		t.Fatalf("unexpected status error %+v", got)
+	}
+	if repo.insertedPekerjaan != nil {
+		t.Fatal("invalid rows must not be inserted")
+	}
+}
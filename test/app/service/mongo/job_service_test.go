@@ -0,0 +1,121 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	service "go-fiber/app/service/mongo"
+	"go-fiber/apperror"
+	"go-fiber/queue"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// mockJobStore menyimpan job di map; method Store lain tidak dipakai oleh
+// test ini.
+type mockJobStore struct {
+	queue.Store
+	jobs map[string]queue.Job
+}
+
+func (m *mockJobStore) Get(ctx context.Context, id string) (*queue.Job, error) {
+	job, ok := m.jobs[id]
+	if !ok {
+		return nil, queue.ErrNotFound
+	}
+	return &job, nil
+}
+func (m *mockJobStore) Retry(ctx context.Context, id string) (*queue.Job, error) {
+	return m.transition(id, queue.StatusPending, queue.StatusFailed, queue.StatusCancelled)
+}
+func (m *mockJobStore) Cancel(ctx context.Context, id string) (*queue.Job, error) {
+	return m.transition(id, queue.StatusCancelled, queue.StatusPending)
+}
+func (m *mockJobStore) transition(id, to string, from ...string) (*queue.Job, error) {
+	job, ok := m.jobs[id]
+	if !ok {
+		return nil, queue.ErrNotFound
+	}
+	for _, status := range from {
+		if job.Status == status {
+			job.Status = to
+			m.jobs[id] = job
+			return &job, nil
+		}
+	}
+	return nil, queue.ErrInvalidState
+}
+
+func sendJob(t *testing.T, s *service.JobService, method, path string) (int, map[string]interface{}) {
+	t.Helper()
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Get("/jobs/schedules", s.GetJobSchedulesService)
+	app.Get("/jobs/:id", s.GetJobService)
+	app.Post("/jobs/:id/retry", s.RetryJobService)
+	app.Post("/jobs/:id/cancel", s.CancelJobService)
+
+	resp, err := app.Test(httptest.NewRequest(method, path, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&result)
+	return resp.StatusCode, result
+}
+
+func newJobService(t *testing.T) (*service.JobService, *mockJobStore) {
+	t.Helper()
+	store := &mockJobStore{jobs: map[string]queue.Job{
+		"507f1f77bcf86cd799439011": {ID: "507f1f77bcf86cd799439011", Type: "email.send", Status: queue.StatusFailed},
+		"507f1f77bcf86cd799439012": {ID: "507f1f77bcf86cd799439012", Type: "email.send", Status: queue.StatusPending},
+	}}
+	q, err := queue.New("mongo", store, queue.Options{Concurrency: 1, MaxAttempts: 1, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return service.NewJobService(q), store
+}
+
+func TestRetryJob(t *testing.T) {
+	s, store := newJobService(t)
+
+	status, result := sendJob(t, s, "POST", "/jobs/507f1f77bcf86cd799439011/retry")
+	if status != fiber.StatusAccepted || store.jobs["507f1f77bcf86cd799439011"].Status != queue.StatusPending {
+		t.Fatalf("status got %d want 202: %v", status, result)
+	}
+	if status, _ := sendJob(t, s, "POST", "/jobs/507f1f77bcf86cd799439012/retry"); status != fiber.StatusConflict {
+		t.Fatalf("retrying a pending job: status got %d want 409", status)
+	}
+	if status, _ := sendJob(t, s, "POST", "/jobs/507f1f77bcf86cd799439013/retry"); status != fiber.StatusNotFound {
+		t.Fatalf("unknown job status got %d want 404", status)
+	}
+}
+
+func TestCancelJob(t *testing.T) {
+	s, store := newJobService(t)
+
+	if status, _ := sendJob(t, s, "POST", "/jobs/507f1f77bcf86cd799439012/cancel"); status != fiber.StatusOK {
+		t.Fatalf("status got %d want 200", status)
+	}
+	if store.jobs["507f1f77bcf86cd799439012"].Status != queue.StatusCancelled {
+		t.Fatalf("job not cancelled: %+v", store.jobs["507f1f77bcf86cd799439012"])
+	}
+	if status, _ := sendJob(t, s, "POST", "/jobs/507f1f77bcf86cd799439011/cancel"); status != fiber.StatusConflict {
+		t.Fatalf("cancelling a failed job: status got %d want 409", status)
+	}
+}
+
+func TestGetJobSchedules(t *testing.T) {
+	s, _ := newJobService(t)
+
+	status, result := sendJob(t, s, "GET", "/jobs/schedules")
+	if status != fiber.StatusOK {
+		t.Fatalf("status got %d want 200", status)
+	}
+	if schedules, ok := result["data"].([]interface{}); !ok || len(schedules) != 0 {
+		t.Fatalf("schedules %v", result["data"])
+	}
+}
@@ -6,10 +6,13 @@ import (
 	"strings"
 	testing "testing"
 
-	service "go-fiber/app/service/mongo"
 	model "go-fiber/app/model/mongo"
+	service "go-fiber/app/service/mongo"
+	"go-fiber/apperror"
+	"go-fiber/outbox"
 
 	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 type mockPekerjaanRepo struct {
@@ -30,15 +33,18 @@ func (m *mockPekerjaanRepo) FindAllPekerjaanAlumni(ctx context.Context) ([]model
 func (m *mockPekerjaanRepo) FindPekerjaanAlumniByAlumniID(ctx context.Context, id string) ([]model.PekerjaanAlumni, error) {
 	return m.all, m.err
 }
-func (m *mockPekerjaanRepo) UpdatePekerjaanAlumni(ctx context.Context, id string, p *model.PekerjaanAlumni) (*model.PekerjaanAlumni, error) {
+func (m *mockPekerjaanRepo) UpdatePekerjaanAlumni(ctx context.Context, id string, version int, p *model.PekerjaanAlumni) (*model.PekerjaanAlumni, error) {
 	return p, m.err
 }
-func (m *mockPekerjaanRepo) DeletePekerjaanAlumni(ctx context.Context, id string) error { return m.err }
+func (m *mockPekerjaanRepo) PatchPekerjaanAlumni(ctx context.Context, id string, version int, changes bson.M) (*model.PekerjaanAlumni, error) {
+	return m.byID, m.err
+}
+func (m *mockPekerjaanRepo) DeletePekerjaanAlumni(ctx context.Context, id string, version int) error { return m.err }
 
 func TestCreatePekerjaanAlumniServiceBadTanggal(t *testing.T) {
 	repo := &mockPekerjaanRepo{}
-	svc := service.NewPekerjaanAlumniService(repo)
-	app := fiber.New()
+	svc := service.NewPekerjaanAlumniService(repo, false, &mockAuditStore{}, outbox.Discard)
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
 	app.Post("/", func(c *fiber.Ctx) error { return svc.CreatePekerjaanAlumniService(c) })
 	body := `{"alumni_info":{"alumni_id":"507f1f77bcf86cd799439011","nim":"1","nama":"A","email":"a@a.com"},"nama_perusahaan":"X","posisi_jabatan":"Y","bidang_industri":"Z","lokasi_kerja":"K","status_pekerjaan":"aktif","tanggal_mulai_kerja":"2025-13-01"}`
 	req := httptest.NewRequest("POST", "/", strings.NewReader(body))
@@ -51,8 +57,8 @@ func TestCreatePekerjaanAlumniServiceBadTanggal(t *testing.T) {
 
 func TestGetPekerjaanAlumniByIDNotFound(t *testing.T) {
 	repo := &mockPekerjaanRepo{byID: nil}
-	svc := service.NewPekerjaanAlumniService(repo)
-	app := fiber.New()
+	svc := service.NewPekerjaanAlumniService(repo, false, &mockAuditStore{}, outbox.Discard)
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
 	app.Get("/:id", func(c *fiber.Ctx) error { return svc.GetPekerjaanAlumniByIDService(c) })
 	req := httptest.NewRequest("GET", "/507f1f77bcf86cd799439011", nil)
 	resp, _ := app.Test(req)
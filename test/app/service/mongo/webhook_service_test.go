@@ -0,0 +1,134 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	service "go-fiber/app/service/mongo"
+	"go-fiber/apperror"
+	"go-fiber/webhook"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// mockWebhookStore menyimpan subscription di map; method Store lain tidak
+// dipakai oleh test ini.
+type mockWebhookStore struct {
+	webhook.Store
+	subscriptions map[string]webhook.Subscription
+	enqueued      []webhook.Delivery
+}
+
+func (m *mockWebhookStore) CreateSubscription(ctx context.Context, subscription *webhook.Subscription) error {
+	subscription.ID = "507f1f77bcf86cd799439011"
+	m.subscriptions[subscription.ID] = *subscription
+	return nil
+}
+func (m *mockWebhookStore) ListSubscriptions(ctx context.Context) ([]webhook.Subscription, error) {
+	var subscriptions []webhook.Subscription
+	for _, subscription := range m.subscriptions {
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, nil
+}
+func (m *mockWebhookStore) GetSubscription(ctx context.Context, id string) (*webhook.Subscription, error) {
+	subscription, ok := m.subscriptions[id]
+	if !ok {
+		return nil, webhook.ErrNotFound
+	}
+	return &subscription, nil
+}
+func (m *mockWebhookStore) EnqueueDeliveries(ctx context.Context, deliveries ...webhook.Delivery) error {
+	m.enqueued = append(m.enqueued, deliveries...)
+	return nil
+}
+func (m *mockWebhookStore) Redeliver(ctx context.Context, id string) (*webhook.Delivery, error) {
+	if id == "507f1f77bcf86cd799439012" {
+		return nil, webhook.ErrInvalidState
+	}
+	return &webhook.Delivery{ID: id, Status: webhook.StatusPending}, nil
+}
+
+func sendWebhook(t *testing.T, s *service.WebhookService, method, path, body string) (int, map[string]interface{}) {
+	t.Helper()
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Get("/webhooks", s.GetWebhooksService)
+	app.Post("/webhooks", s.CreateWebhookService)
+	app.Post("/webhooks/:id/test", s.TestWebhookService)
+	app.Post("/webhooks/deliveries/:deliveryId/redeliver", s.RedeliverService)
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&result)
+	return resp.StatusCode, result
+}
+
+func TestCreateWebhookReturnsSecretOnce(t *testing.T) {
+	store := &mockWebhookStore{subscriptions: map[string]webhook.Subscription{}}
+	s := service.NewWebhookService(store)
+
+	status, result := sendWebhook(t, s, "POST", "/webhooks", `{"url":"https://example.com/hook","event_types":["alumni.*"]}`)
+	if status != fiber.StatusCreated {
+		t.Fatalf("status got %d want 201: %v", status, result)
+	}
+	data := result["data"].(map[string]interface{})
+	if secret, _ := data["secret"].(string); len(secret) != 64 || data["active"] != true {
+		t.Fatalf("create must return the generated secret: %v", data)
+	}
+
+	status, result = sendWebhook(t, s, "GET", "/webhooks", "")
+	if status != fiber.StatusOK {
+		t.Fatalf("status got %d want 200", status)
+	}
+	list := result["data"].([]interface{})
+	if _, ok := list[0].(map[string]interface{})["secret"]; ok || len(list) != 1 {
+		t.Fatalf("list must not expose secrets: %v", list)
+	}
+}
+
+func TestCreateWebhookRejectsInvalidEventType(t *testing.T) {
+	store := &mockWebhookStore{subscriptions: map[string]webhook.Subscription{}}
+	status, _ := sendWebhook(t, service.NewWebhookService(store), "POST", "/webhooks", `{"url":"https://example.com/hook","event_types":["alumni"]}`)
+	if status != fiber.StatusBadRequest || len(store.subscriptions) != 0 {
+		t.Fatalf("status got %d want 400", status)
+	}
+}
+
+func TestTestWebhook(t *testing.T) {
+	store := &mockWebhookStore{subscriptions: map[string]webhook.Subscription{
+		"507f1f77bcf86cd799439011": {ID: "507f1f77bcf86cd799439011", URL: "https://example.com", Active: true},
+		"507f1f77bcf86cd799439012": {ID: "507f1f77bcf86cd799439012", URL: "https://example.com", Active: false},
+	}}
+	s := service.NewWebhookService(store)
+
+	status, _ := sendWebhook(t, s, "POST", "/webhooks/507f1f77bcf86cd799439011/test", "")
+	if status != fiber.StatusAccepted || len(store.enqueued) != 1 || store.enqueued[0].Event.Type != webhook.TestEventType {
+		t.Fatalf("status got %d, enqueued %+v", status, store.enqueued)
+	}
+	if status, _ := sendWebhook(t, s, "POST", "/webhooks/507f1f77bcf86cd799439012/test", ""); status != fiber.StatusUnprocessableEntity {
+		t.Fatalf("inactive webhook status got %d want 422", status)
+	}
+	if status, _ := sendWebhook(t, s, "POST", "/webhooks/507f1f77bcf86cd799439013/test", ""); status != fiber.StatusNotFound {
+		t.Fatalf("unknown webhook status got %d want 404", status)
+	}
+}
+
+func TestRedeliverRejectsPendingDelivery(t *testing.T) {
+	s := service.NewWebhookService(&mockWebhookStore{})
+
+	if status, _ := sendWebhook(t, s, "POST", "/webhooks/deliveries/507f1f77bcf86cd799439011/redeliver", ""); status != fiber.StatusAccepted {
+		t.Fatalf("dead delivery status got %d want 202", status)
+	}
+	status, body := sendWebhook(t, s, "POST", "/webhooks/deliveries/507f1f77bcf86cd799439012/redeliver", "")
+	if status != fiber.StatusConflict {
+		t.Fatalf("pending delivery status got %d want 409, body %v", status, body)
+	}
+}
@@ -0,0 +1,135 @@
+package apperror_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go-fiber/apperror"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/lib/pq"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func problemFor(t *testing.T, handlerErr error) (int, string, apperror.Problem) {
+	t.Helper()
+	return problemIn(t, "", handlerErr)
+}
+
+func problemIn(t *testing.T, acceptLanguage string, handlerErr error) (int, string, apperror.Problem) {
+	t.Helper()
+
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Get("/alumni/:id", func(c *fiber.Ctx) error { return handlerErr })
+
+	req := httptest.NewRequest("GET", "/alumni/42", nil)
+	if acceptLanguage != "" {
+		req.Header.Set("Accept-Language", acceptLanguage)
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var problem apperror.Problem
+	if err := json.NewDecoder(resp.Body).Decode(&problem); err != nil {
+		t.Fatalf("decode problem: %v", err)
+	}
+	return resp.StatusCode, resp.Header.Get("Content-Type"), problem
+}
+
+func TestHandlerWritesProblemJSON(t *testing.T) {
+	status, contentType, problem := problemFor(t, apperror.NotFound("alumni.not_found"))
+
+	if status != fiber.StatusNotFound || problem.Status != fiber.StatusNotFound {
+		t.Fatalf("status = %d, problem.status = %d", status, problem.Status)
+	}
+	if !strings.HasPrefix(contentType, apperror.ContentType) {
+		t.Errorf("Content-Type = %s", contentType)
+	}
+	if problem.Code != "not_found" || problem.Title != "Not Found" || problem.Detail != "Data alumni tidak ditemukan." {
+		t.Errorf("unexpected problem: %+v", problem)
+	}
+	if problem.Instance != "/alumni/42" || problem.Type != "about:blank" {
+		t.Errorf("unexpected problem: %+v", problem)
+	}
+}
+
+func TestHandlerHidesUnknownErrors(t *testing.T) {
+	status, _, problem := problemFor(t, errors.New("pq: password authentication failed for user alumni"))
+
+	if status != fiber.StatusInternalServerError || problem.Code != "internal_error" {
+		t.Fatalf("status = %d, problem = %+v", status, problem)
+	}
+	if strings.Contains(problem.Detail, "pq:") {
+		t.Errorf("driver error leaked: %s", problem.Detail)
+	}
+
+	_, _, problem = problemFor(t, apperror.Wrap(errors.New("connection reset by peer"), "alumni.fetch_failed"))
+	if problem.Detail != "Error mengambil data alumni dari database." {
+		t.Errorf("detail = %q", problem.Detail)
+	}
+}
+
+func TestHandlerMapsFiberErrors(t *testing.T) {
+	status, _, problem := problemFor(t, fiber.ErrRequestEntityTooLarge)
+	if status != fiber.StatusRequestEntityTooLarge || problem.Code != "payload_too_large" {
+		t.Errorf("status = %d, problem = %+v", status, problem)
+	}
+	if problem.Detail != "Ukuran request melebihi batas maksimal." {
+		t.Errorf("detail = %q", problem.Detail)
+	}
+}
+
+func TestHandlerLocalizesDetail(t *testing.T) {
+	_, _, problem := problemIn(t, "en-US,en;q=0.9", apperror.Conflict("error.duplicate_field", "email"))
+	if problem.Detail != "Data with this email already exists." {
+		t.Errorf("detail = %q", problem.Detail)
+	}
+
+	_, _, problem = problemIn(t, "en", errors.New("pq: connection refused"))
+	if problem.Detail != "An internal server error occurred." {
+		t.Errorf("detail = %q", problem.Detail)
+	}
+
+	_, _, problem = problemIn(t, "fr", apperror.NotFound("alumni.not_found"))
+	if problem.Detail != "Data alumni tidak ditemukan." {
+		t.Errorf("unsupported language should fall back to Indonesian, got %q", problem.Detail)
+	}
+}
+
+func TestWrapKeepsDomainErrors(t *testing.T) {
+	err := apperror.Wrap(apperror.InvalidID(), "alumni.fetch_failed")
+	if !errors.Is(err, apperror.ErrValidation) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	if apperror.StatusCode(err) != fiber.StatusBadRequest {
+		t.Errorf("status = %d", apperror.StatusCode(err))
+	}
+}
+
+func TestFromDBMapsDuplicateKeys(t *testing.T) {
+	pgErr := &pq.Error{Code: "23505", Table: "alumni", Constraint: "alumni_email_key"}
+	err := apperror.Wrap(pgErr, "alumni.create_failed")
+
+	var appErr *apperror.Error
+	if !errors.As(err, &appErr) || appErr.Status != fiber.StatusConflict || appErr.Code != "duplicate_email" {
+		t.Fatalf("postgres duplicate mapped to %v", err)
+	}
+
+	mongoErr := mongo.WriteException{WriteErrors: mongo.WriteErrors{{
+		Code:    11000,
+		Message: `E11000 duplicate key error collection: alumni_db.alumni index: nim_1 dup key: { nim: "2021001" }`,
+	}}}
+	err = apperror.FromDB(mongoErr)
+	if !errors.As(err, &appErr) || appErr.Status != fiber.StatusConflict || appErr.Code != "duplicate_nim" {
+		t.Fatalf("mongo duplicate mapped to %v", err)
+	}
+	if !errors.Is(err, apperror.ErrConflict) {
+		t.Error("duplicate should match ErrConflict")
+	}
+}
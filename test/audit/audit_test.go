@@ -0,0 +1,211 @@
+package audit_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-fiber/apperror"
+	"go-fiber/audit"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type alumni struct {
+	ID           int     `json:"id"`
+	Email        string  `json:"email"`
+	Password     string  `json:"password"`
+	PasswordHash string  `json:"password_hash"`
+	NoTelepon    *string `json:"no_telepon"`
+}
+
+func TestSnapshotRedactsSecrets(t *testing.T) {
+	snapshot := audit.Snapshot(alumni{ID: 1, Email: "a@example.com", Password: "rahasia", PasswordHash: "hash"})
+	if _, ok := snapshot["password"]; ok {
+		t.Errorf("password must be redacted: %v", snapshot)
+	}
+	if _, ok := snapshot["password_hash"]; ok {
+		t.Errorf("password_hash must be redacted: %v", snapshot)
+	}
+	if snapshot["email"] != "a@example.com" {
+		t.Errorf("snapshot = %v", snapshot)
+	}
+
+	var missing *alumni
+	if audit.Snapshot(missing) != nil || audit.Snapshot(nil) != nil {
+		t.Error("nil values should produce a nil snapshot")
+	}
+}
+
+func TestDiffListsChangedFields(t *testing.T) {
+	phone := "0812"
+	before := audit.Snapshot(alumni{ID: 1, Email: "lama@example.com"})
+	after := audit.Snapshot(alumni{ID: 1, Email: "baru@example.com", NoTelepon: &phone})
+
+	changes := audit.Diff(before, after)
+	if len(changes) != 2 {
+		t.Fatalf("changes = %+v", changes)
+	}
+	if changes[0].Field != "email" || changes[0].Before != "lama@example.com" || changes[0].After != "baru@example.com" {
+		t.Errorf("email change = %+v", changes[0])
+	}
+	if changes[1].Field != "no_telepon" || changes[1].Before != nil || changes[1].After != "0812" {
+		t.Errorf("no_telepon change = %+v", changes[1])
+	}
+}
+
+func TestNewEntryReadsRequestContext(t *testing.T) {
+	var entry audit.Entry
+	app := fiber.New()
+	app.Delete("/alumni/:id", func(c *fiber.Ctx) error {
+		c.Locals("alumni_id", 7)
+		c.Locals("email", "admin@example.com")
+		c.Locals("role", "admin")
+		c.Locals("request_id", "req-42")
+		entry = audit.NewEntry(c, audit.ActionDelete, audit.ResourceAlumni, 3, alumni{ID: 3}, nil)
+		return nil
+	})
+	app.Test(httptest.NewRequest("DELETE", "/alumni/3", nil))
+
+	if entry.ActorID != "7" || entry.ActorEmail != "admin@example.com" || entry.ActorRole != "admin" {
+		t.Errorf("actor = %+v", entry)
+	}
+	if entry.ResourceID != "3" || entry.RequestID != "req-42" || entry.IP == "" {
+		t.Errorf("entry = %+v", entry)
+	}
+	if entry.Before == nil || entry.After != nil || entry.Changes != nil {
+		t.Errorf("delete entry should only have before: %+v", entry)
+	}
+}
+
+func TestNewSystemEntryUsesJobAsActor(t *testing.T) {
+	entry := audit.NewSystemEntry("pekerjaan.purge_trash", audit.ActionHardDelete, audit.ResourcePekerjaan, 9, alumni{ID: 9, Password: "secret"}, nil)
+
+	if entry.ActorID != "pekerjaan.purge_trash" || entry.ActorRole != audit.RoleSystem || entry.ActorEmail != "" {
+		t.Errorf("actor = %+v", entry)
+	}
+	if entry.ResourceID != "9" || entry.IP != "" || entry.RequestID != "" || entry.CreatedAt.IsZero() {
+		t.Errorf("entry = %+v", entry)
+	}
+	if _, ok := entry.Before["password"]; ok || entry.After != nil {
+		t.Errorf("before must be a redacted snapshot: %+v", entry.Before)
+	}
+}
+
+func parseFilter(t *testing.T, query string) (audit.Filter, int) {
+	t.Helper()
+
+	var filter audit.Filter
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Get("/", func(c *fiber.Ctx) error {
+		var err error
+		filter, err = audit.ParseFilter(c)
+		return err
+	})
+	resp, err := app.Test(httptest.NewRequest("GET", "/?"+query, nil))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	return filter, resp.StatusCode
+}
+
+func TestParseFilter(t *testing.T) {
+	filter, status := parseFilter(t, "actor=admin@example.com&resource=Alumni&from=2025-01-01&to=2025-01-31&page=2&limit=500")
+	if status != fiber.StatusOK {
+		t.Fatalf("status = %d", status)
+	}
+	if filter.Actor != "admin@example.com" || filter.Resource != "alumni" || filter.Page != 2 || filter.Limit != 20 {
+		t.Errorf("filter = %+v", filter)
+	}
+	if !filter.From.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("from = %v", filter.From)
+	}
+	if want := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond); !filter.To.Equal(want) {
+		t.Errorf("date-only to should cover the whole day, got %v", filter.To)
+	}
+	if filter.Offset() != 20 {
+		t.Errorf("offset = %d", filter.Offset())
+	}
+
+	filter, _ = parseFilter(t, "from=2025-01-01T08:00:00%2B07:00")
+	if !filter.From.Equal(time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC)) {
+		t.Errorf("RFC3339 from = %v", filter.From)
+	}
+
+	for _, query := range []string{"from=kemarin", "to=2025-13-01", "from=2025-02-01&to=2025-01-01"} {
+		if _, status := parseFilter(t, query); status != fiber.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", query, status)
+		}
+	}
+}
+
+func TestNewMeta(t *testing.T) {
+	meta := audit.NewMeta(audit.Filter{Page: 1, Limit: 20}, 41)
+	data, _ := json.Marshal(meta)
+	if string(data) != `{"page":1,"limit":20,"total":41,"pages":3}` {
+		t.Errorf("meta = %s", data)
+	}
+	if audit.NewMeta(audit.Filter{Page: 1, Limit: 20}, 0).Pages != 1 {
+		t.Error("empty result should still have one page")
+	}
+}
+
+func history() []audit.Entry {
+	return []audit.Entry{
+		{Action: audit.ActionCreate, After: map[string]interface{}{"alamat": "Surabaya"}, CreatedAt: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)},
+		{Action: audit.ActionUpdate, Before: map[string]interface{}{"alamat": "Surabaya"}, After: map[string]interface{}{"alamat": "Malang"}, CreatedAt: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{Action: audit.ActionDelete, Before: map[string]interface{}{"alamat": "Malang"}, CreatedAt: time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)},
+	}
+}
+
+func TestVersions(t *testing.T) {
+	versions := audit.Versions(history())
+	if len(versions) != 3 || versions[0].Version != 1 || versions[2].Version != 3 {
+		t.Fatalf("versions = %+v", versions)
+	}
+	if len(versions[0].Changes) != 1 || versions[0].Changes[0].After != "Surabaya" {
+		t.Errorf("create changes = %+v", versions[0].Changes)
+	}
+	if versions[2].Data != nil || len(versions[2].Changes) != 1 || versions[2].Changes[0].Before != "Malang" {
+		t.Errorf("delete version = %+v", versions[2])
+	}
+}
+
+func TestStateAt(t *testing.T) {
+	entries := history()
+	state, ok := audit.StateAt(entries, time.Date(2024, 6, 1, 23, 59, 59, 0, time.UTC))
+	if !ok || state["alamat"] != "Surabaya" {
+		t.Errorf("state on 2024-06-01 = %v", state)
+	}
+	if _, ok := audit.StateAt(entries, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)); ok {
+		t.Error("record should not exist before it was created")
+	}
+	if _, ok := audit.StateAt(entries, time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)); ok {
+		t.Error("record should not exist after it was deleted")
+	}
+}
+
+func TestVersionData(t *testing.T) {
+	entries := history()
+	data, err := audit.VersionData(entries, 2)
+	if err != nil || data["alamat"] != "Malang" {
+		t.Errorf("version 2 = %v, %v", data, err)
+	}
+	if _, err := audit.VersionData(entries, 4); apperror.StatusCode(err) != fiber.StatusNotFound {
+		t.Errorf("unknown version error = %v", err)
+	}
+	if _, err := audit.VersionData(entries, 3); apperror.StatusCode(err) != fiber.StatusUnprocessableEntity {
+		t.Errorf("deleted version error = %v", err)
+	}
+}
+
+func TestParseAsOf(t *testing.T) {
+	at, err := audit.ParseAsOf("2024-06-01")
+	if err != nil || !at.Equal(time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)) {
+		t.Errorf("as_of = %v, %v", at, err)
+	}
+	if _, err := audit.ParseAsOf("kemarin"); apperror.StatusCode(err) != fiber.StatusBadRequest {
+		t.Errorf("invalid as_of error = %v", err)
+	}
+}
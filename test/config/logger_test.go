@@ -0,0 +1,42 @@
+package config_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"go-fiber/config"
+)
+
+func TestParseLogLevel(t *testing.T) {
+	cases := map[string]slog.Level{
+		"debug":   slog.LevelDebug,
+		"WARN":    slog.LevelWarn,
+		"error":   slog.LevelError,
+		"":        slog.LevelInfo,
+		"verbose": slog.LevelInfo,
+	}
+	for input, want := range cases {
+		if got := config.ParseLogLevel(input); got != want {
+			t.Fatalf("ParseLogLevel(%q) got %v want %v", input, got, want)
+		}
+	}
+}
+
+func TestRedactAttrHidesSensitiveFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: config.RedactAttr}))
+	logger.Info("login", "email", "budi@example.com", "Password", "rahasia", slog.Group("headers", "Authorization", "Bearer abc"))
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("invalid log output %q", buf.String())
+	}
+	if entry["email"] != "budi@example.com" || entry["Password"] != "[REDACTED]" {
+		t.Fatalf("unexpected log entry %v", entry)
+	}
+	if headers := entry["headers"].(map[string]interface{}); headers["Authorization"] != "[REDACTED]" {
+		t.Fatalf("nested attribute not redacted: %v", headers)
+	}
+}
@@ -0,0 +1,91 @@
+package etag_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"go-fiber/apperror"
+	"go-fiber/etag"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestFormat(t *testing.T) {
+	if got := etag.Format(12); got != `"12"` {
+		t.Fatalf("got %s want %s", got, `"12"`)
+	}
+}
+
+func TestCheckIfMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		required bool
+		header   string
+		wantCode string
+	}{
+		{"no header optional", false, "", ""},
+		{"no header required", true, "", "precondition_required"},
+		{"match", false, `"5"`, ""},
+		{"weak match", false, `W/"5"`, ""},
+		{"match in list", false, `"4", "5"`, ""},
+		{"wildcard", true, "*", ""},
+		{"mismatch", true, `"4"`, "version_conflict"},
+		{"unquoted", false, "5", "version_conflict"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got error
+			app := fiber.New()
+			app.Put("/", func(c *fiber.Ctx) error {
+				got = etag.CheckIfMatch(c, 5, tt.required)
+				return nil
+			})
+			req := httptest.NewRequest("PUT", "/", nil)
+			if tt.header != "" {
+				req.Header.Set("If-Match", tt.header)
+			}
+			if _, err := app.Test(req); err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+
+			if tt.wantCode == "" {
+				if got != nil {
+					t.Fatalf("got %v want nil", got)
+				}
+				return
+			}
+			var appErr *apperror.Error
+			if !errors.As(got, &appErr) || appErr.Code != tt.wantCode {
+				t.Fatalf("got %v want code %s", got, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		if etag.NotModified(c, 7) {
+			return c.SendStatus(fiber.StatusNotModified)
+		}
+		return c.SendString("data")
+	})
+
+	for header, want := range map[string]int{"": 200, `"7"`: 304, `W/"7"`: 304, `"6"`: 200} {
+		req := httptest.NewRequest("GET", "/", nil)
+		if header != "" {
+			req.Header.Set("If-None-Match", header)
+		}
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		if resp.StatusCode != want {
+			t.Fatalf("If-None-Match %q: status got %d want %d", header, resp.StatusCode, want)
+		}
+		if resp.Header.Get("ETag") != `"7"` {
+			t.Fatalf("ETag got %q want %q", resp.Header.Get("ETag"), `"7"`)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go-fiber/health"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type readinessResponse struct {
+	Success bool `json:"success"`
+	Data    struct {
+		Status string                        `json:"status"`
+		Checks map[string]health.CheckResult `json:"checks"`
+	} `json:"data"`
+}
+
+func newApp(checker *health.Checker) *fiber.App {
+	app := fiber.New()
+	app.Get("/healthz", checker.Liveness)
+	app.Get("/readyz", checker.Readiness)
+	return app
+}
+
+func getReadiness(t *testing.T, app *fiber.App) (int, readinessResponse) {
+	t.Helper()
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/readyz", nil), 5000)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var body readinessResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	return resp.StatusCode, body
+}
+
+func TestReadinessAllUp(t *testing.T) {
+	checker := health.NewChecker(time.Second)
+	checker.Register("postgres", func(ctx context.Context) error { return nil })
+	checker.Register("mongodb", func(ctx context.Context) error { return nil })
+
+	status, body := getReadiness(t, newApp(checker))
+	if status != fiber.StatusOK {
+		t.Fatalf("status = %d, want 200", status)
+	}
+	if body.Data.Status != "ready" || len(body.Data.Checks) != 2 {
+		t.Errorf("unexpected body: %+v", body)
+	}
+	if body.Data.Checks["mongodb"].Status != health.StatusUp {
+		t.Errorf("mongodb status = %s", body.Data.Checks["mongodb"].Status)
+	}
+}
+
+func TestReadinessReportsFailingDependency(t *testing.T) {
+	checker := health.NewChecker(time.Second)
+	checker.Register("postgres", func(ctx context.Context) error { return nil })
+	checker.Register("mongodb", func(ctx context.Context) error { return errors.New("connection refused") })
+
+	resp, err := newApp(checker).Test(httptest.NewRequest("GET", "/readyz", nil), 5000)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	raw, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != fiber.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want 503", resp.StatusCode)
+	}
+	if strings.Contains(string(raw), "connection refused") {
+		t.Errorf("readiness must not expose check errors: %s", raw)
+	}
+
+	var body readinessResponse
+	json.Unmarshal(raw, &body)
+	if body.Data.Checks["postgres"].Status != health.StatusUp {
+		t.Errorf("postgres status = %s", body.Data.Checks["postgres"].Status)
+	}
+	if body.Data.Checks["mongodb"].Status != health.StatusDown {
+		t.Errorf("mongodb result = %+v", body.Data.Checks["mongodb"])
+	}
+
+	results, _ := checker.Run(context.Background())
+	if results["mongodb"].Error != "connection refused" {
+		t.Errorf("Run must keep the error for logging: %+v", results["mongodb"])
+	}
+}
+
+func TestReadinessTimesOutSlowDependency(t *testing.T) {
+	checker := health.NewChecker(50 * time.Millisecond)
+	checker.Register("postgres", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	start := time.Now()
+	status, _ := getReadiness(t, newApp(checker))
+	if status != fiber.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want 503", status)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("readiness did not respect the check timeout")
+	}
+	results, _ := checker.Run(context.Background())
+	if results["postgres"].Error == "" {
+		t.Error("expected timeout error in check result")
+	}
+}
+
+func TestReadinessFailsDuringShutdown(t *testing.T) {
+	checker := health.NewChecker(time.Second)
+	checker.Register("postgres", func(ctx context.Context) error { return nil })
+	app := newApp(checker)
+
+	checker.SetShuttingDown()
+
+	status, body := getReadiness(t, app)
+	if status != fiber.StatusServiceUnavailable || body.Data.Status != "shutting_down" {
+		t.Errorf("status = %d, body = %+v", status, body)
+	}
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/healthz", nil))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("liveness status = %d, want 200 during shutdown", resp.StatusCode)
+	}
+}
+
+func TestWritableDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "uploads")
+	if err := health.WritableDir(dir)(context.Background()); err != nil {
+		t.Fatalf("WritableDir: %v", err)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Errorf("probe file was left behind: %v", entries)
+	}
+
+	file := filepath.Join(t.TempDir(), "not-a-dir")
+	os.WriteFile(file, []byte("x"), 0644)
+	if err := health.WritableDir(file)(context.Background()); err == nil {
+		t.Error("expected error when path is a file")
+	}
+}
@@ -0,0 +1,122 @@
+package helper_test
+
+import (
+	"errors"
+	"testing"
+
+	"go-fiber/apperror"
+	"go-fiber/helper"
+)
+
+func TestBatchIDs(t *testing.T) {
+	noFind := func(limit int) ([]int, error) {
+		t.Fatal("find must not be called without filter")
+		return nil, nil
+	}
+
+	ids, err := helper.BatchIDs([]int{3, 1, 3, 2, 1}, false, noFind)
+	if err != nil {
+		t.Fatalf("BatchIDs error %v", err)
+	}
+	if len(ids) != 3 || ids[0] != 3 || ids[1] != 1 || ids[2] != 2 {
+		t.Fatalf("expected duplicates removed in order, got %v", ids)
+	}
+
+	for _, tt := range []struct {
+		name      string
+		ids       []int
+		hasFilter bool
+	}{
+		{"neither ids nor filter", nil, false},
+		{"both ids and filter", []int{1}, true},
+	} {
+		_, err := helper.BatchIDs(tt.ids, tt.hasFilter, noFind)
+		var appErr *apperror.Error
+		if !errors.As(err, &appErr) || appErr.Code != "batch_selection_required" {
+			t.Fatalf("%s: expected batch_selection_required, got %v", tt.name, err)
+		}
+	}
+
+	tooMany := make([]int, helper.BatchMaxItems+1)
+	for i := range tooMany {
+		tooMany[i] = i
+	}
+	_, err = helper.BatchIDs(tooMany, false, noFind)
+	var appErr *apperror.Error
+	if !errors.As(err, &appErr) || appErr.Code != "batch_too_large" || appErr.Status != 400 {
+		t.Fatalf("expected 400 batch_too_large, got %v", err)
+	}
+
+	var askedLimit int
+	_, err = helper.BatchIDs(nil, true, func(limit int) ([]int, error) {
+		askedLimit = limit
+		return tooMany[:limit], nil
+	})
+	if askedLimit != helper.BatchMaxItems+1 || !errors.As(err, &appErr) || appErr.Code != "batch_too_large" {
+		t.Fatalf("expected filter matching too many rows to be rejected, limit %d err %v", askedLimit, err)
+	}
+}
+
+func TestBatchResultErr(t *testing.T) {
+	result := helper.NewBatchResult([]int{1, 2, 3})
+	result.Succeed(0, helper.BatchUpdated, 4)
+	if err := result.Err(); err != nil {
+		t.Fatalf("expected no error without failures, got %v", err)
+	}
+
+	if !result.Fail(1, "en", apperror.NotFound("alumni.not_found_id")) {
+		t.Fatal("expected client error to be recorded")
+	}
+	if result.Fail(2, "en", errors.New("connection reset")) {
+		t.Fatal("expected internal error not to be recorded per item")
+	}
+
+	err := result.Err()
+	var appErr *apperror.Error
+	if !errors.As(err, &appErr) || appErr.Status != 422 || appErr.Code != "batch_failed" || appErr.Details != result {
+		t.Fatalf("expected 422 batch_failed with result details, got %v", err)
+	}
+	if result.Succeeded != 0 || result.Failed != 1 {
+		t.Fatalf("unexpected counts %+v", result)
+	}
+	want := []string{helper.BatchSkipped, helper.BatchFailed, helper.BatchSkipped}
+	for i, item := range result.Items {
+		if item.Status != want[i] {
+			t.Fatalf("item %d status got %s want %s", i, item.Status, want[i])
+		}
+	}
+	if result.Items[0].Version != 0 || result.Items[1].Code != "not_found" || result.Items[1].Message == "" {
+		t.Fatalf("unexpected items %+v", result.Items)
+	}
+}
+
+func TestCheckBatchChanges(t *testing.T) {
+	type update struct {
+		Nama  string  `json:"nama"`
+		Email *string `json:"email"`
+	}
+	tests := []struct {
+		changes  string
+		wantCode string
+	}{
+		{`{"nama":"B"}`, ""},
+		{`{"email":null}`, ""},
+		{``, "batch_changes_required"},
+		{`{}`, "batch_changes_required"},
+		{`{"nim":"1"}`, "unknown_field"},
+		{`[1]`, "invalid_patch"},
+	}
+	for _, tt := range tests {
+		err := helper.CheckBatchChanges([]byte(tt.changes), update{}, &update{})
+		if tt.wantCode == "" {
+			if err != nil {
+				t.Fatalf("%s: unexpected error %v", tt.changes, err)
+			}
+			continue
+		}
+		var appErr *apperror.Error
+		if !errors.As(err, &appErr) || appErr.Code != tt.wantCode {
+			t.Fatalf("%s: expected %s, got %v", tt.changes, tt.wantCode, err)
+		}
+	}
+}
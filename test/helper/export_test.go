@@ -0,0 +1,112 @@
+package helper_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"go-fiber/helper"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type exportRow struct {
+	ID     int     `json:"id"`
+	Nama   string  `json:"nama"`
+	Alamat *string `json:"alamat"`
+	Secret string  `json:"-"`
+}
+
+func TestJSONColumnsSkipsHiddenFields(t *testing.T) {
+	got := helper.JSONColumns([]exportRow{})
+	want := []string{"id", "nama", "alamat"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("JSONColumns got %v want %v", got, want)
+	}
+}
+
+func TestFilterColumnsKeepsOrderAndWhitelist(t *testing.T) {
+	whitelist := map[string]bool{"id": true, "nama": true}
+	got := helper.FilterColumns([]string{"id", "nama", "alamat"}, []string{"nama", "id"}, whitelist)
+	if !reflect.DeepEqual(got, []string{"id", "nama"}) {
+		t.Fatalf("FilterColumns got %v", got)
+	}
+}
+
+func TestWriteTableCSV(t *testing.T) {
+	var buf bytes.Buffer
+	rows := []exportRow{{ID: 1, Nama: "Budi, S.Kom"}, {ID: 2, Nama: "Ani"}}
+	if err := helper.WriteTable("csv", &buf, rows); err != nil {
+		t.Fatalf("WriteTable error %v", err)
+	}
+
+	want := "id,nama,alamat\n1,\"Budi, S.Kom\",\n2,Ani,\n"
+	if buf.String() != want {
+		t.Fatalf("WriteTable got %q want %q", buf.String(), want)
+	}
+}
+
+func TestRowValuesEscapesFormulas(t *testing.T) {
+	alamat := "@SUM(A1:A2)"
+	values, err := helper.RowValues(exportRow{ID: -1, Nama: "=HYPERLINK(\"http://evil\")", Alamat: &alamat}, []string{"id", "nama", "alamat"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"-1", "'=HYPERLINK(\"http://evil\")", "'@SUM(A1:A2)"}
+	if !reflect.DeepEqual(values, want) {
+		t.Fatalf("RowValues got %q want %q", values, want)
+	}
+
+	for _, value := range []string{"+62812", "-5", "\tcmd", "\rcmd"} {
+		if got := helper.EscapeFormula(value); got != "'"+value {
+			t.Errorf("EscapeFormula(%q) got %q", value, got)
+		}
+	}
+	for _, value := range []string{"", "Budi", "PT. A=B"} {
+		if got := helper.EscapeFormula(value); got != value {
+			t.Errorf("EscapeFormula(%q) got %q", value, got)
+		}
+	}
+}
+
+func TestWriteTableXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	if err := helper.WriteTable("xlsx", &buf, []exportRow{{ID: 7, Nama: "Citra"}}); err != nil {
+		t.Fatalf("WriteTable error %v", err)
+	}
+
+	file, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("OpenReader error %v", err)
+	}
+	defer file.Close()
+
+	rows, err := file.GetRows("Sheet1")
+	if err != nil {
+		t.Fatalf("GetRows error %v", err)
+	}
+	if len(rows) != 2 || rows[1][0] != "7" || rows[1][1] != "Citra" {
+		t.Fatalf("unexpected rows %v", rows)
+	}
+}
+
+func TestNewTabularWriterRejectsUnknownFormat(t *testing.T) {
+	if _, err := helper.NewTabularWriter("json", &bytes.Buffer{}); err == nil {
+		t.Fatal("expected error for unsupported format")
+	}
+}
+
+func TestWritePDFReport(t *testing.T) {
+	section, err := helper.NewReportSection("Ringkasan", "", []exportRow{{ID: 1, Nama: "Budi"}})
+	if err != nil {
+		t.Fatalf("NewReportSection error %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := helper.WritePDFReport(&buf, "Laporan", "", []helper.ReportSection{section}); err != nil {
+		t.Fatalf("WritePDFReport error %v", err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF")) {
+		t.Fatal("output is not a PDF document")
+	}
+}
@@ -0,0 +1,59 @@
+package helper_test
+
+import (
+	"strings"
+	"testing"
+
+	"go-fiber/helper"
+)
+
+func TestMapRecordsUsesMappingAndHeaderNames(t *testing.T) {
+	table, err := helper.ReadTable("csv", strings.NewReader("NIM Mahasiswa,Nama,Email\n123,Budi,budi@example.com\n,,\n456,Ani,\n"))
+	if err != nil {
+		t.Fatalf("ReadTable error %v", err)
+	}
+
+	records, err := helper.MapRecords(table, []string{"nim", "nama", "email"}, map[string]string{"nim": "NIM Mahasiswa"})
+	if err != nil {
+		t.Fatalf("MapRecords error %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected empty row to be skipped, got %d records", len(records))
+	}
+	if records[0].Row != 2 || records[0].Get("nim") != "123" || records[0].Get("nama") != "Budi" {
+		t.Fatalf("unexpected first record %+v", records[0])
+	}
+	if records[1].Row != 4 || records[1].Optional("email") != nil {
+		t.Fatalf("unexpected second record %+v", records[1])
+	}
+}
+
+func TestMapRecordsRejectsUnknownMapping(t *testing.T) {
+	table := [][]string{{"nim"}, {"123"}}
+	if _, err := helper.MapRecords(table, []string{"nim"}, map[string]string{"password_hash": "nim"}); err == nil {
+		t.Fatal("expected error for unknown mapping field")
+	}
+	if _, err := helper.MapRecords(table, []string{"nim"}, map[string]string{"nim": "Nomor Induk"}); err == nil {
+		t.Fatal("expected error for missing mapped column")
+	}
+}
+
+func TestImportRecordDate(t *testing.T) {
+	record := helper.ImportRecord{Values: map[string]string{"ok": "2025-01-15", "bad": "15/01/2025"}}
+	if date, err := record.Date("ok"); err != nil || date.Format("2006-01-02") != "2025-01-15" {
+		t.Fatalf("Date(ok) got %v, %v", date, err)
+	}
+	if _, err := record.Date("bad"); err == nil {
+		t.Fatal("expected error for invalid date layout")
+	}
+}
+
+func TestGeneratePassword(t *testing.T) {
+	password, err := helper.GeneratePassword(12)
+	if err != nil {
+		t.Fatalf("GeneratePassword error %v", err)
+	}
+	if len(password) != 12 || strings.ContainsAny(password, "0O1lI") {
+		t.Fatalf("unexpected password %q", password)
+	}
+}
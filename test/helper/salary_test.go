@@ -0,0 +1,41 @@
+package helper_test
+
+import (
+	"testing"
+
+	"go-fiber/helper"
+)
+
+func TestParseGajiRange(t *testing.T) {
+	cases := []struct {
+		input string
+		want  helper.Salary
+	}{
+		{"5-7 juta", helper.Salary{Min: 5000000, Max: 7000000, Currency: "IDR", Period: "bulanan"}},
+		{"Rp 5.000.000 - 7.000.000", helper.Salary{Min: 5000000, Max: 7000000, Currency: "IDR", Period: "bulanan"}},
+		{"7,5jt", helper.Salary{Min: 7500000, Max: 7500000, Currency: "IDR", Period: "bulanan"}},
+		{"USD 1,500 - 2,000 per tahun", helper.Salary{Min: 1500, Max: 2000, Currency: "USD", Period: "tahunan"}},
+		{"Rp 800.000 - 1,2 juta", helper.Salary{Min: 800000, Max: 1200000, Currency: "IDR", Period: "bulanan"}},
+		{"500 ribu - 1,5 jt", helper.Salary{Min: 500000, Max: 1500000, Currency: "IDR", Period: "bulanan"}},
+		{"800rb - 1.500.000", helper.Salary{Min: 800000, Max: 1500000, Currency: "IDR", Period: "bulanan"}},
+		{"5-7k per tahun", helper.Salary{Min: 5000, Max: 7000, Currency: "IDR", Period: "tahunan"}},
+		{"10 juta+", helper.Salary{Min: 10000000, Max: 10000000, Currency: "IDR", Period: "bulanan"}},
+	}
+	for _, tc := range cases {
+		got, err := helper.ParseGajiRange(tc.input)
+		if err != nil {
+			t.Fatalf("ParseGajiRange(%q) error %v", tc.input, err)
+		}
+		if *got != tc.want {
+			t.Fatalf("ParseGajiRange(%q) got %+v want %+v", tc.input, *got, tc.want)
+		}
+	}
+}
+
+func TestParseGajiRangeInvalid(t *testing.T) {
+	for _, input := range []string{"", "negotiable", "9-5 juta"} {
+		if _, err := helper.ParseGajiRange(input); err == nil {
+			t.Fatalf("ParseGajiRange(%q) expected error", input)
+		}
+	}
+}
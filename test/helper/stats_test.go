@@ -0,0 +1,20 @@
+package helper_test
+
+import (
+	"testing"
+
+	"go-fiber/helper"
+)
+
+func TestMedianEvenCount(t *testing.T) {
+	got := helper.Median([]float64{40, 10, 30, 20})
+	if got != 25 {
+		t.Fatalf("got %v want %v", got, 25)
+	}
+}
+
+func TestPercentileEmpty(t *testing.T) {
+	if got := helper.Percentile(nil, 90); got != 0 {
+		t.Fatalf("got %v want %v", got, 0)
+	}
+}
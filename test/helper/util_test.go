@@ -0,0 +1,45 @@
+package helper_test
+
+import (
+	"reflect"
+	"testing"
+
+	"go-fiber/helper"
+)
+
+func TestParseListParam(t *testing.T) {
+	got := helper.ParseListParam(" Nama, nim,,nama ,email")
+	want := []string{"nama", "nim", "email"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v want %v", got, want)
+	}
+}
+
+func TestSelectFieldsSlice(t *testing.T) {
+	type item struct {
+		A string `json:"a"`
+		B int    `json:"b"`
+		C bool   `json:"c"`
+	}
+	got, err := helper.SelectFields([]item{{A: "x", B: 1, C: true}}, []string{"a", "c"})
+	if err != nil {
+		t.Fatalf("SelectFields error %v", err)
+	}
+	want := []interface{}{map[string]interface{}{"a": "x", "c": true}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v want %v", got, want)
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	cases := map[string]string{
+		"+62 812-3456-7890": "+6281234567890",
+		"(021) 555-0100":    "0215550100",
+		"081234567890":      "081234567890",
+	}
+	for input, want := range cases {
+		if got := helper.NormalizePhone(input); got != want {
+			t.Fatalf("NormalizePhone(%q) got %q want %q", input, got, want)
+		}
+	}
+}
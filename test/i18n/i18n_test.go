@@ -0,0 +1,177 @@
+package i18n_test
+
+import (
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/fs"
+	"net/http/httptest"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"go-fiber/i18n"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var formatVerb = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z]`)
+
+func TestCatalogsHaveSameKeysAndArgs(t *testing.T) {
+	base := i18n.Keys(i18n.Default)
+	for _, lang := range i18n.Languages() {
+		if got := i18n.Keys(lang); len(got) != len(base) {
+			t.Errorf("%s has %d keys, %s has %d", lang, len(got), i18n.Default, len(base))
+		}
+		for _, key := range base {
+			text, ok := i18n.Lookup(lang, key)
+			if !ok {
+				t.Errorf("%s: missing key %s", lang, key)
+				continue
+			}
+			want, _ := i18n.Lookup(i18n.Default, key)
+			if got, exp := formatVerb.FindAllString(text, -1), formatVerb.FindAllString(want, -1); strings.Join(got, " ") != strings.Join(exp, " ") {
+				t.Errorf("%s: %s uses verbs %v, %s uses %v", lang, key, got, i18n.Default, exp)
+			}
+		}
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := map[string]string{
+		"":                        i18n.Indonesian,
+		"en":                      i18n.English,
+		"en-US,en;q=0.9":          i18n.English,
+		"id-ID,id;q=0.9,en;q=0.8": i18n.Indonesian,
+		"fr-FR,en;q=0.5,id;q=0.7": i18n.Indonesian,
+		"fr-FR,en;q=0.5":          i18n.English,
+		"de":                      i18n.Indonesian,
+		"*":                       i18n.Indonesian,
+		"en;q=0":                  i18n.Indonesian,
+		"EN-gb":                   i18n.English,
+		"en;q=abc, id;q=0.1":      i18n.Indonesian,
+	}
+	for header, want := range tests {
+		if got := i18n.Negotiate(header); got != want {
+			t.Errorf("Negotiate(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestTFallsBackAndLocalizesErrors(t *testing.T) {
+	if got := i18n.T(i18n.English, "import.row.alumni_not_found", "2021001"); got != "no alumni found with NIM 2021001" {
+		t.Errorf("T = %q", got)
+	}
+	if got := i18n.T("fr", "alumni.not_found"); got != "Data alumni tidak ditemukan." {
+		t.Errorf("unknown language should use Indonesian, got %q", got)
+	}
+	if got := i18n.T(i18n.English, "unknown.key"); got != "unknown.key" {
+		t.Errorf("unknown key should be returned as is, got %q", got)
+	}
+
+	err := i18n.NewError("salary.min_gt_max")
+	if got := i18n.T(i18n.English, "import.row.invalid_salary", err); got != "invalid salary data: gaji_min must not be greater than gaji_max" {
+		t.Errorf("error argument = %q", got)
+	}
+	if got := i18n.Text(i18n.English, errors.New("plain")); got != "plain" {
+		t.Errorf("Text = %q", got)
+	}
+	if got := i18n.NewError("import.too_many_rows", 5000).Error(); got != "jumlah baris melebihi batas maksimal 5000" {
+		t.Errorf("Error() should use Indonesian, got %q", got)
+	}
+}
+
+func TestMiddlewareSetsLanguage(t *testing.T) {
+	app := fiber.New()
+	app.Use(i18n.Middleware)
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString(i18n.Msg(c, "health.live"))
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+	resp, _ := app.Test(req)
+
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "Service is running." {
+		t.Errorf("body = %q", body)
+	}
+	if resp.Header.Get("Content-Language") != i18n.English {
+		t.Errorf("Content-Language = %q", resp.Header.Get("Content-Language"))
+	}
+	if !strings.Contains(resp.Header.Get("Vary"), "Accept-Language") {
+		t.Errorf("Vary = %q", resp.Header.Get("Vary"))
+	}
+}
+
+// keyArgs adalah posisi argumen key pada fungsi yang menerima key katalog.
+var keyArgs = map[string]map[string]int{
+	"apperror": {"New": 2, "Validation": 0, "Unauthorized": 0, "Forbidden": 0, "NotFound": 0, "Conflict": 0, "Unprocessable": 0, "Internal": 0, "Wrap": 1},
+	"i18n":     {"T": 1, "Msg": 1, "NewError": 0},
+}
+
+// TestSourceKeysExist memastikan setiap key literal yang dipakai kode
+// aplikasi ada di katalog, sehingga typo tidak lolos menjadi pesan berupa key.
+func TestSourceKeysExist(t *testing.T) {
+	root := filepath.Join("..", "..")
+	checked := 0
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			switch d.Name() {
+			case "test", "docs", ".git", "uploads", "exports":
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			pkg, ok := sel.X.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			index, ok := keyArgs[pkg.Name][sel.Sel.Name]
+			if !ok || index >= len(call.Args) {
+				return true
+			}
+			lit, ok := call.Args[index].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			key, _ := strconv.Unquote(lit.Value)
+			if _, found := i18n.Lookup(i18n.Default, key); !found {
+				t.Errorf("%s: key %q is missing from the catalog", path, key)
+			}
+			checked++
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checked == 0 {
+		t.Fatal("no keys found in source")
+	}
+}
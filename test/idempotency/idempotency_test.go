@@ -0,0 +1,239 @@
+package idempotency_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-fiber/apperror"
+	"go-fiber/idempotency"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// newApp memasang middleware pada POST /items. Header X-User menggantikan
+// AuthRequired untuk mengisi alumni_id.
+func newApp(store idempotency.Store, handler fiber.Handler) *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Use(func(c *fiber.Ctx) error {
+		if user := c.Get("X-User"); user != "" {
+			c.Locals("alumni_id", user)
+		}
+		return c.Next()
+	})
+	app.Post("/items", idempotency.New(store, time.Hour), handler)
+	return app
+}
+
+// counting membuat handler yang menghitung berapa kali dijalankan.
+func counting(calls *int32) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		n := atomic.AddInt32(calls, 1)
+		c.Set(fiber.HeaderLocation, "/items/1")
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"call": n, "body": string(c.Body())})
+	}
+}
+
+func post(t *testing.T, app *fiber.App, key, user, body string) (*http.Response, string) {
+	t.Helper()
+
+	req := httptest.NewRequest("POST", "/items", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(idempotency.Header, key)
+	}
+	if user != "" {
+		req.Header.Set("X-User", user)
+	}
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	raw, _ := io.ReadAll(resp.Body)
+	return resp, string(raw)
+}
+
+func TestReplaysStoredResponse(t *testing.T) {
+	var calls int32
+	app := newApp(idempotency.NewMemoryStore(), counting(&calls))
+
+	first, firstBody := post(t, app, "key-1", "7", `{"nama":"PT Maju"}`)
+	second, secondBody := post(t, app, "key-1", "7", `{"nama":"PT Maju"}`)
+
+	if calls != 1 {
+		t.Fatalf("handler called %d times, want 1", calls)
+	}
+	if second.StatusCode != fiber.StatusCreated || secondBody != firstBody {
+		t.Fatalf("replay got %d %s, want %d %s", second.StatusCode, secondBody, first.StatusCode, firstBody)
+	}
+	if second.Header.Get(idempotency.ReplayedHeader) != "true" || first.Header.Get(idempotency.ReplayedHeader) != "" {
+		t.Errorf("%s header: first %q, replay %q", idempotency.ReplayedHeader,
+			first.Header.Get(idempotency.ReplayedHeader), second.Header.Get(idempotency.ReplayedHeader))
+	}
+	if second.Header.Get(fiber.HeaderLocation) != "/items/1" || !strings.HasPrefix(second.Header.Get(fiber.HeaderContentType), "application/json") {
+		t.Errorf("replay headers not restored: %v", second.Header)
+	}
+}
+
+func TestRejectsKeyReuseWithDifferentBody(t *testing.T) {
+	var calls int32
+	app := newApp(idempotency.NewMemoryStore(), counting(&calls))
+
+	post(t, app, "key-1", "7", `{"nama":"PT Maju"}`)
+	resp, body := post(t, app, "key-1", "7", `{"nama":"PT Mundur"}`)
+	if resp.StatusCode != fiber.StatusUnprocessableEntity || !strings.Contains(body, "idempotency_key_reused") {
+		t.Fatalf("got %d %s", resp.StatusCode, body)
+	}
+	if calls != 1 {
+		t.Fatalf("handler called %d times, want 1", calls)
+	}
+}
+
+func TestKeysAreScopedAndOptional(t *testing.T) {
+	var calls int32
+	app := newApp(idempotency.NewMemoryStore(), counting(&calls))
+
+	post(t, app, "", "7", `{}`)
+	post(t, app, "", "7", `{}`)
+	post(t, app, "key-1", "7", `{}`)
+	post(t, app, "key-1", "8", `{}`)
+	if calls != 4 {
+		t.Fatalf("handler called %d times, want 4", calls)
+	}
+
+	resp, _ := post(t, app, strings.Repeat("k", 256), "7", `{}`)
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Fatalf("long key status = %d, want 400", resp.StatusCode)
+	}
+}
+
+func TestFailedRequestReleasesKey(t *testing.T) {
+	var calls int32
+	app := newApp(idempotency.NewMemoryStore(), func(c *fiber.Ctx) error {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return apperror.Validation("error.invalid_body")
+		}
+		return c.Status(fiber.StatusCreated).SendString("created")
+	})
+
+	if resp, _ := post(t, app, "key-1", "7", `{}`); resp.StatusCode != fiber.StatusBadRequest {
+		t.Fatalf("first status = %d, want 400", resp.StatusCode)
+	}
+	resp, body := post(t, app, "key-1", "7", `{}`)
+	if resp.StatusCode != fiber.StatusCreated || body != "created" || calls != 2 {
+		t.Fatalf("retry got %d %q after %d calls", resp.StatusCode, body, calls)
+	}
+}
+
+func TestConcurrentRetryIsRejectedWhileInProgress(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	app := newApp(idempotency.NewMemoryStore(), func(c *fiber.Ctx) error {
+		close(started)
+		<-release
+		return c.Status(fiber.StatusCreated).SendString("created")
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		post(t, app, "key-1", "7", `{}`)
+	}()
+	<-started
+
+	resp, body := post(t, app, "key-1", "7", `{}`)
+	close(release)
+	<-done
+	if resp.StatusCode != fiber.StatusConflict || !strings.Contains(body, "idempotency_in_progress") {
+		t.Fatalf("got %d %s", resp.StatusCode, body)
+	}
+	if resp.Header.Get(fiber.HeaderRetryAfter) == "" {
+		t.Error("Retry-After header missing")
+	}
+}
+
+func TestMultipartFingerprintIgnoresBoundary(t *testing.T) {
+	var calls int32
+	app := newApp(idempotency.NewMemoryStore(), counting(&calls))
+
+	upload := func(content string) *http.Response {
+		var buf bytes.Buffer
+		writer := multipart.NewWriter(&buf)
+		writer.WriteField("alumni_id", "7")
+		part, _ := writer.CreateFormFile("file", "foto.jpg")
+		part.Write([]byte(content))
+		writer.Close()
+
+		req := httptest.NewRequest("POST", "/items", &buf)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		req.Header.Set(idempotency.Header, "upload-1")
+		req.Header.Set("X-User", "7")
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		return resp
+	}
+
+	upload("isi foto")
+	if resp := upload("isi foto"); resp.Header.Get(idempotency.ReplayedHeader) != "true" {
+		t.Fatalf("same upload with a new boundary was not replayed (status %d)", resp.StatusCode)
+	}
+	if resp := upload("foto lain"); resp.StatusCode != fiber.StatusUnprocessableEntity {
+		t.Fatalf("different file status = %d, want 422", resp.StatusCode)
+	}
+	if calls != 1 {
+		t.Fatalf("handler called %d times, want 1", calls)
+	}
+}
+
+type downStore struct{}
+
+func (downStore) Reserve(ctx context.Context, key, fingerprint string, lockTTL time.Duration) (*idempotency.Record, error) {
+	return nil, errors.New("connection refused")
+}
+func (downStore) Complete(ctx context.Context, key, fingerprint string, response idempotency.Response, ttl time.Duration) error {
+	return errors.New("connection refused")
+}
+func (downStore) Release(ctx context.Context, key, fingerprint string) error {
+	return errors.New("connection refused")
+}
+
+func TestFailsOpenWhenStoreIsDown(t *testing.T) {
+	var calls int32
+	app := newApp(downStore{}, counting(&calls))
+
+	post(t, app, "key-1", "7", `{}`)
+	resp, _ := post(t, app, "key-1", "7", `{}`)
+	if resp.StatusCode != fiber.StatusCreated || calls != 2 {
+		t.Fatalf("got %d after %d calls", resp.StatusCode, calls)
+	}
+}
+
+func TestMemoryStoreExpiresKeys(t *testing.T) {
+	store := idempotency.NewMemoryStore()
+	ctx := context.Background()
+
+	if existing, _ := store.Reserve(ctx, "k", "a", time.Millisecond); existing != nil {
+		t.Fatalf("first reserve returned %+v", existing)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if existing, _ := store.Reserve(ctx, "k", "b", time.Hour); existing != nil {
+		t.Fatalf("expired lock was not taken over: %+v", existing)
+	}
+
+	// Pemesan lama tidak boleh menimpa pesanan baru.
+	store.Complete(ctx, "k", "a", idempotency.Response{Status: 201}, time.Hour)
+	existing, _ := store.Reserve(ctx, "k", "c", time.Hour)
+	if existing == nil || existing.Fingerprint != "b" || existing.Response != nil {
+		t.Fatalf("got %+v, want pending reservation by b", existing)
+	}
+}
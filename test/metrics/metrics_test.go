@@ -0,0 +1,63 @@
+package metrics_test
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go-fiber/metrics"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func scrape(t *testing.T, app *fiber.App) string {
+	t.Helper()
+	resp, err := app.Test(httptest.NewRequest("GET", "/metrics", nil))
+	if err != nil {
+		t.Fatalf("scrape error %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return string(body)
+}
+
+func TestMiddlewareLabelsByRouteTemplate(t *testing.T) {
+	app := fiber.New()
+	app.Use(metrics.Middleware)
+	app.Get("/metrics", metrics.Handler())
+	app.Get("/alumni/:id", func(c *fiber.Ctx) error { return c.SendString("ok") })
+
+	for _, id := range []string{"1", "2"} {
+		app.Test(httptest.NewRequest("GET", "/alumni/"+id, nil))
+	}
+
+	output := scrape(t, app)
+	if !strings.Contains(output, `http_requests_total{method="GET",route="/alumni/:id",status="200"} 2`) {
+		t.Fatalf("expected request counter for route template, got:\n%s", output)
+	}
+	if strings.Contains(output, `route="/alumni/1"`) {
+		t.Fatal("raw path must not be used as label")
+	}
+}
+
+func TestObserveLoginAndUpload(t *testing.T) {
+	metrics.ObserveLogin("mongo", fiber.StatusOK)
+	metrics.ObserveLogin("mongo", fiber.StatusUnauthorized)
+	metrics.ObserveUpload("foto", fiber.StatusCreated, 2048)
+	metrics.ObserveUpload("foto", fiber.StatusBadRequest, 0)
+
+	app := fiber.New()
+	app.Get("/metrics", metrics.Handler())
+	output := scrape(t, app)
+
+	for _, want := range []string{
+		`auth_logins_total{result="success",stack="mongo"} 1`,
+		`auth_logins_total{result="failure",stack="mongo"} 1`,
+		`file_upload_bytes_total{category="foto"} 2048`,
+		`file_uploads_total{category="foto",result="failure"} 1`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %s in metrics output", want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-fiber/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRequestContextCancelledWithBase(t *testing.T) {
+	base, cancelBase := context.WithCancel(context.Background())
+
+	app := fiber.New()
+	app.Use(middleware.RequestContext(base))
+	app.Get("/", func(c *fiber.Ctx) error {
+		cancelBase()
+		select {
+		case <-c.UserContext().Done():
+			return c.SendString("cancelled")
+		case <-time.After(time.Second):
+			return c.SendString("still running")
+		}
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	body := make([]byte, 32)
+	n, _ := resp.Body.Read(body)
+	if got := string(body[:n]); got != "cancelled" {
+		t.Fatalf("handler context got %q, want cancelled", got)
+	}
+}
+
+func TestRequestContextCancelledAfterHandler(t *testing.T) {
+	var requestCtx context.Context
+
+	app := fiber.New()
+	app.Use(middleware.RequestContext(context.Background()))
+	app.Get("/", func(c *fiber.Ctx) error {
+		requestCtx = c.UserContext()
+		if requestCtx.Err() != nil {
+			t.Error("context cancelled before handler finished")
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	if _, err := app.Test(httptest.NewRequest("GET", "/", nil)); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if requestCtx.Err() == nil {
+		t.Error("context not cancelled after handler returned")
+	}
+}
@@ -0,0 +1,38 @@
+package middleware_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"go-fiber/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func newRequestIDApp() *fiber.App {
+	app := fiber.New()
+	app.Use(middleware.RequestID)
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString(middleware.GetRequestID(c))
+	})
+	return app
+}
+
+func TestRequestIDPropagatesClientHeader(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(middleware.RequestIDHeader, "abc-123")
+	resp, _ := newRequestIDApp().Test(req)
+	if got := resp.Header.Get(middleware.RequestIDHeader); got != "abc-123" {
+		t.Fatalf("request id got %q want %q", got, "abc-123")
+	}
+}
+
+func TestRequestIDReplacesInvalidHeader(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(middleware.RequestIDHeader, "bad id\nwith newline")
+	resp, _ := newRequestIDApp().Test(req)
+	got := resp.Header.Get(middleware.RequestIDHeader)
+	if got == "" || got == "bad id\nwith newline" {
+		t.Fatalf("expected generated request id, got %q", got)
+	}
+}
@@ -0,0 +1,418 @@
+package outbox_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go-fiber/audit"
+	"go-fiber/outbox"
+)
+
+func TestFromEntries(t *testing.T) {
+	entries := []audit.Entry{
+		{Action: audit.ActionCreate, Resource: audit.ResourceAlumni, ResourceID: "1", After: map[string]interface{}{"nim": "1"}, RequestID: "req-1"},
+		{Action: audit.ActionSoftDelete, Resource: audit.ResourcePekerjaan, ResourceID: "2", Before: map[string]interface{}{"id": 2.0}, After: map[string]interface{}{"id": 2.0, "is_deleted": true}},
+		{Action: audit.ActionHardDelete, Resource: audit.ResourcePekerjaan, ResourceID: "3", Before: map[string]interface{}{"id": 3.0}},
+		{Action: audit.ActionCreate, Resource: audit.ResourceFile, ResourceID: "4", After: map[string]interface{}{"id": "4"}},
+		{Action: audit.ActionImport, Resource: audit.ResourceAlumni, ResourceID: "5"},
+	}
+	events := outbox.FromEntries(entries...)
+
+	wantTypes := []string{"alumni.created", "pekerjaan.soft_deleted", "pekerjaan.deleted", "file.uploaded", "alumni.created"}
+	if len(events) != len(wantTypes) {
+		t.Fatalf("got %d events want %d", len(events), len(wantTypes))
+	}
+	for i, want := range wantTypes {
+		if events[i].Type != want {
+			t.Errorf("event %d type got %q want %q", i, events[i].Type, want)
+		}
+		if events[i].ID == "" {
+			t.Errorf("event %d has no ID", i)
+		}
+	}
+	if events[0].RequestID != "req-1" || events[0].Data["nim"] != "1" {
+		t.Fatalf("unexpected created event %+v", events[0])
+	}
+	if events[2].Data["id"] != 3.0 {
+		t.Fatalf("deleted event must carry the before snapshot: %+v", events[2])
+	}
+	if events[4].Action != audit.ActionImport {
+		t.Fatalf("original action lost: %+v", events[4])
+	}
+}
+
+func TestMatchType(t *testing.T) {
+	tests := []struct {
+		pattern, eventType string
+		want               bool
+	}{
+		{"*", "alumni.created", true},
+		{"alumni.created", "alumni.created", true},
+		{"alumni.*", "alumni.deleted", true},
+		{"alumni.*", "pekerjaan.created", false},
+		{"alumni", "alumni.created", false},
+	}
+	for _, tt := range tests {
+		if got := outbox.MatchType(tt.pattern, tt.eventType); got != tt.want {
+			t.Errorf("MatchType(%q, %q) got %v want %v", tt.pattern, tt.eventType, got, tt.want)
+		}
+	}
+}
+
+func TestBusPublish(t *testing.T) {
+	bus := outbox.NewBus()
+	var got []string
+	bus.Subscribe("alumni.*", func(ctx context.Context, event outbox.Event) error {
+		got = append(got, event.Type)
+		return nil
+	})
+	bus.Subscribe("pekerjaan.created", func(ctx context.Context, event outbox.Event) error {
+		return errors.New("gagal")
+	})
+
+	if err := bus.Publish(context.Background(), outbox.Event{Type: "alumni.updated"}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := bus.Publish(context.Background(), outbox.Event{Type: "pekerjaan.created"}); err == nil {
+		t.Fatal("expected handler error")
+	}
+	if len(got) != 1 || got[0] != "alumni.updated" {
+		t.Fatalf("unexpected deliveries %v", got)
+	}
+}
+
+// memoryStore adalah outbox.Store sederhana untuk menguji Relay.
+type memoryStore struct {
+	mu      sync.Mutex
+	records map[string]*storedEvent
+}
+
+type storedEvent struct {
+	event     outbox.Event
+	status    string
+	attempts  int
+	next      time.Time
+	lastError string
+}
+
+func newMemoryStore(events ...outbox.Event) *memoryStore {
+	store := &memoryStore{records: map[string]*storedEvent{}}
+	store.Append(context.Background(), events...)
+	return store
+}
+
+func (s *memoryStore) Append(ctx context.Context, events ...outbox.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, event := range events {
+		s.records[event.ID] = &storedEvent{event: event, status: outbox.StatusPending}
+	}
+	return nil
+}
+
+func (s *memoryStore) Claim(ctx context.Context, limit int, lease time.Duration) ([]outbox.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var records []outbox.Record
+	for _, stored := range s.records {
+		if len(records) == limit {
+			break
+		}
+		if stored.status == outbox.StatusPending && !stored.next.After(time.Now()) {
+			stored.next = time.Now().Add(lease)
+			records = append(records, outbox.Record{Event: stored.event, Attempts: stored.attempts})
+		}
+	}
+	return records, nil
+}
+
+func (s *memoryStore) MarkPublished(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[id].status = outbox.StatusPublished
+	s.records[id].attempts++
+	return nil
+}
+
+func (s *memoryStore) MarkFailed(ctx context.Context, id string, attempts int, next time.Time, lastError string, dead bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored := s.records[id]
+	stored.status = outbox.StatusPending
+	if dead {
+		stored.status = outbox.StatusDead
+	}
+	stored.attempts = attempts
+	stored.next = next
+	stored.lastError = lastError
+	return nil
+}
+
+func (s *memoryStore) Purge(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+type flakySink struct {
+	failures  int
+	published []string
+}
+
+func (s *flakySink) Name() string { return "flaky" }
+
+func (s *flakySink) Publish(ctx context.Context, event outbox.Event) error {
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("tujuan tidak tersedia")
+	}
+	s.published = append(s.published, event.ID)
+	return nil
+}
+
+func TestRelayFlushPublishesAndRetries(t *testing.T) {
+	store := newMemoryStore(outbox.Event{ID: "e1", Type: "alumni.created"})
+	sink := &flakySink{failures: 1}
+	relay := outbox.NewRelay("test", store, []outbox.Sink{sink}, 10, 3, 0)
+
+	claimed, err := relay.Flush(context.Background())
+	if err != nil || claimed != 1 {
+		t.Fatalf("first flush got %d, %v", claimed, err)
+	}
+	stored := store.records["e1"]
+	if stored.status != outbox.StatusPending || stored.attempts != 1 || stored.lastError == "" {
+		t.Fatalf("failure not recorded: %+v", stored)
+	}
+	if !stored.next.After(time.Now()) {
+		t.Fatalf("retry must be delayed: %v", stored.next)
+	}
+
+	// Percobaan berikutnya belum waktunya, jadi tidak ada yang diambil.
+	if claimed, _ := relay.Flush(context.Background()); claimed != 0 {
+		t.Fatalf("event retried before backoff: %d", claimed)
+	}
+
+	stored.next = time.Now()
+	if _, err := relay.Flush(context.Background()); err != nil {
+		t.Fatalf("second flush error %v", err)
+	}
+	if stored.status != outbox.StatusPublished || stored.attempts != 2 {
+		t.Fatalf("event not published: %+v", stored)
+	}
+	if len(sink.published) != 1 || sink.published[0] != "e1" {
+		t.Fatalf("unexpected deliveries %v", sink.published)
+	}
+}
+
+func TestRelayFlushMarksDeadAfterMaxAttempts(t *testing.T) {
+	store := newMemoryStore(outbox.Event{ID: "e1", Type: "alumni.created"})
+	relay := outbox.NewRelay("test", store, []outbox.Sink{&flakySink{failures: 10}}, 10, 2, 0)
+
+	for i := 0; i < 2; i++ {
+		store.records["e1"].next = time.Time{}
+		if _, err := relay.Flush(context.Background()); err != nil {
+			t.Fatalf("flush error %v", err)
+		}
+	}
+	if stored := store.records["e1"]; stored.status != outbox.StatusDead || stored.attempts != 2 {
+		t.Fatalf("event not dead-lettered: %+v", stored)
+	}
+}
+
+func TestRelayStartAndShutdown(t *testing.T) {
+	store := newMemoryStore(outbox.Event{ID: "e1", Type: "alumni.created"})
+	sink := &flakySink{}
+	relay := outbox.NewRelay("test", store, []outbox.Sink{sink}, 10, 3, 0)
+	relay.Start(10 * time.Millisecond)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		store.mu.Lock()
+		status := store.records["e1"].status
+		store.mu.Unlock()
+		if status == outbox.StatusPublished {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("relay did not publish the event")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := relay.Shutdown(ctx); err != nil {
+		t.Fatalf("shutdown error %v", err)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 4: 8 * time.Second, 30: time.Hour}
+	for attempts, want := range tests {
+		if got := outbox.Backoff(attempts); got != want {
+			t.Errorf("Backoff(%d) got %v want %v", attempts, got, want)
+		}
+	}
+}
+
+func TestWebhookSinkSignsBody(t *testing.T) {
+	var header http.Header
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sink := outbox.NewWebhookSink(server.URL, "rahasia")
+	if err := sink.Publish(context.Background(), outbox.Event{ID: "e1", Type: "alumni.created"}); err != nil {
+		t.Fatalf("publish error %v", err)
+	}
+	if header.Get(outbox.HeaderEventID) != "e1" || header.Get(outbox.HeaderEventType) != "alumni.created" {
+		t.Fatalf("unexpected headers %v", header)
+	}
+	timestamp, err := strconv.ParseInt(header.Get(outbox.HeaderTimestamp), 10, 64)
+	if err != nil {
+		t.Fatalf("invalid timestamp header %q", header.Get(outbox.HeaderTimestamp))
+	}
+	if want := outbox.Sign("rahasia", timestamp, body); header.Get(outbox.HeaderSignature) != want {
+		t.Fatalf("signature got %q want %q", header.Get(outbox.HeaderSignature), want)
+	}
+}
+
+func TestWebhookSinkRejectsNon2xx(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	err := outbox.NewWebhookSink(server.URL, "").Publish(context.Background(), outbox.Event{ID: "e1"})
+	if err == nil || !strings.Contains(err.Error(), "503") {
+		t.Fatalf("expected 503 error, got %v", err)
+	}
+}
+
+func TestKafkaSink(t *testing.T) {
+	var path, contentType string
+	var request struct {
+		Records []struct {
+			Key   string       `json:"key"`
+			Value outbox.Event `json:"value"`
+		} `json:"records"`
+	}
+	response := `{"offsets":[{"partition":0,"offset":1,"error_code":null,"error":null}]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, contentType = r.URL.Path, r.Header.Get("Content-Type")
+		json.NewDecoder(r.Body).Decode(&request)
+		w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	sink := outbox.NewKafkaSink(server.URL+"/", "alumni-events")
+	event := outbox.Event{ID: "e1", Type: "alumni.created", Resource: "alumni", ResourceID: "7"}
+	if err := sink.Publish(context.Background(), event); err != nil {
+		t.Fatalf("publish error %v", err)
+	}
+	if path != "/topics/alumni-events" || contentType != "application/vnd.kafka.json.v2+json" {
+		t.Fatalf("unexpected request %s %s", path, contentType)
+	}
+	if len(request.Records) != 1 || request.Records[0].Key != "alumni:7" || request.Records[0].Value.ID != "e1" {
+		t.Fatalf("unexpected records %+v", request.Records)
+	}
+
+	response = `{"offsets":[{"partition":null,"offset":null,"error_code":40403,"error":"topic tidak ada"}]}`
+	if err := sink.Publish(context.Background(), event); err == nil || !strings.Contains(err.Error(), "40403") {
+		t.Fatalf("expected record error, got %v", err)
+	}
+}
+
+// fakeNATS menerima satu koneksi, membalas PING dengan PONG, dan mencatat
+// subject dan header dari HPUB.
+func fakeNATS(t *testing.T, publishErr string) (string, <-chan string) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen error %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	published := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		conn.Write([]byte(`INFO {"server_id":"test","headers":true}` + "\r\n"))
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			fields := strings.Fields(line)
+			switch {
+			case len(fields) == 0:
+			case fields[0] == "PING":
+				conn.Write([]byte("PONG\r\n"))
+			case fields[0] == "HPUB" && len(fields) == 4:
+				headerLen, _ := strconv.Atoi(fields[2])
+				total, _ := strconv.Atoi(fields[3])
+				message := make([]byte, total+2)
+				io.ReadFull(reader, message)
+				published <- fields[1] + "\n" + string(message[:headerLen])
+				if publishErr != "" {
+					conn.Write([]byte("-ERR '" + publishErr + "'\r\n"))
+				}
+			}
+		}
+	}()
+	return listener.Addr().String(), published
+}
+
+func TestNATSSinkPublishesWithMsgID(t *testing.T) {
+	addr, published := fakeNATS(t, "")
+	sink, err := outbox.NewNATSSink("nats://"+addr, "go-fiber.events")
+	if err != nil {
+		t.Fatalf("new sink error %v", err)
+	}
+	defer sink.Close()
+
+	if err := sink.Publish(context.Background(), outbox.Event{ID: "e1", Type: "alumni.created"}); err != nil {
+		t.Fatalf("publish error %v", err)
+	}
+	got := <-published
+	if !strings.HasPrefix(got, "go-fiber.events.alumni.created\n") || !strings.Contains(got, "Nats-Msg-Id: e1") {
+		t.Fatalf("unexpected message %q", got)
+	}
+}
+
+func TestNATSSinkReturnsServerError(t *testing.T) {
+	addr, _ := fakeNATS(t, "Permissions Violation for Publish")
+	sink, err := outbox.NewNATSSink("nats://"+addr, "")
+	if err != nil {
+		t.Fatalf("new sink error %v", err)
+	}
+	defer sink.Close()
+
+	err = sink.Publish(context.Background(), outbox.Event{ID: "e1", Type: "alumni.created"})
+	if err == nil || !strings.Contains(err.Error(), "Permissions Violation") {
+		t.Fatalf("expected server error, got %v", err)
+	}
+}
+
+func TestNewNATSSinkRejectsScheme(t *testing.T) {
+	if _, err := outbox.NewNATSSink("http://localhost:4222", ""); err == nil {
+		t.Fatal("expected scheme error")
+	}
+}
@@ -0,0 +1,166 @@
+package patch_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go-fiber/apperror"
+	"go-fiber/patch"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type document struct {
+	Nama   string   `json:"nama"`
+	Alamat *string  `json:"alamat"`
+	Tags   []string `json:"tags"`
+	Meta   *meta    `json:"meta"`
+}
+
+type meta struct {
+	Kota string `json:"kota"`
+	Kode int    `json:"kode"`
+}
+
+func strPtr(s string) *string { return &s }
+
+func current() document {
+	return document{Nama: "Budi", Alamat: strPtr("Jl. Lama"), Tags: []string{"a", "b"}, Meta: &meta{Kota: "Malang", Kode: 65}}
+}
+
+// apply menjalankan patch.Apply pada current melalui request fiber.
+func apply(t *testing.T, contentType, body string) (document, []string, error) {
+	t.Helper()
+	var out document
+	var touched []string
+	var applyErr error
+	app := fiber.New()
+	app.Patch("/", func(c *fiber.Ctx) error {
+		touched, applyErr = patch.Apply(c, current(), &out)
+		return nil
+	})
+	req := httptest.NewRequest("PATCH", "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	if _, err := app.Test(req); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	return out, touched, applyErr
+}
+
+func errorCode(err error) string {
+	var appErr *apperror.Error
+	if errors.As(err, &appErr) {
+		return appErr.Code
+	}
+	return ""
+}
+
+func TestMergePatch(t *testing.T) {
+	out, touched, err := apply(t, patch.MergePatchType, `{"nama":"Ani","alamat":null,"meta":{"kode":66}}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := document{Nama: "Ani", Tags: []string{"a", "b"}, Meta: &meta{Kota: "Malang", Kode: 66}}
+	if !reflect.DeepEqual(out, want) {
+		t.Fatalf("got %+v want %+v", out, want)
+	}
+	if !reflect.DeepEqual(touched, []string{"alamat", "meta", "nama"}) {
+		t.Fatalf("touched got %v", touched)
+	}
+}
+
+func TestMergePatchCharsetAndPlainJSON(t *testing.T) {
+	for _, contentType := range []string{"application/merge-patch+json; charset=utf-8", "application/json"} {
+		out, _, err := apply(t, contentType, `{"tags":["c"]}`)
+		if err != nil || !reflect.DeepEqual(out.Tags, []string{"c"}) {
+			t.Fatalf("%s: got %+v, %v", contentType, out, err)
+		}
+	}
+}
+
+func TestJSONPatch(t *testing.T) {
+	body := `[
+		{"op":"test","path":"/nama","value":"Budi"},
+		{"op":"replace","path":"/nama","value":"Ani"},
+		{"op":"add","path":"/tags/1","value":"x"},
+		{"op":"add","path":"/tags/-","value":"z"},
+		{"op":"remove","path":"/tags/0"},
+		{"op":"copy","from":"/meta/kota","path":"/alamat"},
+		{"op":"move","from":"/meta/kode","path":"/meta/kota"}
+	]`
+	_, _, err := apply(t, patch.JSONPatchType, body)
+	if err == nil {
+		t.Fatalf("moving a number into a string field should fail decoding")
+	}
+	if errorCode(err) != "invalid_body" {
+		t.Fatalf("code got %q (%v)", errorCode(err), err)
+	}
+
+	body = strings.Replace(body, `{"op":"move","from":"/meta/kode","path":"/meta/kota"}`, `{"op":"remove","path":"/meta/kode"}`, 1)
+	out, touched, err := apply(t, patch.JSONPatchType, body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := document{Nama: "Ani", Alamat: strPtr("Malang"), Tags: []string{"x", "b", "z"}, Meta: &meta{Kota: "Malang"}}
+	if !reflect.DeepEqual(out, want) {
+		t.Fatalf("got %+v want %+v", out, want)
+	}
+	if !reflect.DeepEqual(touched, []string{"alamat", "meta", "nama", "tags"}) {
+		t.Fatalf("touched got %v", touched)
+	}
+}
+
+func TestJSONPatchErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		code string
+	}{
+		{"not an array", `{"op":"remove","path":"/nama"}`, "invalid_patch"},
+		{"test failed", `[{"op":"test","path":"/nama","value":"Ani"}]`, "patch_test_failed"},
+		{"missing path", `[{"op":"remove","path":"/meta/negara"}]`, "invalid_patch"},
+		{"bad index", `[{"op":"add","path":"/tags/5","value":"x"}]`, "invalid_patch"},
+		{"value required", `[{"op":"replace","path":"/nama"}]`, "invalid_patch"},
+		{"unknown op", `[{"op":"merge","path":"/nama","value":"Ani"}]`, "invalid_patch"},
+		{"whole document", `[{"op":"replace","path":"","value":{}}]`, "invalid_patch"},
+		{"move into child", `[{"op":"move","from":"/meta","path":"/meta/lain"}]`, "invalid_patch"},
+		{"unknown field", `[{"op":"add","path":"/nim","value":"1"}]`, "unknown_field"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := apply(t, patch.JSONPatchType, tt.body)
+			if errorCode(err) != tt.code {
+				t.Fatalf("code got %q want %q (%v)", errorCode(err), tt.code, err)
+			}
+		})
+	}
+}
+
+func TestApplyRejectsOtherContentTypes(t *testing.T) {
+	_, _, err := apply(t, "text/plain", `nama=Ani`)
+	var appErr *apperror.Error
+	if !errors.As(err, &appErr) || appErr.Status != fiber.StatusUnsupportedMediaType || appErr.Code != "unsupported_media_type" {
+		t.Fatalf("got %v", err)
+	}
+
+	_, _, err = apply(t, patch.MergePatchType, `{"nim":"1"}`)
+	if errorCode(err) != "unknown_field" {
+		t.Fatalf("code got %q", errorCode(err))
+	}
+}
+
+func TestValues(t *testing.T) {
+	doc := document{Nama: "Ani", Meta: &meta{Kota: "Malang"}}
+	got := patch.Values(&doc, []string{"nama", "alamat", "meta"})
+	raw, _ := json.Marshal(got)
+	if string(raw) != `{"alamat":null,"meta":{"kota":"Malang","kode":0},"nama":"Ani"}` {
+		t.Fatalf("got %s", raw)
+	}
+	if !patch.Touches([]string{"nama", "gaji_min"}, "gaji_max", "gaji_min") || patch.Touches(nil, "nama") {
+		t.Fatalf("Touches result mismatch")
+	}
+}
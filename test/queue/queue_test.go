@@ -0,0 +1,416 @@
+package queue_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-fiber/queue"
+)
+
+// memoryStore adalah queue.Store in-memory dengan aturan yang sama seperti
+// store database: unique key tidak boleh ganda dan claim hanya mengambil job
+// yang sudah waktunya atau yang lease-nya habis.
+type memoryStore struct {
+	mu     sync.Mutex
+	jobs   map[string]*queue.Job
+	nextID int
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{jobs: map[string]*queue.Job{}}
+}
+
+func (s *memoryStore) Enqueue(ctx context.Context, job *queue.Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if job.UniqueKey != "" {
+		for _, existing := range s.jobs {
+			if existing.UniqueKey == job.UniqueKey {
+				return queue.ErrDuplicate
+			}
+		}
+	}
+	s.nextID++
+	job.ID = strconv.Itoa(s.nextID)
+	job.Status = queue.StatusPending
+	job.CreatedAt = time.Now()
+	copied := *job
+	s.jobs[job.ID] = &copied
+	return nil
+}
+
+func (s *memoryStore) Claim(ctx context.Context, jobType string, limit int, lease time.Duration) ([]queue.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	var ready []*queue.Job
+	for _, job := range s.jobs {
+		if job.Type != jobType {
+			continue
+		}
+		due := job.Status == queue.StatusPending && !job.RunAt.After(now)
+		expired := job.Status == queue.StatusRunning && job.LockedUntil.Before(now)
+		if due || expired {
+			ready = append(ready, job)
+		}
+	}
+	sort.Slice(ready, func(i, j int) bool { return ready[i].RunAt.Before(ready[j].RunAt) })
+
+	claimed := []queue.Job{}
+	for _, job := range ready {
+		if len(claimed) == limit {
+			break
+		}
+		lockedUntil := now.Add(lease)
+		job.Status = queue.StatusRunning
+		job.Attempts++
+		job.StartedAt = &now
+		job.LockedUntil = &lockedUntil
+		claimed = append(claimed, *job)
+	}
+	return claimed, nil
+}
+
+func (s *memoryStore) Complete(ctx context.Context, id string, attempt int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job := s.jobs[id]
+	if job == nil || job.Status != queue.StatusRunning || job.Attempts != attempt {
+		return queue.ErrLeaseLost
+	}
+	now := time.Now()
+	job.Status = queue.StatusSucceeded
+	job.LastError = ""
+	job.LockedUntil = nil
+	job.FinishedAt = &now
+	return nil
+}
+
+func (s *memoryStore) Fail(ctx context.Context, id string, attempt int, lastError string, next time.Time, final bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job := s.jobs[id]
+	if job == nil || job.Status != queue.StatusRunning || job.Attempts != attempt {
+		return queue.ErrLeaseLost
+	}
+	job.Status = queue.StatusPending
+	job.LastError = lastError
+	job.RunAt = next
+	job.LockedUntil = nil
+	if final {
+		now := time.Now()
+		job.Status = queue.StatusFailed
+		job.FinishedAt = &now
+	}
+	return nil
+}
+
+// expireLease mensimulasikan lease yang habis selagi handler masih berjalan.
+func (s *memoryStore) expireLease(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	past := time.Now().Add(-time.Second)
+	s.jobs[id].LockedUntil = &past
+}
+
+func (s *memoryStore) List(ctx context.Context, filter queue.Filter) ([]queue.Job, int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	jobs := []queue.Job{}
+	for _, job := range s.jobs {
+		if (filter.Type == "" || job.Type == filter.Type) && (filter.Status == "" || job.Status == filter.Status) {
+			jobs = append(jobs, *job)
+		}
+	}
+	return jobs, int64(len(jobs)), nil
+}
+
+func (s *memoryStore) Get(ctx context.Context, id string) (*queue.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, queue.ErrNotFound
+	}
+	copied := *job
+	return &copied, nil
+}
+
+func (s *memoryStore) Retry(ctx context.Context, id string) (*queue.Job, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *memoryStore) Cancel(ctx context.Context, id string) (*queue.Job, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *memoryStore) Purge(ctx context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var deleted int64
+	for id, job := range s.jobs {
+		finished := job.Status == queue.StatusSucceeded || job.Status == queue.StatusCancelled
+		if finished && job.FinishedAt.Before(before) {
+			delete(s.jobs, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// makeDue memindahkan job yang menunggu backoff ke sekarang.
+func (s *memoryStore) makeDue(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[id].RunAt = time.Now()
+}
+
+func newQueue(t *testing.T, store queue.Store, options queue.Options) *queue.Queue {
+	t.Helper()
+	q, err := queue.New("memory", store, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return q
+}
+
+type emailPayload struct {
+	To string `json:"to"`
+}
+
+func TestParseCronNext(t *testing.T) {
+	from := time.Date(2024, 1, 6, 10, 7, 30, 0, time.UTC) // Sabtu
+	cases := []struct {
+		spec string
+		want time.Time
+	}{
+		{"*/15 * * * *", time.Date(2024, 1, 6, 10, 15, 0, 0, time.UTC)},
+		{"0 9 * * 1-5", time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC)},
+		{"30 8 1,15 * *", time.Date(2024, 1, 15, 8, 30, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)},
+		{"0 0 13 * 5", time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)},
+		{"0 3 * 2 *", time.Date(2024, 2, 1, 3, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2024, 1, 6, 11, 0, 0, 0, time.UTC)},
+		{"@every 20m", time.Date(2024, 1, 6, 10, 20, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+	for _, tc := range cases {
+		cron, err := queue.ParseCron(tc.spec)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.spec, err)
+		}
+		if got := cron.Next(from); !got.Equal(tc.want) {
+			t.Errorf("%s: got %v want %v", tc.spec, got, tc.want)
+		}
+	}
+}
+
+func TestParseCronRejectsInvalidSpec(t *testing.T) {
+	for _, spec := range []string{"* * * *", "60 * * * *", "*/0 * * * *", "5-1 * * * *", "0 0 * * 8", "@every 10ms", "@often"} {
+		if _, err := queue.ParseCron(spec); err == nil {
+			t.Errorf("%q: expected error", spec)
+		}
+	}
+}
+
+func TestQueueRunsRegisteredHandler(t *testing.T) {
+	store := newMemoryStore()
+	q := newQueue(t, store, queue.Options{Concurrency: 4, MaxAttempts: 3, Timeout: time.Second})
+	var got []string
+	queue.Register(q, "email.send", func(ctx context.Context, payload emailPayload) error {
+		got = append(got, payload.To)
+		return nil
+	}, queue.HandlerOptions{Concurrency: 1})
+
+	job, err := queue.Enqueue(context.Background(), q.Store(), "email.send", emailPayload{To: "a@example.com"}, queue.EnqueueOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claimed, err := q.Flush(context.Background()); err != nil || claimed != 1 {
+		t.Fatalf("claimed %d, err %v", claimed, err)
+	}
+	if len(got) != 1 || got[0] != "a@example.com" {
+		t.Fatalf("handler got %v", got)
+	}
+	if stored, _ := store.Get(context.Background(), job.ID); stored.Status != queue.StatusSucceeded || stored.Attempts != 1 {
+		t.Fatalf("job %+v", stored)
+	}
+}
+
+func TestQueueDropsResultAfterLeaseIsLost(t *testing.T) {
+	store := newMemoryStore()
+	q := newQueue(t, store, queue.Options{Concurrency: 4, MaxAttempts: 3, Timeout: time.Second})
+	var reclaimed []queue.Job
+	queue.Register(q, "email.send", func(ctx context.Context, payload emailPayload) error {
+		// Lease habis dan worker lain mengambil job ini sebelum handler selesai.
+		jobs, _, _ := store.List(ctx, queue.Filter{Type: "email.send"})
+		store.expireLease(jobs[0].ID)
+		reclaimed, _ = store.Claim(ctx, "email.send", 1, time.Minute)
+		return nil
+	}, queue.HandlerOptions{Concurrency: 1})
+
+	job, err := queue.Enqueue(context.Background(), q.Store(), "email.send", emailPayload{To: "a@example.com"}, queue.EnqueueOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claimed, err := q.Flush(context.Background()); err != nil || claimed != 1 {
+		t.Fatalf("claimed %d, err %v", claimed, err)
+	}
+	if len(reclaimed) != 1 || reclaimed[0].Attempts != 2 {
+		t.Fatalf("reclaimed %+v", reclaimed)
+	}
+	stored, _ := store.Get(context.Background(), job.ID)
+	if stored.Status != queue.StatusRunning || stored.Attempts != 2 {
+		t.Fatalf("stale attempt must not finish the job: %+v", stored)
+	}
+	if err := store.Complete(context.Background(), job.ID, 1); !errors.Is(err, queue.ErrLeaseLost) {
+		t.Fatalf("Complete with stale attempt err = %v, want ErrLeaseLost", err)
+	}
+	if err := store.Complete(context.Background(), job.ID, 2); err != nil {
+		t.Fatalf("Complete with current attempt: %v", err)
+	}
+}
+
+func TestQueueRetriesWithBackoffThenFails(t *testing.T) {
+	store := newMemoryStore()
+	q := newQueue(t, store, queue.Options{Concurrency: 4, MaxAttempts: 3, Timeout: time.Second})
+	queue.Register(q, "export.build", func(ctx context.Context, payload struct{}) error {
+		return errors.New("disk penuh")
+	}, queue.HandlerOptions{MaxAttempts: 2})
+
+	job, _ := queue.Enqueue(context.Background(), q.Store(), "export.build", struct{}{}, queue.EnqueueOptions{})
+	q.Flush(context.Background())
+	stored, _ := store.Get(context.Background(), job.ID)
+	if stored.Status != queue.StatusPending || stored.LastError != "disk penuh" || !stored.RunAt.After(time.Now()) {
+		t.Fatalf("first failure must be retried later: %+v", stored)
+	}
+	if claimed, _ := q.Flush(context.Background()); claimed != 0 {
+		t.Fatalf("job must wait for backoff, claimed %d", claimed)
+	}
+
+	store.makeDue(job.ID)
+	q.Flush(context.Background())
+	stored, _ = store.Get(context.Background(), job.ID)
+	if stored.Status != queue.StatusFailed || stored.Attempts != 2 || stored.FinishedAt == nil {
+		t.Fatalf("job must fail after max attempts: %+v", stored)
+	}
+}
+
+func TestQueueDoesNotRetryPermanentErrors(t *testing.T) {
+	store := newMemoryStore()
+	q := newQueue(t, store, queue.Options{Concurrency: 4, MaxAttempts: 3, Timeout: time.Second})
+	queue.Register(q, "email.send", func(ctx context.Context, payload emailPayload) error {
+		if payload.To == "" {
+			return queue.Permanent(errors.New("alamat kosong"))
+		}
+		panic("smtp down")
+	}, queue.HandlerOptions{})
+
+	permanent, _ := queue.Enqueue(context.Background(), q.Store(), "email.send", emailPayload{}, queue.EnqueueOptions{})
+	invalid, _ := queue.Enqueue(context.Background(), q.Store(), "email.send", []int{1}, queue.EnqueueOptions{})
+	panicked, _ := queue.Enqueue(context.Background(), q.Store(), "email.send", emailPayload{To: "a@example.com"}, queue.EnqueueOptions{})
+	q.Flush(context.Background())
+
+	for _, id := range []string{permanent.ID, invalid.ID} {
+		if stored, _ := store.Get(context.Background(), id); stored.Status != queue.StatusFailed || stored.Attempts != 1 {
+			t.Errorf("job must fail without retry: %+v", stored)
+		}
+	}
+	if stored, _ := store.Get(context.Background(), panicked.ID); stored.Status != queue.StatusPending || stored.LastError != "panic: smtp down" {
+		t.Errorf("panic must be retried like an error: %+v", stored)
+	}
+}
+
+func TestQueueLimitsConcurrency(t *testing.T) {
+	store := newMemoryStore()
+	q := newQueue(t, store, queue.Options{Concurrency: 3, MaxAttempts: 1, Timeout: time.Second})
+	var running, peak atomic.Int32
+	handler := func(ctx context.Context, payload struct{}) error {
+		if current := running.Add(1); current > peak.Load() {
+			peak.Store(current)
+		}
+		time.Sleep(20 * time.Millisecond)
+		running.Add(-1)
+		return nil
+	}
+	queue.Register(q, "thumbnail.generate", handler, queue.HandlerOptions{Concurrency: 2})
+	queue.Register(q, "trash.purge", handler, queue.HandlerOptions{})
+
+	for i := 0; i < 5; i++ {
+		queue.Enqueue(context.Background(), q.Store(), "thumbnail.generate", struct{}{}, queue.EnqueueOptions{})
+		queue.Enqueue(context.Background(), q.Store(), "trash.purge", struct{}{}, queue.EnqueueOptions{})
+	}
+
+	// Global 3: thumbnail.generate dibatasi 2, sisanya untuk trash.purge.
+	if claimed, _ := q.Flush(context.Background()); claimed != 3 {
+		t.Fatalf("claimed %d want 3", claimed)
+	}
+	if peak.Load() > 3 {
+		t.Fatalf("peak concurrency %d exceeds limit", peak.Load())
+	}
+	thumbnails, _, _ := store.List(context.Background(), queue.Filter{Type: "thumbnail.generate", Status: queue.StatusSucceeded})
+	if len(thumbnails) != 2 {
+		t.Fatalf("thumbnail.generate ran %d jobs want 2", len(thumbnails))
+	}
+}
+
+func TestQueueScheduleCreatesOneJobPerRun(t *testing.T) {
+	store := newMemoryStore()
+	instances := []*queue.Queue{newQueue(t, store, queue.Options{Concurrency: 4, MaxAttempts: 3, Timeout: time.Second}), newQueue(t, store, queue.Options{Concurrency: 4, MaxAttempts: 3, Timeout: time.Second})}
+	for _, q := range instances {
+		if err := q.Schedule("nightly-export", "0 2 * * *", "export.build", map[string]string{"resource": "alumni"}); err != nil {
+			t.Fatal(err)
+		}
+		q.Flush(context.Background())
+		q.Flush(context.Background())
+	}
+
+	jobs, _, _ := store.List(context.Background(), queue.Filter{})
+	if len(jobs) != 1 {
+		t.Fatalf("got %d scheduled jobs want 1", len(jobs))
+	}
+	job := jobs[0]
+	if job.Type != "export.build" || job.RunAt.Hour() != 2 || job.RunAt.Minute() != 0 || string(job.Payload) != `{"resource":"alumni"}` {
+		t.Fatalf("scheduled job %+v", job)
+	}
+
+	schedules := instances[0].Schedules()
+	if len(schedules) != 1 || schedules[0].Name != "nightly-export" || !schedules[0].NextRunAt.Equal(job.RunAt) {
+		t.Fatalf("schedules %+v", schedules)
+	}
+	if err := instances[0].Schedule("nightly-export", "@daily", "export.build", nil); err == nil {
+		t.Fatal("duplicate schedule name must be rejected")
+	}
+	if err := instances[0].Schedule("never", "0 0 30 2 *", "export.build", nil); err == nil {
+		t.Fatal("schedule that never runs must be rejected")
+	}
+}
+
+func TestQueuePurgesFinishedJobs(t *testing.T) {
+	store := newMemoryStore()
+	q := newQueue(t, store, queue.Options{Concurrency: 1, MaxAttempts: 1, Timeout: time.Second, Retention: time.Hour})
+	if schedules := q.Schedules(); len(schedules) != 1 || schedules[0].Type != queue.PurgeJobType {
+		t.Fatalf("purge must be scheduled: %+v", schedules)
+	}
+
+	old := time.Now().Add(-2 * time.Hour)
+	for _, status := range []string{queue.StatusSucceeded, queue.StatusFailed} {
+		job := &queue.Job{Type: "email.send"}
+		store.Enqueue(context.Background(), job)
+		store.jobs[job.ID].Status = status
+		store.jobs[job.ID].FinishedAt = &old
+	}
+	queue.Enqueue(context.Background(), q.Store(), queue.PurgeJobType, struct{}{}, queue.EnqueueOptions{})
+	q.Flush(context.Background())
+
+	remaining, _, _ := store.List(context.Background(), queue.Filter{Type: "email.send"})
+	if len(remaining) != 1 || remaining[0].Status != queue.StatusFailed {
+		t.Fatalf("only old succeeded jobs must be purged, remaining %+v", remaining)
+	}
+}
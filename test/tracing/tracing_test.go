@@ -0,0 +1,130 @@
+package tracing_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"go-fiber/tracing"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const parentTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func setupRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+	return recorder
+}
+
+func TestMiddlewareContinuesTraceparent(t *testing.T) {
+	recorder := setupRecorder(t)
+
+	app := fiber.New()
+	app.Use(tracing.Middleware)
+	app.Get("/alumni/:id", func(c *fiber.Ctx) error {
+		_, span := tracing.StartPostgres(c.UserContext(), "GetAlumniByID")
+		span.End()
+		return c.SendString("ok")
+	})
+
+	req := httptest.NewRequest("GET", "/alumni/7", nil)
+	req.Header.Set("traceparent", parentTraceparent)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp.Header.Get("traceparent") == "" {
+		t.Error("response traceparent header is empty")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("ended spans = %d, want 2", len(spans))
+	}
+	repoSpan, serverSpan := spans[0], spans[1]
+
+	if serverSpan.Name() != "GET /alumni/:id" {
+		t.Errorf("server span name = %q", serverSpan.Name())
+	}
+	if serverSpan.SpanKind() != trace.SpanKindServer {
+		t.Errorf("server span kind = %v", serverSpan.SpanKind())
+	}
+	if got := serverSpan.SpanContext().TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("server span trace id = %s, want trace id from traceparent", got)
+	}
+	if got := serverSpan.Parent().SpanID().String(); got != "00f067aa0ba902b7" {
+		t.Errorf("server span parent = %s", got)
+	}
+
+	if repoSpan.Name() != "postgre.GetAlumniByID" {
+		t.Errorf("repository span name = %q", repoSpan.Name())
+	}
+	if repoSpan.Parent().SpanID() != serverSpan.SpanContext().SpanID() {
+		t.Error("repository span is not a child of the server span")
+	}
+}
+
+func TestMiddlewareMarksServerErrors(t *testing.T) {
+	recorder := setupRecorder(t)
+
+	app := fiber.New()
+	app.Use(tracing.Middleware)
+	app.Get("/boom", func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusInternalServerError).SendString("boom")
+	})
+
+	if _, err := app.Test(httptest.NewRequest("GET", "/boom", nil)); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("ended spans = %d, want 1", len(spans))
+	}
+	if spans[0].Status().Code != codes.Error {
+		t.Errorf("status = %v, want Error", spans[0].Status().Code)
+	}
+}
+
+func TestErrorIgnoresNotFound(t *testing.T) {
+	recorder := setupRecorder(t)
+
+	_, span := tracing.StartMongo(context.Background(), "AlumniRepository.FindAlumniByID")
+	if err := tracing.Error(span, sql.ErrNoRows); err != sql.ErrNoRows {
+		t.Errorf("Error returned %v", err)
+	}
+	span.End()
+
+	_, span = tracing.StartMongo(context.Background(), "AlumniRepository.UpdateAlumni")
+	tracing.Error(span, errors.New("connection reset"))
+	span.End()
+
+	spans := recorder.Ended()
+	if spans[0].Status().Code == codes.Error {
+		t.Error("not found should not mark the span as error")
+	}
+	if spans[1].Status().Code != codes.Error {
+		t.Error("driver error should mark the span as error")
+	}
+}
+
+func TestInitRejectsUnknownExporter(t *testing.T) {
+	t.Setenv("OTEL_TRACES_EXPORTER", "zipkin")
+
+	if _, err := tracing.Init(context.Background()); err == nil {
+		t.Error("expected error for unknown exporter")
+	}
+}
@@ -1,19 +1,19 @@
 package utils_test
 
 import (
-	"os"
 	"testing"
 
 	utils "go-fiber/utils/mongo"
 )
 
+const testSecret = "secret-for-test-32-chars-minimum-123"
+
 func TestGenerateAndValidateToken(t *testing.T) {
-	os.Setenv("JWT_SECRET", "secret-for-test-32-chars-minimum-123")
-	token, err := utils.GenerateToken(utils.AlumniToken{ID: "507f1f77bcf86cd799439011", Email: "a@a.com", Role: "admin"})
+	token, err := utils.GenerateToken(testSecret, utils.AlumniToken{ID: "507f1f77bcf86cd799439011", Email: "a@a.com", Role: "admin"})
 	if err != nil {
 		t.Fatalf("GenerateToken error %v", err)
 	}
-	claims, err := utils.ValidateToken(token)
+	claims, err := utils.ValidateToken(testSecret, token)
 	if err != nil {
 		t.Fatalf("ValidateToken error %v", err)
 	}
@@ -26,6 +26,9 @@ func TestGenerateAndValidateToken(t *testing.T) {
 	if claims.Role != "admin" {
 		t.Fatalf("Role got %s want %s", claims.Role, "admin")
 	}
+	if _, err := utils.ValidateToken("another-secret-32-chars-minimum-456", token); err == nil {
+		t.Fatal("ValidateToken accepted a token signed with another secret")
+	}
 }
 
 func TestExtractTokenFromHeader(t *testing.T) {
@@ -0,0 +1,179 @@
+package validation_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	modelmongo "go-fiber/app/model/mongo"
+	modelpostgre "go-fiber/app/model/postgre"
+	"go-fiber/apperror"
+	"go-fiber/i18n"
+	"go-fiber/validation"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func strPtr(s string) *string { return &s }
+
+func rules(errs []validation.FieldError) map[string]string {
+	result := map[string]string{}
+	for _, fieldErr := range errs {
+		result[fieldErr.Field] = fieldErr.Rule
+	}
+	return result
+}
+
+func validAlumni() modelpostgre.CreateAlumniRequest {
+	return modelpostgre.CreateAlumniRequest{
+		NIM:        "21.11.4001",
+		Nama:       "Budi",
+		Jurusan:    "TI",
+		Angkatan:   2021,
+		TahunLulus: 2025,
+		Email:      "budi@example.com",
+		Password:   "rahasia123",
+		NoTelepon:  strPtr("0812-3456-7890"),
+		RoleID:     2,
+	}
+}
+
+func TestStructAcceptsValidRequest(t *testing.T) {
+	if err := validation.Struct(i18n.Default, validAlumni()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestFieldsReportsEveryRule(t *testing.T) {
+	req := validAlumni()
+	req.NIM = "21/11/4001"
+	req.Nama = ""
+	req.Email = "bukan-email"
+	req.TahunLulus = 2019
+	req.NoTelepon = strPtr("12345")
+	req.RoleID = 3
+
+	got := rules(validation.Fields(i18n.Default, req))
+	want := map[string]string{
+		"nim":         "nim",
+		"nama":        "required",
+		"email":       "email",
+		"tahun_lulus": "gtefield",
+		"no_telepon":  "phone",
+		"role_id":     "oneof",
+	}
+	for field, rule := range want {
+		if got[field] != rule {
+			t.Errorf("%s: rule = %q, want %q (all: %v)", field, got[field], rule, got)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("unexpected fields: %v", got)
+	}
+}
+
+func TestFieldsUsesJSONNamesInMessages(t *testing.T) {
+	req := validAlumni()
+	req.TahunLulus = 2019
+
+	errs := validation.Fields(i18n.Default, req)
+	if len(errs) != 1 || errs[0].Message != "tahun_lulus tidak boleh lebih kecil dari angkatan" {
+		t.Fatalf("unexpected errors: %+v", errs)
+	}
+}
+
+func TestPekerjaanDates(t *testing.T) {
+	req := modelmongo.CreatePekerjaanAlumniRequest{
+		AlumniInfo:          modelmongo.AlumniInfo{NIM: "2021001", Nama: "Budi", Email: "budi"},
+		NamaPerusahaan:      "PT Maju",
+		PosisiJabatan:       "Engineer",
+		BidangIndustri:      "IT",
+		LokasiKerja:         "Jakarta",
+		TanggalMulaiKerja:   "2025-03-01",
+		TanggalSelesaiKerja: strPtr("2025-02-01"),
+		StatusPekerjaan:     "selesai",
+	}
+
+	got := rules(validation.Fields(i18n.Default, req))
+	if got["tanggal_selesai_kerja"] != "after" || got["alumni_info.email"] != "email" || len(got) != 2 {
+		t.Fatalf("unexpected errors: %v", got)
+	}
+
+	req.AlumniInfo.Email = "budi@example.com"
+	req.TanggalSelesaiKerja = strPtr("")
+	if errs := validation.Fields(i18n.Default, req); len(errs) != 0 {
+		t.Fatalf("empty optional date should be skipped: %+v", errs)
+	}
+
+	req.TanggalMulaiKerja = "01-03-2025"
+	if got := rules(validation.Fields(i18n.Default, req)); got["tanggal_mulai_kerja"] != "date" || len(got) != 1 {
+		t.Fatalf("unexpected errors: %v", got)
+	}
+}
+
+func TestFieldsExceptSkipsField(t *testing.T) {
+	req := validAlumni()
+	req.Password = ""
+	if errs := validation.FieldsExcept(i18n.Default, req, "Password"); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %+v", errs)
+	}
+	if errs := validation.Fields(i18n.Default, req); len(errs) != 1 {
+		t.Fatalf("password should be required: %+v", errs)
+	}
+}
+
+func TestParseBodyReturnsFieldList(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Post("/", func(c *fiber.Ctx) error {
+		var req modelpostgre.CreateRoleRequest
+		return validation.ParseBody(c, &req)
+	})
+
+	req := httptest.NewRequest("POST", "/", strings.NewReader(`{"nama":""}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Fatalf("status = %d", resp.StatusCode)
+	}
+
+	var problem struct {
+		Code    string                  `json:"code"`
+		Details []validation.FieldError `json:"details"`
+	}
+	json.NewDecoder(resp.Body).Decode(&problem)
+	if problem.Code != "validation_failed" || len(problem.Details) != 1 || problem.Details[0].Field != "nama" {
+		t.Fatalf("unexpected problem: %+v", problem)
+	}
+
+	if err := validation.Struct(i18n.Default, modelpostgre.CreateRoleRequest{}); !errors.Is(err, apperror.ErrValidation) {
+		t.Errorf("Struct error = %v, want validation error", err)
+	}
+}
+
+func TestPartialOnlyReportsTouchedFields(t *testing.T) {
+	req := modelmongo.UpdateAlumniRequest{Nama: "Budi", Angkatan: 2021, TahunLulus: 2019, Email: "bukan-email", Role: "user"}
+
+	if err := validation.Partial(i18n.Default, req, []string{"nama"}); err != nil {
+		t.Fatalf("untouched fields should be skipped: %v", err)
+	}
+
+	var appErr *apperror.Error
+	err := validation.Partial(i18n.Default, req, []string{"angkatan"})
+	if !errors.As(err, &appErr) {
+		t.Fatalf("cross field rule should be reported: %v", err)
+	}
+	details := appErr.Details.([]validation.FieldError)
+	if len(details) != 1 || details[0].Field != "tahun_lulus" {
+		t.Fatalf("unexpected details: %+v", details)
+	}
+
+	err = validation.Partial(i18n.Default, req, []string{"email", "jurusan"})
+	if !errors.As(err, &appErr) {
+		t.Fatalf("touched fields should be reported: %v", err)
+	}
+	if got := rules(appErr.Details.([]validation.FieldError)); len(got) != 2 || got["email"] != "email" || got["jurusan"] != "required" {
+		t.Fatalf("unexpected errors: %v", got)
+	}
+}
@@ -0,0 +1,457 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"go-fiber/outbox"
+	"go-fiber/validation"
+	"go-fiber/webhook"
+)
+
+// memoryStore adalah webhook.Store in-memory dengan aturan yang sama seperti
+// store database: delivery unik per subscription dan event, claim hanya
+// mengambil delivery pending yang sudah waktunya, percobaan hanya dicatat
+// selama lease masih dipegang, dan redeliver hanya untuk delivery dead atau
+// succeeded.
+type memoryStore struct {
+	mu            sync.Mutex
+	subscriptions map[string]*webhook.Subscription
+	deliveries    map[string]*webhook.Delivery
+	nextID        int
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{subscriptions: map[string]*webhook.Subscription{}, deliveries: map[string]*webhook.Delivery{}}
+}
+
+func (s *memoryStore) id() string {
+	s.nextID++
+	return strconv.Itoa(s.nextID)
+}
+
+func (s *memoryStore) CreateSubscription(ctx context.Context, subscription *webhook.Subscription) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	subscription.ID = s.id()
+	copied := *subscription
+	s.subscriptions[subscription.ID] = &copied
+	return nil
+}
+
+func (s *memoryStore) ListSubscriptions(ctx context.Context) ([]webhook.Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var subscriptions []webhook.Subscription
+	for _, subscription := range s.subscriptions {
+		subscriptions = append(subscriptions, *subscription)
+	}
+	return subscriptions, nil
+}
+
+func (s *memoryStore) GetSubscription(ctx context.Context, id string) (*webhook.Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	subscription, ok := s.subscriptions[id]
+	if !ok {
+		return nil, webhook.ErrNotFound
+	}
+	copied := *subscription
+	return &copied, nil
+}
+
+func (s *memoryStore) UpdateSubscription(ctx context.Context, subscription *webhook.Subscription) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.subscriptions[subscription.ID]; !ok {
+		return webhook.ErrNotFound
+	}
+	copied := *subscription
+	s.subscriptions[subscription.ID] = &copied
+	return nil
+}
+
+func (s *memoryStore) DeleteSubscription(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.subscriptions[id]; !ok {
+		return webhook.ErrNotFound
+	}
+	delete(s.subscriptions, id)
+	for deliveryID, delivery := range s.deliveries {
+		if delivery.SubscriptionID == id {
+			delete(s.deliveries, deliveryID)
+		}
+	}
+	return nil
+}
+
+func (s *memoryStore) EnqueueDeliveries(ctx context.Context, deliveries ...webhook.Delivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, delivery := range deliveries {
+		duplicate := false
+		for _, existing := range s.deliveries {
+			if existing.SubscriptionID == delivery.SubscriptionID && existing.Event.ID == delivery.Event.ID {
+				duplicate = true
+			}
+		}
+		if !duplicate {
+			delivery.ID = s.id()
+			delivery.Log = []webhook.Attempt{}
+			s.deliveries[delivery.ID] = &delivery
+		}
+	}
+	return nil
+}
+
+func (s *memoryStore) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]webhook.Delivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	var claimed []webhook.Delivery
+	for _, delivery := range s.sorted() {
+		if len(claimed) == limit {
+			break
+		}
+		if delivery.Status != webhook.StatusPending || delivery.NextAttemptAt.After(now) {
+			continue
+		}
+		next := now.Add(lease)
+		delivery.NextAttemptAt = &next
+		claimed = append(claimed, *delivery)
+	}
+	return claimed, nil
+}
+
+func (s *memoryStore) RecordAttempt(ctx context.Context, id string, attempt webhook.Attempt, status string, next time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delivery := s.deliveries[id]
+	if delivery.Status != webhook.StatusPending || delivery.Attempts != attempt.Attempt-1 {
+		return webhook.ErrLeaseLost
+	}
+	delivery.Status = status
+	delivery.Attempts = attempt.Attempt
+	delivery.LastStatusCode = attempt.StatusCode
+	delivery.LastError = attempt.Error
+	delivery.NextAttemptAt = nil
+	if status == webhook.StatusPending {
+		delivery.NextAttemptAt = &next
+	}
+	if status == webhook.StatusSucceeded {
+		delivery.DeliveredAt = &attempt.At
+	}
+	delivery.Log = append(delivery.Log, attempt)
+	return nil
+}
+
+func (s *memoryStore) ListDeliveries(ctx context.Context, filter webhook.DeliveryFilter) ([]webhook.Delivery, int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var deliveries []webhook.Delivery
+	for _, delivery := range s.sorted() {
+		if (filter.SubscriptionID == "" || delivery.SubscriptionID == filter.SubscriptionID) &&
+			(filter.Status == "" || delivery.Status == filter.Status) {
+			deliveries = append(deliveries, *delivery)
+		}
+	}
+	return deliveries, int64(len(deliveries)), nil
+}
+
+func (s *memoryStore) GetDelivery(ctx context.Context, id string) (*webhook.Delivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delivery, ok := s.deliveries[id]
+	if !ok {
+		return nil, webhook.ErrNotFound
+	}
+	copied := *delivery
+	return &copied, nil
+}
+
+func (s *memoryStore) Redeliver(ctx context.Context, id string) (*webhook.Delivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delivery, ok := s.deliveries[id]
+	if !ok {
+		return nil, webhook.ErrNotFound
+	}
+	if delivery.Status == webhook.StatusPending {
+		return nil, webhook.ErrInvalidState
+	}
+	now := time.Now()
+	delivery.Status = webhook.StatusPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = &now
+	delivery.DeliveredAt = nil
+	copied := *delivery
+	return &copied, nil
+}
+
+func (s *memoryStore) sorted() []*webhook.Delivery {
+	deliveries := make([]*webhook.Delivery, 0, len(s.deliveries))
+	for _, delivery := range s.deliveries {
+		deliveries = append(deliveries, delivery)
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		a, _ := strconv.Atoi(deliveries[i].ID)
+		b, _ := strconv.Atoi(deliveries[j].ID)
+		return a < b
+	})
+	return deliveries
+}
+
+func (s *memoryStore) only(t *testing.T) webhook.Delivery {
+	t.Helper()
+	deliveries, _, _ := s.ListDeliveries(context.Background(), webhook.DeliveryFilter{})
+	if len(deliveries) != 1 {
+		t.Fatalf("got %d deliveries want 1", len(deliveries))
+	}
+	return deliveries[0]
+}
+
+func addSubscription(t *testing.T, store *memoryStore, url string, active bool, eventTypes ...string) webhook.Subscription {
+	t.Helper()
+	subscription := webhook.Subscription{URL: url, EventTypes: eventTypes, Active: active, Secret: "rahasia-subscription"}
+	if err := store.CreateSubscription(context.Background(), &subscription); err != nil {
+		t.Fatal(err)
+	}
+	return subscription
+}
+
+func testEvent(eventType string) outbox.Event {
+	return outbox.Event{ID: "evt-" + eventType, Type: eventType, Resource: "alumni", ResourceID: "7", Action: "create", OccurredAt: time.Now().UTC()}
+}
+
+func TestDispatcherEnqueuesMatchingSubscriptions(t *testing.T) {
+	store := newMemoryStore()
+	all := addSubscription(t, store, "http://a", true, "*")
+	alumni := addSubscription(t, store, "http://b", true, "alumni.*")
+	addSubscription(t, store, "http://c", true, "pekerjaan.*")
+	addSubscription(t, store, "http://d", false, "*")
+
+	dispatcher := webhook.NewDispatcher(store)
+	event := testEvent("alumni.created")
+	// Relay outbox bisa mengirim event yang sama lebih dari sekali.
+	for i := 0; i < 2; i++ {
+		if err := dispatcher.Publish(context.Background(), event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deliveries, _, _ := store.ListDeliveries(context.Background(), webhook.DeliveryFilter{})
+	if len(deliveries) != 2 {
+		t.Fatalf("got %d deliveries want 2: %+v", len(deliveries), deliveries)
+	}
+	got := map[string]bool{}
+	for _, delivery := range deliveries {
+		got[delivery.SubscriptionID] = true
+		if delivery.Status != webhook.StatusPending || delivery.Event.ID != event.ID {
+			t.Fatalf("unexpected delivery %+v", delivery)
+		}
+	}
+	if !got[all.ID] || !got[alumni.ID] {
+		t.Fatalf("deliveries went to the wrong subscriptions: %v", got)
+	}
+}
+
+func TestDelivererSendsSignedRequest(t *testing.T) {
+	type received struct {
+		header http.Header
+		body   []byte
+	}
+	requests := make(chan received, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- received{header: r.Header.Clone(), body: body}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	store := newMemoryStore()
+	subscription := addSubscription(t, store, server.URL, true, "alumni.*")
+	webhook.NewDispatcher(store).Publish(context.Background(), testEvent("alumni.created"))
+
+	deliverer := webhook.NewDeliverer("test", store, 10, 3, time.Second)
+	claimed, err := deliverer.Flush(context.Background())
+	if err != nil || claimed != 1 {
+		t.Fatalf("Flush got (%d, %v) want (1, nil)", claimed, err)
+	}
+
+	request := <-requests
+	if err := webhook.Verify(subscription.Secret, request.header, request.body, webhook.DefaultTolerance); err != nil {
+		t.Fatalf("signature did not verify: %v", err)
+	}
+	if err := webhook.Verify("secret-lain-sekali", request.header, request.body, webhook.DefaultTolerance); err != webhook.ErrInvalidSignature {
+		t.Fatalf("wrong secret got %v want ErrInvalidSignature", err)
+	}
+	var event outbox.Event
+	if err := json.Unmarshal(request.body, &event); err != nil || event.Type != "alumni.created" {
+		t.Fatalf("unexpected body %s (%v)", request.body, err)
+	}
+	if request.header.Get(webhook.HeaderSubscriptionID) != subscription.ID || request.header.Get(webhook.HeaderAttempt) != "1" {
+		t.Fatalf("unexpected headers %v", request.header)
+	}
+
+	delivery := store.only(t)
+	if delivery.Status != webhook.StatusSucceeded || delivery.DeliveredAt == nil {
+		t.Fatalf("delivery not succeeded: %+v", delivery)
+	}
+	if len(delivery.Log) != 1 || delivery.Log[0].StatusCode != http.StatusOK || delivery.Log[0].Response != `{"ok":true}` {
+		t.Fatalf("unexpected log %+v", delivery.Log)
+	}
+}
+
+func TestDelivererRetriesThenDeadLetters(t *testing.T) {
+	var mu sync.Mutex
+	status := http.StatusServiceUnavailable
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	store := newMemoryStore()
+	addSubscription(t, store, server.URL, true, "*")
+	webhook.NewDispatcher(store).Publish(context.Background(), testEvent("alumni.updated"))
+	deliverer := webhook.NewDeliverer("test", store, 10, 2, time.Second)
+
+	deliverer.Flush(context.Background())
+	delivery := store.only(t)
+	if delivery.Status != webhook.StatusPending || delivery.Attempts != 1 || delivery.LastStatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("first failure must be retried: %+v", delivery)
+	}
+	if delivery.NextAttemptAt == nil || time.Until(*delivery.NextAttemptAt) <= 0 {
+		t.Fatalf("retry must be scheduled in the future: %+v", delivery)
+	}
+
+	// Percobaan berikutnya belum waktunya, jadi tidak ada yang diambil.
+	if claimed, _ := deliverer.Flush(context.Background()); claimed != 0 {
+		t.Fatalf("claimed %d deliveries before backoff elapsed", claimed)
+	}
+
+	// Majukan jadwal retry agar tidak perlu menunggu backoff.
+	due := time.Now()
+	store.deliveries[delivery.ID].NextAttemptAt = &due
+	deliverer.Flush(context.Background())
+	delivery = store.only(t)
+	if delivery.Status != webhook.StatusDead || len(delivery.Log) != 2 {
+		t.Fatalf("delivery must be dead after max attempts: %+v", delivery)
+	}
+	dead, _, _ := store.ListDeliveries(context.Background(), webhook.DeliveryFilter{Status: webhook.StatusDead})
+	if len(dead) != 1 {
+		t.Fatalf("dead-letter list got %d want 1", len(dead))
+	}
+
+	mu.Lock()
+	status = http.StatusNoContent
+	mu.Unlock()
+	if _, err := store.Redeliver(context.Background(), delivery.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Redeliver(context.Background(), delivery.ID); err != webhook.ErrInvalidState {
+		t.Fatalf("redeliver of pending delivery got %v want ErrInvalidState", err)
+	}
+	deliverer.Flush(context.Background())
+	delivery = store.only(t)
+	if delivery.Status != webhook.StatusSucceeded || delivery.Attempts != 1 || len(delivery.Log) != 3 {
+		t.Fatalf("redelivery must succeed with a fresh attempt count and keep the log: %+v", delivery)
+	}
+}
+
+func TestDelivererDiscardsAttemptAfterLeaseLost(t *testing.T) {
+	store := newMemoryStore()
+	// Selama request pertama berjalan, lease dianggap habis dan worker lain
+	// sudah mencatat percobaannya sendiri.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		store.mu.Lock()
+		for _, delivery := range store.deliveries {
+			delivery.Attempts = 1
+			delivery.Log = append(delivery.Log, webhook.Attempt{Attempt: 1, StatusCode: http.StatusOK})
+		}
+		store.mu.Unlock()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	addSubscription(t, store, server.URL, true, "*")
+	webhook.NewDispatcher(store).Publish(context.Background(), testEvent("alumni.created"))
+	webhook.NewDeliverer("test", store, 10, 3, time.Second).Flush(context.Background())
+
+	delivery := store.only(t)
+	if delivery.Status != webhook.StatusPending || len(delivery.Log) != 1 || delivery.Log[0].StatusCode != http.StatusOK {
+		t.Fatalf("stale attempt must not be recorded: %+v", delivery)
+	}
+}
+
+func TestDelivererDeadLettersInactiveSubscription(t *testing.T) {
+	store := newMemoryStore()
+	subscription := addSubscription(t, store, "http://127.0.0.1:1", true, "*")
+	webhook.NewDispatcher(store).Publish(context.Background(), testEvent("alumni.created"))
+
+	subscription.Active = false
+	store.UpdateSubscription(context.Background(), &subscription)
+	webhook.NewDeliverer("test", store, 10, 5, time.Second).Flush(context.Background())
+
+	delivery := store.only(t)
+	if delivery.Status != webhook.StatusDead || delivery.Log[0].StatusCode != 0 {
+		t.Fatalf("inactive subscription must not be called: %+v", delivery)
+	}
+}
+
+func TestVerifyRejectsOldTimestamp(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+	timestamp := time.Now().Add(-time.Hour).Unix()
+	header := http.Header{}
+	header.Set(outbox.HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	header.Set(outbox.HeaderSignature, outbox.Sign("rahasia", timestamp, body))
+
+	if err := webhook.Verify("rahasia", header, body, webhook.DefaultTolerance); err != webhook.ErrTimestampExpired {
+		t.Fatalf("got %v want ErrTimestampExpired", err)
+	}
+	if err := webhook.Verify("rahasia", http.Header{}, body, webhook.DefaultTolerance); err != webhook.ErrMissingSignature {
+		t.Fatalf("got %v want ErrMissingSignature", err)
+	}
+}
+
+func TestSubscriptionRequest(t *testing.T) {
+	req := webhook.SubscriptionRequest{URL: "https://example.com/hook", EventTypes: []string{"alumni.*", "alumni.*", "*", "file.uploaded"}}
+	if err := validation.Struct("en", req); err != nil {
+		t.Fatalf("valid request rejected: %v", err)
+	}
+	subscription, err := webhook.NewSubscription(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !subscription.Active || len(subscription.Secret) != 64 || len(subscription.EventTypes) != 3 {
+		t.Fatalf("unexpected subscription %+v", subscription)
+	}
+
+	inactive := false
+	webhook.SubscriptionRequest{URL: "https://example.com/new", EventTypes: []string{"*"}, Active: &inactive}.Apply(&subscription)
+	if subscription.Active || subscription.URL != "https://example.com/new" || len(subscription.Secret) != 64 {
+		t.Fatalf("update must keep the secret when none is given: %+v", subscription)
+	}
+
+	for _, invalid := range []webhook.SubscriptionRequest{
+		{URL: "ftp://example.com", EventTypes: []string{"*"}},
+		{URL: "https://example.com", EventTypes: []string{}},
+		{URL: "https://example.com", EventTypes: []string{"alumni"}},
+		{URL: "https://example.com", EventTypes: []string{"Alumni.Created"}},
+		{URL: "https://example.com", EventTypes: []string{"*"}, Secret: "pendek"},
+	} {
+		if err := validation.Struct("en", invalid); err == nil {
+			t.Errorf("invalid request accepted: %+v", invalid)
+		}
+	}
+}
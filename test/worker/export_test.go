@@ -0,0 +1,357 @@
+package worker_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"go-fiber/apperror"
+	"go-fiber/queue"
+	"go-fiber/worker"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// memoryStore adalah queue.Store in-memory secukupnya untuk menjalankan job
+// export dengan queue.Flush.
+type memoryStore struct {
+	mu     sync.Mutex
+	jobs   map[string]*queue.Job
+	nextID int
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{jobs: map[string]*queue.Job{}}
+}
+
+func (s *memoryStore) Enqueue(ctx context.Context, job *queue.Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	job.ID = strconv.Itoa(s.nextID)
+	job.CreatedAt = time.Now()
+	copied := *job
+	s.jobs[job.ID] = &copied
+	return nil
+}
+
+func (s *memoryStore) Claim(ctx context.Context, jobType string, limit int, lease time.Duration) ([]queue.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	claimed := []queue.Job{}
+	for _, job := range s.jobs {
+		if len(claimed) == limit || job.Type != jobType || job.Status != queue.StatusPending || job.RunAt.After(time.Now()) {
+			continue
+		}
+		job.Status = queue.StatusRunning
+		job.Attempts++
+		claimed = append(claimed, *job)
+	}
+	return claimed, nil
+}
+
+func (s *memoryStore) Complete(ctx context.Context, id string, attempt int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	s.jobs[id].Status = queue.StatusSucceeded
+	s.jobs[id].FinishedAt = &now
+	return nil
+}
+
+func (s *memoryStore) Fail(ctx context.Context, id string, attempt int, lastError string, next time.Time, final bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job := s.jobs[id]
+	job.Status = queue.StatusPending
+	job.LastError = lastError
+	job.RunAt = next
+	if final {
+		now := time.Now()
+		job.Status = queue.StatusFailed
+		job.FinishedAt = &now
+	}
+	return nil
+}
+
+func (s *memoryStore) List(ctx context.Context, filter queue.Filter) ([]queue.Job, int64, error) {
+	return nil, 0, errors.New("not implemented")
+}
+
+func (s *memoryStore) Get(ctx context.Context, id string) (*queue.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, queue.ErrNotFound
+	}
+	copied := *job
+	return &copied, nil
+}
+
+func (s *memoryStore) Retry(ctx context.Context, id string) (*queue.Job, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *memoryStore) Cancel(ctx context.Context, id string) (*queue.Job, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *memoryStore) Purge(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+type exportRequest struct {
+	Rows []string `json:"rows"`
+	Fail string   `json:"fail"`
+}
+
+// buildExport menulis setiap baris request, atau gagal sesuai request.Fail.
+func buildExport(ctx context.Context, data json.RawMessage) (worker.ExportFunc, error) {
+	var request exportRequest
+	if err := json.Unmarshal(data, &request); err != nil {
+		return nil, err
+	}
+	switch request.Fail {
+	case "invalid":
+		return nil, apperror.Validation("query.invalid_format")
+	case "write":
+		return func(ctx context.Context, w io.Writer) error {
+			io.WriteString(w, "partial\n")
+			return errors.New("database down")
+		}, nil
+	}
+	return func(ctx context.Context, w io.Writer) error {
+		for _, row := range request.Rows {
+			if _, err := io.WriteString(w, row+"\n"); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, nil
+}
+
+func newExporter(t *testing.T) (*worker.Exporter, *queue.Queue, *memoryStore, string) {
+	t.Helper()
+	store := newMemoryStore()
+	q, err := queue.New("memory", store, queue.Options{Concurrency: 4, MaxAttempts: 3, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	exporter, err := worker.NewExporter(q, dir, time.Hour, buildExport, queue.HandlerOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return exporter, q, store, dir
+}
+
+// newApp memasang endpoint export seperti route admin dengan email login
+// dari header X-Email.
+func newApp(exporter *worker.Exporter) *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("email", c.Get("X-Email"))
+		return c.Next()
+	})
+	app.Get("/export", func(c *fiber.Ctx) error {
+		return exporter.Send(c, c.QueryBool("async"), "alumni", "csv", exportRequest{Rows: []string{"nim", "123"}, Fail: c.Query("fail")})
+	})
+	app.Get("/export/jobs/:id", exporter.JobStatusHandler)
+	app.Get("/export/jobs/:id/download", exporter.DownloadHandler)
+	return app
+}
+
+func get(t *testing.T, app *fiber.App, path, email string) (int, []byte) {
+	t.Helper()
+	req := httptest.NewRequest(fiber.MethodGet, path, nil)
+	req.Header.Set("X-Email", email)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, body
+}
+
+func exportJob(t *testing.T, body []byte) worker.ExportJob {
+	t.Helper()
+	var response struct {
+		Data worker.ExportJob `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		t.Fatalf("invalid response %s: %v", body, err)
+	}
+	return response.Data
+}
+
+func TestExporterStreamsSyncExport(t *testing.T) {
+	exporter, _, store, _ := newExporter(t)
+	app := newApp(exporter)
+
+	status, body := get(t, app, "/export", "admin@example.com")
+	if status != fiber.StatusOK || string(body) != "nim\n123\n" {
+		t.Fatalf("got %d %q", status, body)
+	}
+	if len(store.jobs) != 0 {
+		t.Fatalf("sync export must not enqueue a job, got %d", len(store.jobs))
+	}
+
+	if status, _ := get(t, app, "/export?fail=invalid", "admin@example.com"); status != fiber.StatusBadRequest {
+		t.Fatalf("builder error must be returned, got %d", status)
+	}
+}
+
+func TestExporterRunsAsyncExportOnQueue(t *testing.T) {
+	exporter, q, _, _ := newExporter(t)
+	app := newApp(exporter)
+
+	status, body := get(t, app, "/export?async=true", "admin@example.com")
+	if status != fiber.StatusAccepted {
+		t.Fatalf("got %d %s", status, body)
+	}
+	job := exportJob(t, body)
+	if job.Status != worker.ExportStatusPending {
+		t.Fatalf("expected pending job, got %+v", job)
+	}
+
+	if status, _ := get(t, app, "/export/jobs/"+job.ID+"/download", "admin@example.com"); status != fiber.StatusConflict {
+		t.Fatalf("download before completion got %d", status)
+	}
+
+	if _, err := q.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	_, body = get(t, app, "/export/jobs/"+job.ID, "admin@example.com")
+	if got := exportJob(t, body); got.Status != worker.ExportStatusCompleted || got.FinishedAt == nil {
+		t.Fatalf("expected completed job, got %+v", got)
+	}
+	status, body = get(t, app, "/export/jobs/"+job.ID+"/download", "admin@example.com")
+	if status != fiber.StatusOK || string(body) != "nim\n123\n" {
+		t.Fatalf("download got %d %q", status, body)
+	}
+}
+
+func TestExporterHidesJobsOfOtherAdmins(t *testing.T) {
+	exporter, _, _, _ := newExporter(t)
+	app := newApp(exporter)
+
+	_, body := get(t, app, "/export?async=true", "admin@example.com")
+	job := exportJob(t, body)
+
+	for _, path := range []string{"/export/jobs/" + job.ID, "/export/jobs/" + job.ID + "/download", "/export/jobs/999"} {
+		if status, _ := get(t, app, path, "other@example.com"); status != fiber.StatusNotFound {
+			t.Fatalf("%s got %d, want 404", path, status)
+		}
+	}
+}
+
+func TestExporterFailsRejectedRequestWithoutRetry(t *testing.T) {
+	exporter, q, store, _ := newExporter(t)
+	app := newApp(exporter)
+
+	_, body := get(t, app, "/export?async=true&fail=invalid", "admin@example.com")
+	job := exportJob(t, body)
+	q.Flush(context.Background())
+
+	if got := store.jobs[job.ID]; got.Status != queue.StatusFailed || got.Attempts != 1 {
+		t.Fatalf("expected permanent failure, got %+v", got)
+	}
+	_, body = get(t, app, "/export/jobs/"+job.ID, "admin@example.com")
+	if got := exportJob(t, body); got.Status != worker.ExportStatusFailed || got.Error == "" {
+		t.Fatalf("expected failed job with error, got %+v", got)
+	}
+}
+
+func TestExporterRemovesPartialFileAndRetries(t *testing.T) {
+	exporter, q, store, dir := newExporter(t)
+	app := newApp(exporter)
+
+	_, body := get(t, app, "/export?async=true&fail=write", "admin@example.com")
+	job := exportJob(t, body)
+	q.Flush(context.Background())
+
+	if got := store.jobs[job.ID]; got.Status != queue.StatusPending || got.LastError != "database down" {
+		t.Fatalf("expected job waiting for retry, got %+v", got)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Fatalf("partial export file left behind: %v", entries)
+	}
+}
+
+func TestExporterWithoutQueue(t *testing.T) {
+	exporter, err := worker.NewExporter(nil, t.TempDir(), time.Hour, buildExport, queue.HandlerOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	app := newApp(exporter)
+
+	if exporter.Async() {
+		t.Fatal("exporter without queue must not report async support")
+	}
+	if status, _ := get(t, app, "/export?async=true", "admin@example.com"); status != fiber.StatusServiceUnavailable {
+		t.Fatalf("async export without queue got %d", status)
+	}
+	if status, _ := get(t, app, "/export", "admin@example.com"); status != fiber.StatusOK {
+		t.Fatalf("sync export without queue got %d", status)
+	}
+	if status, _ := get(t, app, "/export/jobs/1", "admin@example.com"); status != fiber.StatusNotFound {
+		t.Fatalf("job status without queue got %d", status)
+	}
+}
+
+func TestExporterSchedulesCleanup(t *testing.T) {
+	_, q, _, _ := newExporter(t)
+
+	schedules := q.Schedules()
+	if len(schedules) != 1 || schedules[0].Type != worker.ExportCleanupJobType || schedules[0].Spec != "@hourly" {
+		t.Fatalf("unexpected schedules %+v", schedules)
+	}
+}
+
+func TestNewExporterReturnsScheduleError(t *testing.T) {
+	q, err := queue.New("memory", newMemoryStore(), queue.Options{Concurrency: 1, MaxAttempts: 1, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := q.Schedule(worker.ExportCleanupJobType, "@daily", "other.job", struct{}{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := worker.NewExporter(q, t.TempDir(), time.Hour, buildExport, queue.HandlerOptions{}); err == nil {
+		t.Fatal("duplicate cleanup schedule must be returned as an error")
+	}
+}
+
+func TestRemoveExpiredFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"old.csv", "kept.csv", "new.csv"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("nim\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	os.Chtimes(filepath.Join(dir, "old.csv"), old, old)
+	os.Chtimes(filepath.Join(dir, "kept.csv"), old, old)
+
+	removed, err := worker.RemoveExpiredFiles(dir, time.Now().Add(-time.Hour), map[string]bool{"kept.csv": true})
+	if err != nil || removed != 1 {
+		t.Fatalf("removed %d, err %v", removed, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "old.csv")); !os.IsNotExist(err) {
+		t.Fatal("expired file must be removed")
+	}
+
+	if removed, err := worker.RemoveExpiredFiles(filepath.Join(dir, "missing"), time.Now(), nil); err != nil || removed != 0 {
+		t.Fatalf("missing dir: removed %d, err %v", removed, err)
+	}
+}
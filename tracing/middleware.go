@@ -0,0 +1,85 @@
+package tracing
+
+import (
+	"go-fiber/apperror"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// headerCarrier membaca header request fiber untuk propagator W3C.
+type headerCarrier struct {
+	c *fiber.Ctx
+}
+
+func (h headerCarrier) Get(key string) string {
+	return h.c.Get(key)
+}
+
+func (h headerCarrier) Set(key, value string) {
+	h.c.Request().Header.Set(key, value)
+}
+
+func (h headerCarrier) Keys() []string {
+	keys := make([]string, 0)
+	for key := range h.c.GetReqHeaders() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Middleware membuat server span untuk setiap request. Nama span memakai
+// template route (misalnya GET /go-fiber-mongo/alumni/:id). Jika client mengirim
+// header traceparent, span menjadi lanjutan dari trace tersebut. Context
+// berisi span disimpan di c.UserContext() supaya service dan repository bisa
+// membuat child span. Trace ID dikembalikan lewat header traceparent response.
+func Middleware(c *fiber.Ctx) error {
+	propagator := otel.GetTextMapPropagator()
+	ctx := propagator.Extract(c.UserContext(), headerCarrier{c})
+
+	// Method dan path disalin karena buffer request dipakai ulang fiber,
+	// sedangkan span diekspor setelah handler selesai.
+	method := utils.CopyString(c.Method())
+	path := utils.CopyString(c.Path())
+	ctx, span := tracer().Start(ctx, method+" "+path,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(method),
+			semconv.URLPath(path),
+		),
+	)
+	defer span.End()
+
+	c.SetUserContext(ctx)
+
+	responseHeaders := propagation.MapCarrier{}
+	propagator.Inject(ctx, responseHeaders)
+	for key, value := range responseHeaders {
+		c.Set(key, value)
+	}
+
+	err := c.Next()
+
+	status := apperror.ResponseStatus(c, err)
+
+	route := c.Route().Path
+	if status == fiber.StatusNotFound && route == "/" && path != "/" {
+		route = "unmatched"
+	}
+	span.SetName(method + " " + route)
+	span.SetAttributes(semconv.HTTPRoute(route))
+	span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+	if err != nil {
+		span.RecordError(err)
+	}
+	if status >= fiber.StatusInternalServerError {
+		span.SetStatus(codes.Error, "")
+	}
+
+	return err
+}
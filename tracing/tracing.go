@@ -0,0 +1,140 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	instrumentationName = "go-fiber"
+	defaultServiceName  = "go-fiber"
+)
+
+// Init menyiapkan TracerProvider global sesuai OTEL_TRACES_EXPORTER:
+//   - "otlp": kirim span lewat OTLP/HTTP. Endpoint, header, dan opsi lain dibaca
+//     dari variabel standar OTEL_EXPORTER_OTLP_* (default localhost:4318).
+//   - "stdout": cetak span ke stdout, berguna saat development.
+//   - "none" atau kosong: span tetap dibuat agar traceparent diteruskan,
+//     tetapi tidak diekspor ke mana pun.
+//
+// Fungsi yang dikembalikan harus dipanggil saat aplikasi berhenti agar span
+// yang masih di buffer sempat terkirim.
+func Init(ctx context.Context) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	exporter, err := newExporter(ctx, strings.ToLower(strings.TrimSpace(os.Getenv("OTEL_TRACES_EXPORTER"))))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName())),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	options := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+	if exporter != nil {
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+
+	provider := sdktrace.NewTracerProvider(options...)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+func newExporter(ctx context.Context, name string) (sdktrace.SpanExporter, error) {
+	switch name {
+	case "", "none":
+		return nil, nil
+	case "otlp":
+		return otlptracehttp.New(ctx)
+	case "stdout", "console":
+		return stdouttrace.New(stdouttrace.WithPrettyPrint())
+	default:
+		return nil, fmt.Errorf("OTEL_TRACES_EXPORTER %q tidak dikenal. Gunakan otlp, stdout, atau none", name)
+	}
+}
+
+// serviceName memakai OTEL_SERVICE_NAME jika diisi. resource.Default juga
+// membaca variabel ini, tetapi nilai default-nya unknown_service.
+func serviceName() string {
+	if name := os.Getenv("OTEL_SERVICE_NAME"); name != "" {
+		return name
+	}
+	return defaultServiceName
+}
+
+func tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// StartPostgres membuat span untuk satu fungsi repository PostgreSQL.
+func StartPostgres(ctx context.Context, operation string) (context.Context, trace.Span) {
+	return tracer().Start(ctx, "postgre."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemNamePostgreSQL,
+			semconv.DBOperationName(operation),
+		),
+	)
+}
+
+// StartMongo membuat span untuk satu method repository MongoDB, misalnya
+// StartMongo(ctx, "AlumniRepository.FindAlumniByID").
+func StartMongo(ctx context.Context, operation string) (context.Context, trace.Span) {
+	return tracer().Start(ctx, "mongo."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemNameMongoDB,
+			semconv.DBOperationName(operation),
+		),
+	)
+}
+
+// Error mencatat err pada span lalu mengembalikannya kembali, sehingga bisa
+// dipakai langsung di statement return. Data tidak ditemukan
+// (sql.ErrNoRows/mongo.ErrNoDocuments) tidak dianggap error.
+func Error(span trace.Span, err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, sql.ErrNoRows) || errors.Is(err, mongo.ErrNoDocuments) {
+		span.SetAttributes(attribute.Bool("db.not_found", true))
+		return err
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	return err
+}
+
+// TraceID mengembalikan trace ID dari span aktif di ctx, atau string kosong.
+func TraceID(ctx context.Context) string {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+	return spanContext.TraceID().String()
+}
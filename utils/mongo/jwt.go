@@ -1,7 +1,6 @@
 package utils
 
 import (
-	"os"
 	"strings"
 	"time"
 
@@ -21,17 +20,9 @@ type JWTClaims struct {
 	jwt.RegisteredClaims
 }
 
-var jwtSecret = []byte(getJWTSecret())
-
-func getJWTSecret() string {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		return "go-fiber-alumni-jwt-secret-key-minimum-32-characters-long-for-production-security"
-	}
-	return secret
-}
-
-func GenerateToken(alumniToken AlumniToken) (string, error) {
+// GenerateToken menandatangani token login dengan secret dari
+// config.AuthConfig.JWTSecret.
+func GenerateToken(secret string, alumniToken AlumniToken) (string, error) {
 	claims := JWTClaims{
 		AlumniID: alumniToken.ID,
 		Email:    alumniToken.Email,
@@ -46,15 +37,16 @@ func GenerateToken(alumniToken AlumniToken) (string, error) {
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(jwtSecret)
+	return token.SignedString([]byte(secret))
 }
 
-func ValidateToken(tokenString string) (*JWTClaims, error) {
+// ValidateToken memeriksa token dengan secret yang sama dengan GenerateToken.
+func ValidateToken(secret, tokenString string) (*JWTClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, jwt.ErrSignatureInvalid
 		}
-		return jwtSecret, nil
+		return []byte(secret), nil
 	})
 
 	if err != nil {
@@ -0,0 +1,92 @@
+package validation
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"go-fiber/helper"
+	"go-fiber/i18n"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// DateLayout adalah format tanggal yang diterima request, misalnya 2025-01-15.
+const DateLayout = "2006-01-02"
+
+var (
+	// NIM berisi huruf, angka, titik, atau tanda hubung, misalnya 2021001,
+	// 21.11.4001, atau A11.2021.13001.
+	nimPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9.\-]{0,19}$`)
+	// Nomor telepon Indonesia setelah spasi, tanda hubung, dan kurung dibuang:
+	// diawali 0, 62, atau +62 dan berisi 7-12 digit berikutnya.
+	phonePattern = regexp.MustCompile(`^(\+62|62|0)[1-9][0-9]{6,11}$`)
+	// Tipe domain event berformat resource.aksi, dengan * untuk semua aksi
+	// satu resource atau semua event, misalnya alumni.created, alumni.*, *.
+	eventTypePattern = regexp.MustCompile(`^(\*|[a-z_]+\.(\*|[a-z_]+))$`)
+)
+
+// registerRules mendaftarkan rule khusus aplikasi:
+//
+//	nim          format NIM
+//	phone        nomor telepon Indonesia
+//	date         tanggal dengan format DateLayout
+//	after=Field  tanggal harus setelah tanggal pada Field di struct yang sama
+//	event_type   tipe domain event atau pattern-nya
+func registerRules(v *validator.Validate) {
+	v.RegisterValidation("nim", func(fl validator.FieldLevel) bool {
+		return nimPattern.MatchString(fl.Field().String())
+	})
+	// phone dan date menerima string kosong karena field opsional berupa
+	// pointer yang berisi "" tidak dilewati omitempty; wajib tidaknya field
+	// diatur oleh rule required.
+	v.RegisterValidation("phone", func(fl validator.FieldLevel) bool {
+		value := fl.Field().String()
+		return value == "" || phonePattern.MatchString(helper.NormalizePhone(value))
+	})
+	v.RegisterValidation("date", func(fl validator.FieldLevel) bool {
+		value := fl.Field().String()
+		if value == "" {
+			return true
+		}
+		_, err := time.Parse(DateLayout, value)
+		return err == nil
+	})
+	v.RegisterValidation("after", validateAfter)
+	v.RegisterValidation("event_type", func(fl validator.FieldLevel) bool {
+		return eventTypePattern.MatchString(fl.Field().String())
+	})
+}
+
+func validateAfter(fl validator.FieldLevel) bool {
+	end, err := time.Parse(DateLayout, fl.Field().String())
+	if err != nil {
+		// Format yang salah sudah dilaporkan oleh rule date.
+		return true
+	}
+
+	start, _, _, found := fl.GetStructFieldOKAdvanced2(fl.Parent(), fl.Param())
+	if !found {
+		return true
+	}
+	startDate, err := time.Parse(DateLayout, start.String())
+	if err != nil {
+		return true
+	}
+	return end.After(startDate)
+}
+
+func message(lang string, fe validator.FieldError, param string) string {
+	field := fe.Field()
+	switch fe.Tag() {
+	case "required", "date":
+		return i18n.T(lang, "validation."+fe.Tag(), field)
+	case "email", "nim", "phone", "event_type":
+		return i18n.T(lang, "validation."+fe.Tag())
+	case "oneof":
+		return i18n.T(lang, "validation.oneof", field, strings.Join(strings.Fields(fe.Param()), ", "))
+	case "after", "gtefield":
+		return i18n.T(lang, "validation."+fe.Tag(), field, param)
+	}
+	return i18n.T(lang, "validation.invalid", field)
+}
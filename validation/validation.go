@@ -0,0 +1,168 @@
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"go-fiber/apperror"
+	"go-fiber/i18n"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// FieldError adalah satu kesalahan validasi yang dikirim ke client di
+// details response problem+json. Field memakai nama JSON, misalnya
+// "alumni_info.email" untuk field bertingkat.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+
+	param string
+}
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	registerRules(v)
+	return v
+}
+
+// ParseBody mem-parse body request ke out lalu memvalidasinya berdasarkan
+// tag validate. Error yang dikembalikan sudah berupa apperror dengan pesan
+// field dalam bahasa request.
+func ParseBody(c *fiber.Ctx, out interface{}) error {
+	if err := c.BodyParser(out); err != nil {
+		return apperror.InvalidBody(err)
+	}
+	return Struct(i18n.Lang(c), out)
+}
+
+// Struct memvalidasi s dan mengembalikan apperror.Validation berisi daftar
+// FieldError di Details dengan pesan dalam bahasa lang, atau nil jika s
+// valid.
+func Struct(lang string, s interface{}) error {
+	return toError(Fields(lang, s))
+}
+
+// Fields memvalidasi s dan mengembalikan semua FieldError dengan pesan dalam
+// bahasa lang. Dipakai jika kesalahan perlu digabung dengan hasil pemeriksaan
+// lain, misalnya per baris file import.
+func Fields(lang string, s interface{}) []FieldError {
+	return fieldErrors(lang, s, validate.Struct(s))
+}
+
+// FieldsExcept sama seperti Fields tetapi melewati field Go yang disebutkan,
+// misalnya "Password" pada import yang membuat password awal sendiri.
+func FieldsExcept(lang string, s interface{}, fields ...string) []FieldError {
+	return fieldErrors(lang, s, validate.StructExcept(s, fields...))
+}
+
+// Partial memvalidasi s seperti Struct tetapi hanya melaporkan field JSON
+// tingkat atas yang ada di fields, termasuk rule pembanding seperti
+// gtefield=Angkatan yang merujuk salah satunya. Dipakai PATCH agar data lama
+// yang tidak disentuh request tidak ikut ditolak.
+func Partial(lang string, s interface{}, fields []string) error {
+	touched := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		touched[field] = true
+	}
+
+	var errs []FieldError
+	for _, fe := range fieldErrors(lang, s, validate.Struct(s)) {
+		top, _, _ := strings.Cut(fe.Field, ".")
+		if touched[top] || touched[fe.param] {
+			errs = append(errs, fe)
+		}
+	}
+	return toError(errs)
+}
+
+func toError(errs []FieldError) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return apperror.Validation("validation.failed").WithDetails(errs)
+}
+
+func fieldErrors(lang string, s interface{}, err error) []FieldError {
+	if err == nil {
+		return nil
+	}
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		// InvalidValidationError hanya terjadi jika s bukan struct, yang
+		// berarti kesalahan pemanggil dan bukan input client.
+		panic(err)
+	}
+
+	root := reflect.TypeOf(s)
+	result := make([]FieldError, 0, len(validationErrs))
+	for _, fe := range validationErrs {
+		param := paramField(root, fe)
+		result = append(result, FieldError{
+			Field:   fieldPath(fe.Namespace()),
+			Rule:    fe.Tag(),
+			Message: message(lang, fe, param),
+			param:   param,
+		})
+	}
+	return result
+}
+
+// fieldPath membuang nama struct di awal namespace validator, sehingga
+// "CreateAlumniRequest.alumni_info.nim" menjadi "alumni_info.nim".
+func fieldPath(namespace string) string {
+	_, path, found := strings.Cut(namespace, ".")
+	if !found {
+		return namespace
+	}
+	return path
+}
+
+// paramField mengembalikan nama JSON field yang dirujuk rule pembanding
+// seperti gtefield=Angkatan, agar pesan error memakai nama yang dikenal client.
+func paramField(root reflect.Type, fe validator.FieldError) string {
+	if fe.Param() == "" {
+		return ""
+	}
+
+	t := root
+	segments := strings.Split(fe.StructNamespace(), ".")
+	for _, segment := range segments[1 : len(segments)-1] {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		field, ok := t.FieldByName(segment)
+		if !ok {
+			return fe.Param()
+		}
+		t = field.Type
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return fe.Param()
+	}
+
+	field, ok := t.FieldByName(fe.Param())
+	if !ok {
+		return fe.Param()
+	}
+	if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return fe.Param()
+}
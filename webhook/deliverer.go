@@ -0,0 +1,237 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go-fiber/outbox"
+)
+
+const (
+	// claimLease harus lebih lama dari timeout satu request; delivery yang
+	// belum dicatat setelah lease habis (misalnya instance mati) diambil lagi.
+	claimLease = 5 * time.Minute
+	// responseSnippet membatasi body response yang disimpan di log attempt.
+	responseSnippet = 512
+)
+
+// Header tambahan pada request webhook selain header outbox.
+const (
+	HeaderSubscriptionID = "X-Webhook-Subscription"
+	HeaderDeliveryID     = "X-Webhook-Delivery"
+	HeaderAttempt        = "X-Webhook-Attempt"
+)
+
+// Deliverer mengambil delivery pending dari Store dan mengirimnya ke URL
+// subscription. Satu batch dikirim bersamaan; delivery yang gagal dicoba
+// lagi dengan outbox.Backoff dan menjadi dead setelah maxAttempts.
+type Deliverer struct {
+	name        string
+	store       Store
+	client      *http.Client
+	batchSize   int
+	maxAttempts int
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+// NewDeliverer membuat worker untuk store. name (misalnya postgres atau
+// mongo) hanya dipakai di log. timeout membatasi satu request ke penerima.
+func NewDeliverer(name string, store Store, batchSize, maxAttempts int, timeout time.Duration) *Deliverer {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Deliverer{
+		name:        name,
+		store:       store,
+		client:      &http.Client{Timeout: timeout},
+		batchSize:   batchSize,
+		maxAttempts: maxAttempts,
+		ctx:         ctx,
+		cancel:      cancel,
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+}
+
+// Start menjalankan worker di goroutine terpisah. Delivery diperiksa setiap
+// interval, atau langsung lagi jika batch sebelumnya penuh.
+func (d *Deliverer) Start(interval time.Duration) {
+	go func() {
+		defer close(d.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			claimed, err := d.Flush(d.ctx)
+			if err != nil && d.ctx.Err() == nil {
+				slog.Warn("Webhook deliverer gagal mengambil delivery", "store", d.name, "error", err)
+			}
+			if err == nil && claimed == d.batchSize {
+				select {
+				case <-d.stop:
+					return
+				default:
+					continue
+				}
+			}
+			select {
+			case <-d.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Shutdown menghentikan worker setelah batch yang sedang dikirim selesai
+// atau ctx habis. Jika waktu habis, request dibatalkan; delivery yang belum
+// dicatat dikirim ulang setelah lease-nya habis.
+func (d *Deliverer) Shutdown(ctx context.Context) error {
+	close(d.stop)
+	select {
+	case <-d.done:
+		return nil
+	case <-ctx.Done():
+		d.cancel()
+		return ctx.Err()
+	}
+}
+
+// Flush mengirim satu batch delivery dan mengembalikan jumlah delivery yang
+// diambil. Hasil setiap percobaan dicatat di store dan tidak dikembalikan
+// sebagai error.
+func (d *Deliverer) Flush(ctx context.Context) (int, error) {
+	deliveries, err := d.store.ClaimDeliveries(ctx, d.batchSize, claimLease)
+	if err != nil {
+		return 0, err
+	}
+
+	subscriptions := d.loadSubscriptions(ctx, deliveries)
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		go func(delivery Delivery) {
+			defer wg.Done()
+			d.deliver(ctx, delivery, subscriptions[delivery.SubscriptionID])
+		}(delivery)
+	}
+	wg.Wait()
+	return len(deliveries), nil
+}
+
+// loadSubscriptions membaca subscription setiap delivery sekali per batch.
+// Subscription yang sudah dihapus tidak ada di map.
+func (d *Deliverer) loadSubscriptions(ctx context.Context, deliveries []Delivery) map[string]*Subscription {
+	subscriptions := map[string]*Subscription{}
+	for _, delivery := range deliveries {
+		if _, ok := subscriptions[delivery.SubscriptionID]; ok {
+			continue
+		}
+		subscription, err := d.store.GetSubscription(ctx, delivery.SubscriptionID)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			slog.WarnContext(ctx, "Gagal membaca subscription webhook", "store", d.name, "subscription_id", delivery.SubscriptionID, "error", err)
+		}
+		subscriptions[delivery.SubscriptionID] = subscription
+	}
+	return subscriptions
+}
+
+func (d *Deliverer) deliver(ctx context.Context, delivery Delivery, subscription *Subscription) {
+	attempt := Attempt{Attempt: delivery.Attempts + 1, At: time.Now().UTC()}
+
+	// Subscription yang dinonaktifkan tidak dikirimi lagi; delivery-nya
+	// langsung masuk dead-letter dan bisa di-redeliver setelah diaktifkan.
+	if subscription == nil || !subscription.Active {
+		attempt.Error = "subscription tidak aktif"
+		d.record(ctx, delivery, attempt, StatusDead, time.Time{})
+		return
+	}
+
+	started := time.Now()
+	attempt.StatusCode, attempt.Response, attempt.Error = d.send(ctx, delivery, *subscription, attempt)
+	attempt.DurationMS = time.Since(started).Milliseconds()
+
+	if attempt.Error == "" {
+		d.record(ctx, delivery, attempt, StatusSucceeded, time.Time{})
+		return
+	}
+
+	// Shutdown yang membatalkan request bukan kegagalan penerima; delivery
+	// dibiarkan dan diambil lagi setelah lease habis.
+	if d.ctx.Err() != nil {
+		return
+	}
+
+	logArgs := []interface{}{"store", d.name, "delivery_id", delivery.ID, "subscription_id", delivery.SubscriptionID,
+		"type", delivery.Event.Type, "attempts", attempt.Attempt, "error", attempt.Error}
+	if attempt.Attempt >= d.maxAttempts {
+		slog.ErrorContext(ctx, "Webhook gagal dikirim dan masuk dead-letter", logArgs...)
+		d.record(ctx, delivery, attempt, StatusDead, time.Time{})
+		return
+	}
+	next := time.Now().Add(outbox.Backoff(attempt.Attempt))
+	slog.WarnContext(ctx, "Webhook gagal dikirim", append(logArgs, "next_attempt_at", next)...)
+	d.record(ctx, delivery, attempt, StatusPending, next)
+}
+
+// send mengirim event dan mengembalikan status code, awal body response,
+// dan pesan error jika status bukan 2xx atau request gagal.
+func (d *Deliverer) send(ctx context.Context, delivery Delivery, subscription Subscription, attempt Attempt) (int, string, string) {
+	body, err := json.Marshal(delivery.Event)
+	if err != nil {
+		return 0, "", err.Error()
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", err.Error()
+	}
+	timestamp := attempt.At.Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "go-fiber-webhooks/1.0")
+	request.Header.Set(outbox.HeaderEventID, delivery.Event.ID)
+	request.Header.Set(outbox.HeaderEventType, delivery.Event.Type)
+	request.Header.Set(outbox.HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(outbox.HeaderSignature, outbox.Sign(subscription.Secret, timestamp, body))
+	request.Header.Set(HeaderSubscriptionID, subscription.ID)
+	request.Header.Set(HeaderDeliveryID, delivery.ID)
+	request.Header.Set(HeaderAttempt, strconv.Itoa(attempt.Attempt))
+
+	response, err := d.client.Do(request)
+	if err != nil {
+		return 0, "", err.Error()
+	}
+	defer response.Body.Close()
+
+	snippet, _ := io.ReadAll(io.LimitReader(response.Body, responseSnippet))
+	io.Copy(io.Discard, response.Body)
+	text := strings.TrimSpace(string(snippet))
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return response.StatusCode, text, fmt.Sprintf("penerima membalas %d", response.StatusCode)
+	}
+	return response.StatusCode, text, ""
+}
+
+// record mencatat hasil percobaan. ErrLeaseLost berarti delivery sudah
+// diambil worker lain setelah lease habis, sehingga hasil percobaan ini
+// dibuang dan percobaan yang baru yang menentukan status delivery.
+func (d *Deliverer) record(ctx context.Context, delivery Delivery, attempt Attempt, status string, next time.Time) {
+	err := d.store.RecordAttempt(ctx, delivery.ID, attempt, status, next)
+	if errors.Is(err, ErrLeaseLost) {
+		slog.WarnContext(ctx, "Lease webhook hilang, hasil percobaan dibuang", "store", d.name, "delivery_id", delivery.ID, "attempts", attempt.Attempt)
+		return
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "Gagal mencatat percobaan webhook", "store", d.name, "delivery_id", delivery.ID, "error", err)
+	}
+}
@@ -0,0 +1,42 @@
+package webhook
+
+import (
+	"context"
+
+	"go-fiber/outbox"
+)
+
+// Dispatcher adalah outbox.Sink yang mengubah setiap event menjadi delivery
+// untuk subscription aktif yang cocok. Pengiriman HTTP-nya dikerjakan
+// Deliverer, sehingga URL yang lambat atau mati tidak menahan relay outbox
+// maupun sink lain.
+type Dispatcher struct {
+	store Store
+}
+
+func NewDispatcher(store Store) *Dispatcher {
+	return &Dispatcher{store: store}
+}
+
+func (d *Dispatcher) Name() string {
+	return "webhooks"
+}
+
+// Publish membaca subscription setiap kali dipanggil sehingga perubahan
+// lewat admin API langsung berlaku untuk event berikutnya.
+func (d *Dispatcher) Publish(ctx context.Context, event outbox.Event) error {
+	subscriptions, err := d.store.ListSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+	var deliveries []Delivery
+	for _, subscription := range subscriptions {
+		if subscription.Matches(event.Type) {
+			deliveries = append(deliveries, NewDelivery(subscription.ID, event))
+		}
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return d.store.EnqueueDeliveries(ctx, deliveries...)
+}
@@ -0,0 +1,413 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-fiber/apperror"
+	"go-fiber/outbox"
+	"go-fiber/tracing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoStore menyimpan subscription dan delivery di collection
+// webhook_subscriptions dan webhook_deliveries. Index unik
+// (subscription_id, event.id) dibuat oleh RunMigrations.
+type MongoStore struct {
+	subscriptions *mongo.Collection
+	deliveries    *mongo.Collection
+}
+
+func NewMongoStore(db *mongo.Database) *MongoStore {
+	return &MongoStore{
+		subscriptions: db.Collection("webhook_subscriptions"),
+		deliveries:    db.Collection("webhook_deliveries"),
+	}
+}
+
+type mongoSubscription struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	URL         string             `bson:"url"`
+	EventTypes  []string           `bson:"event_types"`
+	Description string             `bson:"description"`
+	Active      bool               `bson:"active"`
+	Secret      string             `bson:"secret"`
+	CreatedAt   time.Time          `bson:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at"`
+}
+
+func (d mongoSubscription) subscription() Subscription {
+	return Subscription{
+		ID:          d.ID.Hex(),
+		URL:         d.URL,
+		EventTypes:  d.EventTypes,
+		Description: d.Description,
+		Active:      d.Active,
+		Secret:      d.Secret,
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+	}
+}
+
+type mongoDelivery struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty"`
+	SubscriptionID primitive.ObjectID `bson:"subscription_id"`
+	Event          outbox.Event       `bson:"event"`
+	Status         string             `bson:"status"`
+	Attempts       int                `bson:"attempts"`
+	LastStatusCode int                `bson:"last_status_code,omitempty"`
+	LastError      string             `bson:"last_error,omitempty"`
+	NextAttemptAt  *time.Time         `bson:"next_attempt_at,omitempty"`
+	CreatedAt      time.Time          `bson:"created_at"`
+	DeliveredAt    *time.Time         `bson:"delivered_at,omitempty"`
+	Log            []Attempt          `bson:"log"`
+}
+
+func (d mongoDelivery) delivery() Delivery {
+	log := d.Log
+	if log == nil {
+		log = []Attempt{}
+	}
+	return Delivery{
+		ID:             d.ID.Hex(),
+		SubscriptionID: d.SubscriptionID.Hex(),
+		Event:          d.Event,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		NextAttemptAt:  d.NextAttemptAt,
+		CreatedAt:      d.CreatedAt,
+		DeliveredAt:    d.DeliveredAt,
+		Log:            log,
+	}
+}
+
+func objectID(id string) (primitive.ObjectID, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return primitive.NilObjectID, apperror.InvalidID()
+	}
+	return objectID, nil
+}
+
+func (s *MongoStore) CreateSubscription(ctx context.Context, subscription *Subscription) error {
+	ctx, span := tracing.StartMongo(ctx, "webhook.MongoStore.CreateSubscription")
+	defer span.End()
+
+	now := time.Now()
+	document := mongoSubscription{
+		ID:          primitive.NewObjectID(),
+		URL:         subscription.URL,
+		EventTypes:  subscription.EventTypes,
+		Description: subscription.Description,
+		Active:      subscription.Active,
+		Secret:      subscription.Secret,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if _, err := s.subscriptions.InsertOne(ctx, document); err != nil {
+		return tracing.Error(span, err)
+	}
+	subscription.ID = document.ID.Hex()
+	subscription.CreatedAt = now
+	subscription.UpdatedAt = now
+	return nil
+}
+
+func (s *MongoStore) ListSubscriptions(ctx context.Context) ([]Subscription, error) {
+	ctx, span := tracing.StartMongo(ctx, "webhook.MongoStore.ListSubscriptions")
+	defer span.End()
+
+	cursor, err := s.subscriptions.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	var documents []mongoSubscription
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	subscriptions := make([]Subscription, len(documents))
+	for i, document := range documents {
+		subscriptions[i] = document.subscription()
+	}
+	return subscriptions, nil
+}
+
+func (s *MongoStore) GetSubscription(ctx context.Context, id string) (*Subscription, error) {
+	ctx, span := tracing.StartMongo(ctx, "webhook.MongoStore.GetSubscription")
+	defer span.End()
+
+	subscriptionID, err := objectID(id)
+	if err != nil {
+		return nil, err
+	}
+	var document mongoSubscription
+	err = s.subscriptions.FindOne(ctx, bson.M{"_id": subscriptionID}).Decode(&document)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	subscription := document.subscription()
+	return &subscription, nil
+}
+
+func (s *MongoStore) UpdateSubscription(ctx context.Context, subscription *Subscription) error {
+	ctx, span := tracing.StartMongo(ctx, "webhook.MongoStore.UpdateSubscription")
+	defer span.End()
+
+	subscriptionID, err := objectID(subscription.ID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	result, err := s.subscriptions.UpdateOne(ctx, bson.M{"_id": subscriptionID}, bson.M{"$set": bson.M{
+		"url":         subscription.URL,
+		"event_types": subscription.EventTypes,
+		"description": subscription.Description,
+		"active":      subscription.Active,
+		"secret":      subscription.Secret,
+		"updated_at":  now,
+	}})
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+	if result.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	subscription.UpdatedAt = now
+	return nil
+}
+
+func (s *MongoStore) DeleteSubscription(ctx context.Context, id string) error {
+	ctx, span := tracing.StartMongo(ctx, "webhook.MongoStore.DeleteSubscription")
+	defer span.End()
+
+	subscriptionID, err := objectID(id)
+	if err != nil {
+		return err
+	}
+	result, err := s.subscriptions.DeleteOne(ctx, bson.M{"_id": subscriptionID})
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+	if result.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	_, err = s.deliveries.DeleteMany(ctx, bson.M{"subscription_id": subscriptionID})
+	return tracing.Error(span, err)
+}
+
+func (s *MongoStore) EnqueueDeliveries(ctx context.Context, deliveries ...Delivery) error {
+	ctx, span := tracing.StartMongo(ctx, "webhook.MongoStore.EnqueueDeliveries")
+	defer span.End()
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+	documents := make([]interface{}, len(deliveries))
+	for i, delivery := range deliveries {
+		subscriptionID, err := objectID(delivery.SubscriptionID)
+		if err != nil {
+			return err
+		}
+		documents[i] = mongoDelivery{
+			ID:             primitive.NewObjectID(),
+			SubscriptionID: subscriptionID,
+			Event:          delivery.Event,
+			Status:         delivery.Status,
+			NextAttemptAt:  delivery.NextAttemptAt,
+			CreatedAt:      delivery.CreatedAt,
+			Log:            []Attempt{},
+		}
+	}
+	// Insert unordered tetap menyimpan delivery lain saat sebagian bentrok
+	// dengan index unik; bentrok itu berarti delivery sudah ada.
+	_, err := s.deliveries.InsertMany(ctx, documents, options.InsertMany().SetOrdered(false))
+	if err != nil && !onlyDuplicateKeys(err) {
+		return tracing.Error(span, err)
+	}
+	return nil
+}
+
+func onlyDuplicateKeys(err error) bool {
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil {
+		return false
+	}
+	for _, writeErr := range bulkErr.WriteErrors {
+		if writeErr.Code != 11000 {
+			return false
+		}
+	}
+	return true
+}
+
+// ClaimDeliveries mengambil delivery satu per satu dengan FindOneAndUpdate
+// sehingga setiap delivery hanya didapat oleh satu worker selama lease.
+func (s *MongoStore) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]Delivery, error) {
+	ctx, span := tracing.StartMongo(ctx, "webhook.MongoStore.ClaimDeliveries")
+	defer span.End()
+
+	now := time.Now()
+	filter := bson.M{"status": StatusPending, "next_attempt_at": bson.M{"$lte": now}}
+	update := bson.M{"$set": bson.M{"next_attempt_at": now.Add(lease)}}
+	opts := options.FindOneAndUpdate().SetSort(bson.D{{Key: "next_attempt_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	var deliveries []Delivery
+	for len(deliveries) < limit {
+		var document mongoDelivery
+		err := s.deliveries.FindOneAndUpdate(ctx, filter, update, opts).Decode(&document)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			break
+		}
+		if err != nil {
+			return deliveries, tracing.Error(span, err)
+		}
+		deliveries = append(deliveries, document.delivery())
+	}
+	return deliveries, nil
+}
+
+func (s *MongoStore) RecordAttempt(ctx context.Context, id string, attempt Attempt, status string, next time.Time) error {
+	ctx, span := tracing.StartMongo(ctx, "webhook.MongoStore.RecordAttempt")
+	defer span.End()
+
+	deliveryID, err := objectID(id)
+	if err != nil {
+		return err
+	}
+	set := bson.M{"status": status, "attempts": attempt.Attempt}
+	unset := bson.M{}
+	if attempt.StatusCode != 0 {
+		set["last_status_code"] = attempt.StatusCode
+	} else {
+		unset["last_status_code"] = ""
+	}
+	if attempt.Error != "" {
+		set["last_error"] = attempt.Error
+	} else {
+		unset["last_error"] = ""
+	}
+	switch status {
+	case StatusPending:
+		set["next_attempt_at"] = next
+	case StatusSucceeded:
+		set["delivered_at"] = attempt.At
+		unset["next_attempt_at"] = ""
+	default:
+		unset["next_attempt_at"] = ""
+	}
+	update := bson.M{"$set": set, "$push": bson.M{"log": attempt}}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	filter := bson.M{"_id": deliveryID, "status": StatusPending, "attempts": attempt.Attempt - 1}
+	result, err := s.deliveries.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+	if result.MatchedCount == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func (s *MongoStore) ListDeliveries(ctx context.Context, filter DeliveryFilter) ([]Delivery, int64, error) {
+	ctx, span := tracing.StartMongo(ctx, "webhook.MongoStore.ListDeliveries")
+	defer span.End()
+
+	query := bson.M{}
+	if filter.SubscriptionID != "" {
+		subscriptionID, err := objectID(filter.SubscriptionID)
+		if err != nil {
+			return nil, 0, err
+		}
+		query["subscription_id"] = subscriptionID
+	}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+
+	total, err := s.deliveries.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, tracing.Error(span, err)
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(filter.Offset())).
+		SetLimit(int64(filter.Limit))
+	cursor, err := s.deliveries.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, tracing.Error(span, err)
+	}
+	var documents []mongoDelivery
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, 0, tracing.Error(span, err)
+	}
+	deliveries := make([]Delivery, len(documents))
+	for i, document := range documents {
+		deliveries[i] = document.delivery()
+	}
+	return deliveries, total, nil
+}
+
+func (s *MongoStore) GetDelivery(ctx context.Context, id string) (*Delivery, error) {
+	ctx, span := tracing.StartMongo(ctx, "webhook.MongoStore.GetDelivery")
+	defer span.End()
+
+	deliveryID, err := objectID(id)
+	if err != nil {
+		return nil, err
+	}
+	var document mongoDelivery
+	err = s.deliveries.FindOne(ctx, bson.M{"_id": deliveryID}).Decode(&document)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	delivery := document.delivery()
+	return &delivery, nil
+}
+
+func (s *MongoStore) Redeliver(ctx context.Context, id string) (*Delivery, error) {
+	ctx, span := tracing.StartMongo(ctx, "webhook.MongoStore.Redeliver")
+	defer span.End()
+
+	deliveryID, err := objectID(id)
+	if err != nil {
+		return nil, err
+	}
+	update := bson.M{
+		"$set":   bson.M{"status": StatusPending, "attempts": 0, "next_attempt_at": time.Now()},
+		"$unset": bson.M{"delivered_at": ""},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var document mongoDelivery
+	filter := bson.M{"_id": deliveryID, "status": bson.M{"$in": bson.A{StatusDead, StatusSucceeded}}}
+	err = s.deliveries.FindOneAndUpdate(ctx, filter, update, opts).Decode(&document)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		count, err := s.deliveries.CountDocuments(ctx, bson.M{"_id": deliveryID}, options.Count().SetLimit(1))
+		if err != nil {
+			return nil, tracing.Error(span, err)
+		}
+		if count > 0 {
+			return nil, ErrInvalidState
+		}
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	delivery := document.delivery()
+	return &delivery, nil
+}
@@ -0,0 +1,370 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"go-fiber/apperror"
+	"go-fiber/outbox"
+	"go-fiber/tracing"
+
+	"github.com/lib/pq"
+)
+
+// PostgresStore menyimpan subscription dan delivery di tabel
+// webhook_subscriptions dan webhook_deliveries (dibuat oleh
+// RunPostgresMigrations). Dengan *sql.Tx delivery ikut tersimpan atau batal
+// bersama transaksi pemanggil.
+type PostgresStore struct {
+	db outbox.DBTX
+}
+
+func NewPostgresStore(db outbox.DBTX) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// parseID mengubah ID string menjadi BIGSERIAL. ID yang bukan angka positif
+// tidak mungkin ada di tabel.
+func parseID(id string) (int64, error) {
+	value, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || value <= 0 {
+		return 0, apperror.InvalidID()
+	}
+	return value, nil
+}
+
+const subscriptionColumns = `id, url, event_types, description, active, secret, created_at, updated_at`
+
+func scanSubscription(row interface{ Scan(...interface{}) error }) (*Subscription, error) {
+	var subscription Subscription
+	var id int64
+	err := row.Scan(&id, &subscription.URL, pq.Array(&subscription.EventTypes), &subscription.Description,
+		&subscription.Active, &subscription.Secret, &subscription.CreatedAt, &subscription.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	subscription.ID = strconv.FormatInt(id, 10)
+	return &subscription, nil
+}
+
+func (s *PostgresStore) CreateSubscription(ctx context.Context, subscription *Subscription) error {
+	ctx, span := tracing.StartPostgres(ctx, "webhook.PostgresStore.CreateSubscription")
+	defer span.End()
+
+	var id int64
+	err := s.db.QueryRowContext(ctx, `
+		INSERT INTO webhook_subscriptions (url, event_types, description, active, secret, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+		RETURNING id, created_at, updated_at
+	`, subscription.URL, pq.Array(subscription.EventTypes), subscription.Description, subscription.Active, subscription.Secret,
+	).Scan(&id, &subscription.CreatedAt, &subscription.UpdatedAt)
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+	subscription.ID = strconv.FormatInt(id, 10)
+	return nil
+}
+
+func (s *PostgresStore) ListSubscriptions(ctx context.Context) ([]Subscription, error) {
+	ctx, span := tracing.StartPostgres(ctx, "webhook.PostgresStore.ListSubscriptions")
+	defer span.End()
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+subscriptionColumns+` FROM webhook_subscriptions ORDER BY id`)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	defer rows.Close()
+
+	subscriptions := []Subscription{}
+	for rows.Next() {
+		subscription, err := scanSubscription(rows)
+		if err != nil {
+			return nil, tracing.Error(span, err)
+		}
+		subscriptions = append(subscriptions, *subscription)
+	}
+	return subscriptions, tracing.Error(span, rows.Err())
+}
+
+func (s *PostgresStore) GetSubscription(ctx context.Context, id string) (*Subscription, error) {
+	ctx, span := tracing.StartPostgres(ctx, "webhook.PostgresStore.GetSubscription")
+	defer span.End()
+
+	subscriptionID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+	row := s.db.QueryRowContext(ctx, `SELECT `+subscriptionColumns+` FROM webhook_subscriptions WHERE id = $1`, subscriptionID)
+	subscription, err := scanSubscription(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	return subscription, nil
+}
+
+func (s *PostgresStore) UpdateSubscription(ctx context.Context, subscription *Subscription) error {
+	ctx, span := tracing.StartPostgres(ctx, "webhook.PostgresStore.UpdateSubscription")
+	defer span.End()
+
+	subscriptionID, err := parseID(subscription.ID)
+	if err != nil {
+		return err
+	}
+	err = s.db.QueryRowContext(ctx, `
+		UPDATE webhook_subscriptions
+		SET url = $2, event_types = $3, description = $4, active = $5, secret = $6, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at
+	`, subscriptionID, subscription.URL, pq.Array(subscription.EventTypes), subscription.Description,
+		subscription.Active, subscription.Secret,
+	).Scan(&subscription.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	return tracing.Error(span, err)
+}
+
+// DeleteSubscription mengandalkan ON DELETE CASCADE untuk menghapus
+// delivery-nya.
+func (s *PostgresStore) DeleteSubscription(ctx context.Context, id string) error {
+	ctx, span := tracing.StartPostgres(ctx, "webhook.PostgresStore.DeleteSubscription")
+	defer span.End()
+
+	subscriptionID, err := parseID(id)
+	if err != nil {
+		return err
+	}
+	result, err := s.db.ExecContext(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1`, subscriptionID)
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *PostgresStore) EnqueueDeliveries(ctx context.Context, deliveries ...Delivery) error {
+	ctx, span := tracing.StartPostgres(ctx, "webhook.PostgresStore.EnqueueDeliveries")
+	defer span.End()
+
+	query := `
+		INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload, status, next_attempt_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (subscription_id, event_id) DO NOTHING
+	`
+	for _, delivery := range deliveries {
+		subscriptionID, err := parseID(delivery.SubscriptionID)
+		if err != nil {
+			return err
+		}
+		payload, err := json.Marshal(delivery.Event)
+		if err != nil {
+			return tracing.Error(span, err)
+		}
+		_, err = s.db.ExecContext(ctx, query, subscriptionID, delivery.Event.ID, delivery.Event.Type, payload,
+			delivery.Status, delivery.NextAttemptAt, delivery.CreatedAt)
+		if err != nil {
+			return tracing.Error(span, err)
+		}
+	}
+	return nil
+}
+
+const deliveryColumns = `id, subscription_id, payload, status, attempts, last_status_code, last_error,
+	next_attempt_at, created_at, delivered_at, attempt_log`
+
+func scanDelivery(row interface{ Scan(...interface{}) error }) (*Delivery, error) {
+	var delivery Delivery
+	var id, subscriptionID int64
+	var payload, attemptLog []byte
+	var lastStatusCode sql.NullInt64
+	var lastError sql.NullString
+	var nextAttemptAt, deliveredAt sql.NullTime
+	err := row.Scan(&id, &subscriptionID, &payload, &delivery.Status, &delivery.Attempts, &lastStatusCode, &lastError,
+		&nextAttemptAt, &delivery.CreatedAt, &deliveredAt, &attemptLog)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(payload, &delivery.Event); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(attemptLog, &delivery.Log); err != nil {
+		return nil, err
+	}
+	delivery.ID = strconv.FormatInt(id, 10)
+	delivery.SubscriptionID = strconv.FormatInt(subscriptionID, 10)
+	delivery.LastStatusCode = int(lastStatusCode.Int64)
+	delivery.LastError = lastError.String
+	if nextAttemptAt.Valid {
+		delivery.NextAttemptAt = &nextAttemptAt.Time
+	}
+	if deliveredAt.Valid {
+		delivery.DeliveredAt = &deliveredAt.Time
+	}
+	return &delivery, nil
+}
+
+func scanDeliveries(rows *sql.Rows) ([]Delivery, error) {
+	defer rows.Close()
+	deliveries := []Delivery{}
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, *delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+// ClaimDeliveries memakai FOR UPDATE SKIP LOCKED seperti outbox, sehingga
+// beberapa instance bisa mengirim bersamaan tanpa mengambil delivery yang
+// sama.
+func (s *PostgresStore) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]Delivery, error) {
+	ctx, span := tracing.StartPostgres(ctx, "webhook.PostgresStore.ClaimDeliveries")
+	defer span.End()
+
+	rows, err := s.db.QueryContext(ctx, `
+		UPDATE webhook_deliveries SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at, id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+deliveryColumns,
+		limit, time.Now().Add(lease).UTC())
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	deliveries, err := scanDeliveries(rows)
+	return deliveries, tracing.Error(span, err)
+}
+
+func (s *PostgresStore) RecordAttempt(ctx context.Context, id string, attempt Attempt, status string, next time.Time) error {
+	ctx, span := tracing.StartPostgres(ctx, "webhook.PostgresStore.RecordAttempt")
+	defer span.End()
+
+	deliveryID, err := parseID(id)
+	if err != nil {
+		return err
+	}
+	entry, err := json.Marshal([]Attempt{attempt})
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+	var nextAttemptAt, deliveredAt *time.Time
+	switch status {
+	case StatusPending:
+		nextAttemptAt = &next
+	case StatusSucceeded:
+		deliveredAt = &attempt.At
+	}
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, last_status_code = NULLIF($4, 0), last_error = NULLIF($5, ''),
+			next_attempt_at = $6, delivered_at = $7, attempt_log = attempt_log || $8::jsonb
+		WHERE id = $1 AND status = 'pending' AND attempts = $9
+	`, deliveryID, status, attempt.Attempt, attempt.StatusCode, attempt.Error, nextAttemptAt, deliveredAt, entry, attempt.Attempt-1)
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+	if affected == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func (s *PostgresStore) ListDeliveries(ctx context.Context, filter DeliveryFilter) ([]Delivery, int64, error) {
+	ctx, span := tracing.StartPostgres(ctx, "webhook.PostgresStore.ListDeliveries")
+	defer span.End()
+
+	where := `WHERE ($1 = 0 OR subscription_id = $1) AND ($2 = '' OR status = $2)`
+	var subscriptionID int64
+	if filter.SubscriptionID != "" {
+		var err error
+		if subscriptionID, err = parseID(filter.SubscriptionID); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	var total int64
+	err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM webhook_deliveries `+where, subscriptionID, filter.Status).Scan(&total)
+	if err != nil {
+		return nil, 0, tracing.Error(span, err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+deliveryColumns+` FROM webhook_deliveries `+where+`
+		ORDER BY created_at DESC, id DESC LIMIT $3 OFFSET $4`,
+		subscriptionID, filter.Status, filter.Limit, filter.Offset())
+	if err != nil {
+		return nil, 0, tracing.Error(span, err)
+	}
+	deliveries, err := scanDeliveries(rows)
+	if err != nil {
+		return nil, 0, tracing.Error(span, err)
+	}
+	return deliveries, total, nil
+}
+
+func (s *PostgresStore) GetDelivery(ctx context.Context, id string) (*Delivery, error) {
+	ctx, span := tracing.StartPostgres(ctx, "webhook.PostgresStore.GetDelivery")
+	defer span.End()
+
+	deliveryID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+	row := s.db.QueryRowContext(ctx, `SELECT `+deliveryColumns+` FROM webhook_deliveries WHERE id = $1`, deliveryID)
+	delivery, err := scanDelivery(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	return delivery, nil
+}
+
+func (s *PostgresStore) Redeliver(ctx context.Context, id string) (*Delivery, error) {
+	ctx, span := tracing.StartPostgres(ctx, "webhook.PostgresStore.Redeliver")
+	defer span.End()
+
+	deliveryID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+	row := s.db.QueryRowContext(ctx, `
+		UPDATE webhook_deliveries
+		SET status = 'pending', attempts = 0, next_attempt_at = NOW(), delivered_at = NULL
+		WHERE id = $1 AND status IN ('dead', 'succeeded')
+		RETURNING `+deliveryColumns, deliveryID)
+	delivery, err := scanDelivery(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		var exists bool
+		if err := s.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM webhook_deliveries WHERE id = $1)`, deliveryID).Scan(&exists); err != nil {
+			return nil, tracing.Error(span, err)
+		}
+		if exists {
+			return nil, ErrInvalidState
+		}
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+	return delivery, nil
+}
@@ -0,0 +1,43 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-fiber/outbox"
+)
+
+// DefaultTolerance adalah selisih waktu maksimum antara timestamp request
+// dan jam penerima yang diterima Verify.
+const DefaultTolerance = 5 * time.Minute
+
+var (
+	ErrMissingSignature = errors.New("webhook: header signature atau timestamp tidak ada")
+	ErrInvalidSignature = errors.New("webhook: signature tidak cocok")
+	ErrTimestampExpired = errors.New("webhook: timestamp di luar toleransi")
+)
+
+// Verify memeriksa request webhook di sisi penerima: signature harus cocok
+// dengan body dan secret subscription, dan timestamp tidak boleh lebih jauh
+// dari tolerance agar request lama tidak bisa diputar ulang.
+func Verify(secret string, header http.Header, body []byte, tolerance time.Duration) error {
+	signature := header.Get(outbox.HeaderSignature)
+	rawTimestamp := header.Get(outbox.HeaderTimestamp)
+	if signature == "" || rawTimestamp == "" {
+		return ErrMissingSignature
+	}
+	timestamp, err := strconv.ParseInt(rawTimestamp, 10, 64)
+	if err != nil {
+		return ErrMissingSignature
+	}
+	if !hmac.Equal([]byte(signature), []byte(outbox.Sign(secret, timestamp, body))) {
+		return ErrInvalidSignature
+	}
+	if age := time.Since(time.Unix(timestamp, 0)); age > tolerance || age < -tolerance {
+		return ErrTimestampExpired
+	}
+	return nil
+}
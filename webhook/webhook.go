@@ -0,0 +1,288 @@
+// Package webhook mengirim domain event dari package outbox ke URL yang
+// didaftarkan admin sebagai subscription. Dispatcher dipasang sebagai sink
+// relay outbox dan membuat satu delivery per subscription yang cocok;
+// Deliverer mengirim delivery di background dengan tanda tangan HMAC, retry
+// dengan backoff, dan menandai delivery dead setelah batas percobaan.
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-fiber/apperror"
+	"go-fiber/audit"
+	"go-fiber/outbox"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Status delivery.
+const (
+	StatusPending   = "pending"
+	StatusSucceeded = "succeeded"
+	// StatusDead menandai delivery yang tetap gagal setelah batas percobaan,
+	// atau subscription-nya tidak aktif. Delivery ini masuk dead-letter list
+	// dan hanya dikirim lagi lewat redeliver.
+	StatusDead = "dead"
+)
+
+// TestEventType adalah tipe event yang dikirim endpoint test subscription.
+const TestEventType = "webhook.test"
+
+var (
+	// ErrNotFound dikembalikan Store jika subscription atau delivery tidak ada.
+	ErrNotFound = errors.New("webhook: data tidak ditemukan")
+	// ErrInvalidState dikembalikan Redeliver jika delivery masih pending,
+	// termasuk yang sedang dikirim, sehingga delivery tidak terkirim dua kali.
+	ErrInvalidState = errors.New("webhook: status delivery tidak mengizinkan aksi ini")
+	// ErrLeaseLost dikembalikan RecordAttempt jika delivery sudah tidak
+	// dipegang oleh percobaan tersebut, misalnya lease-nya habis lalu
+	// delivery diambil worker lain. Hasil percobaan lama tidak dicatat.
+	ErrLeaseLost = errors.New("webhook: lease delivery sudah diambil alih")
+)
+
+// Subscription adalah URL tujuan beserta tipe event yang ingin diterima.
+// EventTypes berisi tipe persis (alumni.created), semua aksi satu resource
+// (alumni.*), atau semua event (*). Secret hanya dikirim ke client saat
+// subscription dibuat, lihat WithoutSecret.
+type Subscription struct {
+	ID          string    `json:"id"`
+	URL         string    `json:"url"`
+	EventTypes  []string  `json:"event_types"`
+	Description string    `json:"description"`
+	Active      bool      `json:"active"`
+	Secret      string    `json:"secret,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Matches melaporkan apakah subscription aktif dan menerima eventType.
+func (s Subscription) Matches(eventType string) bool {
+	if !s.Active {
+		return false
+	}
+	for _, pattern := range s.EventTypes {
+		if outbox.MatchType(pattern, eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithoutSecret mengembalikan salinan s tanpa secret untuk response.
+func (s Subscription) WithoutSecret() Subscription {
+	s.Secret = ""
+	return s
+}
+
+// Attempt adalah log satu percobaan kirim. Response berisi awal body
+// response penerima untuk membantu penelusuran.
+type Attempt struct {
+	Attempt    int       `json:"attempt" bson:"attempt"`
+	At         time.Time `json:"at" bson:"at"`
+	StatusCode int       `json:"status_code,omitempty" bson:"status_code,omitempty"`
+	Error      string    `json:"error,omitempty" bson:"error,omitempty"`
+	DurationMS int64     `json:"duration_ms" bson:"duration_ms"`
+	Response   string    `json:"response,omitempty" bson:"response,omitempty"`
+}
+
+// Delivery adalah pengiriman satu event ke satu subscription. Attempts
+// dihitung sejak delivery dibuat atau terakhir di-redeliver; Log berisi
+// semua percobaan, terlama lebih dulu.
+type Delivery struct {
+	ID             string       `json:"id"`
+	SubscriptionID string       `json:"subscription_id"`
+	Event          outbox.Event `json:"event"`
+	Status         string       `json:"status"`
+	Attempts       int          `json:"attempts"`
+	LastStatusCode int          `json:"last_status_code,omitempty"`
+	LastError      string       `json:"last_error,omitempty"`
+	NextAttemptAt  *time.Time   `json:"next_attempt_at,omitempty"`
+	CreatedAt      time.Time    `json:"created_at"`
+	DeliveredAt    *time.Time   `json:"delivered_at,omitempty"`
+	Log            []Attempt    `json:"log"`
+}
+
+// Store menyimpan subscription dan delivery. ID yang formatnya salah
+// menghasilkan apperror.InvalidID, ID yang tidak ada menghasilkan
+// ErrNotFound.
+type Store interface {
+	CreateSubscription(ctx context.Context, subscription *Subscription) error
+	ListSubscriptions(ctx context.Context) ([]Subscription, error)
+	GetSubscription(ctx context.Context, id string) (*Subscription, error)
+	UpdateSubscription(ctx context.Context, subscription *Subscription) error
+	// DeleteSubscription juga menghapus semua delivery subscription.
+	DeleteSubscription(ctx context.Context, id string) error
+
+	// EnqueueDeliveries menyimpan delivery baru. Delivery dengan pasangan
+	// subscription dan event yang sudah ada diabaikan, sehingga event yang
+	// dikirim ulang oleh relay outbox tidak membuat delivery ganda.
+	EnqueueDeliveries(ctx context.Context, deliveries ...Delivery) error
+	// ClaimDeliveries mengambil paling banyak limit delivery pending yang
+	// sudah waktunya dikirim dan menahannya selama lease.
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]Delivery, error)
+	// RecordAttempt menambahkan attempt ke log dan menyimpan status serta
+	// jadwal percobaan berikutnya. Perubahan hanya disimpan jika delivery
+	// masih pending dengan attempts sama seperti saat di-claim, yaitu
+	// attempt.Attempt - 1; jika tidak, hasilnya ErrLeaseLost.
+	RecordAttempt(ctx context.Context, id string, attempt Attempt, status string, next time.Time) error
+	ListDeliveries(ctx context.Context, filter DeliveryFilter) ([]Delivery, int64, error)
+	GetDelivery(ctx context.Context, id string) (*Delivery, error)
+	// Redeliver menjadwalkan ulang delivery yang sudah dead atau succeeded
+	// untuk segera dikirim dengan hitungan percobaan dari nol. Log percobaan
+	// sebelumnya tetap ada. Delivery yang masih pending menghasilkan
+	// ErrInvalidState.
+	Redeliver(ctx context.Context, id string) (*Delivery, error)
+}
+
+// SubscriptionRequest adalah body create dan update subscription. Secret
+// kosong saat create dibuatkan otomatis, dan saat update berarti secret lama
+// tetap dipakai. Active kosong berarti aktif saat create dan tidak berubah
+// saat update.
+type SubscriptionRequest struct {
+	URL         string   `json:"url" validate:"required,http_url,max=2048"`
+	EventTypes  []string `json:"event_types" validate:"required,min=1,max=50,dive,event_type"`
+	Description string   `json:"description" validate:"max=255"`
+	Secret      string   `json:"secret" validate:"omitempty,min=16,max=255"`
+	Active      *bool    `json:"active"`
+}
+
+// NewSubscription membentuk subscription baru dari req.
+func NewSubscription(req SubscriptionRequest) (Subscription, error) {
+	subscription := Subscription{Active: true, Secret: req.Secret}
+	if subscription.Secret == "" {
+		secret, err := GenerateSecret()
+		if err != nil {
+			return Subscription{}, err
+		}
+		subscription.Secret = secret
+	}
+	req.applyTo(&subscription)
+	return subscription, nil
+}
+
+// Apply menerapkan req pada subscription yang sudah ada.
+func (req SubscriptionRequest) Apply(subscription *Subscription) {
+	if req.Secret != "" {
+		subscription.Secret = req.Secret
+	}
+	req.applyTo(subscription)
+}
+
+func (req SubscriptionRequest) applyTo(subscription *Subscription) {
+	subscription.URL = req.URL
+	subscription.EventTypes = uniqueTypes(req.EventTypes)
+	subscription.Description = strings.TrimSpace(req.Description)
+	if req.Active != nil {
+		subscription.Active = *req.Active
+	}
+}
+
+func uniqueTypes(types []string) []string {
+	seen := map[string]bool{}
+	unique := make([]string, 0, len(types))
+	for _, eventType := range types {
+		eventType = strings.TrimSpace(eventType)
+		if !seen[eventType] {
+			seen[eventType] = true
+			unique = append(unique, eventType)
+		}
+	}
+	return unique
+}
+
+// GenerateSecret membuat secret acak 32 byte dalam bentuk hex.
+func GenerateSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// NewDelivery membuat delivery pending untuk event ke subscription.
+func NewDelivery(subscriptionID string, event outbox.Event) Delivery {
+	now := time.Now().UTC()
+	return Delivery{
+		SubscriptionID: subscriptionID,
+		Event:          event,
+		Status:         StatusPending,
+		NextAttemptAt:  &now,
+		CreatedAt:      now,
+	}
+}
+
+// NewTestEvent membuat event webhook.test untuk memeriksa URL dan secret
+// subscription tanpa menunggu perubahan data. Actor dan request ID diambil
+// dari request admin seperti entry audit.
+func NewTestEvent(c *fiber.Ctx, subscription Subscription) outbox.Event {
+	entry := audit.NewEntry(c, "test", "webhook", subscription.ID, nil, nil)
+	return outbox.Event{
+		ID:         uuid.New().String(),
+		Type:       TestEventType,
+		Resource:   "webhook",
+		ResourceID: subscription.ID,
+		Action:     "test",
+		Data:       map[string]interface{}{"subscription_id": subscription.ID, "url": subscription.URL},
+		ActorID:    entry.ActorID,
+		RequestID:  entry.RequestID,
+		OccurredAt: entry.CreatedAt,
+	}
+}
+
+// DeliveryFilter membatasi delivery yang dikembalikan ListDeliveries. Field
+// kosong tidak membatasi apa pun.
+type DeliveryFilter struct {
+	SubscriptionID string
+	Status         string
+	Page           int
+	Limit          int
+}
+
+// Offset adalah jumlah delivery yang dilewati untuk halaman Page.
+func (f DeliveryFilter) Offset() int {
+	return (f.Page - 1) * f.Limit
+}
+
+// ParseDeliveryFilter membaca query status, page, dan limit.
+func ParseDeliveryFilter(c *fiber.Ctx) (DeliveryFilter, error) {
+	filter := DeliveryFilter{Status: strings.ToLower(strings.TrimSpace(c.Query("status")))}
+	switch filter.Status {
+	case "", StatusPending, StatusSucceeded, StatusDead:
+	default:
+		return DeliveryFilter{}, apperror.Validation("webhook.invalid_status", filter.Status)
+	}
+
+	filter.Page, _ = strconv.Atoi(c.Query("page", "1"))
+	filter.Limit, _ = strconv.Atoi(c.Query("limit", "20"))
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Limit < 1 || filter.Limit > 100 {
+		filter.Limit = 20
+	}
+	return filter, nil
+}
+
+// Meta adalah informasi pagination pada response daftar delivery.
+type Meta struct {
+	Page  int   `json:"page"`
+	Limit int   `json:"limit"`
+	Total int64 `json:"total"`
+	Pages int64 `json:"pages"`
+}
+
+// NewMeta menghitung jumlah halaman dari total delivery.
+func NewMeta(filter DeliveryFilter, total int64) Meta {
+	pages := (total + int64(filter.Limit) - 1) / int64(filter.Limit)
+	if pages == 0 {
+		pages = 1
+	}
+	return Meta{Page: filter.Page, Limit: filter.Limit, Total: total, Pages: pages}
+}
@@ -0,0 +1,343 @@
+package worker
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-fiber/apperror"
+	"go-fiber/helper"
+	"go-fiber/i18n"
+	"go-fiber/queue"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const (
+	// ExportJobType membuat file export di background.
+	ExportJobType = "export.generate"
+	// ExportCleanupJobType menghapus file export yang lebih tua dari TTL.
+	ExportCleanupJobType = "export.cleanup"
+)
+
+const (
+	ExportStatusPending   = "pending"
+	ExportStatusRunning   = "running"
+	ExportStatusCompleted = "completed"
+	ExportStatusFailed    = "failed"
+)
+
+// ExportFunc menulis hasil export ke w. Fungsi ini tidak boleh memakai
+// *fiber.Ctx karena bisa dijalankan setelah request selesai; gunakan ctx yang
+// diberikan, yang tetap membawa trace request asal.
+type ExportFunc func(ctx context.Context, w io.Writer) error
+
+// ExportBuilder menyiapkan export dari request yang disimpan di payload job,
+// sehingga export yang sama bisa dijalankan langsung atau oleh worker queue
+// di instance mana pun. Error dari builder dikembalikan sebelum ada data
+// yang ditulis.
+type ExportBuilder func(ctx context.Context, request json.RawMessage) (ExportFunc, error)
+
+// ExportPayload adalah payload job ExportJobType. File adalah nama file hasil
+// di folder export dan FileName nama file saat diunduh.
+type ExportPayload struct {
+	File      string          `json:"file"`
+	FileName  string          `json:"file_name"`
+	Resource  string          `json:"resource"`
+	Format    string          `json:"format"`
+	CreatedBy string          `json:"created_by"`
+	Request   json.RawMessage `json:"request"`
+}
+
+// ExportJob adalah status job export yang ditampilkan ke admin. ID sama
+// dengan ID job di queue.
+type ExportJob struct {
+	ID         string     `json:"id"`
+	Resource   string     `json:"resource"`
+	Format     string     `json:"format"`
+	Status     string     `json:"status"`
+	Error      string     `json:"error,omitempty"`
+	CreatedBy  string     `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	FileName   string     `json:"file_name"`
+}
+
+// Exporter mengirim export langsung sebagai stream atau menjalankannya
+// sebagai job ExportJobType di queue. File hasil disimpan di dir selama ttl
+// lalu dihapus job ExportCleanupJobType. Jika aplikasi berjalan di beberapa
+// instance, dir harus berupa storage bersama karena job bisa dijalankan dan
+// diunduh dari instance yang berbeda.
+type Exporter struct {
+	queue *queue.Queue
+	dir   string
+	ttl   time.Duration
+	build ExportBuilder
+}
+
+// NewExporter mendaftarkan handler export dan, jika ttl lebih dari 0,
+// pembersihan file setiap jam ke q. q nil berarti job queue dimatikan
+// sehingga export hanya bisa dikirim langsung. Error dari penjadwalan
+// pembersihan dikembalikan.
+func NewExporter(q *queue.Queue, dir string, ttl time.Duration, build ExportBuilder, opts queue.HandlerOptions) (*Exporter, error) {
+	e := &Exporter{queue: q, dir: dir, ttl: ttl, build: build}
+	if q == nil {
+		return e, nil
+	}
+
+	queue.Register(q, ExportJobType, e.generate, opts)
+	if ttl > 0 {
+		queue.Register(q, ExportCleanupJobType, e.cleanup, queue.HandlerOptions{Concurrency: 1, MaxAttempts: 1})
+		if err := q.Schedule(ExportCleanupJobType, "@hourly", ExportCleanupJobType, struct{}{}); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// Async melaporkan apakah export bisa dijalankan sebagai job background.
+func (e *Exporter) Async() bool {
+	return e.queue != nil
+}
+
+func (e *Exporter) generate(ctx context.Context, payload ExportPayload) error {
+	run, err := e.build(ctx, payload.Request)
+	if err != nil {
+		// Request yang ditolak builder tidak akan berhasil walaupun dicoba lagi.
+		var appErr *apperror.Error
+		if errors.As(err, &appErr) && appErr.Status < fiber.StatusInternalServerError {
+			return queue.Permanent(err)
+		}
+		return err
+	}
+
+	if err := os.MkdirAll(e.dir, 0755); err != nil {
+		return err
+	}
+	path := filepath.Join(e.dir, payload.File)
+	if err := writeFile(ctx, path, run); err != nil {
+		os.Remove(path)
+		return err
+	}
+	return nil
+}
+
+func writeFile(ctx context.Context, path string, run ExportFunc) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	if err := run(ctx, writer); err != nil {
+		return err
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+func (e *Exporter) cleanup(ctx context.Context, _ struct{}) error {
+	removed, err := RemoveExpiredFiles(e.dir, time.Now().Add(-e.ttl), nil)
+	if err != nil {
+		return err
+	}
+	if removed > 0 {
+		slog.InfoContext(ctx, "Export kedaluwarsa dihapus", "dir", e.dir, "removed", removed)
+	}
+	return nil
+}
+
+// RemoveExpiredFiles menghapus file di dir yang terakhir diubah sebelum
+// before, kecuali yang namanya ada di keep. Folder yang belum ada tidak
+// dianggap error.
+func RemoveExpiredFiles(dir string, before time.Time, keep map[string]bool) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, entry := range entries {
+		if entry.IsDir() || keep[entry.Name()] {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || !info.ModTime().Before(before) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// Submit membuat job export untuk request yang nanti dibaca builder.
+func (e *Exporter) Submit(ctx context.Context, resource, format, createdBy string, request interface{}) (ExportJob, error) {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return ExportJob{}, err
+	}
+	payload := ExportPayload{
+		File:      uuid.New().String() + "." + format,
+		FileName:  helper.ExportFileName(resource, format),
+		Resource:  resource,
+		Format:    format,
+		CreatedBy: createdBy,
+		Request:   data,
+	}
+	job, err := queue.Enqueue(ctx, e.queue.Store(), ExportJobType, payload, queue.EnqueueOptions{})
+	if err != nil {
+		return ExportJob{}, err
+	}
+	return exportJob(job, payload), nil
+}
+
+// Send mengirim hasil export langsung sebagai stream, atau membuat job
+// background jika async bernilai true. request harus bisa di-encode sebagai
+// JSON karena dibaca lagi oleh builder, baik di request ini maupun di worker.
+func (e *Exporter) Send(c *fiber.Ctx, async bool, resource, format string, request interface{}) error {
+	if async {
+		if e.queue == nil {
+			return apperror.New(fiber.StatusServiceUnavailable, "jobs_disabled", "export.async_disabled")
+		}
+		createdBy, _ := c.Locals("email").(string)
+		job, err := e.Submit(c.UserContext(), resource, format, createdBy, request)
+		if err != nil {
+			return apperror.Wrap(err, "export.job_create_failed")
+		}
+
+		return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+			"success": true,
+			"message": i18n.Msg(c, "export.accepted"),
+			"data":    job,
+		})
+	}
+
+	data, err := json.Marshal(request)
+	if err != nil {
+		return apperror.Internal("export.job_create_failed", err)
+	}
+	run, err := e.build(c.UserContext(), data)
+	if err != nil {
+		return err
+	}
+
+	// Stream ditulis setelah handler return, jadi context-nya tidak ikut
+	// dibatalkan saat request selesai.
+	ctx := context.WithoutCancel(c.UserContext())
+	requestID, _ := c.Locals("request_id").(string)
+	c.Set(fiber.HeaderContentType, helper.ExportContentTypes[format])
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, helper.ExportFileName(resource, format)))
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := run(ctx, w); err != nil {
+			slog.Error("Export gagal", "request_id", requestID, "resource", resource, "error", err)
+		}
+		w.Flush()
+	})
+	return nil
+}
+
+// JobStatusHandler mengembalikan status job milik admin yang sedang login.
+func (e *Exporter) JobStatusHandler(c *fiber.Ctx) error {
+	job, _, err := e.ownedJob(c)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "export.status_fetched"),
+		"data":    job,
+	})
+}
+
+// DownloadHandler mengirim file hasil job yang sudah selesai.
+func (e *Exporter) DownloadHandler(c *fiber.Ctx) error {
+	job, payload, err := e.ownedJob(c)
+	if err != nil {
+		return err
+	}
+
+	if job.Status != ExportStatusCompleted {
+		return apperror.Conflict("export.job_not_ready", job.Status)
+	}
+
+	path := filepath.Join(e.dir, payload.File)
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		return apperror.NotFound("export.file_expired")
+	}
+
+	c.Set(fiber.HeaderContentType, helper.ExportContentTypes[job.Format])
+	return c.Download(path, job.FileName)
+}
+
+// ownedJob mengambil job export dari queue. Job lain atau milik admin lain
+// dianggap tidak ada.
+func (e *Exporter) ownedJob(c *fiber.Ctx) (ExportJob, ExportPayload, error) {
+	notFound := apperror.NotFound("export.job_not_found")
+	if e.queue == nil {
+		return ExportJob{}, ExportPayload{}, notFound
+	}
+
+	job, err := e.queue.Store().Get(c.UserContext(), c.Params("id"))
+	if errors.Is(err, queue.ErrNotFound) {
+		return ExportJob{}, ExportPayload{}, notFound
+	}
+	if err != nil {
+		return ExportJob{}, ExportPayload{}, apperror.Wrap(err, "export.job_fetch_failed")
+	}
+	if job.Type != ExportJobType {
+		return ExportJob{}, ExportPayload{}, notFound
+	}
+
+	var payload ExportPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return ExportJob{}, ExportPayload{}, apperror.Internal("export.job_fetch_failed", err)
+	}
+	email, _ := c.Locals("email").(string)
+	if payload.CreatedBy != email {
+		return ExportJob{}, ExportPayload{}, notFound
+	}
+	return exportJob(job, payload), payload, nil
+}
+
+// exportJob mengubah job queue menjadi status export. Job cancelled dari
+// admin API ditampilkan sebagai failed.
+func exportJob(job *queue.Job, payload ExportPayload) ExportJob {
+	export := ExportJob{
+		ID:         job.ID,
+		Resource:   payload.Resource,
+		Format:     payload.Format,
+		Status:     ExportStatusPending,
+		CreatedBy:  payload.CreatedBy,
+		CreatedAt:  job.CreatedAt,
+		FinishedAt: job.FinishedAt,
+		FileName:   payload.FileName,
+	}
+	switch job.Status {
+	case queue.StatusRunning:
+		export.Status = ExportStatusRunning
+	case queue.StatusSucceeded:
+		export.Status = ExportStatusCompleted
+	case queue.StatusFailed, queue.StatusCancelled:
+		export.Status = ExportStatusFailed
+		export.Error = job.LastError
+	}
+	return export
+}